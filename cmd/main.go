@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -10,6 +11,23 @@ import (
 )
 
 func main() {
+	// --diff-saves a b сравнивает два слота сохранений, не запуская игру
+	diffSaves := flag.Bool("diff-saves", false, "print the differences between two save slot directories and exit")
+	flag.Parse()
+	if *diffSaves {
+		if flag.NArg() != 2 {
+			fmt.Println("Использование: --diff-saves <слот A> <слот B>")
+			os.Exit(2)
+		}
+		diff, err := core.DiffSaves(flag.Arg(0), flag.Arg(1))
+		if err != nil {
+			fmt.Printf("Ошибка сравнения сохранений: %v\n", err)
+			os.Exit(1)
+		}
+		diff.Render(os.Stdout)
+		return
+	}
+
 	// Загружаем конфигурацию
 	cfg, err := config.Load()
 	if err != nil {
@@ -17,8 +35,21 @@ func main() {
 		cfg = config.DefaultConfig()
 	}
 
-	// Создаем игру
-	game, err := core.NewGame(cfg)
+	// Создаем игру в фоне, показывая прогресс загрузки; рендерер создается
+	// в главном потоке внутри Wait
+	loader := core.StartLoading(cfg)
+loading:
+	for {
+		select {
+		case progress := <-loader.Progress():
+			fmt.Printf("\rЗагрузка: %3.0f%% (%s)", progress.Fraction*100, progress.Phase)
+		case <-loader.Done():
+			break loading
+		}
+	}
+	fmt.Println()
+
+	game, err := loader.Wait()
 	if err != nil {
 		fmt.Printf("Ошибка создания игры: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,53 @@
+package fear
+
+import (
+	"fmt"
+	"math"
+)
+
+// BiomeDangerWeight is how much tension a biome of danger 1 adds to the target
+const BiomeDangerWeight = 0.3
+
+// DefaultBiomeDanger returns the default danger of each biome type (0-1).
+// The marsh feels more oppressive than open taiga even without scares.
+func DefaultBiomeDanger() map[string]float64 {
+	return map[string]float64{
+		"taiga":     0.3,
+		"marsh":     0.6,
+		"rocky":     0.4,
+		"distorted": 0.8,
+		"void":      0.9,
+	}
+}
+
+// SetBiomeDanger sets the danger of each biome type. Biomes missing from the
+// table add no tension.
+func (fd *Director) SetBiomeDanger(danger map[string]float64) error {
+	for biome, value := range danger {
+		if value < 0 || value > 1 {
+			return fmt.Errorf("danger of biome %q must be in [0, 1], got %v", biome, value)
+		}
+	}
+
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.biomeDanger = danger
+	return nil
+}
+
+// SetCurrentBiome sets the biome type the player is in
+func (fd *Director) SetCurrentBiome(biome string) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.currentBiome = biome
+}
+
+// biomeAdjustedTarget returns the tension target raised by the danger of the
+// current biome. The raise is added on top of the dynamic target, so dangerous
+// biomes keep a higher floor whatever the tension curve does.
+// Must be called with the mutex held.
+func (fd *Director) biomeAdjustedTarget(target float64) float64 {
+	return math.Min(1.0, target+fd.biomeDanger[fd.currentBiome]*BiomeDangerWeight)
+}
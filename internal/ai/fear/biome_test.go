@@ -0,0 +1,47 @@
+package fear
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// settledTension runs the tension curve of a director in a biome until it settles
+func settledTension(t *testing.T, biome string) float64 {
+	t.Helper()
+
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	fd.mutex.Lock()
+	fd.grace.Active = false
+	fd.targetTension = 0.2
+	fd.mutex.Unlock()
+	fd.SetCurrentBiome(biome)
+
+	for i := 0; i < 600; i++ {
+		fd.updateTension(1)
+	}
+
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+	return fd.tensionCurve
+}
+
+func TestDangerousBiomeRaisesTensionFloor(t *testing.T) {
+	taiga, marsh := settledTension(t, "taiga"), settledTension(t, "marsh")
+
+	if marsh <= taiga {
+		t.Fatalf("tension settles at %v in the marsh and %v in the taiga, want the marsh higher", marsh, taiga)
+	}
+	// The raise is added on top of the same dynamic target
+	danger := DefaultBiomeDanger()
+	want := (danger["marsh"] - danger["taiga"]) * BiomeDangerWeight
+	if diff := marsh - taiga; diff < want-1e-9 || diff > want+1e-9 {
+		t.Errorf("marsh floor is %v above the taiga, want %v", diff, want)
+	}
+}
+
+func TestUnknownBiomeAddsNoTension(t *testing.T) {
+	if got := settledTension(t, "meadow"); got < 0.2-1e-9 || got > 0.2+1e-9 {
+		t.Errorf("tension settles at %v in a biome without danger, want the dynamic target 0.2", got)
+	}
+}
@@ -0,0 +1,146 @@
+package fear
+
+import (
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// A player busy fighting or crafting cannot watch their surroundings: a fight
+// is the moment for a second creature to sound from the flank, a long crafting
+// session the moment for slow dread and a sudden interruption
+const (
+	ReinforcementHealthWeight = 0.3 // Value added to a reinforcement scare as the player's health runs out
+	ExtremeThreatLevel        = 0.8 // Threat level at which real danger is scary enough without reinforcements
+
+	StationaryRadius       = 1.5  // The player counts as stationary while staying this close to where they stopped
+	StationaryBuildUp      = 60.0 // Seconds stationary after which crafting scares reach their full value
+	StationaryWeight       = 0.3  // Value added to crafting scares by a full build-up
+	InterruptionStationary = 10.0 // Seconds stationary before an interruption can break the player's focus
+	SlowBurnBaseValue      = 0.3  // Value of a slow-burn crafting scare at tension level 0, before build-up
+	InterruptionBaseValue  = 0.4  // Value of an interruption at tension level 0, before build-up
+)
+
+// addFightingScareOpportunity adds a reinforcement scare while the player fights:
+// the sounds of a second creature from a flank, worth more the lower the player's
+// health. Skipped when the threat is already extreme. Must be called with the mutex held.
+func (fd *Director) addFightingScareOpportunity() {
+	threatLevel := 0.0
+	if fd.threatSource != nil {
+		threatLevel = fd.threatSource.GetThreatLevel()
+	}
+
+	value, ok := reinforcementValue(fd.tensionLevel, fd.playerHealth(), threatLevel)
+	if !ok {
+		return
+	}
+
+	opportunity := ScareOpportunity{
+		Timestamp:      fd.clock.Now(),
+		ScareTypes:     []string{"reinforcement"},
+		Position:       fd.playerPosition,
+		OptimalTiming:  1.0 + fd.rng.Float64()*2.0, // 1-3 seconds, while the fight is still on
+		EstimatedValue: value,
+		PlayerState:    "fighting",
+		Context:        "reinforcement",
+		TensionLevel:   fd.tensionLevel,
+	}
+
+	fd.scareOpportunities = append(fd.scareOpportunities, opportunity)
+}
+
+// addCraftingScareOpportunity adds slow-burn psychological scares while the player
+// crafts and, once they have stayed put long enough, an interruption of their work.
+// Both are worth more the longer the player has been stationary. Must be called
+// with the mutex held.
+func (fd *Director) addCraftingScareOpportunity() {
+	stationary := fd.stationaryDuration()
+
+	fd.scareOpportunities = append(fd.scareOpportunities, ScareOpportunity{
+		Timestamp:      fd.clock.Now(),
+		ScareTypes:     []string{"psychological", "ambient_sound"},
+		Position:       fd.playerPosition,
+		OptimalTiming:  5.0 + fd.rng.Float64()*10.0, // 5-15 seconds (slow burn)
+		EstimatedValue: slowBurnCraftingValue(fd.tensionLevel, stationary),
+		PlayerState:    "crafting",
+		Context:        "slow_burn",
+		TensionLevel:   fd.tensionLevel,
+	})
+
+	if value, ok := interruptionValue(fd.tensionLevel, stationary); ok {
+		fd.scareOpportunities = append(fd.scareOpportunities, ScareOpportunity{
+			Timestamp:      fd.clock.Now(),
+			ScareTypes:     []string{"interruption"},
+			Position:       fd.playerPosition,
+			OptimalTiming:  1.0 + fd.rng.Float64()*3.0, // 1-4 seconds
+			EstimatedValue: value,
+			PlayerState:    "crafting",
+			Context:        "interruption",
+			TensionLevel:   fd.tensionLevel,
+		})
+	}
+}
+
+// reinforcementValue returns the value of a reinforcement scare at a tension
+// level, player health (0-1) and threat level, and false when the threat is
+// already extreme
+func reinforcementValue(tensionLevel int, health, threatLevel float64) (float64, bool) {
+	if threatLevel >= ExtremeThreatLevel {
+		return 0, false
+	}
+	health = math.Max(0.0, math.Min(1.0, health))
+	return 0.5 + float64(tensionLevel)*0.1 + ReinforcementHealthWeight*(1.0-health), true
+}
+
+// stationaryBuildUp returns how far a stationary period has built up toward the
+// full value of crafting scares (0-1)
+func stationaryBuildUp(stationary float64) float64 {
+	return math.Max(0.0, math.Min(1.0, stationary/StationaryBuildUp))
+}
+
+// slowBurnCraftingValue returns the value of a slow-burn scare during crafting
+func slowBurnCraftingValue(tensionLevel int, stationary float64) float64 {
+	return SlowBurnBaseValue + float64(tensionLevel)*0.1 + StationaryWeight*stationaryBuildUp(stationary)
+}
+
+// interruptionValue returns the value of an interruption scare during crafting,
+// and false while the player has not been stationary long enough to be absorbed
+func interruptionValue(tensionLevel int, stationary float64) (float64, bool) {
+	if stationary < InterruptionStationary {
+		return 0, false
+	}
+	return InterruptionBaseValue + float64(tensionLevel)*0.1 + StationaryWeight*stationaryBuildUp(stationary), true
+}
+
+// playerHealth returns the tracked player's health as a share of their maximum,
+// or 1 when it is unknown. Must be called with the mutex held.
+func (fd *Director) playerHealth() float64 {
+	player, exists := fd.world.GetEntity(fd.playerID)
+	if !exists {
+		return 1.0
+	}
+	health, has := ecs.ComponentAs[*ecs.HealthComponent](player, ecs.HealthComponentID)
+	if !has || health.MaxHealth <= 0 {
+		return 1.0
+	}
+	return health.CurrentHealth / health.MaxHealth
+}
+
+// stationaryDuration returns how many seconds the player's recorded actions have
+// stayed within StationaryRadius of the latest one. Must be called with the mutex held.
+func (fd *Director) stationaryDuration() float64 {
+	if len(fd.actionHistory) == 0 {
+		return 0
+	}
+
+	latest := fd.actionHistory[len(fd.actionHistory)-1]
+	since := latest.Timestamp
+	for i := len(fd.actionHistory) - 2; i >= 0; i-- {
+		action := fd.actionHistory[i]
+		if action.Position.Distance(latest.Position) > StationaryRadius {
+			break
+		}
+		since = action.Timestamp
+	}
+	return fd.clock.Now().Sub(since).Seconds()
+}
@@ -0,0 +1,79 @@
+package fear
+
+import (
+	"math"
+	"testing"
+)
+
+func TestReinforcementValue(t *testing.T) {
+	tests := []struct {
+		name         string
+		tensionLevel int
+		health       float64
+		threatLevel  float64
+		want         float64
+		wantOK       bool
+	}{
+		{"calm and healthy", 0, 1.0, 0.0, 0.5, true},
+		{"tense and wounded", 2, 0.5, 0.3, 0.85, true},
+		{"near death", 1, 0.0, 0.0, 0.9, true},
+		{"health below zero is clamped", 0, -0.5, 0.0, 0.8, true},
+		{"just below extreme threat", 3, 1.0, ExtremeThreatLevel - 0.01, 0.8, true},
+		{"extreme threat", 0, 1.0, ExtremeThreatLevel, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := reinforcementValue(tt.tensionLevel, tt.health, tt.threatLevel)
+			if ok != tt.wantOK || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("reinforcementValue(%d, %v, %v) = %v, %v; want %v, %v",
+					tt.tensionLevel, tt.health, tt.threatLevel, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSlowBurnCraftingValue(t *testing.T) {
+	tests := []struct {
+		name         string
+		tensionLevel int
+		stationary   float64
+		want         float64
+	}{
+		{"just stopped", 0, 0, SlowBurnBaseValue},
+		{"half built up", 2, StationaryBuildUp / 2, 0.65},
+		{"build-up is capped", 1, StationaryBuildUp * 2, 0.7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slowBurnCraftingValue(tt.tensionLevel, tt.stationary); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("slowBurnCraftingValue(%d, %v) = %v, want %v", tt.tensionLevel, tt.stationary, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterruptionValue(t *testing.T) {
+	tests := []struct {
+		name         string
+		tensionLevel int
+		stationary   float64
+		want         float64
+		wantOK       bool
+	}{
+		{"not absorbed yet", 2, InterruptionStationary - 0.1, 0, false},
+		{"just absorbed", 0, InterruptionStationary, 0.45, true},
+		{"fully built up", 3, StationaryBuildUp, 1.0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := interruptionValue(tt.tensionLevel, tt.stationary)
+			if ok != tt.wantOK || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("interruptionValue(%d, %v) = %v, %v; want %v, %v",
+					tt.tensionLevel, tt.stationary, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
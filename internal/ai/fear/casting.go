@@ -0,0 +1,20 @@
+package fear
+
+import "echo-taiga/internal/engine/ecs"
+
+// RecordRuneWordCast records the player tracing a rune word as a casting action
+func (fd *Director) RecordRuneWordCast(position ecs.Vector3, word []string, success bool) {
+	contextTags := []string{"rune_word"}
+	if success {
+		contextTags = append(contextTags, "cast_success")
+	} else {
+		contextTags = append(contextTags, "cast_fizzle")
+	}
+
+	fd.RecordPlayerAction(PlayerAction{
+		Type:        ActionCasting,
+		Timestamp:   fd.clock.Now(),
+		Position:    position,
+		ContextTags: contextTags,
+	})
+}
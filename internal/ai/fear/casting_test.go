@@ -0,0 +1,22 @@
+package fear
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+func TestRuneWordCastIsRecordedAsCasting(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+
+	fd.RecordRuneWordCast(ecs.Vector3{X: 3}, []string{"a", "b"}, false)
+
+	action := fd.actionHistory[len(fd.actionHistory)-1]
+	if action.Type != ActionCasting || action.Position != (ecs.Vector3{X: 3}) {
+		t.Fatalf("recorded %+v, want a casting action at the caster", action)
+	}
+	want := []string{"rune_word", "cast_fizzle"}
+	if len(action.ContextTags) != len(want) || action.ContextTags[0] != want[0] || action.ContextTags[1] != want[1] {
+		t.Errorf("context tags %v, want %v", action.ContextTags, want)
+	}
+}
@@ -0,0 +1,34 @@
+package fear
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+func TestScareIDsFollowTheClockAndStayUnique(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	clock := engine.NewFakeClock(time.Date(2026, 1, 1, 22, 0, 0, 0, time.UTC))
+	fd.SetClock(clock)
+	template := &ScareEvent{Type: "ambient_dread", Intensity: 0.2, Duration: 30}
+
+	fd.mutex.Lock()
+	first := fd.generateScareFromTemplate(template, ecs.Vector3{})
+	fd.triggerScare(first)
+	second := fd.generateScareFromTemplate(template, ecs.Vector3{})
+	fd.mutex.Unlock()
+
+	want := fmt.Sprintf("ambient_dread_%d", clock.Now().UnixNano())
+	if first.ID != want {
+		t.Errorf("scare ID %q, want %q stamped with the director's clock", first.ID, want)
+	}
+	if second.ID == first.ID {
+		t.Errorf("two scares made at the same instant share the ID %q", first.ID)
+	}
+	if !first.SuccessRating.Equal(clock.Now()) {
+		t.Errorf("scare started at %v, want the clock's %v", first.SuccessRating, clock.Now())
+	}
+}
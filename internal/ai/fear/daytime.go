@@ -0,0 +1,33 @@
+package fear
+
+// DayClock reports the world's time of day (e.g. world.World)
+type DayClock interface {
+	GetGlobalTimeOfDay() float64
+}
+
+// SetDayClock sets the source of the time of day stamped on player actions.
+// Without one, actions keep the time of day their caller filled in.
+func (fd *Director) SetDayClock(clock DayClock) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.dayClock = clock
+}
+
+// observeTimeOfDay takes the world's time of day, if there is a day clock, and
+// notes whether the player has seen night fall. Returns the time of day in
+// effect. Must be called with the mutex held.
+func (fd *Director) observeTimeOfDay(fallback float64) float64 {
+	timeOfDay := fallback
+	if fd.dayClock != nil {
+		timeOfDay = fd.dayClock.GetGlobalTimeOfDay()
+	}
+	fd.currentTimeOfDay = timeOfDay
+
+	// Seeing night fall counts toward ending the grace period
+	if timeOfDay >= NightfallTime {
+		fd.grace.Milestone = true
+		fd.updateGrace()
+	}
+	return timeOfDay
+}
@@ -0,0 +1,53 @@
+package fear
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// fakeDayClock reports a fixed time of day
+type fakeDayClock struct {
+	timeOfDay float64
+}
+
+func (fc *fakeDayClock) GetGlobalTimeOfDay() float64 {
+	return fc.timeOfDay
+}
+
+func TestPlayerActionsCarryWorldTimeOfDay(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	clock := &fakeDayClock{timeOfDay: 0.3}
+	fd.SetDayClock(clock)
+
+	fd.RecordRuneWordCast(ecs.Vector3{}, []string{"ash"}, true)
+	fd.RecordDiversion(ecs.Vector3{X: 5})
+
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+	for _, action := range fd.actionHistory {
+		if action.TimeOfDay != 0.3 {
+			t.Errorf("%v action has time of day %v, want 0.3", action.Type, action.TimeOfDay)
+		}
+	}
+}
+
+func TestNightfallEndsGraceWithoutActions(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	clock := &fakeDayClock{timeOfDay: 0.5}
+	fd.SetDayClock(clock)
+	fd.BeginGracePeriod()
+
+	// Past the minimum duration, but night has not fallen
+	minimum := fd.graceConfig.MinDuration.Seconds()
+	fd.advancePlaytime(minimum + 1)
+	if !fd.InGracePeriod() {
+		t.Fatalf("grace period ended at noon after %.0fs", minimum+1)
+	}
+
+	clock.timeOfDay = NightfallTime + 0.05
+	fd.advancePlaytime(1)
+	if fd.InGracePeriod() {
+		t.Errorf("grace period still active after nightfall at %.0fs", minimum+2)
+	}
+}
@@ -10,9 +10,12 @@ import (
 	"path/filepath"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"echo-taiga/internal/engine"
 	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/savefile"
 )
 
 // ActionType represents the type of action the player is performing
@@ -26,8 +29,12 @@ const (
 	ActionCrafting   ActionType = "crafting"
 	ActionExploring  ActionType = "exploring"
 	ActionInspecting ActionType = "inspecting"
+	ActionCasting    ActionType = "casting"
 	ActionResting    ActionType = "resting"
 	ActionNone       ActionType = "none"
+
+	// The player deliberately made noise to lure creatures away
+	ActionDistracting ActionType = "distracting"
 )
 
 // EmotionalResponse represents the player's emotional state
@@ -113,6 +120,20 @@ type ScareEvent struct {
 	ExclusionTags     []string     // Tags for scares that shouldn't happen close to this
 	EntityID          ecs.EntityID // Associated entity (if any)
 	MetamorphID       string       // Associated metamorphosis (if any)
+
+	ownedEntities        []ecs.EntityID // Entities spawned by this scare, removed when it ends
+	environmentTransient bool           // The environment effect is reverted when the scare ends
+	lightingChanged      bool           // Lights changed by the scare are restored when it ends
+}
+
+// AddOwnedEntity records an entity spawned by the scare so it is removed when the scare ends
+func (se *ScareEvent) AddOwnedEntity(id ecs.EntityID) {
+	se.ownedEntities = append(se.ownedEntities, id)
+}
+
+// OwnedEntities returns the entities spawned by the scare
+func (se *ScareEvent) OwnedEntities() []ecs.EntityID {
+	return se.ownedEntities
 }
 
 // TensionLevelName maps tension level to a name
@@ -160,6 +181,10 @@ type FearProfile struct {
 
 	// Recovery (how quickly player recovers from scares)
 	Recovery float64
+
+	// How many times each rating was learned from play, keyed like the seeding
+	// questionnaire ("darkness") or by map and name ("contextual:<tag>")
+	Observations map[string]int
 }
 
 // ScareOpportunity represents an identified opportunity to scare the player
@@ -196,6 +221,8 @@ type Director struct {
 	scareOpportunities []ScareOpportunity
 	currentScares      map[string]*ScareEvent
 	scareCooldowns     map[string]time.Time
+	wardZones          []WardZone
+	placement          PlacementConfig
 
 	// Tension curve management
 	tensionCurve      float64   // Current tension (0-1)
@@ -205,9 +232,54 @@ type Director struct {
 	lastTensionChange time.Time // When tension last changed levels
 	tensionPhase      string    // build, peak, release, calm
 
+	// Real danger around the player raises tension without a scripted scare
+	threatSource ThreatSource
+
+	// The world's time of day (see SetDayClock)
+	dayClock DayClock
+
+	// Dangerous biomes raise the tension floor (see SetBiomeDanger)
+	biomeDanger  map[string]float64
+	currentBiome string
+
+	// Very effective scares leave metamorphoses behind
+	metamorphRequester MetamorphRequester
+	escalation         EscalationConfig
+	lastEscalation     time.Time
+
+	// Applies the environment and lighting effects of scares
+	environmentEffector EnvironmentEffector
+	lightingEffector    LightingEffector
+
+	// Creates the creatures of entity scares (see SetEntitySpawner)
+	entitySpawner EntitySpawner
+
+	// Keeps scare positions within loaded chunks (see SetLoadedArea)
+	loadedArea LoadedArea
+
+	// Scripted moments that hold scares back (see Suppress)
+	suppressions      map[int]*suppressionToken
+	nextSuppressionID int
+	suppressionEnded  time.Time         // When the last token was released or expired
+	queuedOpportunity *ScareOpportunity // Best opportunity missed while suppressed
+	peakHeld          bool              // Tension reached the peak during a suppression
+
+	// Gentle first minutes of a new game (see BeginGracePeriod)
+	graceConfig GraceConfig
+	grace       graceState
+
+	// Music state machine
+	musicConfig        MusicConfig
+	musicState         MusicState
+	musicDwell         float64 // Seconds in the current music state
+	aftermathRemaining float64 // Seconds left of the "scare just ended" window
+	scareJustEnded     bool
+	wasPursued         bool
+
 	// Timing
 	lastScareTime    time.Time
 	lastAnalysisTime time.Time
+	lastDiversion    time.Time // When the player last made a diversion (see RecordDiversion)
 
 	// Templates
 	scareTemplates map[string]ScareEvent
@@ -229,16 +301,33 @@ type Director struct {
 
 	// Callbacks
 	OnScareTriggered func(ScareEvent)
+	OnScareAssessed  func(scare ScareEvent, effectiveness float64) // Player reaction to a recent scare
+
+	// Called when the music state changes
+	OnMusicStateChanged func(from, to MusicState)
+
+	// Called when the tension phase changes (e.g. to swell music on "peak")
+	OnTensionPhaseChanged func(oldPhase, newPhase string)
 
 	// Path for saving/loading data
 	savePath string
 
+	// Source of the current time (see SetClock)
+	clock engine.Clock
+
+	// The director's own random stream (see SetSeed), used with the mutex held
+	rng *rand.Rand
+
+	// State snapshot for the UI (read without locking)
+	snapshot atomic.Pointer[StateSnapshot]
+
 	// Thread safety
 	mutex sync.RWMutex
 }
 
 // NewDirector creates a new fear director
 func NewDirector(world *ecs.World, savePath string) *Director {
+	clock := engine.RealClock{}
 	return &Director{
 		world:              world,
 		actionHistory:      make([]PlayerAction, 0, 100),
@@ -249,12 +338,18 @@ func NewDirector(world *ecs.World, savePath string) *Director {
 		scareOpportunities: make([]ScareOpportunity, 0),
 		currentScares:      make(map[string]*ScareEvent),
 		scareCooldowns:     make(map[string]time.Time),
+		wardZones:          make([]WardZone, 0),
+		placement:          DefaultPlacementConfig(),
+		suppressions:       make(map[int]*suppressionToken),
+		graceConfig:        DefaultGraceConfig(),
+		escalation:         DefaultEscalationConfig(),
+		biomeDanger:        DefaultBiomeDanger(),
 		tensionCurve:       0.1,     // Start with low tension
 		targetTension:      0.3,     // Initial target is slightly elevated
 		tensionDirection:   1,       // Starting by increasing tension
 		tensionLevel:       0,       // Start at "calm"
 		tensionPhase:       "build", // Start in build phase
-		lastTensionChange:  time.Now(),
+		lastTensionChange:  clock.Now(),
 		baseScareInterval:  180.0, // 3 minutes between major scares by default
 		minScareInterval:   60.0,  // Minimum 1 minute between scares
 		maxTensionTime:     300.0, // Maximum 5 minutes at high tension
@@ -262,20 +357,58 @@ func NewDirector(world *ecs.World, savePath string) *Director {
 		successfulScares:   make(map[string]int),
 		failedScares:       make(map[string]int),
 		savePath:           savePath,
+		clock:              clock,
+		rng:                engine.NewRandStream(time.Now().UnixNano(), RandStream),
 		scareTemplates:     make(map[string]ScareEvent),
+		musicConfig:        DefaultMusicConfig(),
+		musicState:         MusicExplore,
 	}
 }
 
+// SetClock sets the source of time for scare timing, cooldowns and history.
+// The tension timer restarts from the new clock's current time.
+func (fd *Director) SetClock(clock engine.Clock) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.clock = clock
+	fd.lastTensionChange = clock.Now()
+}
+
+// RandStream is the name of the director's random stream (see engine.NewRandStream)
+const RandStream = "fear"
+
+// SetSeed derives the director's random stream from the world seed, so scare
+// timing and placement are reproducible for a seed regardless of other systems
+func (fd *Director) SetSeed(seed int64) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.rng = engine.NewRandStream(seed, RandStream)
+}
+
 // Initialize sets up the fear director
 func (fd *Director) Initialize() error {
+	return fd.InitializeWithProgress(engine.NopProgress{})
+}
+
+// InitializeWithProgress initializes the director, reporting each loading phase
+func (fd *Director) InitializeWithProgress(progress engine.ProgressReporter) error {
 	// Try to load existing behavior and fear profiles
+	progress.ReportProgress("fear_profiles", 0)
 	err := fd.LoadProfiles()
 	if err != nil {
 		// If profiles don't exist, we already have defaults
 		fmt.Printf("No existing profiles found, using defaults\n")
 	}
 
+	// Restore grace period progress, so reloading does not restart it
+	if err := fd.LoadGraceState(); err != nil {
+		return fmt.Errorf("failed to load grace state: %v", err)
+	}
+
 	// Load scare templates
+	progress.ReportProgress("scare_templates", 0.4)
 	err = fd.LoadScareTemplates()
 	if err != nil {
 		// Create default templates if they don't exist
@@ -291,11 +424,18 @@ func (fd *Director) Initialize() error {
 		}
 	}
 
+	// Load the music state table
+	if err := fd.LoadMusicConfig(); err != nil {
+		return fmt.Errorf("failed to load music rules: %v", err)
+	}
+
+	progress.ReportProgress("scare_templates", 1)
+
 	// Register as a system in the world
 	fd.world.AddSystem(fd)
 
 	// Set timestamps
-	now := time.Now()
+	now := fd.clock.Now()
 	fd.lastScareTime = now
 	fd.lastAnalysisTime = now
 	fd.lastTensionChange = now
@@ -311,6 +451,9 @@ func (fd *Director) RequiredComponents() []ecs.ComponentID {
 
 // Update is called every frame
 func (fd *Director) Update(deltaTime float64) {
+	// Count play time toward the end of the grace period
+	fd.advancePlaytime(deltaTime)
+
 	// Track player
 	fd.trackPlayer()
 
@@ -318,7 +461,7 @@ func (fd *Director) Update(deltaTime float64) {
 	fd.updateTension(deltaTime)
 
 	// Analyze player behavior (less frequently)
-	now := time.Now()
+	now := fd.clock.Now()
 	if now.Sub(fd.lastAnalysisTime).Seconds() >= 5.0 {
 		fd.analyzePlayerBehavior()
 		fd.lastAnalysisTime = now
@@ -332,6 +475,12 @@ func (fd *Director) Update(deltaTime float64) {
 
 	// Update active scares
 	fd.updateActiveScares(deltaTime)
+
+	// Follow tension and threats with the music
+	fd.updateMusicState(deltaTime)
+
+	// Publish a snapshot for the UI
+	fd.publishSnapshot()
 }
 
 // RecordPlayerAction records a player action for analysis
@@ -341,9 +490,12 @@ func (fd *Director) RecordPlayerAction(action PlayerAction) {
 
 	// Add timestamp if not set
 	if action.Timestamp.IsZero() {
-		action.Timestamp = time.Now()
+		action.Timestamp = fd.clock.Now()
 	}
 
+	// Stamp the world's time of day
+	action.TimeOfDay = fd.observeTimeOfDay(action.TimeOfDay)
+
 	// Add action to history
 	fd.actionHistory = append(fd.actionHistory, action)
 
@@ -355,7 +507,11 @@ func (fd *Director) RecordPlayerAction(action PlayerAction) {
 	// Update environment awareness from action
 	fd.currentAreaType = action.AreaType
 	fd.currentLightLevel = action.LightLevel
-	fd.currentTimeOfDay = action.TimeOfDay
+
+	// A diversion puts the player in control for a while
+	if action.Type == ActionDistracting {
+		fd.lastDiversion = action.Timestamp
+	}
 
 	// Immediate analysis on certain action types
 	if action.EmotionalState >= EmotionFrightened {
@@ -388,20 +544,22 @@ func (fd *Director) GetTensionValue() float64 {
 	return fd.tensionCurve
 }
 
-// GetBehaviorProfile returns the current behavior profile
+// GetBehaviorProfile returns a copy of the current behavior profile.
+// Changing the copy does not affect the director.
 func (fd *Director) GetBehaviorProfile() *BehaviorProfile {
 	fd.mutex.RLock()
 	defer fd.mutex.RUnlock()
 
-	return fd.behaviorProfile
+	return fd.behaviorProfile.clone()
 }
 
-// GetFearProfile returns the current fear profile
+// GetFearProfile returns a copy of the current fear profile.
+// Changing the copy does not affect the director.
 func (fd *Director) GetFearProfile() *FearProfile {
 	fd.mutex.RLock()
 	defer fd.mutex.RUnlock()
 
-	return fd.fearProfile
+	return fd.fearProfile.clone()
 }
 
 // SetTensionTarget sets the target tension level
@@ -418,6 +576,16 @@ func (fd *Director) ForceScare(scareType string) bool {
 	fd.mutex.Lock()
 	defer fd.mutex.Unlock()
 
+	// Scripted moments block forced scares, except the ambient ones they allow
+	if active, ambientAllowed := fd.suppressed(); active && !(ambientAllowed && isAmbientScare(scareType)) {
+		return false
+	}
+
+	// The first minutes of a new game allow ambient scares only
+	if !fd.graceAllows(scareType) {
+		return false
+	}
+
 	// Check if we have a template for this scare type
 	template, exists := fd.scareTemplates[scareType]
 	if !exists {
@@ -428,9 +596,7 @@ func (fd *Director) ForceScare(scareType string) bool {
 	scare := fd.generateScareFromTemplate(&template, fd.playerPosition)
 
 	// Trigger the scare
-	fd.triggerScare(scare)
-
-	return true
+	return fd.triggerScare(scare)
 }
 
 // SetScareInterval sets the base interval between scares
@@ -454,29 +620,30 @@ func (fd *Director) SaveProfiles() error {
 
 	// Save behavior profile
 	behaviorPath := filepath.Join(fd.savePath, "behavior_profile.json")
-	behaviorData, err := json.MarshalIndent(fd.behaviorProfile, "", "  ")
+	behaviorData, err := savefile.Marshal(fd.behaviorProfile)
 	if err != nil {
 		return err
 	}
 
-	err = ioutil.WriteFile(behaviorPath, behaviorData, 0644)
+	err = savefile.Write(behaviorPath, behaviorData)
 	if err != nil {
 		return err
 	}
 
 	// Save fear profile
 	fearPath := filepath.Join(fd.savePath, "fear_profile.json")
-	fearData, err := json.MarshalIndent(fd.fearProfile, "", "  ")
+	fearData, err := savefile.Marshal(fd.fearProfile)
 	if err != nil {
 		return err
 	}
 
-	err = ioutil.WriteFile(fearPath, fearData, 0644)
+	err = savefile.Write(fearPath, fearData)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	// Save grace period progress
+	return fd.saveGraceState()
 }
 
 // LoadProfiles loads behavior and fear profiles from files
@@ -486,8 +653,8 @@ func (fd *Director) LoadProfiles() error {
 
 	// Load behavior profile
 	behaviorPath := filepath.Join(fd.savePath, "behavior_profile.json")
-	if _, err := os.Stat(behaviorPath); err == nil {
-		behaviorData, err := ioutil.ReadFile(behaviorPath)
+	if savefile.Exists(behaviorPath) {
+		behaviorData, err := savefile.Read(behaviorPath)
 		if err != nil {
 			return err
 		}
@@ -505,8 +672,8 @@ func (fd *Director) LoadProfiles() error {
 
 	// Load fear profile
 	fearPath := filepath.Join(fd.savePath, "fear_profile.json")
-	if _, err := os.Stat(fearPath); err == nil {
-		fearData, err := ioutil.ReadFile(fearPath)
+	if savefile.Exists(fearPath) {
+		fearData, err := savefile.Read(fearPath)
 		if err != nil {
 			return err
 		}
@@ -569,6 +736,14 @@ func (fd *Director) LoadScareTemplates() error {
 	return nil
 }
 
+// AddScareTemplate adds or replaces a scare template of the same type
+func (fd *Director) AddScareTemplate(template ScareEvent) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.scareTemplates[template.Type] = template
+}
+
 // CreateDefaultScareTemplates creates a set of default scare templates
 func (fd *Director) CreateDefaultScareTemplates() error {
 	templatesPath := filepath.Join(fd.savePath, "scare_templates")
@@ -590,10 +765,10 @@ func (fd *Director) CreateDefaultScareTemplates() error {
 			Subtype:           "distant",
 			Intensity:         0.3,
 			Duration:          5.0,
-			LightingEffect:    "none",
+			LightingEffect:    LightingNone,
 			SoundEffect:       "distant_howl",
 			EntityEffect:      "none",
-			EnvironmentEffect: "none",
+			EnvironmentEffect: EnvironmentNone,
 			EffectRadius:      30.0,
 			Cooldown:          30.0,
 			Tags:              []string{"ambient", "sound", "subtle"},
@@ -604,10 +779,10 @@ func (fd *Director) CreateDefaultScareTemplates() error {
 			Subtype:           "close",
 			Intensity:         0.5,
 			Duration:          3.0,
-			LightingEffect:    "none",
+			LightingEffect:    LightingNone,
 			SoundEffect:       "nearby_breaking",
 			EntityEffect:      "none",
-			EnvironmentEffect: "none",
+			EnvironmentEffect: EnvironmentNone,
 			EffectRadius:      10.0,
 			Cooldown:          45.0,
 			Tags:              []string{"ambient", "sound", "moderate"},
@@ -618,10 +793,10 @@ func (fd *Director) CreateDefaultScareTemplates() error {
 			Subtype:           "shadow",
 			Intensity:         0.4,
 			Duration:          2.0,
-			LightingEffect:    "shadow_movement",
+			LightingEffect:    LightingShadowMovement,
 			SoundEffect:       "none",
 			EntityEffect:      "none",
-			EnvironmentEffect: "none",
+			EnvironmentEffect: EnvironmentNone,
 			EffectRadius:      15.0,
 			Cooldown:          60.0,
 			Tags:              []string{"ambient", "visual", "subtle"},
@@ -634,10 +809,10 @@ func (fd *Director) CreateDefaultScareTemplates() error {
 			Subtype:           "weather",
 			Intensity:         0.4,
 			Duration:          20.0,
-			LightingEffect:    "darkening",
+			LightingEffect:    LightingDarkening,
 			SoundEffect:       "thunder",
 			EntityEffect:      "none",
-			EnvironmentEffect: "rain_intensify",
+			EnvironmentEffect: EnvironmentRainIntensify,
 			EffectRadius:      100.0,
 			Cooldown:          180.0,
 			Tags:              []string{"environment", "weather", "moderate"},
@@ -648,10 +823,10 @@ func (fd *Director) CreateDefaultScareTemplates() error {
 			Subtype:           "object",
 			Intensity:         0.5,
 			Duration:          5.0,
-			LightingEffect:    "none",
+			LightingEffect:    LightingNone,
 			SoundEffect:       "breaking",
 			EntityEffect:      "none",
-			EnvironmentEffect: "object_break",
+			EnvironmentEffect: EnvironmentObjectBreak,
 			EffectRadius:      8.0,
 			Cooldown:          90.0,
 			Tags:              []string{"environment", "object", "moderate"},
@@ -664,10 +839,10 @@ func (fd *Director) CreateDefaultScareTemplates() error {
 			Subtype:           "creature",
 			Intensity:         0.6,
 			Duration:          15.0,
-			LightingEffect:    "none",
+			LightingEffect:    LightingNone,
 			SoundEffect:       "growl",
 			EntityEffect:      "creature_appear",
-			EnvironmentEffect: "none",
+			EnvironmentEffect: EnvironmentNone,
 			EffectRadius:      20.0,
 			Cooldown:          300.0,
 			Tags:              []string{"entity", "creature", "intense"},
@@ -678,10 +853,10 @@ func (fd *Director) CreateDefaultScareTemplates() error {
 			Subtype:           "stalker",
 			Intensity:         0.7,
 			Duration:          30.0,
-			LightingEffect:    "none",
+			LightingEffect:    LightingNone,
 			SoundEffect:       "footsteps",
 			EntityEffect:      "stalker",
-			EnvironmentEffect: "none",
+			EnvironmentEffect: EnvironmentNone,
 			EffectRadius:      25.0,
 			Cooldown:          360.0,
 			Tags:              []string{"entity", "stalker", "intense"},
@@ -694,10 +869,10 @@ func (fd *Director) CreateDefaultScareTemplates() error {
 			Subtype:           "visual",
 			Intensity:         0.8,
 			Duration:          1.0,
-			LightingEffect:    "flash",
+			LightingEffect:    LightingFlash,
 			SoundEffect:       "scare_sound",
 			EntityEffect:      "jump_visual",
-			EnvironmentEffect: "none",
+			EnvironmentEffect: EnvironmentNone,
 			EffectRadius:      5.0,
 			Cooldown:          600.0,
 			Tags:              []string{"jumpscare", "visual", "intense"},
@@ -710,7 +885,7 @@ func (fd *Director) CreateDefaultScareTemplates() error {
 			Subtype:           "paranoia",
 			Intensity:         0.5,
 			Duration:          45.0,
-			LightingEffect:    "subtle_pulsing",
+			LightingEffect:    LightingSubtlePulsing,
 			SoundEffect:       "whispers",
 			EntityEffect:      "none",
 			EnvironmentEffect: "subtle_movement",
@@ -719,6 +894,38 @@ func (fd *Director) CreateDefaultScareTemplates() error {
 			Tags:              []string{"psychological", "paranoia", "moderate"},
 		},
 
+		// Reinforcement scares (during fights)
+		{
+			ID:                "reinforcement_1",
+			Type:              "reinforcement",
+			Subtype:           "flank",
+			Intensity:         0.6,
+			Duration:          8.0,
+			LightingEffect:    LightingNone,
+			SoundEffect:       "flank_growl",
+			EntityEffect:      "none",
+			EnvironmentEffect: EnvironmentNone,
+			EffectRadius:      20.0,
+			Cooldown:          240.0,
+			Tags:              []string{"reinforcement", "sound", "intense"},
+		},
+
+		// Interruption scares (during crafting)
+		{
+			ID:                "interruption_1",
+			Type:              "interruption",
+			Subtype:           "workbench",
+			Intensity:         0.5,
+			Duration:          3.0,
+			LightingEffect:    LightingShadowAcross,
+			SoundEffect:       "tool_clatter",
+			EntityEffect:      "none",
+			EnvironmentEffect: EnvironmentNone,
+			EffectRadius:      5.0,
+			Cooldown:          180.0,
+			Tags:              []string{"interruption", "visual", "moderate"},
+		},
+
 		// Metamorphosis scares
 		{
 			ID:                "metamorph_1",
@@ -726,10 +933,10 @@ func (fd *Director) CreateDefaultScareTemplates() error {
 			Subtype:           "environment",
 			Intensity:         0.7,
 			Duration:          60.0,
-			LightingEffect:    "color_shift",
+			LightingEffect:    LightingColorShift,
 			SoundEffect:       "reality_shift",
 			EntityEffect:      "none",
-			EnvironmentEffect: "metamorph_environment",
+			EnvironmentEffect: EnvironmentMetamorph,
 			EffectRadius:      30.0,
 			Cooldown:          900.0,
 			Tags:              []string{"metamorphosis", "environment", "strong"},
@@ -757,7 +964,7 @@ func (fd *Director) CreateDefaultScareTemplates() error {
 func (fd *Director) trackPlayer() {
 	// Find player entity if not already tracked
 	if fd.playerID == "" {
-		playerEntities := fd.world.GetEntitiesWithTag("player")
+		playerEntities := fd.world.GetEntitiesWithTag(TagPlayer)
 		if len(playerEntities) > 0 {
 			fd.playerID = playerEntities[0].ID
 		} else {
@@ -781,18 +988,24 @@ func (fd *Director) trackPlayer() {
 
 	transform := transformComp.(*ecs.TransformComponent)
 	fd.playerPosition = transform.Position
-	fd.playerLastSeen = time.Now()
+	fd.playerLastSeen = fd.clock.Now()
 }
 
 // updateTension updates the tension curve
 func (fd *Director) updateTension(deltaTime float64) {
 	fd.mutex.Lock()
-	defer fd.mutex.Unlock()
+	defer fd.unlockNotifyingPhase(fd.tensionPhase)
+
+	// Real threats and dangerous biomes set a floor under the target; the grace period caps it
+	target := fd.graceTension(fd.biomeAdjustedTarget(fd.threatAdjustedTarget()))
+
+	// Tension keeps moving during scripted moments, but the peak waits for them to end
+	suppressed, _ := fd.suppressed()
 
 	// Determine tension direction
-	if fd.tensionCurve < fd.targetTension {
+	if fd.tensionCurve < target {
 		fd.tensionDirection = 1 // Increasing
-	} else if fd.tensionCurve > fd.targetTension {
+	} else if fd.tensionCurve > target {
 		fd.tensionDirection = -1 // Decreasing
 	} else {
 		fd.tensionDirection = 0 // Stable
@@ -802,14 +1015,14 @@ func (fd *Director) updateTension(deltaTime float64) {
 	if fd.tensionDirection > 0 {
 		// Increasing tension
 		fd.tensionCurve += fd.tensionChangeRate * deltaTime
-		if fd.tensionCurve > fd.targetTension {
-			fd.tensionCurve = fd.targetTension
+		if fd.tensionCurve > target {
+			fd.tensionCurve = target
 		}
 	} else if fd.tensionDirection < 0 {
 		// Decreasing tension
 		fd.tensionCurve -= fd.tensionChangeRate * deltaTime
-		if fd.tensionCurve < fd.targetTension {
-			fd.tensionCurve = fd.targetTension
+		if fd.tensionCurve < target {
+			fd.tensionCurve = target
 		}
 	}
 
@@ -822,11 +1035,11 @@ func (fd *Director) updateTension(deltaTime float64) {
 	// Check if tension level changed
 	if newLevel != fd.tensionLevel {
 		// Record when the level changed
-		fd.lastTensionChange = time.Now()
+		fd.lastTensionChange = fd.clock.Now()
 		fd.tensionLevel = newLevel
 
 		// Update tension phase
-		if newLevel >= 3 {
+		if newLevel >= 3 && !suppressed {
 			fd.tensionPhase = "peak"
 		} else if fd.tensionDirection > 0 {
 			fd.tensionPhase = "build"
@@ -835,11 +1048,24 @@ func (fd *Director) updateTension(deltaTime float64) {
 		} else {
 			fd.tensionPhase = "calm"
 		}
+
+		if newLevel >= 3 && suppressed {
+			fd.peakHeld = true
+		}
+	}
+
+	// A peak held back by a scripted moment arrives once the moment is over
+	if fd.peakHeld && !suppressed {
+		fd.peakHeld = false
+		if fd.tensionLevel >= 3 && fd.tensionPhase != "peak" {
+			fd.tensionPhase = "peak"
+			fd.lastTensionChange = fd.clock.Now()
+		}
 	}
 
 	// Check if we need to start decreasing tension after peaking too long
 	if fd.tensionPhase == "peak" {
-		peakDuration := time.Since(fd.lastTensionChange).Seconds()
+		peakDuration := fd.clock.Now().Sub(fd.lastTensionChange).Seconds()
 		if peakDuration > fd.maxTensionTime {
 			// We've been at peak tension too long, start decreasing
 			fd.targetTension = 0.3 // Target a moderate-low tension
@@ -975,6 +1201,7 @@ func (fd *Director) analyzePlayerBehavior() {
 				increase := float64(action.EmotionalState) * 0.1
 				fd.fearProfile.ContextualFears[tag] = math.Min(1.0,
 					fd.fearProfile.ContextualFears[tag]+increase)
+				fd.observe(observationContextual + tag)
 			}
 
 			// Update environment fears
@@ -986,6 +1213,7 @@ func (fd *Director) analyzePlayerBehavior() {
 				increase := float64(action.EmotionalState) * 0.05
 				fd.fearProfile.EnvironmentFears[action.AreaType] = math.Min(1.0,
 					fd.fearProfile.EnvironmentFears[action.AreaType]+increase)
+				fd.observe(observationEnvironment + action.AreaType)
 			}
 
 			// Update entity fears if a target is specified
@@ -998,6 +1226,7 @@ func (fd *Director) analyzePlayerBehavior() {
 				increase := float64(action.EmotionalState) * 0.15
 				fd.fearProfile.EntityFears[targetStr] = math.Min(1.0,
 					fd.fearProfile.EntityFears[targetStr]+increase)
+				fd.observe(observationEntity + targetStr)
 			}
 
 			// Update specific fears based on context
@@ -1005,6 +1234,7 @@ func (fd *Director) analyzePlayerBehavior() {
 				// Dark situation
 				fd.fearProfile.DarknessFear = math.Min(1.0,
 					fd.fearProfile.DarknessFear+float64(action.EmotionalState)*0.05)
+				fd.observe("darkness")
 			}
 		}
 	}
@@ -1016,7 +1246,7 @@ func (fd *Director) analyzeScareResponse(action PlayerAction) {
 	recentScareTime := 10.0 // Look for scares in last 10 seconds
 
 	// Find scares that happened recently
-	now := time.Now()
+	now := fd.clock.Now()
 	recentScares := []*ScareEvent{}
 
 	for _, scare := range fd.currentScares {
@@ -1065,9 +1295,17 @@ func (fd *Director) analyzeScareResponse(action PlayerAction) {
 	// Update effective scares
 	fd.fearProfile.EffectiveScares[mostRecentScare.Type] = 0.7*effectiveness +
 		0.3*fd.fearProfile.EffectiveScares[mostRecentScare.Type]
+	fd.observe(observationScare + mostRecentScare.Type)
 
 	// Update successful scares counter
 	fd.successfulScares[mostRecentScare.Type]++
+
+	// The scariest moments leave a lasting mark on the world
+	fd.escalateScare(mostRecentScare, effectiveness)
+
+	if fd.OnScareAssessed != nil {
+		fd.OnScareAssessed(*mostRecentScare, effectiveness)
+	}
 }
 
 // identifyScareOpportunities looks for good opportunities to scare the player
@@ -1081,7 +1319,7 @@ func (fd *Director) identifyScareOpportunities() {
 	}
 
 	// Skip if we've recently triggered a scare
-	timeSinceLastScare := time.Since(fd.lastScareTime).Seconds()
+	timeSinceLastScare := fd.clock.Now().Sub(fd.lastScareTime).Seconds()
 	if timeSinceLastScare < fd.minScareInterval {
 		return
 	}
@@ -1130,12 +1368,23 @@ func (fd *Director) identifyScareOpportunities() {
 	case string(ActionResting):
 		// Player resting - good time for subtle buildup
 		fd.addRestingScareOpportunity()
+
+	case string(ActionFighting):
+		// Player fighting - a second creature on the flank overwhelms them
+		fd.addFightingScareOpportunity()
+
+	case string(ActionCrafting):
+		// Player absorbed in crafting - slow dread and a sudden interruption
+		fd.addCraftingScareOpportunity()
 	}
 
 	// Add metamorphosis opportunity if tension is very high
 	if fd.tensionLevel >= 3 && fd.tensionPhase == "peak" {
 		fd.addMetamorphosisOpportunity()
 	}
+
+	// Creatures lose their bite while the player feels in control after a diversion
+	fd.discountEntityScares()
 }
 
 // triggerScares triggers scare events when appropriate
@@ -1143,6 +1392,16 @@ func (fd *Director) triggerScares() {
 	fd.mutex.Lock()
 	defer fd.mutex.Unlock()
 
+	// Scripted moments hold scares back; a queued scare fires shortly after them
+	if active, ambientAllowed := fd.suppressed(); active {
+		fd.holdOpportunities(ambientAllowed)
+	} else {
+		fd.releaseQueuedOpportunity()
+	}
+
+	// The first minutes of a new game allow ambient scares only
+	fd.graceOpportunities()
+
 	// Skip if no opportunities
 	if len(fd.scareOpportunities) == 0 {
 		return
@@ -1158,7 +1417,7 @@ func (fd *Director) triggerScares() {
 
 	// Skip if we need to wait longer
 	if bestOpportunity.OptimalTiming > 0 &&
-		time.Since(bestOpportunity.Timestamp).Seconds() < bestOpportunity.OptimalTiming {
+		fd.clock.Now().Sub(bestOpportunity.Timestamp).Seconds() < bestOpportunity.OptimalTiming {
 		return
 	}
 
@@ -1195,7 +1454,7 @@ func (fd *Director) triggerScares() {
 		if exists {
 			// Check cooldown
 			if cooldownTime, hasCooldown := fd.scareCooldowns[scareType]; hasCooldown {
-				if time.Now().Before(cooldownTime) {
+				if fd.clock.Now().Before(cooldownTime) {
 					// Scare is on cooldown, try next opportunity
 					if len(fd.scareOpportunities) > 1 {
 						fd.scareOpportunities = fd.scareOpportunities[1:]
@@ -1208,7 +1467,7 @@ func (fd *Director) triggerScares() {
 			// Generate scare from template
 			scare := fd.generateScareFromTemplate(&template, bestOpportunity.Position)
 
-			// Trigger the scare
+			// Trigger the scare; an aborted one leaves the cooldown untouched
 			fd.triggerScare(scare)
 
 			// Remove this opportunity
@@ -1222,7 +1481,10 @@ func (fd *Director) updateActiveScares(deltaTime float64) {
 	fd.mutex.Lock()
 	defer fd.mutex.Unlock()
 
-	now := time.Now()
+	now := fd.clock.Now()
+
+	// Drop faded ward zones
+	fd.expireWardZones()
 
 	// Check each active scare
 	for id, scare := range fd.currentScares {
@@ -1230,6 +1492,15 @@ func (fd *Director) updateActiveScares(deltaTime float64) {
 		if now.Sub(scare.SuccessRating).Seconds() >= scare.Duration {
 			// Scare is over, remove from active scares
 			delete(fd.currentScares, id)
+			fd.scareJustEnded = true
+
+			// Despawn anything the scare brought into the world
+			fd.despawnOwnedEntities(scare)
+
+			// Undo transient environment changes, such as intensified rain,
+			// and restore the lights the scare dimmed or strobed
+			fd.revertEnvironmentEffect(scare)
+			fd.revertLightingEffect(scare)
 
 			// Add to history
 			fd.scareHistory = append(fd.scareHistory, *scare)
@@ -1242,17 +1513,71 @@ func (fd *Director) updateActiveScares(deltaTime float64) {
 	}
 }
 
+// ScareIntensityAt returns the intensity of the strongest active scare reaching
+// a position. Scares without a radius reach everywhere. Implements threat.ScareSource.
+func (fd *Director) ScareIntensityAt(position ecs.Vector3) float64 {
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+
+	intensity := 0.0
+	for _, scare := range fd.currentScares {
+		if scare.EffectRadius > 0 && scare.StartPosition.Distance(position) > scare.EffectRadius {
+			continue
+		}
+		intensity = math.Max(intensity, scare.Intensity)
+	}
+	return intensity
+}
+
+// despawnOwnedEntities removes the entities spawned by a scare from the world
+func (fd *Director) despawnOwnedEntities(scare *ScareEvent) {
+	for _, entityID := range scare.ownedEntities {
+		fd.world.RemoveEntity(entityID)
+	}
+	scare.ownedEntities = nil
+}
+
+// uniqueScareID returns an ID for a new scare of the given type, stamped with the
+// director's clock. Scares started at the same instant get a numbered suffix.
+// Must be called with fd.mutex held.
+func (fd *Director) uniqueScareID(scareType string) string {
+	id := fmt.Sprintf("%s_%d", scareType, fd.clock.Now().UnixNano())
+	for n := 2; fd.currentScares[id] != nil; n++ {
+		id = fmt.Sprintf("%s_%d_%d", scareType, fd.clock.Now().UnixNano(), n)
+	}
+	return id
+}
+
 // generateScareFromTemplate creates a scare event from a template
 func (fd *Director) generateScareFromTemplate(template *ScareEvent, position ecs.Vector3) *ScareEvent {
 	// Create a copy of the template
 	scare := *template
 
 	// Generate unique ID
-	scare.ID = fmt.Sprintf("%s_%d", template.Type, time.Now().UnixNano())
+	scare.ID = fd.uniqueScareID(template.Type)
+
+	// The copy must not share spawned entities or environment state with the template
+	scare.ownedEntities = nil
+	scare.environmentTransient = false
+	scare.lightingChanged = false
 
 	// Set positions
 	scare.StartPosition = position
 
+	// Some scares work best just out of sight, others right in front of the player
+	placement := fd.placement.preferenceFor(template)
+	switch placement {
+	case PlaceOutOfView:
+		scare.StartPosition = fd.placeOutOfView(template.EffectRadius * 0.7)
+	case PlaceInView:
+		scare.StartPosition = fd.placeInView(template.EffectRadius * 0.7)
+	}
+
+	// Warded areas dampen scares
+	if ward := fd.wardStrengthAt(scare.StartPosition); ward > 0 {
+		scare.Intensity *= 1.0 - ward
+	}
+
 	// Calculate target position (usually in front of player)
 	// Get player entity
 	player, exists := fd.world.GetEntity(fd.playerID)
@@ -1276,22 +1601,45 @@ func (fd *Director) generateScareFromTemplate(template *ScareEvent, position ecs
 		scare.TargetPosition = position
 	}
 
+	// Placed scares close in on the player
+	if placement != PlaceAnywhere {
+		scare.TargetPosition = fd.playerPosition
+	}
+
+	// Scares must not land in chunks that are not loaded
+	fd.clampScareToLoaded(&scare)
+
 	// Mark creation time as now
-	scare.SuccessRating = time.Now()
+	scare.SuccessRating = fd.clock.Now()
 
 	return &scare
 }
 
-// triggerScare triggers a specific scare event
-func (fd *Director) triggerScare(scare *ScareEvent) {
+// triggerScare triggers a specific scare event. A scare outside the loaded
+// chunks is aborted before it is charged a cooldown; returns false then.
+func (fd *Director) triggerScare(scare *ScareEvent) bool {
+	if !fd.scareInLoadedArea(scare) {
+		return false
+	}
+
+	// Scares stay mild during the grace period
+	fd.graceIntensity(scare)
+
 	// Add to current scares
 	fd.currentScares[scare.ID] = scare
 
 	// Set cooldown
-	fd.scareCooldowns[scare.Type] = time.Now().Add(time.Duration(scare.Cooldown * float64(time.Second)))
+	fd.scareCooldowns[scare.Type] = fd.clock.Now().Add(time.Duration(scare.Cooldown * float64(time.Second)))
 
 	// Update last scare time
-	fd.lastScareTime = time.Now()
+	fd.lastScareTime = fd.clock.Now()
+
+	// Change the environment and the lights around the scare
+	fd.applyEnvironmentEffect(scare)
+	fd.applyLightingEffect(scare)
+
+	// Bring the scare's creature into the world; it leaves when the scare ends
+	fd.spawnScareEntity(scare)
 
 	// Update tension target based on intensity
 	newTarget := fd.tensionCurve + scare.Intensity*0.3
@@ -1301,6 +1649,7 @@ func (fd *Director) triggerScare(scare *ScareEvent) {
 	if fd.OnScareTriggered != nil {
 		fd.OnScareTriggered(*scare)
 	}
+	return true
 }
 
 // Helper methods for generating scare opportunities
@@ -1327,10 +1676,10 @@ func (fd *Director) addAmbientScareOpportunity() {
 
 	// Create opportunity
 	opportunity := ScareOpportunity{
-		Timestamp:      time.Now(),
+		Timestamp:      fd.clock.Now(),
 		ScareTypes:     []string{"ambient_sound", "ambient_visual"},
 		Position:       fd.playerPosition,
-		OptimalTiming:  2.0 + rand.Float64()*5.0, // 2-7 seconds delay
+		OptimalTiming:  2.0 + fd.rng.Float64()*5.0, // 2-7 seconds delay
 		EstimatedValue: value,
 		PlayerState:    "moving",
 		Context:        fd.currentAreaType,
@@ -1353,10 +1702,10 @@ func (fd *Director) addEntityScareOpportunity() {
 
 	// Create opportunity
 	opportunity := ScareOpportunity{
-		Timestamp:      time.Now(),
+		Timestamp:      fd.clock.Now(),
 		ScareTypes:     []string{"entity"},
 		Position:       fd.playerPosition,
-		OptimalTiming:  1.0 + rand.Float64()*3.0, // 1-4 seconds delay
+		OptimalTiming:  1.0 + fd.rng.Float64()*3.0, // 1-4 seconds delay
 		EstimatedValue: value,
 		PlayerState:    "exploring",
 		Context:        fd.currentAreaType,
@@ -1373,10 +1722,10 @@ func (fd *Director) addChaseScare() {
 
 	// Create opportunity
 	opportunity := ScareOpportunity{
-		Timestamp:      time.Now(),
+		Timestamp:      fd.clock.Now(),
 		ScareTypes:     []string{"entity", "environment"},
 		Position:       fd.playerPosition,
-		OptimalTiming:  0.5 + rand.Float64()*1.5, // Quick response, 0.5-2 seconds
+		OptimalTiming:  0.5 + fd.rng.Float64()*1.5, // Quick response, 0.5-2 seconds
 		EstimatedValue: value,
 		PlayerState:    "running",
 		Context:        "chase",
@@ -1398,10 +1747,10 @@ func (fd *Director) addHidingScareOpportunity() {
 
 	// Create opportunity
 	opportunity := ScareOpportunity{
-		Timestamp:      time.Now(),
+		Timestamp:      fd.clock.Now(),
 		ScareTypes:     []string{"ambient_sound", "psychological"},
 		Position:       fd.playerPosition,
-		OptimalTiming:  3.0 + rand.Float64()*5.0, // 3-8 seconds (let them think they're safe)
+		OptimalTiming:  3.0 + fd.rng.Float64()*5.0, // 3-8 seconds (let them think they're safe)
 		EstimatedValue: value,
 		PlayerState:    "hiding",
 		Context:        "false_safety",
@@ -1418,10 +1767,10 @@ func (fd *Director) addInspectionScareOpportunity() {
 
 	// Create opportunity
 	opportunity := ScareOpportunity{
-		Timestamp:      time.Now(),
+		Timestamp:      fd.clock.Now(),
 		ScareTypes:     []string{"jumpscare", "environment"},
 		Position:       fd.playerPosition,
-		OptimalTiming:  1.0 + rand.Float64()*2.0, // 1-3 seconds
+		OptimalTiming:  1.0 + fd.rng.Float64()*2.0, // 1-3 seconds
 		EstimatedValue: value,
 		PlayerState:    "inspecting",
 		Context:        "focus_break",
@@ -1438,10 +1787,10 @@ func (fd *Director) addRestingScareOpportunity() {
 
 	// Create opportunity
 	opportunity := ScareOpportunity{
-		Timestamp:      time.Now(),
+		Timestamp:      fd.clock.Now(),
 		ScareTypes:     []string{"ambient_sound", "ambient_visual", "psychological"},
 		Position:       fd.playerPosition,
-		OptimalTiming:  5.0 + rand.Float64()*10.0, // 5-15 seconds (slow build)
+		OptimalTiming:  5.0 + fd.rng.Float64()*10.0, // 5-15 seconds (slow build)
 		EstimatedValue: value,
 		PlayerState:    "resting",
 		Context:        "calm_before_storm",
@@ -1461,10 +1810,10 @@ func (fd *Director) addMetamorphosisOpportunity() {
 
 	// Create opportunity
 	opportunity := ScareOpportunity{
-		Timestamp:      time.Now(),
+		Timestamp:      fd.clock.Now(),
 		ScareTypes:     []string{"metamorphosis"},
 		Position:       fd.playerPosition,
-		OptimalTiming:  2.0 + rand.Float64()*3.0, // 2-5 seconds
+		OptimalTiming:  2.0 + fd.rng.Float64()*3.0, // 2-5 seconds
 		EstimatedValue: value,
 		PlayerState:    "any",
 		Context:        "reality_shift",
@@ -1518,5 +1867,6 @@ func NewDefaultFearProfile() *FearProfile {
 		EffectiveScares:    make(map[string]float64),
 		Habituation:        0.3, // Low value means slower habituation
 		Recovery:           0.5,
+		Observations:       make(map[string]int),
 	}
 }
@@ -0,0 +1,55 @@
+package fear
+
+import (
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// A player who lures creatures away with noise feels in control for a while,
+// and creatures jumping out at them lose their bite
+const (
+	DiversionWindow      = 30 * time.Second // How long a diversion blunts entity scares
+	DiversionEntityScale = 0.5              // Share of an entity scare's value kept during the window
+)
+
+// RecordDiversion records that the player deliberately made noise at a position
+// to lure creatures away. The ActionDistracting action is recorded where the
+// player stands, facing the noise, so it does not move them in the history.
+func (fd *Director) RecordDiversion(noisePosition ecs.Vector3) {
+	fd.mutex.RLock()
+	action := PlayerAction{
+		Type:       ActionDistracting,
+		Position:   fd.playerPosition,
+		Direction:  noisePosition.Sub(fd.playerPosition).Normalize(),
+		AreaType:   fd.currentAreaType,
+		LightLevel: fd.currentLightLevel,
+		TimeOfDay:  fd.currentTimeOfDay,
+	}
+	fd.mutex.RUnlock()
+
+	fd.RecordPlayerAction(action)
+}
+
+// inDiversionWindow checks whether the player created a diversion recently enough
+// for entity scares to be blunted. Must be called with the mutex held.
+func (fd *Director) inDiversionWindow() bool {
+	return !fd.lastDiversion.IsZero() && fd.clock.Now().Sub(fd.lastDiversion) < DiversionWindow
+}
+
+// discountEntityScares lowers the value of entity scare opportunities while the
+// player feels in control after a diversion. Must be called with the mutex held.
+func (fd *Director) discountEntityScares() {
+	if !fd.inDiversionWindow() {
+		return
+	}
+
+	for i := range fd.scareOpportunities {
+		for _, scareType := range fd.scareOpportunities[i].ScareTypes {
+			if scareType == "entity" {
+				fd.scareOpportunities[i].EstimatedValue *= DiversionEntityScale
+				break
+			}
+		}
+	}
+}
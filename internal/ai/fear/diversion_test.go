@@ -0,0 +1,32 @@
+package fear
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+func TestDiversionIsRecordedWhereThePlayerStands(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	fd.mutex.Lock()
+	fd.playerPosition = ecs.Vector3{X: 2, Z: 2}
+	fd.mutex.Unlock()
+
+	fd.RecordDiversion(ecs.Vector3{X: 2, Z: 17})
+
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+	action := fd.actionHistory[len(fd.actionHistory)-1]
+	if action.Type != ActionDistracting {
+		t.Fatalf("recorded %v, want a distracting action", action.Type)
+	}
+	if action.Position != (ecs.Vector3{X: 2, Z: 2}) {
+		t.Errorf("diversion recorded at %v, want the player's position", action.Position)
+	}
+	if action.Direction != (ecs.Vector3{Z: 1}) {
+		t.Errorf("diversion faces %v, want toward the noise", action.Direction)
+	}
+	if !fd.inDiversionWindow() {
+		t.Errorf("diversion did not open the diversion window")
+	}
+}
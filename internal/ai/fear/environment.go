@@ -0,0 +1,48 @@
+package fear
+
+// Environment effects of scares that an EnvironmentEffector may act on
+const (
+	EnvironmentNone          = "none"
+	EnvironmentRainIntensify = "rain_intensify"
+	EnvironmentObjectBreak   = "object_break"
+	EnvironmentMetamorph     = "metamorph_environment"
+)
+
+// EnvironmentEffector carries out the environment effects of scares (e.g. an adapter
+// over the world that changes the weather or breaks nearby objects). It is called with
+// the director locked and must not call back into the director.
+type EnvironmentEffector interface {
+	// ApplyEnvironmentEffect applies scare.EnvironmentEffect. It returns true if the
+	// change is transient and has to be reverted when the scare ends.
+	ApplyEnvironmentEffect(scare ScareEvent) (transient bool)
+
+	// RevertEnvironmentEffect undoes a transient change made for the scare
+	RevertEnvironmentEffect(scare ScareEvent)
+}
+
+// SetEnvironmentEffector sets the system that applies environment effects of scares
+func (fd *Director) SetEnvironmentEffector(effector EnvironmentEffector) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.environmentEffector = effector
+}
+
+// applyEnvironmentEffect hands a triggered scare's environment effect to the effector.
+// Must be called with fd.mutex held.
+func (fd *Director) applyEnvironmentEffect(scare *ScareEvent) {
+	if fd.environmentEffector == nil || scare.EnvironmentEffect == "" || scare.EnvironmentEffect == EnvironmentNone {
+		return
+	}
+	scare.environmentTransient = fd.environmentEffector.ApplyEnvironmentEffect(*scare)
+}
+
+// revertEnvironmentEffect undoes the transient environment change of an ended scare.
+// Must be called with fd.mutex held.
+func (fd *Director) revertEnvironmentEffect(scare *ScareEvent) {
+	if fd.environmentEffector == nil || !scare.environmentTransient {
+		return
+	}
+	fd.environmentEffector.RevertEnvironmentEffect(*scare)
+	scare.environmentTransient = false
+}
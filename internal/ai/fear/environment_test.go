@@ -0,0 +1,90 @@
+package fear
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// fakeEnvironmentEffector records the environment changes it is asked for; rain is
+// transient, broken objects stay broken
+type fakeEnvironmentEffector struct {
+	applied, reverted []string
+}
+
+func (fe *fakeEnvironmentEffector) ApplyEnvironmentEffect(scare ScareEvent) bool {
+	fe.applied = append(fe.applied, scare.EnvironmentEffect)
+	return scare.EnvironmentEffect == EnvironmentRainIntensify
+}
+
+func (fe *fakeEnvironmentEffector) RevertEnvironmentEffect(scare ScareEvent) {
+	fe.reverted = append(fe.reverted, scare.EnvironmentEffect)
+}
+
+// triggerEnvironmentScare triggers a ten second scare with an environment effect
+func triggerEnvironmentScare(t *testing.T, fd *Director, clock *engine.FakeClock, effect string) {
+	t.Helper()
+
+	scare := &ScareEvent{
+		ID:                effect + "_1",
+		Type:              "weather_change",
+		Intensity:         0.5,
+		Duration:          10,
+		EffectRadius:      100,
+		EnvironmentEffect: effect,
+		SuccessRating:     clock.Now(),
+	}
+	fd.mutex.Lock()
+	triggered := fd.triggerScare(scare)
+	fd.mutex.Unlock()
+	if !triggered {
+		t.Fatalf("%s scare was not triggered", effect)
+	}
+}
+
+func TestRainScareIntensifiesWeatherUntilItEnds(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	clock := engine.NewFakeClock(time.Date(2026, 1, 1, 22, 0, 0, 0, time.UTC))
+	fd.SetClock(clock)
+	effector := &fakeEnvironmentEffector{}
+	fd.SetEnvironmentEffector(effector)
+
+	triggerEnvironmentScare(t, fd, clock, EnvironmentRainIntensify)
+	if len(effector.applied) != 1 || effector.applied[0] != EnvironmentRainIntensify {
+		t.Fatalf("applied %v on trigger, want one rain_intensify", effector.applied)
+	}
+
+	clock.Advance(5 * time.Second)
+	fd.updateActiveScares(5)
+	if len(effector.reverted) != 0 {
+		t.Errorf("rain reverted while the scare was still running")
+	}
+
+	clock.Advance(6 * time.Second)
+	fd.updateActiveScares(6)
+	if len(effector.reverted) != 1 || effector.reverted[0] != EnvironmentRainIntensify {
+		t.Errorf("reverted %v after the scare ended, want one rain_intensify", effector.reverted)
+	}
+}
+
+func TestPermanentAndEmptyEnvironmentEffectsAreNotReverted(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	clock := engine.NewFakeClock(time.Date(2026, 1, 1, 22, 0, 0, 0, time.UTC))
+	fd.SetClock(clock)
+	effector := &fakeEnvironmentEffector{}
+	fd.SetEnvironmentEffector(effector)
+
+	triggerEnvironmentScare(t, fd, clock, EnvironmentObjectBreak)
+	triggerEnvironmentScare(t, fd, clock, EnvironmentNone)
+	if len(effector.applied) != 1 || effector.applied[0] != EnvironmentObjectBreak {
+		t.Errorf("applied %v, want only object_break", effector.applied)
+	}
+
+	clock.Advance(11 * time.Second)
+	fd.updateActiveScares(11)
+	if len(effector.reverted) != 0 {
+		t.Errorf("reverted %v, want nothing for a broken object", effector.reverted)
+	}
+}
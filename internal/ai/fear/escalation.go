@@ -0,0 +1,136 @@
+package fear
+
+import (
+	"fmt"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// MetamorphRequest asks the metamorphosis system for a small effect left behind by a scare
+type MetamorphRequest struct {
+	Order     int         // Metamorphosis order (1-2 for escalations)
+	Center    ecs.Vector3 // Where the effect is anchored
+	Radius    float64
+	Intensity float64
+	ScareID   string // The scare that caused the effect
+}
+
+// MetamorphRequester creates metamorphoses on request, subject to the metamorphosis
+// system's own budget and phase rules (e.g. an adapter over metamorphosis.MetamorphosisManager)
+type MetamorphRequester interface {
+	RequestMetamorph(request MetamorphRequest) (effectID string, ok bool)
+}
+
+// EscalationConfig controls how very effective scares leave marks on the world
+type EscalationConfig struct {
+	MinEffectiveness float64       // Scares at least this effective may escalate
+	ScareTypes       []string      // Scare types that can escalate
+	Cooldown         time.Duration // Minimum time between escalations
+	Radius           float64       // Radius of the resulting effect
+	SecondOrderAt    float64       // Effectiveness from which a second-order effect is requested
+}
+
+// DefaultEscalationConfig returns the default escalation settings
+func DefaultEscalationConfig() EscalationConfig {
+	return EscalationConfig{
+		MinEffectiveness: 0.8,
+		ScareTypes:       []string{"entity", "metamorphosis"},
+		Cooldown:         10 * time.Minute,
+		Radius:           6.0,
+		SecondOrderAt:    0.95,
+	}
+}
+
+// Validate checks the escalation settings
+func (ec EscalationConfig) Validate() error {
+	if ec.MinEffectiveness < 0 || ec.MinEffectiveness > 1 {
+		return fmt.Errorf("escalation effectiveness must be in [0, 1], got %v", ec.MinEffectiveness)
+	}
+	if ec.Cooldown < 0 {
+		return fmt.Errorf("escalation cooldown must not be negative, got %v", ec.Cooldown)
+	}
+	if ec.Radius <= 0 {
+		return fmt.Errorf("escalation radius must be positive, got %v", ec.Radius)
+	}
+	return nil
+}
+
+// SetMetamorphRequester sets the system asked for metamorphoses when scares escalate
+func (fd *Director) SetMetamorphRequester(requester MetamorphRequester) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.metamorphRequester = requester
+}
+
+// SetEscalationConfig replaces the scare escalation settings
+func (fd *Director) SetEscalationConfig(config EscalationConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.escalation = config
+	return nil
+}
+
+// escalateScare asks for a lasting metamorphosis where a very effective scare struck.
+// The resulting effect and the scare reference each other. Must be called with the mutex held.
+func (fd *Director) escalateScare(scare *ScareEvent, effectiveness float64) {
+	config := fd.escalation
+	if fd.metamorphRequester == nil || effectiveness < config.MinEffectiveness || scare.MetamorphID != "" {
+		return
+	}
+	if !containsScareType(config.ScareTypes, scare.Type) {
+		return
+	}
+	if !fd.lastEscalation.IsZero() && fd.clock.Now().Sub(fd.lastEscalation) < config.Cooldown {
+		return
+	}
+
+	order := 1
+	if effectiveness >= config.SecondOrderAt {
+		order = 2
+	}
+
+	effectID, ok := fd.metamorphRequester.RequestMetamorph(MetamorphRequest{
+		Order:     order,
+		Center:    scare.TargetPosition,
+		Radius:    config.Radius,
+		Intensity: 0.3 * effectiveness,
+		ScareID:   scare.ID,
+	})
+	if !ok {
+		return
+	}
+
+	fd.lastEscalation = fd.clock.Now()
+	scare.MetamorphID = effectID
+	fd.linkScareToMetamorph(scare.ID, effectID)
+}
+
+// linkScareToMetamorph records the metamorphosis caused by a scare in the
+// active scare and in the scare history
+func (fd *Director) linkScareToMetamorph(scareID, effectID string) {
+	if scare, exists := fd.currentScares[scareID]; exists {
+		scare.MetamorphID = effectID
+	}
+	for i := range fd.scareHistory {
+		if fd.scareHistory[i].ID == scareID {
+			fd.scareHistory[i].MetamorphID = effectID
+		}
+	}
+}
+
+// containsScareType checks whether a scare type is in the list
+func containsScareType(types []string, scareType string) bool {
+	for _, t := range types {
+		if t == scareType {
+			return true
+		}
+	}
+	return false
+}
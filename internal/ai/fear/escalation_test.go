@@ -0,0 +1,130 @@
+package fear
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// recordingRequester grants or denies metamorphosis requests and remembers them
+type recordingRequester struct {
+	deny     bool
+	requests []MetamorphRequest
+}
+
+func (rr *recordingRequester) RequestMetamorph(request MetamorphRequest) (string, bool) {
+	rr.requests = append(rr.requests, request)
+	if rr.deny {
+		return "", false
+	}
+	return "effect_" + request.ScareID, true
+}
+
+// newEscalationTestDirector creates a director with a fake clock and the given requester
+func newEscalationTestDirector(t *testing.T, requester MetamorphRequester) (*Director, *engine.FakeClock) {
+	t.Helper()
+
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	clock := engine.NewFakeClock(time.Date(2026, 1, 1, 22, 0, 0, 0, time.UTC))
+	fd.SetClock(clock)
+	fd.SetMetamorphRequester(requester)
+	return fd, clock
+}
+
+// assessScare plays a scare striking a second after the previous one and the
+// player's reaction to it
+func assessScare(fd *Director, clock *engine.FakeClock, scare ScareEvent, reaction EmotionalResponse) {
+	clock.Advance(time.Second)
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	scare.SuccessRating = fd.clock.Now()
+	fd.currentScares[scare.ID] = &scare
+	fd.scareHistory = append(fd.scareHistory, scare)
+	fd.analyzeScareResponse(PlayerAction{Type: ActionRunning, EmotionalState: reaction})
+}
+
+func TestTerrifyingScareLeavesLinkedMetamorphosis(t *testing.T) {
+	requester := &recordingRequester{}
+	fd, clock := newEscalationTestDirector(t, requester)
+
+	target := ecs.Vector3{X: 12, Z: -4}
+	assessScare(fd, clock, ScareEvent{ID: "shadow_1", Type: "entity", TargetPosition: target}, EmotionTerrified)
+
+	if len(requester.requests) != 1 {
+		t.Fatalf("%d metamorphosis requests, want 1", len(requester.requests))
+	}
+	request := requester.requests[0]
+	if request.Center != target || request.ScareID != "shadow_1" || request.Order != 2 {
+		t.Errorf("request %+v, want a second-order effect at %v for shadow_1", request, target)
+	}
+
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+	if id := fd.currentScares["shadow_1"].MetamorphID; id != "effect_shadow_1" {
+		t.Errorf("active scare linked to %q, want effect_shadow_1", id)
+	}
+	if id := fd.scareHistory[len(fd.scareHistory)-1].MetamorphID; id != "effect_shadow_1" {
+		t.Errorf("scare history linked to %q, want effect_shadow_1", id)
+	}
+}
+
+func TestEscalationIsRateLimited(t *testing.T) {
+	requester := &recordingRequester{}
+	fd, clock := newEscalationTestDirector(t, requester)
+
+	assessScare(fd, clock, ScareEvent{ID: "shadow_1", Type: "entity"}, EmotionTerrified)
+	clock.Advance(time.Minute)
+	assessScare(fd, clock, ScareEvent{ID: "shadow_2", Type: "entity"}, EmotionTerrified)
+	if len(requester.requests) != 1 {
+		t.Fatalf("%d requests within the cooldown, want 1", len(requester.requests))
+	}
+
+	clock.Advance(DefaultEscalationConfig().Cooldown)
+	assessScare(fd, clock, ScareEvent{ID: "shadow_3", Type: "entity"}, EmotionTerrified)
+	if len(requester.requests) != 2 {
+		t.Errorf("%d requests after the cooldown, want 2", len(requester.requests))
+	}
+}
+
+func TestMildOrDeniedScaresDoNotEscalate(t *testing.T) {
+	requester := &recordingRequester{}
+	fd, clock := newEscalationTestDirector(t, requester)
+
+	// Too mild a reaction and a scare type that never escalates
+	assessScare(fd, clock, ScareEvent{ID: "shadow_1", Type: "entity"}, EmotionNervous)
+	assessScare(fd, clock, ScareEvent{ID: "whisper_1", Type: "ambient_whisper"}, EmotionTerrified)
+	if len(requester.requests) != 0 {
+		t.Fatalf("requests %+v for scares that should not escalate", requester.requests)
+	}
+
+	// A denied request leaves no link and does not start the cooldown
+	requester.deny = true
+	assessScare(fd, clock, ScareEvent{ID: "shadow_2", Type: "entity"}, EmotionTerrified)
+	requester.deny = false
+	assessScare(fd, clock, ScareEvent{ID: "shadow_3", Type: "metamorphosis"}, EmotionTerrified)
+	if len(requester.requests) != 2 {
+		t.Fatalf("%d requests, want the denied one and the next", len(requester.requests))
+	}
+
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+	if id := fd.currentScares["shadow_2"].MetamorphID; id != "" {
+		t.Errorf("denied scare linked to %q", id)
+	}
+}
+
+func TestEscalationConfigValidation(t *testing.T) {
+	fd, _ := newEscalationTestDirector(t, nil)
+	for _, config := range []EscalationConfig{
+		{MinEffectiveness: 1.5, Cooldown: time.Minute, Radius: 6},
+		{MinEffectiveness: 0.8, Cooldown: -time.Minute, Radius: 6},
+		{MinEffectiveness: 0.8, Cooldown: time.Minute, Radius: 0},
+	} {
+		if err := fd.SetEscalationConfig(config); err == nil {
+			t.Errorf("config %+v accepted", config)
+		}
+	}
+}
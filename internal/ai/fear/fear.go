@@ -38,8 +38,8 @@ type FearTrigger struct {
 	Probability float64     // base probability
 }
 
-// BehaviorProfile tracks the player's behavior patterns
-type BehaviorProfile struct {
+// BehaviorHistory tracks the raw behavior history used by FearDirector
+type BehaviorHistory struct {
 	BehaviorCounts     map[BehaviorType]int
 	RecentBehaviors    []BehaviorType
 	FearResponses      map[string]float64 // event ID -> effectiveness
@@ -52,7 +52,7 @@ type BehaviorProfile struct {
 type FearDirector struct {
 	Events           map[string]*FearEvent
 	Triggers         map[string]*FearTrigger
-	PlayerProfile    *BehaviorProfile
+	PlayerProfile    *BehaviorHistory
 	CurrentTension   float64 // 0.0-1.0
 	SuspenseBuildup  float64 // rate of tension increase
 	LastEventTime    int64
@@ -70,7 +70,7 @@ func NewFearDirector() *FearDirector {
 	return &FearDirector{
 		Events:          make(map[string]*FearEvent),
 		Triggers:        make(map[string]*FearTrigger),
-		PlayerProfile:   newBehaviorProfile(),
+		PlayerProfile:   newBehaviorHistory(),
 		CurrentTension:  0.2, // start with a little tension
 		SuspenseBuildup: 0.01,
 		MinEventSpacing: 120, // 2 minutes minimum between events
@@ -603,7 +603,7 @@ func (fd *FearDirector) LoadState(state map[string]interface{}) {
 		fd.Triggers = triggers
 	}
 
-	if profile, ok := state["playerProfile"].(*BehaviorProfile); ok {
+	if profile, ok := state["playerProfile"].(*BehaviorHistory); ok {
 		fd.PlayerProfile = profile
 	}
 
@@ -654,9 +654,9 @@ func (fd *FearDirector) updateBehaviorProfile(behavior BehaviorType, position [3
 	fd.UpdatePlayStyle(behavior)
 }
 
-// newBehaviorProfile creates a new empty behavior profile
-func newBehaviorProfile() *BehaviorProfile {
-	return &BehaviorProfile{
+// newBehaviorHistory creates a new empty behavior history
+func newBehaviorHistory() *BehaviorHistory {
+	return &BehaviorHistory{
 		BehaviorCounts:     make(map[BehaviorType]int),
 		RecentBehaviors:    make([]BehaviorType, 0),
 		FearResponses:      make(map[string]float64),
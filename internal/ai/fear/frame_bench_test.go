@@ -0,0 +1,35 @@
+//go:build dev
+
+package fear
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/engine/framebudget"
+)
+
+// frameTime is one frame at 60 frames per second
+const frameTime = time.Second / 60
+
+// BenchmarkDirectorUpdate is a director frame with a full action history
+func BenchmarkDirectorUpdate(b *testing.B) {
+	fd := NewDirector(ecs.NewWorld(), b.TempDir())
+	clock := engine.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	fd.SetClock(clock)
+	if err := fd.PopulateSynthetic(1); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		clock.Advance(frameTime)
+		fd.Update(frameTime.Seconds())
+	}
+}
+
+func TestDirectorUpdateBudget(t *testing.T) {
+	framebudget.Check(t, "DirectorUpdate", BenchmarkDirectorUpdate)
+}
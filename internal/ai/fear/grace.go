@@ -0,0 +1,195 @@
+package fear
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"echo-taiga/internal/savefile"
+)
+
+// NightfallTime is the time of day (0 is midnight) from which the player has seen night fall
+const NightfallTime = 0.8
+
+// GraceConfig controls the "cold open" of a new game, when scares stay gentle
+type GraceConfig struct {
+	Duration     time.Duration // Play time after which the grace period always ends
+	MinDuration  time.Duration // Play time after which a milestone (symbol or nightfall) ends it early
+	MaxIntensity float64       // Intensity cap of scares during the grace period
+	MaxTension   float64       // Cap of the tension target during the grace period
+}
+
+// DefaultGraceConfig returns the default grace period settings
+func DefaultGraceConfig() GraceConfig {
+	return GraceConfig{
+		Duration:     10 * time.Minute,
+		MinDuration:  3 * time.Minute,
+		MaxIntensity: 0.4,
+		MaxTension:   0.35,
+	}
+}
+
+// Validate checks the grace period settings
+func (gc GraceConfig) Validate() error {
+	if gc.Duration < 0 || gc.MinDuration < 0 {
+		return fmt.Errorf("grace durations must not be negative, got %v and %v", gc.Duration, gc.MinDuration)
+	}
+	if gc.MinDuration > gc.Duration {
+		return fmt.Errorf("grace minimum duration %v exceeds its duration %v", gc.MinDuration, gc.Duration)
+	}
+	if gc.MaxIntensity <= 0 || gc.MaxIntensity > 1 {
+		return fmt.Errorf("grace intensity cap must be in (0, 1], got %v", gc.MaxIntensity)
+	}
+	if gc.MaxTension <= 0 || gc.MaxTension >= 0.4 {
+		return fmt.Errorf("grace tension cap must be in (0, 0.4), got %v", gc.MaxTension)
+	}
+	return nil
+}
+
+// graceState is the saved progress of the grace period. Play time is kept in
+// the save, so reloading an early save does not restart the grace period.
+type graceState struct {
+	Active    bool    `json:"active"`    // The grace period has not ended yet
+	Playtime  float64 `json:"playtime"`  // Seconds of play the director has been running for
+	Milestone bool    `json:"milestone"` // The player has discovered a symbol or seen night fall
+}
+
+// SetGraceConfig replaces the grace period settings
+func (fd *Director) SetGraceConfig(config GraceConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.graceConfig = config
+	return nil
+}
+
+// BeginGracePeriod starts the cold open of a brand-new world. Loaded games keep
+// the grace state they were saved with instead.
+func (fd *Director) BeginGracePeriod() {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.grace = graceState{Active: true}
+}
+
+// InGracePeriod checks whether the cold open restrictions are in force
+func (fd *Director) InGracePeriod() bool {
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+
+	return fd.grace.Active
+}
+
+// NoteGraceMilestone records that the player has discovered a symbol or seen
+// night fall, which lets the grace period end after its minimum duration
+func (fd *Director) NoteGraceMilestone() {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.grace.Milestone = true
+	fd.updateGrace()
+}
+
+// advancePlaytime counts play time and ends the grace period when it is over
+func (fd *Director) advancePlaytime(deltaTime float64) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.grace.Playtime += deltaTime
+	fd.updateGrace()
+
+	// Night can fall while the player stands still
+	fd.observeTimeOfDay(fd.currentTimeOfDay)
+}
+
+// updateGrace ends the grace period once its duration has been played, or once
+// its minimum duration has been played after a milestone. Must be called with the mutex held.
+func (fd *Director) updateGrace() {
+	if !fd.grace.Active {
+		return
+	}
+
+	played := time.Duration(fd.grace.Playtime * float64(time.Second))
+	if played >= fd.graceConfig.Duration || (fd.grace.Milestone && played >= fd.graceConfig.MinDuration) {
+		fd.grace.Active = false
+	}
+}
+
+// graceTension caps the tension target during the grace period. Must be called with the mutex held.
+func (fd *Director) graceTension(target float64) float64 {
+	if !fd.grace.Active {
+		return target
+	}
+	return math.Min(target, fd.graceConfig.MaxTension)
+}
+
+// graceAllows checks whether a scare type may play during the grace period.
+// Must be called with the mutex held.
+func (fd *Director) graceAllows(scareType string) bool {
+	return !fd.grace.Active || isAmbientScare(scareType)
+}
+
+// graceOpportunities keeps only ambient scares among the opportunities during the
+// grace period. Must be called with the mutex held.
+func (fd *Director) graceOpportunities() {
+	if !fd.grace.Active {
+		return
+	}
+
+	kept := fd.scareOpportunities[:0]
+	for _, opportunity := range fd.scareOpportunities {
+		types := ambientScareTypes(opportunity.ScareTypes)
+		if len(types) > 0 {
+			opportunity.ScareTypes = types
+			kept = append(kept, opportunity)
+		}
+	}
+	fd.scareOpportunities = kept
+}
+
+// graceIntensity caps a scare's intensity during the grace period. Must be called with the mutex held.
+func (fd *Director) graceIntensity(scare *ScareEvent) {
+	if fd.grace.Active {
+		scare.Intensity = math.Min(scare.Intensity, fd.graceConfig.MaxIntensity)
+	}
+}
+
+// saveGraceState writes the grace period progress. Must be called with the mutex held.
+func (fd *Director) saveGraceState() error {
+	data, err := savefile.Marshal(fd.grace)
+	if err != nil {
+		return err
+	}
+	return savefile.Write(filepath.Join(fd.savePath, "grace.json"), data)
+}
+
+// LoadGraceState restores the grace period progress. Without a saved state the
+// grace period stays off; a brand-new world starts it with BeginGracePeriod.
+func (fd *Director) LoadGraceState() error {
+	data, err := savefile.Read(filepath.Join(fd.savePath, "grace.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var state graceState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("invalid grace state: %v", err)
+	}
+
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.grace = state
+	fd.updateGrace()
+	return nil
+}
@@ -0,0 +1,52 @@
+package fear
+
+// Lighting effects of scares that a LightingEffector may act on
+const (
+	LightingNone           = "none"
+	LightingFlash          = "flash"
+	LightingDarkening      = "darkening"
+	LightingShadowMovement = "shadow_movement"
+	LightingShadowAcross   = "shadow_across" // A single shadow sweeping past, lit like shadow_movement
+	LightingColorShift     = "color_shift"
+	LightingSubtlePulsing  = "subtle_pulsing"
+)
+
+// LightingEffector changes the lights around scares (e.g. dims every light within
+// the scare's EffectRadius of its start on "darkening", strobes them on "flash").
+// It is called with the director locked and must not call back into the director.
+type LightingEffector interface {
+	// ApplyLightingEffect applies scare.LightingEffect, possibly later on the
+	// ECS update. It returns true if lights may change and have to be restored
+	// when the scare ends.
+	ApplyLightingEffect(scare ScareEvent) (changed bool)
+
+	// RevertLightingEffect restores the lights changed for the scare
+	RevertLightingEffect(scare ScareEvent)
+}
+
+// SetLightingEffector sets the system that applies lighting effects of scares
+func (fd *Director) SetLightingEffector(effector LightingEffector) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.lightingEffector = effector
+}
+
+// applyLightingEffect hands a triggered scare's lighting effect to the effector.
+// Must be called with fd.mutex held.
+func (fd *Director) applyLightingEffect(scare *ScareEvent) {
+	if fd.lightingEffector == nil || scare.LightingEffect == "" || scare.LightingEffect == LightingNone {
+		return
+	}
+	scare.lightingChanged = fd.lightingEffector.ApplyLightingEffect(*scare)
+}
+
+// revertLightingEffect restores the lights changed by an ended scare.
+// Must be called with fd.mutex held.
+func (fd *Director) revertLightingEffect(scare *ScareEvent) {
+	if fd.lightingEffector == nil || !scare.lightingChanged {
+		return
+	}
+	fd.lightingEffector.RevertLightingEffect(*scare)
+	scare.lightingChanged = false
+}
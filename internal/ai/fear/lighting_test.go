@@ -0,0 +1,94 @@
+package fear
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// fakeLight is a light the fake effector dims
+type fakeLight struct {
+	position  ecs.Vector3
+	intensity float64
+}
+
+// fakeLightingEffector halves the lights within a darkening scare's radius and
+// restores them when the scare is reverted
+type fakeLightingEffector struct {
+	lights map[string]*fakeLight
+	saved  map[string]map[string]float64 // Original intensity by scare ID and light name
+}
+
+func (fe *fakeLightingEffector) ApplyLightingEffect(scare ScareEvent) bool {
+	if scare.LightingEffect != LightingDarkening {
+		return false
+	}
+	saved := make(map[string]float64)
+	for name, light := range fe.lights {
+		if light.position.Distance(scare.StartPosition) <= scare.EffectRadius {
+			saved[name] = light.intensity
+			light.intensity *= 0.5
+		}
+	}
+	fe.saved[scare.ID] = saved
+	return len(saved) > 0
+}
+
+func (fe *fakeLightingEffector) RevertLightingEffect(scare ScareEvent) {
+	for name, intensity := range fe.saved[scare.ID] {
+		fe.lights[name].intensity = intensity
+	}
+	delete(fe.saved, scare.ID)
+}
+
+func TestDarkeningScareDimsNearbyLightsUntilItEnds(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	clock := engine.NewFakeClock(time.Date(2026, 1, 1, 22, 0, 0, 0, time.UTC))
+	fd.SetClock(clock)
+	effector := &fakeLightingEffector{
+		lights: map[string]*fakeLight{
+			"lantern":  {position: ecs.Vector3{X: 3}, intensity: 1.0},
+			"campfire": {position: ecs.Vector3{X: 40}, intensity: 1.0},
+		},
+		saved: make(map[string]map[string]float64),
+	}
+	fd.SetLightingEffector(effector)
+
+	scare := &ScareEvent{
+		ID:             "darkening_1",
+		Type:           "darkening",
+		Intensity:      0.5,
+		Duration:       10,
+		EffectRadius:   8,
+		LightingEffect: LightingDarkening,
+		SuccessRating:  clock.Now(),
+	}
+	fd.mutex.Lock()
+	triggered := fd.triggerScare(scare)
+	fd.mutex.Unlock()
+	if !triggered {
+		t.Fatalf("darkening scare was not triggered")
+	}
+
+	if got := effector.lights["lantern"].intensity; got != 0.5 {
+		t.Errorf("lantern within the radius has intensity %v, want 0.5", got)
+	}
+	if got := effector.lights["campfire"].intensity; got != 1.0 {
+		t.Errorf("campfire outside the radius has intensity %v, want 1", got)
+	}
+
+	// The scare is still running: the lights stay dimmed
+	clock.Advance(5 * time.Second)
+	fd.updateActiveScares(5)
+	if got := effector.lights["lantern"].intensity; got != 0.5 {
+		t.Errorf("lantern restored at %v before the scare ended", got)
+	}
+
+	clock.Advance(5 * time.Second)
+	fd.updateActiveScares(5)
+	if got := effector.lights["lantern"].intensity; got != 1.0 {
+		t.Errorf("lantern has intensity %v after the scare ended, want 1", got)
+	}
+}
@@ -0,0 +1,44 @@
+package fear
+
+import "echo-taiga/internal/engine/ecs"
+
+// LoadedArea reports which positions lie in loaded chunks (e.g. world.World).
+// Near the edge of the view distance a scare placed behind the player could
+// otherwise land in a chunk that does not exist.
+type LoadedArea interface {
+	IsPositionLoaded(position ecs.Vector3) bool
+	ClampToLoaded(position ecs.Vector3) ecs.Vector3
+}
+
+// SetLoadedArea sets the source of loaded chunks that scare positions are kept within
+func (fd *Director) SetLoadedArea(area LoadedArea) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.loadedArea = area
+}
+
+// clampScareToLoaded moves a scare's start and target positions into the
+// nearest loaded chunks. Must be called with the mutex held.
+func (fd *Director) clampScareToLoaded(scare *ScareEvent) {
+	scare.StartPosition = fd.loadedPosition(scare.StartPosition)
+	scare.TargetPosition = fd.loadedPosition(scare.TargetPosition)
+}
+
+// loadedPosition returns a position moved into the nearest loaded chunk.
+// Must be called with the mutex held.
+func (fd *Director) loadedPosition(position ecs.Vector3) ecs.Vector3 {
+	if fd.loadedArea == nil {
+		return position
+	}
+	return fd.loadedArea.ClampToLoaded(position)
+}
+
+// scareInLoadedArea checks that both positions of a scare lie in loaded chunks.
+// Must be called with the mutex held.
+func (fd *Director) scareInLoadedArea(scare *ScareEvent) bool {
+	if fd.loadedArea == nil {
+		return true
+	}
+	return fd.loadedArea.IsPositionLoaded(scare.StartPosition) && fd.loadedArea.IsPositionLoaded(scare.TargetPosition)
+}
@@ -0,0 +1,84 @@
+package fear
+
+import (
+	"math"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// fakeLoadedArea loads the square |x|, |z| <= Half and clamps points into it
+type fakeLoadedArea struct {
+	Half float64
+}
+
+func (fa fakeLoadedArea) IsPositionLoaded(position ecs.Vector3) bool {
+	return math.Abs(position.X) <= fa.Half && math.Abs(position.Z) <= fa.Half
+}
+
+func (fa fakeLoadedArea) ClampToLoaded(position ecs.Vector3) ecs.Vector3 {
+	position.X = math.Max(-fa.Half, math.Min(fa.Half, position.X))
+	position.Z = math.Max(-fa.Half, math.Min(fa.Half, position.Z))
+	return position
+}
+
+// unplaceableArea reports every position as unloaded and cannot clamp
+type unplaceableArea struct{}
+
+func (unplaceableArea) IsPositionLoaded(ecs.Vector3) bool              { return false }
+func (unplaceableArea) ClampToLoaded(position ecs.Vector3) ecs.Vector3 { return position }
+
+// newLoadedTestDirector creates a director with a single ambient scare template
+func newLoadedTestDirector(t *testing.T, area LoadedArea) *Director {
+	t.Helper()
+
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	fd.AddScareTemplate(ScareEvent{Type: "ambient_whisper", Intensity: 0.3, Cooldown: 30, EffectRadius: 10})
+	fd.SetLoadedArea(area)
+	return fd
+}
+
+func TestScareAtRingEdgeIsClampedIntoLoadedArea(t *testing.T) {
+	fd := newLoadedTestDirector(t, fakeLoadedArea{Half: 16})
+	fd.mutex.Lock()
+	fd.playerPosition = ecs.Vector3{X: 15, Z: -15}
+	fd.mutex.Unlock()
+
+	var triggered *ScareEvent
+	fd.OnScareTriggered = func(scare ScareEvent) { triggered = &scare }
+	if !fd.ForceScare("ambient_whisper") {
+		t.Fatalf("scare at the edge of the loaded area was not triggered")
+	}
+	for name, position := range map[string]ecs.Vector3{"start": triggered.StartPosition, "target": triggered.TargetPosition} {
+		if !(fakeLoadedArea{Half: 16}).IsPositionLoaded(position) {
+			t.Errorf("%s position %v lies outside the loaded area", name, position)
+		}
+	}
+}
+
+func TestUnplaceableScareChargesNoCooldown(t *testing.T) {
+	fd := newLoadedTestDirector(t, unplaceableArea{})
+
+	if fd.ForceScare("ambient_whisper") {
+		t.Fatalf("scare outside the loaded chunks was triggered")
+	}
+	fd.mutex.RLock()
+	_, charged := fd.scareCooldowns["ambient_whisper"]
+	scares := len(fd.currentScares)
+	fd.mutex.RUnlock()
+	if charged || scares != 0 {
+		t.Errorf("aborted scare: cooldown charged %v, %d current scares", charged, scares)
+	}
+
+	// Once the area loads, the same scare goes off and starts its cooldown
+	fd.SetLoadedArea(fakeLoadedArea{Half: 100})
+	if !fd.ForceScare("ambient_whisper") {
+		t.Fatalf("scare inside the loaded chunks was not triggered")
+	}
+	fd.mutex.RLock()
+	_, charged = fd.scareCooldowns["ambient_whisper"]
+	fd.mutex.RUnlock()
+	if !charged {
+		t.Errorf("triggered scare charged no cooldown")
+	}
+}
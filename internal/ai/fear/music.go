@@ -0,0 +1,241 @@
+package fear
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// MusicState is a discrete music mood driven by the director
+type MusicState string
+
+const (
+	MusicExplore   MusicState = "explore"
+	MusicUnease    MusicState = "unease"
+	MusicDanger    MusicState = "danger"
+	MusicChase     MusicState = "chase"
+	MusicAftermath MusicState = "aftermath"
+)
+
+// MusicRule selects a music state when all of its conditions hold
+type MusicRule struct {
+	State         MusicState `json:"state"`
+	MinTension    int        `json:"min_tension,omitempty"`     // Lowest tension level (0-4)
+	Phases        []string   `json:"phases,omitempty"`          // Any of these tension phases (empty: any)
+	ScareTypes    []string   `json:"scare_types,omitempty"`     // Any of these scares active (empty: not required)
+	Pursued       bool       `json:"pursued,omitempty"`         // A hostile is actively pursuing the player
+	AfterScareEnd bool       `json:"after_scare_end,omitempty"` // A scare or pursuit has just ended
+}
+
+// MusicConfig is the data-driven music state table. Rules are checked in order
+// and the first match wins; MusicExplore plays when nothing matches. A state's
+// first rule also sets its urgency: moving to a more urgent state happens at
+// once, while calming down waits out the current state's minimum dwell.
+type MusicConfig struct {
+	Rules             []MusicRule            `json:"rules"`
+	MinDwell          map[MusicState]float64 `json:"min_dwell"`          // Seconds a state is held before it may calm down
+	AftermathDuration float64                `json:"aftermath_duration"` // Seconds after a scare or pursuit counted as "just ended"
+}
+
+// DefaultMusicConfig returns the default music state table
+func DefaultMusicConfig() MusicConfig {
+	return MusicConfig{
+		Rules: []MusicRule{
+			{State: MusicChase, Pursued: true},
+			{State: MusicChase, ScareTypes: []string{"chase"}},
+			{State: MusicDanger, MinTension: 3},
+			{State: MusicDanger, ScareTypes: []string{"entity", "jumpscare"}},
+			{State: MusicAftermath, AfterScareEnd: true},
+			{State: MusicUnease, MinTension: 1, Phases: []string{"build", "peak"}},
+			{State: MusicUnease, MinTension: 2},
+		},
+		MinDwell: map[MusicState]float64{
+			MusicExplore:   8.0,
+			MusicUnease:    6.0,
+			MusicDanger:    5.0,
+			MusicChase:     4.0,
+			MusicAftermath: 10.0,
+		},
+		AftermathDuration: 15.0,
+	}
+}
+
+// musicInputs is what the music state is computed from
+type musicInputs struct {
+	tensionLevel   int
+	tensionPhase   string
+	activeScares   map[string]bool
+	pursued        bool
+	afterScareEnds bool
+}
+
+// matches checks whether the rule's conditions all hold
+func (mr MusicRule) matches(in musicInputs) bool {
+	if in.tensionLevel < mr.MinTension {
+		return false
+	}
+	if mr.Pursued && !in.pursued {
+		return false
+	}
+	if mr.AfterScareEnd && !in.afterScareEnds {
+		return false
+	}
+	if len(mr.Phases) > 0 {
+		inPhase := false
+		for _, phase := range mr.Phases {
+			if phase == in.tensionPhase {
+				inPhase = true
+				break
+			}
+		}
+		if !inPhase {
+			return false
+		}
+	}
+	if len(mr.ScareTypes) > 0 {
+		active := false
+		for _, scareType := range mr.ScareTypes {
+			if in.activeScares[scareType] {
+				active = true
+				break
+			}
+		}
+		if !active {
+			return false
+		}
+	}
+	return true
+}
+
+// desiredState returns the state of the first matching rule
+func (mc MusicConfig) desiredState(in musicInputs) MusicState {
+	for _, rule := range mc.Rules {
+		if rule.matches(in) {
+			return rule.State
+		}
+	}
+	return MusicExplore
+}
+
+// urgency ranks a state by its first rule; lower is more urgent and states
+// without a rule (MusicExplore) come last
+func (mc MusicConfig) urgency(state MusicState) int {
+	for i, rule := range mc.Rules {
+		if rule.State == state {
+			return i
+		}
+	}
+	return len(mc.Rules)
+}
+
+// canLeave checks whether the music may move from previous to desired after
+// dwelling in previous for the given number of seconds
+func (mc MusicConfig) canLeave(previous, desired MusicState, dwell float64) bool {
+	if mc.urgency(desired) < mc.urgency(previous) {
+		return true
+	}
+	return dwell >= mc.MinDwell[previous]
+}
+
+// GetMusicState returns the current music state
+func (fd *Director) GetMusicState() MusicState {
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+
+	return fd.musicState
+}
+
+// GetMusicConfig returns the music state table
+func (fd *Director) GetMusicConfig() MusicConfig {
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+
+	return fd.musicConfig
+}
+
+// SetMusicConfig replaces the music state table
+func (fd *Director) SetMusicConfig(config MusicConfig) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.musicConfig = config
+}
+
+// LoadMusicConfig loads the music state table from music_rules.json, writing
+// the default table there first if the file does not exist
+func (fd *Director) LoadMusicConfig() error {
+	rulesPath := filepath.Join(fd.savePath, "music_rules.json")
+	if _, err := os.Stat(rulesPath); os.IsNotExist(err) {
+		if err := os.MkdirAll(fd.savePath, os.ModePerm); err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(DefaultMusicConfig(), "", "  ")
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(rulesPath, data, 0644)
+	}
+
+	data, err := ioutil.ReadFile(rulesPath)
+	if err != nil {
+		return err
+	}
+
+	config := DefaultMusicConfig()
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("invalid music rules: %v", err)
+	}
+
+	fd.SetMusicConfig(config)
+	return nil
+}
+
+// updateMusicState moves the music state toward the first matching rule.
+// Escalation is immediate; calming down waits for the minimum dwell time so
+// the music does not flap.
+func (fd *Director) updateMusicState(deltaTime float64) {
+	fd.mutex.Lock()
+
+	fd.musicDwell += deltaTime
+
+	// A scare or pursuit ending starts the aftermath window
+	pursued := fd.threatSource != nil && fd.threatSource.IsPlayerPursued()
+	if fd.scareJustEnded || (fd.wasPursued && !pursued) {
+		fd.aftermathRemaining = fd.musicConfig.AftermathDuration
+	} else if fd.aftermathRemaining > 0 {
+		fd.aftermathRemaining -= deltaTime
+	}
+	fd.scareJustEnded = false
+	fd.wasPursued = pursued
+
+	activeScares := make(map[string]bool, len(fd.currentScares))
+	for _, scare := range fd.currentScares {
+		activeScares[scare.Type] = true
+	}
+
+	desired := fd.musicConfig.desiredState(musicInputs{
+		tensionLevel:   fd.tensionLevel,
+		tensionPhase:   fd.tensionPhase,
+		activeScares:   activeScares,
+		pursued:        pursued,
+		afterScareEnds: fd.aftermathRemaining > 0,
+	})
+
+	previous := fd.musicState
+	if desired == previous || !fd.musicConfig.canLeave(previous, desired, fd.musicDwell) {
+		fd.mutex.Unlock()
+		return
+	}
+
+	fd.musicState = desired
+	fd.musicDwell = 0
+	callback := fd.OnMusicStateChanged
+	fd.mutex.Unlock()
+
+	if callback != nil {
+		callback(previous, desired)
+	}
+}
@@ -0,0 +1,106 @@
+package fear
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// fakeThreat reports a fixed pursuit flag
+type fakeThreat struct {
+	pursued bool
+}
+
+func (ft *fakeThreat) GetThreatLevel() float64 {
+	return 0
+}
+
+func (ft *fakeThreat) IsPlayerPursued() bool {
+	return ft.pursued
+}
+
+// musicStep is the director's input for one second of the scripted timeline
+type musicStep struct {
+	tension int
+	phase   string
+	pursued bool
+}
+
+// musicTransition is a state change expected at a second of the timeline
+type musicTransition struct {
+	second int
+	to     MusicState
+}
+
+func TestMusicStateTimeline(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	threat := &fakeThreat{}
+	fd.SetThreatSource(threat)
+
+	got := make([]musicTransition, 0)
+	second := 0
+	fd.OnMusicStateChanged = func(from, to MusicState) {
+		got = append(got, musicTransition{second, to})
+	}
+
+	// Quiet start, tension builds, a hostile gives chase for a second, then
+	// the aftermath plays out and the forest calms down again
+	timeline := make([]musicStep, 30)
+	for i := range timeline {
+		switch {
+		case i == 0 || i >= 19:
+			timeline[i] = musicStep{tension: 0, phase: "calm"}
+		case i == 2:
+			timeline[i] = musicStep{tension: 1, phase: "build", pursued: true}
+		default:
+			timeline[i] = musicStep{tension: 1, phase: "build"}
+		}
+	}
+
+	for second = range timeline {
+		step := timeline[second]
+		fd.mutex.Lock()
+		fd.tensionLevel = step.tension
+		fd.tensionPhase = step.phase
+		fd.mutex.Unlock()
+		threat.pursued = step.pursued
+
+		fd.updateMusicState(1.0)
+	}
+
+	want := []musicTransition{
+		{1, MusicUnease},    // escalates without waiting out the explore dwell
+		{2, MusicChase},     // pursuit interrupts at once
+		{6, MusicAftermath}, // chase is held for its 4 second dwell
+		{18, MusicUnease},   // aftermath lasts 15 seconds after the pursuit
+		{24, MusicExplore},  // unease is held for its 6 second dwell
+	}
+	if len(got) != len(want) {
+		t.Fatalf("transitions %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("transition %d = %v, want %v (all: %v)", i, got[i], want[i], got)
+		}
+	}
+	if state := fd.GetMusicState(); state != MusicExplore {
+		t.Errorf("final state %s, want %s", state, MusicExplore)
+	}
+}
+
+func TestMusicDwellHoldsOnlyWhenCalming(t *testing.T) {
+	config := DefaultMusicConfig()
+
+	if !config.canLeave(MusicExplore, MusicDanger, 0) {
+		t.Errorf("explore held back an escalation to danger")
+	}
+	if !config.canLeave(MusicUnease, MusicChase, 0) {
+		t.Errorf("unease held back an escalation to chase")
+	}
+	if config.canLeave(MusicDanger, MusicUnease, config.MinDwell[MusicDanger]-1) {
+		t.Errorf("danger calmed down to unease before its dwell time")
+	}
+	if !config.canLeave(MusicDanger, MusicUnease, config.MinDwell[MusicDanger]) {
+		t.Errorf("danger did not calm down after its dwell time")
+	}
+}
@@ -0,0 +1,105 @@
+package fear
+
+import (
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Where a scare prefers to appear relative to the player's view
+const (
+	PlaceAnywhere  = ""
+	PlaceInView    = "in_view"
+	PlaceOutOfView = "out_of_view"
+)
+
+// PlacementConfig controls where scares appear relative to the player's facing
+type PlacementConfig struct {
+	FieldOfView float64           // Full width of the frontal view cone (degrees)
+	Preferences map[string]string // Placement by scare subtype or type (subtype wins)
+}
+
+// DefaultPlacementConfig returns the default scare placement settings
+func DefaultPlacementConfig() PlacementConfig {
+	return PlacementConfig{
+		FieldOfView: 100.0,
+		Preferences: map[string]string{
+			"stalker":       PlaceOutOfView,
+			"ambient_sound": PlaceOutOfView,
+			"jumpscare":     PlaceInView,
+			"reinforcement": PlaceOutOfView,
+			"interruption":  PlaceInView,
+		},
+	}
+}
+
+// preferenceFor returns the placement preference of a scare
+func (pc PlacementConfig) preferenceFor(scare *ScareEvent) string {
+	if preference, ok := pc.Preferences[scare.Subtype]; ok {
+		return preference
+	}
+	return pc.Preferences[scare.Type]
+}
+
+// SetPlacementConfig sets where scares appear relative to the player's view
+func (fd *Director) SetPlacementConfig(config PlacementConfig) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.placement = config
+}
+
+// playerFacing returns the player's horizontal forward direction
+func (fd *Director) playerFacing() (ecs.Vector3, bool) {
+	player, exists := fd.world.GetEntity(fd.playerID)
+	if !exists {
+		return ecs.Vector3{}, false
+	}
+
+	transformComp, has := player.GetComponent(ecs.TransformComponentID)
+	if !has {
+		return ecs.Vector3{}, false
+	}
+
+	forward := transformComp.(*ecs.TransformComponent).Forward()
+	forward.Y = 0
+	if forward.Magnitude() == 0 {
+		return ecs.Vector3{}, false
+	}
+	return forward.Normalize(), true
+}
+
+// placeOutOfView returns a point at the given distance from the player, behind
+// or to the periphery of their facing, outside the frontal view cone.
+// Must be called with the mutex held.
+func (fd *Director) placeOutOfView(radius float64) ecs.Vector3 {
+	halfFOV := fd.placement.FieldOfView * math.Pi / 360.0
+	// Angles away from forward that stay outside the cone on either side
+	offset := halfFOV + fd.rng.Float64()*(2*math.Pi-2*halfFOV)
+	return fd.placeAtAngle(radius, offset)
+}
+
+// placeInView returns a point at the given distance from the player, inside
+// the frontal view cone. Must be called with the mutex held.
+func (fd *Director) placeInView(radius float64) ecs.Vector3 {
+	halfFOV := fd.placement.FieldOfView * math.Pi / 360.0
+	offset := (fd.rng.Float64()*2 - 1) * halfFOV * 0.5
+	return fd.placeAtAngle(radius, offset)
+}
+
+// placeAtAngle returns a point at the given distance from the player, rotated
+// by offset radians from their facing around the vertical axis
+func (fd *Director) placeAtAngle(radius, offset float64) ecs.Vector3 {
+	forward, ok := fd.playerFacing()
+	if !ok {
+		forward = ecs.Vector3{X: 0, Y: 0, Z: 1}
+	}
+
+	sin, cos := math.Sincos(offset)
+	direction := ecs.Vector3{
+		X: forward.X*cos + forward.Z*sin,
+		Y: 0,
+		Z: -forward.X*sin + forward.Z*cos,
+	}
+	return fd.playerPosition.Add(direction.Multiply(radius))
+}
@@ -0,0 +1,84 @@
+package fear
+
+import (
+	"math"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newFacingTestDirector creates a director whose player stands at position
+// facing +X, with the given frontal field of view
+func newFacingTestDirector(t *testing.T, position ecs.Vector3, fieldOfView float64) *Director {
+	t.Helper()
+
+	world := ecs.NewWorld()
+	player := ecs.NewEntity()
+	transform := ecs.NewTransformComponent(position)
+	transform.Rotation.Y = math.Pi / 2
+	player.AddComponent(transform)
+	world.AddEntity(player)
+
+	fd := NewDirector(world, t.TempDir())
+	config := DefaultPlacementConfig()
+	config.FieldOfView = fieldOfView
+	fd.SetPlacementConfig(config)
+	fd.playerID = player.ID
+	fd.playerPosition = position
+	return fd
+}
+
+// angleFromFacing returns the angle (degrees) between +X and the direction from player to point
+func angleFromFacing(player, point ecs.Vector3) float64 {
+	return math.Abs(math.Atan2(point.Z-player.Z, point.X-player.X)) * 180 / math.Pi
+}
+
+func TestStalkerScareIsPlacedOutOfView(t *testing.T) {
+	player := ecs.Vector3{X: 5, Z: -3}
+	fd := newFacingTestDirector(t, player, 90)
+	template := ScareEvent{Type: "entity", Subtype: "stalker", Intensity: 0.5, EffectRadius: 20}
+
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+	for i := 0; i < 100; i++ {
+		scare := fd.generateScareFromTemplate(&template, player)
+		if angle := angleFromFacing(player, scare.StartPosition); angle < 45-1e-9 {
+			t.Fatalf("stalker placed %.1f degrees from the player's facing, inside the 90 degree view", angle)
+		}
+		if distance := scare.StartPosition.Distance(player); math.Abs(distance-14) > 1e-9 {
+			t.Fatalf("stalker placed %.2f away, want 70%% of the effect radius", distance)
+		}
+		if scare.TargetPosition != player {
+			t.Fatalf("stalker heads for %v, want the player at %v", scare.TargetPosition, player)
+		}
+	}
+}
+
+func TestJumpscareIsPlacedInView(t *testing.T) {
+	player := ecs.Vector3{}
+	fd := newFacingTestDirector(t, player, 90)
+
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+	for i := 0; i < 100; i++ {
+		position := fd.placeInView(10)
+		if angle := angleFromFacing(player, position); angle > 45 {
+			t.Fatalf("jumpscare placed %.1f degrees from the player's facing, outside the 90 degree view", angle)
+		}
+	}
+}
+
+func TestPlacementPreferenceSubtypeWinsOverType(t *testing.T) {
+	config := DefaultPlacementConfig()
+	config.Preferences["entity"] = PlaceInView
+
+	if got := config.preferenceFor(&ScareEvent{Type: "entity", Subtype: "stalker"}); got != PlaceOutOfView {
+		t.Errorf("stalker entity placed %q, want the subtype's %q", got, PlaceOutOfView)
+	}
+	if got := config.preferenceFor(&ScareEvent{Type: "entity", Subtype: "shade"}); got != PlaceInView {
+		t.Errorf("unlisted subtype placed %q, want the type's %q", got, PlaceInView)
+	}
+	if got := config.preferenceFor(&ScareEvent{Type: "ambient_whisper"}); got != PlaceAnywhere {
+		t.Errorf("unlisted scare placed %q, want anywhere", got)
+	}
+}
@@ -0,0 +1,181 @@
+package fear
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"echo-taiga/internal/savefile"
+)
+
+// SeedObservationWeight is the weight a seeded or default fear value carries when
+// profiles are merged, as if it had been observed this many times
+const SeedObservationWeight = 1
+
+// Observation keys of the fear maps are prefixed with the map they belong to
+const (
+	observationEntity      = "entity:"
+	observationEnvironment = "environment:"
+	observationContextual  = "contextual:"
+	observationScare       = "scare:"
+)
+
+// fearFields maps questionnaire and observation keys to the general fear ratings
+var fearFields = map[string]func(p *FearProfile) *float64{
+	"darkness":        func(p *FearProfile) *float64 { return &p.DarknessFear },
+	"enclosed_spaces": func(p *FearProfile) *float64 { return &p.EnclosedSpacesFear },
+	"heights":         func(p *FearProfile) *float64 { return &p.HeightsFear },
+	"water":           func(p *FearProfile) *float64 { return &p.WaterFear },
+	"fire":            func(p *FearProfile) *float64 { return &p.FireFear },
+	"monsters":        func(p *FearProfile) *float64 { return &p.MonstersFear },
+	"insects":         func(p *FearProfile) *float64 { return &p.InsectsFear },
+	"gore":            func(p *FearProfile) *float64 { return &p.GoreFear },
+	"jumpscares":      func(p *FearProfile) *float64 { return &p.JumpscaresFear },
+	"psychological":   func(p *FearProfile) *float64 { return &p.PsychologicalFear },
+	"noise":           func(p *FearProfile) *float64 { return &p.NoiseFear },
+	"silence":         func(p *FearProfile) *float64 { return &p.SilenceFear },
+	"unknown":         func(p *FearProfile) *float64 { return &p.UnknownFear },
+	"isolation":       func(p *FearProfile) *float64 { return &p.IsolationFear },
+	"paranormal":      func(p *FearProfile) *float64 { return &p.ParanormalFear },
+}
+
+// questionnaireAliases maps everyday questionnaire answers onto fear ratings
+var questionnaireAliases = map[string][]string{
+	"spiders":        {"insects"},
+	"claustrophobia": {"enclosed_spaces"},
+	"blood":          {"gore"},
+	"loud_noises":    {"noise"},
+	"slow_dread":     {"psychological", "silence"},
+	"ghosts":         {"paranormal"},
+	"being_alone":    {"isolation"},
+}
+
+// observe records one more observation of a fear rating. Must be called with fd.mutex held.
+func (fd *Director) observe(key string) {
+	if fd.fearProfile.Observations == nil {
+		fd.fearProfile.Observations = make(map[string]int)
+	}
+	fd.fearProfile.Observations[key]++
+}
+
+// SeedFearProfile sets the general fear ratings from an onboarding questionnaire.
+// Answers are 0-1 and keyed by rating ("darkness", "jumpscares", ...) or by one of the
+// everyday aliases ("spiders", "slow_dread", ...). Values are clamped; unknown keys are
+// ignored. Seeded ratings carry no observations, so anything learned later outweighs them.
+func (fd *Director) SeedFearProfile(answers map[string]float64) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	// Apply answers in a fixed order, so aliases overlapping a direct answer resolve the same way
+	keys := make([]string, 0, len(answers))
+	for key := range answers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := math.Max(0, math.Min(1, answers[key]))
+
+		fields := questionnaireAliases[key]
+		if _, direct := fearFields[key]; direct {
+			fields = []string{key}
+		}
+		for _, field := range fields {
+			*fearFields[field](fd.fearProfile) = value
+		}
+	}
+}
+
+// ImportProfileFrom merges the fear profile learned in another save slot into this one.
+// path is the slot's fear directory or the fear profile file itself. Every rating becomes
+// the average of both values weighted by how often each was observed (seeded and default
+// values count as SeedObservationWeight), and the observation counts are summed.
+func (fd *Director) ImportProfileFrom(path string) error {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "fear_profile.json")
+	}
+
+	data, err := savefile.Read(path)
+	if err != nil {
+		return err
+	}
+
+	var imported FearProfile
+	if err := json.Unmarshal(data, &imported); err != nil {
+		return fmt.Errorf("invalid fear profile: %v", err)
+	}
+
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.fearProfile = mergeFearProfiles(fd.fearProfile, &imported)
+	return nil
+}
+
+// mergeFearProfiles returns a copy of current with the imported ratings merged in by
+// observation-weighted average. Ratings present in only one profile are kept as they are.
+func mergeFearProfiles(current, imported *FearProfile) *FearProfile {
+	merged := *current
+	merged.EntityFears = copyRatings(current.EntityFears)
+	merged.EnvironmentFears = copyRatings(current.EnvironmentFears)
+	merged.ContextualFears = copyRatings(current.ContextualFears)
+	merged.EffectiveScares = copyRatings(current.EffectiveScares)
+	merged.Observations = make(map[string]int)
+	for key, count := range current.Observations {
+		merged.Observations[key] = count
+	}
+
+	mergeRating := func(key string, target *float64, value float64) {
+		currentWeight := observationWeight(current.Observations[key])
+		importedWeight := observationWeight(imported.Observations[key])
+		*target = (*target*currentWeight + value*importedWeight) / (currentWeight + importedWeight)
+		if count := imported.Observations[key]; count > 0 {
+			merged.Observations[key] += count
+		}
+	}
+
+	for key, field := range fearFields {
+		mergeRating(key, field(&merged), *field(imported))
+	}
+
+	mergeMap := func(prefix string, target, source map[string]float64) {
+		for name, value := range source {
+			if _, exists := target[name]; !exists {
+				target[name] = value
+				if count := imported.Observations[prefix+name]; count > 0 {
+					merged.Observations[prefix+name] += count
+				}
+				continue
+			}
+			rating := target[name]
+			mergeRating(prefix+name, &rating, value)
+			target[name] = rating
+		}
+	}
+	mergeMap(observationEntity, merged.EntityFears, imported.EntityFears)
+	mergeMap(observationEnvironment, merged.EnvironmentFears, imported.EnvironmentFears)
+	mergeMap(observationContextual, merged.ContextualFears, imported.ContextualFears)
+	mergeMap(observationScare, merged.EffectiveScares, imported.EffectiveScares)
+
+	return &merged
+}
+
+// observationWeight returns the merge weight of a rating observed count times
+func observationWeight(count int) float64 {
+	if count < SeedObservationWeight {
+		return SeedObservationWeight
+	}
+	return float64(count)
+}
+
+// copyRatings copies a map of fear ratings, creating it if it is nil
+func copyRatings(ratings map[string]float64) map[string]float64 {
+	copied := make(map[string]float64, len(ratings))
+	for key, value := range ratings {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,168 @@
+package fear
+
+import (
+	"math"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// closeTo compares fear ratings up to rounding
+func closeTo(got, want float64) bool {
+	return math.Abs(got-want) < 1e-9
+}
+
+func TestSeedFearProfileMapsAndClampsAnswers(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	before := fd.GetFearProfile()
+
+	fd.SeedFearProfile(map[string]float64{
+		"darkness":   1.7,
+		"jumpscares": -0.4,
+		"spiders":    0.9,
+		"slow_dread": 0.6,
+		"clowns":     1.0,
+	})
+
+	profile := fd.GetFearProfile()
+	for _, tt := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"darkness", profile.DarknessFear, 1},
+		{"jumpscares", profile.JumpscaresFear, 0},
+		{"insects (spiders)", profile.InsectsFear, 0.9},
+		{"psychological (slow_dread)", profile.PsychologicalFear, 0.6},
+		{"silence (slow_dread)", profile.SilenceFear, 0.6},
+		{"water (not asked)", profile.WaterFear, before.WaterFear},
+	} {
+		if !closeTo(tt.got, tt.want) {
+			t.Errorf("%s = %.2f, want %.2f", tt.name, tt.got, tt.want)
+		}
+	}
+	if len(profile.Observations) != 0 {
+		t.Errorf("seeding recorded observations %v, want none", profile.Observations)
+	}
+}
+
+func TestMergeWeighsRatingsByObservations(t *testing.T) {
+	current := NewDefaultFearProfile()
+	current.DarknessFear = 0.2 // seeded, never observed
+	current.WaterFear = 0.4
+	current.Observations["water"] = 2
+	current.EntityFears["wolf"] = 0.3
+	current.Observations[observationEntity+"wolf"] = 1
+
+	imported := NewDefaultFearProfile()
+	imported.DarknessFear = 0.8
+	imported.Observations["darkness"] = 3
+	imported.WaterFear = 1.0
+	imported.Observations["water"] = 6
+	imported.EntityFears["wolf"] = 0.9
+	imported.Observations[observationEntity+"wolf"] = 2
+	imported.EntityFears["wisp"] = 0.7
+	imported.Observations[observationEntity+"wisp"] = 4
+
+	merged := mergeFearProfiles(current, imported)
+
+	for _, tt := range []struct {
+		name      string
+		got, want float64
+	}{
+		// The seeded value counts as a single observation against three learned ones
+		{"darkness", merged.DarknessFear, (0.2*1 + 0.8*3) / 4},
+		{"water", merged.WaterFear, (0.4*2 + 1.0*6) / 8},
+		{"wolf", merged.EntityFears["wolf"], (0.3*1 + 0.9*2) / 3},
+		{"wisp", merged.EntityFears["wisp"], 0.7},
+		// Neither side observed it, so both defaults weigh the same
+		{"fire", merged.FireFear, current.FireFear},
+	} {
+		if !closeTo(tt.got, tt.want) {
+			t.Errorf("merged %s = %.4f, want %.4f", tt.name, tt.got, tt.want)
+		}
+	}
+
+	wantCounts := map[string]int{
+		"darkness":                 3,
+		"water":                    8,
+		observationEntity + "wolf": 3,
+		observationEntity + "wisp": 4,
+	}
+	for key, want := range wantCounts {
+		if got := merged.Observations[key]; got != want {
+			t.Errorf("observations of %s = %d, want %d", key, got, want)
+		}
+	}
+
+	// The inputs are left alone
+	if current.DarknessFear != 0.2 || current.Observations["water"] != 2 || len(current.EntityFears) != 1 {
+		t.Errorf("merge changed the current profile: %+v", current)
+	}
+}
+
+func TestImportProfileFromAnotherSlotPersists(t *testing.T) {
+	otherSlot := t.TempDir()
+	other := NewDirector(ecs.NewWorld(), otherSlot)
+	other.mutex.Lock()
+	other.fearProfile.DarknessFear = 0.9
+	other.fearProfile.Observations["darkness"] = 9
+	other.mutex.Unlock()
+	if err := other.SaveProfiles(); err != nil {
+		t.Fatalf("SaveProfiles: %v", err)
+	}
+
+	slot := t.TempDir()
+	fd := NewDirector(ecs.NewWorld(), slot)
+	fd.SeedFearProfile(map[string]float64{"darkness": 0.0})
+	if err := fd.ImportProfileFrom(otherSlot); err != nil {
+		t.Fatalf("ImportProfileFrom: %v", err)
+	}
+	if got := fd.GetFearProfile().DarknessFear; !closeTo(got, 0.9*9/10) {
+		t.Errorf("darkness after import = %.3f, want the learned value to dominate (%.3f)", got, 0.9*9/10)
+	}
+
+	if err := fd.SaveProfiles(); err != nil {
+		t.Fatalf("SaveProfiles: %v", err)
+	}
+	loaded := NewDirector(ecs.NewWorld(), slot)
+	if err := loaded.LoadProfiles(); err != nil {
+		t.Fatalf("LoadProfiles: %v", err)
+	}
+	profile := loaded.GetFearProfile()
+	if !closeTo(profile.DarknessFear, 0.9*9/10) || profile.Observations["darkness"] != 9 {
+		t.Errorf("reloaded darkness %.3f with %d observations, want %.3f with 9",
+			profile.DarknessFear, profile.Observations["darkness"], 0.9*9/10)
+	}
+
+	if err := fd.ImportProfileFrom(t.TempDir()); err == nil {
+		t.Errorf("importing from a slot without a fear profile succeeded")
+	}
+}
+
+func TestAnalyzePlayerBehaviorCountsObservations(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	for i := 0; i < 5; i++ {
+		fd.RecordPlayerAction(PlayerAction{
+			Type:           ActionExploring,
+			EmotionalState: EmotionNervous,
+			ContextTags:    []string{"fog"},
+			AreaType:       "cave",
+			Target:         "wolf",
+			LightLevel:     0.1,
+		})
+	}
+	fd.analyzePlayerBehavior()
+
+	observations := fd.GetFearProfile().Observations
+	for _, key := range []string{"darkness", observationContextual + "fog", observationEnvironment + "cave", observationEntity + "wolf"} {
+		if observations[key] != 5 {
+			t.Errorf("observations of %s = %d, want 5", key, observations[key])
+		}
+	}
+
+	// The copy handed out does not share counts with the director
+	observations["darkness"] = 100
+	if got := fd.GetFearProfile().Observations["darkness"]; got != 5 {
+		t.Errorf("changing a profile copy set the director's darkness observations to %d", got)
+	}
+}
@@ -0,0 +1,117 @@
+package fear
+
+import (
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// ScareView is an immutable description of an active scare for the UI
+type ScareView struct {
+	ID        string
+	Type      string
+	Subtype   string
+	Intensity float64
+	Position  ecs.Vector3
+	Remaining float64 // Seconds until the scare ends
+}
+
+// StateSnapshot is a deep copy of the director's state that the UI reads
+// without locking. Snapshots must not be modified.
+type StateSnapshot struct {
+	Taken        time.Time
+	TensionLevel int
+	TensionName  string
+	TensionValue float64
+	TensionPhase string
+	MusicState   MusicState
+	ActiveScares []ScareView
+	WardZones    []WardZone
+	Suppressions []SuppressionView
+	Behavior     BehaviorProfile
+	Fears        FearProfile
+}
+
+// Snapshot returns the latest state snapshot without taking the director's
+// mutex. It is refreshed once per frame and is nil before the first update.
+func (fd *Director) Snapshot() *StateSnapshot {
+	return fd.snapshot.Load()
+}
+
+// publishSnapshot builds and publishes a state snapshot
+func (fd *Director) publishSnapshot() {
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+
+	now := fd.clock.Now()
+	snapshot := &StateSnapshot{
+		Taken:        now,
+		TensionLevel: fd.tensionLevel,
+		TensionName:  TensionLevelName[fd.tensionLevel],
+		TensionValue: fd.tensionCurve,
+		TensionPhase: fd.tensionPhase,
+		MusicState:   fd.musicState,
+		ActiveScares: make([]ScareView, 0, len(fd.currentScares)),
+		WardZones:    append([]WardZone(nil), fd.wardZones...),
+		Suppressions: fd.suppressionViews(),
+		Behavior:     *fd.behaviorProfile.clone(),
+		Fears:        *fd.fearProfile.clone(),
+	}
+
+	for _, scare := range fd.currentScares {
+		remaining := scare.Duration - now.Sub(scare.SuccessRating).Seconds()
+		if remaining < 0 {
+			remaining = 0
+		}
+		snapshot.ActiveScares = append(snapshot.ActiveScares, ScareView{
+			ID:        scare.ID,
+			Type:      scare.Type,
+			Subtype:   scare.Subtype,
+			Intensity: scare.Intensity,
+			Position:  scare.StartPosition,
+			Remaining: remaining,
+		})
+	}
+
+	fd.snapshot.Store(snapshot)
+}
+
+// clone returns a deep copy of the behavior profile
+func (bp *BehaviorProfile) clone() *BehaviorProfile {
+	clone := *bp
+	clone.FearTriggers = copyFloatMap(bp.FearTriggers)
+	clone.ComfortZones = copyFloatMap(bp.ComfortZones)
+	clone.MovementPatterns = copyFloatMap(bp.MovementPatterns)
+	clone.DecisionPatterns = copyFloatMap(bp.DecisionPatterns)
+	clone.TimePatterns = copyFloatMap(bp.TimePatterns)
+	clone.ScareResponses = copyFloatMap(bp.ScareResponses)
+	return &clone
+}
+
+// clone returns a deep copy of the fear profile
+func (fp *FearProfile) clone() *FearProfile {
+	clone := *fp
+	clone.EntityFears = copyFloatMap(fp.EntityFears)
+	clone.EnvironmentFears = copyFloatMap(fp.EnvironmentFears)
+	clone.ContextualFears = copyFloatMap(fp.ContextualFears)
+	clone.EffectiveScares = copyFloatMap(fp.EffectiveScares)
+	if fp.Observations != nil {
+		clone.Observations = make(map[string]int, len(fp.Observations))
+		for key, count := range fp.Observations {
+			clone.Observations[key] = count
+		}
+	}
+	return &clone
+}
+
+// copyFloatMap copies a map of values (nil stays nil)
+func copyFloatMap(source map[string]float64) map[string]float64 {
+	if source == nil {
+		return nil
+	}
+	result := make(map[string]float64, len(source))
+	for key, value := range source {
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package fear
+
+import "echo-taiga/internal/engine/ecs"
+
+// scareEntityTypes maps the entity effect of a scare to the creature it brings into the world
+var scareEntityTypes = map[string]string{
+	"creature_appear": "shadow",
+	"stalker":         "wraith",
+}
+
+// EntitySpawner creates the creatures of entity scares (e.g. world.World).
+// It is called with the director locked and must not call back into the director.
+type EntitySpawner interface {
+	SpawnEffectEntity(entityType string, position ecs.Vector3) *ecs.Entity
+}
+
+// SetEntitySpawner sets the system that creates the creatures of entity scares
+func (fd *Director) SetEntitySpawner(spawner EntitySpawner) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.entitySpawner = spawner
+}
+
+// spawnScareEntity creates the creature of a triggered scare at its start position
+// and records it as owned by the scare, so it is removed when the scare ends.
+// Must be called with fd.mutex held.
+func (fd *Director) spawnScareEntity(scare *ScareEvent) {
+	entityType, exists := scareEntityTypes[scare.EntityEffect]
+	if fd.entitySpawner == nil || !exists {
+		return
+	}
+
+	entity := fd.entitySpawner.SpawnEffectEntity(entityType, scare.StartPosition)
+	if entity == nil {
+		return
+	}
+	scare.EntityID = entity.ID
+	scare.AddOwnedEntity(entity.ID)
+}
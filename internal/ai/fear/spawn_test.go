@@ -0,0 +1,94 @@
+package fear
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// fakeSpawner adds a bare entity tagged with the requested type to the world
+type fakeSpawner struct {
+	world *ecs.World
+	types []string
+}
+
+func (fs *fakeSpawner) SpawnEffectEntity(entityType string, position ecs.Vector3) *ecs.Entity {
+	fs.types = append(fs.types, entityType)
+
+	entity := ecs.NewEntity()
+	entity.AddComponent(ecs.NewTransformComponent(position))
+	entity.AddTag(ecs.RegisterTag(entityType, "species", ""))
+	fs.world.AddEntity(entity)
+	return entity
+}
+
+// inWorld reports whether the entity is still in the world
+func inWorld(world *ecs.World, id ecs.EntityID) bool {
+	_, exists := world.GetEntity(id)
+	return exists
+}
+
+func TestStalkerScareRemovesItsEntityWhenItEnds(t *testing.T) {
+	world := ecs.NewWorld()
+	fd := NewDirector(world, t.TempDir())
+	clock := engine.NewFakeClock(time.Date(2026, 1, 1, 23, 0, 0, 0, time.UTC))
+	fd.SetClock(clock)
+	spawner := &fakeSpawner{world: world}
+	fd.SetEntitySpawner(spawner)
+
+	scare := &ScareEvent{
+		ID:            "stalker_1",
+		Type:          "entity",
+		Subtype:       "stalker",
+		Intensity:     0.7,
+		Duration:      30,
+		EntityEffect:  "stalker",
+		StartPosition: ecs.Vector3{X: 10},
+		SuccessRating: clock.Now(),
+	}
+	fd.mutex.Lock()
+	triggered := fd.triggerScare(scare)
+	fd.mutex.Unlock()
+	if !triggered {
+		t.Fatalf("stalker scare was not triggered")
+	}
+
+	owned := scare.OwnedEntities()
+	if len(spawner.types) != 1 || len(owned) != 1 {
+		t.Fatalf("scare spawned %v and owns %v, want one stalker", spawner.types, owned)
+	}
+	stalker := owned[0]
+	if !inWorld(world, stalker) {
+		t.Fatalf("stalker %v is not in the world", stalker)
+	}
+
+	clock.Advance(10 * time.Second)
+	fd.updateActiveScares(10)
+	if !inWorld(world, stalker) {
+		t.Errorf("stalker removed while the scare is still running")
+	}
+
+	clock.Advance(20 * time.Second)
+	fd.updateActiveScares(20)
+	if inWorld(world, stalker) {
+		t.Errorf("stalker still in the world after the scare ended")
+	}
+}
+
+func TestAmbientScareSpawnsNothing(t *testing.T) {
+	world := ecs.NewWorld()
+	fd := NewDirector(world, t.TempDir())
+	spawner := &fakeSpawner{world: world}
+	fd.SetEntitySpawner(spawner)
+
+	scare := &ScareEvent{ID: "whisper_1", Type: "ambient", Duration: 5, EntityEffect: "none"}
+	fd.mutex.Lock()
+	fd.triggerScare(scare)
+	fd.mutex.Unlock()
+
+	if len(spawner.types) != 0 || len(scare.OwnedEntities()) != 0 {
+		t.Errorf("ambient scare spawned %v", spawner.types)
+	}
+}
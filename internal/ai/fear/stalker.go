@@ -0,0 +1,24 @@
+package fear
+
+import "echo-taiga/internal/engine/ecs"
+
+// SummonStalker triggers a stalker scare aimed at a position right away, ignoring the
+// scare cooldown (e.g. when forbidden knowledge draws something to the player).
+// The intensity scales the template's. Returns false if no stalker template is
+// loaded or the scare cannot be placed in loaded chunks.
+func (fd *Director) SummonStalker(position ecs.Vector3, intensity float64) bool {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	for _, template := range fd.scareTemplates {
+		if template.Subtype != "stalker" {
+			continue
+		}
+
+		scare := fd.generateScareFromTemplate(&template, position)
+		scare.Intensity *= intensity
+		scare.TargetPosition = fd.loadedPosition(position)
+		return fd.triggerScare(scare)
+	}
+	return false
+}
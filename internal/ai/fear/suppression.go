@@ -0,0 +1,222 @@
+package fear
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Suppression settings
+const (
+	DefaultSuppressionDuration = 2 * time.Minute // Tokens expire after this long unless told otherwise
+	SuppressionReleaseDelay    = 3 * time.Second // Delay before a queued scare fires after the last release
+)
+
+// SuppressOptions controls what a suppression token still lets through
+type SuppressOptions struct {
+	MaxDuration  time.Duration // The token expires after this long; 0 means DefaultSuppressionDuration
+	QueueBest    bool          // Keep the best missed opportunity and fire it shortly after release
+	AllowAmbient bool          // Ambient sounds and shadows may still play
+}
+
+// Suppression is a held suppression token. Release it when the scripted moment ends.
+type Suppression struct {
+	director *Director
+	id       int
+	once     sync.Once
+}
+
+// Release ends the suppression. Releasing more than once is harmless.
+func (s *Suppression) Release() {
+	s.once.Do(func() {
+		s.director.releaseSuppression(s.id)
+	})
+}
+
+// suppressionToken is the director's record of a held suppression
+type suppressionToken struct {
+	reason   string
+	options  SuppressOptions
+	acquired time.Time
+	expires  time.Time
+}
+
+// SuppressionView describes a held suppression token for the UI and debugging
+type SuppressionView struct {
+	Reason       string
+	Remaining    float64 // Seconds until the token expires on its own
+	QueueBest    bool
+	AllowAmbient bool
+}
+
+// Suppress keeps scares from interrupting a scripted moment (a ritual, a vision,
+// a cinematic) until the returned token is released or expires. While any token
+// is held, scares and ForceScare are blocked and tension cannot reach its peak phase.
+func (fd *Director) Suppress(reason string, options SuppressOptions) *Suppression {
+	if options.MaxDuration <= 0 {
+		options.MaxDuration = DefaultSuppressionDuration
+	}
+
+	fd.mutex.Lock()
+	defer fd.unlockNotifyingPhase(fd.tensionPhase)
+
+	now := fd.clock.Now()
+	fd.nextSuppressionID++
+	id := fd.nextSuppressionID
+	fd.suppressions[id] = &suppressionToken{
+		reason:   reason,
+		options:  options,
+		acquired: now,
+		expires:  now.Add(options.MaxDuration),
+	}
+
+	// A peak already under way gives way to the scripted moment
+	if fd.tensionPhase == "peak" {
+		fd.tensionPhase = "build"
+		fd.peakHeld = true
+	}
+
+	return &Suppression{director: fd, id: id}
+}
+
+// ActiveSuppressions returns the suppression tokens currently held, oldest first
+func (fd *Director) ActiveSuppressions() []SuppressionView {
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+
+	return fd.suppressionViews()
+}
+
+// releaseSuppression drops a token; the last release arms the queued scare
+func (fd *Director) releaseSuppression(id int) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	if _, held := fd.suppressions[id]; !held {
+		return
+	}
+	delete(fd.suppressions, id)
+	if len(fd.suppressions) == 0 {
+		fd.suppressionEnded = fd.clock.Now()
+	}
+}
+
+// suppressed drops expired tokens and reports whether any token is still held
+// and whether all held tokens let ambient scares through. Must be called with the mutex held.
+func (fd *Director) suppressed() (active bool, ambientAllowed bool) {
+	if len(fd.suppressions) == 0 {
+		return false, true
+	}
+
+	now := fd.clock.Now()
+	ambientAllowed = true
+	for id, token := range fd.suppressions {
+		if !now.Before(token.expires) {
+			// Forgotten releases must not silence the director forever
+			delete(fd.suppressions, id)
+			continue
+		}
+		ambientAllowed = ambientAllowed && token.options.AllowAmbient
+	}
+
+	if len(fd.suppressions) == 0 {
+		fd.suppressionEnded = now
+		return false, true
+	}
+	return true, ambientAllowed
+}
+
+// queuesBest checks whether any held token asks to keep the best missed opportunity.
+// Must be called with the mutex held.
+func (fd *Director) queuesBest() bool {
+	for _, token := range fd.suppressions {
+		if token.options.QueueBest {
+			return true
+		}
+	}
+	return false
+}
+
+// holdOpportunities filters the opportunities while scares are suppressed: the
+// best one is queued if asked for, and only ambient scares remain if every token
+// allows them. Must be called with the mutex held.
+func (fd *Director) holdOpportunities(ambientAllowed bool) {
+	if fd.queuesBest() {
+		for _, opportunity := range fd.scareOpportunities {
+			if fd.queuedOpportunity == nil || opportunity.EstimatedValue > fd.queuedOpportunity.EstimatedValue {
+				queued := opportunity
+				fd.queuedOpportunity = &queued
+			}
+		}
+	}
+
+	kept := fd.scareOpportunities[:0]
+	if ambientAllowed {
+		for _, opportunity := range fd.scareOpportunities {
+			types := ambientScareTypes(opportunity.ScareTypes)
+			if len(types) > 0 {
+				opportunity.ScareTypes = types
+				kept = append(kept, opportunity)
+			}
+		}
+	}
+	fd.scareOpportunities = kept
+}
+
+// releaseQueuedOpportunity puts the opportunity queued during a suppression back
+// in front once the release delay has passed. Must be called with the mutex held.
+func (fd *Director) releaseQueuedOpportunity() {
+	if fd.queuedOpportunity == nil {
+		return
+	}
+	now := fd.clock.Now()
+	if now.Sub(fd.suppressionEnded) < SuppressionReleaseDelay {
+		return
+	}
+
+	queued := *fd.queuedOpportunity
+	fd.queuedOpportunity = nil
+	queued.Timestamp = now
+	queued.OptimalTiming = 0
+	fd.scareOpportunities = append([]ScareOpportunity{queued}, fd.scareOpportunities...)
+}
+
+// suppressionViews describes the held tokens. Must be called with the mutex held.
+func (fd *Director) suppressionViews() []SuppressionView {
+	now := fd.clock.Now()
+	tokens := make([]*suppressionToken, 0, len(fd.suppressions))
+	for _, token := range fd.suppressions {
+		if now.Before(token.expires) {
+			tokens = append(tokens, token)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].acquired.Before(tokens[j].acquired) })
+
+	views := make([]SuppressionView, 0, len(tokens))
+	for _, token := range tokens {
+		views = append(views, SuppressionView{
+			Reason:       token.reason,
+			Remaining:    token.expires.Sub(now).Seconds(),
+			QueueBest:    token.options.QueueBest,
+			AllowAmbient: token.options.AllowAmbient,
+		})
+	}
+	return views
+}
+
+// isAmbientScare checks whether a scare type is an ambient sound or sight
+func isAmbientScare(scareType string) bool {
+	return strings.HasPrefix(scareType, "ambient")
+}
+
+// ambientScareTypes returns the ambient scare types in the list
+func ambientScareTypes(types []string) []string {
+	ambient := make([]string, 0, len(types))
+	for _, scareType := range types {
+		if isAmbientScare(scareType) {
+			ambient = append(ambient, scareType)
+		}
+	}
+	return ambient
+}
@@ -0,0 +1,126 @@
+package fear
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newSuppressionTestDirector creates a director on a fake clock with an ambient and
+// a stalker scare, recording the scares it triggers
+func newSuppressionTestDirector(t *testing.T) (*Director, *engine.FakeClock, *[]string) {
+	t.Helper()
+
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	clock := engine.NewFakeClock(time.Date(2026, 1, 1, 22, 0, 0, 0, time.UTC))
+	fd.SetClock(clock)
+	fd.AddScareTemplate(ScareEvent{Type: "ambient_whisper", Intensity: 0.3, Duration: 5, Cooldown: 1})
+	fd.AddScareTemplate(ScareEvent{Type: "stalker", Intensity: 0.8, Duration: 5, Cooldown: 1})
+
+	triggered := make([]string, 0)
+	fd.OnScareTriggered = func(scare ScareEvent) { triggered = append(triggered, scare.Type) }
+	return fd, clock, &triggered
+}
+
+// offerOpportunity gives the director an opportunity to play a scare right away
+func offerOpportunity(fd *Director, scareType string, value float64) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.scareOpportunities = append(fd.scareOpportunities, ScareOpportunity{
+		Timestamp:      fd.clock.Now(),
+		ScareTypes:     []string{scareType},
+		EstimatedValue: value,
+	})
+}
+
+func TestQueuedScareFiresShortlyAfterRelease(t *testing.T) {
+	fd, clock, triggered := newSuppressionTestDirector(t)
+	suppression := fd.Suppress("ritual", SuppressOptions{QueueBest: true})
+
+	offerOpportunity(fd, "stalker", 0.6)
+	offerOpportunity(fd, "stalker", 0.9)
+	fd.triggerScares()
+	if fd.ForceScare("stalker") || len(*triggered) != 0 {
+		t.Fatalf("scares %v played during the suppression", *triggered)
+	}
+
+	suppression.Release()
+	suppression.Release() // A second release is harmless
+	fd.triggerScares()
+	if len(*triggered) != 0 {
+		t.Fatalf("queued scare fired before the release delay: %v", *triggered)
+	}
+
+	clock.Advance(SuppressionReleaseDelay)
+	fd.triggerScares()
+	if len(*triggered) != 1 || (*triggered)[0] != "stalker" {
+		t.Fatalf("after the release delay the director played %v, want the queued stalker", *triggered)
+	}
+	fd.mutex.RLock()
+	queued := fd.queuedOpportunity
+	fd.mutex.RUnlock()
+	if queued != nil {
+		t.Errorf("opportunity %+v still queued after it fired", *queued)
+	}
+}
+
+func TestSuppressionWithoutQueueDropsMissedScares(t *testing.T) {
+	fd, clock, triggered := newSuppressionTestDirector(t)
+	suppression := fd.Suppress("vision", SuppressOptions{})
+
+	offerOpportunity(fd, "stalker", 0.9)
+	fd.triggerScares()
+	suppression.Release()
+
+	clock.Advance(SuppressionReleaseDelay)
+	fd.triggerScares()
+	if len(*triggered) != 0 {
+		t.Errorf("missed scares %v played after a suppression that did not queue them", *triggered)
+	}
+}
+
+func TestForgottenSuppressionExpires(t *testing.T) {
+	fd, clock, triggered := newSuppressionTestDirector(t)
+	fd.Suppress("cinematic", SuppressOptions{MaxDuration: 30 * time.Second})
+
+	if views := fd.ActiveSuppressions(); len(views) != 1 || views[0].Reason != "cinematic" || views[0].Remaining != 30 {
+		t.Fatalf("active suppressions %+v, want the cinematic with 30 seconds left", views)
+	}
+	if fd.ForceScare("stalker") {
+		t.Fatalf("forced scare played during the suppression")
+	}
+
+	clock.Advance(30 * time.Second)
+	if views := fd.ActiveSuppressions(); len(views) != 0 {
+		t.Errorf("expired suppressions still listed: %+v", views)
+	}
+	if !fd.ForceScare("stalker") || len(*triggered) != 1 {
+		t.Errorf("forced scare blocked after the suppression expired (played %v)", *triggered)
+	}
+}
+
+func TestAmbientScaresPassOnlyWhenEveryTokenAllowsThem(t *testing.T) {
+	fd, _, triggered := newSuppressionTestDirector(t)
+	lenient := fd.Suppress("vision", SuppressOptions{AllowAmbient: true})
+
+	if fd.ForceScare("stalker") {
+		t.Errorf("stalker played during an ambient-only suppression")
+	}
+	if !fd.ForceScare("ambient_whisper") {
+		t.Errorf("ambient scare blocked by a token that allows it")
+	}
+
+	strict := fd.Suppress("ritual", SuppressOptions{})
+	lenient.Release()
+	if fd.ForceScare("ambient_whisper") {
+		t.Errorf("ambient scare played past a token that does not allow it")
+	}
+	strict.Release()
+
+	if len(*triggered) != 1 || (*triggered)[0] != "ambient_whisper" {
+		t.Errorf("played %v, want only the allowed ambient scare", *triggered)
+	}
+}
@@ -0,0 +1,47 @@
+//go:build dev
+
+package fear
+
+import (
+	"math/rand"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// syntheticExtent is the side of the square synthetic actions are scattered over
+const syntheticExtent = 256.0
+
+// PopulateSynthetic fills the director with synthetic state for profiling Update:
+// a player entity at the origin and a full action history spread over the last
+// minutes (dev builds only)
+func (fd *Director) PopulateSynthetic(seed int64) error {
+	ecs.RegisterTag(TagPlayer, "actor", "")
+	if _, err := ecs.PopulateSynthetic(fd.world, 1, 0, seed, TagPlayer); err != nil {
+		return err
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	types := []ActionType{ActionMoving, ActionRunning, ActionHiding, ActionExploring, ActionInspecting, ActionResting}
+
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	now := fd.clock.Now()
+	fd.actionHistory = make([]PlayerAction, 0, fd.maxHistorySize)
+	for i := 0; i < fd.maxHistorySize; i++ {
+		fd.actionHistory = append(fd.actionHistory, PlayerAction{
+			Type:      types[rng.Intn(len(types))],
+			Timestamp: now.Add(-time.Duration(fd.maxHistorySize-i) * time.Second),
+			Position: ecs.Vector3{
+				X: (rng.Float64() - 0.5) * syntheticExtent,
+				Z: (rng.Float64() - 0.5) * syntheticExtent,
+			},
+			Speed:      rng.Float64() * 6,
+			LightLevel: rng.Float64(),
+			TimeOfDay:  rng.Float64(),
+		})
+	}
+
+	return nil
+}
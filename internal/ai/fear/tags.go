@@ -0,0 +1,12 @@
+package fear
+
+import "echo-taiga/internal/engine/ecs"
+
+// Entity tags queried by the fear director
+const (
+	TagPlayer = "player"
+)
+
+func init() {
+	ecs.DeclareTagQuery(TagPlayer)
+}
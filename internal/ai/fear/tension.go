@@ -0,0 +1,55 @@
+package fear
+
+// Tension phases, in the order the tension curve usually moves through them
+var TensionPhases = []string{"build", "peak", "release", "calm"}
+
+// GetTensionPhase returns the current tension phase: build, peak, release or calm
+func (fd *Director) GetTensionPhase() string {
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+
+	return fd.tensionPhase
+}
+
+// ForceTensionPhase switches the tension phase for scripted sequences. The phase
+// holds until the tension level next changes; a peak is held back while scares are
+// suppressed. Returns false for an unknown phase.
+func (fd *Director) ForceTensionPhase(phase string) bool {
+	known := false
+	for _, p := range TensionPhases {
+		if p == phase {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return false
+	}
+
+	fd.mutex.Lock()
+	defer fd.unlockNotifyingPhase(fd.tensionPhase)
+
+	// A scripted peak waits for suppressions to end, like a natural one
+	if active, _ := fd.suppressed(); active && phase == "peak" {
+		fd.peakHeld = true
+		return true
+	}
+
+	if phase != fd.tensionPhase {
+		fd.tensionPhase = phase
+		fd.lastTensionChange = fd.clock.Now() // A forced peak lasts no longer than a natural one
+	}
+	return true
+}
+
+// unlockNotifyingPhase releases the mutex and, if the tension phase is no longer
+// previous, reports the change. The callback runs outside the lock.
+func (fd *Director) unlockNotifyingPhase(previous string) {
+	phase := fd.tensionPhase
+	callback := fd.OnTensionPhaseChanged
+	fd.mutex.Unlock()
+
+	if callback != nil && phase != previous {
+		callback(previous, phase)
+	}
+}
@@ -0,0 +1,119 @@
+package fear
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// phaseChange is one reported tension phase change
+type phaseChange struct {
+	from, to string
+}
+
+// recordPhases records the phase changes of a director. The callback reads the
+// phase back, which would deadlock if it ran under the director's lock.
+func recordPhases(fd *Director) *[]phaseChange {
+	changes := make([]phaseChange, 0)
+	fd.OnTensionPhaseChanged = func(oldPhase, newPhase string) {
+		if current := fd.GetTensionPhase(); current != newPhase {
+			changes = append(changes, phaseChange{oldPhase, "reported " + newPhase + " while " + current})
+			return
+		}
+		changes = append(changes, phaseChange{oldPhase, newPhase})
+	}
+	return &changes
+}
+
+// withinDeadline runs f, failing the test if it does not return (a callback under the lock)
+func withinDeadline(t *testing.T, f func()) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		f()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("did not return: the phase callback ran under the director's lock")
+	}
+}
+
+// driveTensionToPeak raises the target tension and updates until the peak or a timeout
+func driveTensionToPeak(fd *Director) {
+	fd.mutex.Lock()
+	fd.targetTension = 1.0
+	fd.mutex.Unlock()
+
+	for i := 0; i < 100 && fd.GetTensionPhase() != "peak"; i++ {
+		fd.updateTension(1.0)
+	}
+}
+
+func TestRisingTensionReportsBuildThenPeak(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	changes := recordPhases(fd)
+
+	withinDeadline(t, func() { driveTensionToPeak(fd) })
+
+	if fd.GetTensionPhase() != "peak" {
+		t.Fatalf("tension phase %q after rising to the top, want peak", fd.GetTensionPhase())
+	}
+	if len(*changes) != 1 || (*changes)[0] != (phaseChange{"build", "peak"}) {
+		t.Errorf("phase changes %v, want only build -> peak", *changes)
+	}
+}
+
+func TestForceTensionPhaseOverridesAndNotifies(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	changes := recordPhases(fd)
+	driveTensionToPeak(fd)
+	*changes = (*changes)[:0]
+
+	withinDeadline(t, func() {
+		if !fd.ForceTensionPhase("release") {
+			t.Errorf("ForceTensionPhase(release) rejected")
+		}
+	})
+	if fd.GetTensionPhase() != "release" {
+		t.Errorf("tension phase %q, want the forced release", fd.GetTensionPhase())
+	}
+
+	// Forcing the current phase or an unknown one reports nothing
+	fd.ForceTensionPhase("release")
+	if fd.ForceTensionPhase("panic") {
+		t.Errorf("unknown phase accepted")
+	}
+	if len(*changes) != 1 || (*changes)[0] != (phaseChange{"peak", "release"}) {
+		t.Errorf("phase changes %v, want only peak -> release", *changes)
+	}
+}
+
+func TestForcedPeakWaitsForSuppression(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	changes := recordPhases(fd)
+
+	fd.mutex.Lock()
+	fd.tensionLevel = 3
+	fd.targetTension = 0.8
+	fd.tensionCurve = 0.8
+	fd.mutex.Unlock()
+
+	suppression := fd.Suppress("vision", SuppressOptions{})
+	fd.ForceTensionPhase("peak")
+	if fd.GetTensionPhase() == "peak" || len(*changes) != 0 {
+		t.Fatalf("forced peak arrived during a suppression (changes %v)", *changes)
+	}
+
+	suppression.Release()
+	fd.updateTension(0.1)
+	if fd.GetTensionPhase() != "peak" {
+		t.Errorf("tension phase %q after the suppression ended, want the held peak", fd.GetTensionPhase())
+	}
+	if n := len(*changes); n == 0 || (*changes)[n-1].to != "peak" {
+		t.Errorf("phase changes %v, want the peak reported", *changes)
+	}
+}
@@ -0,0 +1,29 @@
+package fear
+
+import "math"
+
+// threatTensionWeight is how strongly the threat level pulls tension up
+const threatTensionWeight = 0.8
+
+// ThreatSource reports the current danger to the player (e.g. threat.System)
+type ThreatSource interface {
+	GetThreatLevel() float64
+	IsPlayerPursued() bool
+}
+
+// SetThreatSource sets the system whose threat level feeds into tension
+func (fd *Director) SetThreatSource(source ThreatSource) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.threatSource = source
+}
+
+// threatAdjustedTarget returns the tension target raised by real danger.
+// Must be called with the mutex held.
+func (fd *Director) threatAdjustedTarget() float64 {
+	if fd.threatSource == nil {
+		return fd.targetTension
+	}
+	return math.Max(fd.targetTension, math.Min(1.0, fd.threatSource.GetThreatLevel()*threatTensionWeight))
+}
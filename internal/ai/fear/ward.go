@@ -0,0 +1,78 @@
+package fear
+
+import (
+	"math"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// WardZone is a temporary safe zone (e.g. from a warding ritual) that dampens scares
+type WardZone struct {
+	Center    ecs.Vector3 // Center of the warded area
+	Radius    float64     // Radius of the warded area
+	Strength  float64     // 0-1: How much scare intensity is reduced inside
+	ExpiresAt time.Time   // When the ward fades
+}
+
+// AddWardZone adds a safe zone that reduces scare intensity for the given duration (seconds)
+func (fd *Director) AddWardZone(center ecs.Vector3, radius, strength, duration float64) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.wardZones = append(fd.wardZones, WardZone{
+		Center:    center,
+		Radius:    radius,
+		Strength:  math.Max(0.0, math.Min(1.0, strength)),
+		ExpiresAt: fd.clock.Now().Add(time.Duration(duration * float64(time.Second))),
+	})
+}
+
+// GetWardZones returns the currently active ward zones
+func (fd *Director) GetWardZones() []WardZone {
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+
+	result := make([]WardZone, len(fd.wardZones))
+	copy(result, fd.wardZones)
+	return result
+}
+
+// GetWardStrength returns the strongest ward covering a position (0 if none)
+func (fd *Director) GetWardStrength(position ecs.Vector3) float64 {
+	fd.mutex.RLock()
+	defer fd.mutex.RUnlock()
+
+	return fd.wardStrengthAt(position)
+}
+
+// wardStrengthAt returns the strongest active ward at a position. Must be called with the mutex held.
+func (fd *Director) wardStrengthAt(position ecs.Vector3) float64 {
+	now := fd.clock.Now()
+	strength := 0.0
+
+	for _, ward := range fd.wardZones {
+		if now.After(ward.ExpiresAt) {
+			continue
+		}
+		if position.Distance(ward.Center) <= ward.Radius {
+			strength = math.Max(strength, ward.Strength)
+		}
+	}
+
+	return strength
+}
+
+// expireWardZones removes faded wards. Must be called with the mutex held.
+func (fd *Director) expireWardZones() {
+	now := fd.clock.Now()
+	active := fd.wardZones[:0]
+
+	for _, ward := range fd.wardZones {
+		if now.Before(ward.ExpiresAt) {
+			active = append(active, ward)
+		}
+	}
+
+	fd.wardZones = active
+}
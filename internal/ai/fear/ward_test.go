@@ -0,0 +1,28 @@
+package fear
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+func TestWardZoneFadesAfterItsDuration(t *testing.T) {
+	fd := NewDirector(ecs.NewWorld(), t.TempDir())
+	clock := engine.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	fd.SetClock(clock)
+
+	fd.AddWardZone(ecs.Vector3{}, 15, 0.6, 300)
+	if got := fd.GetWardStrength(ecs.Vector3{X: 10}); got != 0.6 {
+		t.Errorf("ward strength inside the zone = %v, want 0.6", got)
+	}
+	if got := fd.GetWardStrength(ecs.Vector3{X: 20}); got != 0 {
+		t.Errorf("ward strength outside the zone = %v, want 0", got)
+	}
+
+	clock.Advance(301 * time.Second)
+	if got := fd.GetWardStrength(ecs.Vector3{}); got != 0 {
+		t.Errorf("ward strength after 301 seconds = %v, want it faded", got)
+	}
+}
@@ -0,0 +1,228 @@
+package threat
+
+import (
+	"math"
+	"sync"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// Sanity bands used for threshold-crossing notifications, from sound mind to breaking
+const (
+	SanityStable    = 0
+	SanityUneasy    = 1
+	SanityDisturbed = 2
+	SanityBreaking  = 3
+)
+
+// sanityThresholds are the upper bounds of SanityUneasy, SanityDisturbed and SanityBreaking (0-100 scale)
+var sanityThresholds = []float64{75, 50, 25}
+
+// ScareSource reports how strongly active scares press on a position (e.g. fear.Director)
+type ScareSource interface {
+	ScareIntensityAt(position ecs.Vector3) float64
+}
+
+// LevelSource reports the combined threat level to the player (e.g. System)
+type LevelSource interface {
+	GetThreatLevel() float64
+}
+
+// Refuge reports safe, lit places where the mind can rest (e.g. world.World)
+type Refuge interface {
+	IsRefuge(position ecs.Vector3) bool
+}
+
+// SanityConfig controls how the player's sanity drains and recovers.
+// Rates are in sanity points per minute at full strength (0-100 scale).
+type SanityConfig struct {
+	AnomalyRange    float64 // Anomalies without a light radius affect the player within this range
+	AnomalyDrain    float64 // Drain next to a fully abnormal anomaly
+	EffectDrain     float64 // Drain inside a metamorphosis effect at full intensity
+	EffectThreshold float64 // Effects weaker than this at the player's position are ignored
+	ScareDrain      float64 // Drain during a scare at full intensity
+	ThreatDrain     float64 // Drain at the highest threat level
+	Recovery        float64 // Recovery in a refuge while nothing drains sanity
+}
+
+// DefaultSanityConfig returns the default sanity settings
+func DefaultSanityConfig() SanityConfig {
+	return SanityConfig{
+		AnomalyRange:    10.0,
+		AnomalyDrain:    6.0,
+		EffectDrain:     4.0,
+		EffectThreshold: 0.5,
+		ScareDrain:      5.0,
+		ThreatDrain:     3.0,
+		Recovery:        4.0,
+	}
+}
+
+// SanitySystem drains the player's sanity near anomalies, strong metamorphosis
+// effects and active scares and under real threat, and restores it in refuges.
+// It is the only system that changes the player's sanity for these sources.
+type SanitySystem struct {
+	world   *ecs.World
+	effects EffectSource
+	scares  ScareSource
+	threats LevelSource
+	refuge  Refuge
+
+	Config SanityConfig
+
+	band     int
+	pressure float64
+
+	// Called when the player's sanity moves into a different band
+	OnSanityThreshold func(previousBand, band int, sanity float64)
+
+	mutex sync.RWMutex
+}
+
+// NewSanitySystem creates a sanity system. effects, scares, threats and refuge may be nil.
+func NewSanitySystem(world *ecs.World, effects EffectSource, scares ScareSource, threats LevelSource, refuge Refuge) *SanitySystem {
+	return &SanitySystem{
+		world:   world,
+		effects: effects,
+		scares:  scares,
+		threats: threats,
+		refuge:  refuge,
+		Config:  DefaultSanityConfig(),
+	}
+}
+
+// RequiredComponents implements ecs.System
+func (ss *SanitySystem) RequiredComponents() []ecs.ComponentID {
+	return []ecs.ComponentID{ecs.TransformComponentID, ecs.SurvivalComponentID}
+}
+
+// Update drains or restores the player's sanity
+func (ss *SanitySystem) Update(deltaTime float64) {
+	players := ss.world.GetEntitiesWithTag(TagPlayer)
+	if len(players) == 0 {
+		return
+	}
+	player := players[0]
+
+	playerPos, ok := entityPosition(player)
+	if !ok {
+		return
+	}
+	survivalComp, has := player.GetComponent(ecs.SurvivalComponentID)
+	if !has {
+		return
+	}
+	survival := survivalComp.(*ecs.SurvivalComponent)
+
+	// Drain from every source adds up; recovery only comes when nothing drains
+	drain := ss.anomalyDrain(playerPos) + ss.effectDrain(playerPos) + ss.scareDrain(playerPos) + ss.threatDrain()
+	rate := -drain
+	if drain <= 0 && ss.refuge != nil && ss.refuge.IsRefuge(playerPos) {
+		rate = ss.Config.Recovery
+	}
+	survival.SanityLevel = math.Max(0, math.Min(100, survival.SanityLevel+rate*deltaTime/60.0))
+
+	band := sanityBandFor(survival.SanityLevel)
+
+	ss.mutex.Lock()
+	previousBand := ss.band
+	ss.band = band
+	ss.pressure = drain
+	ss.mutex.Unlock()
+
+	if band != previousBand && ss.OnSanityThreshold != nil {
+		ss.OnSanityThreshold(previousBand, band, survival.SanityLevel)
+	}
+}
+
+// GetSanityBand returns the player's current sanity band
+func (ss *SanitySystem) GetSanityBand() int {
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+
+	return ss.band
+}
+
+// GetSanityPressure returns the sanity drained per minute on the last update
+func (ss *SanitySystem) GetSanityPressure() float64 {
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+
+	return ss.pressure
+}
+
+// anomalyDrain sums the drain of anomalies around the player. An anomaly
+// reaches as far as its light and drains more the closer and more abnormal it is.
+func (ss *SanitySystem) anomalyDrain(playerPos ecs.Vector3) float64 {
+	drain := 0.0
+	for _, entity := range ss.world.GetEntitiesWithTag(TagAnomaly) {
+		position, ok := entityPosition(entity)
+		if !ok {
+			continue
+		}
+
+		reach := ss.Config.AnomalyRange
+		if lightComp, has := entity.GetComponent(ecs.LightComponentID); has {
+			if lightRange := lightComp.(*ecs.LightComponent).Range; lightRange > 0 {
+				reach = lightRange
+			}
+		}
+		distance := position.Distance(playerPos)
+		if reach <= 0 || distance > reach {
+			continue
+		}
+
+		abnormality := 1.0
+		if metaComp, has := entity.GetComponent(ecs.MetamorphicComponentID); has {
+			abnormality = metaComp.(*ecs.MetamorphicComponent).AbnormalityIndex
+		}
+
+		drain += ss.Config.AnomalyDrain * abnormality * (1.0 - distance/reach)
+	}
+	return drain
+}
+
+// effectDrain sums the drain of strong metamorphosis effects covering the player
+func (ss *SanitySystem) effectDrain(playerPos ecs.Vector3) float64 {
+	if ss.effects == nil {
+		return 0
+	}
+
+	drain := 0.0
+	for _, effect := range ss.effects.GetActiveEffects() {
+		intensity := effect.EffectiveIntensityFor(playerPos)
+		if intensity < ss.Config.EffectThreshold || intensity <= 0 {
+			continue
+		}
+		drain += ss.Config.EffectDrain * intensity * float64(effect.Order) / float64(metamorphosis.OrderFifth)
+	}
+	return drain
+}
+
+// scareDrain returns the drain of active scares reaching the player
+func (ss *SanitySystem) scareDrain(playerPos ecs.Vector3) float64 {
+	if ss.scares == nil {
+		return 0
+	}
+	return ss.Config.ScareDrain * math.Max(0, math.Min(1, ss.scares.ScareIntensityAt(playerPos)))
+}
+
+// threatDrain returns the drain of the threats the player faces
+func (ss *SanitySystem) threatDrain() float64 {
+	if ss.threats == nil {
+		return 0
+	}
+	return ss.Config.ThreatDrain * math.Max(0, math.Min(1, ss.threats.GetThreatLevel()))
+}
+
+// sanityBandFor returns the band of a sanity level
+func sanityBandFor(sanity float64) int {
+	band := SanityStable
+	for i, threshold := range sanityThresholds {
+		if sanity < threshold {
+			band = i + 1
+		}
+	}
+	return band
+}
@@ -0,0 +1,159 @@
+package threat
+
+import (
+	"image/color"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// fakeRefuge marks everything within radius of the origin as a lit, safe place
+type fakeRefuge struct {
+	radius float64
+}
+
+func (r fakeRefuge) IsRefuge(position ecs.Vector3) bool {
+	return position.Distance(ecs.Vector3{}) <= r.radius
+}
+
+// addSanityPlayer adds a player with full sanity at position
+func addSanityPlayer(world *ecs.World, position ecs.Vector3) (*ecs.TransformComponent, *ecs.SurvivalComponent) {
+	transform := ecs.NewTransformComponent(position)
+	survival := ecs.NewSurvivalComponent()
+
+	player := ecs.NewEntity()
+	player.AddComponent(transform)
+	player.AddComponent(survival)
+	player.AddTag(ecs.RegisterTag(TagPlayer, "actor", ""))
+	world.AddEntity(player)
+	return transform, survival
+}
+
+// addMajorAnomaly adds a fully abnormal anomaly whose light reaches radius
+func addMajorAnomaly(world *ecs.World, position ecs.Vector3, radius float64) {
+	metamorphic := ecs.NewMetamorphicComponent(0)
+	metamorphic.AbnormalityIndex = 1.0
+
+	anomaly := ecs.NewEntity()
+	anomaly.AddComponent(ecs.NewTransformComponent(position))
+	anomaly.AddComponent(ecs.NewLightComponent(color.RGBA{R: 120, G: 40, B: 200, A: 255}, 1.0, radius))
+	anomaly.AddComponent(metamorphic)
+	anomaly.AddTag(ecs.RegisterTag(TagAnomaly, "mystic", ""))
+	world.AddEntity(anomaly)
+}
+
+// runMinutes updates the system once a second for the given number of minutes
+func runMinutes(ss *SanitySystem, minutes int) {
+	for i := 0; i < minutes*60; i++ {
+		ss.Update(1.0)
+	}
+}
+
+func TestSanityDrainsNearAnomalyAndRecoversInRefuge(t *testing.T) {
+	world := ecs.NewWorld()
+	anomalyPos := ecs.Vector3{X: 200}
+	addMajorAnomaly(world, anomalyPos, 20)
+	transform, survival := addSanityPlayer(world, ecs.Vector3{X: 195})
+
+	ss := NewSanitySystem(world, nil, nil, nil, fakeRefuge{radius: 30})
+	crossings := make([]int, 0)
+	ss.OnSanityThreshold = func(previousBand, band int, sanity float64) {
+		crossings = append(crossings, band)
+	}
+
+	runMinutes(ss, 10)
+	drained := survival.SanityLevel
+	if drained >= 100-10*ss.Config.AnomalyDrain*0.5 {
+		t.Fatalf("sanity after 10 minutes next to the anomaly = %.1f, want it drained", drained)
+	}
+	if ss.GetSanityPressure() <= 0 {
+		t.Errorf("pressure = %v next to the anomaly, want positive", ss.GetSanityPressure())
+	}
+	if ss.GetSanityBand() == SanityStable || len(crossings) == 0 {
+		t.Errorf("band = %d with crossings %v, want the player past the stable band", ss.GetSanityBand(), crossings)
+	}
+
+	// Outside the refuge and out of the anomaly's reach sanity holds
+	transform.Position = ecs.Vector3{X: 100}
+	runMinutes(ss, 5)
+	if survival.SanityLevel != drained {
+		t.Errorf("sanity away from anomalies and refuges = %.1f, want it to stay at %.1f", survival.SanityLevel, drained)
+	}
+
+	// In the lit refuge it recovers at the configured rate
+	transform.Position = ecs.Vector3{X: 5}
+	runMinutes(ss, 5)
+	want := drained + 5*ss.Config.Recovery
+	if diff := survival.SanityLevel - want; diff > 1e-6 || diff < -1e-6 {
+		t.Errorf("sanity after 5 minutes in the refuge = %.2f, want %.2f", survival.SanityLevel, want)
+	}
+	if ss.GetSanityPressure() != 0 {
+		t.Errorf("pressure in the refuge = %v, want 0", ss.GetSanityPressure())
+	}
+}
+
+func TestAnomalyBlocksRecoveryInRefuge(t *testing.T) {
+	world := ecs.NewWorld()
+	addMajorAnomaly(world, ecs.Vector3{X: 10}, 20)
+	_, survival := addSanityPlayer(world, ecs.Vector3{})
+	survival.SanityLevel = 50
+
+	ss := NewSanitySystem(world, nil, nil, nil, fakeRefuge{radius: 30})
+	runMinutes(ss, 1)
+
+	if survival.SanityLevel >= 50 {
+		t.Errorf("sanity = %.1f in a refuge next to an anomaly, want it to keep draining", survival.SanityLevel)
+	}
+}
+
+func TestThreatDrainsSanityOnce(t *testing.T) {
+	world := ecs.NewWorld()
+	_, survival := addSanityPlayer(world, ecs.Vector3{})
+	addWolf(world, ecs.Vector3{X: 8}, 1.0)
+
+	// Both systems run every frame, as in the game
+	ts := NewSystem(world, nil, nil)
+	ss := NewSanitySystem(world, nil, nil, ts, fakeRefuge{radius: 30})
+	for i := 0; i < 60; i++ {
+		ts.Update(1.0)
+		ss.Update(1.0)
+	}
+
+	level := ts.GetThreatLevel()
+	if level <= 0 {
+		t.Fatalf("threat level = %v next to a hunting wolf, want positive", level)
+	}
+	want := 100 - ss.Config.ThreatDrain*level
+	if diff := survival.SanityLevel - want; diff > 1e-6 || diff < -1e-6 {
+		t.Errorf("sanity after a minute under threat = %.3f, want %.3f (drained once, no refuge recovery)", survival.SanityLevel, want)
+	}
+	if pressure := ss.GetSanityPressure(); pressure != ss.Config.ThreatDrain*level {
+		t.Errorf("pressure = %v, want the threat drain %v", pressure, ss.Config.ThreatDrain*level)
+	}
+
+	// Without the sanity system the threat system leaves sanity alone
+	survival.SanityLevel = 50
+	ts.Update(60.0)
+	if survival.SanityLevel != 50 {
+		t.Errorf("threat system changed sanity to %.3f on its own", survival.SanityLevel)
+	}
+}
+
+func TestSanityBands(t *testing.T) {
+	cases := []struct {
+		sanity float64
+		band   int
+	}{
+		{100, SanityStable},
+		{75, SanityStable},
+		{74.9, SanityUneasy},
+		{49.9, SanityDisturbed},
+		{24.9, SanityBreaking},
+		{0, SanityBreaking},
+	}
+	for _, c := range cases {
+		if band := sanityBandFor(c.sanity); band != c.band {
+			t.Errorf("sanityBandFor(%v) = %d, want %d", c.sanity, band, c.band)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package threat
+
+import "echo-taiga/internal/engine/ecs"
+
+// Entity tags queried by the threat system
+const (
+	TagPlayer  = "player"
+	TagHostile = "hostile"
+	TagAnomaly = "anomaly"
+)
+
+func init() {
+	ecs.DeclareTagQuery(TagPlayer, TagHostile, TagAnomaly)
+}
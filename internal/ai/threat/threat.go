@@ -0,0 +1,355 @@
+package threat
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// Kinds of threats
+const (
+	KindCreature    = "creature"
+	KindEffect      = "effect"
+	KindEnvironment = "environment"
+)
+
+// Threat level bands used for threshold-crossing notifications
+const (
+	BandNone     = 0
+	BandLow      = 1
+	BandElevated = 2
+	BandHigh     = 3
+)
+
+// bandThresholds are the lower bounds of BandLow, BandElevated and BandHigh
+var bandThresholds = []float64{0.25, 0.5, 0.75}
+
+// Metamorphosis categories whose effects can hurt the player
+var harmfulCategories = []string{"physics", "entity", "reality"}
+
+// Threat is a single source of danger to the player
+type Threat struct {
+	Kind     string       // KindCreature, KindEffect or KindEnvironment
+	Source   string       // Effect ID or hazard name (empty for creatures)
+	EntityID ecs.EntityID // Creature entity (empty for other kinds)
+	Position ecs.Vector3  // Where the threat is
+	Distance float64      // Distance to the player
+	Score    float64      // How dangerous the threat is (0-1)
+	Pursuing bool         // A creature actively chasing or attacking the player
+}
+
+// Hazard is an environmental danger at a position
+type Hazard struct {
+	Name     string  // e.g. "void_ground", "storm"
+	Severity float64 // 0-1
+}
+
+// Environment reports environmental hazards (e.g. world.World)
+type Environment interface {
+	EnvironmentalHazards(position ecs.Vector3) []Hazard
+}
+
+// EffectSource lists active metamorphosis effects (e.g. metamorphosis.MetamorphosisManager)
+type EffectSource interface {
+	GetActiveEffects() []*metamorphosis.MetamorphEffect
+}
+
+// Config controls threat scoring
+type Config struct {
+	CreatureRange   float64 // Hostiles further away are ignored
+	DamageReference float64 // Attack damage considered maximally dangerous
+}
+
+// DefaultConfig returns the default threat settings
+func DefaultConfig() Config {
+	return Config{
+		CreatureRange:   40.0,
+		DamageReference: 50.0,
+	}
+}
+
+// System maintains a ranked list of threats to the player every frame
+type System struct {
+	world       *ecs.World
+	effects     EffectSource
+	environment Environment
+
+	Config Config
+
+	threats []Threat
+	level   float64
+	band    int
+	pursued bool
+
+	// Called when the threat level moves into a different band
+	OnThresholdCrossed func(previousBand, band int, level float64)
+
+	mutex sync.RWMutex
+}
+
+// NewSystem creates a threat system. effects and environment may be nil.
+func NewSystem(world *ecs.World, effects EffectSource, environment Environment) *System {
+	return &System{
+		world:       world,
+		effects:     effects,
+		environment: environment,
+		Config:      DefaultConfig(),
+		threats:     make([]Threat, 0),
+	}
+}
+
+// RequiredComponents implements ecs.System
+func (ts *System) RequiredComponents() []ecs.ComponentID {
+	return []ecs.ComponentID{ecs.TransformComponentID}
+}
+
+// Update re-evaluates threats around the player. The threat level drains the
+// player's sanity through SanitySystem.
+func (ts *System) Update(deltaTime float64) {
+	players := ts.world.GetEntitiesWithTag(TagPlayer)
+	if len(players) == 0 {
+		return
+	}
+	player := players[0]
+
+	playerPos, ok := entityPosition(player)
+	if !ok {
+		return
+	}
+
+	threats := ts.assess(player.ID, playerPos)
+	level := combinedLevel(threats)
+	band := bandFor(level)
+	pursued := anyPursuing(threats)
+
+	ts.mutex.Lock()
+	previousBand := ts.band
+	ts.threats = threats
+	ts.level = level
+	ts.band = band
+	ts.pursued = pursued
+	ts.mutex.Unlock()
+
+	if band != previousBand && ts.OnThresholdCrossed != nil {
+		ts.OnThresholdCrossed(previousBand, band, level)
+	}
+}
+
+// GetThreats returns the current threats, most dangerous first
+func (ts *System) GetThreats() []Threat {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+
+	result := make([]Threat, len(ts.threats))
+	copy(result, ts.threats)
+	return result
+}
+
+// GetThreatLevel returns the combined threat level (0-1)
+func (ts *System) GetThreatLevel() float64 {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+
+	return ts.level
+}
+
+// GetThreatBand returns the current threat band
+func (ts *System) GetThreatBand() int {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+
+	return ts.band
+}
+
+// IsPlayerPursued reports whether a hostile creature is actively chasing or attacking the player
+func (ts *System) IsPlayerPursued() bool {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+
+	return ts.pursued
+}
+
+// assess collects and ranks all threats to a player at the given position
+func (ts *System) assess(playerID ecs.EntityID, playerPos ecs.Vector3) []Threat {
+	threats := ts.creatureThreats(playerID, playerPos)
+
+	if ts.effects != nil {
+		threats = append(threats, effectThreats(ts.effects.GetActiveEffects(), playerPos)...)
+	}
+
+	if ts.environment != nil {
+		for _, hazard := range ts.environment.EnvironmentalHazards(playerPos) {
+			if hazard.Severity <= 0 {
+				continue
+			}
+			threats = append(threats, Threat{
+				Kind:     KindEnvironment,
+				Source:   hazard.Name,
+				Position: playerPos,
+				Score:    math.Min(1.0, hazard.Severity),
+			})
+		}
+	}
+
+	sort.SliceStable(threats, func(i, j int) bool {
+		if threats[i].Score != threats[j].Score {
+			return threats[i].Score > threats[j].Score
+		}
+		if threats[i].Distance != threats[j].Distance {
+			return threats[i].Distance < threats[j].Distance
+		}
+		if threats[i].EntityID != threats[j].EntityID {
+			return threats[i].EntityID < threats[j].EntityID
+		}
+		return threats[i].Source < threats[j].Source
+	})
+
+	return threats
+}
+
+// creatureThreats scores hostile creatures by distance, awareness, damage and mutation
+func (ts *System) creatureThreats(playerID ecs.EntityID, playerPos ecs.Vector3) []Threat {
+	threats := make([]Threat, 0)
+	if ts.Config.CreatureRange <= 0 {
+		return threats
+	}
+
+	for _, entity := range ts.world.GetEntitiesWithTag(TagHostile) {
+		position, ok := entityPosition(entity)
+		if !ok {
+			continue
+		}
+
+		distance := position.Distance(playerPos)
+		if distance > ts.Config.CreatureRange {
+			continue
+		}
+
+		proximity := 1.0 - distance/ts.Config.CreatureRange
+		awareness := 0.0
+		damage := 0.5
+		pursuing := false
+		if aiComp, has := entity.GetComponent(ecs.AIComponentID); has {
+			ai := aiComp.(*ecs.AIComponent)
+			awareness = ai.AwarenessLevel
+			pursuing = isPursuing(ai, playerID)
+			if ts.Config.DamageReference > 0 {
+				damage = math.Min(1.0, ai.AttackDamage/ts.Config.DamageReference)
+			}
+		}
+
+		mutation := 0.0
+		if metaComp, has := entity.GetComponent(ecs.MetamorphicComponentID); has {
+			mutation = metaComp.(*ecs.MetamorphicComponent).AbnormalityIndex
+		}
+
+		// Unaware creatures are still dangerous, just less so
+		score := proximity * (0.5 + 0.5*awareness) * (0.5 + 0.5*damage) * (1.0 + 0.5*mutation)
+
+		threats = append(threats, Threat{
+			Kind:     KindCreature,
+			EntityID: entity.ID,
+			Position: position,
+			Distance: distance,
+			Score:    math.Min(1.0, score),
+			Pursuing: pursuing,
+		})
+	}
+
+	return threats
+}
+
+// isPursuing checks whether a creature's AI is chasing or attacking the player.
+// Creatures without a target are assumed to be after the player.
+func isPursuing(ai *ecs.AIComponent, playerID ecs.EntityID) bool {
+	if ai.CurrentState != "chase" && ai.CurrentState != "attack" {
+		return false
+	}
+	return ai.TargetID == "" || ai.TargetID == playerID
+}
+
+// anyPursuing checks whether any of the threats is pursuing the player
+func anyPursuing(threats []Threat) bool {
+	for _, threat := range threats {
+		if threat.Pursuing {
+			return true
+		}
+	}
+	return false
+}
+
+// effectThreats scores harmful metamorphosis effects overlapping the player
+func effectThreats(effects []*metamorphosis.MetamorphEffect, playerPos ecs.Vector3) []Threat {
+	threats := make([]Threat, 0)
+
+	for _, effect := range effects {
+		if !isHarmful(effect) {
+			continue
+		}
+
+		intensity := effect.EffectiveIntensityFor(playerPos)
+		if intensity <= 0 {
+			continue
+		}
+
+		position := playerPos
+		if effect.AffectedArea != nil {
+			position = effect.AffectedArea.Center
+		}
+
+		threats = append(threats, Threat{
+			Kind:     KindEffect,
+			Source:   effect.ID,
+			Position: position,
+			Distance: position.Distance(playerPos),
+			Score:    math.Min(1.0, intensity*float64(effect.Order)/float64(metamorphosis.OrderFifth)),
+		})
+	}
+
+	return threats
+}
+
+// isHarmful checks whether an effect can hurt the player
+func isHarmful(effect *metamorphosis.MetamorphEffect) bool {
+	if effect.Order >= metamorphosis.OrderThird {
+		return true
+	}
+	for _, category := range harmfulCategories {
+		if effect.Category == category {
+			return true
+		}
+	}
+	return false
+}
+
+// combinedLevel merges threat scores so that several threats add up but never exceed 1
+func combinedLevel(threats []Threat) float64 {
+	safe := 1.0
+	for _, threat := range threats {
+		safe *= 1.0 - threat.Score
+	}
+	return 1.0 - safe
+}
+
+// bandFor returns the band of a threat level
+func bandFor(level float64) int {
+	band := BandNone
+	for i, threshold := range bandThresholds {
+		if level >= threshold {
+			band = i + 1
+		}
+	}
+	return band
+}
+
+// entityPosition returns an entity's position, if it has a transform
+func entityPosition(entity *ecs.Entity) (ecs.Vector3, bool) {
+	transformComp, has := entity.GetComponent(ecs.TransformComponentID)
+	if !has {
+		return ecs.Vector3{}, false
+	}
+	return transformComp.(*ecs.TransformComponent).Position, true
+}
@@ -0,0 +1,147 @@
+package threat
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// fakeEnvironment reports the same hazards everywhere
+type fakeEnvironment struct {
+	hazards []Hazard
+}
+
+func (e fakeEnvironment) EnvironmentalHazards(position ecs.Vector3) []Hazard {
+	return e.hazards
+}
+
+// addThreatPlayer adds a player at the origin
+func addThreatPlayer(world *ecs.World) *ecs.Entity {
+	player := ecs.NewEntity()
+	player.AddComponent(ecs.NewTransformComponent(ecs.Vector3{}))
+	player.AddTag(ecs.RegisterTag(TagPlayer, "actor", ""))
+	world.AddEntity(player)
+	return player
+}
+
+// addWolf adds a hostile wolf at position with the given awareness of the player
+func addWolf(world *ecs.World, position ecs.Vector3, awareness float64) (*ecs.Entity, *ecs.AIComponent) {
+	ai := ecs.NewAIComponent("aggressive", 30)
+	ai.AwarenessLevel = awareness
+
+	wolf := ecs.NewEntity()
+	wolf.AddComponent(ecs.NewTransformComponent(position))
+	wolf.AddComponent(ai)
+	wolf.AddTag(ecs.RegisterTag(TagHostile, "disposition", ""))
+	world.AddEntity(wolf)
+	return wolf, ai
+}
+
+func TestWolvesAreRankedByDistance(t *testing.T) {
+	world := ecs.NewWorld()
+	addThreatPlayer(world)
+	far, _ := addWolf(world, ecs.Vector3{X: 30}, 0.5)
+	near, _ := addWolf(world, ecs.Vector3{Z: 5}, 0.5)
+	middle, _ := addWolf(world, ecs.Vector3{X: -15}, 0.5)
+	addWolf(world, ecs.Vector3{X: 60}, 1.0)
+
+	ts := NewSystem(world, nil, nil)
+	ts.Update(1.0)
+
+	threats := ts.GetThreats()
+	want := []ecs.EntityID{near.ID, middle.ID, far.ID}
+	if len(threats) != len(want) {
+		t.Fatalf("got %d threats, want %d (the wolf out of range ignored): %+v", len(threats), len(want), threats)
+	}
+	for i, threat := range threats {
+		if threat.Kind != KindCreature || threat.EntityID != want[i] {
+			t.Errorf("threat %d = %s %s at %.0f, want creature %s", i, threat.Kind, threat.EntityID, threat.Distance, want[i])
+		}
+	}
+	if level := ts.GetThreatLevel(); level <= threats[0].Score || level > 1 {
+		t.Errorf("threat level %.3f, want above the strongest score %.3f and at most 1", level, threats[0].Score)
+	}
+}
+
+func TestAwareWolfOutranksCloserUnawareOne(t *testing.T) {
+	world := ecs.NewWorld()
+	addThreatPlayer(world)
+	addWolf(world, ecs.Vector3{X: 8}, 0)
+	hunter, ai := addWolf(world, ecs.Vector3{X: 12}, 1.0)
+	ai.CurrentState = "chase"
+
+	ts := NewSystem(world, nil, nil)
+	ts.Update(1.0)
+
+	threats := ts.GetThreats()
+	if len(threats) != 2 || threats[0].EntityID != hunter.ID {
+		t.Fatalf("threats %+v, want the chasing wolf first", threats)
+	}
+	if !threats[0].Pursuing || !ts.IsPlayerPursued() {
+		t.Errorf("chasing wolf not reported as pursuing the player")
+	}
+}
+
+func TestEnvironmentalHazardsAreThreats(t *testing.T) {
+	world := ecs.NewWorld()
+	addThreatPlayer(world)
+	addWolf(world, ecs.Vector3{X: 35}, 0)
+
+	ts := NewSystem(world, nil, fakeEnvironment{hazards: []Hazard{{Name: "storm", Severity: 0.6}, {Name: "calm", Severity: 0}}})
+	ts.Update(1.0)
+
+	threats := ts.GetThreats()
+	if len(threats) != 2 || threats[0].Kind != KindEnvironment || threats[0].Source != "storm" {
+		t.Errorf("threats %+v, want the storm ahead of the distant wolf and no calm hazard", threats)
+	}
+}
+
+func TestThresholdCrossings(t *testing.T) {
+	world := ecs.NewWorld()
+	addThreatPlayer(world)
+
+	ts := NewSystem(world, nil, nil)
+	crossings := make([][2]int, 0)
+	ts.OnThresholdCrossed = func(previousBand, band int, level float64) {
+		crossings = append(crossings, [2]int{previousBand, band})
+	}
+
+	ts.Update(1.0)
+	if len(crossings) != 0 {
+		t.Fatalf("crossings %v without threats, want none", crossings)
+	}
+
+	wolf, ai := addWolf(world, ecs.Vector3{X: 2}, 1.0)
+	ai.AttackDamage = ts.Config.DamageReference
+	ts.Update(1.0)
+	ts.Update(1.0)
+	if ts.GetThreatBand() != BandHigh || len(crossings) != 1 || crossings[0] != [2]int{BandNone, BandHigh} {
+		t.Fatalf("band %d with crossings %v, want one crossing into the high band", ts.GetThreatBand(), crossings)
+	}
+
+	transform, _ := ecs.ComponentAs[*ecs.TransformComponent](wolf, ecs.TransformComponentID)
+	transform.Position = ecs.Vector3{X: 100}
+	ts.Update(1.0)
+	if len(crossings) != 2 || crossings[1] != [2]int{BandHigh, BandNone} {
+		t.Errorf("crossings %v after the wolf left, want a crossing back to none", crossings)
+	}
+}
+
+func TestBandFor(t *testing.T) {
+	cases := []struct {
+		level float64
+		band  int
+	}{
+		{0, BandNone},
+		{0.24, BandNone},
+		{0.25, BandLow},
+		{0.5, BandElevated},
+		{0.75, BandHigh},
+		{1, BandHigh},
+	}
+	for _, c := range cases {
+		if band := bandFor(c.level); band != c.band {
+			t.Errorf("bandFor(%v) = %d, want %d", c.level, band, c.band)
+		}
+	}
+}
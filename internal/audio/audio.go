@@ -2,6 +2,8 @@ package audio
 
 import (
 	"log"
+
+	"echo-taiga/internal/engine/ecs"
 )
 
 // Manager отвечает за аудио в игре
@@ -39,6 +41,25 @@ func (m *Manager) PlaySound(soundName string) error {
 	return nil
 }
 
+// PlayEmitter воспроизводит звук источника в мире с заданной громкостью
+func (m *Manager) PlayEmitter(soundID string, volume float64) error {
+	if m.isMuted {
+		return nil
+	}
+	log.Printf("Проигрывание звука источника: %s (громкость %.2f)", soundID, volume)
+	// TODO: Реальная логика проигрывания звука
+	return nil
+}
+
+// EmitterVolume возвращает громкость источника с учетом общей громкости и
+// заглушения рельефом; без звука - ноль
+func (m *Manager) EmitterVolume(sound *ecs.SoundEmitterComponent) float64 {
+	if m.isMuted {
+		return 0
+	}
+	return m.volume * sound.AudibleVolume()
+}
+
 // SetVolume устанавливает общую громкость
 func (m *Manager) SetVolume(volume float64) {
 	m.volume = volume
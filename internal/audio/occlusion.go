@@ -0,0 +1,71 @@
+package audio
+
+import (
+	"echo-taiga/internal/engine/ecs"
+)
+
+// OcclusionInterval - как часто (в секундах) пересчитывается заглушение источников
+const OcclusionInterval = 0.25
+
+// Occluder оценивает, насколько препятствия заглушают звук между точками (например, world.World)
+type Occluder interface {
+	ComputeOcclusion(from, to ecs.Vector3) float64
+}
+
+// OcclusionSystem заглушает источники звука, скрытые от слушателя рельефом
+type OcclusionSystem struct {
+	world    *ecs.World
+	occluder Occluder
+	elapsed  float64
+}
+
+// NewOcclusionSystem создает систему заглушения звука
+func NewOcclusionSystem(world *ecs.World, occluder Occluder) *OcclusionSystem {
+	return &OcclusionSystem{
+		world:    world,
+		occluder: occluder,
+		elapsed:  OcclusionInterval, // Первый пересчет - в первом же обновлении
+	}
+}
+
+// RequiredComponents реализует ecs.System
+func (oc *OcclusionSystem) RequiredComponents() []ecs.ComponentID {
+	return []ecs.ComponentID{ecs.SoundEmitterComponentID}
+}
+
+// Update пересчитывает заглушение звучащих источников в пределах их слышимости
+func (oc *OcclusionSystem) Update(deltaTime float64) {
+	oc.elapsed += deltaTime
+	if oc.elapsed < OcclusionInterval {
+		return
+	}
+	oc.elapsed = 0
+
+	players := oc.world.GetEntitiesWithTag(TagPlayer)
+	if len(players) == 0 {
+		return
+	}
+	listener, has := ecs.ComponentAs[*ecs.TransformComponent](players[0], ecs.TransformComponentID)
+	if !has {
+		return
+	}
+
+	for _, entity := range oc.world.GetEntitiesWithComponent(ecs.SoundEmitterComponentID) {
+		sound, has := ecs.ComponentAs[*ecs.SoundEmitterComponent](entity, ecs.SoundEmitterComponentID)
+		if !has {
+			continue
+		}
+
+		// Источник звучит оттуда, где стоит его сущность
+		position := sound.Position
+		if transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID); has {
+			position = transform.Position
+		}
+
+		if !sound.IsPlaying || listener.Position.Distance(position) > sound.Range {
+			sound.Occlusion = 0
+			continue
+		}
+		sound.Occlusion = oc.occluder.ComputeOcclusion(listener.Position, position)
+	}
+}
@@ -0,0 +1,59 @@
+package audio
+
+import (
+	"echo-taiga/internal/engine/ecs"
+)
+
+// PlaybackSystem проигрывает звуки источников с громкостью, которую слышит
+// игрок: источники, заглушенные рельефом, звучат тише
+type PlaybackSystem struct {
+	world   *ecs.World
+	manager *Manager
+	playing map[ecs.EntityID]float64 // Громкость звучащих источников
+}
+
+// NewPlaybackSystem создает систему проигрывания звуков источников
+func NewPlaybackSystem(world *ecs.World, manager *Manager) *PlaybackSystem {
+	return &PlaybackSystem{
+		world:   world,
+		manager: manager,
+		playing: make(map[ecs.EntityID]float64),
+	}
+}
+
+// RequiredComponents реализует ecs.System
+func (ps *PlaybackSystem) RequiredComponents() []ecs.ComponentID {
+	return []ecs.ComponentID{ecs.SoundEmitterComponentID}
+}
+
+// Update начинает проигрывание зазвучавших источников и обновляет громкость
+// звучащих вслед за их заглушением
+func (ps *PlaybackSystem) Update(deltaTime float64) {
+	active := make(map[ecs.EntityID]bool)
+	for _, entity := range ps.world.GetEntitiesWithComponent(ecs.SoundEmitterComponentID) {
+		sound, has := ecs.ComponentAs[*ecs.SoundEmitterComponent](entity, ecs.SoundEmitterComponentID)
+		if !has || !sound.IsPlaying {
+			continue
+		}
+		active[entity.ID] = true
+
+		volume := ps.manager.EmitterVolume(sound)
+		if _, started := ps.playing[entity.ID]; !started {
+			ps.manager.PlayEmitter(sound.SoundID, volume)
+		}
+		ps.playing[entity.ID] = volume
+	}
+
+	// Умолкшие и удаленные источники
+	for id := range ps.playing {
+		if !active[id] {
+			delete(ps.playing, id)
+		}
+	}
+}
+
+// Volume возвращает громкость, с которой звучит источник сущности
+func (ps *PlaybackSystem) Volume(id ecs.EntityID) (float64, bool) {
+	volume, playing := ps.playing[id]
+	return volume, playing
+}
@@ -0,0 +1,90 @@
+package audio
+
+import (
+	"math"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// fixedOccluder заглушает любой путь звука одинаково
+type fixedOccluder float64
+
+func (fo fixedOccluder) ComputeOcclusion(from, to ecs.Vector3) float64 {
+	return float64(fo)
+}
+
+// newPlaybackWorld создает мир с игроком в начале координат и звучащим источником
+func newPlaybackWorld(t *testing.T, emitterVolume float64) (*ecs.World, *ecs.Entity, *ecs.SoundEmitterComponent) {
+	t.Helper()
+
+	world := ecs.NewWorld()
+
+	player := ecs.NewEntity()
+	player.AddComponent(ecs.NewTransformComponent(ecs.Vector3{}))
+	player.AddTag(ecs.RegisterTag(TagPlayer, "actor", ""))
+	world.AddEntity(player)
+
+	sound := ecs.NewSoundEmitterComponent("wolf_howl", emitterVolume, 20)
+	sound.Play()
+	emitter := ecs.NewEntity()
+	emitter.AddComponent(ecs.NewTransformComponent(ecs.Vector3{X: 10}))
+	emitter.AddComponent(sound)
+	world.AddEntity(emitter)
+
+	return world, emitter, sound
+}
+
+func TestPlaybackUsesOccludedVolume(t *testing.T) {
+	world, emitter, _ := newPlaybackWorld(t, 0.8)
+	manager := NewManager() // Общая громкость 0.5
+	occlusion := NewOcclusionSystem(world, fixedOccluder(0.5))
+	playback := NewPlaybackSystem(world, manager)
+
+	occlusion.Update(0)
+	playback.Update(0)
+
+	volume, playing := playback.Volume(emitter.ID)
+	if !playing {
+		t.Fatalf("emitter is not playing")
+	}
+	if want := 0.5 * 0.8 * (1 - 0.5); math.Abs(volume-want) > 1e-9 {
+		t.Errorf("occluded volume = %v, want %v", volume, want)
+	}
+}
+
+func TestPlaybackFollowsOcclusionAndStop(t *testing.T) {
+	world, emitter, sound := newPlaybackWorld(t, 0.8)
+	manager := NewManager()
+	playback := NewPlaybackSystem(world, manager)
+
+	playback.Update(0)
+	if volume, _ := playback.Volume(emitter.ID); math.Abs(volume-0.4) > 1e-9 {
+		t.Errorf("unoccluded volume = %v, want 0.4", volume)
+	}
+
+	// Источник скрылся за холмом целиком
+	sound.Occlusion = 1
+	playback.Update(0)
+	if volume, _ := playback.Volume(emitter.ID); volume != 0 {
+		t.Errorf("fully occluded volume = %v, want 0", volume)
+	}
+
+	sound.Stop()
+	playback.Update(0)
+	if _, playing := playback.Volume(emitter.ID); playing {
+		t.Errorf("stopped emitter is still playing")
+	}
+}
+
+func TestMutedManagerSilencesEmitters(t *testing.T) {
+	world, emitter, _ := newPlaybackWorld(t, 1.0)
+	manager := NewManager()
+	manager.Mute()
+	playback := NewPlaybackSystem(world, manager)
+
+	playback.Update(0)
+	if volume, _ := playback.Volume(emitter.ID); volume != 0 {
+		t.Errorf("muted volume = %v, want 0", volume)
+	}
+}
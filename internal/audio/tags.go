@@ -0,0 +1,12 @@
+package audio
+
+import "echo-taiga/internal/engine/ecs"
+
+// Теги сущностей, на которые опирается звук
+const (
+	TagPlayer = "player"
+)
+
+func init() {
+	ecs.DeclareTagQuery(TagPlayer)
+}
@@ -10,39 +10,129 @@ import (
 
 // Добавьте определение структуры Config
 type Config struct {
-	WindowWidth       int
-	WindowHeight      int
-	Fullscreen        bool
-	Title             string
-	Seed              int64
-	Difficulty        string
-	WorldSize         string
-	MetamorphosisRate float64
-	TargetFPS         int
-	EnableVSync       bool
-	ChunkSize         int
-	ViewDistance      int
-	EnableShadows     bool
-	TextureQuality    int
+	WindowWidth         int
+	WindowHeight        int
+	Fullscreen          bool
+	Title               string
+	Seed                int64
+	Difficulty          string
+	WorldSize           string
+	MetamorphosisRate   float64
+	TargetFPS           int
+	SimulationTickRate  int // Шагов симуляции в секунду, независимо от частоты кадров
+	EnableVSync         bool
+	ChunkSize           int
+	ViewDistance        int
+	EnableShadows       bool
+	TextureQuality      int
+	SaveDir             string
+	SaveSlot            string
+	TelemetryDir        string
+	SymbolsPerType      int     // Вариаций каждого базового символа
+	MinRitualSymbols    int     // Минимум символов в сгенерированном ритуале
+	MaxRitualSymbols    int     // Максимум символов в сгенерированном ритуале
+	MaxRitualEffects    int     // Максимум эффектов сгенерированного ритуала
+	SafeZoneRadius      int     // Радиус безопасной зоны вокруг начала мира (в чанках)
+	EntityDensityScale  float64 // Доля объектов и животных в чанках (0-1), снижает нагрузку на слабых машинах
+	RitualPartialMargin float64 // Запас броска сверх шанса успеха, дающий частичный успех ритуала
+	SimpleRituals       bool    // Не проверять порядок действий ритуалов (для спокойной игры)
+
+	// Темп обнаружения символов: сколько символов за обновление и пауза между обнаружениями
+	SymbolDiscoveryBudget   int
+	SymbolDiscoveryCooldown float64 // В секундах
+	SymbolDiscoveryFOV      float64 // Ширина конуса обзора, в котором замечаются символы (в градусах)
+
+	// Фрактальный шум аномальности: форма пятен и прожилок порчи
+	AnomalyNoiseOctaves     int
+	AnomalyNoiseLacunarity  float64
+	AnomalyNoisePersistence float64
+
+	// Проводимость порчи между чанками по типам биомов (0-1, "water" - реки и озера).
+	// Задает только отличия от world.DefaultAnomalyConductivity.
+	AnomalyConductivity map[string]float64
+
+	// Опасность биомов (0-1): поднимает фоновое напряжение директора страха.
+	// Задает только отличия от fear.DefaultBiomeDanger.
+	BiomeDanger map[string]float64
+
+	// Пополнение триггеров метаморфоз: предел доступных и пауза шаблона после срабатывания
+	MetamorphTriggerLimit    int
+	MetamorphTriggerCooldown float64 // В секундах
+
+	// Мир живет и без игрока: при загрузке слота проматывается время отсутствия
+	OfflineCatchUp         bool
+	OfflineCatchUpMaxHours float64 // Сколько часов отсутствия проматывается самое большее
+
+	// Пулы массовых компонентов ECS; выключаются при поиске ошибок работы с памятью
+	ComponentPooling bool
+
+	// Повторная доставка запланированных событий, обработка которых прервалась сбоем
+	SchedulerRedelivery bool
+
+	// Метаморфозы окрашивают сущности только в цвета, различимые при нарушениях цветового зрения
+	ColorblindPalettes bool
+
+	// Сохранения в читаемом JSON без сжатия (для отладки) и срок, после которого
+	// неиспользованные сгенерированные символы и ритуалы удаляются (в днях, 0 - никогда)
+	ReadableSaves bool
+	SavePruneDays float64
 }
 
 // Добавьте функцию DefaultConfig()
 func DefaultConfig() *Config {
 	return &Config{
-		WindowWidth:       1280,
-		WindowHeight:      720,
-		Fullscreen:        false,
-		Title:             "Эхо Тайги",
-		Seed:              0, // Генерация случайного сида
-		Difficulty:        "normal",
-		WorldSize:         "medium",
-		MetamorphosisRate: 0.5,
-		TargetFPS:         60,
-		EnableVSync:       true,
-		ChunkSize:         64,
-		ViewDistance:      3,
-		EnableShadows:     true,
-		TextureQuality:    1,
+		WindowWidth:         1280,
+		WindowHeight:        720,
+		Fullscreen:          false,
+		Title:               "Эхо Тайги",
+		Seed:                0, // Генерация случайного сида
+		Difficulty:          "normal",
+		WorldSize:           "medium",
+		MetamorphosisRate:   0.5,
+		TargetFPS:           60,
+		SimulationTickRate:  30,
+		EnableVSync:         true,
+		ChunkSize:           64,
+		ViewDistance:        3,
+		EnableShadows:       true,
+		TextureQuality:      1,
+		SaveDir:             "saves",
+		SaveSlot:            "default",
+		SymbolsPerType:      3,
+		MinRitualSymbols:    2,
+		MaxRitualSymbols:    4,
+		MaxRitualEffects:    2,
+		SafeZoneRadius:      2,
+		EntityDensityScale:  1.0,
+		RitualPartialMargin: 0.15,
+		SimpleRituals:       false,
+		TelemetryDir:        "", // Пустой каталог отключает сбор телеметрии плейтестов
+
+		SymbolDiscoveryBudget:   1,
+		SymbolDiscoveryCooldown: 1.0,
+		SymbolDiscoveryFOV:      110,
+
+		AnomalyNoiseOctaves:     4,
+		AnomalyNoiseLacunarity:  2.0,
+		AnomalyNoisePersistence: 0.5,
+
+		AnomalyConductivity: map[string]float64{}, // Таблица мира без изменений
+		BiomeDanger:         map[string]float64{}, // Таблица директора страха без изменений
+
+		MetamorphTriggerLimit:    5,
+		MetamorphTriggerCooldown: 300,
+
+		OfflineCatchUp:         true,
+		OfflineCatchUpMaxHours: 24,
+
+		ComponentPooling: true,
+
+		SchedulerRedelivery: false,
+
+		ColorblindPalettes: false,
+
+		ReadableSaves: false,
+		SavePruneDays: 30,
 	}
 }
 
@@ -68,11 +158,40 @@ func Load() (*Config, error) {
 	viper.SetDefault("world_size", config.WorldSize)
 	viper.SetDefault("metamorphosis_rate", config.MetamorphosisRate)
 	viper.SetDefault("target_fps", config.TargetFPS)
+	viper.SetDefault("simulation_tick_rate", config.SimulationTickRate)
 	viper.SetDefault("enable_vsync", config.EnableVSync)
 	viper.SetDefault("chunk_size", config.ChunkSize)
 	viper.SetDefault("view_distance", config.ViewDistance)
 	viper.SetDefault("enable_shadows", config.EnableShadows)
 	viper.SetDefault("texture_quality", config.TextureQuality)
+	viper.SetDefault("save_dir", config.SaveDir)
+	viper.SetDefault("save_slot", config.SaveSlot)
+	viper.SetDefault("symbols_per_type", config.SymbolsPerType)
+	viper.SetDefault("min_ritual_symbols", config.MinRitualSymbols)
+	viper.SetDefault("max_ritual_symbols", config.MaxRitualSymbols)
+	viper.SetDefault("max_ritual_effects", config.MaxRitualEffects)
+	viper.SetDefault("safe_zone_radius", config.SafeZoneRadius)
+	viper.SetDefault("entity_density_scale", config.EntityDensityScale)
+	viper.SetDefault("ritual_partial_margin", config.RitualPartialMargin)
+	viper.SetDefault("simple_rituals", config.SimpleRituals)
+	viper.SetDefault("symbol_discovery_budget", config.SymbolDiscoveryBudget)
+	viper.SetDefault("symbol_discovery_cooldown", config.SymbolDiscoveryCooldown)
+	viper.SetDefault("symbol_discovery_fov", config.SymbolDiscoveryFOV)
+	viper.SetDefault("anomaly_noise_octaves", config.AnomalyNoiseOctaves)
+	viper.SetDefault("anomaly_noise_lacunarity", config.AnomalyNoiseLacunarity)
+	viper.SetDefault("anomaly_noise_persistence", config.AnomalyNoisePersistence)
+	viper.SetDefault("anomaly_conductivity", config.AnomalyConductivity)
+	viper.SetDefault("biome_danger", config.BiomeDanger)
+	viper.SetDefault("metamorph_trigger_limit", config.MetamorphTriggerLimit)
+	viper.SetDefault("metamorph_trigger_cooldown", config.MetamorphTriggerCooldown)
+	viper.SetDefault("offline_catch_up", config.OfflineCatchUp)
+	viper.SetDefault("offline_catch_up_max_hours", config.OfflineCatchUpMaxHours)
+	viper.SetDefault("component_pooling", config.ComponentPooling)
+	viper.SetDefault("scheduler_redelivery", config.SchedulerRedelivery)
+	viper.SetDefault("colorblind_palettes", config.ColorblindPalettes)
+	viper.SetDefault("readable_saves", config.ReadableSaves)
+	viper.SetDefault("save_prune_days", config.SavePruneDays)
+	viper.SetDefault("telemetry_dir", config.TelemetryDir)
 
 	// Попытка прочитать существующий конфиг
 	if err := viper.ReadInConfig(); err != nil {
@@ -102,11 +221,46 @@ func Load() (*Config, error) {
 	config.WorldSize = viper.GetString("world_size")
 	config.MetamorphosisRate = viper.GetFloat64("metamorphosis_rate")
 	config.TargetFPS = viper.GetInt("target_fps")
+	config.SimulationTickRate = viper.GetInt("simulation_tick_rate")
 	config.EnableVSync = viper.GetBool("enable_vsync")
 	config.ChunkSize = viper.GetInt("chunk_size")
 	config.ViewDistance = viper.GetInt("view_distance")
 	config.EnableShadows = viper.GetBool("enable_shadows")
 	config.TextureQuality = viper.GetInt("texture_quality")
+	config.SaveDir = viper.GetString("save_dir")
+	config.SaveSlot = viper.GetString("save_slot")
+	config.SymbolsPerType = viper.GetInt("symbols_per_type")
+	config.MinRitualSymbols = viper.GetInt("min_ritual_symbols")
+	config.MaxRitualSymbols = viper.GetInt("max_ritual_symbols")
+	config.MaxRitualEffects = viper.GetInt("max_ritual_effects")
+	config.SafeZoneRadius = viper.GetInt("safe_zone_radius")
+	config.EntityDensityScale = viper.GetFloat64("entity_density_scale")
+	config.RitualPartialMargin = viper.GetFloat64("ritual_partial_margin")
+	config.SimpleRituals = viper.GetBool("simple_rituals")
+	config.SymbolDiscoveryBudget = viper.GetInt("symbol_discovery_budget")
+	config.SymbolDiscoveryCooldown = viper.GetFloat64("symbol_discovery_cooldown")
+	config.SymbolDiscoveryFOV = viper.GetFloat64("symbol_discovery_fov")
+	config.AnomalyNoiseOctaves = viper.GetInt("anomaly_noise_octaves")
+	config.AnomalyNoiseLacunarity = viper.GetFloat64("anomaly_noise_lacunarity")
+	config.AnomalyNoisePersistence = viper.GetFloat64("anomaly_noise_persistence")
+	config.AnomalyConductivity = make(map[string]float64)
+	for biome := range viper.GetStringMap("anomaly_conductivity") {
+		config.AnomalyConductivity[biome] = viper.GetFloat64("anomaly_conductivity." + biome)
+	}
+	config.BiomeDanger = make(map[string]float64)
+	for biome := range viper.GetStringMap("biome_danger") {
+		config.BiomeDanger[biome] = viper.GetFloat64("biome_danger." + biome)
+	}
+	config.MetamorphTriggerLimit = viper.GetInt("metamorph_trigger_limit")
+	config.MetamorphTriggerCooldown = viper.GetFloat64("metamorph_trigger_cooldown")
+	config.OfflineCatchUp = viper.GetBool("offline_catch_up")
+	config.OfflineCatchUpMaxHours = viper.GetFloat64("offline_catch_up_max_hours")
+	config.ComponentPooling = viper.GetBool("component_pooling")
+	config.SchedulerRedelivery = viper.GetBool("scheduler_redelivery")
+	config.ColorblindPalettes = viper.GetBool("colorblind_palettes")
+	config.ReadableSaves = viper.GetBool("readable_saves")
+	config.SavePruneDays = viper.GetFloat64("save_prune_days")
+	config.TelemetryDir = viper.GetString("telemetry_dir")
 
 	return config, nil
 }
@@ -125,11 +279,40 @@ func (c *Config) Save() error {
 	viper.Set("world_size", c.WorldSize)
 	viper.Set("metamorphosis_rate", c.MetamorphosisRate)
 	viper.Set("target_fps", c.TargetFPS)
+	viper.Set("simulation_tick_rate", c.SimulationTickRate)
 	viper.Set("enable_vsync", c.EnableVSync)
 	viper.Set("chunk_size", c.ChunkSize)
 	viper.Set("view_distance", c.ViewDistance)
 	viper.Set("enable_shadows", c.EnableShadows)
 	viper.Set("texture_quality", c.TextureQuality)
+	viper.Set("save_dir", c.SaveDir)
+	viper.Set("save_slot", c.SaveSlot)
+	viper.Set("symbols_per_type", c.SymbolsPerType)
+	viper.Set("min_ritual_symbols", c.MinRitualSymbols)
+	viper.Set("max_ritual_symbols", c.MaxRitualSymbols)
+	viper.Set("max_ritual_effects", c.MaxRitualEffects)
+	viper.Set("safe_zone_radius", c.SafeZoneRadius)
+	viper.Set("entity_density_scale", c.EntityDensityScale)
+	viper.Set("ritual_partial_margin", c.RitualPartialMargin)
+	viper.Set("simple_rituals", c.SimpleRituals)
+	viper.Set("symbol_discovery_budget", c.SymbolDiscoveryBudget)
+	viper.Set("symbol_discovery_cooldown", c.SymbolDiscoveryCooldown)
+	viper.Set("symbol_discovery_fov", c.SymbolDiscoveryFOV)
+	viper.Set("anomaly_noise_octaves", c.AnomalyNoiseOctaves)
+	viper.Set("anomaly_noise_lacunarity", c.AnomalyNoiseLacunarity)
+	viper.Set("anomaly_noise_persistence", c.AnomalyNoisePersistence)
+	viper.Set("anomaly_conductivity", c.AnomalyConductivity)
+	viper.Set("biome_danger", c.BiomeDanger)
+	viper.Set("metamorph_trigger_limit", c.MetamorphTriggerLimit)
+	viper.Set("metamorph_trigger_cooldown", c.MetamorphTriggerCooldown)
+	viper.Set("offline_catch_up", c.OfflineCatchUp)
+	viper.Set("offline_catch_up_max_hours", c.OfflineCatchUpMaxHours)
+	viper.Set("component_pooling", c.ComponentPooling)
+	viper.Set("scheduler_redelivery", c.SchedulerRedelivery)
+	viper.Set("colorblind_palettes", c.ColorblindPalettes)
+	viper.Set("readable_saves", c.ReadableSaves)
+	viper.Set("save_prune_days", c.SavePruneDays)
+	viper.Set("telemetry_dir", c.TelemetryDir)
 
 	configPath := filepath.Join(configDir, "config.yaml")
 	return viper.WriteConfigAs(configPath)
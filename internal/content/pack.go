@@ -0,0 +1,311 @@
+package content
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"echo-taiga/internal/ai/fear"
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/symbols"
+	"echo-taiga/internal/world/biomes"
+)
+
+// Каталоги внутри пакета контента
+const (
+	ManifestFile        = "manifest.json"
+	SymbolsDir          = "symbols"
+	RitualsDir          = "rituals"
+	RitualEffectsDir    = "ritual_effects"
+	EffectTemplatesDir  = "effect_templates"
+	TriggerTemplatesDir = "trigger_templates"
+	ScareTemplatesDir   = "scare_templates"
+	BiomesDir           = "biomes"
+)
+
+// Manifest описывает пакет контента
+type Manifest struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+}
+
+// ContentPack содержит набор контента, накладываемый поверх стандартного
+type ContentPack struct {
+	Manifest Manifest
+
+	Symbols          []*symbols.Symbol
+	Rituals          []*symbols.Ritual
+	RitualEffects    map[string]symbols.RitualEffect
+	EffectTemplates  []*metamorphosis.MetamorphEffect
+	TriggerTemplates []*metamorphosis.MetamorphTrigger
+	ScareTemplates   []fear.ScareEvent
+	Biomes           []*biomes.Biome // Новые описания стандартных биомов
+}
+
+// LoadPack загружает пакет контента из каталога
+func LoadPack(dir string) (*ContentPack, error) {
+	return LoadPackFS(os.DirFS(dir))
+}
+
+// LoadPackFS загружает пакет контента из файловой системы (например, embed.FS)
+func LoadPackFS(fsys fs.FS) (*ContentPack, error) {
+	pack := &ContentPack{
+		RitualEffects: make(map[string]symbols.RitualEffect),
+	}
+
+	// Манифест обязателен
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %v", err)
+	}
+	if err := json.Unmarshal(data, &pack.Manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %v", err)
+	}
+	if pack.Manifest.Name == "" {
+		return nil, fmt.Errorf("manifest has no pack name")
+	}
+
+	err = readJSONDir(fsys, SymbolsDir, func(name string, data []byte) error {
+		var symbol symbols.Symbol
+		if err := json.Unmarshal(data, &symbol); err != nil {
+			return err
+		}
+		pack.Symbols = append(pack.Symbols, &symbol)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readJSONDir(fsys, RitualsDir, func(name string, data []byte) error {
+		var ritual symbols.Ritual
+		if err := json.Unmarshal(data, &ritual); err != nil {
+			return err
+		}
+		pack.Rituals = append(pack.Rituals, &ritual)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readJSONDir(fsys, RitualEffectsDir, func(name string, data []byte) error {
+		var effect symbols.RitualEffect
+		if err := json.Unmarshal(data, &effect); err != nil {
+			return err
+		}
+		pack.RitualEffects[name] = effect
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readJSONDir(fsys, EffectTemplatesDir, func(name string, data []byte) error {
+		var effect metamorphosis.MetamorphEffect
+		if err := json.Unmarshal(data, &effect); err != nil {
+			return err
+		}
+		pack.EffectTemplates = append(pack.EffectTemplates, &effect)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readJSONDir(fsys, TriggerTemplatesDir, func(name string, data []byte) error {
+		var trigger metamorphosis.MetamorphTrigger
+		if err := json.Unmarshal(data, &trigger); err != nil {
+			return err
+		}
+		pack.TriggerTemplates = append(pack.TriggerTemplates, &trigger)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readJSONDir(fsys, ScareTemplatesDir, func(name string, data []byte) error {
+		var scare fear.ScareEvent
+		if err := json.Unmarshal(data, &scare); err != nil {
+			return err
+		}
+		pack.ScareTemplates = append(pack.ScareTemplates, scare)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readJSONDir(fsys, BiomesDir, func(name string, data []byte) error {
+		var biome biomes.Biome
+		if err := json.Unmarshal(data, &biome); err != nil {
+			return err
+		}
+		pack.Biomes = append(pack.Biomes, &biome)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pack.Validate(); err != nil {
+		return nil, err
+	}
+
+	return pack, nil
+}
+
+// Validate проверяет содержимое пакета теми же правилами, что и шаблоны на диске,
+// и ищет повторяющиеся ID внутри пакета
+func (p *ContentPack) Validate() error {
+	problems := make([]string, 0)
+	seen := make(map[string]bool)
+
+	// duplicate отмечает ID вида kind и сообщает, встречался ли он раньше
+	duplicate := func(kind, id string) bool {
+		key := kind + "/" + id
+		if seen[key] {
+			problems = append(problems, fmt.Sprintf("%s %q: duplicate ID", kind, id))
+			return true
+		}
+		seen[key] = true
+		return false
+	}
+
+	for _, symbol := range p.Symbols {
+		for _, err := range symbols.ValidateSymbol(symbol) {
+			err.File = fmt.Sprintf("symbol %q", symbol.ID)
+			problems = append(problems, err.Error())
+		}
+		duplicate("symbol", symbol.ID)
+	}
+
+	for _, ritual := range p.Rituals {
+		for _, err := range symbols.ValidateRitual(ritual) {
+			err.File = fmt.Sprintf("ritual %q", ritual.ID)
+			problems = append(problems, err.Error())
+		}
+		duplicate("ritual", ritual.ID)
+	}
+
+	for _, effect := range p.EffectTemplates {
+		for _, err := range metamorphosis.ValidateEffectTemplate(effect) {
+			err.File = fmt.Sprintf("effect template %q", effect.ID)
+			problems = append(problems, err.Error())
+		}
+		duplicate("effect template", effect.ID)
+	}
+
+	for _, trigger := range p.TriggerTemplates {
+		for _, err := range metamorphosis.ValidateTriggerTemplate(trigger) {
+			err.File = fmt.Sprintf("trigger template %q", trigger.ID)
+			problems = append(problems, err.Error())
+		}
+		duplicate("trigger template", trigger.ID)
+	}
+
+	// Шаблоны испуга различаются по типу
+	for _, scare := range p.ScareTemplates {
+		if strings.TrimSpace(scare.Type) == "" {
+			problems = append(problems, fmt.Sprintf("scare template %q: Type: must not be empty", scare.ID))
+			continue
+		}
+		if scare.Intensity < 0 || scare.Intensity > 1 {
+			problems = append(problems, fmt.Sprintf("scare template %q: Intensity: must be between 0 and 1, got %.2f", scare.Type, scare.Intensity))
+		}
+		duplicate("scare template", scare.Type)
+	}
+
+	// Генерация выбирает только стандартные биомы, поэтому пакет может лишь заменить их описание
+	for _, biome := range p.Biomes {
+		if !biomes.IsStandardBiome(biome.Type) {
+			problems = append(problems, fmt.Sprintf("biome %q: Type: unsupported, packs can only redefine the standard biomes", biome.Type))
+			continue
+		}
+		if biome.StabilityModifier < 0 {
+			problems = append(problems, fmt.Sprintf("biome %q: StabilityModifier: must not be negative, got %.2f", biome.Type, biome.StabilityModifier))
+		}
+		duplicate("biome", string(biome.Type))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid content pack %q: %s", p.Manifest.Name, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ApplyToSymbols накладывает символы и ритуалы пакета на менеджер символов.
+// Пакет передается менеджеру через AddBaseContent до его инициализации.
+func (p *ContentPack) ApplyToSymbols(manager *symbols.Manager) {
+	for _, symbol := range p.Symbols {
+		manager.Registry.AddBaseSymbol(symbol)
+	}
+
+	for _, ritual := range p.Rituals {
+		manager.RitualRegistry.AddBaseRitual(ritual)
+	}
+
+	for id, effect := range p.RitualEffects {
+		manager.RitualRegistry.AddEffectTemplate(id, effect)
+	}
+}
+
+// ApplyToMetamorphosis накладывает шаблоны эффектов и триггеров пакета
+func (p *ContentPack) ApplyToMetamorphosis(manager *metamorphosis.MetamorphosisManager) {
+	for _, effect := range p.EffectTemplates {
+		manager.RegisterEffectTemplate(effect)
+	}
+
+	for _, trigger := range p.TriggerTemplates {
+		manager.RegisterTriggerTemplate(trigger)
+	}
+}
+
+// ApplyToFear накладывает шаблоны пугающих событий пакета
+func (p *ContentPack) ApplyToFear(director *fear.Director) {
+	for _, scare := range p.ScareTemplates {
+		director.AddScareTemplate(scare)
+	}
+}
+
+// ApplyToBiomes заменяет описания стандартных биомов описаниями из пакета
+func (p *ContentPack) ApplyToBiomes(biomeMap *biomes.BiomeMap) {
+	for _, biome := range p.Biomes {
+		biomeMap.RegisterBiome(biome)
+	}
+}
+
+// readJSONDir вызывает handler для каждого JSON-файла в каталоге пакета.
+// Отсутствующий каталог не является ошибкой.
+func readJSONDir(fsys fs.FS, dir string, handler func(name string, data []byte) error) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		if err := handler(name, data); err != nil {
+			return fmt.Errorf("failed to parse %s/%s: %v", dir, entry.Name(), err)
+		}
+	}
+
+	return nil
+}
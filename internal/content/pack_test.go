@@ -0,0 +1,159 @@
+package content
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/symbols"
+	"echo-taiga/internal/world"
+)
+
+// testPackFS возвращает пакет с новым символом, новым ритуалом и заменой стандартного символа
+func testPackFS() fstest.MapFS {
+	return fstest.MapFS{
+		ManifestFile: {Data: []byte(`{"name": "test_pack", "version": "1.0"}`)},
+		"symbols/pack_frost.json": {Data: []byte(`{
+			"ID": "pack_frost", "Name": "Frost", "SymbolType": "elemental", "Complexity": 0.4, "Power": 0.5
+		}`)},
+		"symbols/base_elemental.json": {Data: []byte(`{
+			"ID": "base_elemental", "Name": "Elemental (pack)", "SymbolType": "elemental", "Complexity": 0.3, "Power": 0.4
+		}`)},
+		"rituals/pack_frost_rite.json": {Data: []byte(`{
+			"ID": "pack_frost_rite", "Name": "Frost Rite", "RequiredSymbols": ["pack_frost"],
+			"RequiredLocation": "water", "Difficulty": 0.4, "SuccessChance": 0.6
+		}`)},
+	}
+}
+
+// countIDs считает, сколько раз встречается каждый ID
+func countIDs(ids []string) map[string]int {
+	counts := make(map[string]int)
+	for _, id := range ids {
+		counts[id]++
+	}
+	return counts
+}
+
+func TestPackLayersSymbolsAndRitualsOverDefaults(t *testing.T) {
+	pack, err := LoadPackFS(testPackFS())
+	if err != nil {
+		t.Fatalf("failed to load pack: %v", err)
+	}
+
+	manager := symbols.NewManagerInMemory(ecs.NewWorld())
+	manager.AddBaseContent(pack)
+	if err := manager.Initialize(); err != nil {
+		t.Fatalf("failed to initialize symbols: %v", err)
+	}
+
+	symbolIDs := make([]string, 0)
+	var elemental *symbols.Symbol
+	for _, symbol := range manager.Registry.GetBaseSymbols() {
+		symbolIDs = append(symbolIDs, symbol.ID)
+		if symbol.ID == "base_elemental" {
+			elemental = symbol
+		}
+	}
+	for id, count := range countIDs(symbolIDs) {
+		if count > 1 {
+			t.Errorf("base symbol %q appears %d times", id, count)
+		}
+	}
+	if counts := countIDs(symbolIDs); counts["pack_frost"] != 1 || counts["base_arcane"] != 1 {
+		t.Errorf("base symbols %v, want the pack symbol next to the defaults", symbolIDs)
+	}
+	if elemental == nil || elemental.Name != "Elemental (pack)" {
+		t.Errorf("base_elemental = %+v, want the pack version", elemental)
+	}
+
+	ritualIDs := make([]string, 0)
+	for _, ritual := range manager.RitualRegistry.GetBaseRituals() {
+		ritualIDs = append(ritualIDs, ritual.ID)
+	}
+	counts := countIDs(ritualIDs)
+	for id, count := range counts {
+		if count > 1 {
+			t.Errorf("base ritual %q appears %d times", id, count)
+		}
+	}
+	if counts["pack_frost_rite"] != 1 || counts["base_forest"] != 1 {
+		t.Errorf("base rituals %v, want the pack ritual next to the defaults", ritualIDs)
+	}
+}
+
+func TestInvalidPackIsRejected(t *testing.T) {
+	fsys := testPackFS()
+	fsys["symbols/broken.json"] = &fstest.MapFile{Data: []byte(`{"ID": "broken", "SymbolType": "unknown", "Power": 2}`)}
+
+	if _, err := LoadPackFS(fsys); err == nil || !strings.Contains(err.Error(), `symbol "broken"`) {
+		t.Errorf("LoadPackFS error = %v, want a validation error for the broken symbol", err)
+	}
+}
+
+func TestPackRejectsDuplicateIDs(t *testing.T) {
+	pack, err := LoadPackFS(testPackFS())
+	if err != nil {
+		t.Fatalf("failed to load pack: %v", err)
+	}
+
+	pack.Rituals = append(pack.Rituals, pack.Rituals[0])
+	if err := pack.Validate(); err == nil || !strings.Contains(err.Error(), "duplicate ID") {
+		t.Errorf("Validate() = %v, want a duplicate ID error", err)
+	}
+}
+
+// firstTreeStability генерирует чанк мира и возвращает стабильность первого дерева и биом чанка
+func firstTreeStability(t *testing.T, pack *ContentPack, x int) (float64, string) {
+	t.Helper()
+
+	ecsWorld := ecs.NewWorld()
+	w := world.NewWorld(1, ecsWorld, metamorphosis.NewMetamorphosisManager(ecsWorld, ""))
+	if pack != nil {
+		pack.ApplyToBiomes(w.BiomeMap)
+	}
+
+	chunk := w.GetChunkAt(x, 0)
+	for _, id := range chunk.Entities {
+		entity, exists := ecsWorld.GetEntity(id)
+		if !exists || !entity.HasTag(world.TagTree) {
+			continue
+		}
+		meta, _ := ecs.ComponentAs[*ecs.MetamorphicComponent](entity, ecs.MetamorphicComponentID)
+		return meta.Stability, chunk.BiomeType
+	}
+	return 0, chunk.BiomeType
+}
+
+func TestPackBiomeIsUsedByGeneration(t *testing.T) {
+	fsys := testPackFS()
+	fsys["biomes/taiga.json"] = &fstest.MapFile{Data: []byte(`{"Type": "taiga", "Name": "Dead Taiga", "StabilityModifier": 0.5}`)}
+	pack, err := LoadPackFS(fsys)
+	if err != nil {
+		t.Fatalf("failed to load pack: %v", err)
+	}
+	if len(pack.Biomes) != 1 || pack.Biomes[0].Name != "Dead Taiga" {
+		t.Fatalf("pack biomes = %+v, want the dead taiga", pack.Biomes)
+	}
+
+	// Чанк (-3, 0) первого мира лежит в тайге
+	plain, biome := firstTreeStability(t, nil, -3)
+	if biome != "taiga" || plain == 0 {
+		t.Fatalf("chunk (-3, 0) is %s with tree stability %v, want a taiga chunk with trees", biome, plain)
+	}
+	dead, _ := firstTreeStability(t, pack, -3)
+	if diff := dead - plain*0.5; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("tree stability %.3f in the pack's taiga, want half of the default %.3f", dead, plain)
+	}
+}
+
+func TestPackRejectsUnknownBiomes(t *testing.T) {
+	fsys := testPackFS()
+	fsys["biomes/glacier.json"] = &fstest.MapFile{Data: []byte(`{"Type": "glacier", "Name": "Glacier"}`)}
+
+	if _, err := LoadPackFS(fsys); err == nil || !strings.Contains(err.Error(), `biome "glacier"`) {
+		t.Errorf("LoadPackFS error = %v, want the unsupported biome reported", err)
+	}
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// DebugSnapshot - сводка состояния всех систем для отладочного оверлея
+type DebugSnapshot struct {
+	Taken time.Time
+
+	// Директор страха
+	TensionLevel int
+	TensionName  string
+	TensionValue float64
+	TensionPhase string
+
+	// Метаморфозы
+	TransformationPhase int
+	AnomalyBudget       float64
+	MaxAnomalyBudget    float64
+	AnomalyLevel        float64                          // Глобальный уровень аномальности мира
+	ActiveEffects       map[metamorphosis.OrderLevel]int // Число активных эффектов по порядкам
+
+	// Знания игрока
+	DiscoveredSymbols int
+	DiscoveredRituals int
+
+	// Мир и игрок
+	Weather        string
+	TimeOfDay      float64
+	Day            int
+	PlayerPosition ecs.Vector3
+}
+
+// DebugSnapshot собирает состояние систем в одну структуру для отладочного
+// оверлея, чтобы оверлею не приходилось опрашивать каждый менеджер отдельно.
+// Снимок собирается под блокировкой состояния игры на чтение, поэтому все его
+// поля относятся к одному и тому же шагу симуляции.
+func (g *Game) DebugSnapshot() DebugSnapshot {
+	g.stateMutex.RLock()
+	defer g.stateMutex.RUnlock()
+
+	snapshot := DebugSnapshot{
+		Taken:         g.clock.Now(),
+		ActiveEffects: make(map[metamorphosis.OrderLevel]int),
+	}
+
+	if g.fearMgr != nil {
+		snapshot.TensionLevel = g.fearMgr.GetTensionLevel()
+		snapshot.TensionName = g.fearMgr.GetTensionName()
+		snapshot.TensionValue = g.fearMgr.GetTensionValue()
+		snapshot.TensionPhase = g.fearMgr.GetTensionPhase()
+	}
+
+	if g.metamorph != nil {
+		snapshot.TransformationPhase = g.metamorph.GetTransformationPhase()
+		snapshot.AnomalyBudget = g.metamorph.GetAnomalyBudget()
+		snapshot.MaxAnomalyBudget = g.metamorph.GetMaxBudget()
+		for _, effect := range g.metamorph.GetActiveEffects() {
+			snapshot.ActiveEffects[effect.Order]++
+		}
+	}
+
+	if g.symbolMgr != nil {
+		snapshot.DiscoveredSymbols = len(g.symbolMgr.Registry.GetDiscoveredSymbols())
+		snapshot.DiscoveredRituals = len(g.symbolMgr.RitualRegistry.GetDiscoveredRituals())
+	}
+
+	if g.world != nil {
+		snapshot.AnomalyLevel = g.world.GetGlobalAnomalyLevel()
+		snapshot.Weather = g.world.GetWeatherCondition()
+		snapshot.TimeOfDay = g.world.GetGlobalTimeOfDay()
+		snapshot.Day = g.world.Day
+	}
+
+	if g.player != nil {
+		snapshot.PlayerPosition = g.player.GetPosition()
+	}
+
+	return snapshot
+}
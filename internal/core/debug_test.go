@@ -0,0 +1,71 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"echo-taiga/internal/ai/fear"
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// newDebugTestGame создает игру только с директором страха и менеджером метаморфоз
+func newDebugTestGame(t *testing.T) *Game {
+	t.Helper()
+
+	ecsWorld := ecs.NewWorld()
+	metamorph := metamorphosis.NewMetamorphosisManager(ecsWorld, t.TempDir())
+	templates := t.TempDir()
+	template := `{"ID": "shimmer", "Name": "Shimmer", "Description": "The air shimmers", "Order": 1, "Category": "visual", "Intensity": 0.5, "Duration": 600000000000}`
+	if err := os.WriteFile(filepath.Join(templates, "shimmer.json"), []byte(template), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := metamorph.LoadEffectTemplates(templates); err != nil {
+		t.Fatalf("LoadEffectTemplates: %v", err)
+	}
+
+	fearMgr := fear.NewDirector(ecsWorld, t.TempDir())
+	fearMgr.AddScareTemplate(fear.ScareEvent{Type: "ambient_dread", Intensity: 1.0, Duration: 30, Cooldown: 60})
+
+	game := &Game{ecsWorld: ecsWorld, metamorph: metamorph, fearMgr: fearMgr}
+	game.SetClock(engine.NewFakeClock(time.Date(2026, 1, 1, 22, 0, 0, 0, time.UTC)))
+	return game
+}
+
+func TestDebugSnapshotFollowsScaresAndMetamorphoses(t *testing.T) {
+	g := newDebugTestGame(t)
+	before := g.DebugSnapshot()
+	if want := g.clock.Now(); !before.Taken.Equal(want) {
+		t.Errorf("snapshot taken at %v, want the game clock's %v", before.Taken, want)
+	}
+
+	if !g.fearMgr.ForceScare("ambient_dread") {
+		t.Fatalf("scare was not triggered")
+	}
+	for i := 0; i < 10; i++ {
+		g.fearMgr.Update(1)
+	}
+
+	if _, err := g.metamorph.RequestEffect(metamorphosis.EffectRequest{
+		Order:      metamorphosis.OrderFirst,
+		Categories: []string{"visual"},
+		Radius:     10,
+		Intensity:  0.5,
+	}); err != nil {
+		t.Fatalf("RequestEffect: %v", err)
+	}
+
+	after := g.DebugSnapshot()
+	if after.TensionValue <= before.TensionValue {
+		t.Errorf("tension %v did not rise above %v after a scare", after.TensionValue, before.TensionValue)
+	}
+	if got, want := after.ActiveEffects[metamorphosis.OrderFirst], before.ActiveEffects[metamorphosis.OrderFirst]+1; got != want {
+		t.Errorf("%d first-order effects after a metamorphosis, want %d", got, want)
+	}
+	if after.AnomalyBudget >= before.AnomalyBudget {
+		t.Errorf("anomaly budget %v was not spent (before %v)", after.AnomalyBudget, before.AnomalyBudget)
+	}
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"echo-taiga/internal/ai/fear"
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/world"
+)
+
+// heavierWeather - погода, в которую усиливается текущая при испуге
+var heavierWeather = map[string]string{
+	"clear":  "rain",
+	"cloudy": "rain",
+	"fog":    "rain",
+	"rain":   "storm",
+}
+
+// scareEnvironment применяет изменения окружения, которые вызывают испуги:
+// усиливает дождь, ломает деревья и кусты, оставляет локальные метаморфозы
+type scareEnvironment struct {
+	world     *world.World
+	metamorph *metamorphosis.MetamorphosisManager
+
+	// Аренды погоды по ID испуга - их снимают по окончании испуга
+	weatherLeases map[string]uint64
+}
+
+// newScareEnvironment создает исполнителя изменений окружения для директора страха
+func newScareEnvironment(gameWorld *world.World, metamorphMgr *metamorphosis.MetamorphosisManager) *scareEnvironment {
+	return &scareEnvironment{
+		world:         gameWorld,
+		metamorph:     metamorphMgr,
+		weatherLeases: make(map[string]uint64),
+	}
+}
+
+// ApplyEnvironmentEffect реализует fear.EnvironmentEffector
+func (se *scareEnvironment) ApplyEnvironmentEffect(scare fear.ScareEvent) bool {
+	switch scare.EnvironmentEffect {
+	case fear.EnvironmentRainIntensify:
+		heavier, exists := heavierWeather[se.world.WeatherCondition]
+		if !exists {
+			return false
+		}
+		se.weatherLeases[scare.ID] = se.world.LeaseWeather(heavier, scare.Duration, "scare:"+scare.ID)
+		return true
+
+	case fear.EnvironmentObjectBreak:
+		if !se.loaded(scare) {
+			return false
+		}
+		se.world.BreakNearestObject(scare.StartPosition, scare.EffectRadius)
+
+	case fear.EnvironmentMetamorph:
+		if !se.loaded(scare) {
+			return false
+		}
+		se.metamorph.RequestEffect(metamorphosis.EffectRequest{
+			Order:      metamorphosis.OrderFirst,
+			Categories: []string{"environment"},
+			Center:     scare.StartPosition,
+			Radius:     scare.EffectRadius,
+			Intensity:  scare.Intensity,
+			Source:     "scare:" + scare.ID,
+		})
+	}
+	return false
+}
+
+// loaded перепроверяет перед изменением окружения, что испуг не ушел за
+// пределы загруженных чанков
+func (se *scareEnvironment) loaded(scare fear.ScareEvent) bool {
+	return se.world.IsPositionLoaded(scare.StartPosition)
+}
+
+// RevertEnvironmentEffect реализует fear.EnvironmentEffector
+func (se *scareEnvironment) RevertEnvironmentEffect(scare fear.ScareEvent) {
+	lease, exists := se.weatherLeases[scare.ID]
+	if !exists {
+		return
+	}
+	delete(se.weatherLeases, scare.ID)
+
+	// Аренда могла уже истечь, тогда снимать нечего
+	se.world.ReleaseWeather(lease)
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"time"
+
+	"echo-taiga/internal/ai/fear"
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// scareMetamorphs передает менеджеру метаморфоз запросы директора страха:
+// тень, напугавшая игрока, оставляет после себя искаженную растительность
+type scareMetamorphs struct {
+	manager *metamorphosis.MetamorphosisManager
+}
+
+// RequestMetamorph реализует fear.MetamorphRequester
+func (sm scareMetamorphs) RequestMetamorph(request fear.MetamorphRequest) (string, bool) {
+	effectID, err := sm.manager.RequestEffect(metamorphosis.EffectRequest{
+		Order:      metamorphosis.OrderLevel(request.Order),
+		Categories: []string{"environment", "visual"},
+		Center:     request.Center,
+		Radius:     request.Radius,
+		Intensity:  request.Intensity,
+		Source:     "scare:" + request.ScareID,
+	})
+	return effectID, err == nil
+}
+
+// symbolHallucinations передает менеджеру метаморфоз галлюцинации игрока, слишком
+// долго смотревшего на запретные символы: окружение вокруг него искажается
+type symbolHallucinations struct {
+	manager *metamorphosis.MetamorphosisManager
+}
+
+// RequestHallucination реализует symbols.HallucinationRequester
+func (sh symbolHallucinations) RequestHallucination(center ecs.Vector3, radius, intensity float64, duration time.Duration, symbolID string) bool {
+	_, err := sh.manager.RequestEffect(metamorphosis.EffectRequest{
+		Order:      metamorphosis.OrderFirst,
+		Categories: []string{"visual"},
+		Center:     center,
+		Radius:     radius,
+		Intensity:  intensity,
+		Duration:   duration,
+		Source:     "symbol:" + symbolID,
+	})
+	return err == nil
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"echo-taiga/internal/ai/fear"
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+func TestTerrifyingScareLeavesMetamorphosis(t *testing.T) {
+	ecsWorld := ecs.NewWorld()
+	metamorph := metamorphosis.NewMetamorphosisManager(ecsWorld, t.TempDir())
+	metamorph.SetTransformationPhase(2)
+	metamorph.RegisterEffectTemplate(&metamorphosis.MetamorphEffect{
+		ID: "twisted_growth", Name: "Twisted Growth", Order: metamorphosis.OrderSecond, Category: "environment", Intensity: 0.5,
+	})
+
+	director := fear.NewDirector(ecsWorld, t.TempDir())
+	director.SetMetamorphRequester(scareMetamorphs{manager: metamorph})
+	director.AddScareTemplate(fear.ScareEvent{Type: "entity", Subtype: "shade", Intensity: 0.6, Cooldown: 30, EffectRadius: 10})
+
+	var assessed fear.ScareEvent
+	director.OnScareAssessed = func(scare fear.ScareEvent, effectiveness float64) { assessed = scare }
+
+	// Испуг, от которого игрок в ужасе
+	if !director.ForceScare("entity") {
+		t.Fatalf("entity scare was not triggered")
+	}
+	director.RecordPlayerAction(fear.PlayerAction{Type: fear.ActionRunning, EmotionalState: fear.EmotionTerrified})
+
+	if assessed.MetamorphID == "" {
+		t.Fatalf("scare %s is not linked to a metamorphosis", assessed.ID)
+	}
+	effect, exists := metamorph.GetEffect(assessed.MetamorphID)
+	if !exists || effect.Name != "Twisted Growth" {
+		t.Fatalf("linked effect %s is not the active Twisted Growth", assessed.MetamorphID)
+	}
+
+	// История метаморфоз ссылается на испуг
+	for _, entry := range metamorph.GetHistory() {
+		if entry.EffectID == effect.ID && entry.Action == "requested" && strings.Contains(entry.Description, "scare:"+assessed.ID) {
+			return
+		}
+	}
+	t.Errorf("metamorphosis history has no request by scare %s", assessed.ID)
+}
@@ -0,0 +1,32 @@
+//go:build dev
+
+package core
+
+import (
+	"testing"
+
+	"echo-taiga/internal/config"
+	"echo-taiga/internal/engine/framebudget"
+)
+
+// BenchmarkGameTick - один шаг симуляции игры без окна и отрисовки
+func BenchmarkGameTick(b *testing.B) {
+	cfg := config.DefaultConfig()
+	cfg.Seed = 1
+	cfg.SaveDir = b.TempDir()
+
+	game, err := newGame(cfg, newProgressAggregator(loadingWeights), nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	step := game.ticker.Step()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		game.tick(step)
+	}
+}
+
+func TestGameTickBudget(t *testing.T) {
+	framebudget.Check(t, "GameTick", BenchmarkGameTick)
+}
@@ -1,18 +1,24 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"echo-taiga/internal/ai/fear"
+	"echo-taiga/internal/ai/threat"
 	"echo-taiga/internal/audio"
 	"echo-taiga/internal/config"
+	"echo-taiga/internal/content"
 	"echo-taiga/internal/engine"
 	"echo-taiga/internal/engine/ecs"
 	"echo-taiga/internal/entities/player"
 	"echo-taiga/internal/metamorphosis"
 	"echo-taiga/internal/render"
+	"echo-taiga/internal/savefile"
 	"echo-taiga/internal/symbols"
+	"echo-taiga/internal/telemetry"
 	"echo-taiga/internal/world"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -21,6 +27,7 @@ import (
 // Game представляет полную игровую структуру
 type Game struct {
 	config    *config.Config
+	saveSlot  *SaveSlot
 	ecsWorld  *ecs.World
 	engine    *engine.Engine
 	world     *world.World
@@ -30,14 +37,51 @@ type Game struct {
 	fearMgr   *fear.Director
 	symbolMgr *symbols.Manager
 	metamorph *metamorphosis.MetamorphosisManager
+	threats   *threat.System
+	clock     engine.Clock
+	sanity    *threat.SanitySystem
+	telemetry *telemetry.Telemetry
+	scheduler *Scheduler
+	ticker    *engine.FixedStep
 
 	isRunning      bool
 	lastUpdateTime time.Time
+
+	// Шаг симуляции держит блокировку на запись, поэтому снимок состояния
+	// (см. DebugSnapshot) никогда не застает шаг на середине
+	stateMutex sync.RWMutex
+
+	// Сообщения для интерфейса; без обработчика сообщение не показывается
+	OnVisions      func(visions []symbols.Vision) // Видения ритуала предвидения (пустой список - будущее скрыто)
+	OnJournalEntry func(entry string)             // Новая запись журнала
+	OnError        func(err error)                // Сбои, после которых игра продолжается
 }
 
-// NewGame создает новый экземпляр игры
-func NewGame(cfg *config.Config) (*Game, error) {
-	// Инициализируем ECS мир
+// NewGame создает новый экземпляр игры.
+// Пакеты контента накладываются поверх стандартного контента в указанном порядке.
+func NewGame(cfg *config.Config, packs ...*content.ContentPack) (*Game, error) {
+	progress := newProgressAggregator(loadingWeights)
+	game, err := newGame(cfg, progress, packs)
+	if err != nil {
+		return nil, err
+	}
+	if err := game.initPresentation(progress.subsystem(LoadingPresentation)); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// newGame создает игру, сообщая о прогрессе инициализации подсистем
+func newGame(cfg *config.Config, progress *progressAggregator, packs []*content.ContentPack) (*Game, error) {
+	// Пакеты, собранные в коде, не проходили проверку LoadPackFS
+	for _, pack := range packs {
+		if err := pack.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Инициализируем ECS мир; массовые компоненты берутся из пулов, если они не выключены
+	ecs.SetComponentPooling(cfg.ComponentPooling)
 	ecsWorld := ecs.NewWorld()
 
 	// Создаем мир с определенным сидом
@@ -45,7 +89,151 @@ func NewGame(cfg *config.Config) (*Game, error) {
 	if seed == 0 {
 		seed = time.Now().UnixNano()
 	}
-	gameWorld := world.NewWorld(seed, ecsWorld)
+
+	// Все подсистемы сохраняют состояние в каталог активного слота; сохранения
+	// сжимаются, если не включен читаемый режим для отладки
+	saveSlot := NewSaveSlot(cfg.SaveDir, cfg.SaveSlot)
+	savefile.SetReadable(cfg.ReadableSaves)
+
+	// Создаем менеджер метаморфоз
+	metamorphMgr := metamorphosis.NewMetamorphosisManager(ecsWorld, saveSlot.MetamorphosisPath())
+
+	// У каждой подсистемы свой поток случайных чисел из сида мира: они не делят
+	// глобальный генератор и воспроизводятся независимо друг от друга
+	metamorphMgr.SetSeed(seed)
+
+	// Сработавшие триггеры возвращаются после паузы, чтобы мир продолжал меняться
+	triggers := metamorphosis.DefaultTriggerConfig()
+	triggers.MaxAvailable = cfg.MetamorphTriggerLimit
+	triggers.TemplateCooldown = cfg.MetamorphTriggerCooldown
+	if err := metamorphMgr.SetTriggerConfig(triggers); err != nil {
+		return nil, err
+	}
+
+	if err := metamorphMgr.InitWithProgress(progress.subsystem(LoadingMetamorphosis)); err != nil {
+		return nil, fmt.Errorf("failed to initialize metamorphosis manager: %v", err)
+	}
+	for _, pack := range packs {
+		pack.ApplyToMetamorphosis(metamorphMgr)
+	}
+
+	gameWorld := world.NewWorld(seed, ecsWorld, metamorphMgr)
+	gameWorld.SetSafeZoneRadius(cfg.SafeZoneRadius)
+	gameWorld.SetEntityDensityScale(cfg.EntityDensityScale)
+	for _, pack := range packs {
+		pack.ApplyToBiomes(gameWorld.BiomeMap)
+	}
+
+	// Метаморфозы окрашивают сущности в цвета палитр; доступный режим оставляет
+	// только цвета, различимые при нарушениях цветового зрения
+	gameWorld.ColorblindPalettes = cfg.ColorblindPalettes
+
+	// Форма пятен порчи задается фрактальным шумом
+	anomalyNoise := world.DefaultAnomalyNoiseParams()
+	anomalyNoise.Octaves = cfg.AnomalyNoiseOctaves
+	anomalyNoise.Lacunarity = cfg.AnomalyNoiseLacunarity
+	anomalyNoise.Persistence = cfg.AnomalyNoisePersistence
+	if err := gameWorld.SetAnomalyNoiseParams(anomalyNoise); err != nil {
+		return nil, err
+	}
+
+	// Порча расползается между чанками с проводимостью их биомов
+	conductivity := world.DefaultAnomalyConductivity()
+	for biome, value := range cfg.AnomalyConductivity {
+		conductivity[biome] = value
+	}
+	if err := gameWorld.SetAnomalyConductivity(conductivity); err != nil {
+		return nil, err
+	}
+
+	// Размещение символов продолжается с сохраненного состояния
+	if err := gameWorld.LoadSymbolPlan(saveSlot.WorldPath()); err != nil {
+		return nil, fmt.Errorf("failed to load symbol plan: %v", err)
+	}
+
+	// Якоря стабильности восстанавливаются вместе со своими чанками
+	if err := gameWorld.LoadAnchors(saveSlot.WorldPath()); err != nil {
+		return nil, fmt.Errorf("failed to load anchors: %v", err)
+	}
+
+	// Измененный рельеф возвращается чанкам при их активации
+	if err := gameWorld.LoadTerrainDiffs(saveSlot.WorldPath()); err != nil {
+		return nil, fmt.Errorf("failed to load terrain diffs: %v", err)
+	}
+
+	// Метки целей заданий и ритуалов
+	if err := gameWorld.LoadMarkers(saveSlot.WorldPath()); err != nil {
+		return nil, fmt.Errorf("failed to load markers: %v", err)
+	}
+
+	// Сутки и время суток продолжаются с сохранения
+	if err := gameWorld.LoadTime(saveSlot.WorldPath()); err != nil {
+		return nil, fmt.Errorf("failed to load world time: %v", err)
+	}
+
+	// Погода, навязанная ритуалами и испугами, действует и после загрузки
+	if err := gameWorld.LoadWeather(saveSlot.WorldPath()); err != nil {
+		return nil, fmt.Errorf("failed to load weather: %v", err)
+	}
+
+	// Создаем менеджер символов
+	symbolMgr := symbols.NewManager(ecsWorld, saveSlot.SymbolsPath())
+	err := symbolMgr.SetGenerationConfig(symbols.GenerationConfig{
+		SymbolsPerType:   cfg.SymbolsPerType,
+		MinRitualSymbols: cfg.MinRitualSymbols,
+		MaxRitualSymbols: cfg.MaxRitualSymbols,
+		MaxRitualEffects: cfg.MaxRitualEffects,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Почти удачный бросок дает ослабленные эффекты ритуала с осложнением
+	partialSuccess := symbols.DefaultPartialSuccessConfig()
+	partialSuccess.Margin = cfg.RitualPartialMargin
+	if err := symbolMgr.SetPartialSuccessConfig(partialSuccess); err != nil {
+		return nil, err
+	}
+
+	// Шаги ритуала, выполненные не по порядку, грозят провалом; в простом режиме они не проверяются
+	actions := symbols.DefaultActionConfig()
+	actions.SimpleMode = cfg.SimpleRituals
+	if err := symbolMgr.SetActionConfig(actions); err != nil {
+		return nil, err
+	}
+
+	// Неиспользованные сгенерированные символы и ритуалы со временем удаляются из сохранений
+	prune := symbols.DefaultPruneConfig()
+	prune.Enabled = cfg.SavePruneDays > 0
+	if prune.Enabled {
+		prune.MaxAge = time.Duration(cfg.SavePruneDays * float64(24*time.Hour))
+	}
+	if err := symbolMgr.SetPruneConfig(prune); err != nil {
+		return nil, err
+	}
+
+	// Символы на пути игрока открываются по одному и только если игрок их увидел или коснулся
+	discovery := symbols.DefaultDiscoveryConfig()
+	discovery.MaxPerUpdate = cfg.SymbolDiscoveryBudget
+	discovery.Cooldown = cfg.SymbolDiscoveryCooldown
+	discovery.FieldOfView = cfg.SymbolDiscoveryFOV
+	if err := symbolMgr.SetDiscoveryConfig(discovery); err != nil {
+		return nil, err
+	}
+
+	// Сид мира определяет рунные слова, имена сгенерированных символов и броски ритуалов
+	symbolMgr.SetWorldSeed(seed)
+	for _, pack := range packs {
+		symbolMgr.AddBaseContent(pack)
+	}
+	if err := symbolMgr.InitializeWithProgress(progress.subsystem(LoadingSymbols)); err != nil {
+		return nil, fmt.Errorf("failed to initialize symbol manager: %v", err)
+	}
+
+	// Символы, вырезанные в мире, берутся из реестра, поэтому область появления
+	// генерируется только после его загрузки
+	gameWorld.SetSymbolCatalog(symbolMgr.Registry)
+	gameWorld.GenerateSpawnRegion(ecs.Vector3{X: 0, Y: 0, Z: 0}, progress.subsystem(LoadingWorld))
 
 	// Создаем игрока
 	playerEntity, err := player.CreatePlayerEntity(ecsWorld, gameWorld)
@@ -53,50 +241,258 @@ func NewGame(cfg *config.Config) (*Game, error) {
 		return nil, fmt.Errorf("failed to create player: %v", err)
 	}
 
-	// Создаем менеджер символов
-	symbolMgr := symbols.NewSymbolManager()
-	err = symbolMgr.Initialize(worldSeed)
-	if err := symbolMgr.Initialize(worldSeed); err != nil {
-		return nil, err
+	// Изучение символов повышает локальную аномальность
+	symbolMgr.SetAnomalyReceiver(metamorphMgr)
+
+	// Древние круги камней открывают слухи о ритуалах; слух попадает в кодекс
+	gameWorld.OnAncientSiteInteract = func(actor, site *ecs.Entity) bool {
+		siteComp, has := site.GetComponent(ecs.AncientSiteComponentID)
+		if !has {
+			return false
+		}
+		symbolMgr.InteractWithAncientSite(siteComp.(*ecs.AncientSiteComponent))
+		return true
+	}
+
+	// Изучая вырезанный символ, игрок перерисовывает его на табличку, которая
+	// резонирует с другими надписями и кругами того же символа
+	gameWorld.OnSymbolInteract = func(actor, inscription *ecs.Entity) bool {
+		symbolComp, has := ecs.ComponentAs[*ecs.SymbolComponent](inscription, ecs.SymbolComponentID)
+		if !has {
+			return false
+		}
+		_, err := symbolMgr.CopyToTablet(actor, symbolComp.SymbolID)
+		return err == nil
 	}
 
+	// Ритуалы предвидения показывают прогноз метаморфоз
+	symbolMgr.SetVisionSource(metamorphVisions{manager: metamorphMgr})
+
+	// Ритуалы лечат игрока, восстанавливают рассудок и силы, а неудачи ранят
+	symbolMgr.SetPlayerBridge(symbols.NewPlayerBridge(ecsWorld))
+
+	// Рельеф скрывает символы от взгляда игрока
+	symbolMgr.SetLineOfSight(gameWorld)
+
+	// Ритуалы ставят и заряжают якоря стабильности
+	symbolMgr.SetAnchorKeeper(gameWorld)
+
+	// Погодные ритуалы на время навязывают погоду, которую зовет их главный символ
+	symbolMgr.SetWeatherController(gameWorld)
+
+	// Ритуалы знания отмечают открытые символы на карте и показывают поле аномальности
+	symbolMgr.SetSymbolMarker(gameWorld)
+	symbolMgr.SetAreaRevealer(gameWorld)
+
+	// Символы резонируют с временем суток и погодой и пульсируют светом в резонансе
+	symbolMgr.SetEnvironmentSource(gameWorld)
+	ecsWorld.AddSystem(symbols.NewResonanceSystem(symbolMgr))
+
+	// Увиденные игроком сильные метаморфозы открывают связанные символы
+	metamorphMgr.SetLineOfSight(gameWorld)
+	metamorphMgr.OnEffectWitnessed = witnessSymbols(symbolMgr)
+
+	// Эффекты с правилами ai.spawn.* порождают существ и аномалии в мире
+	metamorphMgr.SetEntitySpawner(gameWorld)
+
+	// Ритуалы и рунные слова призывают духов, зажигают свет и вызывают метаморфозы
+	symbolMgr.SetRitualEffectApplier(gameWorld)
+
 	// Создаем менеджер страха
-	fearMgr := fear.NewFearDirector(ecsWorld, "saves/fear")
-	err = fearMgr.Initialize()
+	fearMgr := fear.NewDirector(ecsWorld, saveSlot.FearPath())
+	fearMgr.SetSeed(seed)
+	err = fearMgr.InitializeWithProgress(progress.subsystem(LoadingFear))
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize fear manager: %v", err)
 	}
+	for _, pack := range packs {
+		pack.ApplyToFear(fearMgr)
+	}
+
+	// Первые минуты нового мира проходят без резких пугалок; загруженная игра
+	// продолжает льготный период с сохраненного места
+	if _, err := saveSlot.ReadManifest(); err != nil {
+		fearMgr.BeginGracePeriod()
+	}
+	onDiscovered := symbolMgr.OnSymbolDiscovered
+	symbolMgr.OnSymbolDiscovered = func(symbol *symbols.Symbol) {
+		fearMgr.NoteGraceMilestone()
+		if onDiscovered != nil {
+			onDiscovered(symbol)
+		}
+	}
+
+	// Игрок, уводящий существ шумом, чувствует себя хозяином положения
+	playerEntity.OnDiversion = func(position ecs.Vector3, maker engine.NoiseMaker) {
+		fearMgr.RecordDiversion(position)
+	}
+
+	// Самые удачные пугалки оставляют в мире метаморфозы
+	fearMgr.SetMetamorphRequester(scareMetamorphs{manager: metamorphMgr})
+
+	// Испуги меняют погоду, ломают деревья и искажают окружение
+	fearMgr.SetEnvironmentEffector(newScareEnvironment(gameWorld, metamorphMgr))
+
+	// Испуги гасят, заставляют мигать и окрашивают источники света вокруг себя
+	scareLights := newScareLighting(ecsWorld)
+	fearMgr.SetLightingEffector(scareLights)
+	ecsWorld.AddSystem(scareLights)
+
+	// Испуги и порождения ритуалов не выходят за пределы загруженных чанков
+	fearMgr.SetLoadedArea(gameWorld)
+	fearMgr.SetEntitySpawner(gameWorld)
+	symbolMgr.SetLoadedArea(gameWorld)
+
+	// Действия игрока помечаются временем суток мира
+	fearMgr.SetDayClock(gameWorld)
+
+	// Опасные биомы вроде болота поднимают фоновое напряжение
+	biomeDanger := fear.DefaultBiomeDanger()
+	for biome, value := range cfg.BiomeDanger {
+		biomeDanger[biome] = value
+	}
+	if err := fearMgr.SetBiomeDanger(biomeDanger); err != nil {
+		return nil, err
+	}
+
+	// Обереги ритуалов создают безопасные зоны для директора страха
+	symbolMgr.SetWardReceiver(fearMgr)
+
+	// Директор страха учитывает произнесение рунных слов
+	symbolMgr.SetCastReceiver(fearMgr)
+
+	// Запретные символы мстят тем, кто смотрит на них слишком долго
+	symbolMgr.SetHallucinationRequester(symbolHallucinations{manager: metamorphMgr})
+	symbolMgr.SetStalkerSummoner(fearMgr)
+
+	// Ритуалы и видения не прерываются пугалками
+	symbolMgr.SetScareSuppressor(ritualScareSuppressor{director: fearMgr})
+
+	// Запланированные события игрового времени переживают сохранение и загрузку
+	scheduler := NewScheduler()
+	scheduler.Redeliver = cfg.SchedulerRedelivery
+	if err := scheduler.Load(saveSlot.SchedulerPath()); err != nil {
+		return nil, fmt.Errorf("failed to load scheduled events: %v", err)
+	}
+
+	// Погода сменяется раз в WeatherPeriod игрового времени, буря объявляется заранее
+	handleWeather(scheduler, gameWorld)
+
+	// Пока игрока не было, тайга продолжала меняться
+	if cfg.OfflineCatchUp {
+		catchUpAwayTime(awayTime(saveSlot, time.Now(), cfg.OfflineCatchUpMaxHours), gameWorld, metamorphMgr, symbolMgr)
+	}
+
+	// Оценка угроз игроку: реальная опасность повышает напряжение
+	threatSys := threat.NewSystem(ecsWorld, metamorphMgr, gameWorld)
+	ecsWorld.AddSystem(threatSys)
+	fearMgr.SetThreatSource(threatSys)
+
+	// Аномалии, сильные метаморфозы, пугающие события и угрозы подтачивают рассудок,
+	// а в безопасной зоне, у костра или под укрытием он восстанавливается
+	sanitySys := threat.NewSanitySystem(ecsWorld, metamorphMgr, fearMgr, threatSys, gameWorld)
+	ecsWorld.AddSystem(sanitySys)
+
+	// Телеметрия плейтестов собирается, только если задан каталог выгрузки
+	var sessionTelemetry *telemetry.Telemetry
+	if cfg.TelemetryDir != "" {
+		sessionTelemetry = telemetry.New()
+		attachTelemetry(sessionTelemetry, fearMgr, symbolMgr, metamorphMgr, threatSys)
+	}
 
 	// Создаем аудио менеджер
+	presentationProgress := progress.subsystem(LoadingPresentation)
+	presentationProgress.ReportProgress("audio", 0)
 	audioMgr := audio.NewManager()
 
-	// Создаем рендерер
-	renderer, err := render.NewRenderer(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create renderer: %v", err)
+	// Табличка, зазвучавшая в унисон с начертанием своего символа, отзывается тихим звоном
+	symbolMgr.OnTabletResonanceBegan = func(resonance symbols.TabletResonance) {
+		audioMgr.PlaySound("tablet_resonance")
+	}
+
+	// Удавшийся ритуал, которым игрок овладел, завершается особым звуком
+	symbolMgr.OnSessionEnded = func(session *symbols.RitualSession) {
+		if session.MasteryCue {
+			audioMgr.PlaySound("ritual_mastery_cue")
+		}
 	}
 
-	// Создаем движок
+	// Рельеф глушит звуки существ и аномалий, скрытых за холмами
+	ecsWorld.AddSystem(audio.NewOcclusionSystem(ecsWorld, gameWorld))
+	ecsWorld.AddSystem(audio.NewPlaybackSystem(ecsWorld, audioMgr))
+
+	// Создаем движок; физика идет по правилам мира, сведенным из глобальных метаморфоз
 	gameEngine := engine.NewEngine(ecsWorld)
+	gameEngine.SetWorldRules(metamorphMgr.GetEffectiveWorldRules)
 
 	game := &Game{
 		config:         cfg,
+		saveSlot:       saveSlot,
 		ecsWorld:       ecsWorld,
 		world:          gameWorld,
 		player:         playerEntity,
-		renderer:       renderer,
 		audioMgr:       audioMgr,
 		fearMgr:        fearMgr,
 		symbolMgr:      symbolMgr,
-		metamorph:      gameWorld.MetamorphManager,
+		metamorph:      metamorphMgr,
+		threats:        threatSys,
+		sanity:         sanitySys,
+		telemetry:      sessionTelemetry,
+		scheduler:      scheduler,
+		ticker:         engine.NewFixedStep(cfg.SimulationTickRate),
 		engine:         gameEngine,
 		isRunning:      false,
 		lastUpdateTime: time.Now(),
 	}
 
+	// Подсистемы идут по одним часам; тесты подменяют их на engine.FakeClock
+	game.SetClock(engine.RealClock{})
+
+	// Система, раз за разом падающая с паникой, отключается; сохраняем игру,
+	// пока остальное состояние еще цело
+	ecsWorld.OnSystemQuarantined = func(system string, err error) {
+		game.reportError(fmt.Errorf("system %s was disabled after repeated failures: %v", system, err))
+		game.reportError(game.saveGameState())
+	}
+
+	// Журнал хранит предания открытых символов, замеченные издалека знаки и
+	// ранги мастерства ритуалов; записи показывает интерфейс, подписанный на OnJournalEntry
+	symbolMgr.OnFirstDiscovery = func(symbol *symbols.Symbol, lore string, firstOfType bool) {
+		game.writeJournal(loreEntries(symbol, lore, firstOfType)...)
+	}
+	symbolMgr.OnSymbolGlimpsed = func(symbol *symbols.Symbol) {
+		game.writeJournal(glimpseEntry(symbol))
+	}
+	symbolMgr.OnMasteryRankUp = func(ritual *symbols.Ritual, rank int) {
+		game.writeJournal(masteryRankUpEntry(ritual, rank))
+	}
+
+	// Видения показывает интерфейс, подписанный на OnVisions
+	symbolMgr.OnVisionsGranted = func(visions []symbols.Vision) {
+		if game.OnVisions != nil {
+			game.OnVisions(visions)
+		}
+	}
+
 	return game, nil
 }
 
+// initPresentation создает рендерер. Изображения ebiten создаются в главном
+// потоке, поэтому этот этап не уходит в горутину загрузки вместе с newGame.
+func (g *Game) initPresentation(progress engine.ProgressReporter) error {
+	progress.ReportProgress("renderer", 0.5)
+	renderer, err := render.NewRenderer(g.config)
+	if err != nil {
+		return fmt.Errorf("failed to create renderer: %v", err)
+	}
+	g.renderer = renderer
+	progress.ReportProgress("renderer", 1)
+
+	// Отладочная команда world.heatmap переключает тепловую карту рендерера
+	g.world.OnHeatmapToggled = renderer.SetHeatmapEnabled
+	return nil
+}
+
 // Update обновляет состояние игры
 func (g *Game) Update() error {
 	// Вычисляем время между кадрами
@@ -104,9 +500,39 @@ func (g *Game) Update() error {
 	deltaTime := now.Sub(g.lastUpdateTime).Seconds()
 	g.lastUpdateTime = now
 
+	// Симуляция идет шагами постоянной длины, сколько бы кадров ни успело пройти
+	for ticks := g.ticker.Advance(deltaTime); ticks > 0; ticks-- {
+		g.tick(g.ticker.Step())
+	}
+
+	// Отрисовка сглаживает движение между последними шагами
+	g.renderer.SetInterpolation(g.ticker.Alpha())
+
+	stats := g.ticker.Stats()
+	g.telemetry.SetTickStats(stats.TicksPerSecond, stats.DroppedTicks)
+
+	return nil
+}
+
+// tick выполняет один шаг симуляции длиной deltaTime
+func (g *Game) tick(deltaTime float64) {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+
+	// Запоминаем положения до шага, чтобы отрисовка могла сгладить движение
+	for _, entity := range g.ecsWorld.GetEntitiesWithComponent(ecs.TransformComponentID) {
+		if transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID); has {
+			transform.PreviousPosition = transform.Position
+		}
+	}
+
 	// Обновляем мир
 	g.world.Update(deltaTime)
 
+	// Доставляем наступившие запланированные события; игровое время течет
+	// с темпом, заданным метаморфозами (правило time.flow)
+	g.scheduler.Advance(deltaTime * g.metamorph.TimeFlow())
+
 	// Обновляем движок ECS
 	g.ecsWorld.Update(deltaTime)
 
@@ -116,10 +542,18 @@ func (g *Game) Update() error {
 	// Обновляем систему метаморфоз
 	g.metamorph.Update(deltaTime)
 
-	// Обновляем менеджер страха
+	// Обновляем менеджер страха с учетом биома, в котором стоит игрок
+	position := g.player.GetPosition()
+	if biome, generated := g.world.BiomeAtPosition(position.X, position.Z); generated {
+		g.fearMgr.SetCurrentBiome(biome)
+	}
 	g.fearMgr.Update(deltaTime)
 
-	return nil
+	// Погибший игрок возрождается, а тайга начинает новый цикл
+	if g.player.IsDead() {
+		g.metamorph.RecordPlayerDeath()
+		g.player.Respawn(g.world)
+	}
 }
 
 // Draw отрисовывает игровой мир
@@ -154,23 +588,97 @@ func (g *Game) Run() error {
 	return nil
 }
 
-// Stop останавливает игру
-func (g *Game) Stop() {
+// Stop останавливает игру, сохраняя состояние и телеметрию сессии.
+// Возвращает все сбои сохранения и выгрузки.
+func (g *Game) Stop() error {
 	g.isRunning = false
 
-	// Сохраняем состояние
-	g.saveGameState()
+	// Сохраняем состояние и выгружаем телеметрию сессии
+	return errors.Join(g.saveGameState(), g.exportTelemetry())
 }
 
-// saveGameState сохраняет текущее состояние игры
-func (g *Game) saveGameState() {
+// reportError передает сбой обработчику OnError
+func (g *Game) reportError(err error) {
+	if err != nil && g.OnError != nil {
+		g.OnError(err)
+	}
+}
+
+// saveGameState сохраняет текущее состояние игры. Сбой одной подсистемы не
+// мешает сохранить остальные; возвращаются все сбои.
+func (g *Game) saveGameState() error {
+	var errs []error
+	check := func(what string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to save %s: %v", what, err))
+		}
+	}
+
 	// Сохраняем состояние мира
+	check("symbol plan", g.world.SaveSymbolPlan(g.saveSlot.WorldPath()))
+	check("anchors", g.world.SaveAnchors(g.saveSlot.WorldPath()))
+	check("terrain diffs", g.world.SaveTerrainDiffs(g.saveSlot.WorldPath()))
+	check("markers", g.world.SaveMarkers(g.saveSlot.WorldPath()))
+	check("world time", g.world.SaveTime(g.saveSlot.WorldPath()))
+	check("weather", g.world.SaveWeather(g.saveSlot.WorldPath()))
+
 	// Сохраняем состояние метаморфоз
-	g.metamorph.SaveState()
+	check("metamorphosis state", g.metamorph.SaveState())
 
 	// Сохраняем состояние символов
-	g.symbolMgr.SaveState()
+	check("symbol state", g.symbolMgr.SaveState())
+
+	// Сохраняем профили игрока и ход льготного периода директора страха
+	check("fear profiles", g.fearMgr.SaveProfiles())
+
+	// Сохраняем запланированные события
+	check("scheduled events", g.scheduler.Save(g.saveSlot.SchedulerPath()))
+
+	// Время сохранения нужно, чтобы промотать время отсутствия при следующей загрузке
+	check("slot manifest", g.saveSlot.WriteManifest(SlotManifest{SavedAt: time.Now()}))
 
 	// Сохраняем состояние игрока
 	// TODO: Реализовать сохранение состояния игрока
+
+	return errors.Join(errs...)
+}
+
+// SetClock задает общий источник времени для движка, игрока, мира, символов,
+// директора страха и метаморфоз: отметки открытий, перезарядки испугов и атак,
+// сроки эффектов и снимки отладки идут по одним часам. Подсистемы, которых у игры нет, пропускаются.
+func (g *Game) SetClock(clock engine.Clock) {
+	g.clock = clock
+	if g.engine != nil {
+		g.engine.SetClock(clock)
+	}
+	if g.player != nil {
+		g.player.SetClock(clock)
+	}
+	if g.world != nil {
+		g.world.SetClock(clock)
+	}
+	if g.symbolMgr != nil {
+		g.symbolMgr.SetClock(clock)
+	}
+	if g.fearMgr != nil {
+		g.fearMgr.SetClock(clock)
+	}
+	if g.metamorph != nil {
+		g.metamorph.SetClock(clock)
+	}
+}
+
+// GetThreats возвращает систему оценки угроз (для интерфейса и ИИ)
+func (g *Game) GetThreats() *threat.System {
+	return g.threats
+}
+
+// TickStats возвращает статистику шагов симуляции (частота, отброшенные шаги)
+func (g *Game) TickStats() engine.TickStats {
+	return g.ticker.Stats()
+}
+
+// GetSanity возвращает систему рассудка игрока (для интерфейса и подписки на пороги)
+func (g *Game) GetSanity() *threat.SanitySystem {
+	return g.sanity
 }
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"fmt"
+
+	"echo-taiga/internal/symbols"
+)
+
+// masteryRankNames - названия рангов мастерства ритуалов для журнала
+var masteryRankNames = map[int]string{
+	symbols.MasteryApprentice: "apprentice",
+	symbols.MasteryAdept:      "adept",
+	symbols.MasteryExpert:     "expert",
+	symbols.MasteryMaster:     "master",
+}
+
+// writeJournal передает записи журнала интерфейсу, подписанному на OnJournalEntry
+func (g *Game) writeJournal(entries ...string) {
+	if g.OnJournalEntry == nil {
+		return
+	}
+	for _, entry := range entries {
+		g.OnJournalEntry(entry)
+	}
+}
+
+// loreEntries возвращает записи журнала о впервые открытом символе: новый
+// тип знаков и предание символа
+func loreEntries(symbol *symbols.Symbol, lore string, firstOfType bool) []string {
+	entries := make([]string, 0, 2)
+	if firstOfType {
+		entries = append(entries, fmt.Sprintf("A new kind of sign - %s (%s)", symbol.Name, symbol.SymbolType))
+	}
+	if lore != "" {
+		entries = append(entries, lore)
+	}
+	return entries
+}
+
+// glimpseEntry возвращает запись журнала о символе, замеченном издалека
+func glimpseEntry(symbol *symbols.Symbol) string {
+	return fmt.Sprintf("Something carved in the distance, like %s", symbol.Name)
+}
+
+// masteryRankUpEntry возвращает запись журнала о новом ранге мастерства ритуала
+func masteryRankUpEntry(ritual *symbols.Ritual, rank int) string {
+	name, exists := masteryRankNames[rank]
+	if !exists {
+		name = fmt.Sprintf("rank %d", rank)
+	}
+	return fmt.Sprintf("You are now %s of %s", withArticle(name), ritual.Name)
+}
+
+// withArticle добавляет к названию ранга неопределенный артикль
+func withArticle(name string) string {
+	switch name[0] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return "an " + name
+	}
+	return "a " + name
+}
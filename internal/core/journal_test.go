@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+
+	"echo-taiga/internal/symbols"
+)
+
+func TestJournalEntriesReachTheSubscriber(t *testing.T) {
+	var entries []string
+	game := &Game{OnJournalEntry: func(entry string) { entries = append(entries, entry) }}
+
+	symbol := &symbols.Symbol{Name: "Antler", SymbolType: "protection"}
+	game.writeJournal(loreEntries(symbol, "Carved by those who waited out the winter", true)...)
+	game.writeJournal(glimpseEntry(symbol))
+	game.writeJournal(masteryRankUpEntry(&symbols.Ritual{Name: "Mist Veil"}, symbols.MasteryAdept))
+
+	want := []string{
+		"A new kind of sign - Antler (protection)",
+		"Carved by those who waited out the winter",
+		"Something carved in the distance, like Antler",
+		"You are now an adept of Mist Veil",
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("journal got %q, want %q", entries, want)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d = %q, want %q", i, entries[i], want[i])
+		}
+	}
+
+	// Без подписчика записи просто не показываются
+	(&Game{}).writeJournal(glimpseEntry(symbol))
+}
+
+func TestLoreEntriesSkipWhatIsNotNew(t *testing.T) {
+	symbol := &symbols.Symbol{Name: "Antler", SymbolType: "protection"}
+	if entries := loreEntries(symbol, "", false); len(entries) != 0 {
+		t.Errorf("known type without lore wrote %q, want nothing", entries)
+	}
+	if got := masteryRankUpEntry(&symbols.Ritual{Name: "Mist Veil"}, 9); got != "You are now a rank 9 of Mist Veil" {
+		t.Errorf("unnamed rank entry = %q", got)
+	}
+}
@@ -0,0 +1,204 @@
+package core
+
+import (
+	"image/color"
+	"math"
+	"sync"
+
+	"echo-taiga/internal/ai/fear"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Сила изменений света при испуге полной интенсивности
+const (
+	scareDarkening     = 0.8 // Доля силы света, которую гасит затемнение
+	scareShadowDimming = 0.3 // Доля силы света, которую гасят движущиеся тени
+	scareFlashBoost    = 1.0 // Прибавка к силе света во вспышке
+)
+
+// scareShiftColor - болезненный оттенок, к которому испуги сдвигают цвет света
+var scareShiftColor = color.RGBA{R: 120, G: 200, B: 140, A: 255}
+
+// savedLight - состояние источника света до испуга
+type savedLight struct {
+	intensity  float64
+	flickering bool
+	color      color.RGBA
+}
+
+// lightingRequest - изменение или восстановление света для испуга, ждущее
+// обновления мира ECS
+type lightingRequest struct {
+	scare  fear.ScareEvent
+	revert bool
+}
+
+// scareLighting меняет свет вокруг испугов: гасит источники при затемнении,
+// заставляет мигать во вспышке и восстанавливает их по окончании испуга.
+// Директор страха только ставит изменения в очередь, а компоненты света
+// меняются в Update как у любой системы ECS.
+type scareLighting struct {
+	world *ecs.World
+
+	pendingMutex sync.Mutex
+	pending      []lightingRequest
+
+	// Исходное состояние измененных источников по ID испуга. Источник, уже
+	// измененный другим испугом, не трогается, чтобы восстановление не путалось.
+	saved map[string]map[ecs.EntityID]savedLight
+	held  map[ecs.EntityID]string
+}
+
+// newScareLighting создает исполнителя световых эффектов для директора страха
+func newScareLighting(world *ecs.World) *scareLighting {
+	return &scareLighting{
+		world: world,
+		saved: make(map[string]map[ecs.EntityID]savedLight),
+		held:  make(map[ecs.EntityID]string),
+	}
+}
+
+// ApplyLightingEffect реализует fear.LightingEffector
+func (sl *scareLighting) ApplyLightingEffect(scare fear.ScareEvent) bool {
+	if _, known := lightingChange(scare.LightingEffect, scare.Intensity); !known {
+		return false
+	}
+	sl.queue(lightingRequest{scare: scare})
+	return true
+}
+
+// RevertLightingEffect реализует fear.LightingEffector
+func (sl *scareLighting) RevertLightingEffect(scare fear.ScareEvent) {
+	sl.queue(lightingRequest{scare: scare, revert: true})
+}
+
+// queue ставит изменение света в очередь до следующего обновления
+func (sl *scareLighting) queue(request lightingRequest) {
+	sl.pendingMutex.Lock()
+	defer sl.pendingMutex.Unlock()
+
+	sl.pending = append(sl.pending, request)
+}
+
+// RequiredComponents реализует ecs.System
+func (sl *scareLighting) RequiredComponents() []ecs.ComponentID {
+	return []ecs.ComponentID{ecs.LightComponentID}
+}
+
+// Update применяет накопленные изменения света в порядке поступления
+func (sl *scareLighting) Update(deltaTime float64) {
+	sl.pendingMutex.Lock()
+	pending := sl.pending
+	sl.pending = nil
+	sl.pendingMutex.Unlock()
+
+	for _, request := range pending {
+		if request.revert {
+			sl.revert(request.scare)
+		} else {
+			sl.apply(request.scare)
+		}
+	}
+}
+
+// apply меняет источники света в радиусе испуга, запоминая их исходное состояние
+func (sl *scareLighting) apply(scare fear.ScareEvent) {
+	change, known := lightingChange(scare.LightingEffect, scare.Intensity)
+	if !known {
+		return
+	}
+
+	saved := make(map[ecs.EntityID]savedLight)
+	for _, entity := range sl.world.GetEntitiesWithComponent(ecs.LightComponentID) {
+		if _, taken := sl.held[entity.ID]; taken || !inScareRadius(entity, scare) {
+			continue
+		}
+		light, has := ecs.ComponentAs[*ecs.LightComponent](entity, ecs.LightComponentID)
+		if !has {
+			continue
+		}
+
+		saved[entity.ID] = savedLight{intensity: light.Intensity, flickering: light.Flickering, color: light.Color}
+		sl.held[entity.ID] = scare.ID
+		change(light)
+	}
+
+	if len(saved) > 0 {
+		sl.saved[scare.ID] = saved
+	}
+}
+
+// revert восстанавливает источники света, измененные испугом
+func (sl *scareLighting) revert(scare fear.ScareEvent) {
+	saved, exists := sl.saved[scare.ID]
+	if !exists {
+		return
+	}
+	delete(sl.saved, scare.ID)
+
+	for id, state := range saved {
+		delete(sl.held, id)
+
+		// Источник мог исчезнуть вместе с сущностью
+		entity, exists := sl.world.GetEntity(id)
+		if !exists {
+			continue
+		}
+		if light, has := ecs.ComponentAs[*ecs.LightComponent](entity, ecs.LightComponentID); has {
+			light.Intensity = state.intensity
+			light.Flickering = state.flickering
+			light.Color = state.color
+		}
+	}
+}
+
+// lightingChange возвращает изменение источника света для эффекта испуга
+// заданной интенсивности; false для неизвестного эффекта
+func lightingChange(effect string, intensity float64) (func(light *ecs.LightComponent), bool) {
+	intensity = math.Max(0.0, math.Min(1.0, intensity))
+
+	switch effect {
+	case fear.LightingDarkening:
+		return func(light *ecs.LightComponent) {
+			light.Intensity *= 1.0 - scareDarkening*intensity
+		}, true
+	case fear.LightingFlash:
+		return func(light *ecs.LightComponent) {
+			light.Intensity *= 1.0 + scareFlashBoost*intensity
+			light.Flickering = true
+		}, true
+	case fear.LightingShadowMovement, fear.LightingShadowAcross:
+		return func(light *ecs.LightComponent) {
+			light.Intensity *= 1.0 - scareShadowDimming*intensity
+			light.Flickering = true
+		}, true
+	case fear.LightingColorShift:
+		return func(light *ecs.LightComponent) {
+			light.Color = blendColor(light.Color, scareShiftColor, intensity)
+		}, true
+	case fear.LightingSubtlePulsing:
+		return func(light *ecs.LightComponent) {
+			light.Flickering = true
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+// inScareRadius проверяет, попадает ли сущность в радиус действия испуга.
+// Испуг без радиуса достает везде.
+func inScareRadius(entity *ecs.Entity, scare fear.ScareEvent) bool {
+	if scare.EffectRadius <= 0 {
+		return true
+	}
+	transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
+	return has && transform.Position.Distance(scare.StartPosition) <= scare.EffectRadius
+}
+
+// blendColor смешивает цвет с целевым на долю amount, сохраняя прозрачность
+func blendColor(base, target color.RGBA, amount float64) color.RGBA {
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*amount))
+	}
+	return color.RGBA{R: mix(base.R, target.R), G: mix(base.G, target.G), B: mix(base.B, target.B), A: base.A}
+}
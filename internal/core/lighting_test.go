@@ -0,0 +1,57 @@
+package core
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"echo-taiga/internal/ai/fear"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// addLight добавляет в мир источник света в точке
+func addLight(world *ecs.World, position ecs.Vector3) *ecs.LightComponent {
+	entity := ecs.NewEntity()
+	entity.AddComponent(ecs.NewTransformComponent(position))
+	light := ecs.NewLightComponent(color.RGBA{R: 255, G: 200, B: 150, A: 255}, 1.0, 10.0)
+	entity.AddComponent(light)
+	world.AddEntity(entity)
+	return light
+}
+
+func TestScareLightingChangesLightsOnlyOnUpdate(t *testing.T) {
+	world := ecs.NewWorld()
+	near := addLight(world, ecs.Vector3{X: 2})
+	far := addLight(world, ecs.Vector3{X: 50})
+	lighting := newScareLighting(world)
+
+	scare := fear.ScareEvent{ID: "darkening_1", Intensity: 1.0, EffectRadius: 10, LightingEffect: fear.LightingDarkening}
+	if !lighting.ApplyLightingEffect(scare) {
+		t.Fatalf("darkening was not accepted")
+	}
+	if near.Intensity != 1.0 {
+		t.Fatalf("light changed outside the ECS update")
+	}
+
+	lighting.Update(0)
+	if want := 1.0 - scareDarkening; math.Abs(near.Intensity-want) > 1e-9 {
+		t.Errorf("near light intensity = %v, want %v", near.Intensity, want)
+	}
+	if far.Intensity != 1.0 {
+		t.Errorf("far light intensity = %v, want it untouched", far.Intensity)
+	}
+
+	lighting.RevertLightingEffect(scare)
+	lighting.Update(0)
+	if math.Abs(near.Intensity-1.0) > 1e-9 {
+		t.Errorf("near light intensity = %v after revert, want 1", near.Intensity)
+	}
+}
+
+func TestScareLightingIgnoresUnknownEffects(t *testing.T) {
+	lighting := newScareLighting(ecs.NewWorld())
+
+	if lighting.ApplyLightingEffect(fear.ScareEvent{ID: "odd_1", LightingEffect: "sparkle"}) {
+		t.Errorf("unknown lighting effect was accepted")
+	}
+}
@@ -0,0 +1,177 @@
+package core
+
+import (
+	"sync"
+
+	"echo-taiga/internal/config"
+	"echo-taiga/internal/content"
+	"echo-taiga/internal/engine"
+)
+
+// Подсистемы, сообщающие о прогрессе загрузки
+const (
+	LoadingMetamorphosis = "metamorphosis"
+	LoadingWorld         = "world"
+	LoadingSymbols       = "symbols"
+	LoadingFear          = "fear"
+	LoadingPresentation  = "presentation"
+)
+
+// loadingWeights задает долю каждой подсистемы в общем прогрессе загрузки
+var loadingWeights = map[string]float64{
+	LoadingMetamorphosis: 0.25,
+	LoadingWorld:         0.35,
+	LoadingSymbols:       0.25,
+	LoadingFear:          0.1,
+	LoadingPresentation:  0.05,
+}
+
+// LoadingProgress описывает текущее состояние загрузки для экрана загрузки
+type LoadingProgress struct {
+	Subsystem string  // Подсистема, сообщившая о прогрессе
+	Phase     string  // Текущий этап загрузки
+	Fraction  float64 // Общий прогресс загрузки (0-1), никогда не уменьшается
+	Done      bool    // Загрузка завершена
+}
+
+// progressAggregator собирает прогресс подсистем во взвешенный общий прогресс
+type progressAggregator struct {
+	weights   map[string]float64
+	fractions map[string]float64
+	last      float64
+	updates   chan LoadingProgress
+	mutex     sync.Mutex
+}
+
+// newProgressAggregator создает агрегатор с указанными весами подсистем
+func newProgressAggregator(weights map[string]float64) *progressAggregator {
+	return &progressAggregator{
+		weights:   weights,
+		fractions: make(map[string]float64),
+		updates:   make(chan LoadingProgress, 1),
+	}
+}
+
+// subsystem возвращает репортер прогресса для подсистемы
+func (pa *progressAggregator) subsystem(name string) engine.ProgressReporter {
+	return &subsystemProgress{aggregator: pa, name: name}
+}
+
+// report обновляет прогресс подсистемы и публикует общий прогресс
+func (pa *progressAggregator) report(name, phase string, fraction float64) {
+	pa.mutex.Lock()
+	defer pa.mutex.Unlock()
+
+	if fraction < 0 {
+		fraction = 0
+	} else if fraction > 1 {
+		fraction = 1
+	}
+
+	// Прогресс подсистемы не откатывается назад
+	if fraction > pa.fractions[name] {
+		pa.fractions[name] = fraction
+	}
+
+	totalWeight := 0.0
+	weighted := 0.0
+	for subsystem, weight := range pa.weights {
+		totalWeight += weight
+		weighted += weight * pa.fractions[subsystem]
+	}
+
+	aggregate := 0.0
+	if totalWeight > 0 {
+		aggregate = weighted / totalWeight
+	}
+	if aggregate < pa.last {
+		aggregate = pa.last
+	}
+	pa.last = aggregate
+
+	pa.publish(LoadingProgress{Subsystem: name, Phase: phase, Fraction: aggregate})
+}
+
+// finish публикует завершение загрузки и закрывает канал
+func (pa *progressAggregator) finish() {
+	pa.mutex.Lock()
+	defer pa.mutex.Unlock()
+
+	pa.last = 1
+	pa.publish(LoadingProgress{Phase: "done", Fraction: 1, Done: true})
+	close(pa.updates)
+}
+
+// publish отправляет обновление, заменяя непрочитанное.
+// Должен вызываться с захваченным мьютексом.
+func (pa *progressAggregator) publish(update LoadingProgress) {
+	select {
+	case <-pa.updates:
+	default:
+	}
+	pa.updates <- update
+}
+
+// subsystemProgress передает прогресс подсистемы в агрегатор
+type subsystemProgress struct {
+	aggregator *progressAggregator
+	name       string
+}
+
+// ReportProgress реализует engine.ProgressReporter
+func (sp *subsystemProgress) ReportProgress(phase string, fraction float64) {
+	sp.aggregator.report(sp.name, phase, fraction)
+}
+
+// Loader загружает игру в отдельной горутине, чтобы экран загрузки мог показывать прогресс.
+// Рендерер создается в Wait, в горутине вызывающего.
+type Loader struct {
+	progress *progressAggregator
+	done     chan struct{}
+	finish   sync.Once
+	game     *Game
+	err      error
+}
+
+// StartLoading запускает создание игры в фоне
+func StartLoading(cfg *config.Config, packs ...*content.ContentPack) *Loader {
+	loader := &Loader{
+		progress: newProgressAggregator(loadingWeights),
+		done:     make(chan struct{}),
+	}
+
+	go func() {
+		loader.game, loader.err = newGame(cfg, loader.progress, packs)
+		close(loader.done)
+	}()
+
+	return loader
+}
+
+// Progress возвращает канал обновлений прогресса. В канале всегда лежит
+// только последнее обновление; канал закрывается, когда Wait завершит загрузку.
+func (l *Loader) Progress() <-chan LoadingProgress {
+	return l.progress.updates
+}
+
+// Done возвращает канал, который закрывается после фоновой части загрузки;
+// после этого главный поток вызывает Wait
+func (l *Loader) Done() <-chan struct{} {
+	return l.done
+}
+
+// Wait ожидает фоновую часть загрузки, создает рендерер и возвращает игру.
+// Вызывается из главного потока.
+func (l *Loader) Wait() (*Game, error) {
+	<-l.done
+	l.finish.Do(func() {
+		if l.err == nil {
+			l.err = l.game.initPresentation(l.progress.subsystem(LoadingPresentation))
+		}
+		if l.err != nil {
+			l.game = nil
+		}
+		l.progress.finish()
+	})
+	return l.game, l.err
+}
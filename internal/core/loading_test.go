@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadingProgressNeverDecreasesAndReachesOne(t *testing.T) {
+	progress := newProgressAggregator(loadingWeights)
+
+	go func() {
+		// Медленная синтетическая подсистема сообщает о каждом шаге с паузой
+		slow := progress.subsystem(LoadingWorld)
+		for i := 0; i <= 20; i++ {
+			slow.ReportProgress("chunks", float64(i)/20)
+			time.Sleep(time.Millisecond)
+		}
+
+		// Откат прогресса подсистемы не уменьшает общий прогресс
+		slow.ReportProgress("chunks", 0.5)
+
+		for _, name := range []string{LoadingMetamorphosis, LoadingSymbols, LoadingFear, LoadingPresentation} {
+			progress.subsystem(name).ReportProgress("done", 1)
+		}
+		progress.finish()
+	}()
+
+	last := 0.0
+	updates := 0
+	var final LoadingProgress
+	for update := range progress.updates {
+		if update.Fraction < last {
+			t.Fatalf("progress went back from %.3f to %.3f (%s/%s)", last, update.Fraction, update.Subsystem, update.Phase)
+		}
+		last = update.Fraction
+		final = update
+		updates++
+	}
+
+	if updates < 2 {
+		t.Errorf("received %d progress updates, want the slow subsystem's steps", updates)
+	}
+	if !final.Done || final.Fraction != 1 {
+		t.Errorf("final update %+v, want done at 1.0", final)
+	}
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"time"
+
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/symbols"
+	"echo-taiga/internal/world"
+)
+
+// awayTime возвращает время, прошедшее с последнего сохранения слота, не больше maxHours.
+// Без манифеста (новый слот) время отсутствия нулевое.
+func awayTime(slot *SaveSlot, now time.Time, maxHours float64) time.Duration {
+	manifest, err := slot.ReadManifest()
+	if err != nil || manifest.SavedAt.IsZero() {
+		return 0
+	}
+
+	elapsed := now.Sub(manifest.SavedAt)
+	limit := time.Duration(maxHours * float64(time.Hour))
+	if elapsed > limit {
+		elapsed = limit
+	}
+	if elapsed < 0 {
+		return 0
+	}
+	return elapsed.Truncate(time.Second)
+}
+
+// catchUpAwayTime проматывает мир на время отсутствия игрока: каждая система
+// применяет свои обычные правила (см. FastForward), а возникшие без игрока
+// метаморфозы попадают в историю с действием "offline"
+func catchUpAwayTime(elapsed time.Duration, gameWorld *world.World, metamorphMgr *metamorphosis.MetamorphosisManager, symbolMgr *symbols.Manager) {
+	if elapsed <= 0 {
+		return
+	}
+	deltaTime := elapsed.Seconds()
+
+	gameWorld.FastForward(deltaTime)
+	metamorphMgr.FastForward(deltaTime, gameWorld.AnomalyHotspots())
+	symbolMgr.FastForward(deltaTime)
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"echo-taiga/internal/savefile"
+)
+
+// Подкаталоги подсистем внутри слота сохранения
+const (
+	MetamorphosisSaveDir = "metamorphosis"
+	SymbolsSaveDir       = "symbols"
+	FearSaveDir          = "fear"
+	WorldSaveDir         = "world"
+	ManifestFile         = "manifest.json"
+	SchedulerFile        = "scheduler.json"
+)
+
+// SlotManifest - общие сведения о сохранении в слоте
+type SlotManifest struct {
+	SavedAt time.Time        `json:"saved_at"`        // Когда слот сохранялся в последний раз
+	Sizes   map[string]int64 `json:"sizes,omitempty"` // Размер сохранений подсистем на диске (в байтах)
+}
+
+// SaveSlot описывает слот сохранения и выдает пути для подсистем
+type SaveSlot struct {
+	Name string // Имя слота
+	Root string // Корневой каталог слота
+}
+
+// NewSaveSlot создает слот сохранения в указанном базовом каталоге
+func NewSaveSlot(baseDir, name string) *SaveSlot {
+	if name == "" {
+		name = "default"
+	}
+
+	return &SaveSlot{
+		Name: name,
+		Root: filepath.Join(baseDir, name),
+	}
+}
+
+// Path возвращает каталог подсистемы внутри слота
+func (s *SaveSlot) Path(subsystem string) string {
+	return filepath.Join(s.Root, subsystem)
+}
+
+// MetamorphosisPath возвращает каталог сохранений системы метаморфоз
+func (s *SaveSlot) MetamorphosisPath() string {
+	return s.Path(MetamorphosisSaveDir)
+}
+
+// SymbolsPath возвращает каталог сохранений символов и ритуалов
+func (s *SaveSlot) SymbolsPath() string {
+	return s.Path(SymbolsSaveDir)
+}
+
+// FearPath возвращает каталог сохранений директора страха
+func (s *SaveSlot) FearPath() string {
+	return s.Path(FearSaveDir)
+}
+
+// WorldPath возвращает каталог сохранений мира
+func (s *SaveSlot) WorldPath() string {
+	return s.Path(WorldSaveDir)
+}
+
+// ManifestPath возвращает путь к манифесту слота
+func (s *SaveSlot) ManifestPath() string {
+	return filepath.Join(s.Root, ManifestFile)
+}
+
+// SchedulerPath возвращает путь к сохранению планировщика событий
+func (s *SaveSlot) SchedulerPath() string {
+	return filepath.Join(s.Root, SchedulerFile)
+}
+
+// ReadManifest загружает манифест слота
+func (s *SaveSlot) ReadManifest() (*SlotManifest, error) {
+	data, err := savefile.Read(s.ManifestPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest SlotManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// WriteManifest сохраняет манифест слота вместе с размерами сохранений подсистем
+func (s *SaveSlot) WriteManifest(manifest SlotManifest) error {
+	manifest.Sizes = s.sizes()
+
+	data, err := savefile.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Root, os.ModePerm); err != nil {
+		return err
+	}
+	return savefile.Write(s.ManifestPath(), data)
+}
+
+// sizes возвращает объем файлов каждой подсистемы слота, включая резервные копии
+// и контрольные суммы
+func (s *SaveSlot) sizes() map[string]int64 {
+	sizes := make(map[string]int64)
+	for _, subsystem := range []string{MetamorphosisSaveDir, SymbolsSaveDir, FearSaveDir, WorldSaveDir} {
+		filepath.Walk(s.Path(subsystem), func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() {
+				sizes[subsystem] += info.Size()
+			}
+			return nil
+		})
+	}
+
+	matches, _ := filepath.Glob(s.SchedulerPath() + "*")
+	for _, path := range matches {
+		if info, err := os.Stat(path); err == nil {
+			sizes["scheduler"] += info.Size()
+		}
+	}
+	return sizes
+}
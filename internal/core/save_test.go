@@ -0,0 +1,89 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"echo-taiga/internal/ai/fear"
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/symbols"
+	"echo-taiga/internal/world"
+)
+
+// saveSlotSubsystems сохраняет метаморфозы, символы и директора страха в слот;
+// знание символа marker отличает сохранения разных слотов
+func saveSlotSubsystems(t *testing.T, slot *SaveSlot, seed int64, marker string) {
+	t.Helper()
+
+	ecsWorld := ecs.NewWorld()
+	metamorph := metamorphosis.NewMetamorphosisManager(ecsWorld, slot.MetamorphosisPath())
+	gameWorld := world.NewWorld(seed, ecsWorld, metamorph)
+	if gameWorld.MetamorphManager != metamorph {
+		t.Fatalf("world created its own metamorphosis manager instead of the slot's")
+	}
+	if err := metamorph.SaveState(); err != nil {
+		t.Fatalf("metamorphosis SaveState: %v", err)
+	}
+
+	symbolMgr := symbols.NewManager(ecsWorld, slot.SymbolsPath())
+	symbolMgr.IncreaseKnowledge(marker, 0.5)
+	if err := symbolMgr.SaveState(); err != nil {
+		t.Fatalf("symbols SaveState: %v", err)
+	}
+
+	fearMgr := fear.NewDirector(ecsWorld, slot.FearPath())
+	if err := fearMgr.SaveProfiles(); err != nil {
+		t.Fatalf("fear SaveProfiles: %v", err)
+	}
+}
+
+func TestSaveSlotsKeepSubsystemFilesApart(t *testing.T) {
+	base := t.TempDir()
+	first := NewSaveSlot(base, "first")
+	second := NewSaveSlot(base, "second")
+
+	saveSlotSubsystems(t, first, 1, "marker_first")
+	saveSlotSubsystems(t, second, 2, "marker_second")
+
+	// Все файлы лежат в каталогах подсистем своего слота
+	err := filepath.Walk(base, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		for _, slot := range []*SaveSlot{first, second} {
+			for _, dir := range []string{slot.MetamorphosisPath(), slot.SymbolsPath(), slot.FearPath()} {
+				if strings.HasPrefix(path, dir+string(filepath.Separator)) {
+					return nil
+				}
+			}
+		}
+		t.Errorf("%s was saved outside the subsystem directories of a slot", path)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Каждый слот загружает только свое знание
+	for _, tt := range []struct {
+		slot        *SaveSlot
+		own, others string
+	}{
+		{first, "marker_first", "marker_second"},
+		{second, "marker_second", "marker_first"},
+	} {
+		loaded := symbols.NewManager(ecs.NewWorld(), tt.slot.SymbolsPath())
+		if err := loaded.LoadState(); err != nil {
+			t.Fatalf("slot %s LoadState: %v", tt.slot.Name, err)
+		}
+		if loaded.GetKnowledgeLevel(tt.own) == 0 {
+			t.Errorf("slot %s lost its own knowledge of %s", tt.slot.Name, tt.own)
+		}
+		if loaded.GetKnowledgeLevel(tt.others) != 0 {
+			t.Errorf("slot %s loaded %s saved by the other slot", tt.slot.Name, tt.others)
+		}
+	}
+}
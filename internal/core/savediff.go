@@ -0,0 +1,296 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/symbols"
+	"echo-taiga/internal/world"
+)
+
+// anomalyDiffThreshold - изменения аномальности области меньше этого не попадают в отчет
+const anomalyDiffThreshold = 0.01
+
+// knowledgeDiffThreshold - изменения знаний игрока меньше этого не попадают в отчет
+const knowledgeDiffThreshold = 0.01
+
+// SaveDiff - различия между двумя сохранениями для разбора жалоб игроков
+// ("мой лес за ночь превратился в пустоту")
+type SaveDiff struct {
+	SlotA, SlotB string
+
+	// Метаморфозы
+	PhaseA, PhaseB   int
+	BudgetA, BudgetB float64
+	EffectsAdded     []string // Активные эффекты, появившиеся в B ("ID (шаблон)")
+	EffectsRemoved   []string // Активные эффекты A, которых нет в B
+
+	// Мир по чанкам
+	AnomalyChanges []AreaAnomalyChange
+	TerrainChanges []ChunkTerrainChange
+
+	// Знания игрока
+	SymbolsDiscovered []string // Символы, открытые в B, но не в A
+	SymbolsLost       []string // Символы, открытые в A, но не в B
+	RitualsDiscovered []string
+	RitualsLost       []string
+	KnowledgeChanges  []KnowledgeChange
+}
+
+// AreaAnomalyChange - изменение аномальности области (чанка)
+type AreaAnomalyChange struct {
+	Area          string
+	Before, After float64
+}
+
+// ChunkTerrainChange - изменение клеток террейна чанка
+type ChunkTerrainChange struct {
+	Chunk         [2]int
+	Before, After int // Число измененных после генерации клеток
+	Differing     int // Число клеток, которые в A и B различаются
+}
+
+// KnowledgeChange - изменение знания игрока о символе или ритуале
+type KnowledgeChange struct {
+	ID            string
+	Before, After float64
+}
+
+// savedSlot - состояние слота, прочитанное без запуска игры
+type savedSlot struct {
+	metamorph *metamorphosis.SavedState
+	content   *symbols.SavedContent
+	terrain   map[[2]int][]world.TerrainTile
+}
+
+// readSavedSlot читает сохранения подсистем слота в режиме только для чтения
+func readSavedSlot(slot *SaveSlot) (*savedSlot, error) {
+	metamorph, err := metamorphosis.ReadSavedState(slot.MetamorphosisPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metamorphosis state of %s: %v", slot.Root, err)
+	}
+	content, err := symbols.ReadSavedContent(slot.SymbolsPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read symbols of %s: %v", slot.Root, err)
+	}
+	terrain, err := world.ReadTerrainDiffs(slot.WorldPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read terrain of %s: %v", slot.Root, err)
+	}
+	return &savedSlot{metamorph: metamorph, content: content, terrain: terrain}, nil
+}
+
+// DiffSaves сравнивает два сохранения, не запуская игру. slotA и slotB - каталоги
+// слотов (например, saves/default). Чанки мира генерируются из сида и не
+// сохраняются, поэтому мир сравнивается по аномальности областей и изменениям
+// террейна.
+func DiffSaves(slotA, slotB string) (SaveDiff, error) {
+	savedA, err := readSavedSlot(&SaveSlot{Name: filepath.Base(slotA), Root: slotA})
+	if err != nil {
+		return SaveDiff{}, err
+	}
+	savedB, err := readSavedSlot(&SaveSlot{Name: filepath.Base(slotB), Root: slotB})
+	if err != nil {
+		return SaveDiff{}, err
+	}
+
+	diff := SaveDiff{
+		SlotA:   slotA,
+		SlotB:   slotB,
+		PhaseA:  savedA.metamorph.TransformationPhase,
+		PhaseB:  savedB.metamorph.TransformationPhase,
+		BudgetA: savedA.metamorph.AnomalyBudget,
+		BudgetB: savedB.metamorph.AnomalyBudget,
+	}
+
+	// Активные эффекты
+	for id, template := range savedB.metamorph.ActiveEffects {
+		if _, exists := savedA.metamorph.ActiveEffects[id]; !exists {
+			diff.EffectsAdded = append(diff.EffectsAdded, fmt.Sprintf("%s (%s)", id, template))
+		}
+	}
+	for id, template := range savedA.metamorph.ActiveEffects {
+		if _, exists := savedB.metamorph.ActiveEffects[id]; !exists {
+			diff.EffectsRemoved = append(diff.EffectsRemoved, fmt.Sprintf("%s (%s)", id, template))
+		}
+	}
+
+	// Аномальность областей
+	levelsA, levelsB := localAnomalyLevels(savedA.metamorph), localAnomalyLevels(savedB.metamorph)
+	for area := range unionKeys(levelsA, levelsB) {
+		if before, after := levelsA[area], levelsB[area]; after-before > anomalyDiffThreshold || before-after > anomalyDiffThreshold {
+			diff.AnomalyChanges = append(diff.AnomalyChanges, AreaAnomalyChange{Area: area, Before: before, After: after})
+		}
+	}
+
+	// Террейн
+	for chunk := range unionKeys(savedA.terrain, savedB.terrain) {
+		tilesA, tilesB := savedA.terrain[chunk], savedB.terrain[chunk]
+		if differing := differingTiles(tilesA, tilesB); differing > 0 {
+			diff.TerrainChanges = append(diff.TerrainChanges, ChunkTerrainChange{
+				Chunk: chunk, Before: len(tilesA), After: len(tilesB), Differing: differing,
+			})
+		}
+	}
+
+	// Знания игрока
+	symbolsA, symbolsB := discoveredSymbols(savedA.content), discoveredSymbols(savedB.content)
+	diff.SymbolsDiscovered, diff.SymbolsLost = setDifference(symbolsB, symbolsA), setDifference(symbolsA, symbolsB)
+	ritualsA, ritualsB := discoveredRituals(savedA.content), discoveredRituals(savedB.content)
+	diff.RitualsDiscovered, diff.RitualsLost = setDifference(ritualsB, ritualsA), setDifference(ritualsA, ritualsB)
+	for id := range unionKeys(savedA.content.Knowledge, savedB.content.Knowledge) {
+		if before, after := savedA.content.Knowledge[id], savedB.content.Knowledge[id]; after-before > knowledgeDiffThreshold || before-after > knowledgeDiffThreshold {
+			diff.KnowledgeChanges = append(diff.KnowledgeChanges, KnowledgeChange{ID: id, Before: before, After: after})
+		}
+	}
+
+	diff.sort()
+	return diff, nil
+}
+
+// sort упорядочивает списки отчета, чтобы он не зависел от порядка обхода карт
+func (d *SaveDiff) sort() {
+	sort.Strings(d.EffectsAdded)
+	sort.Strings(d.EffectsRemoved)
+	sort.Slice(d.AnomalyChanges, func(i, j int) bool { return d.AnomalyChanges[i].Area < d.AnomalyChanges[j].Area })
+	sort.Slice(d.TerrainChanges, func(i, j int) bool {
+		a, b := d.TerrainChanges[i].Chunk, d.TerrainChanges[j].Chunk
+		return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+	})
+	sort.Slice(d.KnowledgeChanges, func(i, j int) bool { return d.KnowledgeChanges[i].ID < d.KnowledgeChanges[j].ID })
+}
+
+// Render выводит отчет о различиях в читаемом виде
+func (d SaveDiff) Render(w io.Writer) {
+	fmt.Fprintf(w, "Save diff: %s -> %s\n", d.SlotA, d.SlotB)
+	fmt.Fprintf(w, "Transformation phase: %d -> %d\n", d.PhaseA, d.PhaseB)
+	fmt.Fprintf(w, "Anomaly budget: %.1f -> %.1f\n", d.BudgetA, d.BudgetB)
+
+	renderList(w, "Effects added", d.EffectsAdded)
+	renderList(w, "Effects removed", d.EffectsRemoved)
+
+	if len(d.AnomalyChanges) > 0 {
+		fmt.Fprintf(w, "Anomaly changes (%d areas):\n", len(d.AnomalyChanges))
+		for _, change := range d.AnomalyChanges {
+			fmt.Fprintf(w, "  %s: %.2f -> %.2f\n", change.Area, change.Before, change.After)
+		}
+	}
+	if len(d.TerrainChanges) > 0 {
+		fmt.Fprintf(w, "Terrain changes (%d chunks):\n", len(d.TerrainChanges))
+		for _, change := range d.TerrainChanges {
+			fmt.Fprintf(w, "  %d,%d: %d tiles differ (%d -> %d changed tiles)\n",
+				change.Chunk[0], change.Chunk[1], change.Differing, change.Before, change.After)
+		}
+	}
+
+	renderList(w, "Symbols discovered", d.SymbolsDiscovered)
+	renderList(w, "Symbols lost", d.SymbolsLost)
+	renderList(w, "Rituals discovered", d.RitualsDiscovered)
+	renderList(w, "Rituals lost", d.RitualsLost)
+
+	if len(d.KnowledgeChanges) > 0 {
+		fmt.Fprintf(w, "Knowledge changes (%d):\n", len(d.KnowledgeChanges))
+		for _, change := range d.KnowledgeChanges {
+			fmt.Fprintf(w, "  %s: %.2f -> %.2f\n", change.ID, change.Before, change.After)
+		}
+	}
+}
+
+// String возвращает отчет о различиях в читаемом виде
+func (d SaveDiff) String() string {
+	var sb strings.Builder
+	d.Render(&sb)
+	return sb.String()
+}
+
+// renderList выводит непустой список отчета с заголовком
+func renderList(w io.Writer, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%s (%d):\n", title, len(items))
+	for _, item := range items {
+		fmt.Fprintf(w, "  %s\n", item)
+	}
+}
+
+// differingTiles возвращает число клеток, измененных только в одном из списков
+// или измененных в них по-разному
+func differingTiles(a, b []world.TerrainTile) int {
+	tilesA := make(map[[2]int]world.TerrainTile, len(a))
+	for _, tile := range a {
+		tilesA[[2]int{tile.X, tile.Y}] = tile
+	}
+	tilesB := make(map[[2]int]world.TerrainTile, len(b))
+	for _, tile := range b {
+		tilesB[[2]int{tile.X, tile.Y}] = tile
+	}
+
+	differing := 0
+	for pos := range unionKeys(tilesA, tilesB) {
+		tileA, inA := tilesA[pos]
+		tileB, inB := tilesB[pos]
+		if inA != inB || tileA != tileB {
+			differing++
+		}
+	}
+	return differing
+}
+
+// localAnomalyLevels возвращает сохраненную аномальность областей
+func localAnomalyLevels(state *metamorphosis.SavedState) map[string]float64 {
+	if state.WorldState == nil || state.WorldState.LocalAnomalyLevels == nil {
+		return map[string]float64{}
+	}
+	return state.WorldState.LocalAnomalyLevels
+}
+
+// discoveredSymbols возвращает имена открытых символов по их ID
+func discoveredSymbols(content *symbols.SavedContent) map[string]string {
+	discovered := make(map[string]string)
+	for _, symbol := range content.Symbols {
+		if symbol.IsDiscovered {
+			discovered[symbol.ID] = symbol.Name
+		}
+	}
+	return discovered
+}
+
+// discoveredRituals возвращает имена открытых ритуалов по их ID
+func discoveredRituals(content *symbols.SavedContent) map[string]string {
+	discovered := make(map[string]string)
+	for _, ritual := range content.Rituals {
+		if ritual.IsDiscovered {
+			discovered[ritual.ID] = ritual.Name
+		}
+	}
+	return discovered
+}
+
+// setDifference возвращает отсортированные "имя (ID)" элементов a, которых нет в b
+func setDifference(a, b map[string]string) []string {
+	var result []string
+	for id, name := range a {
+		if _, exists := b[id]; !exists {
+			result = append(result, fmt.Sprintf("%s (%s)", name, id))
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// unionKeys возвращает объединение ключей двух карт
+func unionKeys[K comparable, V any](a, b map[K]V) map[K]bool {
+	keys := make(map[K]bool, len(a)+len(b))
+	for key := range a {
+		keys[key] = true
+	}
+	for key := range b {
+		keys[key] = true
+	}
+	return keys
+}
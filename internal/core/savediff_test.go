@@ -0,0 +1,64 @@
+package core
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// updateGolden перезаписывает эталонные отчеты вместо сравнения с ними
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+func TestDiffSavesMatchesGolden(t *testing.T) {
+	diff, err := DiffSaves("testdata/savediff/a", "testdata/savediff/b")
+	if err != nil {
+		t.Fatalf("DiffSaves: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "savediff", "a_b.golden")
+	if *updateGolden {
+		if err := os.WriteFile(golden, []byte(diff.String()), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := diff.String(); got != string(want) {
+		t.Errorf("report differs from %s:\n--- got\n%s--- want\n%s", golden, got, want)
+	}
+}
+
+func TestDiffSavesComparesTileContents(t *testing.T) {
+	diff, err := DiffSaves("testdata/savediff/a", "testdata/savediff/b")
+	if err != nil {
+		t.Fatalf("DiffSaves: %v", err)
+	}
+
+	// В чанке 0,0 число измененных клеток не поменялось, но одна клетка стала пустотой
+	for _, change := range diff.TerrainChanges {
+		if change.Chunk == [2]int{0, 0} {
+			if change.Before != change.After || change.Differing != 1 {
+				t.Errorf("chunk 0,0: got %+v, want one differing tile with equal counts", change)
+			}
+			return
+		}
+	}
+	t.Errorf("chunk 0,0 is missing from terrain changes %+v", diff.TerrainChanges)
+}
+
+func TestDiffSavesOfSameSlotIsEmpty(t *testing.T) {
+	diff, err := DiffSaves("testdata/savediff/a", "testdata/savediff/a")
+	if err != nil {
+		t.Fatalf("DiffSaves: %v", err)
+	}
+
+	if len(diff.EffectsAdded)+len(diff.EffectsRemoved)+len(diff.AnomalyChanges)+len(diff.TerrainChanges) > 0 {
+		t.Errorf("expected no world changes, got %+v", diff)
+	}
+	if len(diff.SymbolsDiscovered)+len(diff.SymbolsLost)+len(diff.RitualsDiscovered)+len(diff.RitualsLost)+len(diff.KnowledgeChanges) > 0 {
+		t.Errorf("expected no knowledge changes, got %+v", diff)
+	}
+}
@@ -0,0 +1,300 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"sync"
+
+	"echo-taiga/internal/savefile"
+)
+
+// Виды событий погоды (см. scheduleWeatherTurn)
+const (
+	WeatherTurnEvent   = "weather_turn"   // Смена погоды; погода задана в Payload["weather"]
+	StormForecastEvent = "storm_forecast" // Предупреждение о буре за world.StormForecastLead до нее
+)
+
+// ScheduledEvent - событие, запланированное на момент игрового времени.
+// События - это данные, а не функции, чтобы переживать сохранение и загрузку:
+// обработчики подписываются на вид события (см. Scheduler.Handle).
+type ScheduledEvent struct {
+	ID         string            `json:"id"`                // Уникальный идентификатор события
+	Kind       string            `json:"kind"`              // Вид события, по которому выбираются обработчики
+	Payload    map[string]string `json:"payload,omitempty"` // Параметры события
+	Occurrence int               `json:"occurrence"`        // Номер срабатывания периодического события (с нуля)
+}
+
+// ScheduledHandler обрабатывает доставленное событие
+type ScheduledHandler func(event ScheduledEvent)
+
+// scheduledEntry - запланированное срабатывание события
+type scheduledEntry struct {
+	Event    ScheduledEvent `json:"event"`
+	At       float64        `json:"at"`                 // Игровое время срабатывания в секундах
+	Interval float64        `json:"interval,omitempty"` // Период повторения (0 - однократное событие)
+}
+
+// schedulerState - сохраняемое состояние планировщика
+type schedulerState struct {
+	Now      float64           `json:"now"`
+	NextID   int               `json:"next_id"`
+	Pending  []*scheduledEntry `json:"pending"`
+	InFlight []*scheduledEntry `json:"in_flight"` // Доставка начата, но обработка не подтверждена
+}
+
+// Scheduler доставляет события в заданные моменты игрового времени. Игровое время
+// идет только в Advance, поэтому пауза и ускорение времени учитываются сами собой.
+// Срабатывание записывается как начатое до вызова обработчиков и подтверждается
+// после них; сохранение, сделанное между этими моментами, при загрузке либо
+// доставляет событие повторно, либо считает его доставленным (см. Redeliver).
+type Scheduler struct {
+	mutex sync.Mutex
+
+	now      float64
+	nextID   int
+	pending  map[string]*scheduledEntry
+	inFlight map[string]*scheduledEntry
+	handlers map[string][]ScheduledHandler
+
+	// Redeliver - доставлять ли при загрузке события, обработка которых не была подтверждена
+	Redeliver bool
+}
+
+// NewScheduler создает пустой планировщик с нулевым игровым временем
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		pending:  make(map[string]*scheduledEntry),
+		inFlight: make(map[string]*scheduledEntry),
+		handlers: make(map[string][]ScheduledHandler),
+	}
+}
+
+// Now возвращает текущее игровое время планировщика в секундах
+func (s *Scheduler) Now() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.now
+}
+
+// Handle подписывает обработчик на события вида kind
+func (s *Scheduler) Handle(kind string, handler ScheduledHandler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.handlers[kind] = append(s.handlers[kind], handler)
+}
+
+// Schedule планирует событие на момент игрового времени atGameTime. Событие
+// доставляется на ближайшем тике, когда это время наступило, - даже если оно
+// уже в прошлом. Без ID событию присваивается новый; запланированное событие с
+// тем же ID остается как есть, поэтому события, которые ставятся при каждом
+// запуске, не удваиваются после загрузки. Возвращает ID события.
+func (s *Scheduler) Schedule(atGameTime float64, event ScheduledEvent) string {
+	return s.schedule(&scheduledEntry{Event: event, At: atGameTime})
+}
+
+// ScheduleEvery планирует событие, повторяющееся каждые interval секунд игрового
+// времени начиная с текущего момента плюс interval. Возвращает ID события.
+func (s *Scheduler) ScheduleEvery(interval float64, event ScheduledEvent) (string, error) {
+	if interval <= 0 {
+		return "", fmt.Errorf("schedule interval must be positive, got %v", interval)
+	}
+
+	s.mutex.Lock()
+	at := s.now + interval
+	s.mutex.Unlock()
+
+	return s.schedule(&scheduledEntry{Event: event, At: at, Interval: interval}), nil
+}
+
+// schedule добавляет срабатывание в очередь
+func (s *Scheduler) schedule(entry *scheduledEntry) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if entry.Event.ID == "" {
+		s.nextID++
+		entry.Event.ID = fmt.Sprintf("event_%d", s.nextID)
+	}
+	if _, exists := s.pending[entry.Event.ID]; exists {
+		return entry.Event.ID
+	}
+	if _, exists := s.inFlight[entry.Event.ID]; exists {
+		return entry.Event.ID
+	}
+
+	s.pending[entry.Event.ID] = entry
+	return entry.Event.ID
+}
+
+// HasPending проверяет, запланировано ли событие вида kind
+func (s *Scheduler) HasPending(kind string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, entry := range s.pending {
+		if entry.Event.Kind == kind {
+			return true
+		}
+	}
+	for _, entry := range s.inFlight {
+		if entry.Event.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Cancel снимает запланированное событие. Возвращает false, если такого события нет.
+func (s *Scheduler) Cancel(id string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.pending[id]; !exists {
+		return false
+	}
+	delete(s.pending, id)
+	return true
+}
+
+// Advance продвигает игровое время и доставляет наступившие события в порядке их
+// времени. Каждое событие доставляется не больше одного раза за тик; периодическое
+// событие, пропустившее несколько периодов, срабатывает один раз и продолжает
+// по расписанию. Обработчики вызываются без блокировки и могут планировать события.
+func (s *Scheduler) Advance(deltaTime float64) {
+	if deltaTime < 0 {
+		return
+	}
+
+	s.mutex.Lock()
+	s.now += deltaTime
+	due := make([]*scheduledEntry, 0)
+	for id, entry := range s.pending {
+		if entry.At <= s.now {
+			due = append(due, entry)
+			delete(s.pending, id)
+			// Доставка записывается до вызова обработчиков
+			s.inFlight[id] = entry
+		}
+	}
+	s.mutex.Unlock()
+
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].At != due[j].At {
+			return due[i].At < due[j].At
+		}
+		return due[i].Event.ID < due[j].Event.ID
+	})
+
+	for _, entry := range due {
+		s.deliver(entry)
+		s.acknowledge(entry)
+	}
+}
+
+// deliver вызывает обработчики вида события
+func (s *Scheduler) deliver(entry *scheduledEntry) {
+	s.mutex.Lock()
+	handlers := append([]ScheduledHandler(nil), s.handlers[entry.Event.Kind]...)
+	s.mutex.Unlock()
+
+	for _, handler := range handlers {
+		handler(entry.Event)
+	}
+}
+
+// acknowledge подтверждает доставку: однократное событие удаляется, а
+// периодическое переносится на следующий период после текущего времени
+func (s *Scheduler) acknowledge(entry *scheduledEntry) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.ackLocked(entry)
+}
+
+// ackLocked подтверждает доставку. Вызывается с захваченным мьютексом.
+func (s *Scheduler) ackLocked(entry *scheduledEntry) {
+	delete(s.inFlight, entry.Event.ID)
+	if entry.Interval <= 0 {
+		return
+	}
+
+	periods := math.Max(1, math.Floor((s.now-entry.At)/entry.Interval)+1)
+	entry.At += periods * entry.Interval
+	entry.Event.Occurrence++
+	s.pending[entry.Event.ID] = entry
+}
+
+// Save сохраняет игровое время и запланированные события
+func (s *Scheduler) Save(path string) error {
+	s.mutex.Lock()
+	state := schedulerState{
+		Now:      s.now,
+		NextID:   s.nextID,
+		Pending:  sortedEntries(s.pending),
+		InFlight: sortedEntries(s.inFlight),
+	}
+	data, err := savefile.Marshal(state)
+	s.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return savefile.Write(path, data)
+}
+
+// Load восстанавливает планировщик из сохранения. Без сохранения планировщик
+// остается пустым. События, доставка которых не была подтверждена, доставляются
+// повторно на ближайшем тике, если включен Redeliver, иначе считаются доставленными.
+func (s *Scheduler) Load(path string) error {
+	data, err := savefile.Read(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var state schedulerState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("invalid scheduler state: %v", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.now = state.Now
+	s.nextID = state.NextID
+	s.pending = make(map[string]*scheduledEntry)
+	s.inFlight = make(map[string]*scheduledEntry)
+	for _, entry := range state.Pending {
+		s.pending[entry.Event.ID] = entry
+	}
+	for _, entry := range state.InFlight {
+		if s.Redeliver {
+			s.pending[entry.Event.ID] = entry
+		} else {
+			s.ackLocked(entry)
+		}
+	}
+	return nil
+}
+
+// sortedEntries возвращает срабатывания в порядке времени, чтобы сохранения не менялись от запуска к запуску
+func sortedEntries(entries map[string]*scheduledEntry) []*scheduledEntry {
+	result := make([]*scheduledEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].At != result[j].At {
+			return result[i].At < result[j].At
+		}
+		return result[i].Event.ID < result[j].Event.ID
+	})
+	return result
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// recordDeliveries подписывает на вид события обработчик, запоминающий ID доставленных событий
+func recordDeliveries(s *Scheduler, kind string) *[]string {
+	delivered := make([]string, 0)
+	s.Handle(kind, func(event ScheduledEvent) {
+		delivered = append(delivered, event.ID)
+	})
+	return &delivered
+}
+
+func TestScheduledEventSurvivesSaveBeforeItIsDue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.json")
+
+	s := NewScheduler()
+	s.Schedule(100, ScheduledEvent{ID: "omen", Kind: "omen", Payload: map[string]string{"where": "marsh"}})
+	s.Advance(60)
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded := NewScheduler()
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	delivered := recordDeliveries(loaded, "omen")
+
+	// Событие наступает по игровому времени сохранения, а не с нуля
+	loaded.Advance(30)
+	if len(*delivered) != 0 {
+		t.Fatalf("omen delivered at game time %v, due at 100", loaded.Now())
+	}
+	loaded.Advance(10)
+	loaded.Advance(10)
+	if len(*delivered) != 1 || (*delivered)[0] != "omen" {
+		t.Errorf("delivered %v, want [omen] exactly once", *delivered)
+	}
+}
+
+func TestUnacknowledgedEventAcrossSaveAndLoad(t *testing.T) {
+	for _, redeliver := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "scheduler.json")
+
+		// Сохранение во время обработки события: доставка начата, но не подтверждена
+		s := NewScheduler()
+		s.Schedule(10, ScheduledEvent{ID: "howl", Kind: "howl"})
+		s.Handle("howl", func(ScheduledEvent) {
+			if err := s.Save(path); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		})
+		s.Advance(15)
+
+		loaded := NewScheduler()
+		loaded.Redeliver = redeliver
+		if err := loaded.Load(path); err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		delivered := recordDeliveries(loaded, "howl")
+		loaded.Advance(1)
+		loaded.Advance(1)
+
+		want := 0
+		if redeliver {
+			want = 1
+		}
+		if len(*delivered) != want {
+			t.Errorf("redeliver %v: delivered %d times after load, want %d", redeliver, len(*delivered), want)
+		}
+	}
+}
+
+func TestPeriodicEventKeepsItsPhaseAcrossSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.json")
+
+	s := NewScheduler()
+	if _, err := s.ScheduleEvery(50, ScheduledEvent{ID: "tide", Kind: "tide"}); err != nil {
+		t.Fatalf("ScheduleEvery: %v", err)
+	}
+	first := recordDeliveries(s, "tide")
+	s.Advance(70)
+	if len(*first) != 1 {
+		t.Fatalf("tide delivered %d times by 70, want 1", len(*first))
+	}
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded := NewScheduler()
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	// Повторная постановка при запуске не удваивает событие
+	if _, err := loaded.ScheduleEvery(50, ScheduledEvent{ID: "tide", Kind: "tide"}); err != nil {
+		t.Fatalf("ScheduleEvery: %v", err)
+	}
+	delivered := recordDeliveries(loaded, "tide")
+	loaded.Advance(29)
+	if len(*delivered) != 0 {
+		t.Fatalf("tide delivered before 100")
+	}
+	loaded.Advance(1)
+	if len(*delivered) != 1 {
+		t.Errorf("tide delivered %d times at 100, want 1", len(*delivered))
+	}
+}
+
+func TestSlowedTimeDelaysScheduledEvents(t *testing.T) {
+	s := NewScheduler()
+	s.Schedule(10, ScheduledEvent{ID: "omen", Kind: "omen"})
+	delivered := recordDeliveries(s, "omen")
+
+	// Game.tick передает шаг, умноженный на темп времени (правило time.flow)
+	const timeFlow = 0.5
+	for i := 0; i < 15; i++ {
+		s.Advance(1 * timeFlow)
+	}
+	if len(*delivered) != 0 {
+		t.Fatalf("omen delivered after 7.5 s of slowed game time")
+	}
+	for i := 0; i < 5; i++ {
+		s.Advance(1 * timeFlow)
+	}
+	if len(*delivered) != 1 {
+		t.Errorf("omen delivered %d times after 10 s of game time, want 1", len(*delivered))
+	}
+}
@@ -0,0 +1,23 @@
+package core
+
+import (
+	"time"
+
+	"echo-taiga/internal/ai/fear"
+)
+
+// ritualScareSuppressor не дает директору страха прерывать ритуалы и видения:
+// пугалка, упущенная во время сцены, срабатывает вскоре после нее
+type ritualScareSuppressor struct {
+	director *fear.Director
+}
+
+// SuppressScares реализует symbols.ScareSuppressor
+func (rs ritualScareSuppressor) SuppressScares(reason string, maxDuration time.Duration) func() {
+	suppression := rs.director.Suppress(reason, fear.SuppressOptions{
+		MaxDuration:  maxDuration,
+		QueueBest:    true,
+		AllowAmbient: true,
+	})
+	return suppression.Release
+}
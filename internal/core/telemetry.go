@@ -0,0 +1,82 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"echo-taiga/internal/ai/fear"
+	"echo-taiga/internal/ai/threat"
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/symbols"
+	"echo-taiga/internal/telemetry"
+)
+
+// attachTelemetry подписывает сборщик телеметрии на события подсистем,
+// сохраняя ранее установленные обработчики
+func attachTelemetry(t *telemetry.Telemetry, fearMgr *fear.Director, symbolMgr *symbols.Manager, metamorphMgr *metamorphosis.MetamorphosisManager, threatSys *threat.System) {
+	onScare := fearMgr.OnScareTriggered
+	fearMgr.OnScareTriggered = func(scare fear.ScareEvent) {
+		t.RecordScare(scare.ID, scare.Type, scare.Intensity)
+		if onScare != nil {
+			onScare(scare)
+		}
+	}
+
+	onAssessed := fearMgr.OnScareAssessed
+	fearMgr.OnScareAssessed = func(scare fear.ScareEvent, effectiveness float64) {
+		t.RecordScareEffectiveness(scare.ID, scare.Type, effectiveness)
+		if onAssessed != nil {
+			onAssessed(scare, effectiveness)
+		}
+	}
+
+	onRitual := symbolMgr.OnRitualPerformed
+	symbolMgr.OnRitualPerformed = func(ritual *symbols.Ritual, outcome symbols.RitualOutcome, effects []symbols.RitualEffect) {
+		t.RecordRitual(ritual.ID, outcome.Succeeded(), len(effects))
+		if onRitual != nil {
+			onRitual(ritual, outcome, effects)
+		}
+	}
+
+	onPhase := metamorphMgr.OnPhaseChanged
+	metamorphMgr.OnPhaseChanged = func(oldPhase, newPhase int) {
+		t.RecordPhaseChange(oldPhase, newPhase)
+		if onPhase != nil {
+			onPhase(oldPhase, newPhase)
+		}
+	}
+
+	onDeath := metamorphMgr.OnPlayerDeath
+	metamorphMgr.OnPlayerDeath = func(cycles int) {
+		t.RecordDeath(cycles)
+		if onDeath != nil {
+			onDeath(cycles)
+		}
+	}
+
+	onThreat := threatSys.OnThresholdCrossed
+	threatSys.OnThresholdCrossed = func(previousBand, band int, level float64) {
+		t.RecordThreatBand(previousBand, band, level)
+		if onThreat != nil {
+			onThreat(previousBand, band, level)
+		}
+	}
+}
+
+// exportTelemetry выгружает телеметрию сессии в JSON и CSV
+func (g *Game) exportTelemetry() error {
+	if g.telemetry == nil {
+		return nil
+	}
+
+	name := "session_" + time.Now().Format("20060102_150405")
+	base := filepath.Join(g.config.TelemetryDir, name)
+	if err := g.telemetry.ExportJSON(base + ".json"); err != nil {
+		return fmt.Errorf("failed to export telemetry: %v", err)
+	}
+	if err := g.telemetry.ExportCSV(base + ".csv"); err != nil {
+		return fmt.Errorf("failed to export telemetry: %v", err)
+	}
+	return nil
+}
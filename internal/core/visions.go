@@ -0,0 +1,44 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/symbols"
+)
+
+// metamorphVisions превращает прогноз метаморфоз в видения ритуалов предвидения
+type metamorphVisions struct {
+	manager *metamorphosis.MetamorphosisManager
+}
+
+// ForecastVisions реализует symbols.VisionSource
+func (mv metamorphVisions) ForecastVisions(horizon time.Duration) []symbols.Vision {
+	forecasts := mv.manager.ForecastUpcomingEffects(horizon)
+
+	visions := make([]symbols.Vision, 0, len(forecasts))
+	for _, forecast := range forecasts {
+		visions = append(visions, symbols.Vision{
+			Description: describeForecast(forecast),
+			Location:    forecast.Location,
+			ETA:         forecast.ETA,
+			Likelihood:  forecast.Likelihood,
+		})
+	}
+
+	return visions
+}
+
+// describeForecast формирует текст видения
+func describeForecast(forecast metamorphosis.EffectForecast) string {
+	when := "soon"
+	if forecast.ETA > 0 {
+		when = fmt.Sprintf("within %d minutes", int(forecast.ETA.Minutes())+1)
+	}
+
+	if forecast.Location == nil {
+		return fmt.Sprintf("You glimpse %s %s", forecast.Name, when)
+	}
+	return fmt.Sprintf("You glimpse %s near (%.0f, %.0f) %s", forecast.Name, forecast.Location.X, forecast.Location.Z, when)
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/symbols"
+)
+
+// newVisionTestManagers создает менеджер метаморфоз с одним выполненным триггером
+// и менеджер символов, видения которого берутся из прогноза метаморфоз, как в игре
+func newVisionTestManagers(t *testing.T) (*metamorphosis.MetamorphosisManager, *symbols.Manager, *symbols.Ritual) {
+	t.Helper()
+
+	ecsWorld := ecs.NewWorld()
+	player := ecs.NewEntity()
+	player.AddComponent(ecs.NewTransformComponent(ecs.Vector3{}))
+	player.AddTag(metamorphosis.TagPlayer)
+	ecsWorld.AddEntity(player)
+
+	metamorph := metamorphosis.NewMetamorphosisManager(ecsWorld, t.TempDir())
+	metamorph.RegisterEffectTemplate(&metamorphosis.MetamorphEffect{
+		ID: "shimmer", Name: "Shimmer", Order: metamorphosis.OrderFirst, Category: "visual", Intensity: 0.5, Duration: time.Hour,
+	})
+	metamorph.RegisterTriggerTemplate(&metamorphosis.MetamorphTrigger{
+		ID: "restless", Type: "test", Priority: 0.3,
+		Check: func(world *ecs.World, state *metamorphosis.WorldState) bool { return true },
+	})
+	metamorph.InitializeDefaultState()
+
+	symbolMgr := symbols.NewManagerInMemory(ecsWorld)
+	symbolMgr.SetWorldSeed(1)
+	symbolMgr.SetVisionSource(metamorphVisions{manager: metamorph})
+	metamorph.OnEffectWitnessed = witnessSymbols(symbolMgr)
+
+	ritual := &symbols.Ritual{
+		ID:            "foresight",
+		Name:          "Foresight",
+		Difficulty:    0.1,
+		SuccessChance: 1.0,
+		IsDiscovered:  true,
+		Effects:       []symbols.RitualEffect{{Type: "knowledge", Target: "future", Value: 1.0}},
+	}
+	symbolMgr.RitualRegistry.AddRitual(ritual)
+	symbolMgr.IncreaseKnowledge(ritual.ID, 1.0)
+
+	return metamorph, symbolMgr, ritual
+}
+
+func TestForesightRitualShowsForecastAsVisions(t *testing.T) {
+	metamorph, symbolMgr, ritual := newVisionTestManagers(t)
+
+	var granted []symbols.Vision
+	symbolMgr.OnVisionsGranted = func(visions []symbols.Vision) { granted = visions }
+
+	forecasts := metamorph.ForecastUpcomingEffects(symbols.MaxVisionHorizon)
+	if len(forecasts) == 0 {
+		t.Fatalf("forecast is empty, want the restless trigger's shimmer")
+	}
+
+	// Без подходящего места ритуал удается через раз: повторяем до успеха
+	for attempt := 0; attempt < 20 && granted == nil; attempt++ {
+		symbolMgr.PerformRitualWithSkill(ritual, ecs.Vector3{}, nil, 1.0)
+	}
+	if granted == nil {
+		t.Fatalf("20 foresight rituals granted no visions")
+	}
+
+	if len(granted) != len(forecasts) {
+		t.Fatalf("ritual granted %d visions, want one per forecast effect (%d)", len(granted), len(forecasts))
+	}
+	for i, vision := range granted {
+		if vision.Description != describeForecast(forecasts[i]) || vision.ETA != forecasts[i].ETA {
+			t.Errorf("vision %d = %+v, want the forecast of %s", i, vision, forecasts[i].Name)
+		}
+	}
+	if !strings.Contains(granted[0].Description, "Shimmer") {
+		t.Errorf("vision %q does not name the shimmer", granted[0].Description)
+	}
+	if len(symbolMgr.GetLastVisions()) != len(granted) {
+		t.Errorf("GetLastVisions() has %d visions, want %d", len(symbolMgr.GetLastVisions()), len(granted))
+	}
+}
+
+func TestVisionsDoNotDeadlockWithMetamorphosisUpdates(t *testing.T) {
+	metamorph, symbolMgr, ritual := newVisionTestManagers(t)
+	metamorph.SetTransformationPhase(5)
+	metamorph.RegisterEffectTemplate(&metamorphosis.MetamorphEffect{
+		ID: "rift", Name: "Rift", Order: metamorphosis.OrderThird, Category: "reality", Intensity: 0.2, Duration: time.Minute,
+	})
+	var witnessed atomic.Int32
+	onWitnessed := metamorph.OnEffectWitnessed
+	metamorph.OnEffectWitnessed = func(effect *metamorphosis.MetamorphEffect) {
+		witnessed.Add(1)
+		onWitnessed(effect)
+	}
+
+	// Ритуал берет прогноз под мьютексом символов, а обновление метаморфоз сообщает
+	// об увиденных эффектах менеджеру символов: блокировки идут навстречу друг другу
+	stop := make(chan struct{})
+	updated := make(chan struct{})
+	go func() {
+		defer close(updated)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			metamorph.Update(0.1)
+			metamorph.RequestEffect(metamorphosis.EffectRequest{
+				Order: metamorphosis.OrderThird, Center: ecs.Vector3{X: 5}, Radius: 3, Intensity: 0.2,
+			})
+		}
+	}()
+
+	performed := make(chan struct{})
+	go func() {
+		defer close(performed)
+		// Ритуалы идут, пока метаморфозы не будут увидены несколько раз
+		for i := 0; i < 100000 && (i < 50 || witnessed.Load() < 10); i++ {
+			symbolMgr.PerformRitualWithSkill(ritual, ecs.Vector3{}, nil, 1.0)
+		}
+	}()
+
+	select {
+	case <-performed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("foresight rituals deadlocked with metamorphosis updates")
+	}
+	close(stop)
+	<-updated
+
+	if witnessed.Load() == 0 {
+		t.Errorf("no effect reached the symbol manager as witnessed, the locks never met")
+	}
+}
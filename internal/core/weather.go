@@ -0,0 +1,36 @@
+package core
+
+import "echo-taiga/internal/world"
+
+// handleWeather подписывает мир на смены погоды и прогнозы бури и планирует
+// первую смену, если сохранение ее не содержит
+func handleWeather(scheduler *Scheduler, gameWorld *world.World) {
+	scheduler.Handle(WeatherTurnEvent, func(event ScheduledEvent) {
+		weather := event.Payload["weather"]
+		if weather == "" {
+			weather = gameWorld.PlanWeather()
+		}
+		gameWorld.TurnWeather(weather)
+		scheduleWeatherTurn(scheduler, gameWorld)
+	})
+	scheduler.Handle(StormForecastEvent, func(event ScheduledEvent) {
+		gameWorld.ForecastWeather("storm")
+	})
+
+	if !scheduler.HasPending(WeatherTurnEvent) {
+		scheduleWeatherTurn(scheduler, gameWorld)
+	}
+}
+
+// scheduleWeatherTurn планирует следующую смену погоды через world.WeatherPeriod.
+// Погода выбирается сразу и хранится в событии, поэтому прогноз переживает
+// сохранение; о буре мир узнает за world.StormForecastLead до ее начала.
+func scheduleWeatherTurn(scheduler *Scheduler, gameWorld *world.World) {
+	at := scheduler.Now() + world.WeatherPeriod
+	weather := gameWorld.PlanWeather()
+
+	scheduler.Schedule(at, ScheduledEvent{Kind: WeatherTurnEvent, Payload: map[string]string{"weather": weather}})
+	if weather == "storm" {
+		scheduler.Schedule(at-world.StormForecastLead, ScheduledEvent{Kind: StormForecastEvent})
+	}
+}
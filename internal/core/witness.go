@@ -0,0 +1,34 @@
+package core
+
+import (
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/symbols"
+)
+
+// witnessSymbols передает увиденные игроком метаморфозы системе символов и
+// связывает эффект с открытым символом, чтобы журнал мог рассказать о связи
+func witnessSymbols(symbolMgr *symbols.Manager) func(effect *metamorphosis.MetamorphEffect) {
+	return func(effect *metamorphosis.MetamorphEffect) {
+		symbolID := symbolMgr.WitnessEffect(symbols.MetamorphEffectInfo{
+			ID:           effect.ID,
+			Name:         effect.Name,
+			Category:     effect.Category,
+			Order:        int(effect.Order),
+			Intensity:    effect.Intensity,
+			WorldChanges: effect.WorldChanges,
+		})
+		if symbolID != "" && !containsID(effect.RelatedSymbols, symbolID) {
+			effect.RelatedSymbols = append(effect.RelatedSymbols, symbolID)
+		}
+	}
+}
+
+// containsID проверяет наличие идентификатора в списке
+func containsID(ids []string, id string) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
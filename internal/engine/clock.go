@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock сообщает текущее время. Системы получают время через Clock, а не
+// time.Now(), чтобы зависящее от времени поведение можно было проверять
+// без ожидания.
+type Clock interface {
+	Now() time.Time
+}
+
+// RealClock возвращает настоящее время
+type RealClock struct{}
+
+// Now реализует Clock
+func (RealClock) Now() time.Time { return time.Now() }
+
+// FakeClock стоит на месте, пока его не передвинут вручную
+type FakeClock struct {
+	now   time.Time
+	mutex sync.Mutex
+}
+
+// NewFakeClock создает часы, показывающие указанное время
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now реализует Clock
+func (fc *FakeClock) Now() time.Time {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	return fc.now
+}
+
+// Advance передвигает часы вперед на d
+func (fc *FakeClock) Advance(d time.Duration) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	fc.now = fc.now.Add(d)
+}
+
+// Set переводит часы на указанное время
+func (fc *FakeClock) Set(t time.Time) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	fc.now = t
+}
@@ -0,0 +1,121 @@
+package ecs
+
+import (
+	"fmt"
+	"testing"
+)
+
+// newBatch создает count сущностей с трансформацией; каждая вторая - дерево
+func newBatch(count int) []*Entity {
+	tree := RegisterTag("batch_tree", "flora", "")
+	batch := make([]*Entity, count)
+	for i := range batch {
+		entity := NewEntity()
+		entity.AddComponent(NewTransformComponent(Vector3{X: float64(i)}))
+		if i%2 == 0 {
+			entity.AddComponent(NewRenderComponent("pine", "bark"))
+			entity.AddTag(tree)
+		}
+		batch[i] = entity
+	}
+	return batch
+}
+
+// countIDs считает, сколько раз каждая сущность встречается в выборке
+func countIDs(entities []*Entity) map[EntityID]int {
+	counts := make(map[EntityID]int, len(entities))
+	for _, entity := range entities {
+		counts[entity.ID]++
+	}
+	return counts
+}
+
+func TestAddEntitiesIndexesBatchOnce(t *testing.T) {
+	world := NewWorld()
+	existing := NewEntity()
+	existing.AddComponent(NewTransformComponent(Vector3{}))
+	world.AddEntity(existing)
+
+	batch := newBatch(100)
+	ids := world.AddEntities(batch)
+
+	if len(ids) != len(batch) {
+		t.Fatalf("AddEntities returned %d IDs for %d entities", len(ids), len(batch))
+	}
+	for i, entity := range batch {
+		if ids[i] != entity.ID {
+			t.Fatalf("ID %d = %s, want %s (batch order)", i, ids[i], entity.ID)
+		}
+		if got, exists := world.GetEntity(entity.ID); !exists || got != entity {
+			t.Fatalf("entity %s is not in the world", entity.ID)
+		}
+	}
+
+	transforms := countIDs(world.GetEntitiesWithComponent(TransformComponentID))
+	if len(transforms) != 101 {
+		t.Errorf("%d entities indexed by transform, want 101", len(transforms))
+	}
+	for id, count := range transforms {
+		if count != 1 {
+			t.Errorf("entity %s indexed %d times by transform", id, count)
+		}
+	}
+
+	trees := countIDs(world.GetEntitiesWithTag("batch_tree"))
+	rendered := countIDs(world.GetEntitiesWithAllComponents(TransformComponentID, RenderComponentID))
+	if len(trees) != 50 || len(rendered) != 50 {
+		t.Errorf("%d trees by tag and %d by components, want 50 each", len(trees), len(rendered))
+	}
+	for id := range trees {
+		if trees[id] != 1 || rendered[id] != 1 {
+			t.Errorf("tree %s seen %d times by tag and %d by components", id, trees[id], rendered[id])
+		}
+	}
+}
+
+func TestBatchedEntitiesKeepIndexesUpToDate(t *testing.T) {
+	world := NewWorld()
+	batch := newBatch(4)
+	world.AddEntities(batch)
+
+	// Сущности пачки знают свой мир: изменения компонентов и тегов попадают в индексы
+	batch[1].AddComponent(NewRenderComponent("birch", "bark"))
+	batch[0].RemoveTag("batch_tree")
+	world.RemoveEntity(batch[2].ID)
+
+	rendered := countIDs(world.GetEntitiesWithComponent(RenderComponentID))
+	if len(rendered) != 2 || rendered[batch[0].ID] != 1 || rendered[batch[1].ID] != 1 {
+		t.Errorf("rendered entities %v, want the first two", rendered)
+	}
+	if trees := world.GetEntitiesWithTag("batch_tree"); len(trees) != 0 {
+		t.Errorf("%d trees left, want none", len(trees))
+	}
+	if ids := world.AddEntities(nil); len(ids) != 0 {
+		t.Errorf("empty batch returned %d IDs", len(ids))
+	}
+}
+
+// BenchmarkAddEntities сравнивает вставку 1000 сущностей пачкой и по одной
+func BenchmarkAddEntities(b *testing.B) {
+	const count = 1000
+
+	for _, batched := range []bool{false, true} {
+		b.Run(fmt.Sprintf("batched=%v", batched), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				world := NewWorld()
+				batch := newBatch(count)
+				b.StartTimer()
+
+				if batched {
+					world.AddEntities(batch)
+					continue
+				}
+				for _, entity := range batch {
+					world.AddEntity(entity)
+				}
+			}
+		})
+	}
+}
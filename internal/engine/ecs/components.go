@@ -21,6 +21,12 @@ var (
 	SoundEmitterComponentID  = RegisterComponentType("sound_emitter")
 	InventoryComponentID     = RegisterComponentType("inventory")
 	SurvivalComponentID      = RegisterComponentType("survival")
+	RitualAltarComponentID   = RegisterComponentType("ritual_altar")
+	AncientSiteComponentID   = RegisterComponentType("ancient_site")
+	AnchorComponentID        = RegisterComponentType("anchor")
+	NoiseComponentID         = RegisterComponentType("noise")
+	GroupComponentID         = RegisterComponentType("group")
+	TabletComponentID        = RegisterComponentType("tablet")
 )
 
 // Vector3 представляет трехмерный вектор
@@ -100,22 +106,32 @@ func (v Vector3) Cross(other Vector3) Vector3 {
 // TransformComponent содержит информацию о позиции, вращении и масштабе сущности
 type TransformComponent struct {
 	BaseComponent
-	Position Vector3
-	Rotation Vector3 // Углы Эйлера в радианах (yaw, pitch, roll)
-	Scale    Vector3
-	Parent   EntityID
-	Children []EntityID
+	Position         Vector3
+	PreviousPosition Vector3 // Положение на предыдущем шаге симуляции, для сглаживания отрисовки
+	Rotation         Vector3 // Углы Эйлера в радианах (yaw, pitch, roll)
+	Scale            Vector3
+	Parent           EntityID
+	Children         []EntityID
 }
 
 // NewTransformComponent создает новый компонент трансформации
 func NewTransformComponent(position Vector3) *TransformComponent {
-	return &TransformComponent{
-		BaseComponent: NewBaseComponent(TransformComponentID),
-		Position:      position,
-		Rotation:      Vector3{0, 0, 0},
-		Scale:         Vector3{1, 1, 1},
-		Children:      make([]EntityID, 0),
+	t := transformPool.get()
+	t.TypeID = TransformComponentID
+	t.Position = position
+	t.PreviousPosition = position
+	t.Rotation = Vector3{0, 0, 0}
+	t.Scale = Vector3{1, 1, 1}
+	if t.Children == nil {
+		t.Children = make([]EntityID, 0)
 	}
+	return t
+}
+
+// InterpolatedPosition возвращает положение между предыдущим и текущим шагом
+// симуляции; alpha - доля пройденного шага (0 - предыдущий, 1 - текущий)
+func (t *TransformComponent) InterpolatedPosition(alpha float64) Vector3 {
+	return t.PreviousPosition.Add(t.Position.Sub(t.PreviousPosition).Multiply(alpha))
 }
 
 // Forward возвращает вектор направления "вперед" в локальной системе координат
@@ -154,29 +170,31 @@ type RenderComponent struct {
 	ModelID      string
 	TextureID    string
 	Color        color.RGBA
+	BaseColor    color.RGBA // Собственный цвет сущности, запоминается при первой смене цвета (см. TintColor)
 	Visible      bool
 	CastShadow   bool
 	Layer        int
-	Distortion   float64  // Для метаморфоз: 0 - нет искажений, 1 - максимальные искажения
-	Effects      []string // Список применяемых эффектов (свечение, размытие и т.д.)
-	Pixel        bool     // Использовать пиксельный рендеринг
-	CustomShader string   // Идентификатор пользовательского шейдера
+	Distortion   float64     // Для метаморфоз: 0 - нет искажений, 1 - максимальные искажения
+	Effects      EffectStack // Применяемые эффекты (свечение, размытие и т.д.) с учетом источников
+	Pixel        bool        // Использовать пиксельный рендеринг
+	CustomShader string      // Идентификатор пользовательского шейдера
+
+	tints []colorTint // Смены цвета по источникам; действует последняя
 }
 
 // NewRenderComponent создает новый компонент рендеринга
 func NewRenderComponent(modelID, textureID string) *RenderComponent {
-	return &RenderComponent{
-		BaseComponent: NewBaseComponent(RenderComponentID),
-		ModelID:       modelID,
-		TextureID:     textureID,
-		Color:         color.RGBA{255, 255, 255, 255},
-		Visible:       true,
-		CastShadow:    true,
-		Layer:         0,
-		Distortion:    0,
-		Effects:       make([]string, 0),
-		Pixel:         true, // По умолчанию используем пиксельный рендеринг
-	}
+	r := renderPool.get()
+	r.TypeID = RenderComponentID
+	r.ModelID = modelID
+	r.TextureID = textureID
+	r.Color = color.RGBA{255, 255, 255, 255}
+	r.Visible = true
+	r.CastShadow = true
+	r.Layer = 0
+	r.Distortion = 0
+	r.Pixel = true // По умолчанию используем пиксельный рендеринг
+	return r
 }
 
 // PhysicsComponent содержит информацию для физического моделирования
@@ -196,16 +214,16 @@ type PhysicsComponent struct {
 
 // NewPhysicsComponent создает новый компонент физики
 func NewPhysicsComponent(mass float64, static bool) *PhysicsComponent {
-	return &PhysicsComponent{
-		BaseComponent: NewBaseComponent(PhysicsComponentID),
-		Mass:          mass,
-		Friction:      0.5,
-		Restitution:   0.3,
-		Gravity:       1.0,
-		Static:        static,
-		Collider:      "box",
-		ColliderSize:  Vector3{1, 1, 1},
-	}
+	p := physicsPool.get()
+	p.TypeID = PhysicsComponentID
+	p.Mass = mass
+	p.Friction = 0.5
+	p.Restitution = 0.3
+	p.Gravity = 1.0
+	p.Static = static
+	p.Collider = "box"
+	p.ColliderSize = Vector3{1, 1, 1}
+	return p
 }
 
 // HealthComponent содержит информацию о здоровье и состоянии сущности
@@ -279,26 +297,46 @@ type MetamorphicComponent struct {
 
 // NewMetamorphicComponent создает новый компонент метаморфичности
 func NewMetamorphicComponent(stability float64) *MetamorphicComponent {
-	return &MetamorphicComponent{
-		BaseComponent:        NewBaseComponent(MetamorphicComponentID),
-		Stability:            stability,
-		AbnormalityIndex:     0,
-		CurrentMetamorphoses: make([]string, 0),
-		PossibleMutations:    make([]string, 0),
-		PropertyModifiers:    make(map[string]float64),
+	m := metamorphicPool.get()
+	m.TypeID = MetamorphicComponentID
+	m.Stability = stability
+	m.AbnormalityIndex = 0
+	if m.CurrentMetamorphoses == nil {
+		m.CurrentMetamorphoses = make([]string, 0)
+	}
+	if m.PossibleMutations == nil {
+		m.PossibleMutations = make([]string, 0)
 	}
+	if m.PropertyModifiers == nil {
+		m.PropertyModifiers = make(map[string]float64)
+	}
+	return m
+}
+
+// CanMutate проверяет, может ли сущность мутировать под воздействием метаморфозы.
+// Шанс бросается генератором r подсистемы (см. engine.NewRandStream).
+func (m *MetamorphicComponent) CanMutate(r *rand.Rand, metamorphStrength float64) bool {
+	return m.CanMutateWith(r, metamorphStrength, 0)
+}
+
+// CanMutateWith проверяет возможность мутации с временной прибавкой к стабильности
+// (например, рядом с якорем стабильности)
+func (m *MetamorphicComponent) CanMutateWith(r *rand.Rand, metamorphStrength, stabilityBonus float64) bool {
+	return r.Float64() < m.MutationChance(metamorphStrength, stabilityBonus)
 }
 
-// CanMutate проверяет, может ли сущность мутировать под воздействием метаморфозы
-func (m *MetamorphicComponent) CanMutate(metamorphStrength float64) bool {
+// MutationChance возвращает вероятность мутации с временной прибавкой к стабильности.
+// Подсистемы со своим генератором случайных чисел бросают шанс сами.
+func (m *MetamorphicComponent) MutationChance(metamorphStrength, stabilityBonus float64) float64 {
+	stability := math.Min(1.0, m.Stability+stabilityBonus)
+
 	// Если стабильность 1, то не может мутировать
-	if m.Stability >= 1.0 {
-		return false
+	if stability >= 1.0 {
+		return 0
 	}
 
 	// Вероятность мутации зависит от силы метаморфозы и стабильности сущности
-	mutationChance := metamorphStrength * (1.0 - m.Stability)
-	return rand.Float64() < mutationChance
+	return metamorphStrength * (1.0 - stability)
 }
 
 // ApplyMetamorphosis применяет метаморфозу к сущности
@@ -383,6 +421,7 @@ type SymbolComponent struct {
 	Power           float64 // 0-1: сила символа
 	Discovered      bool    // Обнаружен ли символ игроком
 	DiscoveryRadius float64
+	RequiresSight   bool     // Символ спрятан (закопан, зарос): его нужно разглядеть вплотную, издалека он не открывается
 	KnowledgeLevel  float64  // 0-1: насколько хорошо игрок понимает символ
 	RelatedSymbols  []string // Связанные символы
 	Meaning         []string // Набор значений символа
@@ -625,6 +664,8 @@ type SoundEmitterComponent struct {
 	PlayOnStart      bool              // Проигрывать при создании сущности
 	RandomPitchRange float64           // Диапазон случайного изменения высоты тона
 	Sounds           map[string]string // Словарь доступных звуков по ключам
+	Position         Vector3           // Позиция источника в мире (синхронизируется с сущностью)
+	Occlusion        float64           // Заглушение рельефом между источником и слушателем (0-1)
 }
 
 // NewSoundEmitterComponent создает новый компонент звука
@@ -653,6 +694,11 @@ func (s *SoundEmitterComponent) Stop() {
 	s.IsPlaying = false
 }
 
+// AudibleVolume возвращает громкость источника с учетом заглушения рельефом
+func (s *SoundEmitterComponent) AudibleVolume() float64 {
+	return s.Volume * (1 - s.Occlusion)
+}
+
 // PlaySound проигрывает указанный звук
 func (s *SoundEmitterComponent) PlaySound(key string) bool {
 	if soundID, exists := s.Sounds[key]; exists {
@@ -668,7 +714,7 @@ type AIComponent struct {
 	BaseComponent
 	AIType             string    // Тип ИИ: "passive", "neutral", "aggressive", "scared", "smart"
 	DetectionRange     float64   // Радиус обнаружения
-	CurrentState       string    // Текущее состояние: "idle", "patrol", "chase", "attack", "flee"
+	CurrentState       string    // Текущее состояние: "idle", "patrol", "chase", "attack", "flee", "investigate"
 	TargetID           EntityID  // Идентификатор цели
 	LastKnownTargetPos Vector3   // Последняя известная позиция цели
 	PatrolPoints       []Vector3 // Точки патрулирования
@@ -735,3 +781,195 @@ func (a *AIComponent) GetNextPatrolPoint() Vector3 {
 	a.CurrentPatrolIdx = (a.CurrentPatrolIdx + 1) % len(a.PatrolPoints)
 	return point
 }
+
+// RitualAltarComponent содержит подношения, собранные на алтаре для ритуала
+type RitualAltarComponent struct {
+	BaseComponent
+	RitualID      string   // Ритуал, для которого собираются подношения
+	RequiredItems []string // Предметы, которые нужно положить на алтарь
+	Offerings     []string // Уже положенные предметы
+	Ready         bool     // Все подношения собраны, ритуал можно провести
+}
+
+// NewRitualAltarComponent создает новый компонент алтаря
+func NewRitualAltarComponent(ritualID string, requiredItems []string) *RitualAltarComponent {
+	return &RitualAltarComponent{
+		BaseComponent: NewBaseComponent(RitualAltarComponentID),
+		RitualID:      ritualID,
+		RequiredItems: append([]string(nil), requiredItems...),
+		Offerings:     make([]string, 0),
+		Ready:         len(requiredItems) == 0,
+	}
+}
+
+// AcceptsOffering проверяет, нужен ли алтарю указанный предмет
+func (a *RitualAltarComponent) AcceptsOffering(item string) bool {
+	required := 0
+	for _, requiredItem := range a.RequiredItems {
+		if requiredItem == item {
+			required++
+		}
+	}
+
+	placed := 0
+	for _, offering := range a.Offerings {
+		if offering == item {
+			placed++
+		}
+	}
+
+	return placed < required
+}
+
+// AddOffering кладет предмет на алтарь. Возвращает false, если предмет не нужен.
+func (a *RitualAltarComponent) AddOffering(item string) bool {
+	if !a.AcceptsOffering(item) {
+		return false
+	}
+
+	a.Offerings = append(a.Offerings, item)
+	a.Ready = len(a.Offerings) >= len(a.RequiredItems)
+	return true
+}
+
+// Progress возвращает долю собранных подношений (0-1)
+func (a *RitualAltarComponent) Progress() float64 {
+	if len(a.RequiredItems) == 0 {
+		return 1.0
+	}
+	return float64(len(a.Offerings)) / float64(len(a.RequiredItems))
+}
+
+// Clear убирает подношения с алтаря после проведения ритуала
+func (a *RitualAltarComponent) Clear() {
+	a.Offerings = a.Offerings[:0]
+	a.Ready = len(a.RequiredItems) == 0
+}
+
+// AncientSiteComponent описывает древнее место силы (круг стоячих камней).
+// Связанные ритуал и символ определяются при первом взаимодействии,
+// так как реестры могут сгенерировать контент уже после генерации мира.
+type AncientSiteComponent struct {
+	BaseComponent
+	Seed     int64  // Сид места, определяет откровение
+	Location string // Тип места ритуала, соответствующий окружающему биому
+	RitualID string // Связанный ритуал (пусто до первого откровения)
+	SymbolID string // Символ, знание которого дает место
+	Resolved bool   // Откровение уже получено
+}
+
+// NewAncientSiteComponent создает новый компонент древнего места
+func NewAncientSiteComponent(seed int64, location string) *AncientSiteComponent {
+	return &AncientSiteComponent{
+		BaseComponent: NewBaseComponent(AncientSiteComponentID),
+		Seed:          seed,
+		Location:      location,
+	}
+}
+
+// AnchorComponent описывает якорь стабильности, подавляющий метаморфозы вокруг себя.
+// Пока у якоря есть заряд, сущности в радиусе устойчивее, а новые метаморфозы
+// порядка не ниже Strength не могут возникнуть внутри радиуса.
+type AnchorComponent struct {
+	BaseComponent
+	Radius    float64 // Радиус подавления
+	Strength  int     // Наименьший порядок метаморфоз, которые якорь не пропускает
+	Charge    float64 // Текущий заряд
+	MaxCharge float64 // Наибольший заряд
+	Cracked   bool    // Якорь треснул, исчерпав заряд
+}
+
+// NewAnchorComponent создает полностью заряженный якорь
+func NewAnchorComponent(radius float64, strength int, maxCharge float64) *AnchorComponent {
+	return &AnchorComponent{
+		BaseComponent: NewBaseComponent(AnchorComponentID),
+		Radius:        radius,
+		Strength:      strength,
+		Charge:        maxCharge,
+		MaxCharge:     maxCharge,
+	}
+}
+
+// IsCharged проверяет, действует ли якорь
+func (a *AnchorComponent) IsCharged() bool {
+	return !a.Cracked && a.Charge > 0
+}
+
+// Drain расходует заряд якоря. Возвращает true, если якорь при этом треснул.
+func (a *AnchorComponent) Drain(amount float64) bool {
+	if !a.IsCharged() || amount <= 0 {
+		return false
+	}
+
+	a.Charge -= amount
+	if a.Charge <= 0 {
+		a.Charge = 0
+		a.Cracked = true
+		return true
+	}
+	return false
+}
+
+// Recharge восполняет заряд якоря; треснувший якорь снова начинает действовать
+func (a *AnchorComponent) Recharge(amount float64) {
+	if amount <= 0 {
+		return
+	}
+
+	a.Charge = math.Min(a.MaxCharge, a.Charge+amount)
+	if a.Charge > 0 {
+		a.Cracked = false
+	}
+}
+
+// NoiseComponent описывает шум, который слышат существа: брошенный камень,
+// звон костяной погремушки. Существа идут проверить шум, пока он не стихнет.
+type NoiseComponent struct {
+	BaseComponent
+	Loudness  float64 // Громкость (0-1): чем громче, тем дальше слышен шум
+	Remaining float64 // Сколько секунд шум еще слышен
+	Source    string  // Что шумит (например, "stone" или "bone_chime")
+}
+
+// NewNoiseComponent создает новый компонент шума
+func NewNoiseComponent(loudness, duration float64, source string) *NoiseComponent {
+	return &NoiseComponent{
+		BaseComponent: NewBaseComponent(NoiseComponentID),
+		Loudness:      math.Max(0, math.Min(1, loudness)),
+		Remaining:     duration,
+		Source:        source,
+	}
+}
+
+// GroupComponent объединяет сущности в стаю или стадо. Метаморфозы существ
+// действуют на группу целиком, чтобы стая не мутировала вразнобой.
+type GroupComponent struct {
+	BaseComponent
+	GroupID string // Идентификатор группы, общий для всех ее членов
+	Kind    string // Вид группы (например, "pack" или "herd")
+}
+
+// NewGroupComponent создает новый компонент группы
+func NewGroupComponent(groupID, kind string) *GroupComponent {
+	return &GroupComponent{
+		BaseComponent: NewBaseComponent(GroupComponentID),
+		GroupID:       groupID,
+		Kind:          kind,
+	}
+}
+
+// TabletComponent описывает переносимую табличку с начертанным символом.
+// Табличка - предмет инвентаря, а не надпись в мире, поэтому у нее нет
+// SymbolComponent и поиск символов мира ее не видит.
+type TabletComponent struct {
+	BaseComponent
+	SymbolID string // Идентификатор символа на табличке
+}
+
+// NewTabletComponent создает новый компонент таблички
+func NewTabletComponent(symbolID string) *TabletComponent {
+	return &TabletComponent{
+		BaseComponent: NewBaseComponent(TabletComponentID),
+		SymbolID:      symbolID,
+	}
+}
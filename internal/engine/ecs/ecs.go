@@ -1,6 +1,7 @@
 package ecs
 
 import (
+	"fmt"
 	"reflect"
 	"sync"
 
@@ -38,6 +39,7 @@ func NewEntity() *Entity {
 
 // AddComponent добавляет компонент к сущности
 func (e *Entity) AddComponent(c Component) {
+	checkLive(c)
 	e.components[c.Type()] = c
 	if e.world != nil {
 		e.world.entityComponentChanged(e, c, true)
@@ -57,9 +59,30 @@ func (e *Entity) RemoveComponent(id ComponentID) {
 // GetComponent возвращает компонент указанного типа
 func (e *Entity) GetComponent(id ComponentID) (Component, bool) {
 	comp, exists := e.components[id]
+	if exists {
+		checkLive(comp)
+	}
 	return comp, exists
 }
 
+// ComponentAs возвращает компонент сущности, приведенный к типу T.
+// Вместо паники при отсутствии компонента или несовпадении типа возвращает false.
+func ComponentAs[T Component](e *Entity, id ComponentID) (T, bool) {
+	var zero T
+	if e == nil {
+		return zero, false
+	}
+
+	comp, exists := e.components[id]
+	if !exists {
+		return zero, false
+	}
+	checkLive(comp)
+
+	typed, ok := comp.(T)
+	return typed, ok
+}
+
 // HasComponent проверяет, есть ли у сущности компонент указанного типа
 func (e *Entity) HasComponent(id ComponentID) bool {
 	_, exists := e.components[id]
@@ -76,8 +99,14 @@ func (e *Entity) HasAllComponents(ids ...ComponentID) bool {
 	return true
 }
 
-// AddTag добавляет тег к сущности
+// AddTag добавляет тег к сущности. В строгом режиме реестра тегов (dev-сборки)
+// незарегистрированный тег - ошибка программы: AddTag паникует с подсказкой
+// ближайшего известного тега.
 func (e *Entity) AddTag(tag string) {
+	if err := Tags.Validate(tag); err != nil {
+		panic(fmt.Sprintf("ecs: %v", err))
+	}
+
 	e.tags[tag] = true
 	if e.world != nil {
 		e.world.entityTagChanged(e, tag, true)
@@ -96,6 +125,7 @@ func (e *Entity) RemoveTag(tag string) {
 
 // HasTag проверяет, есть ли у сущности указанный тег
 func (e *Entity) HasTag(tag string) bool {
+	Tags.noteQuery(tag)
 	_, exists := e.tags[tag]
 	return exists
 }
@@ -121,7 +151,7 @@ type System interface {
 // World представляет игровой мир, содержащий все сущности и системы
 type World struct {
 	entities map[EntityID]*Entity
-	systems  []System
+	systems  []*systemEntry
 
 	// Индексы для быстрого доступа
 	entitiesByComponent map[ComponentID]map[EntityID]*Entity
@@ -130,13 +160,23 @@ type World struct {
 	// Блокировки для безопасного доступа из разных горутин
 	entitiesMutex sync.RWMutex
 	systemsMutex  sync.RWMutex
+
+	// Перехватывать ли паники систем (по умолчанию только в выпускных сборках, см. runSystem)
+	RecoverSystemPanics bool
+
+	// Вызывается, когда система попадает в карантин после MaxSystemFaults паник подряд
+	OnSystemQuarantined func(system string, err error)
+
+	// Удаленные за кадр сущности; их компоненты возвращаются в пулы в конце Update
+	removed []*Entity
 }
 
 // NewWorld создает новый игровой мир
 func NewWorld() *World {
 	return &World{
 		entities:            make(map[EntityID]*Entity),
-		systems:             make([]System, 0),
+		systems:             make([]*systemEntry, 0),
+		RecoverSystemPanics: recoverSystemPanicsDefault,
 		entitiesByComponent: make(map[ComponentID]map[EntityID]*Entity),
 		entitiesByTag:       make(map[string]map[EntityID]*Entity),
 	}
@@ -147,11 +187,35 @@ func (w *World) AddEntity(e *Entity) {
 	w.entitiesMutex.Lock()
 	defer w.entitiesMutex.Unlock()
 
+	w.addEntityLocked(e)
+}
+
+// AddEntities добавляет пачку сущностей в мир за один захват блокировки.
+// Возвращает ID сущностей в порядке пачки.
+func (w *World) AddEntities(batch []*Entity) []EntityID {
+	ids := make([]EntityID, len(batch))
+	if len(batch) == 0 {
+		return ids
+	}
+
+	w.entitiesMutex.Lock()
+	defer w.entitiesMutex.Unlock()
+
+	for i, e := range batch {
+		w.addEntityLocked(e)
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+// addEntityLocked добавляет сущность в мир и индексы. Вызывается при захваченном entitiesMutex.
+func (w *World) addEntityLocked(e *Entity) {
 	w.entities[e.ID] = e
 	e.world = w
 
 	// Индексируем компоненты
 	for _, comp := range e.components {
+		checkLive(comp)
 		w.indexEntityComponent(e, comp.Type(), true)
 	}
 
@@ -161,7 +225,10 @@ func (w *World) AddEntity(e *Entity) {
 	}
 }
 
-// RemoveEntity удаляет сущность из мира
+// RemoveEntity удаляет сущность из мира. Если пулы компонентов включены, массовые
+// компоненты сущности возвращаются в пулы в конце текущего Update, а сама сущность
+// остается без компонентов: системы, которые еще держат ее в этом кадре, читают
+// прежние значения, но после кадра все нужное из нее уже не прочитать.
 func (w *World) RemoveEntity(id EntityID) {
 	w.entitiesMutex.Lock()
 	defer w.entitiesMutex.Unlock()
@@ -179,6 +246,22 @@ func (w *World) RemoveEntity(id EntityID) {
 
 		delete(w.entities, id)
 		e.world = nil
+
+		if poolingEnabled.Load() {
+			w.removed = append(w.removed, e)
+		}
+	}
+}
+
+// releaseRemovedEntities возвращает в пулы компоненты сущностей, удаленных за кадр
+func (w *World) releaseRemovedEntities() {
+	w.entitiesMutex.Lock()
+	removed := w.removed
+	w.removed = nil
+	w.entitiesMutex.Unlock()
+
+	for _, e := range removed {
+		releaseEntityComponents(e)
 	}
 }
 
@@ -265,6 +348,8 @@ func (w *World) GetEntitiesWithAllComponents(compIDs ...ComponentID) []*Entity {
 
 // GetEntitiesWithTag возвращает все сущности с указанным тегом
 func (w *World) GetEntitiesWithTag(tag string) []*Entity {
+	Tags.noteQuery(tag)
+
 	w.entitiesMutex.RLock()
 	defer w.entitiesMutex.RUnlock()
 
@@ -282,7 +367,7 @@ func (w *World) AddSystem(s System) {
 	w.systemsMutex.Lock()
 	defer w.systemsMutex.Unlock()
 
-	w.systems = append(w.systems, s)
+	w.systems = append(w.systems, &systemEntry{system: s})
 }
 
 // RemoveSystem удаляет систему из мира
@@ -291,8 +376,8 @@ func (w *World) RemoveSystem(system System) {
 	defer w.systemsMutex.Unlock()
 
 	systemType := reflect.TypeOf(system)
-	for i, s := range w.systems {
-		if reflect.TypeOf(s) == systemType {
+	for i, entry := range w.systems {
+		if reflect.TypeOf(entry.system) == systemType {
 			// Удаляем систему, сохраняя порядок остальных
 			w.systems = append(w.systems[:i], w.systems[i+1:]...)
 			return
@@ -306,9 +391,15 @@ func (w *World) Update(deltaTime float64) {
 	systems := w.systems // Создаем копию для безопасного итерирования
 	w.systemsMutex.RUnlock()
 
-	for _, system := range systems {
-		system.Update(deltaTime)
+	// Паника одной системы не останавливает остальные (см. runSystem)
+	for _, entry := range systems {
+		if entry.quarantined {
+			continue
+		}
+		w.runSystem(entry, deltaTime)
 	}
+
+	w.releaseRemovedEntities()
 }
 
 // Внутренние методы для индексирования
@@ -364,7 +455,8 @@ func RegisterComponentType(name string) ComponentID {
 
 // BaseComponent предоставляет базовую реализацию интерфейса Component
 type BaseComponent struct {
-	TypeID ComponentID
+	TypeID     ComponentID
+	generation uint32 // Переходы между сущностями и пулом (см. Generation)
 }
 
 // Type возвращает ID типа компонента
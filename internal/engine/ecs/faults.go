@@ -0,0 +1,92 @@
+package ecs
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"runtime/debug"
+)
+
+// MaxSystemFaults - число паник системы подряд, после которого система
+// помещается в карантин: мир перестает ее вызывать
+const MaxSystemFaults = 3
+
+// systemEntry - система мира и ее сбои
+type systemEntry struct {
+	system      System
+	faults      int  // Паники подряд
+	totalFaults int  // Паники за все время
+	quarantined bool // Система больше не вызывается
+}
+
+// SystemFault - сведения о сбоях системы
+type SystemFault struct {
+	System      string // Тип системы
+	Faults      int    // Паники за все время
+	Quarantined bool   // Система в карантине
+}
+
+// systemName возвращает имя системы для журнала и статистики
+func systemName(s System) string {
+	return reflect.TypeOf(s).String()
+}
+
+// runSystem обновляет систему. Если включен RecoverSystemPanics, паника
+// перехватывается и засчитывается системе как сбой; успешное обновление
+// сбрасывает счетчик сбоев подряд.
+func (w *World) runSystem(entry *systemEntry, deltaTime float64) {
+	if !w.RecoverSystemPanics {
+		entry.system.Update(deltaTime)
+		return
+	}
+
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+				log.Printf("System %s panicked: %v\n%s", systemName(entry.system), r, debug.Stack())
+			}
+		}()
+		entry.system.Update(deltaTime)
+		return nil
+	}()
+
+	if err == nil {
+		if entry.faults > 0 {
+			w.systemsMutex.Lock()
+			entry.faults = 0
+			w.systemsMutex.Unlock()
+		}
+		return
+	}
+
+	w.systemsMutex.Lock()
+	entry.faults++
+	entry.totalFaults++
+	quarantined := entry.faults >= MaxSystemFaults
+	entry.quarantined = quarantined
+	w.systemsMutex.Unlock()
+
+	if quarantined {
+		log.Printf("System %s quarantined after %d consecutive panics", systemName(entry.system), entry.faults)
+		if w.OnSystemQuarantined != nil {
+			w.OnSystemQuarantined(systemName(entry.system), err)
+		}
+	}
+}
+
+// SystemFaults возвращает сбои систем мира в порядке их добавления
+func (w *World) SystemFaults() []SystemFault {
+	w.systemsMutex.RLock()
+	defer w.systemsMutex.RUnlock()
+
+	faults := make([]SystemFault, 0, len(w.systems))
+	for _, entry := range w.systems {
+		faults = append(faults, SystemFault{
+			System:      systemName(entry.system),
+			Faults:      entry.totalFaults,
+			Quarantined: entry.quarantined,
+		})
+	}
+	return faults
+}
@@ -0,0 +1,112 @@
+package ecs
+
+import (
+	"io"
+	"log"
+	"os"
+	"testing"
+)
+
+// countingSystem считает свои обновления
+type countingSystem struct {
+	updates int
+}
+
+func (s *countingSystem) Update(deltaTime float64)          { s.updates++ }
+func (s *countingSystem) RequiredComponents() []ComponentID { return nil }
+
+// panickingSystem паникует в первых failures обновлениях
+type panickingSystem struct {
+	failures int
+	updates  int
+}
+
+func (s *panickingSystem) Update(deltaTime float64) {
+	s.updates++
+	if s.updates <= s.failures {
+		var entity *Entity
+		_ = entity.ID // Разыменование nil, как в сломанном колбэке контента
+	}
+}
+
+func (s *panickingSystem) RequiredComponents() []ComponentID { return nil }
+
+// quietLog отключает журнал паник на время теста
+func quietLog(t *testing.T) {
+	t.Helper()
+
+	log.SetOutput(io.Discard)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+}
+
+func TestPanickingSystemIsQuarantined(t *testing.T) {
+	quietLog(t)
+
+	world := NewWorld()
+	world.RecoverSystemPanics = true
+	broken := &panickingSystem{failures: 100}
+	healthy := &countingSystem{}
+	world.AddSystem(broken)
+	world.AddSystem(healthy)
+
+	quarantined := make([]string, 0)
+	world.OnSystemQuarantined = func(system string, err error) {
+		quarantined = append(quarantined, system)
+	}
+
+	for i := 0; i < MaxSystemFaults+2; i++ {
+		world.Update(0.1)
+	}
+
+	if healthy.updates != MaxSystemFaults+2 {
+		t.Errorf("healthy system updated %d times, want %d", healthy.updates, MaxSystemFaults+2)
+	}
+	if broken.updates != MaxSystemFaults {
+		t.Errorf("broken system updated %d times, want it stopped after %d", broken.updates, MaxSystemFaults)
+	}
+	if len(quarantined) != 1 || quarantined[0] != "*ecs.panickingSystem" {
+		t.Errorf("quarantined %v, want the panicking system once", quarantined)
+	}
+
+	faults := world.SystemFaults()
+	if !faults[0].Quarantined || faults[0].Faults != MaxSystemFaults || faults[1].Quarantined {
+		t.Errorf("SystemFaults() = %+v", faults)
+	}
+}
+
+func TestRecoveredSystemResetsConsecutiveFaults(t *testing.T) {
+	quietLog(t)
+
+	world := NewWorld()
+	world.RecoverSystemPanics = true
+	flaky := &panickingSystem{failures: MaxSystemFaults - 1}
+	world.AddSystem(flaky)
+
+	for i := 0; i < 2*MaxSystemFaults; i++ {
+		world.Update(0.1)
+	}
+
+	faults := world.SystemFaults()
+	if faults[0].Quarantined || faults[0].Faults != MaxSystemFaults-1 {
+		t.Errorf("SystemFaults() = %+v, want %d faults and no quarantine", faults, MaxSystemFaults-1)
+	}
+	if flaky.updates != 2*MaxSystemFaults {
+		t.Errorf("flaky system updated %d times, want %d", flaky.updates, 2*MaxSystemFaults)
+	}
+}
+
+func TestPanicsAreNotRecoveredByDefault(t *testing.T) {
+	if recoverSystemPanicsDefault {
+		t.Skip("release build recovers system panics")
+	}
+
+	world := NewWorld()
+	world.AddSystem(&panickingSystem{failures: 1})
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("panic of a system was swallowed outside release builds")
+		}
+	}()
+	world.Update(0.1)
+}
@@ -0,0 +1,147 @@
+package ecs
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Пулы самых массовых компонентов. Активация и выгрузка чанков создают и бросают
+// тысячи трансформов, компонентов рендера, физики и метаморфичности; пулы
+// переиспользуют их, чтобы не нагружать сборщик мусора. Конструкторы этих
+// компонентов берут их из пулов, а World.RemoveEntity возвращает их обратно
+// в конце кадра.
+
+// poolingEnabled - включены ли пулы компонентов (см. SetComponentPooling)
+var poolingEnabled atomic.Bool
+
+func init() {
+	poolingEnabled.Store(true)
+}
+
+// SetComponentPooling включает или выключает пулы компонентов. Выключенные пулы
+// помогают искать ошибки работы с памятью: компоненты создаются заново и не возвращаются.
+func SetComponentPooling(enabled bool) {
+	poolingEnabled.Store(enabled)
+}
+
+// ComponentPoolingEnabled сообщает, включены ли пулы компонентов
+func ComponentPoolingEnabled() bool {
+	return poolingEnabled.Load()
+}
+
+// pooledComponent - компонент со встроенным BaseComponent, которому нужен счетчик поколений
+type pooledComponent interface {
+	Component
+	base() *BaseComponent
+}
+
+// base возвращает встроенный базовый компонент
+func (bc *BaseComponent) base() *BaseComponent {
+	return bc
+}
+
+// Generation возвращает число переходов компонента между сущностями и пулом.
+// Нечетное значение означает, что компонент лежит в пуле.
+func (bc *BaseComponent) Generation() uint32 {
+	return bc.generation
+}
+
+// pooled проверяет, лежит ли компонент в пуле
+func (bc *BaseComponent) pooled() bool {
+	return bc.generation%2 == 1
+}
+
+// componentPool - пул компонентов одного типа
+type componentPool[T any, P interface {
+	*T
+	pooledComponent
+}] struct {
+	pool  sync.Pool
+	reset func(c P) // Обнуляет компонент перед возвратом в пул, сохраняя выделенную память
+}
+
+// get берет компонент из пула или создает новый, если пулы выключены или пусты
+func (p *componentPool[T, P]) get() P {
+	if !poolingEnabled.Load() {
+		return P(new(T))
+	}
+
+	c, ok := p.pool.Get().(P)
+	if !ok {
+		return P(new(T))
+	}
+	c.base().generation++
+	return c
+}
+
+// put обнуляет компонент и возвращает его в пул
+func (p *componentPool[T, P]) put(c P) {
+	generation := c.base().generation
+	p.reset(c)
+	c.base().generation = generation + 1
+	p.pool.Put(c)
+}
+
+var (
+	transformPool = &componentPool[TransformComponent, *TransformComponent]{
+		reset: func(t *TransformComponent) {
+			*t = TransformComponent{Children: t.Children[:0]}
+		},
+	}
+	renderPool = &componentPool[RenderComponent, *RenderComponent]{
+		reset: func(r *RenderComponent) {
+			*r = RenderComponent{Effects: EffectStack{entries: r.Effects.entries[:0]}, tints: r.tints[:0]}
+		},
+	}
+	physicsPool = &componentPool[PhysicsComponent, *PhysicsComponent]{
+		reset: func(p *PhysicsComponent) {
+			*p = PhysicsComponent{}
+		},
+	}
+	metamorphicPool = &componentPool[MetamorphicComponent, *MetamorphicComponent]{
+		reset: func(m *MetamorphicComponent) {
+			modifiers := m.PropertyModifiers
+			for key := range modifiers {
+				delete(modifiers, key)
+			}
+			*m = MetamorphicComponent{
+				CurrentMetamorphoses: m.CurrentMetamorphoses[:0],
+				PossibleMutations:    m.PossibleMutations[:0],
+				PropertyModifiers:    modifiers,
+			}
+		},
+	}
+)
+
+// releaseComponent возвращает компонент в пул его типа. Компоненты других типов
+// остаются сборщику мусора.
+func releaseComponent(c Component) {
+	switch typed := c.(type) {
+	case *TransformComponent:
+		transformPool.put(typed)
+	case *RenderComponent:
+		renderPool.put(typed)
+	case *PhysicsComponent:
+		physicsPool.put(typed)
+	case *MetamorphicComponent:
+		metamorphicPool.put(typed)
+	}
+}
+
+// releaseEntityComponents отбирает компоненты у удаленной из мира сущности и
+// возвращает их в пулы: после этого компоненты недостижимы через сущность
+func releaseEntityComponents(e *Entity) {
+	for id, c := range e.components {
+		delete(e.components, id)
+		releaseComponent(c)
+	}
+}
+
+// checkLive проверяет, что компонент живой сущности не лежит в пуле
+// (см. poolViolation)
+func checkLive(c Component) {
+	if p, ok := c.(pooledComponent); ok && p.base().pooled() {
+		poolViolation(fmt.Sprintf("ecs: pooled %s component (generation %d) is reachable from a live entity", c.Type(), p.base().generation))
+	}
+}
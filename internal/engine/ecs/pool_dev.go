@@ -0,0 +1,9 @@
+//go:build dev
+
+package ecs
+
+// poolViolation - в dev-сборках (go build -tags dev) компонент из пула,
+// достижимый через живую сущность, останавливает игру
+func poolViolation(message string) {
+	panic(message)
+}
@@ -0,0 +1,11 @@
+//go:build !dev
+
+package ecs
+
+import "log"
+
+// poolViolation - в обычных сборках компонент из пула, достижимый через живую
+// сущность, только попадает в журнал
+func poolViolation(message string) {
+	log.Print(message)
+}
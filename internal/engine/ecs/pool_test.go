@@ -0,0 +1,137 @@
+package ecs
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+// newPooledEntity создает сущность с массовыми компонентами из пулов
+func newPooledEntity(position Vector3) *Entity {
+	entity := NewEntity()
+	entity.AddComponent(NewTransformComponent(position))
+	entity.AddComponent(NewRenderComponent("pine", "bark"))
+	entity.AddComponent(NewPhysicsComponent(1, false))
+	entity.AddComponent(NewMetamorphicComponent(0.5))
+	return entity
+}
+
+// withPooling включает или выключает пулы на время теста
+func withPooling(t testing.TB, enabled bool) {
+	t.Helper()
+	previous := ComponentPoolingEnabled()
+	SetComponentPooling(enabled)
+	t.Cleanup(func() { SetComponentPooling(previous) })
+}
+
+func TestRemovedEntityKeepsComponentsUntilEndOfUpdate(t *testing.T) {
+	withPooling(t, true)
+	world := NewWorld()
+	entity := newPooledEntity(Vector3{X: 5, Z: 7})
+	world.AddEntity(entity)
+	transform, _ := ComponentAs[*TransformComponent](entity, TransformComponentID)
+
+	// Системы, которые еще держат сущность в этом кадре, читают прежние значения
+	world.RemoveEntity(entity.ID)
+	if got, ok := ComponentAs[*TransformComponent](entity, TransformComponentID); !ok || got.Position != (Vector3{X: 5, Z: 7}) {
+		t.Fatalf("removed entity lost its transform before the end of the frame: %v, %v", got, ok)
+	}
+
+	world.Update(0.1)
+	if entity.HasComponent(TransformComponentID) {
+		t.Errorf("removed entity still has its components after Update")
+	}
+	if !transform.pooled() {
+		t.Errorf("transform generation %d, want it back in the pool", transform.Generation())
+	}
+	if transform.Position != (Vector3{}) {
+		t.Errorf("pooled transform was not zeroed: %v", transform.Position)
+	}
+}
+
+func TestPooledComponentsAreReusedZeroed(t *testing.T) {
+	withPooling(t, true)
+
+	render := NewRenderComponent("pine", "bark")
+	render.Effects.AddEffect("glow", 0.5, "test")
+	render.TintColor(render.Color, "test")
+	renderPool.put(render)
+
+	// sync.Pool может отдать и новый компонент; проверяем оба случая
+	reused := NewRenderComponent("birch", "bark")
+	if reused.pooled() {
+		t.Errorf("component from the pool still counts as pooled (generation %d)", reused.Generation())
+	}
+	if len(reused.Effects.entries) != 0 || len(reused.tints) != 0 || reused.ModelID != "birch" {
+		t.Errorf("reused render component kept old state: %+v", reused)
+	}
+}
+
+func TestDisabledPoolingKeepsComponents(t *testing.T) {
+	withPooling(t, false)
+	world := NewWorld()
+	entity := newPooledEntity(Vector3{X: 1})
+	world.AddEntity(entity)
+	transform, _ := ComponentAs[*TransformComponent](entity, TransformComponentID)
+
+	world.RemoveEntity(entity.ID)
+	world.Update(0.1)
+	if !entity.HasComponent(TransformComponentID) || transform.pooled() || transform.Position.X != 1 {
+		t.Errorf("component was released with pooling disabled")
+	}
+}
+
+func TestCheckLiveReportsPooledComponent(t *testing.T) {
+	withPooling(t, true)
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	transform := NewTransformComponent(Vector3{})
+	transformPool.put(transform)
+
+	// Компонент из пула, по ошибке добавленный живой сущности: в dev-сборках
+	// паника, в остальных запись в журнале
+	var panicked interface{}
+	func() {
+		defer func() { panicked = recover() }()
+		NewEntity().AddComponent(transform)
+	}()
+	if panicked == nil && !strings.Contains(logged.String(), "reachable from a live entity") {
+		t.Errorf("pooled component on a live entity was not reported, log: %q", logged.String())
+	}
+}
+
+// BenchmarkChunkWalk активирует и выгружает 100 чанков по 50 сущностей, как при
+// переходе игрока через мир, с пулами и без
+func BenchmarkChunkWalk(b *testing.B) {
+	const chunks, entitiesPerChunk = 100, 50
+
+	for _, pooling := range []bool{false, true} {
+		b.Run(fmt.Sprintf("pooling=%v", pooling), func(b *testing.B) {
+			withPooling(b, pooling)
+			world := NewWorld()
+			ids := make([]EntityID, 0, entitiesPerChunk)
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				for chunk := 0; chunk < chunks; chunk++ {
+					ids = ids[:0]
+					for j := 0; j < entitiesPerChunk; j++ {
+						entity := newPooledEntity(Vector3{X: float64(chunk * 32), Z: float64(j)})
+						world.AddEntity(entity)
+						ids = append(ids, entity.ID)
+					}
+					for _, id := range ids {
+						world.RemoveEntity(id)
+					}
+					world.Update(0.016)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !release
+
+package ecs
+
+// recoverSystemPanicsDefault - без тега release (в том числе в go test и dev-сборках)
+// паника системы не перехватывается, чтобы ошибка была видна сразу
+const recoverSystemPanicsDefault = false
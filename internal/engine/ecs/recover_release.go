@@ -0,0 +1,7 @@
+//go:build release
+
+package ecs
+
+// recoverSystemPanicsDefault - в выпускных сборках (go build -tags release) паника
+// системы перехватывается (см. World.RecoverSystemPanics)
+const recoverSystemPanicsDefault = true
@@ -0,0 +1,226 @@
+package ecs
+
+import (
+	"encoding/json"
+	"image/color"
+	"sort"
+)
+
+// MaxRenderEffects - максимальное число эффектов отрисовки на одной сущности
+const MaxRenderEffects = 8
+
+// EffectSourceBase - источник собственных эффектов сущности (заданных при создании)
+const EffectSourceBase = "base"
+
+// renderEffectPriority задает приоритет эффектов при вытеснении (больше - важнее)
+var renderEffectPriority = map[string]int{
+	"distortion":   5,
+	"warp":         4,
+	"glow":         3,
+	"cracked":      3,
+	"color_shift":  2,
+	"transparency": 2,
+	"blur":         1,
+}
+
+// RenderEffect - вклад одного источника в эффект отрисовки
+type RenderEffect struct {
+	Name      string  // Название эффекта (glow, distortion, ...)
+	Intensity float64 // Сила эффекта (0-1)
+	Priority  int     // Приоритет при вытеснении
+	Source    string  // Кто добавил эффект (ID эффекта метаморфозы или EffectSourceBase)
+}
+
+// EffectStack - ограниченный набор эффектов отрисовки с учетом источников.
+// Один и тот же эффект могут добавить несколько источников; он остается,
+// пока его не уберут все. В JSON сохраняется как прежний массив названий.
+type EffectStack struct {
+	entries []RenderEffect
+}
+
+// AddEffect добавляет вклад источника в эффект. Повторный вклад того же источника
+// обновляет силу эффекта. При переполнении вытесняется наименее важный вклад.
+func (s *EffectStack) AddEffect(name string, intensity float64, source string) {
+	for i := range s.entries {
+		if s.entries[i].Name == name && s.entries[i].Source == source {
+			s.entries[i].Intensity = intensity
+			return
+		}
+	}
+
+	s.entries = append(s.entries, RenderEffect{
+		Name:      name,
+		Intensity: intensity,
+		Priority:  renderEffectPriority[name],
+		Source:    source,
+	})
+
+	if len(s.entries) > MaxRenderEffects {
+		s.evict()
+	}
+}
+
+// RemoveEffect убирает вклад источника в эффект
+func (s *EffectStack) RemoveEffect(name, source string) {
+	s.removeWhere(func(effect RenderEffect) bool {
+		return effect.Name == name && effect.Source == source
+	})
+}
+
+// RemoveEffectsFromSource убирает все вклады источника
+func (s *EffectStack) RemoveEffectsFromSource(source string) {
+	s.removeWhere(func(effect RenderEffect) bool {
+		return effect.Source == source
+	})
+}
+
+// Has проверяет, действует ли эффект (от любого источника)
+func (s *EffectStack) Has(name string) bool {
+	for _, effect := range s.entries {
+		if effect.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Intensity возвращает наибольшую силу эффекта среди источников (0, если эффекта нет)
+func (s *EffectStack) Intensity(name string) float64 {
+	intensity := 0.0
+	for _, effect := range s.entries {
+		if effect.Name == name && effect.Intensity > intensity {
+			intensity = effect.Intensity
+		}
+	}
+	return intensity
+}
+
+// Names возвращает названия действующих эффектов без повторов, важные первыми
+func (s *EffectStack) Names() []string {
+	seen := make(map[string]bool, len(s.entries))
+	names := make([]string, 0, len(s.entries))
+	for _, effect := range s.entries {
+		if !seen[effect.Name] {
+			seen[effect.Name] = true
+			names = append(names, effect.Name)
+		}
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		return renderEffectPriority[names[i]] > renderEffectPriority[names[j]]
+	})
+	return names
+}
+
+// Entries возвращает копию всех вкладов
+func (s *EffectStack) Entries() []RenderEffect {
+	result := make([]RenderEffect, len(s.entries))
+	copy(result, s.entries)
+	return result
+}
+
+// Len возвращает число вкладов
+func (s *EffectStack) Len() int {
+	return len(s.entries)
+}
+
+// Clone возвращает независимую копию набора
+func (s *EffectStack) Clone() EffectStack {
+	return EffectStack{entries: s.Entries()}
+}
+
+// MarshalJSON сохраняет набор как массив названий
+func (s EffectStack) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Names())
+}
+
+// UnmarshalJSON читает массив названий как собственные эффекты сущности
+func (s *EffectStack) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+
+	s.entries = nil
+	for _, name := range names {
+		s.AddEffect(name, 1.0, EffectSourceBase)
+	}
+	return nil
+}
+
+// evict вытесняет вклад с наименьшим приоритетом, при равенстве - самый слабый,
+// затем самый старый
+func (s *EffectStack) evict() {
+	victim := 0
+	for i := 1; i < len(s.entries); i++ {
+		candidate, current := s.entries[i], s.entries[victim]
+		if candidate.Priority < current.Priority ||
+			(candidate.Priority == current.Priority && candidate.Intensity < current.Intensity) {
+			victim = i
+		}
+	}
+
+	s.entries = append(s.entries[:victim], s.entries[victim+1:]...)
+}
+
+// removeWhere убирает вклады, подходящие под условие
+func (s *EffectStack) removeWhere(match func(RenderEffect) bool) {
+	kept := s.entries[:0]
+	for _, effect := range s.entries {
+		if !match(effect) {
+			kept = append(kept, effect)
+		}
+	}
+	s.entries = kept
+}
+
+// colorTint - смена цвета сущности, внесенная источником
+type colorTint struct {
+	Source string
+	Color  color.RGBA
+}
+
+// TintColor меняет цвет сущности от имени источника. Собственный цвет
+// запоминается в BaseColor при первой смене и возвращается, когда все
+// источники уберут свои смены (см. RemoveTint). Повторная смена того же
+// источника заменяет его цвет.
+func (r *RenderComponent) TintColor(tint color.RGBA, source string) {
+	if len(r.tints) == 0 {
+		r.BaseColor = r.Color
+	}
+
+	for i := range r.tints {
+		if r.tints[i].Source == source {
+			r.tints = append(r.tints[:i], r.tints[i+1:]...)
+			break
+		}
+	}
+	r.tints = append(r.tints, colorTint{Source: source, Color: tint})
+	r.Color = tint
+}
+
+// RemoveTint убирает смену цвета источника: действует предыдущая смена или,
+// если смен не осталось, собственный цвет сущности
+func (r *RenderComponent) RemoveTint(source string) {
+	for i := range r.tints {
+		if r.tints[i].Source != source {
+			continue
+		}
+
+		r.tints = append(r.tints[:i], r.tints[i+1:]...)
+		if len(r.tints) == 0 {
+			r.Color = r.BaseColor
+		} else {
+			r.Color = r.tints[len(r.tints)-1].Color
+		}
+		return
+	}
+}
+
+// OwnColor возвращает собственный цвет сущности без смен цвета
+func (r *RenderComponent) OwnColor() color.RGBA {
+	if len(r.tints) == 0 {
+		return r.Color
+	}
+	return r.BaseColor
+}
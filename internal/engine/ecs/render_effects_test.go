@@ -0,0 +1,139 @@
+package ecs
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestTintColorRoundTripsToBaseColor(t *testing.T) {
+	own := color.RGBA{R: 90, G: 120, B: 60, A: 255}
+	render := NewRenderComponent("pine", "bark")
+	render.Color = own
+
+	frost := color.RGBA{R: 180, G: 200, B: 230, A: 255}
+	rot := color.RGBA{R: 70, G: 60, B: 40, A: 255}
+	render.TintColor(frost, "frost")
+	render.TintColor(rot, "rot")
+	if render.Color != rot || render.OwnColor() != own {
+		t.Fatalf("color %v, own %v after two tints", render.Color, render.OwnColor())
+	}
+
+	// Снятие первой смены оставляет действующей последнюю
+	render.RemoveTint("frost")
+	if render.Color != rot {
+		t.Errorf("color %v after removing an older tint, want %v", render.Color, rot)
+	}
+
+	// Повторная смена источника заменяет его цвет, а не добавляет новый
+	render.TintColor(frost, "rot")
+	render.RemoveTint("rot")
+	if render.Color != own {
+		t.Errorf("color %v after removing every tint, want the own color %v", render.Color, own)
+	}
+
+	// Снятие неизвестного источника ничего не меняет
+	render.RemoveTint("ash")
+	if render.Color != own {
+		t.Errorf("removing an unknown tint changed the color to %v", render.Color)
+	}
+}
+
+func TestTintColorRemembersColorAtFirstTint(t *testing.T) {
+	render := NewRenderComponent("pine", "bark")
+	render.TintColor(color.RGBA{R: 10, A: 255}, "frost")
+	render.RemoveTint("frost")
+
+	// Собственный цвет, измененный между сменами, запоминается заново
+	repainted := color.RGBA{G: 200, A: 255}
+	render.Color = repainted
+	render.TintColor(color.RGBA{B: 10, A: 255}, "rot")
+	render.RemoveTint("rot")
+	if render.Color != repainted {
+		t.Errorf("color %v, want the repainted %v", render.Color, repainted)
+	}
+}
+
+func TestEffectStackEvictsLeastImportantEffect(t *testing.T) {
+	var stack EffectStack
+	stack.AddEffect("blur", 0.9, "old")
+	for i := 1; i < MaxRenderEffects; i++ {
+		stack.AddEffect("glow", float64(i)/10, fmt.Sprintf("source_%d", i))
+	}
+	if stack.Len() != MaxRenderEffects {
+		t.Fatalf("%d effects, want a full stack of %d", stack.Len(), MaxRenderEffects)
+	}
+
+	// Сначала вытесняется эффект с наименьшим приоритетом, даже самый сильный
+	stack.AddEffect("distortion", 0.5, "new")
+	if stack.Len() != MaxRenderEffects || stack.Has("blur") {
+		t.Fatalf("effects %v, want blur evicted to make room", stack.Names())
+	}
+
+	// При равном приоритете вытесняется самый слабый вклад
+	stack.AddEffect("glow", 0.95, "strong")
+	for _, effect := range stack.Entries() {
+		if effect.Source == "source_1" {
+			t.Errorf("the weakest glow survived the eviction")
+		}
+	}
+	if !stack.Has("distortion") || stack.Intensity("glow") != 0.95 {
+		t.Errorf("effects %+v, want distortion and the strongest glow kept", stack.Entries())
+	}
+}
+
+func TestEffectStackRemovesOnlyTheSourceContributions(t *testing.T) {
+	var stack EffectStack
+	stack.AddEffect("glow", 0.4, "bloom")
+	stack.AddEffect("glow", 0.8, "rift")
+	stack.AddEffect("warp", 0.5, "rift")
+
+	// Вклад, добавленный повторно, обновляет силу, а не дублируется
+	stack.AddEffect("glow", 0.6, "bloom")
+	if stack.Len() != 3 {
+		t.Fatalf("%d contributions, want 3", stack.Len())
+	}
+
+	stack.RemoveEffectsFromSource("rift")
+	if !stack.Has("glow") || stack.Has("warp") {
+		t.Errorf("effects %v after removing rift, want only the glow of bloom", stack.Names())
+	}
+	if intensity := stack.Intensity("glow"); intensity != 0.6 {
+		t.Errorf("glow intensity %v, want the 0.6 of bloom", intensity)
+	}
+
+	stack.RemoveEffect("glow", "bloom")
+	if stack.Len() != 0 {
+		t.Errorf("effects %v left after removing every contribution", stack.Names())
+	}
+}
+
+func TestEffectStackMarshalsAsNameArray(t *testing.T) {
+	var stack EffectStack
+	stack.AddEffect("glow", 0.4, "bloom")
+	stack.AddEffect("glow", 0.8, "rift")
+	stack.AddEffect("distortion", 0.5, "rift")
+
+	data, err := json.Marshal(stack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `["distortion","glow"]` {
+		t.Errorf("marshaled %s, want the names without repeats", data)
+	}
+
+	var loaded EffectStack
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Names(), stack.Names()) {
+		t.Errorf("loaded %v, want %v", loaded.Names(), stack.Names())
+	}
+	for _, effect := range loaded.Entries() {
+		if effect.Source != EffectSourceBase {
+			t.Errorf("loaded effect %s from %s, want the base source", effect.Name, effect.Source)
+		}
+	}
+}
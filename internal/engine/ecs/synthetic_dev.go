@@ -0,0 +1,36 @@
+//go:build dev
+
+package ecs
+
+import "math/rand"
+
+// PopulateSynthetic добавляет в мир count синтетических сущностей с трансформацией,
+// случайно расставленных в квадрате со стороной extent вокруг начала координат.
+// Используется для профилирования покадрового обновления (только в dev-сборках).
+// Теги должны быть зарегистрированы: dev-сборки проверяют их строго.
+func PopulateSynthetic(world *World, count int, extent float64, seed int64, tags ...string) ([]*Entity, error) {
+	for _, tag := range tags {
+		if err := Tags.Validate(tag); err != nil {
+			return nil, err
+		}
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	entities := make([]*Entity, 0, count)
+
+	for i := 0; i < count; i++ {
+		entity := NewEntity()
+		entity.AddComponent(NewTransformComponent(Vector3{
+			X: (rng.Float64() - 0.5) * extent,
+			Z: (rng.Float64() - 0.5) * extent,
+		}))
+		for _, tag := range tags {
+			entity.AddTag(tag)
+		}
+
+		world.AddEntity(entity)
+		entities = append(entities, entity)
+	}
+
+	return entities, nil
+}
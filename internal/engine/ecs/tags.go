@@ -0,0 +1,243 @@
+package ecs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TagInfo описывает зарегистрированный тег сущности
+type TagInfo struct {
+	Name        string // Имя тега
+	Category    string // Категория (species, environment, state, ...)
+	Description string // Описание назначения тега
+}
+
+// TagReport содержит результат сверки определенных и запрашиваемых тегов
+type TagReport struct {
+	DefinedNotQueried []string // Теги, которые определены, но нигде не запрашиваются
+	QueriedNotDefined []string // Теги, которые запрашиваются, но никем не определены
+}
+
+// TagRegistry хранит таксономию тегов сущностей. Запросы тегов отмечаются без
+// блокировки реестра: HasTag и GetEntitiesWithTag вызываются каждый кадр.
+type TagRegistry struct {
+	tags    map[string]TagInfo
+	queried sync.Map // Имя тега -> struct{}
+	strict  atomic.Bool
+	mutex   sync.RWMutex
+}
+
+// Tags - глобальный реестр тегов. Пакеты регистрируют свои теги в init.
+var Tags = NewTagRegistry()
+
+// NewTagRegistry создает пустой реестр тегов. В dev-сборках и в тестах
+// реестр сразу работает в строгом режиме.
+func NewTagRegistry() *TagRegistry {
+	r := &TagRegistry{tags: make(map[string]TagInfo)}
+	r.strict.Store(strictTagsDefault || testing.Testing())
+	return r
+}
+
+// RegisterTag регистрирует тег в глобальном реестре и возвращает его имя
+func RegisterTag(name, category, description string) string {
+	return Tags.Register(name, category, description)
+}
+
+// DeclareTagQuery отмечает в глобальном реестре, что тег запрашивается
+func DeclareTagQuery(names ...string) {
+	Tags.DeclareQuery(names...)
+}
+
+// SetStrictTags включает или выключает строгий режим глобального реестра
+func SetStrictTags(strict bool) {
+	Tags.SetStrict(strict)
+}
+
+// Register регистрирует тег. Повторная регистрация с пустым описанием не затирает существующее.
+func (r *TagRegistry) Register(name, category, description string) string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if existing, exists := r.tags[name]; exists && description == "" {
+		description = existing.Description
+	}
+
+	r.tags[name] = TagInfo{
+		Name:        name,
+		Category:    category,
+		Description: description,
+	}
+
+	return name
+}
+
+// DeclareQuery отмечает теги как запрашиваемые
+func (r *TagRegistry) DeclareQuery(names ...string) {
+	for _, name := range names {
+		r.queried.Store(name, struct{}{})
+	}
+}
+
+// SetStrict включает или выключает строгий режим
+func (r *TagRegistry) SetStrict(strict bool) {
+	r.strict.Store(strict)
+}
+
+// IsStrict возвращает true, если включен строгий режим
+func (r *TagRegistry) IsStrict() bool {
+	return r.strict.Load()
+}
+
+// IsRegistered проверяет, зарегистрирован ли тег
+func (r *TagRegistry) IsRegistered(name string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	_, exists := r.tags[name]
+	return exists
+}
+
+// GetTag возвращает описание тега
+func (r *TagRegistry) GetTag(name string) (TagInfo, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	info, exists := r.tags[name]
+	return info, exists
+}
+
+// GetTagsByCategory возвращает отсортированный список тегов категории
+func (r *TagRegistry) GetTagsByCategory(category string) []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([]string, 0)
+	for name, info := range r.tags {
+		if info.Category == category {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Validate проверяет тег. В строгом режиме незарегистрированный тег
+// приводит к ошибке с подсказкой ближайшего известного тега.
+func (r *TagRegistry) Validate(name string) error {
+	if !r.strict.Load() {
+		return nil
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if _, exists := r.tags[name]; exists {
+		return nil
+	}
+
+	if suggestion := r.nearestTag(name); suggestion != "" {
+		return fmt.Errorf("unregistered tag %q (did you mean %q?)", name, suggestion)
+	}
+	return fmt.Errorf("unregistered tag %q", name)
+}
+
+// Report сверяет определенные и запрашиваемые теги
+func (r *TagRegistry) Report() TagReport {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	report := TagReport{
+		DefinedNotQueried: make([]string, 0),
+		QueriedNotDefined: make([]string, 0),
+	}
+
+	for name := range r.tags {
+		if _, queried := r.queried.Load(name); !queried {
+			report.DefinedNotQueried = append(report.DefinedNotQueried, name)
+		}
+	}
+
+	r.queried.Range(func(key, _ any) bool {
+		if _, exists := r.tags[key.(string)]; !exists {
+			report.QueriedNotDefined = append(report.QueriedNotDefined, key.(string))
+		}
+		return true
+	})
+
+	sort.Strings(report.DefinedNotQueried)
+	sort.Strings(report.QueriedNotDefined)
+	return report
+}
+
+// String форматирует отчет для вывода
+func (tr TagReport) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("Defined but never queried:\n")
+	for _, name := range tr.DefinedNotQueried {
+		sb.WriteString("  " + name + "\n")
+	}
+
+	sb.WriteString("Queried but never defined:\n")
+	for _, name := range tr.QueriedNotDefined {
+		sb.WriteString("  " + name + "\n")
+	}
+
+	return sb.String()
+}
+
+// noteQuery отмечает тег как запрашиваемый во время работы (только в строгом режиме)
+func (r *TagRegistry) noteQuery(name string) {
+	if !r.strict.Load() {
+		return
+	}
+	if _, known := r.queried.Load(name); !known {
+		r.queried.Store(name, struct{}{})
+	}
+}
+
+// nearestTag находит зарегистрированный тег с минимальным расстоянием Левенштейна
+func (r *TagRegistry) nearestTag(name string) string {
+	best := ""
+	bestDistance := len(name)/2 + 2 // Не предлагаем совсем непохожие теги
+
+	for candidate := range r.tags {
+		distance := levenshtein(name, candidate)
+		if distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+
+	return best
+}
+
+// levenshtein вычисляет редакционное расстояние между строками
+func levenshtein(a, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
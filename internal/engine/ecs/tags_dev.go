@@ -0,0 +1,6 @@
+//go:build dev
+
+package ecs
+
+// strictTagsDefault - в dev-сборках (go build -tags dev) теги проверяются строго
+const strictTagsDefault = true
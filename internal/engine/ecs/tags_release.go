@@ -0,0 +1,6 @@
+//go:build !dev
+
+package ecs
+
+// strictTagsDefault - в обычных сборках незарегистрированные теги разрешены (кроме тестов)
+const strictTagsDefault = false
@@ -0,0 +1,72 @@
+package ecs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// withStrictTags включает строгий режим глобального реестра на время теста
+func withStrictTags(t *testing.T) {
+	t.Helper()
+	previous := Tags.IsStrict()
+	SetStrictTags(true)
+	t.Cleanup(func() { SetStrictTags(previous) })
+}
+
+func TestStrictAddTagPanicsWithSuggestion(t *testing.T) {
+	withStrictTags(t)
+	RegisterTag("test_lantern", "test", "Фонарь для теста")
+
+	entity := NewEntity()
+	entity.AddTag("test_lantern")
+
+	var panicked interface{}
+	func() {
+		defer func() { panicked = recover() }()
+		entity.AddTag("test_lanten")
+	}()
+	if panicked == nil || !strings.Contains(fmt.Sprint(panicked), `did you mean "test_lantern"`) {
+		t.Errorf("misspelled tag gave %v, want a panic suggesting test_lantern", panicked)
+	}
+	if entity.HasTag("test_lanten") {
+		t.Errorf("misspelled tag was added")
+	}
+}
+
+func TestTagReportListsUnmatchedTags(t *testing.T) {
+	registry := NewTagRegistry()
+	registry.Register("test_fern", "test", "Папоротник")
+	registry.Register("test_moss", "test", "Мох")
+	registry.DeclareQuery("test_fern", "test_lichen")
+
+	report := registry.Report()
+	if len(report.DefinedNotQueried) != 1 || report.DefinedNotQueried[0] != "test_moss" {
+		t.Errorf("defined but not queried: %v, want [test_moss]", report.DefinedNotQueried)
+	}
+	if len(report.QueriedNotDefined) != 1 || report.QueriedNotDefined[0] != "test_lichen" {
+		t.Errorf("queried but not defined: %v, want [test_lichen]", report.QueriedNotDefined)
+	}
+}
+
+func TestStrictModeNotesRuntimeQueries(t *testing.T) {
+	registry := NewTagRegistry()
+	registry.SetStrict(false)
+	registry.noteQuery("test_ember")
+	if got := registry.Report().QueriedNotDefined; len(got) != 0 {
+		t.Errorf("lenient registry noted queries: %v", got)
+	}
+
+	registry.SetStrict(true)
+	registry.noteQuery("test_ember")
+	registry.noteQuery("test_ember")
+	if got := registry.Report().QueriedNotDefined; len(got) != 1 || got[0] != "test_ember" {
+		t.Errorf("strict registry noted %v, want [test_ember]", got)
+	}
+}
+
+func TestTagsAreStrictInTests(t *testing.T) {
+	if !NewTagRegistry().IsStrict() {
+		t.Errorf("new registry is lenient in a test binary, want strict")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"echo-taiga/internal/engine/ecs"
 	"math"
 	"math/rand/v2"
-	"time"
 )
 
 // Engine представляет основной игровой движок
@@ -19,8 +18,16 @@ type Engine struct {
 // PhysicsSystem отвечает за физическую симуляцию
 type PhysicsSystem struct {
 	world *ecs.World
+	rules func() map[string]float64 // Действующие правила мира (см. SetWorldRules)
 }
 
+// Правила мира, которые читает физика. Глобальные значения умножаются на
+// собственные модификаторы сущностей.
+const (
+	GravityRule  = "physics.gravity"
+	FrictionRule = "physics.friction"
+)
+
 // CollisionSystem отвечает за обработку столкновений
 type CollisionSystem struct {
 	world *ecs.World
@@ -29,6 +36,7 @@ type CollisionSystem struct {
 // AISystem отвечает за поведение искусственного интеллекта
 type AISystem struct {
 	world *ecs.World
+	clock Clock // Часы, по которым идет перезарядка атак
 }
 
 // NewEngine создает новый игровой движок
@@ -54,10 +62,21 @@ func (e *Engine) initializeSystems() {
 	e.world.AddSystem(e.collisionSystem)
 
 	// Создаем и регистрируем систему ИИ
-	e.aiSystem = &AISystem{world: e.world}
+	e.aiSystem = &AISystem{world: e.world, clock: RealClock{}}
 	e.world.AddSystem(e.aiSystem)
 }
 
+// SetClock задает часы, по которым ИИ отсчитывает перезарядку атак
+func (e *Engine) SetClock(clock Clock) {
+	e.aiSystem.clock = clock
+}
+
+// SetWorldRules задает источник действующих правил мира, общих для всех сущностей.
+// Без него физика идет по собственным модификаторам сущностей.
+func (e *Engine) SetWorldRules(rules func() map[string]float64) {
+	e.physicsSystem.rules = rules
+}
+
 // RequiredComponents возвращает компоненты, необходимые для работы системы физики
 func (ps *PhysicsSystem) RequiredComponents() []ecs.ComponentID {
 	return []ecs.ComponentID{
@@ -68,6 +87,18 @@ func (ps *PhysicsSystem) RequiredComponents() []ecs.ComponentID {
 
 // Update обновляет физическую симуляцию
 func (ps *PhysicsSystem) Update(deltaTime float64) {
+	// Глобальные правила читаются один раз за шаг
+	gravity, friction := 1.0, 1.0
+	if ps.rules != nil {
+		rules := ps.rules()
+		if value, exists := rules[GravityRule]; exists {
+			gravity = value
+		}
+		if value, exists := rules[FrictionRule]; exists {
+			friction = value
+		}
+	}
+
 	// Получаем все сущности с физическими компонентами
 	entities := ps.world.GetEntitiesWithComponent(ecs.PhysicsComponentID)
 
@@ -85,7 +116,7 @@ func (ps *PhysicsSystem) Update(deltaTime float64) {
 		}
 
 		// Применяем гравитацию
-		physics.Velocity.Y -= 9.8 * physics.Gravity * deltaTime
+		physics.Velocity.Y -= 9.8 * physics.Gravity * gravity * deltaTime
 
 		// Обновляем позицию на основе скорости
 		transform.Position = transform.Position.Add(
@@ -93,7 +124,7 @@ func (ps *PhysicsSystem) Update(deltaTime float64) {
 		)
 
 		// Затухание скорости из-за трения
-		physics.Velocity = physics.Velocity.Multiply(1 - physics.Friction*deltaTime)
+		physics.Velocity = physics.Velocity.Multiply(1 - physics.Friction*friction*deltaTime)
 	}
 }
 
@@ -202,6 +233,9 @@ func (as *AISystem) RequiredComponents() []ecs.ComponentID {
 
 // Update обновляет поведение ИИ
 func (as *AISystem) Update(deltaTime float64) {
+	// Стихшие шумы больше не слышны
+	as.updateNoiseEvents(deltaTime)
+
 	// Получаем все сущности с компонентом ИИ
 	entities := as.world.GetEntitiesWithComponent(ecs.AIComponentID)
 
@@ -225,12 +259,19 @@ func (as *AISystem) Update(deltaTime float64) {
 			as.handleAttackState(ai, transform, deltaTime)
 		case "flee":
 			as.handleFleeState(ai, transform, deltaTime)
+		case "investigate":
+			as.handleInvestigateState(ai, transform, deltaTime)
 		}
 	}
 }
 
 // Методы обработки состояний ИИ
 func (as *AISystem) handleIdleState(ai *ecs.AIComponent, transform *ecs.TransformComponent, deltaTime float64) {
+	// Услышанный шум важнее всего остального
+	if as.listenForNoise(ai, transform) {
+		return
+	}
+
 	// Проверяем наличие целей в зоне обнаружения
 	target := as.findNearestTarget(ai, transform)
 
@@ -254,6 +295,11 @@ func (as *AISystem) handleIdleState(ai *ecs.AIComponent, transform *ecs.Transfor
 }
 
 func (as *AISystem) handlePatrolState(ai *ecs.AIComponent, transform *ecs.TransformComponent, deltaTime float64) {
+	// Патрулирующее существо сворачивает к услышанному шуму
+	if as.listenForNoise(ai, transform) {
+		return
+	}
+
 	// Движение к следующей точке патрулирования
 	nextPoint := ai.GetNextPatrolPoint()
 
@@ -272,6 +318,11 @@ func (as *AISystem) handlePatrolState(ai *ecs.AIComponent, transform *ecs.Transf
 }
 
 func (as *AISystem) handleChaseState(ai *ecs.AIComponent, transform *ecs.TransformComponent, deltaTime float64) {
+	// Достаточно громкий шум сбивает погоню
+	if as.listenForNoise(ai, transform) {
+		return
+	}
+
 	// Ищем текущую цель
 	target := as.findNearestTarget(ai, transform)
 
@@ -298,7 +349,7 @@ func (as *AISystem) handleChaseState(ai *ecs.AIComponent, transform *ecs.Transfo
 // Исправьте типы и преобразования
 func (as *AISystem) handleAttackState(ai *ecs.AIComponent, transform *ecs.TransformComponent, deltaTime float64) {
 	// Проверяем, можем ли атаковать
-	currentTime := float64(time.Now().Unix())
+	currentTime := float64(as.clock.Now().Unix())
 	if ai.CanAttack(currentTime) {
 		damage := ai.Attack(currentTime)
 
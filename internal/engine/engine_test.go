@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// fallingSpeed возвращает скорость падения тела через секунду при правилах мира rules
+func fallingSpeed(rules map[string]float64) float64 {
+	world := ecs.NewWorld()
+	engine := NewEngine(world)
+	if rules != nil {
+		engine.SetWorldRules(func() map[string]float64 { return rules })
+	}
+
+	body := ecs.NewEntity()
+	body.AddComponent(ecs.NewTransformComponent(ecs.Vector3{Y: 100}))
+	physics := ecs.NewPhysicsComponent(1, false)
+	physics.Friction = 0
+	body.AddComponent(physics)
+	world.AddEntity(body)
+
+	engine.physicsSystem.Update(1)
+	return -physics.Velocity.Y
+}
+
+func TestPhysicsFollowsWorldGravity(t *testing.T) {
+	normal := fallingSpeed(nil)
+	if math.Abs(normal-9.8) > 1e-9 {
+		t.Fatalf("falling speed without rules = %v, want 9.8", normal)
+	}
+
+	if got := fallingSpeed(map[string]float64{GravityRule: 0.5}); math.Abs(got-normal*0.5) > 1e-9 {
+		t.Errorf("falling speed under half gravity = %v, want %v", got, normal*0.5)
+	}
+
+	// Правила без гравитации ее не меняют
+	if got := fallingSpeed(map[string]float64{FrictionRule: 0.5}); math.Abs(got-normal) > 1e-9 {
+		t.Errorf("falling speed with only a friction rule = %v, want %v", got, normal)
+	}
+}
+
+func TestAIAttackCooldownFollowsTheEngineClock(t *testing.T) {
+	engine := NewEngine(ecs.NewWorld())
+	clock := NewFakeClock(time.Date(2026, 1, 1, 22, 0, 0, 0, time.UTC))
+	engine.SetClock(clock)
+
+	ai := ecs.NewAIComponent("hostile", 10)
+	ai.AttackCooldown = 2
+	transform := ecs.NewTransformComponent(ecs.Vector3{})
+
+	engine.aiSystem.handleAttackState(ai, transform, 0.1)
+	if want := float64(clock.Now().Unix()); ai.LastAttackTime != want {
+		t.Fatalf("first attack at %v, want the clock's time %v", ai.LastAttackTime, want)
+	}
+	first := ai.LastAttackTime
+
+	// Пока часы стоят, перезарядка не проходит
+	engine.aiSystem.handleAttackState(ai, transform, 0.1)
+	if ai.LastAttackTime != first {
+		t.Errorf("attacked again at %v before the cooldown passed", ai.LastAttackTime)
+	}
+
+	clock.Advance(2 * time.Second)
+	engine.aiSystem.handleAttackState(ai, transform, 0.1)
+	if ai.LastAttackTime != first+2 {
+		t.Errorf("attack after the cooldown at %v, want %v", ai.LastAttackTime, first+2)
+	}
+}
@@ -0,0 +1,80 @@
+package engine
+
+import "math"
+
+// DefaultTickRate - частота шагов симуляции по умолчанию (шагов в секунду)
+const DefaultTickRate = 30
+
+// MaxCatchUpTicks - сколько шагов симуляция догоняет за один кадр. Время долгой
+// заминки сверх этого отбрасывается, иначе медленные кадры копили бы все больше
+// шагов и игра не выбралась бы из отставания.
+const MaxCatchUpTicks = 5
+
+// TickStats - статистика шагов симуляции
+type TickStats struct {
+	TicksPerSecond float64 // Шагов за последнюю секунду реального времени
+	DroppedTicks   int     // Всего шагов, отброшенных после долгих заминок
+}
+
+// FixedStep делит время кадров на шаги симуляции постоянной длины. Симуляция
+// идет с одной скоростью при любой частоте кадров, а отрисовка сглаживает
+// движение между шагами с долей Alpha.
+type FixedStep struct {
+	step        float64 // Длина шага (секунды)
+	accumulator float64 // Время, еще не отданное шагам
+
+	windowTime  float64 // Реальное время текущего окна статистики
+	windowTicks int
+	stats       TickStats
+}
+
+// NewFixedStep создает шаги симуляции с частотой tickRate (шагов в секунду);
+// неположительная частота заменяется DefaultTickRate
+func NewFixedStep(tickRate int) *FixedStep {
+	if tickRate <= 0 {
+		tickRate = DefaultTickRate
+	}
+	return &FixedStep{step: 1.0 / float64(tickRate)}
+}
+
+// Step возвращает длину шага симуляции в секундах
+func (fs *FixedStep) Step() float64 {
+	return fs.step
+}
+
+// Advance добавляет время кадра и возвращает, сколько шагов симуляции нужно
+// выполнить. Время сверх MaxCatchUpTicks шагов отбрасывается.
+func (fs *FixedStep) Advance(frameTime float64) int {
+	frameTime = math.Max(0, frameTime)
+	fs.accumulator += frameTime
+
+	ticks := int(fs.accumulator / fs.step)
+	if ticks > MaxCatchUpTicks {
+		fs.stats.DroppedTicks += ticks - MaxCatchUpTicks
+		ticks = MaxCatchUpTicks
+		// Оставляем только долю шага, чтобы сглаживание не прыгало
+		fs.accumulator = math.Mod(fs.accumulator, fs.step) + float64(ticks)*fs.step
+	}
+	fs.accumulator -= float64(ticks) * fs.step
+
+	// Частота шагов считается по окнам в секунду реального времени
+	fs.windowTime += frameTime
+	fs.windowTicks += ticks
+	if fs.windowTime >= 1.0 {
+		fs.stats.TicksPerSecond = float64(fs.windowTicks) / fs.windowTime
+		fs.windowTime, fs.windowTicks = 0, 0
+	}
+
+	return ticks
+}
+
+// Alpha возвращает, какую долю следующего шага уже прошло реальное время (0-1).
+// Отрисовка сглаживает положения между предыдущим и текущим шагом с этой долей.
+func (fs *FixedStep) Alpha() float64 {
+	return math.Min(1.0, fs.accumulator/fs.step)
+}
+
+// Stats возвращает статистику шагов симуляции
+func (fs *FixedStep) Stats() TickStats {
+	return fs.stats
+}
@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFixedStepRunsWholeSteps(t *testing.T) {
+	fs := NewFixedStep(8)
+
+	if ticks := fs.Advance(0.3125); ticks != 2 {
+		t.Fatalf("Advance(0.3125) at 8 ticks/s = %d ticks, want 2", ticks)
+	}
+	if alpha := fs.Alpha(); math.Abs(alpha-0.5) > 1e-9 {
+		t.Fatalf("Alpha() = %v, want 0.5", alpha)
+	}
+
+	// Остаток накапливается между кадрами
+	if ticks := fs.Advance(0.0625); ticks != 1 {
+		t.Fatalf("Advance(0.0625) after a half step = %d ticks, want 1", ticks)
+	}
+	if alpha := fs.Alpha(); alpha > 1e-9 {
+		t.Fatalf("Alpha() = %v, want 0", alpha)
+	}
+}
+
+func TestFixedStepDropsTicksAfterStall(t *testing.T) {
+	fs := NewFixedStep(8)
+
+	ticks := fs.Advance(2.0625)
+	if ticks != MaxCatchUpTicks {
+		t.Fatalf("Advance(2.0625) = %d ticks, want MaxCatchUpTicks (%d)", ticks, MaxCatchUpTicks)
+	}
+	if dropped := fs.Stats().DroppedTicks; dropped != 16-MaxCatchUpTicks {
+		t.Fatalf("DroppedTicks = %d, want %d", dropped, 16-MaxCatchUpTicks)
+	}
+	// Доля шага сохраняется, чтобы сглаживание не прыгало
+	if alpha := fs.Alpha(); math.Abs(alpha-0.5) > 1e-9 {
+		t.Fatalf("Alpha() after stall = %v, want 0.5", alpha)
+	}
+}
+
+func TestFixedStepTicksPerSecond(t *testing.T) {
+	fs := NewFixedStep(30)
+
+	// Один шаг с запасом на погрешность, чтобы остаток не копился
+	frame := 1.0/30 + 1e-9
+	for i := 0; i < 30; i++ {
+		fs.Advance(frame)
+	}
+	if tps := fs.Stats().TicksPerSecond; math.Abs(tps-30) > 0.01 {
+		t.Fatalf("TicksPerSecond = %v, want 30", tps)
+	}
+}
+
+func TestFixedStepDefaultRate(t *testing.T) {
+	for _, rate := range []int{0, -5} {
+		if step := NewFixedStep(rate).Step(); step != 1.0/DefaultTickRate {
+			t.Errorf("NewFixedStep(%d).Step() = %v, want %v", rate, step, 1.0/DefaultTickRate)
+		}
+	}
+}
+
+func TestFixedStepIgnoresNegativeFrameTime(t *testing.T) {
+	fs := NewFixedStep(10)
+	if ticks := fs.Advance(-1); ticks != 0 || fs.Alpha() != 0 {
+		t.Fatalf("Advance(-1) = %d ticks, Alpha %v; want 0 and 0", ticks, fs.Alpha())
+	}
+}
@@ -0,0 +1,81 @@
+// Package framebudget сверяет бенчмарки покадрового обновления с бюджетами
+// времени из budgets.json в корне модуля. Бенчмарки и проверки собираются
+// только с тегом dev: они заполняют системы через dev-хелперы PopulateSynthetic.
+//
+//	go test -tags dev ./...              проверка бюджетов
+//	go test -tags dev -short ./...       без проверки бюджетов
+//	go test -tags dev -bench . ./...     сами бенчмарки
+package framebudget
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// File - имя файла бюджетов в корне модуля
+const File = "budgets.json"
+
+// Load читает бюджеты: имя бенчмарка -> допустимое время одной операции в
+// наносекундах. Файл ищется в корне модуля (каталоге с go.mod), начиная с
+// текущего каталога и поднимаясь вверх.
+func Load() (map[string]float64, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, fmt.Errorf("%s: module root not found", File)
+		}
+		dir = parent
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, File))
+	if err != nil {
+		return nil, err
+	}
+
+	budgets := make(map[string]float64)
+	if err := json.Unmarshal(data, &budgets); err != nil {
+		return nil, fmt.Errorf("%s: %v", File, err)
+	}
+	return budgets, nil
+}
+
+// Check запускает бенчмарк и проваливает тест, если одна операция заняла
+// больше бюджета с именем name. С флагом -short проверка пропускается.
+func Check(t *testing.T, name string, benchmark func(*testing.B)) {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("frame budgets are not checked in -short mode")
+	}
+
+	budgets, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load frame budgets: %v", err)
+	}
+	budget, exists := budgets[name]
+	if !exists {
+		t.Fatalf("%s has no budget for %s", File, name)
+	}
+
+	result := testing.Benchmark(benchmark)
+	if result.N == 0 {
+		t.Fatalf("benchmark %s did not run", name)
+	}
+
+	perOp := time.Duration(result.NsPerOp())
+	if float64(perOp.Nanoseconds()) > budget {
+		t.Errorf("%s takes %v per frame, budget is %v", name, perOp, time.Duration(budget))
+	}
+}
@@ -0,0 +1,165 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// TagNoise - тег сущностей-шумов, которые слышат существа
+const TagNoise = "noise"
+
+// Параметры слуха существ
+const (
+	NoiseHearingScale     = 3.0 // Самый громкий шум слышен на столько радиусов обнаружения
+	NoiseAwarenessDecay   = 0.2 // Осведомленность об игроке, теряемая за секунду проверки шума
+	NoiseInvestigateReach = 1.0 // На таком расстоянии от шума существо считает его проверенным
+)
+
+// NoiseMaker - предмет, который игрок бросает, чтобы отвлечь существ
+type NoiseMaker struct {
+	ID         string
+	Name       string
+	Loudness   float64 // Громкость шума (0-1)
+	Duration   float64 // Сколько секунд шум слышен
+	ThrowRange float64 // Как далеко предмет летит
+	Crafted    bool    // Предмет нужно смастерить, а не подобрать
+}
+
+// DefaultNoiseMakers возвращает бросаемые предметы: короткий стук камня и
+// долгий звон костяной погремушки
+func DefaultNoiseMakers() map[string]NoiseMaker {
+	return map[string]NoiseMaker{
+		"stone": {
+			ID:         "stone",
+			Name:       "Камень",
+			Loudness:   0.5,
+			Duration:   4.0,
+			ThrowRange: 15.0,
+		},
+		"bone_chime": {
+			ID:         "bone_chime",
+			Name:       "Костяная погремушка",
+			Loudness:   0.8,
+			Duration:   12.0,
+			ThrowRange: 10.0,
+			Crafted:    true,
+		},
+	}
+}
+
+func init() {
+	ecs.RegisterTag(TagNoise, "state", "Шум, который слышат существа")
+	ecs.DeclareTagQuery(TagNoise)
+}
+
+// CreateNoiseEvent создает в мире шум, который слышат существа, пока он не стихнет
+func CreateNoiseEvent(world *ecs.World, position ecs.Vector3, loudness, duration float64, source string) *ecs.Entity {
+	noise := ecs.NewEntity()
+	noise.AddComponent(ecs.NewTransformComponent(position))
+	noise.AddComponent(ecs.NewNoiseComponent(loudness, duration, source))
+	noise.AddTag(TagNoise)
+
+	world.AddEntity(noise)
+	return noise
+}
+
+// ThrowNoiseMaker бросает предмет из точки from в направлении direction и
+// создает шум там, где он упал
+func ThrowNoiseMaker(world *ecs.World, maker NoiseMaker, from, direction ecs.Vector3) (*ecs.Entity, error) {
+	direction.Y = 0
+	if direction.Magnitude() == 0 {
+		return nil, fmt.Errorf("no direction to throw %s", maker.ID)
+	}
+
+	landing := from.Add(direction.Normalize().Multiply(maker.ThrowRange))
+	return CreateNoiseEvent(world, landing, maker.Loudness, maker.Duration, maker.ID), nil
+}
+
+// updateNoiseEvents отсчитывает время шумов и убирает стихшие
+func (as *AISystem) updateNoiseEvents(deltaTime float64) {
+	for _, entity := range as.world.GetEntitiesWithTag(TagNoise) {
+		noiseComp, has := entity.GetComponent(ecs.NoiseComponentID)
+		if !has {
+			continue
+		}
+
+		noise := noiseComp.(*ecs.NoiseComponent)
+		noise.Remaining -= deltaTime
+		if noise.Remaining <= 0 {
+			as.world.RemoveEntity(entity.ID)
+		}
+	}
+}
+
+// loudestNoiseHeard возвращает шум, который существо слышит громче всего, с учетом расстояния
+func (as *AISystem) loudestNoiseHeard(ai *ecs.AIComponent, transform *ecs.TransformComponent) *ecs.Entity {
+	var loudest *ecs.Entity
+	bestLevel := 0.0
+
+	for _, entity := range as.world.GetEntitiesWithTag(TagNoise) {
+		noiseComp, hasNoise := entity.GetComponent(ecs.NoiseComponentID)
+		noiseTransform, hasTransform := entity.GetComponent(ecs.TransformComponentID)
+		if !hasNoise || !hasTransform {
+			continue
+		}
+
+		noise := noiseComp.(*ecs.NoiseComponent)
+		hearing := ai.DetectionRange * NoiseHearingScale * noise.Loudness
+		distance := noiseTransform.(*ecs.TransformComponent).Position.Distance(transform.Position)
+		if hearing <= 0 || distance > hearing {
+			continue
+		}
+
+		// Громкость, которая доходит до существа
+		if level := noise.Loudness * (1 - distance/hearing); level > bestLevel {
+			bestLevel = level
+			loudest = entity
+		}
+	}
+
+	return loudest
+}
+
+// listenForNoise уводит существо проверять услышанный шум. Преследующее игрока
+// существо отвлекается, только если шум громче его осведомленности об игроке.
+func (as *AISystem) listenForNoise(ai *ecs.AIComponent, transform *ecs.TransformComponent) bool {
+	noise := as.loudestNoiseHeard(ai, transform)
+	if noise == nil || noise.ID == ai.TargetID {
+		return false
+	}
+
+	noiseComp, _ := noise.GetComponent(ecs.NoiseComponentID)
+	if ai.CurrentState == "chase" && noiseComp.(*ecs.NoiseComponent).Loudness <= ai.AwarenessLevel {
+		return false
+	}
+
+	noiseTransform, _ := noise.GetComponent(ecs.TransformComponentID)
+	ai.TargetID = noise.ID
+	ai.LastKnownTargetPos = noiseTransform.(*ecs.TransformComponent).Position
+	ai.SetState("investigate")
+	return true
+}
+
+// handleInvestigateState ведет существо к шуму. Пока оно проверяет шум,
+// осведомленность об игроке угасает; проверив шум или не дождавшись его,
+// существо возвращается к патрулированию.
+func (as *AISystem) handleInvestigateState(ai *ecs.AIComponent, transform *ecs.TransformComponent, deltaTime float64) {
+	ai.AwarenessLevel = math.Max(0, ai.AwarenessLevel-NoiseAwarenessDecay*deltaTime)
+
+	// Более громкий шум перебивает тот, к которому существо идет
+	as.listenForNoise(ai, transform)
+
+	_, noiseExists := as.world.GetEntity(ai.TargetID)
+	if !noiseExists || transform.Position.Distance(ai.LastKnownTargetPos) <= NoiseInvestigateReach {
+		ai.TargetID = ""
+		ai.SetState("patrol")
+		return
+	}
+
+	direction := ai.LastKnownTargetPos.Sub(transform.Position).Normalize()
+	transform.Position = transform.Position.Add(
+		direction.Multiply(ai.DetectionRange * deltaTime),
+	)
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newListener создает существо с радиусом обнаружения 10 в начале координат
+func newListener(state string, awareness float64) (*ecs.AIComponent, *ecs.TransformComponent) {
+	ai := ecs.NewAIComponent("wolf", 10)
+	ai.SetState(state)
+	ai.AwarenessLevel = awareness
+	return ai, ecs.NewTransformComponent(ecs.Vector3{})
+}
+
+func TestThrowNoiseMakerLandsAtThrowRange(t *testing.T) {
+	world := ecs.NewWorld()
+	stone := DefaultNoiseMakers()["stone"]
+
+	// Наклон броска не влияет на дальность по земле
+	noise, err := ThrowNoiseMaker(world, stone, ecs.Vector3{X: 1, Y: 2, Z: 1}, ecs.Vector3{X: 3, Y: 5, Z: 4})
+	if err != nil {
+		t.Fatalf("ThrowNoiseMaker: %v", err)
+	}
+	transform, _ := ecs.ComponentAs[*ecs.TransformComponent](noise, ecs.TransformComponentID)
+	want := ecs.Vector3{X: 1 + 0.6*stone.ThrowRange, Y: 2, Z: 1 + 0.8*stone.ThrowRange}
+	if transform.Position.Distance(want) > 1e-9 {
+		t.Errorf("noise landed at %v, want %v", transform.Position, want)
+	}
+	if !noise.HasTag(TagNoise) {
+		t.Errorf("thrown noise has no %q tag", TagNoise)
+	}
+
+	if _, err := ThrowNoiseMaker(world, stone, ecs.Vector3{}, ecs.Vector3{Y: 1}); err == nil {
+		t.Errorf("threw a stone straight up without an error")
+	}
+}
+
+func TestNoiseFadesAfterItsDuration(t *testing.T) {
+	world := ecs.NewWorld()
+	as := &AISystem{world: world}
+	noise := CreateNoiseEvent(world, ecs.Vector3{}, 0.5, 2.0, "stone")
+
+	as.updateNoiseEvents(1.5)
+	if _, exists := world.GetEntity(noise.ID); !exists {
+		t.Fatalf("noise faded before its duration")
+	}
+	as.updateNoiseEvents(1.0)
+	if _, exists := world.GetEntity(noise.ID); exists {
+		t.Errorf("noise is still heard after its duration")
+	}
+}
+
+func TestListenForNoiseFollowsLoudestHeard(t *testing.T) {
+	world := ecs.NewWorld()
+	as := &AISystem{world: world}
+	CreateNoiseEvent(world, ecs.Vector3{X: 5}, 0.3, 10, "stone")
+	chime := CreateNoiseEvent(world, ecs.Vector3{X: -8}, 0.9, 10, "bone_chime")
+	CreateNoiseEvent(world, ecs.Vector3{X: 100}, 1.0, 10, "bone_chime") // Too far to hear
+
+	ai, transform := newListener("patrol", 0)
+	if !as.listenForNoise(ai, transform) {
+		t.Fatalf("patrolling creature ignored the noises around it")
+	}
+	if ai.TargetID != chime.ID || ai.CurrentState != "investigate" {
+		t.Errorf("creature went for %s in state %q, want %s in investigate", ai.TargetID, ai.CurrentState, chime.ID)
+	}
+}
+
+func TestChasingCreatureIgnoresQuietNoise(t *testing.T) {
+	world := ecs.NewWorld()
+	as := &AISystem{world: world}
+	CreateNoiseEvent(world, ecs.Vector3{X: 3}, 0.5, 10, "stone")
+
+	ai, transform := newListener("chase", 0.7)
+	if as.listenForNoise(ai, transform) {
+		t.Errorf("chasing creature with awareness 0.7 was lured by a noise of loudness 0.5")
+	}
+
+	ai.AwarenessLevel = 0.4
+	if !as.listenForNoise(ai, transform) {
+		t.Errorf("chasing creature with awareness 0.4 ignored a noise of loudness 0.5")
+	}
+}
+
+func TestInvestigationEndsAtTheNoise(t *testing.T) {
+	world := ecs.NewWorld()
+	as := &AISystem{world: world}
+	CreateNoiseEvent(world, ecs.Vector3{X: 5}, 0.5, 10, "stone")
+
+	ai, transform := newListener("patrol", 0.5)
+	as.listenForNoise(ai, transform)
+
+	as.handleInvestigateState(ai, transform, 0.1)
+	if ai.CurrentState != "investigate" || transform.Position.X <= 0 {
+		t.Fatalf("creature in state %q at %v, want it walking to the noise", ai.CurrentState, transform.Position)
+	}
+	if want := 0.5 - NoiseAwarenessDecay*0.1; math.Abs(ai.AwarenessLevel-want) > 1e-9 {
+		t.Errorf("awareness = %v while investigating, want %v", ai.AwarenessLevel, want)
+	}
+
+	for i := 0; i < 20 && ai.CurrentState == "investigate"; i++ {
+		as.handleInvestigateState(ai, transform, 0.1)
+	}
+	if ai.CurrentState != "patrol" || ai.TargetID != "" {
+		t.Errorf("creature in state %q with target %q after reaching the noise, want patrol", ai.CurrentState, ai.TargetID)
+	}
+}
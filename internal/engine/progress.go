@@ -0,0 +1,47 @@
+package engine
+
+// ProgressReporter получает сведения о ходе длительной инициализации подсистемы.
+// fraction - общий прогресс подсистемы (0-1), phase - имя текущего этапа.
+type ProgressReporter interface {
+	ReportProgress(phase string, fraction float64)
+}
+
+// NopProgress игнорирует сообщения о прогрессе
+type NopProgress struct{}
+
+// ReportProgress реализует ProgressReporter
+func (NopProgress) ReportProgress(phase string, fraction float64) {}
+
+// subProgress отображает прогресс этапа на отрезок прогресса родителя
+type subProgress struct {
+	parent   ProgressReporter
+	from, to float64
+}
+
+// SubProgress возвращает репортер, который переводит прогресс этапа (0-1)
+// в отрезок [from, to] прогресса родительского репортера
+func SubProgress(parent ProgressReporter, from, to float64) ProgressReporter {
+	if parent == nil {
+		return NopProgress{}
+	}
+	return &subProgress{parent: parent, from: from, to: to}
+}
+
+// ReportProgress реализует ProgressReporter
+func (sp *subProgress) ReportProgress(phase string, fraction float64) {
+	if fraction < 0 {
+		fraction = 0
+	} else if fraction > 1 {
+		fraction = 1
+	}
+	sp.parent.ReportProgress(phase, sp.from+(sp.to-sp.from)*fraction)
+}
+
+// ReportCount сообщает о прогрессе по количеству обработанных элементов
+func ReportCount(reporter ProgressReporter, phase string, done, total int) {
+	if total <= 0 {
+		reporter.ReportProgress(phase, 1)
+		return
+	}
+	reporter.ReportProgress(phase, float64(done)/float64(total))
+}
@@ -0,0 +1,17 @@
+package engine
+
+import (
+	"hash/fnv"
+	"math/rand"
+)
+
+// NewRandStream создает генератор случайных чисел подсистемы, производный от
+// сида мира. У каждой подсистемы свой поток: они не делят глобальный генератор
+// math/rand и его блокировку, а поведение одной подсистемы воспроизводится
+// независимо от того, сколько случайных чисел взяли другие.
+// Генератор не потокобезопасен: подсистема обращается к нему под своим мьютексом.
+func NewRandStream(seed int64, stream string) *rand.Rand {
+	hash := fnv.New64a()
+	hash.Write([]byte(stream))
+	return rand.New(rand.NewSource(seed ^ int64(hash.Sum64())))
+}
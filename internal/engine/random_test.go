@@ -0,0 +1,43 @@
+package engine
+
+import "testing"
+
+func TestRandStreamIsReproducible(t *testing.T) {
+	a := NewRandStream(42, "world")
+	b := NewRandStream(42, "world")
+	for i := 0; i < 100; i++ {
+		if x, y := a.Float64(), b.Float64(); x != y {
+			t.Fatalf("draw %d: %v and %v from the same seed and stream", i, x, y)
+		}
+	}
+}
+
+func TestRandStreamsAreIndependent(t *testing.T) {
+	reference := NewRandStream(42, "symbols")
+	want := make([]float64, 10)
+	for i := range want {
+		want[i] = reference.Float64()
+	}
+
+	// Сколько бы чисел ни взял соседний поток, свой поток не сдвигается
+	other := NewRandStream(42, "metamorphosis")
+	symbols := NewRandStream(42, "symbols")
+	for i := range want {
+		for j := 0; j < i; j++ {
+			other.Float64()
+		}
+		if got := symbols.Float64(); got != want[i] {
+			t.Fatalf("draw %d = %v after draws from another stream, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestRandStreamsDifferBySeedAndName(t *testing.T) {
+	base := NewRandStream(42, "fear").Int63()
+	if NewRandStream(43, "fear").Int63() == base {
+		t.Errorf("seeds 42 and 43 start the fear stream with the same number")
+	}
+	if NewRandStream(42, "world").Int63() == base {
+		t.Errorf("streams fear and world of seed 42 start with the same number")
+	}
+}
@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+)
+
+// TemplateError описывает ошибку в конкретном файле шаблона
+type TemplateError struct {
+	File    string // Путь к файлу шаблона
+	Field   string // Поле с ошибкой (пусто, если ошибка в разборе файла)
+	Message string // Описание ошибки
+}
+
+// Error реализует интерфейс error
+func (e TemplateError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %s", e.File, e.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.File, e.Field, e.Message)
+}
+
+// LoadReport собирает результаты загрузки шаблонов с диска
+type LoadReport struct {
+	Loaded int             // Количество успешно загруженных шаблонов
+	Errors []TemplateError // Ошибки по файлам
+}
+
+// HasErrors возвращает true, если при загрузке были ошибки
+func (r *LoadReport) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// ErrorsForFile возвращает ошибки указанного файла
+func (r *LoadReport) ErrorsForFile(file string) []TemplateError {
+	result := make([]TemplateError, 0)
+	for _, err := range r.Errors {
+		if err.File == file {
+			result = append(result, err)
+		}
+	}
+	return result
+}
+
+// AddError добавляет ошибку в отчет и пишет ее в лог
+func (r *LoadReport) AddError(err TemplateError) {
+	r.Errors = append(r.Errors, err)
+	log.Printf("Template error: %s", err.Error())
+}
+
+// AddValidationErrors добавляет ошибки проверки файла; возвращает true, если они были
+func (r *LoadReport) AddValidationErrors(file string, errors []TemplateError) bool {
+	for _, err := range errors {
+		err.File = file
+		r.AddError(err)
+	}
+	return len(errors) > 0
+}
+
+// Merge добавляет в отчет результаты другого отчета
+func (r *LoadReport) Merge(other *LoadReport) {
+	r.Loaded += other.Loaded
+	r.Errors = append(r.Errors, other.Errors...)
+}
+
+// Clone возвращает независимую копию отчета
+func (r *LoadReport) Clone() *LoadReport {
+	clone := &LoadReport{Errors: make([]TemplateError, 0, len(r.Errors))}
+	clone.Merge(r)
+	return clone
+}
@@ -1,14 +1,20 @@
 package player
 
 import (
-	"time"
+	"errors"
+	"fmt"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
+	"echo-taiga/internal/engine"
 	"echo-taiga/internal/engine/ecs"
 	"echo-taiga/internal/world"
 )
 
+// ErrCannotThrow - у игрока нет предмета, который можно бросить
+var ErrCannotThrow = errors.New("cannot be thrown")
+
 // Player представляет игрока в игре
 type Player struct {
 	entity    *ecs.Entity
@@ -16,7 +22,25 @@ type Player struct {
 	physics   *ecs.PhysicsComponent
 	control   *ecs.PlayerControlComponent
 	survival  *ecs.SurvivalComponent
+	health    *ecs.HealthComponent
 	inventory *ecs.InventoryComponent
+
+	world  *ecs.World
+	clock  engine.Clock // Часы, по которым отмечается время взаимодействия
+	facing ecs.Vector3  // Направление последнего движения, в нем летят брошенные предметы
+
+	// Предметы, которые можно бросить, чтобы отвлечь существ
+	NoiseMakers map[string]engine.NoiseMaker
+
+	// Вызывается, когда игрок нарочно шумит, чтобы отвлечь существ
+	OnDiversion func(position ecs.Vector3, maker engine.NoiseMaker)
+}
+
+// spawnPosition - точка, где игрок появляется и возрождается после смерти
+var spawnPosition = ecs.Vector3{
+	X: 0,
+	Y: 1,
+	Z: 0,
 }
 
 // CreatePlayerEntity создает сущность игрока в мире
@@ -25,11 +49,7 @@ func CreatePlayerEntity(world *ecs.World, gameWorld *world.World) (*Player, erro
 	playerEntity := ecs.NewEntity()
 
 	// Позиция спавна
-	startPosition := ecs.Vector3{
-		X: 0,
-		Y: 1,
-		Z: 0,
-	}
+	startPosition := spawnPosition
 
 	// Компонент трансформации
 	transformComp := ecs.NewTransformComponent(startPosition)
@@ -49,13 +69,17 @@ func CreatePlayerEntity(world *ecs.World, gameWorld *world.World) (*Player, erro
 	survivalComp := ecs.NewSurvivalComponent()
 	playerEntity.AddComponent(survivalComp)
 
+	// Компонент здоровья
+	healthComp := ecs.NewHealthComponent(100)
+	playerEntity.AddComponent(healthComp)
+
 	// Компонент инвентаря
 	inventoryComp := ecs.NewInventoryComponent(24, 50.0)
 	playerEntity.AddComponent(inventoryComp)
 
 	// Добавляем теги
-	playerEntity.AddTag("player")
-	playerEntity.AddTag("living")
+	playerEntity.AddTag(TagPlayer)
+	playerEntity.AddTag(TagLiving)
 
 	// Добавляем сущность в мир
 	world.AddEntity(playerEntity)
@@ -70,12 +94,23 @@ func CreatePlayerEntity(world *ecs.World, gameWorld *world.World) (*Player, erro
 		physics:   physicsComp,
 		control:   controlComp,
 		survival:  survivalComp,
+		health:    healthComp,
 		inventory: inventoryComp,
+
+		world:       world,
+		clock:       engine.RealClock{},
+		facing:      ecs.Vector3{Z: -1},
+		NoiseMakers: engine.DefaultNoiseMakers(),
 	}
 
 	return player, nil
 }
 
+// SetClock задает часы, по которым отмечается время взаимодействия игрока
+func (p *Player) SetClock(clock engine.Clock) {
+	p.clock = clock
+}
+
 // Update обновляет состояние игрока
 func (p *Player) Update(deltaTime float64, world *world.World) {
 	// Обработка ввода
@@ -131,6 +166,10 @@ func (p *Player) handleInput(deltaTime float64) {
 		moveZ *= 0.7071
 	}
 
+	if moveX != 0 || moveZ != 0 {
+		p.facing = ecs.Vector3{X: moveX, Z: moveZ}
+	}
+
 	// Применяем движение с учетом направления камеры
 	moveVector := ecs.Vector3{
 		X: moveX * speed * deltaTime,
@@ -150,10 +189,36 @@ func (p *Player) handleInput(deltaTime float64) {
 	// Обработка взаимодействия
 	if ebiten.IsKeyPressed(ebiten.KeyE) {
 		p.control.IsInteracting = true
-		p.control.LastInteractTime = float64(time.Now().UnixNano()) / 1e9
+		p.control.LastInteractTime = float64(p.clock.Now().UnixNano()) / 1e9
 	} else {
 		p.control.IsInteracting = false
 	}
+
+	// Брошенный камень уводит существ от игрока. Без камня клавиша ничего не делает,
+	// а направление броска у игрока есть всегда.
+	if inpututil.IsKeyJustPressed(ebiten.KeyG) {
+		p.ThrowNoiseMaker("stone")
+	}
+}
+
+// ThrowNoiseMaker бросает предмет в направлении движения игрока. Шум там, где
+// он упал, уводит существ проверять его.
+func (p *Player) ThrowNoiseMaker(makerID string) (*ecs.Entity, error) {
+	maker, exists := p.NoiseMakers[makerID]
+	if !exists {
+		return nil, fmt.Errorf("%s %w", makerID, ErrCannotThrow)
+	}
+
+	noise, err := engine.ThrowNoiseMaker(p.world, maker, p.transform.Position, p.facing)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.OnDiversion != nil {
+		noiseTransform, _ := noise.GetComponent(ecs.TransformComponentID)
+		p.OnDiversion(noiseTransform.(*ecs.TransformComponent).Position, maker)
+	}
+	return noise, nil
 }
 
 // updatePhysics обновляет физику игрока
@@ -186,3 +251,23 @@ func (p *Player) GetPosition() ecs.Vector3 {
 func (p *Player) GetEntity() *ecs.Entity {
 	return p.entity
 }
+
+// IsDead проверяет, погиб ли игрок
+func (p *Player) IsDead() bool {
+	return p.health.IsDead()
+}
+
+// Respawn возрождает игрока в точке спавна с полным здоровьем.
+// Голод, жажда и усталость сбрасываются, рассудок - нет: тайга помнит пережитое.
+func (p *Player) Respawn(gameWorld *world.World) {
+	p.health.CurrentHealth = p.health.MaxHealth
+	p.survival.Hunger = 0
+	p.survival.Thirst = 0
+	p.survival.Fatigue = 0
+
+	p.transform.Position = spawnPosition
+	p.transform.PreviousPosition = spawnPosition
+	p.physics.Velocity = ecs.Vector3{}
+
+	gameWorld.SetPlayerPosition(spawnPosition)
+}
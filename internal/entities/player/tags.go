@@ -0,0 +1,9 @@
+package player
+
+import "echo-taiga/internal/entities"
+
+// Теги сущности игрока (регистрируются пакетом entities)
+const (
+	TagPlayer = entities.TagPlayer
+	TagLiving = entities.TagLiving
+)
@@ -0,0 +1,17 @@
+// Package entities содержит общие для всех видов сущностей теги. Они вынесены
+// из пакета игрока, которому нужен ebiten, чтобы реестр тегов можно было
+// проверить без графического окружения.
+package entities
+
+import "echo-taiga/internal/engine/ecs"
+
+// Теги сущности игрока
+const (
+	TagPlayer = "player"
+	TagLiving = "living"
+)
+
+func init() {
+	ecs.RegisterTag(TagPlayer, "actor", "Сущность игрока")
+	ecs.RegisterTag(TagLiving, "fauna", "Живое существо")
+}
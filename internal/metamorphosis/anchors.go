@@ -0,0 +1,151 @@
+package metamorphosis
+
+import (
+	"fmt"
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Параметры якорей стабильности
+const (
+	AnchorStabilityBoost = 0.5  // Прибавка к стабильности в центре заряженного якоря
+	AnchorDrainRate      = 0.1  // Расход заряда в секунду на единицу подавленной интенсивности
+	AnchorBlockCost      = 10.0 // Расход заряда на единицу интенсивности заблокированного эффекта
+)
+
+// anchorSite - заряженный якорь стабильности и его позиция
+type anchorSite struct {
+	entity   *ecs.Entity
+	position ecs.Vector3
+	anchor   *ecs.AnchorComponent
+}
+
+// chargedAnchors возвращает действующие якоря стабильности
+func (mm *MetamorphosisManager) chargedAnchors() []anchorSite {
+	sites := make([]anchorSite, 0)
+	for _, entity := range mm.world.GetEntitiesWithComponent(ecs.AnchorComponentID) {
+		anchor, has := ecs.ComponentAs[*ecs.AnchorComponent](entity, ecs.AnchorComponentID)
+		if !has || !anchor.IsCharged() {
+			continue
+		}
+		transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
+		if !has {
+			continue
+		}
+		sites = append(sites, anchorSite{entity: entity, position: transform.Position, anchor: anchor})
+	}
+	return sites
+}
+
+// AnchorField - снимок заряженных якорей для расчета подавления во многих точках
+// без повторного обхода мира и блокировки менеджера (см. AnchorSuppression)
+type AnchorField struct {
+	sites []anchorSite
+}
+
+// At возвращает степень подавления метаморфоз якорями в точке:
+// 1 в центре заряженного якоря, линейно убывая до 0 на краю его радиуса
+func (af AnchorField) At(position ecs.Vector3) float64 {
+	return anchorSuppression(af.sites, position)
+}
+
+// AnchorSuppression возвращает снимок действующих якорей стабильности.
+// Радиусы копируются, поэтому снимок не меняется вместе с якорями.
+func (mm *MetamorphosisManager) AnchorSuppression() AnchorField {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	sites := mm.chargedAnchors()
+	for i := range sites {
+		anchor := *sites[i].anchor
+		sites[i].anchor = &anchor
+	}
+	return AnchorField{sites: sites}
+}
+
+// anchorSuppression возвращает наибольшее подавление среди якорей в точке
+func anchorSuppression(sites []anchorSite, position ecs.Vector3) float64 {
+	suppression := 0.0
+	for _, site := range sites {
+		if site.anchor.Radius <= 0 {
+			continue
+		}
+		distance := math.Hypot(position.X-site.position.X, position.Z-site.position.Z)
+		suppression = math.Max(suppression, 1.0-distance/site.anchor.Radius)
+	}
+	return suppression
+}
+
+// canMutate проверяет, может ли сущность мутировать под действием эффекта
+// с учетом якорей стабильности рядом с ней
+func (mm *MetamorphosisManager) canMutate(entity *ecs.Entity, metamorphic *ecs.MetamorphicComponent, effect *MetamorphEffect) bool {
+	return mm.rng.Float64() < metamorphic.MutationChance(effect.Intensity, mm.anchorBonus(entity))
+}
+
+// anchorBonus возвращает прибавку к стабильности сущности от ближайших якорей
+func (mm *MetamorphosisManager) anchorBonus(entity *ecs.Entity) float64 {
+	transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
+	if !has {
+		return 0
+	}
+	return AnchorStabilityBoost * anchorSuppression(mm.chargedAnchors(), transform.Position)
+}
+
+// blockingAnchor возвращает якорь, в радиусе которого находится центр эффекта
+// достаточно высокого порядка, или nil. Эффекты без области якоря не блокируют.
+func (mm *MetamorphosisManager) blockingAnchor(effect *MetamorphEffect) *anchorSite {
+	if effect.AffectedArea == nil {
+		return nil
+	}
+	center := effect.AffectedArea.Center
+
+	for _, site := range mm.chargedAnchors() {
+		if int(effect.Order) < site.anchor.Strength {
+			continue
+		}
+		if math.Hypot(center.X-site.position.X, center.Z-site.position.Z) <= site.anchor.Radius {
+			site := site
+			return &site
+		}
+	}
+	return nil
+}
+
+// blockEffect отклоняет эффект, центр которого оказался у якоря. Блокировка
+// расходует заряд якоря. Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) blockEffect(effect *MetamorphEffect, site *anchorSite) {
+	mm.recordHistoryEntry(effect.ID, "blocked", site.entity.ID, fmt.Sprintf("Stability anchor %s suppressed %s", site.entity.ID, effect.Name))
+	mm.drainAnchor(site, effect.Intensity*AnchorBlockCost)
+}
+
+// drainAnchors расходует заряд якорей пропорционально интенсивности эффектов,
+// которые они сдерживают. Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) drainAnchors(deltaTime float64) {
+	for _, site := range mm.chargedAnchors() {
+		suppressed := 0.0
+		for _, effect := range mm.activeEffects {
+			suppressed += effect.EffectiveIntensityFor(site.position)
+		}
+		if suppressed > 0 {
+			site := site
+			mm.drainAnchor(&site, suppressed*AnchorDrainRate*deltaTime)
+		}
+	}
+}
+
+// drainAnchor расходует заряд якоря и отмечает его растрескивание
+func (mm *MetamorphosisManager) drainAnchor(site *anchorSite, amount float64) {
+	if !site.anchor.Drain(amount) {
+		return
+	}
+
+	if render, has := ecs.ComponentAs[*ecs.RenderComponent](site.entity, ecs.RenderComponentID); has {
+		render.Effects.AddEffect("cracked", 1.0, ecs.EffectSourceBase)
+	}
+
+	mm.recordHistoryEntry("", "anchor_cracked", site.entity.ID, fmt.Sprintf("Stability anchor %s cracked", site.entity.ID))
+	if mm.OnAnchorCracked != nil {
+		mm.OnAnchorCracked(site.entity.ID, site.position)
+	}
+}
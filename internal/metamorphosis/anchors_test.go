@@ -0,0 +1,170 @@
+package metamorphosis
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+func TestAnchorSuppressionSnapshot(t *testing.T) {
+	mm, world := newTestManager(t)
+
+	anchorEntity := ecs.NewEntity()
+	anchorEntity.AddComponent(ecs.NewTransformComponent(ecs.Vector3{X: 10, Z: 10}))
+	anchor := ecs.NewAnchorComponent(8, 2, 100)
+	anchorEntity.AddComponent(anchor)
+	world.AddEntity(anchorEntity)
+
+	// Разряженный якорь ничего не подавляет
+	cracked := ecs.NewEntity()
+	cracked.AddComponent(ecs.NewTransformComponent(ecs.Vector3{X: -20}))
+	crackedAnchor := ecs.NewAnchorComponent(8, 2, 100)
+	crackedAnchor.Charge = 0
+	cracked.AddComponent(crackedAnchor)
+	world.AddEntity(cracked)
+
+	field := mm.AnchorSuppression()
+	tests := []struct {
+		position ecs.Vector3
+		want     float64
+	}{
+		{ecs.Vector3{X: 10, Z: 10}, 1},
+		{ecs.Vector3{X: 14, Z: 10}, 0.5},
+		{ecs.Vector3{X: 10, Y: 50, Z: 18}, 0},
+		{ecs.Vector3{X: -20}, 0},
+	}
+	for _, tt := range tests {
+		if got := field.At(tt.position); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("suppression at %v = %v, want %v", tt.position, got, tt.want)
+		}
+	}
+
+	// Снимок не меняется вместе с якорем
+	anchor.Radius = 100
+	if got := field.At(ecs.Vector3{X: 30, Z: 10}); got != 0 {
+		t.Errorf("snapshot follows the anchor's new radius: suppression %v", got)
+	}
+}
+
+func TestEmptyAnchorFieldSuppressesNothing(t *testing.T) {
+	var field AnchorField
+	if got := field.At(ecs.Vector3{}); got != 0 {
+		t.Errorf("zero AnchorField suppression = %v, want 0", got)
+	}
+}
+
+// addTestAnchor добавляет в мир заряженный якорь стабильности с компонентом рендера
+func addTestAnchor(world *ecs.World, position ecs.Vector3, radius float64, strength int, charge float64) (*ecs.Entity, *ecs.AnchorComponent) {
+	entity := ecs.NewEntity()
+	entity.AddComponent(ecs.NewTransformComponent(position))
+	entity.AddComponent(ecs.NewRenderComponent("anchor", "anchor"))
+	anchor := ecs.NewAnchorComponent(radius, strength, charge)
+	entity.AddComponent(anchor)
+	world.AddEntity(entity)
+	return entity, anchor
+}
+
+func TestAnchorDrainsWithSuppressedIntensityUntilItCracks(t *testing.T) {
+	mm, world := newTestManager(t)
+	nearEntity, near := addTestAnchor(world, ecs.Vector3{}, 8, 2, 10)
+	_, far := addTestAnchor(world, ecs.Vector3{X: 50}, 8, 2, 10)
+
+	var cracked []ecs.EntityID
+	mm.OnAnchorCracked = func(anchorID ecs.EntityID, position ecs.Vector3) {
+		cracked = append(cracked, anchorID)
+	}
+
+	// Локальный эффект давит только на ближний якорь, глобальный - на оба
+	local := &MetamorphEffect{ID: "bloom_1", Name: "Bloom", Order: OrderFirst, Intensity: 1.0,
+		AffectedArea: &AffectedArea{Type: "sphere", Radius: 20, Falloff: "linear", FalloffMax: 20}}
+	global := &MetamorphEffect{ID: "hum_1", Name: "Hum", Order: OrderFirst, Intensity: 0.2}
+	mm.activeEffects[local.ID] = local
+	mm.activeEffects[global.ID] = global
+
+	mm.mutex.Lock()
+	mm.drainAnchors(1.0)
+	mm.mutex.Unlock()
+
+	suppressedNear := local.EffectiveIntensityFor(ecs.Vector3{}) + global.Intensity
+	suppressedFar := local.EffectiveIntensityFor(ecs.Vector3{X: 50}) + global.Intensity
+	if got, want := 10-near.Charge, suppressedNear*AnchorDrainRate; math.Abs(got-want) > 1e-9 {
+		t.Errorf("near anchor drained %v, want %v", got, want)
+	}
+	if got, want := 10-far.Charge, suppressedFar*AnchorDrainRate; math.Abs(got-want) > 1e-9 {
+		t.Errorf("far anchor drained %v, want %v", got, want)
+	}
+	if 10-near.Charge <= 10-far.Charge {
+		t.Errorf("near anchor drained %v, want more than the far one's %v", 10-near.Charge, 10-far.Charge)
+	}
+
+	// Ближний якорь разряжается и трескается один раз
+	for i := 0; i < 20; i++ {
+		mm.mutex.Lock()
+		mm.drainAnchors(10.0)
+		mm.mutex.Unlock()
+	}
+	if !near.Cracked || near.IsCharged() {
+		t.Fatalf("near anchor charge %v cracked %v, want it depleted and cracked", near.Charge, near.Cracked)
+	}
+	if far.Cracked {
+		t.Errorf("far anchor cracked at charge %v", far.Charge)
+	}
+	if len(cracked) != 1 || cracked[0] != nearEntity.ID {
+		t.Errorf("OnAnchorCracked calls = %v, want one for %s", cracked, nearEntity.ID)
+	}
+	render, _ := ecs.ComponentAs[*ecs.RenderComponent](nearEntity, ecs.RenderComponentID)
+	if !render.Effects.Has("cracked") {
+		t.Errorf("cracked anchor shows no crack")
+	}
+
+	entries := 0
+	for _, entry := range mm.GetHistory() {
+		if entry.Action == "anchor_cracked" && entry.EntityID == nearEntity.ID {
+			entries++
+		}
+	}
+	if entries != 1 {
+		t.Errorf("history has %d crack entries for the anchor, want 1", entries)
+	}
+}
+
+func TestAnchorBlocksStrongEffectsUntilDepleted(t *testing.T) {
+	mm, world := newTestManager(t)
+	setTestBudget(mm, 1000)
+	mm.orderThresholds[OrderFirst] = 0
+	mm.orderThresholds[OrderSecond] = 0
+	mm.RegisterEffectTemplate(&MetamorphEffect{ID: "glow", Name: "Glow", Order: OrderFirst, Category: "visual", Intensity: 0.5})
+	mm.RegisterEffectTemplate(&MetamorphEffect{ID: "twist", Name: "Twist", Order: OrderSecond, Category: "environment", Intensity: 0.5})
+
+	// Заряда хватает ровно на одну блокировку
+	_, anchor := addTestAnchor(world, ecs.Vector3{}, 8, int(OrderSecond), 0.5*AnchorBlockCost)
+
+	request := func(order OrderLevel, center ecs.Vector3) error {
+		_, err := mm.RequestEffect(EffectRequest{Order: order, Center: center, Radius: 4, Intensity: 0.5})
+		return err
+	}
+
+	// Слабый эффект и эффект вне радиуса якорь пропускает
+	if err := request(OrderFirst, ecs.Vector3{X: 3}); err != nil {
+		t.Errorf("order below the anchor strength: %v, want it accepted", err)
+	}
+	if err := request(OrderSecond, ecs.Vector3{X: 12}); err != nil {
+		t.Errorf("center outside the anchor radius: %v, want it accepted", err)
+	}
+	if anchor.Charge != 0.5*AnchorBlockCost {
+		t.Fatalf("anchor charge %v before any block, want it full", anchor.Charge)
+	}
+
+	if err := request(OrderSecond, ecs.Vector3{X: 3}); !errors.Is(err, ErrBlockedByAnchor) {
+		t.Fatalf("order at the anchor strength inside its radius: %v, want ErrBlockedByAnchor", err)
+	}
+	if anchor.IsCharged() {
+		t.Fatalf("anchor charge %v after blocking, want it depleted", anchor.Charge)
+	}
+
+	if err := request(OrderSecond, ecs.Vector3{X: 3}); err != nil {
+		t.Errorf("same effect at the depleted anchor: %v, want it accepted", err)
+	}
+}
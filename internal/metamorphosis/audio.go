@@ -0,0 +1,111 @@
+package metamorphosis
+
+import (
+	"echo-taiga/internal/engine/ecs"
+)
+
+// savedSound - состояние источника звука до применения звукового эффекта
+type savedSound struct {
+	SoundID   string
+	Volume    float64
+	IsLooping bool
+	IsPlaying bool
+}
+
+// setupAudioCallbacks настраивает колбэки звукового эффекта: зацикленный звук
+// запускается при применении, следует за сущностью, затухает к краю области
+// и останавливается при снятии эффекта. Исходный звук сущностей хранится в
+// effect.savedSounds, поэтому колбэки привязываются к каждому экземпляру
+func setupAudioCallbacks(effect *MetamorphEffect) {
+	effect.OnApply = func(world *ecs.World, entity *ecs.Entity) error {
+		sound, has := soundEmitterOf(entity)
+		if !has {
+			return nil
+		}
+
+		if effect.savedSounds == nil {
+			effect.savedSounds = make(map[ecs.EntityID]savedSound)
+		}
+		if _, saved := effect.savedSounds[entity.ID]; !saved {
+			effect.savedSounds[entity.ID] = savedSound{
+				SoundID:   sound.SoundID,
+				Volume:    sound.Volume,
+				IsLooping: sound.IsLooping,
+				IsPlaying: sound.IsPlaying,
+			}
+		}
+
+		// Добавляем звуковые эффекты
+		for _, soundEffect := range effect.SoundEffects {
+			if soundID, exists := sound.Sounds[soundEffect]; exists {
+				sound.SoundID = soundID
+				sound.IsLooping = true
+				sound.Play()
+			}
+		}
+
+		syncAudioEmitter(effect, entity, sound, effect.savedSounds[entity.ID].Volume)
+		return nil
+	}
+
+	effect.OnUpdate = func(world *ecs.World, entity *ecs.Entity, deltaTime float64) error {
+		sound, has := soundEmitterOf(entity)
+		if !has {
+			return nil
+		}
+
+		baseVolume := sound.Volume
+		if saved, exists := effect.savedSounds[entity.ID]; exists {
+			baseVolume = saved.Volume
+		}
+
+		syncAudioEmitter(effect, entity, sound, baseVolume)
+		return nil
+	}
+
+	effect.OnRemove = func(world *ecs.World, entity *ecs.Entity) error {
+		sound, has := soundEmitterOf(entity)
+		if !has {
+			return nil
+		}
+
+		// Останавливаем зацикленный звук эффекта
+		sound.Stop()
+
+		// Возвращаем исходный звук сущности
+		if saved, exists := effect.savedSounds[entity.ID]; exists {
+			sound.SoundID = saved.SoundID
+			sound.Volume = saved.Volume
+			sound.IsLooping = saved.IsLooping
+			if saved.IsPlaying {
+				sound.Play()
+			}
+			delete(effect.savedSounds, entity.ID)
+		}
+
+		return nil
+	}
+}
+
+// syncAudioEmitter переносит источник звука в позицию сущности и задает громкость
+// с учетом затухания области эффекта
+func syncAudioEmitter(effect *MetamorphEffect, entity *ecs.Entity, sound *ecs.SoundEmitterComponent, baseVolume float64) {
+	transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
+	if !has {
+		return
+	}
+
+	position := transform.Position
+	sound.Position = position
+
+	falloff := 1.0
+	if effect.AffectedArea != nil && effect.Intensity > 0 {
+		falloff = effect.EffectiveIntensityFor(position) / effect.Intensity
+	}
+	sound.Volume = baseVolume * falloff
+}
+
+// soundEmitterOf возвращает компонент звука сущности
+func soundEmitterOf(entity *ecs.Entity) (*ecs.SoundEmitterComponent, bool) {
+	return ecs.ComponentAs[*ecs.SoundEmitterComponent](entity, ecs.SoundEmitterComponentID)
+}
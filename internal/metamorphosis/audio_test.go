@@ -0,0 +1,165 @@
+package metamorphosis
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// addSoundEntity добавляет сущность с источником звука, знающим звук "whisper"
+func addSoundEntity(world *ecs.World, soundID string, volume float64, position ecs.Vector3) (*ecs.Entity, *ecs.SoundEmitterComponent) {
+	sound := ecs.NewSoundEmitterComponent(soundID, volume, 20)
+	sound.Sounds["whisper"] = "whisper_loop"
+	entity := addMetamorphicEntity(world, 0, ecs.NewTransformComponent(position), sound)
+	return entity, sound
+}
+
+func TestAudioEffectInstancesKeepTheirOwnSavedSound(t *testing.T) {
+	mm, world := newTestManager(t)
+	mm.RegisterEffectTemplate(&MetamorphEffect{
+		ID:           "whispers",
+		Name:         "Whispers",
+		Order:        OrderFirst,
+		Category:     "audio",
+		Intensity:    1.0,
+		SoundEffects: []string{"whisper"},
+	})
+
+	first, err := mm.CreateEffectFromTemplate("whispers")
+	if err != nil {
+		t.Fatalf("CreateEffectFromTemplate: %v", err)
+	}
+	second, err := mm.CreateEffectFromTemplate("whispers")
+	if err != nil {
+		t.Fatalf("CreateEffectFromTemplate: %v", err)
+	}
+
+	entity, sound := addSoundEntity(world, "birdsong", 0.8, ecs.Vector3{})
+	if err := first.OnApply(world, entity); err != nil {
+		t.Fatalf("first OnApply: %v", err)
+	}
+	if err := second.OnApply(world, entity); err != nil {
+		t.Fatalf("second OnApply: %v", err)
+	}
+	if sound.SoundID != "whisper_loop" || !sound.IsPlaying {
+		t.Fatalf("sound = %q playing %v, want the whisper loop playing", sound.SoundID, sound.IsPlaying)
+	}
+
+	// Второй экземпляр запомнил уже измененный звук, первый - исходный
+	if err := second.OnRemove(world, entity); err != nil {
+		t.Fatalf("second OnRemove: %v", err)
+	}
+	if err := first.OnRemove(world, entity); err != nil {
+		t.Fatalf("first OnRemove: %v", err)
+	}
+	if sound.SoundID != "birdsong" || sound.IsLooping || sound.IsPlaying {
+		t.Errorf("after removing both instances sound = %q looping %v playing %v, want birdsong restored",
+			sound.SoundID, sound.IsLooping, sound.IsPlaying)
+	}
+	if len(first.savedSounds) != 0 || len(second.savedSounds) != 0 {
+		t.Errorf("saved sounds left behind: %d and %d", len(first.savedSounds), len(second.savedSounds))
+	}
+}
+
+func TestAudioEffectFadesWithItsOwnArea(t *testing.T) {
+	mm, world := newTestManager(t)
+	mm.RegisterEffectTemplate(&MetamorphEffect{
+		ID:           "murmur",
+		Name:         "Murmur",
+		Order:        OrderFirst,
+		Category:     "audio",
+		Intensity:    1.0,
+		SoundEffects: []string{"whisper"},
+		AffectedArea: &AffectedArea{Type: "sphere", Radius: 10, Falloff: "linear", FalloffMax: 10},
+	})
+
+	effect, err := mm.CreateEffectFromTemplate("murmur")
+	if err != nil {
+		t.Fatalf("CreateEffectFromTemplate: %v", err)
+	}
+	effect.AffectedArea = &AffectedArea{Type: "sphere", Center: ecs.Vector3{X: 100}, Radius: 10, Falloff: "linear", FalloffMax: 10}
+
+	// Сущность в центре области экземпляра звучит в полную громкость,
+	// хотя от центра шаблона она далеко
+	entity, sound := addSoundEntity(world, "birdsong", 0.5, ecs.Vector3{X: 100})
+	if err := effect.OnApply(world, entity); err != nil {
+		t.Fatalf("OnApply: %v", err)
+	}
+	if sound.Volume != 0.5 {
+		t.Errorf("volume at the instance center = %v, want 0.5", sound.Volume)
+	}
+}
+
+// newWhisperEffect создает звуковой эффект с областью вокруг точки center
+func newWhisperEffect(t *testing.T, mm *MetamorphosisManager, center ecs.Vector3) *MetamorphEffect {
+	t.Helper()
+
+	mm.RegisterEffectTemplate(&MetamorphEffect{
+		ID:           "whispers",
+		Name:         "Whispers",
+		Order:        OrderFirst,
+		Category:     "audio",
+		Intensity:    1.0,
+		SoundEffects: []string{"whisper"},
+	})
+	effect, err := mm.CreateEffectFromTemplate("whispers")
+	if err != nil {
+		t.Fatalf("CreateEffectFromTemplate: %v", err)
+	}
+	effect.AffectedArea = &AffectedArea{Type: "sphere", Center: center, Radius: 20, Falloff: "linear", FalloffMax: 20}
+	return effect
+}
+
+func TestAudioEmitterFollowsMovingEntity(t *testing.T) {
+	mm, world := newTestManager(t)
+	effect := newWhisperEffect(t, mm, ecs.Vector3{})
+	entity, sound := addSoundEntity(world, "birdsong", 0.8, ecs.Vector3{X: 2})
+	applyTestEffect(mm, effect)
+	if sound.SoundID != "whisper_loop" {
+		t.Fatalf("sound = %q after the effect applied, want the whisper loop", sound.SoundID)
+	}
+
+	// Сущность уходит к краю области: источник идет за ней и звучит тише
+	transform, _ := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
+	before := sound.Volume
+	for _, position := range []ecs.Vector3{{X: 8}, {X: 12, Z: 3}} {
+		transform.Position = position
+
+		mm.mutex.Lock()
+		mm.updateActiveEffects(0.1)
+		mm.mutex.Unlock()
+
+		if sound.Position != position {
+			t.Errorf("emitter at %v, want it on the entity at %v", sound.Position, position)
+		}
+		want := 0.8 * effect.EffectiveIntensityFor(position) / effect.Intensity
+		if diff := sound.Volume - want; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("volume at %v = %v, want %v", position, sound.Volume, want)
+		}
+		if sound.Volume >= before {
+			t.Errorf("volume %v at %v, want it quieter than %v nearer the center", sound.Volume, position, before)
+		}
+		before = sound.Volume
+	}
+}
+
+func TestRemovingAudioEffectStopsTheLoop(t *testing.T) {
+	mm, world := newTestManager(t)
+	effect := newWhisperEffect(t, mm, ecs.Vector3{})
+	_, sound := addSoundEntity(world, "", 0.8, ecs.Vector3{X: 2})
+	applyTestEffect(mm, effect)
+	if !sound.IsPlaying || !sound.IsLooping {
+		t.Fatalf("whisper loop playing %v looping %v, want it looping", sound.IsPlaying, sound.IsLooping)
+	}
+
+	mm.mutex.Lock()
+	mm.removeMetamorphEffect(effect.ID)
+	mm.mutex.Unlock()
+
+	if sound.IsPlaying || sound.IsLooping {
+		t.Errorf("after removal playing %v looping %v, want the loop stopped", sound.IsPlaying, sound.IsLooping)
+	}
+	if sound.SoundID != "" || sound.Volume != 0.8 {
+		t.Errorf("after removal sound = %q at %v, want the silent source restored at 0.8", sound.SoundID, sound.Volume)
+	}
+}
@@ -0,0 +1,37 @@
+package metamorphosis
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine"
+)
+
+func TestEffectExpiresWhenFakeClockPassesDuration(t *testing.T) {
+	mm, _ := newTestManager(t)
+	clock := engine.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	mm.SetClock(clock)
+
+	applyTestEffect(mm, &MetamorphEffect{
+		ID: "fog_1", Name: "Fog", Order: OrderFirst, Category: "visual", Intensity: 0.5,
+		Duration: 10 * time.Minute,
+	})
+	active := func() bool {
+		mm.mutex.RLock()
+		defer mm.mutex.RUnlock()
+		_, exists := mm.activeEffects["fog_1"]
+		return exists
+	}
+
+	clock.Advance(10*time.Minute - time.Second)
+	mm.Update(1)
+	if !active() {
+		t.Fatalf("effect expired a second before its duration")
+	}
+
+	clock.Advance(time.Second)
+	mm.Update(1)
+	if active() {
+		t.Errorf("effect outlived its duration")
+	}
+}
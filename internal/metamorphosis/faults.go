@@ -0,0 +1,77 @@
+package metamorphosis
+
+import (
+	"fmt"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// MaxEffectFaults - число сбоев колбэков эффекта подряд, после которого эффект
+// считается неисправным: он снимается, а потраченный бюджет возвращается
+const MaxEffectFaults = 3
+
+// effectFault - сбой колбэка эффекта на одной сущности
+type effectFault struct {
+	stage    string
+	entityID ecs.EntityID
+	err      error
+}
+
+// IsFaulted сообщает, был ли эффект снят из-за сбоев колбэков
+func (me *MetamorphEffect) IsFaulted() bool {
+	return me.faulted
+}
+
+// metamorphicOf возвращает компонент метаморфичности сущности. Компонент мог
+// пропасть или быть подменен после выборки - тогда сущность пропускается.
+func metamorphicOf(entity *ecs.Entity) (*ecs.MetamorphicComponent, bool) {
+	return ecs.ComponentAs[*ecs.MetamorphicComponent](entity, ecs.MetamorphicComponentID)
+}
+
+// runEffectCallback вызывает колбэк эффекта для одной сущности, перехватывая панику,
+// чтобы одна испорченная сущность не обрывала обновление. Ошибка или паника
+// засчитывается эффекту как сбой, успешный вызов сбрасывает счетчик.
+func (mm *MetamorphosisManager) runEffectCallback(effect *MetamorphEffect, entity *ecs.Entity, stage string, callback func() error) {
+	mm.recordCallbackResult(effect, entity, stage, callEffectCallback(callback))
+}
+
+// callEffectCallback вызывает колбэк эффекта, превращая панику в ошибку.
+// Не обращается к состоянию менеджера, поэтому может вызываться без мьютекса.
+func callEffectCallback(callback func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return callback()
+}
+
+// recordCallbackResult засчитывает эффекту результат вызова колбэка. Сбой не
+// печатается: последний из них попадает в историю, если эффект будет снят.
+// Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) recordCallbackResult(effect *MetamorphEffect, entity *ecs.Entity, stage string, err error) {
+	if err == nil {
+		effect.faults = 0
+		return
+	}
+
+	effect.faults++
+	effect.lastFault = effectFault{stage: stage, entityID: entity.ID, err: err}
+}
+
+// removeFaultedEffects снимает эффекты, колбэки которых сбоили MaxEffectFaults раз
+// подряд, и отмечает сбой в истории. Стоимость эффекта возвращает removeMetamorphEffect.
+func (mm *MetamorphosisManager) removeFaultedEffects() {
+	for id, effect := range mm.activeEffects {
+		if effect.faults < MaxEffectFaults {
+			continue
+		}
+
+		effect.faulted = true
+		fault := effect.lastFault
+		mm.recordHistoryEntry(id, "faulted", fault.entityID, fmt.Sprintf("Effect %s faulted after %d consecutive callback failures, last in %s: %v",
+			effect.Name, effect.faults, fault.stage, fault.err))
+
+		mm.removeMetamorphEffect(id)
+	}
+}
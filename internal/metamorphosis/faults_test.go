@@ -0,0 +1,76 @@
+package metamorphosis
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+func TestFaultedEffectIsRemovedAndRefundedOnce(t *testing.T) {
+	mm, world := newTestManager(t)
+	entity := addMetamorphicEntity(world, 0, ecs.NewRenderComponent("wolf", "wolf"))
+
+	// Колбэк без проверки компонента, как в старых эффектах
+	effect := &MetamorphEffect{
+		ID:        "flicker_1",
+		Name:      "Flicker",
+		Order:     OrderFirst,
+		Category:  "visual",
+		Intensity: 1.0,
+		OnUpdate: func(world *ecs.World, entity *ecs.Entity, deltaTime float64) error {
+			component, _ := entity.GetComponent(ecs.RenderComponentID)
+			component.(*ecs.RenderComponent).Visible = true
+			return nil
+		},
+	}
+
+	mm.maxBudget = 1000
+	mm.anomalyBudget = 100
+	applyTestEffect(mm, effect)
+
+	// Компонент отрисовки пропадает посреди кадра
+	entity.RemoveComponent(ecs.RenderComponentID)
+	for i := 0; i < MaxEffectFaults; i++ {
+		mm.Update(0.1)
+	}
+
+	for _, active := range mm.GetActiveEffects() {
+		if active.ID == effect.ID {
+			t.Fatalf("effect %s is still active after %d faults", effect.ID, MaxEffectFaults)
+		}
+	}
+	if !effect.IsFaulted() {
+		t.Errorf("effect is not marked as faulted")
+	}
+
+	// Сбои не печатаются, последний из них записан в историю
+	faulted := false
+	for _, entry := range mm.GetHistory() {
+		if entry.EffectID == effect.ID && entry.Action == "faulted" {
+			faulted = true
+			if entry.EntityID != entity.ID || !strings.Contains(entry.Description, "update") || !strings.Contains(entry.Description, "panic") {
+				t.Errorf("fault entry = %+v, want the update panic on %s", entry, entity.ID)
+			}
+		}
+	}
+	if !faulted {
+		t.Errorf("history has no fault entry for %s", effect.ID)
+	}
+
+	// Стоимость возвращается один раз и целиком (плюс ничтожная регенерация)
+	if math.Abs(mm.anomalyBudget-100) > 0.1 {
+		t.Errorf("budget after fault = %.2f, want 100 (cost %.2f refunded once)", mm.anomalyBudget, getEffectCost(effect))
+	}
+}
+
+func TestUpdateSkipsEntityWithoutMetamorphicComponent(t *testing.T) {
+	mm, world := newTestManager(t)
+	entity := addMetamorphicEntity(world, 0)
+
+	applyTestEffect(mm, &MetamorphEffect{ID: "shift_1", Name: "Shift", Order: OrderFirst, Category: "visual", Intensity: 1.0})
+
+	entity.RemoveComponent(ecs.MetamorphicComponentID)
+	mm.Update(0.1)
+}
@@ -0,0 +1,147 @@
+package metamorphosis
+
+import (
+	"sort"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// EffectForecast описывает эффект, который, вероятно, скоро сработает
+type EffectForecast struct {
+	TriggerID  string        // Триггер, который активирует эффект
+	TemplateID string        // Шаблон эффекта
+	Name       string        // Название эффекта
+	Order      OrderLevel    // Порядок метаморфозы
+	Category   string        // Категория эффекта
+	Location   *ecs.Vector3  // Где ожидается эффект (nil, если место неизвестно)
+	ETA        time.Duration // Через сколько эффект станет доступен по бюджету (0 - уже сейчас)
+	Likelihood float64       // Вероятность выбора этого эффекта (0-1)
+	Next       bool          // Эффект из числа тех, что checkTriggers применит следующим
+}
+
+// ForecastUpcomingEffects перечисляет эффекты, которые могут сработать в пределах horizon:
+// триггеры, условия которых выполнены сейчас, порядки, разрешенные фазой трансформации,
+// и шаблоны, на которые бюджет аномалий накопится за это время. Состояние не изменяется.
+func (mm *MetamorphosisManager) ForecastUpcomingEffects(horizon time.Duration) []EffectForecast {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	// Триггеры в том же порядке, в котором их перебирает checkTriggers
+	var satisfied []*MetamorphTrigger
+	for _, trigger := range mm.availableTriggers {
+		if trigger.Check != nil && trigger.Check(mm.world, mm.worldState) {
+			satisfied = append(satisfied, trigger)
+		}
+	}
+	sort.Slice(satisfied, func(i, j int) bool {
+		if satisfied[i].Priority != satisfied[j].Priority {
+			return satisfied[i].Priority > satisfied[j].Priority
+		}
+		return satisfied[i].ID < satisfied[j].ID
+	})
+
+	forecasts := make([]EffectForecast, 0)
+	nextFound := false
+
+	for _, trigger := range satisfied {
+		order := getEffectOrderForTrigger(trigger)
+		if !mm.isOrderAllowed(order) {
+			continue
+		}
+
+		templates := mm.templatesForOrder(order)
+		if len(templates) == 0 {
+			continue
+		}
+
+		// checkTriggers выбирает шаблон случайно и применяет его, если хватает бюджета
+		isNext := false
+		if !nextFound {
+			for _, template := range templates {
+				if mm.canAffordEffect(template) {
+					isNext = true
+					nextFound = true
+					break
+				}
+			}
+		}
+
+		for _, template := range templates {
+			eta, reachable := mm.budgetETA(template)
+			if !reachable || eta > horizon {
+				continue
+			}
+
+			forecasts = append(forecasts, EffectForecast{
+				TriggerID:  trigger.ID,
+				TemplateID: template.ID,
+				Name:       template.Name,
+				Order:      template.Order,
+				Category:   template.Category,
+				Location:   mm.forecastLocation(trigger, template),
+				ETA:        eta,
+				Likelihood: trigger.Priority / float64(len(templates)),
+				Next:       isNext && eta == 0,
+			})
+		}
+	}
+
+	return forecasts
+}
+
+// templatesForOrder возвращает шаблоны эффектов порядка, отсортированные по ID
+func (mm *MetamorphosisManager) templatesForOrder(order OrderLevel) []*MetamorphEffect {
+	result := make([]*MetamorphEffect, 0)
+	for _, template := range mm.effectTemplates {
+		if template.Order == order {
+			result = append(result, template)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// budgetETA оценивает, через сколько бюджет аномалий покроет стоимость эффекта
+func (mm *MetamorphosisManager) budgetETA(effect *MetamorphEffect) (time.Duration, bool) {
+	cost := getEffectCost(effect)
+	if cost > mm.maxBudget {
+		return 0, false
+	}
+
+	missing := cost - mm.anomalyBudget
+	if missing <= 0 {
+		return 0, true
+	}
+	if mm.regenerationRate <= 0 {
+		return 0, false
+	}
+
+	// Бюджет регенерирует за минуту (см. updateAnomalyBudget)
+	minutes := missing / mm.regenerationRate
+	return time.Duration(minutes * float64(time.Minute)), true
+}
+
+// forecastLocation определяет, где проявится эффект
+func (mm *MetamorphosisManager) forecastLocation(trigger *MetamorphTrigger, template *MetamorphEffect) *ecs.Vector3 {
+	if trigger.Location != nil {
+		location := *trigger.Location
+		return &location
+	}
+
+	if template.AffectedArea != nil {
+		center := template.AffectedArea.Center
+		return &center
+	}
+
+	// Эффекты без области проявляются вокруг игрока
+	if mm.worldState != nil {
+		position := mm.worldState.PlayerPosition
+		return &position
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package metamorphosis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newForecastTestManager создает менеджер с большим бюджетом, шаблонами первого и
+// второго порядка и двумя выполненными триггерами: приоритетный ведет ко второму
+// порядку, слабый - к первому
+func newForecastTestManager(t *testing.T) *MetamorphosisManager {
+	t.Helper()
+
+	mm, _ := newTestManager(t)
+	setTestBudget(mm, 1000)
+	mm.orderThresholds[OrderFirst] = 0
+	mm.orderThresholds[OrderSecond] = 0
+	mm.effectTemplates = make(map[string]*MetamorphEffect)
+	mm.RegisterEffectTemplate(&MetamorphEffect{ID: "glow", Name: "Glow", Order: OrderFirst, Category: "visual", Intensity: 0.5})
+	mm.RegisterEffectTemplate(&MetamorphEffect{ID: "quake", Name: "Quake", Order: OrderSecond, Category: "environment", Intensity: 0.5})
+
+	always := func(world *ecs.World, state *WorldState) bool { return true }
+	location := ecs.Vector3{X: 40, Z: -12}
+	mm.availableTriggers = map[string]*MetamorphTrigger{
+		"faint":  {ID: "faint", Type: "test", Priority: 0.3, Check: always},
+		"urgent": {ID: "urgent", Type: "test", Priority: 0.7, Location: &location, Check: always},
+	}
+	return mm
+}
+
+func TestForecastNextIsTheEffectTriggersApply(t *testing.T) {
+	mm := newForecastTestManager(t)
+
+	forecasts := mm.ForecastUpcomingEffects(time.Minute)
+	var next []EffectForecast
+	for _, forecast := range forecasts {
+		if forecast.Next {
+			next = append(next, forecast)
+		}
+	}
+	if len(forecasts) != 2 || len(next) != 1 {
+		t.Fatalf("forecast = %+v, want both triggers listed and one next", forecasts)
+	}
+	if next[0].TriggerID != "urgent" || next[0].TemplateID != "quake" {
+		t.Errorf("next = %s from %s, want quake from the urgent trigger", next[0].TemplateID, next[0].TriggerID)
+	}
+	if next[0].Location == nil || *next[0].Location != (ecs.Vector3{X: 40, Z: -12}) {
+		t.Errorf("next location = %v, want the trigger's location", next[0].Location)
+	}
+
+	mm.mutex.Lock()
+	mm.checkTriggers()
+	mm.mutex.Unlock()
+
+	active := mm.GetActiveEffects()
+	if len(active) != 1 || !strings.HasPrefix(active[0].ID, next[0].TemplateID+"_") {
+		t.Fatalf("checkTriggers applied %d effects, want the forecast %s", len(active), next[0].TemplateID)
+	}
+	if _, consumed := mm.availableTriggers[next[0].TriggerID]; consumed {
+		t.Errorf("trigger %s is still available after firing", next[0].TriggerID)
+	}
+}
+
+func TestForecastLeavesStateUntouched(t *testing.T) {
+	forecasted, untouched := newForecastTestManager(t), newForecastTestManager(t)
+	for _, mm := range []*MetamorphosisManager{forecasted, untouched} {
+		setTestBudget(mm, 15)
+		// С двумя шаблонами второго порядка checkTriggers выбирает между ними случайно
+		mm.RegisterEffectTemplate(&MetamorphEffect{ID: "rift", Name: "Rift", Order: OrderSecond, Category: "reality", Intensity: 0.5})
+	}
+
+	forecasts := forecasted.ForecastUpcomingEffects(time.Hour)
+	if len(forecasts) == 0 {
+		t.Fatalf("forecast over an hour is empty")
+	}
+	if forecasted.anomalyBudget != 15 || len(forecasted.activeEffects) != 0 ||
+		len(forecasted.availableTriggers) != 2 || len(forecasted.GetHistory()) != 0 {
+		t.Errorf("forecast left budget %v, %d effects, %d triggers and %d history entries, want 15, none, 2 and none",
+			forecasted.anomalyBudget, len(forecasted.activeEffects), len(forecasted.availableTriggers), len(forecasted.GetHistory()))
+	}
+
+	// Прогноз не расходует случайные числа: дальше оба менеджера живут одинаково
+	for i := 0; i < 3; i++ {
+		forecasted.Update(60)
+		untouched.Update(60)
+	}
+	if got, want := describeActiveEffects(forecasted), describeActiveEffects(untouched); got != want {
+		t.Errorf("effects after the forecast = %s, want %s as without it", got, want)
+	}
+	if got, want := forecasted.GetAnomalyBudget(), untouched.GetAnomalyBudget(); got != want {
+		t.Errorf("budget after the forecast = %v, want %v as without it", got, want)
+	}
+}
+
+// describeActiveEffects перечисляет активные эффекты с интенсивностью в порядке имен
+func describeActiveEffects(mm *MetamorphosisManager) string {
+	effects := make([]string, 0)
+	for _, effect := range mm.GetActiveEffects() {
+		effects = append(effects, fmt.Sprintf("%s@%.6f", effect.Name, effect.Intensity))
+	}
+	sort.Strings(effects)
+	return strings.Join(effects, ",")
+}
@@ -0,0 +1,29 @@
+//go:build dev
+
+package metamorphosis
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/framebudget"
+)
+
+// frameTime - длительность кадра при 60 кадрах в секунду
+const frameTime = 1.0 / 60
+
+// BenchmarkMetamorphosisUpdate - кадр менеджера с 500 сущностями и 30 эффектами
+func BenchmarkMetamorphosisUpdate(b *testing.B) {
+	mm, _ := newTestManager(b)
+	if err := mm.PopulateSynthetic(500, 30, 1); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mm.Update(frameTime)
+	}
+}
+
+func TestMetamorphosisUpdateBudget(t *testing.T) {
+	framebudget.Check(t, "MetamorphosisUpdate", BenchmarkMetamorphosisUpdate)
+}
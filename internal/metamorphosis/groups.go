@@ -0,0 +1,178 @@
+package metamorphosis
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Стаи и стада мутируют целиком: эффект существ, задевший одного члена группы,
+// оценивается для всей группы по средней стабильности ее членов
+const (
+	GroupEffectCategory = "entity" // Категория эффектов, действующих на группы целиком
+	MinGroupSize        = 2        // Группа, в которой осталось меньше живых членов, распадается
+)
+
+// groupPass - группы на время одного прохода по сущностям. Члены групп
+// собираются одним обходом мира, а каждая группа оценивается для эффекта
+// один раз за проход, сколько бы ее членов ни встретилось.
+type groupPass struct {
+	groups    map[string][]*ecs.Entity // Сущности групп по идентификатору (nil - еще не собраны)
+	evaluated map[string]bool          // Пары "группа/эффект", уже оцененные за проход
+}
+
+// newGroupPass начинает проход по сущностям
+func newGroupPass() *groupPass {
+	return &groupPass{evaluated: make(map[string]bool)}
+}
+
+// mutateEntity применяет эффект к сущности, если она может мутировать. Эффекты
+// существ решают за всю группу сущности: мутируют либо все ее члены, либо ни
+// один. Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) mutateEntity(entity *ecs.Entity, metamorphic *ecs.MetamorphicComponent, effect *MetamorphEffect, pass *groupPass) {
+	if effect.Category == GroupEffectCategory {
+		if group, has := ecs.ComponentAs[*ecs.GroupComponent](entity, ecs.GroupComponentID); has {
+			key := group.GroupID + "/" + effect.ID
+			if pass.evaluated[key] {
+				return
+			}
+			pass.evaluated[key] = true
+
+			if members := mm.groupMembers(pass, group.GroupID); len(members) > 0 {
+				mm.mutateGroup(group.GroupID, members, effect)
+				return
+			}
+		}
+	}
+
+	if !mm.canMutate(entity, metamorphic, effect) {
+		return
+	}
+	mm.applyToEntity(entity, metamorphic, effect)
+	mm.recordHistoryEntry(effect.ID, "applied", entity.ID, fmt.Sprintf("Applied effect %s to entity %s", effect.Name, entity.ID))
+}
+
+// mutateGroup применяет эффект ко всем членам группы или ни к одному. Шанс
+// мутации считается по средней стабильности членов с учетом якорей; если часть
+// группы уже мутировала, остальные члены догоняют ее без броска. Вся группа
+// получает одну запись в истории.
+func (mm *MetamorphosisManager) mutateGroup(groupID string, members []*ecs.Entity, effect *MetamorphEffect) {
+	pending := make([]*ecs.Entity, 0, len(members))
+	stability := 0.0
+	for _, member := range members {
+		metamorphic, _ := ecs.ComponentAs[*ecs.MetamorphicComponent](member, ecs.MetamorphicComponentID)
+		stability += math.Min(1.0, metamorphic.Stability+mm.anchorBonus(member))
+		if !containsString(metamorphic.CurrentMetamorphoses, effect.ID) {
+			pending = append(pending, member)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	if len(pending) == len(members) && !groupCanMutate(mm.rng, effect.Intensity, stability/float64(len(members))) {
+		return
+	}
+
+	for _, member := range pending {
+		metamorphic, _ := ecs.ComponentAs[*ecs.MetamorphicComponent](member, ecs.MetamorphicComponentID)
+		mm.applyToEntity(member, metamorphic, effect)
+	}
+
+	mm.appendHistoryEntry(HistoryEntry{
+		Timestamp:   mm.clock.Now(),
+		EffectID:    effect.ID,
+		Action:      "applied",
+		GroupID:     groupID,
+		Description: fmt.Sprintf("Applied effect %s to group %s (%d members)", effect.Name, groupID, len(pending)),
+	})
+}
+
+// groupCanMutate бросает шанс мутации группы так же, как canMutate для одной сущности
+func groupCanMutate(rng *rand.Rand, intensity, averageStability float64) bool {
+	if averageStability >= 1.0 {
+		return false
+	}
+	return rng.Float64() < intensity*(1.0-averageStability)
+}
+
+// applyToEntity применяет эффект к сущности и вызывает его колбэк
+func (mm *MetamorphosisManager) applyToEntity(entity *ecs.Entity, metamorphic *ecs.MetamorphicComponent, effect *MetamorphEffect) {
+	metamorphic.ApplyMetamorphosis(effect.ID, effect.Intensity)
+
+	if effect.OnApply != nil {
+		mm.runEffectCallback(effect, entity, "apply", func() error {
+			return effect.OnApply(mm.world, entity)
+		})
+	}
+}
+
+// groupMembers возвращает живых членов группы с компонентом метаморфичности.
+// Группа, в которой осталось меньше MinGroupSize живых членов, распадается:
+// выжившие теряют компонент группы и дальше мутируют поодиночке.
+func (mm *MetamorphosisManager) groupMembers(pass *groupPass, groupID string) []*ecs.Entity {
+	if pass.groups == nil {
+		pass.groups = make(map[string][]*ecs.Entity)
+		for _, entity := range mm.world.GetEntitiesWithComponent(ecs.GroupComponentID) {
+			if group, has := ecs.ComponentAs[*ecs.GroupComponent](entity, ecs.GroupComponentID); has {
+				pass.groups[group.GroupID] = append(pass.groups[group.GroupID], entity)
+			}
+		}
+	}
+
+	var members []*ecs.Entity
+	for _, entity := range pass.groups[groupID] {
+		if _, has := ecs.ComponentAs[*ecs.MetamorphicComponent](entity, ecs.MetamorphicComponentID); !has {
+			continue
+		}
+		if health, has := ecs.ComponentAs[*ecs.HealthComponent](entity, ecs.HealthComponentID); has && health.IsDead() {
+			continue
+		}
+		members = append(members, entity)
+	}
+
+	if len(members) < MinGroupSize {
+		for _, member := range members {
+			member.RemoveComponent(ecs.GroupComponentID)
+		}
+		delete(pass.groups, groupID)
+		mm.appendHistoryEntry(HistoryEntry{
+			Timestamp:   mm.clock.Now(),
+			Action:      "group_dissolved",
+			GroupID:     groupID,
+			Description: fmt.Sprintf("Group %s dissolved", groupID),
+		})
+		return nil
+	}
+	return members
+}
+
+// coverGroups отмечает покрытыми всех членов групп, которые эффект существ уже
+// задел, чтобы стая на границе области не теряла эффект по частям.
+// Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) coverGroups(covered map[ecs.EntityID]bool, effect *MetamorphEffect) {
+	if effect.Category != GroupEffectCategory {
+		return
+	}
+
+	byGroup := make(map[string][]*ecs.Entity)
+	coveredGroups := make(map[string]bool)
+	for _, entity := range mm.world.GetEntitiesWithComponent(ecs.GroupComponentID) {
+		group, has := ecs.ComponentAs[*ecs.GroupComponent](entity, ecs.GroupComponentID)
+		if !has {
+			continue
+		}
+		byGroup[group.GroupID] = append(byGroup[group.GroupID], entity)
+		if covered[entity.ID] {
+			coveredGroups[group.GroupID] = true
+		}
+	}
+
+	for groupID := range coveredGroups {
+		for _, member := range byGroup[groupID] {
+			covered[member.ID] = true
+		}
+	}
+}
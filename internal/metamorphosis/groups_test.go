@@ -0,0 +1,122 @@
+package metamorphosis
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// addPack добавляет в мир стаю из size волков с одинаковой стабильностью
+func addPack(world *ecs.World, groupID string, size int, stability float64) []*ecs.Entity {
+	members := make([]*ecs.Entity, 0, size)
+	for i := 0; i < size; i++ {
+		members = append(members, addMetamorphicEntity(world, stability,
+			ecs.NewTransformComponent(ecs.Vector3{X: float64(i)}),
+			ecs.NewHealthComponent(50),
+			ecs.NewGroupComponent(groupID, "pack"),
+		))
+	}
+	return members
+}
+
+// mutatedCount возвращает, сколько сущностей подвержено эффекту
+func mutatedCount(entities []*ecs.Entity, effectID string) int {
+	count := 0
+	for _, entity := range entities {
+		metamorphic, _ := ecs.ComponentAs[*ecs.MetamorphicComponent](entity, ecs.MetamorphicComponentID)
+		if containsString(metamorphic.CurrentMetamorphoses, effectID) {
+			count++
+		}
+	}
+	return count
+}
+
+// newPackEffect создает эффект существ без области, задевающий всех
+func newPackEffect(id string, intensity float64) *MetamorphEffect {
+	return &MetamorphEffect{ID: id, Name: "Feral", Order: OrderFirst, Category: GroupEffectCategory, Intensity: intensity}
+}
+
+func TestPackMutatesAllOrNothing(t *testing.T) {
+	outcomes := make(map[int]bool)
+	for seed := int64(1); seed <= 40; seed++ {
+		mm, world := newTestManager(t)
+		mm.SetSeed(seed)
+		setTestBudget(mm, 1000)
+
+		pack := addPack(world, "wolf_pack_0_0_0", 4, 0.5)
+		effect := newPackEffect("feral_1", 1.0)
+		applyTestEffect(mm, effect)
+
+		// Следующие кадры не добирают членов по одному
+		for i := 0; i < 2; i++ {
+			mm.Update(0.1)
+		}
+
+		count := mutatedCount(pack, effect.ID)
+		if count != 0 && count != len(pack) {
+			t.Fatalf("seed %d: %d of %d pack members mutated", seed, count, len(pack))
+		}
+		outcomes[count] = true
+	}
+
+	// При шансе 50% за 40 сидов встречаются оба исхода
+	if !outcomes[0] || !outcomes[4] {
+		t.Errorf("outcomes over 40 seeds = %v, want both 0 and 4", outcomes)
+	}
+}
+
+func TestPackGetsSingleHistoryEntry(t *testing.T) {
+	mm, world := newTestManager(t)
+	setTestBudget(mm, 1000)
+	pack := addPack(world, "wolf_pack_1_1_0", 3, 0)
+
+	effect := newPackEffect("feral_2", 1.0)
+	applyTestEffect(mm, effect)
+	mm.Update(0.1)
+
+	if count := mutatedCount(pack, effect.ID); count != len(pack) {
+		t.Fatalf("%d of %d pack members mutated at zero stability", count, len(pack))
+	}
+
+	entries := 0
+	for _, entry := range mm.GetHistory() {
+		if entry.EffectID == effect.ID && entry.Action == "applied" {
+			if entry.GroupID != "wolf_pack_1_1_0" {
+				t.Errorf("applied entry for %s has group %q", entry.EntityID, entry.GroupID)
+			}
+			entries++
+		}
+	}
+	if entries != 1 {
+		t.Errorf("history has %d applied entries, want 1 for the whole pack", entries)
+	}
+}
+
+func TestPackDissolvesBelowMinGroupSize(t *testing.T) {
+	mm, world := newTestManager(t)
+	setTestBudget(mm, 1000)
+	pack := addPack(world, "wolf_pack_2_2_0", 2, 0)
+
+	health, _ := ecs.ComponentAs[*ecs.HealthComponent](pack[0], ecs.HealthComponentID)
+	health.CurrentHealth = 0
+
+	effect := newPackEffect("feral_3", 1.0)
+	applyTestEffect(mm, effect)
+
+	if pack[1].HasComponent(ecs.GroupComponentID) {
+		t.Errorf("survivor of a dissolved pack still has a group component")
+	}
+	if count := mutatedCount(pack[1:], effect.ID); count != 1 {
+		t.Errorf("survivor did not mutate on its own")
+	}
+
+	dissolved := 0
+	for _, entry := range mm.GetHistory() {
+		if entry.Action == "group_dissolved" {
+			dissolved++
+		}
+	}
+	if dissolved != 1 {
+		t.Errorf("history has %d dissolution entries, want 1", dissolved)
+	}
+}
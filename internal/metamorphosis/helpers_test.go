@@ -0,0 +1,51 @@
+package metamorphosis
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newTestManager создает менеджер метаморфоз с пустым миром и фиксированным сидом
+func newTestManager(tb testing.TB) (*MetamorphosisManager, *ecs.World) {
+	tb.Helper()
+
+	world := ecs.NewWorld()
+	mm := NewMetamorphosisManager(world, tb.TempDir())
+	mm.SetSeed(1)
+	return mm, world
+}
+
+// setTestBudget задает одинаковые общий бюджет и бюджет аномальности
+func setTestBudget(mm *MetamorphosisManager, budget float64) {
+	mm.maxBudget = budget
+	mm.anomalyBudget = budget
+}
+
+// addMetamorphicEntity добавляет в мир сущность с компонентом метаморфичности
+func addMetamorphicEntity(world *ecs.World, stability float64, components ...ecs.Component) *ecs.Entity {
+	entity := ecs.NewEntity()
+	entity.AddComponent(ecs.NewMetamorphicComponent(stability))
+	for _, component := range components {
+		entity.AddComponent(component)
+	}
+	world.AddEntity(entity)
+	return entity
+}
+
+// addTestPlayer добавляет в мир игрока в указанной точке
+func addTestPlayer(world *ecs.World, position ecs.Vector3) *ecs.Entity {
+	player := ecs.NewEntity()
+	player.AddComponent(ecs.NewTransformComponent(position))
+	player.AddTag(ecs.RegisterTag(TagPlayer, "actor", ""))
+	world.AddEntity(player)
+	return player
+}
+
+// applyTestEffect применяет эффект так же, как сработавший триггер
+func applyTestEffect(mm *MetamorphosisManager, effect *MetamorphEffect) {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.applyMetamorphEffect(effect)
+}
@@ -0,0 +1,111 @@
+package metamorphosis
+
+import (
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// minEffectiveFalloff - затухание, ниже которого эффект считается не действующим
+const minEffectiveFalloff = 0.05
+
+// FalloffFactor возвращает множитель затухания (0-1) для расстояния от центра области
+func (a *AffectedArea) FalloffFactor(distance float64) float64 {
+	if a.Falloff == "none" || distance <= a.FalloffMin {
+		return 1.0
+	}
+
+	// Нормализуем расстояние от 0 до 1
+	span := a.FalloffMax - a.FalloffMin
+	normalizedDistance := 1.0
+	if span > 0 {
+		normalizedDistance = math.Min(1.0, math.Max(0.0, (distance-a.FalloffMin)/span))
+	}
+
+	// Вычисляем затухание в зависимости от типа
+	switch a.Falloff {
+	case "quadratic":
+		return 1.0 - normalizedDistance*normalizedDistance
+	case "exponential":
+		return math.Exp(-3.0 * normalizedDistance)
+	default:
+		return 1.0 - normalizedDistance
+	}
+}
+
+// EffectiveIntensityFor возвращает интенсивность эффекта в точке с учетом области и затухания.
+// Эффект без области действует везде с полной интенсивностью.
+func (me *MetamorphEffect) EffectiveIntensityFor(position ecs.Vector3) float64 {
+	area := me.AffectedArea
+	if area == nil {
+		return me.Intensity
+	}
+
+	switch area.Type {
+	case "sphere":
+		distance := position.Distance(area.Center)
+		if distance > area.Radius {
+			return 0
+		}
+		return me.attenuated(area.FalloffFactor(distance))
+
+	case "box":
+		if math.Abs(position.X-area.Center.X) > area.Size.X/2 ||
+			math.Abs(position.Y-area.Center.Y) > area.Size.Y/2 ||
+			math.Abs(position.Z-area.Center.Z) > area.Size.Z/2 {
+			return 0
+		}
+		return me.Intensity
+
+	case "cylinder":
+		horizontalDistance := math.Hypot(position.X-area.Center.X, position.Z-area.Center.Z)
+		if horizontalDistance > area.Radius || math.Abs(position.Y-area.Center.Y) > area.Height/2 {
+			return 0
+		}
+		return me.attenuated(area.FalloffFactor(horizontalDistance))
+
+	case "path":
+		distance := distanceToPath(position, area.Points)
+		if distance > area.Radius {
+			return 0
+		}
+		return me.attenuated(area.FalloffFactor(distance))
+	}
+
+	return 0
+}
+
+// attenuated применяет затухание к интенсивности, отсекая слишком слабое воздействие
+func (me *MetamorphEffect) attenuated(falloff float64) float64 {
+	if falloff < minEffectiveFalloff {
+		return 0
+	}
+	return me.Intensity * falloff
+}
+
+// distanceToPath вычисляет расстояние от точки до ломаной
+func distanceToPath(position ecs.Vector3, points []ecs.Vector3) float64 {
+	if len(points) == 0 {
+		return math.Inf(1)
+	}
+	if len(points) == 1 {
+		return position.Distance(points[0])
+	}
+
+	best := math.Inf(1)
+	for i := 1; i < len(points); i++ {
+		start := points[i-1]
+		segment := points[i].Sub(start)
+		lengthSquared := segment.Dot(segment)
+
+		t := 0.0
+		if lengthSquared > 0 {
+			t = math.Min(1.0, math.Max(0.0, position.Sub(start).Dot(segment)/lengthSquared))
+		}
+
+		closest := start.Add(segment.Multiply(t))
+		best = math.Min(best, position.Distance(closest))
+	}
+
+	return best
+}
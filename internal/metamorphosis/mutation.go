@@ -0,0 +1,207 @@
+package metamorphosis
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// EffectPatch описывает изменение активного эффекта во время игры.
+// Пустые (nil) поля не меняются.
+type EffectPatch struct {
+	Intensity    *float64       // Новая интенсивность (0-1)
+	AffectedArea *AffectedArea  // Новая область воздействия
+	Duration     *time.Duration // Новая полная длительность (0 = постоянно)
+}
+
+// ActiveEffectRecord - сохраняемое состояние активного эффекта вместе с изменениями,
+// накопленными во время игры
+type ActiveEffectRecord struct {
+	TemplateID        string         `json:"template_id"`
+	Intensity         float64        `json:"intensity"`
+	Duration          time.Duration  `json:"duration"`           // Полная длительность (0 = постоянно)
+	RemainingDuration time.Duration  `json:"remaining_duration"` // 0 для постоянных эффектов
+	AffectedArea      *AffectedArea  `json:"affected_area,omitempty"`
+	AppliedEntities   []ecs.EntityID `json:"applied_entities,omitempty"`
+}
+
+// MutateEffect изменяет активный эффект: применяет изменения, пересматривает
+// затронутые сущности (новые получают OnApply, вышедшие из области - OnRemove)
+// и списывает или возвращает бюджет аномалий за разницу в стоимости.
+// OnRemove вызывается после освобождения мьютекса: колбэк может обращаться к
+// менеджеру или брать блокировки других систем.
+func (mm *MetamorphosisManager) MutateEffect(effectID string, patch EffectPatch) error {
+	mm.mutex.Lock()
+	effect, dropped, err := mm.mutateEffect(effectID, patch)
+	mm.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	mm.removeEffectFrom(effect, dropped)
+	return nil
+}
+
+// mutateEffect применяет изменения к активному эффекту и возвращает его вместе
+// с сущностями, вышедшими из области. Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) mutateEffect(effectID string, patch EffectPatch) (*MetamorphEffect, []*ecs.Entity, error) {
+	effect, exists := mm.activeEffects[effectID]
+	if !exists {
+		return nil, nil, fmt.Errorf("active effect not found: %s", effectID)
+	}
+
+	if patch.Intensity != nil && (*patch.Intensity < 0 || *patch.Intensity > 1) {
+		return nil, nil, fmt.Errorf("intensity must be between 0 and 1, got %.2f", *patch.Intensity)
+	}
+
+	// Запоминаем исходное состояние на случай отката
+	previous := *effect
+	oldCost := getEffectCost(effect)
+
+	if patch.Intensity != nil {
+		effect.Intensity = *patch.Intensity
+	}
+	if patch.AffectedArea != nil {
+		area := *patch.AffectedArea
+		effect.AffectedArea = &area
+	}
+	if patch.Duration != nil {
+		effect.Duration = *patch.Duration
+	}
+
+	// Пересчитываем бюджет за разницу в стоимости
+	delta := getEffectCost(effect) - oldCost
+	if delta > mm.anomalyBudget {
+		*effect = previous
+		return nil, nil, fmt.Errorf("not enough anomaly budget to mutate effect %s: need %.1f, have %.1f", effectID, delta, mm.anomalyBudget)
+	}
+	mm.anomalyBudget = math.Min(mm.maxBudget, mm.anomalyBudget-delta)
+
+	dropped := mm.reevaluateEffectMembership(effect)
+
+	mm.recordHistoryEntry(effectID, "mutated", "", fmt.Sprintf("Mutated effect %s (budget delta %.1f)", effect.Name, delta))
+
+	mm.effectsChanged(effect)
+
+	return effect, dropped, nil
+}
+
+// reevaluateEffectMembership применяет эффект к вновь попавшим в него сущностям
+// и снимает его с вышедших. Возвращает вышедшие сущности: их OnRemove вызывает
+// removeEffectFrom уже без мьютекса. Должен вызываться с захваченным мьютексом.
+func (mm *MetamorphosisManager) reevaluateEffectMembership(effect *MetamorphEffect) []*ecs.Entity {
+	covered := make(map[ecs.EntityID]bool)
+	for _, entity := range mm.getEntitiesForEffect(effect) {
+		covered[entity.ID] = true
+	}
+
+	// Группы, задетые эффектом, остаются под ним целиком
+	mm.coverGroups(covered, effect)
+
+	var dropped []*ecs.Entity
+	pass := newGroupPass()
+	for _, entity := range mm.world.GetEntitiesWithComponent(ecs.MetamorphicComponentID) {
+		metamorphic, ok := metamorphicOf(entity)
+		if !ok {
+			continue
+		}
+
+		applied := containsString(metamorphic.CurrentMetamorphoses, effect.ID)
+
+		switch {
+		case covered[entity.ID] && !applied:
+			mm.mutateEntity(entity, metamorphic, effect, pass)
+
+		case !covered[entity.ID] && applied:
+			metamorphic.CurrentMetamorphoses = removeString(metamorphic.CurrentMetamorphoses, effect.ID)
+			dropped = append(dropped, entity)
+			mm.recordHistoryEntry(effect.ID, "removed", entity.ID, fmt.Sprintf("Removed effect %s from entity %s", effect.Name, entity.ID))
+		}
+	}
+
+	return dropped
+}
+
+// removeEffectFrom вызывает OnRemove эффекта для вышедших из него сущностей.
+// Вызывается без мьютекса; сбои засчитываются эффекту под мьютексом.
+func (mm *MetamorphosisManager) removeEffectFrom(effect *MetamorphEffect, entities []*ecs.Entity) {
+	if effect.OnRemove == nil {
+		return
+	}
+
+	for _, entity := range entities {
+		err := callEffectCallback(func() error {
+			return effect.OnRemove(mm.world, entity)
+		})
+
+		mm.mutex.Lock()
+		mm.recordCallbackResult(effect, entity, "remove", err)
+		mm.mutex.Unlock()
+	}
+}
+
+// appliedEntities возвращает сущности, на которые сейчас действует эффект
+func (mm *MetamorphosisManager) appliedEntities(effectID string) []ecs.EntityID {
+	result := make([]ecs.EntityID, 0)
+	for _, entity := range mm.world.GetEntitiesWithComponent(ecs.MetamorphicComponentID) {
+		metamorphic, ok := ecs.ComponentAs[*ecs.MetamorphicComponent](entity, ecs.MetamorphicComponentID)
+		if ok && containsString(metamorphic.CurrentMetamorphoses, effectID) {
+			result = append(result, entity.ID)
+		}
+	}
+	return result
+}
+
+// newEffectRecord создает сохраняемую запись активного эффекта
+func (mm *MetamorphosisManager) newEffectRecord(effect *MetamorphEffect, templateID string, now time.Time) ActiveEffectRecord {
+	record := ActiveEffectRecord{
+		TemplateID:      templateID,
+		Intensity:       effect.Intensity,
+		Duration:        effect.Duration,
+		AffectedArea:    effect.AffectedArea,
+		AppliedEntities: mm.appliedEntities(effect.ID),
+	}
+
+	if effect.Duration > 0 {
+		record.RemainingDuration = effect.Duration - now.Sub(effect.AppliedTime)
+		if record.RemainingDuration < 0 {
+			record.RemainingDuration = 0
+		}
+	}
+
+	return record
+}
+
+// restoreEffectRecord переносит сохраненные изменения на эффект, созданный из шаблона
+func (mm *MetamorphosisManager) restoreEffectRecord(effect *MetamorphEffect, record ActiveEffectRecord, now time.Time) {
+	effect.Intensity = record.Intensity
+	effect.Duration = record.Duration
+	if record.AffectedArea != nil {
+		area := *record.AffectedArea
+		effect.AffectedArea = &area
+	}
+
+	// Время применения сдвигаем так, чтобы осталось сохраненное время действия
+	effect.AppliedTime = now
+	if effect.Duration > 0 {
+		effect.AppliedTime = now.Add(record.RemainingDuration - effect.Duration)
+	}
+
+	// Восстанавливаем отметки на сущностях, которые уже существуют
+	for _, entityID := range record.AppliedEntities {
+		entity, exists := mm.world.GetEntity(entityID)
+		if !exists {
+			continue
+		}
+
+		metamorphic, has := ecs.ComponentAs[*ecs.MetamorphicComponent](entity, ecs.MetamorphicComponentID)
+		if !has {
+			continue
+		}
+		if !containsString(metamorphic.CurrentMetamorphoses, effect.ID) {
+			metamorphic.CurrentMetamorphoses = append(metamorphic.CurrentMetamorphoses, effect.ID)
+		}
+	}
+}
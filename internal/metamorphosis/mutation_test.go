@@ -0,0 +1,110 @@
+package metamorphosis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newMutationTestEffect создает сферический эффект в начале координат,
+// записывающий сущности, к которым он применялся и с которых снимался
+func newMutationTestEffect(mm *MetamorphosisManager, radius float64) (effect *MetamorphEffect, applied, removed *[]ecs.EntityID) {
+	applied, removed = &[]ecs.EntityID{}, &[]ecs.EntityID{}
+	effect = &MetamorphEffect{
+		ID:           "bloom_1",
+		Name:         "Bloom",
+		Order:        OrderFirst,
+		Category:     "visual",
+		Intensity:    1.0,
+		Duration:     time.Hour,
+		AffectedArea: &AffectedArea{Type: "sphere", Radius: radius, Falloff: "none"},
+		OnApply: func(world *ecs.World, entity *ecs.Entity) error {
+			*applied = append(*applied, entity.ID)
+			return nil
+		},
+		OnRemove: func(world *ecs.World, entity *ecs.Entity) error {
+			// Колбэк обращается к менеджеру: под мьютексом это бы зависло
+			mm.GetEffect("bloom_1")
+			*removed = append(*removed, entity.ID)
+			return nil
+		},
+	}
+	return effect, applied, removed
+}
+
+// mutateWithin вызывает MutateEffect и падает, если он не вернулся вовремя
+func mutateWithin(t *testing.T, mm *MetamorphosisManager, patch EffectPatch) {
+	t.Helper()
+
+	done := make(chan error, 1)
+	go func() { done <- mm.MutateEffect("bloom_1", patch) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("MutateEffect: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("MutateEffect did not return: OnRemove ran under mm.mutex")
+	}
+}
+
+func TestMutatingTheAreaReevaluatesEntities(t *testing.T) {
+	mm, world := newTestManager(t)
+	setTestBudget(mm, 100)
+	near := addMetamorphicEntity(world, 0, ecs.NewTransformComponent(ecs.Vector3{X: 3}))
+	far := addMetamorphicEntity(world, 0, ecs.NewTransformComponent(ecs.Vector3{X: 12}))
+
+	effect, applied, removed := newMutationTestEffect(mm, 5)
+	applyTestEffect(mm, effect)
+	if len(*applied) != 1 || (*applied)[0] != near.ID {
+		t.Fatalf("effect applied to %v, want only the near entity", *applied)
+	}
+
+	// Больший радиус накрывает дальнюю сущность
+	mutateWithin(t, mm, EffectPatch{AffectedArea: &AffectedArea{Type: "sphere", Radius: 15, Falloff: "none"}})
+	if len(*applied) != 2 || (*applied)[1] != far.ID {
+		t.Errorf("after growing the radius effect applied to %v, want the far entity added", *applied)
+	}
+	if len(*removed) != 0 {
+		t.Errorf("growing the radius removed the effect from %v", *removed)
+	}
+
+	// Смещенная область оставляет ближнюю сущность снаружи
+	mutateWithin(t, mm, EffectPatch{AffectedArea: &AffectedArea{Type: "sphere", Center: ecs.Vector3{X: 12}, Radius: 5, Falloff: "none"}})
+	if len(*removed) != 1 || (*removed)[0] != near.ID {
+		t.Errorf("after moving the area effect removed from %v, want the near entity", *removed)
+	}
+	metamorphic, _ := metamorphicOf(near)
+	if containsString(metamorphic.CurrentMetamorphoses, effect.ID) {
+		t.Errorf("near entity still lists %s among its metamorphoses", effect.ID)
+	}
+}
+
+func TestMutationRefundsBudgetWhenCostShrinks(t *testing.T) {
+	mm, _ := newTestManager(t)
+	setTestBudget(mm, 100)
+	effect, _, _ := newMutationTestEffect(mm, 5)
+	applyTestEffect(mm, effect)
+
+	before := mm.GetAnomalyBudget()
+	oldCost := getEffectCost(effect)
+	intensity := 0.25
+	mutateWithin(t, mm, EffectPatch{Intensity: &intensity})
+
+	refund := oldCost - getEffectCost(effect)
+	if refund <= 0 {
+		t.Fatalf("lower intensity did not lower the cost (%v -> %v)", oldCost, getEffectCost(effect))
+	}
+	if got := mm.GetAnomalyBudget(); math.Abs(got-(before+refund)) > 1e-9 {
+		t.Errorf("budget after the mutation = %v, want %v refunded to %v", got, refund, before+refund)
+	}
+
+	// Возврат не поднимает бюджет выше максимума
+	intensity = 0
+	mutateWithin(t, mm, EffectPatch{Intensity: &intensity})
+	if got := mm.GetAnomalyBudget(); got > mm.GetMaxBudget() {
+		t.Errorf("budget %v exceeds the maximum %v", got, mm.GetMaxBudget())
+	}
+}
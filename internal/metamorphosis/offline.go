@@ -0,0 +1,93 @@
+package metamorphosis
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Параметры симуляции мира за время отсутствия игрока
+const (
+	OfflineEffectInterval = 2 * time.Hour // Одна метаморфоза за столько времени отсутствия
+	MaxOfflineEffects     = 3             // Наибольшее число метаморфоз за одно отсутствие
+	OfflineEffectRadius   = 12.0          // Радиус метаморфоз, возникших без игрока
+)
+
+// offlineCategories - категории метаморфоз, которые незаметно копятся без игрока
+var offlineCategories = []string{"environment", "visual", "reality"}
+
+// Hotspot - область с высокой аномальностью, рядом с которой мир меняется без игрока
+type Hotspot struct {
+	Center ecs.Vector3
+	Level  float64 // Уровень аномальности области (0-1)
+}
+
+// FastForward проматывает время отсутствия игрока по обычным правилам системы:
+// бюджет аномалий восстанавливается, истекшие эффекты снимаются, а рядом с горячими
+// точками возникает до MaxOfflineEffects метаморфоз (записываются в историю с действием
+// "offline"). Результат определяется длительностью отсутствия и точками.
+// Возвращает ID возникших эффектов.
+func (mm *MetamorphosisManager) FastForward(deltaTime float64, hotspots []Hotspot) []string {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+	defer mm.publishSnapshot()
+
+	elapsed := time.Duration(deltaTime * float64(time.Second))
+	if elapsed <= 0 {
+		return nil
+	}
+
+	// Время действия эффектов шло и без игрока
+	for _, effect := range mm.activeEffects {
+		effect.AppliedTime = effect.AppliedTime.Add(-elapsed)
+	}
+	mm.effectCopies.Store(nil)
+	mm.removeExpiredEffects(mm.clock.Now())
+
+	mm.updateAnomalyBudget(deltaTime)
+
+	if len(hotspots) == 0 {
+		return nil
+	}
+
+	count := int(elapsed / OfflineEffectInterval)
+	if count > MaxOfflineEffects {
+		count = MaxOfflineEffects
+	}
+
+	// Метаморфозы без игрока никто не видит
+	mm.fastForwarding = true
+	defer func() { mm.fastForwarding = false }()
+
+	r := rand.New(rand.NewSource(int64(elapsed / time.Second)))
+	created := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		hotspot := hotspots[i%len(hotspots)]
+
+		order := OrderFirst
+		if r.Float64() < hotspot.Level && mm.isOrderAllowed(OrderSecond) {
+			order = OrderSecond
+		}
+
+		start := r.Intn(len(offlineCategories))
+		categories := append(append([]string(nil), offlineCategories[start:]...), offlineCategories[:start]...)
+
+		effect, err := mm.requestEffect(EffectRequest{
+			Order:      order,
+			Categories: categories,
+			Center:     hotspot.Center,
+			Radius:     OfflineEffectRadius,
+			Intensity:  0.2 + 0.5*hotspot.Level,
+		})
+		if err != nil {
+			continue
+		}
+
+		mm.recordHistoryEntry(effect.ID, "offline", "", fmt.Sprintf("While you were gone: %s", effect.Name))
+		created = append(created, effect.ID)
+	}
+
+	return created
+}
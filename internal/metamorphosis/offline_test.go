@@ -0,0 +1,88 @@
+package metamorphosis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// eightHours - время отсутствия игрока в тестах промотки
+const eightHours = 8 * 60 * 60.0
+
+func TestFastForwardRegeneratesBudget(t *testing.T) {
+	mm, _ := newTestManager(t)
+	mm.anomalyBudget = 10
+	mm.maxBudget = 1000
+
+	mm.FastForward(eightHours, nil)
+
+	// 0.5 единицы в минуту за 480 минут
+	if want := 10 + mm.regenerationRate*480; mm.GetAnomalyBudget() != want {
+		t.Errorf("budget after 8 hours = %v, want %v", mm.GetAnomalyBudget(), want)
+	}
+}
+
+func TestFastForwardExpiresElapsedEffects(t *testing.T) {
+	mm, _ := newTestManager(t)
+	setTestBudget(mm, 1000)
+
+	short := &MetamorphEffect{ID: "short_1", Name: "Short", Order: OrderFirst, Category: "visual", Intensity: 0.5, Duration: time.Hour}
+	long := &MetamorphEffect{ID: "long_1", Name: "Long", Order: OrderFirst, Category: "visual", Intensity: 0.5, Duration: 10 * time.Hour}
+	permanent := &MetamorphEffect{ID: "permanent_1", Name: "Permanent", Order: OrderFirst, Category: "visual", Intensity: 0.5}
+	for _, effect := range []*MetamorphEffect{short, long, permanent} {
+		applyTestEffect(mm, effect)
+	}
+
+	mm.FastForward(eightHours, nil)
+
+	active := make(map[string]bool)
+	for _, effect := range mm.GetActiveEffects() {
+		active[effect.ID] = true
+	}
+	if active[short.ID] {
+		t.Errorf("one hour effect survived 8 hours away")
+	}
+	if !active[long.ID] || !active[permanent.ID] {
+		t.Errorf("active effects after 8 hours = %v, want long_1 and permanent_1", active)
+	}
+}
+
+func TestFastForwardJournalsOfflineMetamorphoses(t *testing.T) {
+	run := func() []string {
+		mm, _ := newTestManager(t)
+		setTestBudget(mm, 1000)
+		mm.RegisterEffectTemplate(&MetamorphEffect{ID: "creeping_frost", Name: "Creeping Frost", Order: OrderFirst, Category: "environment", Intensity: 0.5})
+
+		hotspots := []Hotspot{{Center: ecs.Vector3{X: 40, Z: 40}, Level: 0.8}}
+		created := mm.FastForward(eightHours, hotspots)
+
+		descriptions := make([]string, 0)
+		for _, entry := range mm.GetHistory() {
+			if entry.Action == "offline" {
+				descriptions = append(descriptions, entry.Description)
+			}
+		}
+		if len(descriptions) != len(created) {
+			t.Fatalf("%d offline journal entries for %d created effects", len(descriptions), len(created))
+		}
+		return descriptions
+	}
+
+	first := run()
+	// 8 часов дают 4 интервала, но метаморфоз не больше MaxOfflineEffects
+	if len(first) != MaxOfflineEffects {
+		t.Fatalf("%d offline metamorphoses after 8 hours, want %d", len(first), MaxOfflineEffects)
+	}
+	for _, description := range first {
+		if !strings.HasPrefix(description, "While you were gone: ") {
+			t.Errorf("journal entry %q is not marked as happening while away", description)
+		}
+	}
+
+	second := run()
+	if strings.Join(first, "|") != strings.Join(second, "|") {
+		t.Errorf("the same absence produced %v and %v", first, second)
+	}
+}
@@ -0,0 +1,110 @@
+package metamorphosis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Ошибки запроса эффекта другими системами
+var (
+	ErrOrderNotAllowed   = errors.New("metamorphosis order is not allowed in the current phase")
+	ErrBudgetExceeded    = errors.New("not enough anomaly budget for the effect")
+	ErrNoMatchingEffects = errors.New("no effect template matches the request")
+	ErrBlockedByAnchor   = errors.New("metamorphosis is suppressed by a stability anchor")
+)
+
+// EffectRequest - запрос другой системы на небольшую локальную метаморфозу
+type EffectRequest struct {
+	Order      OrderLevel    // Порядок эффекта
+	Categories []string      // Предпочтительные категории (в порядке предпочтения)
+	Center     ecs.Vector3   // Центр области воздействия
+	Radius     float64       // Радиус области воздействия
+	Intensity  float64       // Интенсивность эффекта (0-1)
+	Duration   time.Duration // Длительность (0 - длительность шаблона)
+	Source     string        // Кто запросил эффект (для истории), например "scare:<id>"
+}
+
+// RequestEffect создает эффект по запросу другой системы. Действуют те же правила,
+// что и для триггеров: порядок должен быть доступен в текущей фазе, а стоимость -
+// укладываться в бюджет аномалий. Возвращает ID созданного эффекта.
+func (mm *MetamorphosisManager) RequestEffect(request EffectRequest) (string, error) {
+	defer mm.flushWitnessed()
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	effect, err := mm.requestEffect(request)
+	if err != nil {
+		return "", err
+	}
+	if request.Source != "" {
+		mm.recordHistoryEntry(effect.ID, "requested", "", fmt.Sprintf("Effect %s requested by %s", effect.Name, request.Source))
+	}
+
+	return effect.ID, nil
+}
+
+// requestEffect создает и применяет эффект по запросу. Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) requestEffect(request EffectRequest) (*MetamorphEffect, error) {
+	if !mm.isOrderAllowed(request.Order) {
+		return nil, ErrOrderNotAllowed
+	}
+
+	templateID := mm.templateForRequest(request)
+	if templateID == "" {
+		return nil, ErrNoMatchingEffects
+	}
+
+	effect, err := mm.CreateEffectFromTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	effect.Intensity = request.Intensity
+	if request.Duration > 0 {
+		effect.Duration = request.Duration
+	}
+	effect.AffectedArea = &AffectedArea{
+		Type:    "sphere",
+		Center:  request.Center,
+		Radius:  request.Radius,
+		Falloff: "linear",
+	}
+
+	if !mm.canAffordEffect(effect) {
+		return nil, ErrBudgetExceeded
+	}
+	if site := mm.blockingAnchor(effect); site != nil {
+		mm.blockEffect(effect, site)
+		return nil, ErrBlockedByAnchor
+	}
+
+	mm.applyMetamorphEffect(effect)
+	return effect, nil
+}
+
+// templateForRequest выбирает шаблон эффекта нужного порядка, предпочитая
+// категории запроса. При равенстве выбирается шаблон с меньшим ID.
+func (mm *MetamorphosisManager) templateForRequest(request EffectRequest) string {
+	ids := make([]string, 0)
+	for id, template := range mm.effectTemplates {
+		if template.Order == request.Order {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, category := range request.Categories {
+		for _, id := range ids {
+			if mm.effectTemplates[id].Category == category {
+				return id
+			}
+		}
+	}
+	if len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package metamorphosis
+
+import (
+	"strings"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newRequestTestManager создает менеджер с двумя шаблонами первого порядка
+// разных категорий и без ограничений по фазе
+func newRequestTestManager(t *testing.T) *MetamorphosisManager {
+	t.Helper()
+
+	mm, _ := newTestManager(t)
+	setTestBudget(mm, 1000)
+	mm.orderThresholds[OrderFirst] = 0
+	mm.RegisterEffectTemplate(&MetamorphEffect{ID: "a_glow", Name: "Glow", Order: OrderFirst, Category: "visual", Intensity: 0.5})
+	mm.RegisterEffectTemplate(&MetamorphEffect{ID: "b_twist", Name: "Twisted Growth", Order: OrderFirst, Category: "environment", Intensity: 0.5})
+	return mm
+}
+
+func TestRequestedEffectPrefersCategoryAndRecordsSource(t *testing.T) {
+	mm := newRequestTestManager(t)
+
+	effectID, err := mm.RequestEffect(EffectRequest{
+		Order:      OrderFirst,
+		Categories: []string{"environment", "visual"},
+		Center:     ecs.Vector3{X: 4, Z: 2},
+		Radius:     6,
+		Intensity:  0.25,
+		Source:     "scare:shadow_1",
+	})
+	if err != nil {
+		t.Fatalf("RequestEffect: %v", err)
+	}
+
+	effect, exists := mm.GetEffect(effectID)
+	if !exists {
+		t.Fatalf("requested effect %s is not active", effectID)
+	}
+	if effect.Name != "Twisted Growth" || effect.Intensity != 0.25 {
+		t.Errorf("effect %s at %v, want Twisted Growth at the requested 0.25", effect.Name, effect.Intensity)
+	}
+	if area := effect.AffectedArea; area == nil || area.Center != (ecs.Vector3{X: 4, Z: 2}) || area.Radius != 6 {
+		t.Errorf("affected area %+v, want the requested sphere", effect.AffectedArea)
+	}
+
+	found := false
+	for _, entry := range mm.GetHistory() {
+		if entry.EffectID == effectID && entry.Action == "requested" && strings.Contains(entry.Description, "scare:shadow_1") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("history %+v has no request by scare:shadow_1", mm.GetHistory())
+	}
+}
+
+func TestRequestedEffectFollowsPhaseAndBudget(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		prepare func(mm *MetamorphosisManager)
+		request EffectRequest
+		want    error
+	}{
+		{"order locked", func(mm *MetamorphosisManager) { mm.orderThresholds[OrderSecond] = 2 },
+			EffectRequest{Order: OrderSecond, Radius: 5, Intensity: 0.3}, ErrOrderNotAllowed},
+		{"no budget", func(mm *MetamorphosisManager) { setTestBudget(mm, 0) },
+			EffectRequest{Order: OrderFirst, Radius: 5, Intensity: 0.3}, ErrBudgetExceeded},
+		{"no template", func(mm *MetamorphosisManager) { mm.orderThresholds[OrderThird] = 0 },
+			EffectRequest{Order: OrderThird, Radius: 5, Intensity: 0.3}, ErrNoMatchingEffects},
+	} {
+		mm := newRequestTestManager(t)
+		tt.prepare(mm)
+
+		if _, err := mm.RequestEffect(tt.request); err != tt.want {
+			t.Errorf("%s: RequestEffect error %v, want %v", tt.name, err, tt.want)
+		}
+		if active := mm.GetActiveEffects(); len(active) != 0 {
+			t.Errorf("%s: %d effects applied by a denied request", tt.name, len(active))
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package metamorphosis
+
+import (
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// EffectView - неизменяемое описание активного эффекта для интерфейса
+type EffectView struct {
+	ID           string
+	Name         string
+	Description  string
+	Order        OrderLevel
+	Category     string
+	Intensity    float64
+	Remaining    time.Duration // Оставшееся время (0 - эффект постоянный)
+	HasArea      bool
+	AreaCenter   ecs.Vector3
+	AreaRadius   float64
+	AffectedTags []string
+}
+
+// StateSnapshot - глубокая копия состояния менеджера метаморфоз, которую
+// интерфейс читает без блокировок. Снимок нельзя изменять.
+type StateSnapshot struct {
+	Taken               time.Time
+	TransformationPhase int
+	AnomalyBudget       float64
+	MaxBudget           float64
+	Weather             string
+	Effects             []EffectView
+	LocalAnomalyLevels  map[string]float64
+}
+
+// Clone возвращает глубокую копию эффекта. Колбэки копируются как есть.
+func (me *MetamorphEffect) Clone() *MetamorphEffect {
+	clone := *me
+	clone.AffectedTags = append([]string(nil), me.AffectedTags...)
+	clone.VisualEffects = append([]string(nil), me.VisualEffects...)
+	clone.SoundEffects = append([]string(nil), me.SoundEffects...)
+	clone.RelatedSymbols = append([]string(nil), me.RelatedSymbols...)
+	clone.ownedEntities = append([]ecs.EntityID(nil), me.ownedEntities...)
+	if me.savedSounds != nil {
+		clone.savedSounds = make(map[ecs.EntityID]savedSound, len(me.savedSounds))
+		for id, saved := range me.savedSounds {
+			clone.savedSounds[id] = saved
+		}
+	}
+	clone.ComponentChanges = copyFloatMap(me.ComponentChanges)
+	clone.WorldChanges = copyFloatMap(me.WorldChanges)
+	if me.AffectedArea != nil {
+		area := *me.AffectedArea
+		area.Points = append([]ecs.Vector3(nil), me.AffectedArea.Points...)
+		clone.AffectedArea = &area
+	}
+	return &clone
+}
+
+// copyFloatMap копирует карту значений (nil остается nil)
+func copyFloatMap(source map[string]float64) map[string]float64 {
+	if source == nil {
+		return nil
+	}
+	result := make(map[string]float64, len(source))
+	for key, value := range source {
+		result[key] = value
+	}
+	return result
+}
+
+// Snapshot возвращает последний снимок состояния без захвата мьютекса менеджера.
+// Снимок обновляется раз в кадр; до первого обновления возвращается nil.
+func (mm *MetamorphosisManager) Snapshot() *StateSnapshot {
+	return mm.snapshot.Load()
+}
+
+// publishSnapshot строит и публикует снимок состояния.
+// Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) publishSnapshot() {
+	now := mm.clock.Now()
+
+	snapshot := &StateSnapshot{
+		Taken:               now,
+		TransformationPhase: mm.transformationPhase,
+		AnomalyBudget:       mm.anomalyBudget,
+		MaxBudget:           mm.maxBudget,
+		Weather:             mm.worldState.Weather,
+		Effects:             make([]EffectView, 0, len(mm.activeEffects)),
+		LocalAnomalyLevels:  copyFloatMap(mm.worldState.LocalAnomalyLevels),
+	}
+
+	for _, effect := range mm.activeEffects {
+		view := EffectView{
+			ID:           effect.ID,
+			Name:         effect.Name,
+			Description:  effect.Description,
+			Order:        effect.Order,
+			Category:     effect.Category,
+			Intensity:    effect.Intensity,
+			AffectedTags: append([]string(nil), effect.AffectedTags...),
+		}
+		if effect.Duration > 0 {
+			view.Remaining = effect.Duration - now.Sub(effect.AppliedTime)
+			if view.Remaining < 0 {
+				view.Remaining = 0
+			}
+		}
+		if effect.AffectedArea != nil {
+			view.HasArea = true
+			view.AreaCenter = effect.AffectedArea.Center
+			view.AreaRadius = effect.AffectedArea.Radius
+		}
+		snapshot.Effects = append(snapshot.Effects, view)
+	}
+
+	mm.snapshot.Store(snapshot)
+}
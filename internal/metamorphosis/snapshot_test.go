@@ -0,0 +1,157 @@
+package metamorphosis
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// applyAreaEffects применяет count зрительных эффектов с областью, как в насыщенной игре
+func applyAreaEffects(mm *MetamorphosisManager, count int) {
+	setTestBudget(mm, 100000)
+	for i := 0; i < count; i++ {
+		applyTestEffect(mm, &MetamorphEffect{
+			ID:           fmt.Sprintf("shimmer_%d", i),
+			Name:         "Shimmer",
+			Order:        OrderFirst,
+			Category:     "visual",
+			Intensity:    0.5,
+			AffectedTags: []string{"tree"},
+			AffectedArea: &AffectedArea{Center: ecs.Vector3{X: float64(i * 10)}, Radius: 20},
+		})
+	}
+}
+
+func TestActiveEffectCopiesAreReusedUntilEffectsChange(t *testing.T) {
+	mm, _ := newTestManager(t)
+	applyAreaEffects(mm, 3)
+
+	first := mm.GetActiveEffects()
+	second := mm.GetActiveEffects()
+	if len(first) != 3 || len(second) != 3 {
+		t.Fatalf("got %d and %d active effects, want 3", len(first), len(second))
+	}
+	copies := make(map[*MetamorphEffect]bool)
+	for _, effect := range first {
+		copies[effect] = true
+	}
+	for _, effect := range second {
+		if !copies[effect] {
+			t.Fatalf("effect %s was cloned again although nothing changed", effect.ID)
+		}
+	}
+	for _, effect := range first {
+		if effect == mm.activeEffects[effect.ID] {
+			t.Fatalf("GetActiveEffects returned the manager's own effect %s", effect.ID)
+		}
+	}
+
+	// Перестановка результата не задевает других читателей
+	first[0], first[1] = first[1], first[0]
+	if again := mm.GetActiveEffects(); again[0] != second[0] {
+		t.Errorf("reordering one caller's slice changed another's")
+	}
+
+	intensity := 0.9
+	if err := mm.MutateEffect("shimmer_1", EffectPatch{Intensity: &intensity}); err != nil {
+		t.Fatalf("MutateEffect: %v", err)
+	}
+	for _, effect := range mm.GetActiveEffects() {
+		if copies[effect] {
+			t.Errorf("effect %s copy was reused after a mutation", effect.ID)
+		}
+		if effect.ID == "shimmer_1" && effect.Intensity != intensity {
+			t.Errorf("mutated effect has intensity %.1f, want %.1f", effect.Intensity, intensity)
+		}
+	}
+
+	mm.mutex.Lock()
+	mm.removeMetamorphEffect("shimmer_0")
+	mm.mutex.Unlock()
+	if effects := mm.GetActiveEffects(); len(effects) != 2 {
+		t.Errorf("got %d active effects after a removal, want 2", len(effects))
+	}
+}
+
+// Запускать с -race: интерфейс читает снимок и эффекты, пока игра их меняет
+func TestSnapshotReadsRaceWithGameplay(t *testing.T) {
+	mm, world := newTestManager(t)
+	for i := 0; i < 20; i++ {
+		addMetamorphicEntity(world, 0, ecs.NewTransformComponent(ecs.Vector3{X: float64(i)}))
+	}
+	applyAreaEffects(mm, 5)
+	mm.Update(0.1)
+
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	for r := 0; r < 2; r++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if snapshot := mm.Snapshot(); snapshot != nil {
+					for _, view := range snapshot.Effects {
+						_ = view.Intensity + float64(len(view.AffectedTags))
+					}
+				}
+				for _, effect := range mm.GetActiveEffects() {
+					_ = effect.Intensity + float64(len(effect.AffectedTags))
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 200; i++ {
+		intensity := float64(i%10) / 10
+		if err := mm.MutateEffect(fmt.Sprintf("shimmer_%d", i%5), EffectPatch{Intensity: &intensity}); err != nil {
+			t.Errorf("MutateEffect: %v", err)
+		}
+		mm.Update(0.1)
+	}
+	close(done)
+	readers.Wait()
+
+	if snapshot := mm.Snapshot(); snapshot == nil || len(snapshot.Effects) != 5 {
+		t.Errorf("final snapshot %+v, want 5 effects", snapshot)
+	}
+}
+
+// snapshotBudget - сколько может стоить снимок на кадр при 60 кадрах в секунду
+const snapshotBudget = 1000000 // нс
+
+func BenchmarkPublishSnapshot(b *testing.B) {
+	mm, _ := newTestManager(b)
+	applyAreaEffects(mm, 200)
+	for i := 0; i < 500; i++ {
+		mm.worldState.LocalAnomalyLevels[fmt.Sprintf("%d_%d", i/25, i%25)] = 0.5
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mm.mutex.Lock()
+		mm.publishSnapshot()
+		mm.mutex.Unlock()
+	}
+	if perOp := b.Elapsed().Nanoseconds() / int64(b.N); perOp > snapshotBudget {
+		b.Errorf("snapshot took %d ns, over the %d ns budget", perOp, snapshotBudget)
+	}
+}
+
+func BenchmarkGetActiveEffects(b *testing.B) {
+	mm, _ := newTestManager(b)
+	applyAreaEffects(mm, 200)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mm.GetActiveEffects()
+	}
+}
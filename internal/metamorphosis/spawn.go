@@ -0,0 +1,112 @@
+package metamorphosis
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Порождение сущностей эффектами: изменение правил мира "ai.spawn.<тип>"
+// порождает столько сущностей типа, сколько указано в значении
+const (
+	SpawnRulePrefix     = "ai.spawn."
+	MaxSpawnPerRule     = 5    // Наибольшее число сущностей одного типа от одного эффекта
+	SpawnNearPlayerDist = 12.0 // Эффекты без области порождают сущности на таком расстоянии от игрока
+)
+
+// EntitySpawner создает в мире сущности, порожденные эффектами (например, world.World)
+type EntitySpawner interface {
+	SpawnEffectEntity(entityType string, position ecs.Vector3) *ecs.Entity
+}
+
+// SetEntitySpawner задает, кто создает сущности эффектов. Эффекты, примененные
+// раньше (в том числе восстановленные из сохранения), порождают свои сущности
+// на ближайшем обновлении.
+func (mm *MetamorphosisManager) SetEntitySpawner(spawner EntitySpawner) {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.entitySpawner = spawner
+}
+
+// spawnEffectEntities порождает сущности по правилам "ai.spawn.*" эффекта в его
+// области, а у эффекта без области - вокруг игрока. Сущности запоминаются в
+// ownedEntities и удаляются вместе с эффектом. Без EntitySpawner порождение
+// откладывается. Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) spawnEffectEntities(effect *MetamorphEffect) {
+	rules := spawnRules(effect)
+	if len(rules) == 0 {
+		return
+	}
+	if mm.entitySpawner == nil {
+		effect.spawnPending = true
+		return
+	}
+	effect.spawnPending = false
+
+	hash := fnv.New64a()
+	hash.Write([]byte(effect.ID))
+	r := rand.New(rand.NewSource(int64(hash.Sum64())))
+
+	for _, rule := range rules {
+		entityType := strings.TrimPrefix(rule, SpawnRulePrefix)
+		count := int(math.Min(MaxSpawnPerRule, math.Round(effect.WorldChanges[rule])))
+		for i := 0; i < count; i++ {
+			entity := mm.entitySpawner.SpawnEffectEntity(entityType, mm.spawnPosition(effect, r))
+			if entity == nil {
+				continue
+			}
+			effect.AddOwnedEntity(entity.ID)
+			mm.recordHistoryEntry(effect.ID, "spawned", entity.ID, fmt.Sprintf("Spawned %s for effect %s", entityType, effect.Name))
+		}
+	}
+}
+
+// spawnPendingEntities порождает сущности эффектов, отложенные до появления
+// EntitySpawner. Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) spawnPendingEntities() {
+	if mm.entitySpawner == nil {
+		return
+	}
+	for _, effect := range mm.activeEffects {
+		if effect.spawnPending {
+			mm.spawnEffectEntities(effect)
+		}
+	}
+}
+
+// spawnPosition выбирает точку для порожденной сущности: в пределах области
+// эффекта или, если области нет, на расстоянии SpawnNearPlayerDist от игрока
+func (mm *MetamorphosisManager) spawnPosition(effect *MetamorphEffect, r *rand.Rand) ecs.Vector3 {
+	angle := r.Float64() * 2 * math.Pi
+
+	center := mm.worldState.PlayerPosition
+	distance := SpawnNearPlayerDist
+	if effect.AffectedArea != nil {
+		center = effect.AffectedArea.Center
+		distance = effect.AffectedArea.Radius * math.Sqrt(r.Float64())
+	}
+
+	return ecs.Vector3{
+		X: center.X + math.Cos(angle)*distance,
+		Y: center.Y,
+		Z: center.Z + math.Sin(angle)*distance,
+	}
+}
+
+// spawnRules возвращает правила порождения эффекта в стабильном порядке
+func spawnRules(effect *MetamorphEffect) []string {
+	rules := make([]string, 0)
+	for rule, value := range effect.WorldChanges {
+		if strings.HasPrefix(rule, SpawnRulePrefix) && value >= 0.5 {
+			rules = append(rules, rule)
+		}
+	}
+	sort.Strings(rules)
+	return rules
+}
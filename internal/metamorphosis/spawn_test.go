@@ -0,0 +1,120 @@
+package metamorphosis
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// fakeSpawner создает сущности прямо в мире ECS и запоминает запросы.
+// Типы из refuse не порождаются, как у мира вне загруженных чанков.
+type fakeSpawner struct {
+	world     *ecs.World
+	refuse    map[string]bool
+	types     []string
+	positions []ecs.Vector3
+}
+
+func (fs *fakeSpawner) SpawnEffectEntity(entityType string, position ecs.Vector3) *ecs.Entity {
+	fs.types = append(fs.types, entityType)
+	fs.positions = append(fs.positions, position)
+	if fs.refuse[entityType] {
+		return nil
+	}
+
+	entity := ecs.NewEntity()
+	entity.AddComponent(ecs.NewTransformComponent(position))
+	entity.AddTag(ecs.RegisterTag(entityType, "fauna", ""))
+	fs.world.AddEntity(entity)
+	return entity
+}
+
+// newSpawnEffect создает эффект, порождающий сущности в круговой области
+func newSpawnEffect(id string, changes map[string]float64) *MetamorphEffect {
+	return &MetamorphEffect{
+		ID:           id,
+		Name:         "Summoning",
+		Order:        OrderFirst,
+		Category:     "ai",
+		Intensity:    1.0,
+		WorldChanges: changes,
+		AffectedArea: &AffectedArea{Type: "sphere", Center: ecs.Vector3{X: 40, Z: -20}, Radius: 8},
+	}
+}
+
+func TestSpawnWaitsForSpawnerAndStaysInArea(t *testing.T) {
+	mm, world := newTestManager(t)
+	setTestBudget(mm, 1000)
+
+	effect := newSpawnEffect("summon_1", map[string]float64{SpawnRulePrefix + "shadow": 2})
+	applyTestEffect(mm, effect)
+	if len(effect.OwnedEntities()) != 0 {
+		t.Fatalf("effect spawned %d entities without a spawner", len(effect.OwnedEntities()))
+	}
+
+	spawner := &fakeSpawner{world: world}
+	mm.SetEntitySpawner(spawner)
+	mm.Update(0.1)
+
+	if len(spawner.types) != 2 {
+		t.Fatalf("spawner called %d times, want 2", len(spawner.types))
+	}
+	if len(effect.OwnedEntities()) != 2 {
+		t.Fatalf("effect owns %d entities, want 2", len(effect.OwnedEntities()))
+	}
+	for _, position := range spawner.positions {
+		if distance := position.Distance(effect.AffectedArea.Center); distance > effect.AffectedArea.Radius {
+			t.Errorf("spawn at %v is %.2f from the area center, radius %.2f", position, distance, effect.AffectedArea.Radius)
+		}
+	}
+
+	// Повторные обновления не порождают сущности снова
+	mm.Update(0.1)
+	if len(spawner.types) != 2 {
+		t.Errorf("spawner called %d times after a second update, want 2", len(spawner.types))
+	}
+}
+
+func TestSpawnedEntitiesAreRemovedWithEffect(t *testing.T) {
+	mm, world := newTestManager(t)
+	setTestBudget(mm, 1000)
+	mm.SetEntitySpawner(&fakeSpawner{world: world})
+
+	effect := newSpawnEffect("summon_2", map[string]float64{SpawnRulePrefix + "wraith": 3})
+	applyTestEffect(mm, effect)
+	owned := append([]ecs.EntityID(nil), effect.OwnedEntities()...)
+	if len(owned) != 3 {
+		t.Fatalf("effect owns %d entities, want 3", len(owned))
+	}
+
+	mm.mutex.Lock()
+	mm.removeMetamorphEffect(effect.ID)
+	mm.mutex.Unlock()
+
+	for _, id := range owned {
+		if _, exists := world.GetEntity(id); exists {
+			t.Errorf("entity %s outlived its effect", id)
+		}
+	}
+}
+
+func TestSpawnRefusedBySpawnerIsNotOwned(t *testing.T) {
+	mm, world := newTestManager(t)
+	setTestBudget(mm, 1000)
+	spawner := &fakeSpawner{world: world, refuse: map[string]bool{"nightmare": true}}
+	mm.SetEntitySpawner(spawner)
+
+	effect := newSpawnEffect("summon_3", map[string]float64{
+		SpawnRulePrefix + "nightmare": 1,
+		SpawnRulePrefix + "shadow":    1,
+		SpawnRulePrefix + "minor":     0.2, // Меньше половины - правило не срабатывает
+	})
+	applyTestEffect(mm, effect)
+
+	if len(spawner.types) != 2 {
+		t.Fatalf("spawner called for %v, want nightmare and shadow only", spawner.types)
+	}
+	if len(effect.OwnedEntities()) != 1 {
+		t.Errorf("effect owns %d entities, want 1 (nightmare refused)", len(effect.OwnedEntities()))
+	}
+}
@@ -0,0 +1,62 @@
+//go:build dev
+
+package metamorphosis
+
+import (
+	"fmt"
+	"math/rand"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// syntheticExtent - сторона квадрата, в котором расставляются синтетические сущности
+const syntheticExtent = 256.0
+
+// PopulateSynthetic заполняет менеджер синтетическим состоянием для профилирования Update:
+// entityCount метаморфичных сущностей и effectCount активных эффектов со сферическими
+// областями. Эффекты добавляются в обход бюджета аномалий (только в dev-сборках).
+func (mm *MetamorphosisManager) PopulateSynthetic(entityCount, effectCount int, seed int64) error {
+	rng := rand.New(rand.NewSource(seed))
+
+	// Растительность регистрирует мир; менеджер может заполняться и без него
+	ecs.RegisterTag(TagPlant, "environment", "")
+	entities, err := ecs.PopulateSynthetic(mm.world, entityCount, syntheticExtent, seed, TagPlant)
+	if err != nil {
+		return fmt.Errorf("failed to populate synthetic entities: %v", err)
+	}
+	for _, entity := range entities {
+		entity.AddComponent(ecs.NewMetamorphicComponent(rng.Float64() * 0.8))
+	}
+
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	categories := []string{"visual", "environment", "physics", "entity"}
+	for i := 0; i < effectCount; i++ {
+		effect := &MetamorphEffect{
+			ID:           fmt.Sprintf("synthetic_%d", i),
+			Name:         fmt.Sprintf("Synthetic effect %d", i),
+			Order:        OrderFirst,
+			Category:     categories[i%len(categories)],
+			AppliedTime:  mm.clock.Now(),
+			Intensity:    0.2 + rng.Float64()*0.6,
+			AffectedTags: []string{TagPlant},
+			AffectedArea: &AffectedArea{
+				Type: "sphere",
+				Center: ecs.Vector3{
+					X: (rng.Float64() - 0.5) * syntheticExtent,
+					Z: (rng.Float64() - 0.5) * syntheticExtent,
+				},
+				Radius:     16 + rng.Float64()*32,
+				Falloff:    "linear",
+				FalloffMax: 48,
+			},
+			ComponentChanges: map[string]float64{"color_shift": 0.1},
+		}
+		mm.setupEffectCallbacks(effect)
+		mm.activeEffects[effect.ID] = effect
+	}
+	mm.effectCopies.Store(nil)
+
+	return nil
+}
@@ -10,9 +10,12 @@ import (
 	"path/filepath"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"echo-taiga/internal/engine"
 	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/savefile"
 )
 
 // OrderLevel определяет уровень (порядок) метаморфозы
@@ -41,6 +44,14 @@ type MetamorphEffect struct {
 	VisualEffects    []string           // Визуальные эффекты
 	SoundEffects     []string           // Звуковые эффекты
 	RelatedSymbols   []string           // Связанные символы
+	Variation        *EffectVariation   // Разброс параметров экземпляров (nil - точная копия шаблона)
+
+	ownedEntities []ecs.EntityID              // Сущности, порожденные эффектом (удаляются вместе с ним)
+	spawnPending  bool                        // Сущности эффекта ждут появления EntitySpawner (см. spawnEffectEntities)
+	faults        int                         // Сбои колбэков подряд (см. MaxEffectFaults)
+	faulted       bool                        // Эффект снят из-за сбоев колбэков
+	lastFault     effectFault                 // Последний сбой колбэка (попадает в историю при снятии)
+	savedSounds   map[ecs.EntityID]savedSound // Звук сущностей до звукового эффекта (см. setupAudioCallbacks)
 
 	// Функции, выполняемые при применении/удалении эффекта
 	OnApply  func(world *ecs.World, entity *ecs.Entity) error
@@ -48,6 +59,27 @@ type MetamorphEffect struct {
 	OnUpdate func(world *ecs.World, entity *ecs.Entity, deltaTime float64) error
 }
 
+// AddOwnedEntity запоминает сущность, порожденную эффектом, чтобы удалить ее по окончании эффекта
+func (me *MetamorphEffect) AddOwnedEntity(id ecs.EntityID) {
+	me.ownedEntities = append(me.ownedEntities, id)
+}
+
+// OwnedEntities возвращает сущности, порожденные эффектом
+func (me *MetamorphEffect) OwnedEntities() []ecs.EntityID {
+	return me.ownedEntities
+}
+
+// resetInstanceState сбрасывает состояние, скопированное из шаблона вместе с
+// эффектом: у каждого экземпляра свои порожденные сущности и свой сохраненный
+// звук, а звуковые колбэки привязываются к самому экземпляру
+func (me *MetamorphEffect) resetInstanceState() {
+	me.ownedEntities = nil
+	me.savedSounds = nil
+	if me.Category == "audio" {
+		setupAudioCallbacks(me)
+	}
+}
+
 // AffectedArea определяет область воздействия метаморфозы
 type AffectedArea struct {
 	Type       string        // sphere, box, cylinder, path
@@ -64,6 +96,7 @@ type AffectedArea struct {
 // MetamorphTrigger определяет условие активации метаморфозы
 type MetamorphTrigger struct {
 	ID               string                 // Уникальный идентификатор
+	TemplateID       string                 // ID шаблона, из которого создан триггер
 	Type             string                 // time, location, action, event, ritual, threshold
 	Priority         float64                // Приоритет триггера (0-1)
 	RequiredTags     []string               // Требуемые теги для активации
@@ -121,6 +154,11 @@ type MetamorphosisManager struct {
 	effectTemplates   map[string]*MetamorphEffect
 	triggerTemplates  map[string]*MetamorphTrigger
 
+	// Пополнение триггеров: паузы шаблонов после срабатывания (в секундах)
+	triggerConfig      TriggerConfig
+	templateCooldowns  map[string]float64
+	triggerRefillTimer float64
+
 	// Бюджет аномалий (ограничивает количество одновременных эффектов)
 	anomalyBudget    float64
 	maxBudget        float64
@@ -146,14 +184,60 @@ type MetamorphosisManager struct {
 
 	// Путь для сохранения/загрузки состояния
 	savePath string
+
+	// Результаты загрузки шаблонов
+	loadReport *engine.LoadReport
+
+	// Снимок состояния для интерфейса (читается без блокировок)
+	snapshot atomic.Pointer[StateSnapshot]
+
+	// Копии активных эффектов, общие для всех читателей до следующего изменения эффектов
+	effectCopies atomic.Pointer[[]*MetamorphEffect]
+
+	// Наблюдение эффектов игроком
+	lineOfSight     LineOfSight
+	witnessDistance float64
+	witnessQueue    []*MetamorphEffect // Примененные эффекты, ждущие проверки наблюдения (см. flushWitnessed)
+
+	// Создает сущности, порожденные эффектами (см. SpawnRulePrefix)
+	entitySpawner EntitySpawner
+
+	// Обработчики изменений. Вызываются при захваченном мьютексе менеджера,
+	// поэтому не должны обращаться к менеджеру.
+	OnEffectsChanged      func(effect *MetamorphEffect)
+	OnEffectRemoved       func(effect *MetamorphEffect)
+	OnAnomalyLevelChanged func(areaID string, level float64)
+	OnPhaseChanged        func(oldPhase, newPhase int)
+	OnPlayerDeath         func(cycles int)
+	OnAnchorCracked       func(anchorID ecs.EntityID, position ecs.Vector3)
+
+	// Вызывается, когда игрок видит применение эффекта порядка MinWitnessOrder и выше.
+	// Обработчик может заполнить RelatedSymbols эффекта.
+	OnEffectWitnessed func(effect *MetamorphEffect)
+
+	// Идет промотка времени отсутствия игрока (см. FastForward)
+	fastForwarding bool
+
+	// Источник текущего времени (см. SetClock)
+	clock engine.Clock
+
+	// Собственный поток случайных чисел (см. SetSeed); используется под мьютексом
+	rng *rand.Rand
 }
 
+// Ограничения истории изменений: по числу записей и по их возрасту
+const (
+	MaxHistoryEntries = 1000
+	MaxHistoryAge     = 7 * 24 * time.Hour
+)
+
 // HistoryEntry представляет запись в истории изменений
 type HistoryEntry struct {
 	Timestamp   time.Time
 	EffectID    string
-	Action      string // applied, removed
+	Action      string // applied, removed, requested, offline, blocked, anchor_cracked
 	EntityID    ecs.EntityID
+	GroupID     string // Группа, к которой эффект применен целиком
 	Description string
 }
 
@@ -165,6 +249,8 @@ func NewMetamorphosisManager(world *ecs.World, savePath string) *MetamorphosisMa
 		availableTriggers:   make(map[string]*MetamorphTrigger),
 		effectTemplates:     make(map[string]*MetamorphEffect),
 		triggerTemplates:    make(map[string]*MetamorphTrigger),
+		triggerConfig:       DefaultTriggerConfig(),
+		templateCooldowns:   make(map[string]float64),
 		anomalyBudget:       100.0,
 		maxBudget:           100.0,
 		regenerationRate:    0.5, // Единиц в минуту
@@ -178,7 +264,11 @@ func NewMetamorphosisManager(world *ecs.World, savePath string) *MetamorphosisMa
 			OrderFifth:  0.9,  // Требуется 90% прогресса
 		},
 		effectDependencies: make(map[string][]string),
+		witnessDistance:    DefaultWitnessDistance,
 		savePath:           savePath,
+		clock:              engine.RealClock{},
+		rng:                engine.NewRandStream(time.Now().UnixNano(), RandStream),
+		loadReport:         &engine.LoadReport{},
 		worldState: &WorldState{
 			TimeOfDay:           0.25, // Начинаем с рассвета
 			TransformationPhase: 1,
@@ -198,31 +288,64 @@ func NewMetamorphosisManager(world *ecs.World, savePath string) *MetamorphosisMa
 	return manager
 }
 
+// SetClock задает источник времени для длительности эффектов, истории и
+// условий триггеров
+func (mm *MetamorphosisManager) SetClock(clock engine.Clock) {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.clock = clock
+}
+
+// RandStream - имя потока случайных чисел менеджера метаморфоз (см. engine.NewRandStream)
+const RandStream = "metamorphosis"
+
+// SetSeed выводит поток случайных чисел менеджера из сида мира, чтобы выбор
+// эффектов, триггеров и мутаций воспроизводился при одном и том же сиде
+func (mm *MetamorphosisManager) SetSeed(seed int64) {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.rng = engine.NewRandStream(seed, RandStream)
+}
+
 // Init инициализирует менеджер метаморфоз
 func (mm *MetamorphosisManager) Init() error {
+	return mm.InitWithProgress(engine.NopProgress{})
+}
+
+// InitWithProgress инициализирует менеджер, сообщая о ходе загрузки шаблонов
+func (mm *MetamorphosisManager) InitWithProgress(progress engine.ProgressReporter) error {
 	// Загружаем шаблоны эффектов и триггеров
-	err := mm.LoadEffectTemplates(filepath.Join(mm.savePath, "effect_templates"))
+	err := mm.loadEffectTemplates(filepath.Join(mm.savePath, "effect_templates"), engine.SubProgress(progress, 0, 0.45))
 	if err != nil {
 		return fmt.Errorf("failed to load effect templates: %v", err)
 	}
 
-	err = mm.LoadTriggerTemplates(filepath.Join(mm.savePath, "trigger_templates"))
+	err = mm.loadTriggerTemplates(filepath.Join(mm.savePath, "trigger_templates"), engine.SubProgress(progress, 0.45, 0.9))
 	if err != nil {
 		return fmt.Errorf("failed to load trigger templates: %v", err)
 	}
 
 	// Пытаемся загрузить текущее состояние, если оно есть
+	progress.ReportProgress("metamorphosis_state", 0.9)
 	err = mm.LoadState()
 	if err != nil {
 		// Если не удалось загрузить, создаем начальное состояние
 		mm.InitializeDefaultState()
 	}
+	progress.ReportProgress("metamorphosis_state", 1)
 
 	return nil
 }
 
 // LoadEffectTemplates загружает шаблоны эффектов из директории
 func (mm *MetamorphosisManager) LoadEffectTemplates(dirPath string) error {
+	return mm.loadEffectTemplates(dirPath, engine.NopProgress{})
+}
+
+// loadEffectTemplates загружает шаблоны эффектов, сообщая о прогрессе по количеству файлов
+func (mm *MetamorphosisManager) loadEffectTemplates(dirPath string, progress engine.ProgressReporter) error {
 	// Проверяем существование директории
 	if _, err := os.Stat(dirPath); os.IsNotExist(err) {
 		// Директория не существует, создаем ее
@@ -241,7 +364,9 @@ func (mm *MetamorphosisManager) LoadEffectTemplates(dirPath string) error {
 		return err
 	}
 
-	for _, file := range files {
+	for i, file := range files {
+		engine.ReportCount(progress, "effect_templates", i, len(files))
+
 		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
 			continue
 		}
@@ -250,18 +375,31 @@ func (mm *MetamorphosisManager) LoadEffectTemplates(dirPath string) error {
 		filePath := filepath.Join(dirPath, file.Name())
 		effectTemplate, err := mm.loadEffectTemplateFromFile(filePath)
 		if err != nil {
-			return err
+			mm.loadReport.AddError(engine.TemplateError{File: filePath, Message: err.Error()})
+			continue
+		}
+
+		// Проверяем обязательные поля
+		if mm.loadReport.AddValidationErrors(filePath, ValidateEffectTemplate(effectTemplate)) {
+			continue
 		}
 
 		// Регистрируем шаблон
 		mm.effectTemplates[effectTemplate.ID] = effectTemplate
+		mm.loadReport.Loaded++
 	}
+	progress.ReportProgress("effect_templates", 1)
 
 	return nil
 }
 
 // LoadTriggerTemplates загружает шаблоны триггеров из директории
 func (mm *MetamorphosisManager) LoadTriggerTemplates(dirPath string) error {
+	return mm.loadTriggerTemplates(dirPath, engine.NopProgress{})
+}
+
+// loadTriggerTemplates загружает шаблоны триггеров, сообщая о прогрессе по количеству файлов
+func (mm *MetamorphosisManager) loadTriggerTemplates(dirPath string, progress engine.ProgressReporter) error {
 	// Проверяем существование директории
 	if _, err := os.Stat(dirPath); os.IsNotExist(err) {
 		// Директория не существует, создаем ее
@@ -280,7 +418,9 @@ func (mm *MetamorphosisManager) LoadTriggerTemplates(dirPath string) error {
 		return err
 	}
 
-	for _, file := range files {
+	for i, file := range files {
+		engine.ReportCount(progress, "trigger_templates", i, len(files))
+
 		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
 			continue
 		}
@@ -289,43 +429,61 @@ func (mm *MetamorphosisManager) LoadTriggerTemplates(dirPath string) error {
 		filePath := filepath.Join(dirPath, file.Name())
 		triggerTemplate, err := mm.loadTriggerTemplateFromFile(filePath)
 		if err != nil {
-			return err
+			mm.loadReport.AddError(engine.TemplateError{File: filePath, Message: err.Error()})
+			continue
+		}
+
+		// Проверяем обязательные поля
+		if mm.loadReport.AddValidationErrors(filePath, ValidateTriggerTemplate(triggerTemplate)) {
+			continue
 		}
 
 		// Регистрируем шаблон
 		mm.triggerTemplates[triggerTemplate.ID] = triggerTemplate
+		mm.loadReport.Loaded++
 	}
+	progress.ReportProgress("trigger_templates", 1)
 
 	return nil
 }
 
-// LoadState загружает текущее состояние менеджера метаморфоз
-func (mm *MetamorphosisManager) LoadState() error {
-	statePath := filepath.Join(mm.savePath, "metamorphosis_state.json")
+// SavedState - сохраненное состояние менеджера метаморфоз
+type SavedState struct {
+	AnomalyBudget       float64                       `json:"anomaly_budget"`
+	MaxBudget           float64                       `json:"max_budget"`
+	RegenerationRate    float64                       `json:"regeneration_rate"`
+	TransformationPhase int                           `json:"transformation_phase"`
+	ActiveEffects       map[string]string             `json:"active_effects"` // ID -> Template ID
+	EffectRecords       map[string]ActiveEffectRecord `json:"effect_records"` // Изменения эффектов во время игры
+	WorldState          *WorldState                   `json:"world_state"`
+}
+
+// ReadSavedState читает сохраненное состояние из каталога сохранений метаморфоз,
+// не создавая менеджера и мира ECS (например, чтобы сравнить два сохранения)
+func ReadSavedState(savePath string) (*SavedState, error) {
+	statePath := filepath.Join(savePath, "metamorphosis_state.json")
 
 	// Проверяем существование файла
-	if _, err := os.Stat(statePath); os.IsNotExist(err) {
-		return fmt.Errorf("state file does not exist")
+	if !savefile.Exists(statePath) {
+		return nil, fmt.Errorf("state file does not exist")
 	}
 
-	// Загружаем файл
-	data, err := ioutil.ReadFile(statePath)
+	// Загружаем файл (при повреждении - резервную копию)
+	data, err := savefile.Read(statePath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Временная структура для хранения серилизуемых данных
-	type SerializedState struct {
-		AnomalyBudget       float64           `json:"anomaly_budget"`
-		MaxBudget           float64           `json:"max_budget"`
-		RegenerationRate    float64           `json:"regeneration_rate"`
-		TransformationPhase int               `json:"transformation_phase"`
-		ActiveEffects       map[string]string `json:"active_effects"` // ID -> Template ID
-		WorldState          *WorldState       `json:"world_state"`
+	var state SavedState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
 	}
+	return &state, nil
+}
 
-	var state SerializedState
-	err = json.Unmarshal(data, &state)
+// LoadState загружает текущее состояние менеджера метаморфоз
+func (mm *MetamorphosisManager) LoadState() error {
+	state, err := ReadSavedState(mm.savePath)
 	if err != nil {
 		return err
 	}
@@ -341,6 +499,7 @@ func (mm *MetamorphosisManager) LoadState() error {
 	mm.worldState = state.WorldState
 
 	// Восстанавливаем активные эффекты из шаблонов
+	now := mm.clock.Now()
 	mm.activeEffects = make(map[string]*MetamorphEffect)
 	for id, templateID := range state.ActiveEffects {
 		template, exists := mm.effectTemplates[templateID]
@@ -351,9 +510,21 @@ func (mm *MetamorphosisManager) LoadState() error {
 		// Создаем копию эффекта из шаблона
 		effect := *template
 		effect.ID = id
+		effect.resetInstanceState()
+		effect.AppliedTime = now
+		varyEffect(&effect)
+
+		// Накладываем сохраненные изменения
+		if record, exists := state.EffectRecords[id]; exists {
+			mm.restoreEffectRecord(&effect, record, now)
+		}
+
+		// Порожденные сущности не сохраняются: эффект порождает их заново
+		effect.spawnPending = len(spawnRules(&effect)) > 0
 
 		mm.activeEffects[id] = &effect
 	}
+	mm.effectCopies.Store(nil)
 
 	return nil
 }
@@ -363,39 +534,32 @@ func (mm *MetamorphosisManager) SaveState() error {
 	mm.mutex.RLock()
 	defer mm.mutex.RUnlock()
 
-	// Временная структура для хранения серилизуемых данных
-	type SerializedState struct {
-		AnomalyBudget       float64           `json:"anomaly_budget"`
-		MaxBudget           float64           `json:"max_budget"`
-		RegenerationRate    float64           `json:"regeneration_rate"`
-		TransformationPhase int               `json:"transformation_phase"`
-		ActiveEffects       map[string]string `json:"active_effects"` // ID -> Template ID
-		WorldState          *WorldState       `json:"world_state"`
-	}
-
 	// Создаем серилизуемое представление
-	state := SerializedState{
+	state := SavedState{
 		AnomalyBudget:       mm.anomalyBudget,
 		MaxBudget:           mm.maxBudget,
 		RegenerationRate:    mm.regenerationRate,
 		TransformationPhase: mm.transformationPhase,
 		ActiveEffects:       make(map[string]string),
+		EffectRecords:       make(map[string]ActiveEffectRecord),
 		WorldState:          mm.worldState,
 	}
 
-	// Сохраняем ID шаблонов активных эффектов
+	// Сохраняем ID шаблонов и текущее состояние активных эффектов
+	now := mm.clock.Now()
 	for id, effect := range mm.activeEffects {
 		// Находим шаблон по параметрам эффекта
 		for templateID, template := range mm.effectTemplates {
 			if effect.Name == template.Name && effect.Order == template.Order && effect.Category == template.Category {
 				state.ActiveEffects[id] = templateID
+				state.EffectRecords[id] = mm.newEffectRecord(effect, templateID, now)
 				break
 			}
 		}
 	}
 
 	// Сериализуем данные
-	data, err := json.MarshalIndent(state, "", "  ")
+	data, err := savefile.Marshal(state)
 	if err != nil {
 		return err
 	}
@@ -408,9 +572,9 @@ func (mm *MetamorphosisManager) SaveState() error {
 		}
 	}
 
-	// Сохраняем файл
+	// Сохраняем файл атомарно, с контрольной суммой и резервной копией
 	statePath := filepath.Join(mm.savePath, "metamorphosis_state.json")
-	return ioutil.WriteFile(statePath, data, 0644)
+	return savefile.Write(statePath, data)
 }
 
 // RequiredComponents возвращает компоненты, необходимые для работы системы
@@ -420,6 +584,7 @@ func (mm *MetamorphosisManager) RequiredComponents() []ecs.ComponentID {
 
 // Update обновляет состояние системы метаморфоз
 func (mm *MetamorphosisManager) Update(deltaTime float64) {
+	defer mm.flushWitnessed()
 	mm.mutex.Lock()
 	defer mm.mutex.Unlock()
 
@@ -429,14 +594,27 @@ func (mm *MetamorphosisManager) Update(deltaTime float64) {
 	// Обновляем состояние мира
 	mm.updateWorldState()
 
-	// Проверяем триггеры для новых метаморфоз
+	// Пополняем триггеры и проверяем их для новых метаморфоз
+	mm.regenerateTriggers(deltaTime)
 	mm.checkTriggers()
 
 	// Обновляем активные эффекты
 	mm.updateActiveEffects(deltaTime)
 
+	// Эффекты, восстановленные до появления EntitySpawner, порождают свои сущности
+	mm.spawnPendingEntities()
+
 	// Применяем эффекты к сущностям
 	mm.applyEffectsToEntities(deltaTime)
+
+	// Якоря стабильности расходуют заряд на сдерживание эффектов
+	mm.drainAnchors(deltaTime)
+
+	// Снимаем эффекты, колбэки которых постоянно сбоят
+	mm.removeFaultedEffects()
+
+	// Публикуем снимок состояния для интерфейса
+	mm.publishSnapshot()
 }
 
 // updateAnomalyBudget обновляет бюджет аномалий
@@ -455,25 +633,22 @@ func (mm *MetamorphosisManager) updateWorldState() {
 	// mm.worldState.TimeOfDay = ...
 
 	// Получаем позицию игрока
-	playerEntities := mm.world.GetEntitiesWithTag("player")
+	playerEntities := mm.world.GetEntitiesWithTag(TagPlayer)
 	if len(playerEntities) > 0 {
 		player := playerEntities[0]
 
 		// Получаем позицию
-		if transformComp, has := player.GetComponent(ecs.TransformComponentID); has {
-			transform := transformComp.(*ecs.TransformComponent)
+		if transform, has := ecs.ComponentAs[*ecs.TransformComponent](player, ecs.TransformComponentID); has {
 			mm.worldState.PlayerPosition = transform.Position
 		}
 
 		// Получаем здоровье
-		if healthComp, has := player.GetComponent(ecs.HealthComponentID); has {
-			health := healthComp.(*ecs.HealthComponent)
+		if health, has := ecs.ComponentAs[*ecs.HealthComponent](player, ecs.HealthComponentID); has {
 			mm.worldState.PlayerHealth = health.CurrentHealth / health.MaxHealth
 		}
 
 		// Получаем рассудок
-		if survivalComp, has := player.GetComponent(ecs.SurvivalComponentID); has {
-			survival := survivalComp.(*ecs.SurvivalComponent)
+		if survival, has := ecs.ComponentAs[*ecs.SurvivalComponent](player, ecs.SurvivalComponentID); has {
 			mm.worldState.PlayerSanity = survival.SanityLevel / 100.0
 		}
 	}
@@ -513,13 +688,20 @@ func (mm *MetamorphosisManager) checkTriggers() {
 		// Пытаемся активировать триггер
 		effect := mm.selectEffectForTrigger(trigger)
 		if effect != nil {
+			// Якорь стабильности не дает эффекту возникнуть рядом с собой
+			if site := mm.blockingAnchor(effect); site != nil {
+				mm.blockEffect(effect, site)
+				mm.consumeTrigger(trigger)
+				continue
+			}
+
 			// Проверяем бюджет
 			if mm.canAffordEffect(effect) {
 				// Активируем эффект
 				mm.applyMetamorphEffect(effect)
 
-				// Удаляем триггер из доступных
-				delete(mm.availableTriggers, trigger.ID)
+				// Удаляем триггер из доступных до конца паузы его шаблона
+				mm.consumeTrigger(trigger)
 
 				// После активации одного эффекта прекращаем (чтобы не было слишком много изменений сразу)
 				break
@@ -530,42 +712,46 @@ func (mm *MetamorphosisManager) checkTriggers() {
 
 // updateActiveEffects обновляет активные эффекты
 func (mm *MetamorphosisManager) updateActiveEffects(deltaTime float64) {
-	now := time.Now()
+	// Снимаем эффекты, время которых истекло
+	mm.removeExpiredEffects(mm.clock.Now())
 
 	// Проверяем все активные эффекты
-	for id, effect := range mm.activeEffects {
-		// Если эффект временный и его время истекло
-		if effect.Duration > 0 && now.Sub(effect.AppliedTime) >= effect.Duration {
-			// Удаляем эффект
-			mm.removeMetamorphEffect(id)
-			continue
-		}
-
+	for _, effect := range mm.activeEffects {
 		// Обновляем эффект
 		if effect.OnUpdate != nil {
 			// Получаем все сущности, подходящие для эффекта
 			entities := mm.getEntitiesForEffect(effect)
 
 			for _, entity := range entities {
-				err := effect.OnUpdate(mm.world, entity, deltaTime)
-				if err != nil {
-					// Логгируем ошибку
-					fmt.Printf("Error updating effect %s for entity %s: %v\n", effect.ID, entity.ID, err)
-				}
+				mm.runEffectCallback(effect, entity, "update", func() error {
+					return effect.OnUpdate(mm.world, entity, deltaTime)
+				})
 			}
 		}
 	}
 }
 
+// removeExpiredEffects снимает временные эффекты, время действия которых истекло к now
+func (mm *MetamorphosisManager) removeExpiredEffects(now time.Time) {
+	for id, effect := range mm.activeEffects {
+		if effect.Duration > 0 && now.Sub(effect.AppliedTime) >= effect.Duration {
+			mm.removeMetamorphEffect(id)
+		}
+	}
+}
+
 // applyEffectsToEntities применяет эффекты к сущностям
 func (mm *MetamorphosisManager) applyEffectsToEntities(deltaTime float64) {
 	// Получаем все сущности с компонентом метаморфичности
 	entities := mm.world.GetEntitiesWithComponent(ecs.MetamorphicComponentID)
+	pass := newGroupPass()
 
 	for _, entity := range entities {
-		// Получаем компонент метаморфичности
-		metamorphicComp, _ := entity.GetComponent(ecs.MetamorphicComponentID)
-		metamorphic := metamorphicComp.(*ecs.MetamorphicComponent)
+		// Получаем компонент метаморфичности: он мог пропасть после выборки
+		metamorphic, ok := metamorphicOf(entity)
+		if !ok {
+			continue
+		}
 
 		// Проверяем стабильность сущности
 		if metamorphic.Stability >= 1.0 {
@@ -582,23 +768,8 @@ func (mm *MetamorphosisManager) applyEffectsToEntities(deltaTime float64) {
 			// Если сущность еще не подвержена этому эффекту, применяем его
 			effectID := effect.ID
 			if !containsString(metamorphic.CurrentMetamorphoses, effectID) {
-				// Проверяем, может ли сущность мутировать под влиянием этого эффекта
-				if metamorphic.CanMutate(effect.Intensity) {
-					// Применяем эффект к сущности
-					metamorphic.ApplyMetamorphosis(effectID, effect.Intensity)
-
-					// Вызываем колбэк применения эффекта
-					if effect.OnApply != nil {
-						err := effect.OnApply(mm.world, entity)
-						if err != nil {
-							// Логгируем ошибку
-							fmt.Printf("Error applying effect %s to entity %s: %v\n", effectID, entity.ID, err)
-						}
-					}
-
-					// Добавляем запись в историю
-					mm.recordHistoryEntry(effectID, "applied", entity.ID, fmt.Sprintf("Applied effect %s to entity %s", effect.Name, entity.ID))
-				}
+				// Применяем эффект, если сущность (или вся ее группа) может мутировать
+				mm.mutateEntity(entity, metamorphic, effect, pass)
 			}
 		}
 
@@ -615,16 +786,15 @@ func (mm *MetamorphosisManager) applyEffectsToEntities(deltaTime float64) {
 	}
 }
 
-// ApplyMetamorphEffect применяет новый эффект метаморфозы
+// applyMetamorphEffect применяет новый эффект метаморфозы.
+// Вызывается при захваченном мьютексе.
 func (mm *MetamorphosisManager) applyMetamorphEffect(effect *MetamorphEffect) {
-	mm.mutex.Lock()
-	defer mm.mutex.Unlock()
-
 	// Устанавливаем время применения
-	effect.AppliedTime = time.Now()
+	effect.AppliedTime = mm.clock.Now()
 
 	// Добавляем эффект в активные
 	mm.activeEffects[effect.ID] = effect
+	mm.effectsChanged(effect)
 
 	// Уменьшаем бюджет аномалий
 	mm.anomalyBudget -= getEffectCost(effect)
@@ -634,32 +804,27 @@ func (mm *MetamorphosisManager) applyMetamorphEffect(effect *MetamorphEffect) {
 
 	// Применяем эффект ко всем подходящим сущностям
 	entities := mm.getEntitiesForEffect(effect)
+	pass := newGroupPass()
 	for _, entity := range entities {
-		metamorphicComp, has := entity.GetComponent(ecs.MetamorphicComponentID)
+		metamorphic, has := ecs.ComponentAs[*ecs.MetamorphicComponent](entity, ecs.MetamorphicComponentID)
 		if !has {
 			continue
 		}
 
-		metamorphic := metamorphicComp.(*ecs.MetamorphicComponent)
+		// Член группы мог уже мутировать вместе со своей стаей
+		if containsString(metamorphic.CurrentMetamorphoses, effect.ID) {
+			continue
+		}
 
-		// Проверяем, может ли сущность мутировать
-		if metamorphic.CanMutate(effect.Intensity) {
-			// Применяем эффект
-			metamorphic.ApplyMetamorphosis(effect.ID, effect.Intensity)
+		// Применяем эффект, если сущность (или вся ее группа) может мутировать
+		mm.mutateEntity(entity, metamorphic, effect, pass)
+	}
 
-			// Вызываем колбэк
-			if effect.OnApply != nil {
-				err := effect.OnApply(mm.world, entity)
-				if err != nil {
-					// Логгируем ошибку
-					fmt.Printf("Error applying effect %s to entity %s: %v\n", effect.ID, entity.ID, err)
-				}
-			}
+	// Эффекты с правилами "ai.spawn.*" порождают сущности в своей области
+	mm.spawnEffectEntities(effect)
 
-			// Добавляем запись в историю
-			mm.recordHistoryEntry(effect.ID, "applied", entity.ID, fmt.Sprintf("Applied effect %s to entity %s", effect.Name, entity.ID))
-		}
-	}
+	// Сильные метаморфозы на глазах у игрока открывают связанные символы
+	mm.queueWitness(effect)
 
 	// Проверяем, нужно ли увеличить фазу трансформации
 	mm.checkTransformationPhaseProgress()
@@ -675,8 +840,10 @@ func (mm *MetamorphosisManager) removeMetamorphEffect(effectID string) {
 	// Находим все сущности, подверженные эффекту
 	entities := mm.world.GetEntitiesWithComponent(ecs.MetamorphicComponentID)
 	for _, entity := range entities {
-		metamorphicComp, _ := entity.GetComponent(ecs.MetamorphicComponentID)
-		metamorphic := metamorphicComp.(*ecs.MetamorphicComponent)
+		metamorphic, ok := metamorphicOf(entity)
+		if !ok {
+			continue
+		}
 
 		// Проверяем, подвержена ли сущность этому эффекту
 		if containsString(metamorphic.CurrentMetamorphoses, effectID) {
@@ -685,11 +852,9 @@ func (mm *MetamorphosisManager) removeMetamorphEffect(effectID string) {
 
 			// Вызываем колбэк удаления эффекта
 			if effect.OnRemove != nil {
-				err := effect.OnRemove(mm.world, entity)
-				if err != nil {
-					// Логгируем ошибку
-					fmt.Printf("Error removing effect %s from entity %s: %v\n", effectID, entity.ID, err)
-				}
+				mm.runEffectCallback(effect, entity, "remove", func() error {
+					return effect.OnRemove(mm.world, entity)
+				})
 			}
 
 			// Добавляем запись в историю
@@ -697,30 +862,62 @@ func (mm *MetamorphosisManager) removeMetamorphEffect(effectID string) {
 		}
 	}
 
+	// Удаляем порожденные эффектом сущности
+	for _, entityID := range effect.ownedEntities {
+		mm.world.RemoveEntity(entityID)
+		mm.recordHistoryEntry(effectID, "despawned", entityID, fmt.Sprintf("Despawned entity %s of effect %s", entityID, effect.Name))
+	}
+	effect.ownedEntities = nil
+
 	// Удаляем эффект из списка активных
 	delete(mm.activeEffects, effectID)
+	mm.effectsChanged(effect)
+	if mm.OnEffectRemoved != nil {
+		mm.OnEffectRemoved(effect)
+	}
 
-	// Возвращаем часть бюджета аномалий
-	mm.anomalyBudget = math.Min(mm.maxBudget, mm.anomalyBudget+getEffectCost(effect)*0.5)
+	// Возвращаем часть бюджета аномалий; эффект, снятый из-за сбоев, возвращает
+	// свою стоимость целиком
+	refund := getEffectCost(effect) * 0.5
+	if effect.faulted {
+		refund = getEffectCost(effect)
+	}
+	mm.anomalyBudget = math.Min(mm.maxBudget, mm.anomalyBudget+refund)
 
 	// Добавляем запись в историю
 	mm.recordHistoryEntry(effectID, "removed", "", fmt.Sprintf("Removed effect: %s", effect.Name))
 }
 
-// GetActiveEffects возвращает список активных эффектов
+// GetActiveEffects возвращает копии активных эффектов. Копии создаются заново
+// только после изменения эффектов и до тех пор общие для всех вызывающих, поэтому
+// их нельзя изменять; для изменений используйте MutateEffect.
 func (mm *MetamorphosisManager) GetActiveEffects() []*MetamorphEffect {
+	if copies := mm.effectCopies.Load(); copies != nil {
+		return append([]*MetamorphEffect(nil), (*copies)...)
+	}
+
 	mm.mutex.RLock()
 	defer mm.mutex.RUnlock()
 
 	effects := make([]*MetamorphEffect, 0, len(mm.activeEffects))
 	for _, effect := range mm.activeEffects {
-		effects = append(effects, effect)
+		effects = append(effects, effect.Clone())
 	}
+	mm.effectCopies.Store(&effects)
 
-	return effects
+	return append([]*MetamorphEffect(nil), effects...)
 }
 
-// GetEffectsByOrder возвращает список активных эффектов указанного порядка
+// effectsChanged сбрасывает общие копии эффектов и сообщает об изменении эффекта.
+// Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) effectsChanged(effect *MetamorphEffect) {
+	mm.effectCopies.Store(nil)
+	if mm.OnEffectsChanged != nil {
+		mm.OnEffectsChanged(effect)
+	}
+}
+
+// GetEffectsByOrder возвращает копии активных эффектов указанного порядка
 func (mm *MetamorphosisManager) GetEffectsByOrder(order OrderLevel) []*MetamorphEffect {
 	mm.mutex.RLock()
 	defer mm.mutex.RUnlock()
@@ -728,20 +925,23 @@ func (mm *MetamorphosisManager) GetEffectsByOrder(order OrderLevel) []*Metamorph
 	effects := make([]*MetamorphEffect, 0)
 	for _, effect := range mm.activeEffects {
 		if effect.Order == order {
-			effects = append(effects, effect)
+			effects = append(effects, effect.Clone())
 		}
 	}
 
 	return effects
 }
 
-// GetEffect возвращает эффект по ID
+// GetEffect возвращает копию активного эффекта по ID
 func (mm *MetamorphosisManager) GetEffect(effectID string) (*MetamorphEffect, bool) {
 	mm.mutex.RLock()
 	defer mm.mutex.RUnlock()
 
 	effect, exists := mm.activeEffects[effectID]
-	return effect, exists
+	if !exists {
+		return nil, false
+	}
+	return effect.Clone(), true
 }
 
 // AddPlayerAction добавляет действие игрока для анализа
@@ -798,11 +998,34 @@ func (mm *MetamorphosisManager) SetLocalAnomalyLevel(areaID string, level float6
 	defer mm.mutex.Unlock()
 
 	mm.worldState.LocalAnomalyLevels[areaID] = level
+	if mm.OnAnomalyLevelChanged != nil {
+		mm.OnAnomalyLevelChanged(areaID, level)
+	}
 
 	// Обновляем общий уровень аномальности
 	mm.updateGlobalAnomalyLevel()
 }
 
+// GetLocalAnomalyLevels возвращает копию уровней аномальности по областям
+func (mm *MetamorphosisManager) GetLocalAnomalyLevels() map[string]float64 {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	levels := make(map[string]float64, len(mm.worldState.LocalAnomalyLevels))
+	for areaID, level := range mm.worldState.LocalAnomalyLevels {
+		levels[areaID] = level
+	}
+	return levels
+}
+
+// GetLocalAnomalyLevel возвращает уровень аномальности для области
+func (mm *MetamorphosisManager) GetLocalAnomalyLevel(areaID string) float64 {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	return mm.worldState.LocalAnomalyLevels[areaID]
+}
+
 // SetWeather устанавливает текущую погоду
 func (mm *MetamorphosisManager) SetWeather(weather string) {
 	mm.mutex.Lock()
@@ -816,9 +1039,13 @@ func (mm *MetamorphosisManager) RecordPlayerDeath() {
 	mm.mutex.Lock()
 	defer mm.mutex.Unlock()
 
-	mm.worldState.LastPlayerDeath = time.Now()
+	mm.worldState.LastPlayerDeath = mm.clock.Now()
 	mm.worldState.Cycles++
 
+	if mm.OnPlayerDeath != nil {
+		mm.OnPlayerDeath(mm.worldState.Cycles)
+	}
+
 	// Увеличиваем бюджет аномалий при перерождении
 	mm.maxBudget += 25.0
 	mm.anomalyBudget = mm.maxBudget
@@ -864,9 +1091,14 @@ func (mm *MetamorphosisManager) SetTransformationPhase(phase int) {
 	mm.mutex.Lock()
 	defer mm.mutex.Unlock()
 
+	oldPhase := mm.transformationPhase
 	mm.transformationPhase = phase
 	mm.worldState.TransformationPhase = phase
 
+	if phase != oldPhase && mm.OnPhaseChanged != nil {
+		mm.OnPhaseChanged(oldPhase, phase)
+	}
+
 	// Обновляем ограничения метаморфоз в зависимости от фазы
 	if phase >= 2 {
 		mm.orderThresholds[OrderSecond] = 0.0 // Сразу доступны
@@ -895,12 +1127,42 @@ func (mm *MetamorphosisManager) CreateEffectFromTemplate(templateID string) (*Me
 	// Генерируем уникальный ID
 	effect.ID = fmt.Sprintf("%s_%s", templateID, generateUUID())
 
-	// Сбрасываем время применения
+	// Сбрасываем время применения и порожденные сущности
 	effect.AppliedTime = time.Time{}
+	effect.resetInstanceState()
+
+	// Каждый экземпляр немного отличается от шаблона
+	varyEffect(&effect)
 
 	return &effect, nil
 }
 
+// GetLoadReport возвращает копию результатов загрузки шаблонов
+func (mm *MetamorphosisManager) GetLoadReport() *engine.LoadReport {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	return mm.loadReport.Clone()
+}
+
+// RegisterEffectTemplate добавляет или заменяет шаблон эффекта
+func (mm *MetamorphosisManager) RegisterEffectTemplate(effect *MetamorphEffect) {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.setupEffectCallbacks(effect)
+	mm.effectTemplates[effect.ID] = effect
+}
+
+// RegisterTriggerTemplate добавляет или заменяет шаблон триггера
+func (mm *MetamorphosisManager) RegisterTriggerTemplate(trigger *MetamorphTrigger) {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.setupTriggerCheck(trigger)
+	mm.triggerTemplates[trigger.ID] = trigger
+}
+
 // Внутренние методы
 
 // loadEffectTemplateFromFile загружает шаблон эффекта из файла
@@ -965,14 +1227,13 @@ func (mm *MetamorphosisManager) CreateDefaultEffectTemplates(dirPath string) {
 	effects := []*MetamorphEffect{
 		// Эффекты первого порядка (визуальные изменения)
 		{
-			ID:           "visual_distortion",
-			Name:         "Visual Distortion",
-			Description:  "Causes visual distortions and color shifts",
-			Order:        OrderFirst,
-			Category:     "visual",
-			Duration:     10 * time.Minute,
-			Intensity:    0.5,
-			AffectedTags: []string{"visible"},
+			ID:          "visual_distortion",
+			Name:        "Visual Distortion",
+			Description: "Causes visual distortions and color shifts",
+			Order:       OrderFirst,
+			Category:    "visual",
+			Duration:    10 * time.Minute,
+			Intensity:   0.5,
 			ComponentChanges: map[string]float64{
 				"render.distortion": 0.3,
 			},
@@ -986,7 +1247,6 @@ func (mm *MetamorphosisManager) CreateDefaultEffectTemplates(dirPath string) {
 			Category:     "audio",
 			Duration:     15 * time.Minute,
 			Intensity:    0.4,
-			AffectedTags: []string{"audio"},
 			SoundEffects: []string{"whispers", "echoes"},
 		},
 
@@ -999,7 +1259,7 @@ func (mm *MetamorphosisManager) CreateDefaultEffectTemplates(dirPath string) {
 			Category:     "environment",
 			Duration:     0, // Постоянный эффект
 			Intensity:    0.6,
-			AffectedTags: []string{"plant", "tree"},
+			AffectedTags: []string{TagPlant, TagTree},
 			ComponentChanges: map[string]float64{
 				"transform.scale.y": 1.5,
 				"render.distortion": 0.4,
@@ -1035,7 +1295,7 @@ func (mm *MetamorphosisManager) CreateDefaultEffectTemplates(dirPath string) {
 			Category:     "entity",
 			Duration:     0, // Постоянный эффект
 			Intensity:    0.8,
-			AffectedTags: []string{"animal", "creature"},
+			AffectedTags: []string{TagAnimal, TagCreature},
 			ComponentChanges: map[string]float64{
 				"health.maxHealth":  1.5,  // Увеличенное здоровье
 				"ai.detectionRange": 1.2,  // Улучшенное обнаружение
@@ -1088,6 +1348,8 @@ func (mm *MetamorphosisManager) CreateDefaultEffectTemplates(dirPath string) {
 			},
 			VisualEffects: []string{"reality_tear", "void_particles", "color_inversion"},
 			SoundEffects:  []string{"void_whispers", "reality_cracking"},
+			// Каждый разрыв не похож на предыдущий
+			Variation: &EffectVariation{Intensity: 0.1, Duration: 0.25, Radius: 0.2},
 		},
 		{
 			ID:           "nightmare_manifestation",
@@ -1097,7 +1359,7 @@ func (mm *MetamorphosisManager) CreateDefaultEffectTemplates(dirPath string) {
 			Category:     "entity",
 			Duration:     40 * time.Minute,
 			Intensity:    0.85,
-			AffectedTags: []string{"player"},
+			AffectedTags: []string{TagPlayer},
 			WorldChanges: map[string]float64{
 				"ai.spawn.nightmare": 1.0, // Активация спавна кошмаров
 			},
@@ -1320,20 +1582,8 @@ func (mm *MetamorphosisManager) InitializeDefaultState() {
 
 // generateInitialTriggers генерирует начальные триггеры
 func (mm *MetamorphosisManager) generateInitialTriggers() {
-	// Загружаем несколько базовых триггеров из шаблонов
-	for id, template := range mm.triggerTemplates {
-		// Создаем копию триггера
-		trigger := *template
-		trigger.ID = fmt.Sprintf("%s_%s", id, generateUUID())
-
-		// Добавляем триггер в доступные
-		mm.availableTriggers[trigger.ID] = &trigger
-
-		// Ограничиваем количество начальных триггеров
-		if len(mm.availableTriggers) >= 5 {
-			break
-		}
-	}
+	// Начальные триггеры создаются из шаблонов по тем же правилам, что и при пополнении
+	mm.refillTriggers()
 }
 
 // setupEffectCallbacks настраивает колбэки для эффекта в зависимости от его типа
@@ -1344,18 +1594,14 @@ func (mm *MetamorphosisManager) setupEffectCallbacks(effect *MetamorphEffect) {
 		// Настраиваем колбэки для визуальных эффектов
 		effect.OnApply = func(world *ecs.World, entity *ecs.Entity) error {
 			// Получаем компонент рендеринга
-			renderComp, has := entity.GetComponent(ecs.RenderComponentID)
+			render, has := ecs.ComponentAs[*ecs.RenderComponent](entity, ecs.RenderComponentID)
 			if !has {
 				return nil
 			}
 
-			render := renderComp.(*ecs.RenderComponent)
-
 			// Применяем визуальные эффекты
 			for _, visualEffect := range effect.VisualEffects {
-				if !containsString(render.Effects, visualEffect) {
-					render.Effects = append(render.Effects, visualEffect)
-				}
+				render.Effects.AddEffect(visualEffect, effect.Intensity, effect.ID)
 			}
 
 			// Применяем искажение
@@ -1368,17 +1614,13 @@ func (mm *MetamorphosisManager) setupEffectCallbacks(effect *MetamorphEffect) {
 
 		effect.OnRemove = func(world *ecs.World, entity *ecs.Entity) error {
 			// Получаем компонент рендеринга
-			renderComp, has := entity.GetComponent(ecs.RenderComponentID)
+			render, has := ecs.ComponentAs[*ecs.RenderComponent](entity, ecs.RenderComponentID)
 			if !has {
 				return nil
 			}
 
-			render := renderComp.(*ecs.RenderComponent)
-
-			// Удаляем визуальные эффекты
-			for _, visualEffect := range effect.VisualEffects {
-				render.Effects = removeString(render.Effects, visualEffect)
-			}
+			// Удаляем только свои визуальные эффекты: тот же эффект мог добавить другой источник
+			render.Effects.RemoveEffectsFromSource(effect.ID)
 
 			// Уменьшаем искажение
 			if distortion, exists := effect.ComponentChanges["render.distortion"]; exists {
@@ -1392,13 +1634,11 @@ func (mm *MetamorphosisManager) setupEffectCallbacks(effect *MetamorphEffect) {
 		// Настраиваем колбэки для физических эффектов
 		effect.OnApply = func(world *ecs.World, entity *ecs.Entity) error {
 			// Получаем компонент физики
-			physicsComp, has := entity.GetComponent(ecs.PhysicsComponentID)
+			physics, has := ecs.ComponentAs[*ecs.PhysicsComponent](entity, ecs.PhysicsComponentID)
 			if !has {
 				return nil
 			}
 
-			physics := physicsComp.(*ecs.PhysicsComponent)
-
 			// Применяем изменения компонентов
 			if gravity, exists := effect.ComponentChanges["physics.gravity"]; exists {
 				physics.Gravity = gravity
@@ -1413,13 +1653,11 @@ func (mm *MetamorphosisManager) setupEffectCallbacks(effect *MetamorphEffect) {
 
 		effect.OnRemove = func(world *ecs.World, entity *ecs.Entity) error {
 			// Получаем компонент физики
-			physicsComp, has := entity.GetComponent(ecs.PhysicsComponentID)
+			physics, has := ecs.ComponentAs[*ecs.PhysicsComponent](entity, ecs.PhysicsComponentID)
 			if !has {
 				return nil
 			}
 
-			physics := physicsComp.(*ecs.PhysicsComponent)
-
 			// Возвращаем нормальные значения
 			physics.Gravity = 1.0
 			physics.Friction = 0.5
@@ -1433,9 +1671,7 @@ func (mm *MetamorphosisManager) setupEffectCallbacks(effect *MetamorphEffect) {
 			// Применяем изменения в зависимости от типа компонента
 
 			// Изменения здоровья
-			if healthComp, has := entity.GetComponent(ecs.HealthComponentID); has {
-				health := healthComp.(*ecs.HealthComponent)
-
+			if health, has := ecs.ComponentAs[*ecs.HealthComponent](entity, ecs.HealthComponentID); has {
 				if maxHealth, exists := effect.ComponentChanges["health.maxHealth"]; exists {
 					// Запоминаем текущий процент здоровья
 					healthPercent := health.CurrentHealth / health.MaxHealth
@@ -1449,9 +1685,7 @@ func (mm *MetamorphosisManager) setupEffectCallbacks(effect *MetamorphEffect) {
 			}
 
 			// Изменения ИИ
-			if aiComp, has := entity.GetComponent(ecs.AIComponentID); has {
-				ai := aiComp.(*ecs.AIComponent)
-
+			if ai, has := ecs.ComponentAs[*ecs.AIComponent](entity, ecs.AIComponentID); has {
 				if detectionRange, exists := effect.ComponentChanges["ai.detectionRange"]; exists {
 					ai.DetectionRange *= detectionRange
 				}
@@ -1469,9 +1703,7 @@ func (mm *MetamorphosisManager) setupEffectCallbacks(effect *MetamorphEffect) {
 			}
 
 			// Изменения трансформации
-			if transformComp, has := entity.GetComponent(ecs.TransformComponentID); has {
-				transform := transformComp.(*ecs.TransformComponent)
-
+			if transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID); has {
 				if scale, exists := effect.ComponentChanges["render.scale"]; exists {
 					transform.Scale = transform.Scale.Multiply(scale)
 				}
@@ -1482,25 +1714,7 @@ func (mm *MetamorphosisManager) setupEffectCallbacks(effect *MetamorphEffect) {
 
 	case "audio":
 		// Настраиваем колбэки для звуковых эффектов
-		effect.OnApply = func(world *ecs.World, entity *ecs.Entity) error {
-			// Получаем компонент звука
-			soundComp, has := entity.GetComponent(ecs.SoundEmitterComponentID)
-			if !has {
-				return nil
-			}
-
-			sound := soundComp.(*ecs.SoundEmitterComponent)
-
-			// Добавляем звуковые эффекты
-			for _, soundEffect := range effect.SoundEffects {
-				if soundID, exists := sound.Sounds[soundEffect]; exists {
-					sound.SoundID = soundID
-					sound.Play()
-				}
-			}
-
-			return nil
-		}
+		setupAudioCallbacks(effect)
 
 	case "reality":
 		// Для эффектов реальности не задаем колбэки на уровне сущностей,
@@ -1550,12 +1764,10 @@ func (mm *MetamorphosisManager) setupTriggerCheck(trigger *MetamorphTrigger) {
 				entities := world.GetEntitiesWithTag(requiresTag)
 
 				for _, entity := range entities {
-					transformComp, has := entity.GetComponent(ecs.TransformComponentID)
+					transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
 					if !has {
 						continue
 					}
-
-					transform := transformComp.(*ecs.TransformComponent)
 					distance := state.PlayerPosition.Distance(transform.Position)
 
 					if distance <= trigger.Radius {
@@ -1576,7 +1788,7 @@ func (mm *MetamorphosisManager) setupTriggerCheck(trigger *MetamorphTrigger) {
 			for _, action := range state.RecentPlayerActions {
 				if action.Type == trigger.ActionType {
 					// Проверяем, было ли действие выполнено недавно (в течение последних 5 минут)
-					if time.Since(action.Timestamp) <= 5*time.Minute {
+					if mm.clock.Now().Sub(action.Timestamp) <= 5*time.Minute {
 						// Проверяем дополнительные условия
 						if minPhase, ok := trigger.Conditions["min_phase"].(float64); ok {
 							return state.TransformationPhase >= int(minPhase)
@@ -1595,7 +1807,7 @@ func (mm *MetamorphosisManager) setupTriggerCheck(trigger *MetamorphTrigger) {
 			switch trigger.EventType {
 			case "player_death":
 				// Проверяем, умирал ли игрок недавно
-				if !state.LastPlayerDeath.IsZero() && time.Since(state.LastPlayerDeath) <= 10*time.Minute {
+				if !state.LastPlayerDeath.IsZero() && mm.clock.Now().Sub(state.LastPlayerDeath) <= 10*time.Minute {
 					if minPhase, ok := trigger.Conditions["min_phase"].(float64); ok {
 						return state.TransformationPhase >= int(minPhase)
 					}
@@ -1607,7 +1819,7 @@ func (mm *MetamorphosisManager) setupTriggerCheck(trigger *MetamorphTrigger) {
 				for _, action := range state.RecentPlayerActions {
 					if action.Type == "blood_spill" {
 						// Проверяем, было ли действие выполнено недавно
-						if time.Since(action.Timestamp) <= 5*time.Minute {
+						if mm.clock.Now().Sub(action.Timestamp) <= 5*time.Minute {
 							// Проверяем количество крови
 							if minAmount, ok := trigger.Conditions["min_amount"].(float64); ok {
 								return action.Value >= minAmount
@@ -1673,7 +1885,7 @@ func (mm *MetamorphosisManager) setupTriggerCheck(trigger *MetamorphTrigger) {
 					// Проверяем, был ли ритуал выполнен недавно
 					for _, action := range state.RecentPlayerActions {
 						if action.Type == "complete_ritual" && action.Target == ecs.EntityID(ritualID) {
-							if time.Since(action.Timestamp) <= 10*time.Minute {
+							if mm.clock.Now().Sub(action.Timestamp) <= 10*time.Minute {
 								if minPhase, ok := trigger.Conditions["min_phase"].(float64); ok {
 									return state.TransformationPhase >= int(minPhase)
 								}
@@ -1708,13 +1920,11 @@ func (mm *MetamorphosisManager) isEntityAffectedByEffect(entity *ecs.Entity, eff
 
 	// Если эффект имеет область действия, проверяем, находится ли сущность в ней
 	if effect.AffectedArea != nil {
-		transformComp, has := entity.GetComponent(ecs.TransformComponentID)
+		transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
 		if !has {
 			return false
 		}
 
-		transform := transformComp.(*ecs.TransformComponent)
-
 		// Проверяем тип области
 		switch effect.AffectedArea.Type {
 		case "sphere":
@@ -1726,31 +1936,10 @@ func (mm *MetamorphosisManager) isEntityAffectedByEffect(entity *ecs.Entity, eff
 				return false
 			}
 
-			// Учитываем затухание эффекта с расстоянием
-			if effect.AffectedArea.Falloff != "none" {
-				if distance > effect.AffectedArea.FalloffMin {
-					// Нормализуем расстояние от 0 до 1
-					normalizedDistance := (distance - effect.AffectedArea.FalloffMin) / (effect.AffectedArea.FalloffMax - effect.AffectedArea.FalloffMin)
-					normalizedDistance = math.Min(1.0, math.Max(0.0, normalizedDistance))
-
-					// Вычисляем затухание в зависимости от типа
-					var falloff float64
-					switch effect.AffectedArea.Falloff {
-					case "linear":
-						falloff = 1.0 - normalizedDistance
-					case "quadratic":
-						falloff = 1.0 - normalizedDistance*normalizedDistance
-					case "exponential":
-						falloff = math.Exp(-3.0 * normalizedDistance)
-					default:
-						falloff = 1.0 - normalizedDistance
-					}
-
-					// Если затухание слишком сильное, считаем, что сущность не подвержена эффекту
-					if falloff < 0.05 {
-						return false
-					}
-				}
+			// Учитываем затухание эффекта с расстоянием: если оно слишком сильное,
+			// считаем, что сущность не подвержена эффекту
+			if effect.AffectedArea.FalloffFactor(distance) < minEffectiveFalloff {
+				return false
 			}
 
 		case "box":
@@ -1839,12 +2028,15 @@ func (mm *MetamorphosisManager) selectEffectForTrigger(trigger *MetamorphTrigger
 		return nil
 	}
 
-	// Выбираем случайный шаблон
-	template := suitableTemplates[rand.Intn(len(suitableTemplates))]
+	// Выбираем случайный шаблон; порядок постоянный, чтобы выбор зависел только от случая
+	sort.Slice(suitableTemplates, func(i, j int) bool { return suitableTemplates[i].ID < suitableTemplates[j].ID })
+	template := suitableTemplates[mm.rng.Intn(len(suitableTemplates))]
 
 	// Создаем копию эффекта
 	effect := *template
 	effect.ID = fmt.Sprintf("%s_%s", template.ID, generateUUID())
+	effect.resetInstanceState()
+	varyEffect(&effect)
 
 	return &effect
 }
@@ -2002,20 +2194,39 @@ func (mm *MetamorphosisManager) updateGlobalAnomalyLevel() {
 
 // recordHistoryEntry записывает событие в историю
 func (mm *MetamorphosisManager) recordHistoryEntry(effectID string, action string, entityID ecs.EntityID, description string) {
-	entry := HistoryEntry{
-		Timestamp:   time.Now(),
+	mm.appendHistoryEntry(HistoryEntry{
+		Timestamp:   mm.clock.Now(),
 		EffectID:    effectID,
 		Action:      action,
 		EntityID:    entityID,
 		Description: description,
-	}
+	})
+}
 
+// appendHistoryEntry добавляет запись в историю, отбрасывая лишние и устаревшие
+func (mm *MetamorphosisManager) appendHistoryEntry(entry HistoryEntry) {
 	mm.changeHistory = append(mm.changeHistory, entry)
 
 	// Ограничиваем размер истории
-	if len(mm.changeHistory) > 1000 {
-		mm.changeHistory = mm.changeHistory[len(mm.changeHistory)-1000:]
+	if len(mm.changeHistory) > MaxHistoryEntries {
+		mm.changeHistory = mm.changeHistory[len(mm.changeHistory)-MaxHistoryEntries:]
+	}
+
+	// Записи идут по времени, поэтому устаревшие отбрасываются с начала
+	cutoff := entry.Timestamp.Add(-MaxHistoryAge)
+	stale := 0
+	for stale < len(mm.changeHistory) && mm.changeHistory[stale].Timestamp.Before(cutoff) {
+		stale++
 	}
+	mm.changeHistory = mm.changeHistory[stale:]
+}
+
+// GetHistory возвращает копию истории изменений
+func (mm *MetamorphosisManager) GetHistory() []HistoryEntry {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	return append([]HistoryEntry(nil), mm.changeHistory...)
 }
 
 // generateUUID генерирует уникальный идентификатор
@@ -0,0 +1,16 @@
+package metamorphosis
+
+import "echo-taiga/internal/engine/ecs"
+
+// Теги сущностей, на которые опираются эффекты метаморфоз
+const (
+	TagPlayer   = "player"
+	TagPlant    = "vegetation"
+	TagTree     = "tree"
+	TagAnimal   = "animal"
+	TagCreature = "creature"
+)
+
+func init() {
+	ecs.DeclareTagQuery(TagPlayer, TagPlant, TagTree, TagAnimal, TagCreature)
+}
@@ -0,0 +1,183 @@
+package metamorphosis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Веса шаблонов при пополнении триггеров
+const (
+	MinTriggerWeight    = 0.1 // Вес шаблона с нулевым приоритетом
+	PhaseTriggerBias    = 2.0 // Множитель веса шаблонов порядка текущей фазы
+	LockedTriggerWeight = 0.1 // Множитель веса шаблонов еще недоступного порядка
+)
+
+// TriggerConfig управляет пополнением триггеров метаморфоз из шаблонов
+type TriggerConfig struct {
+	MaxAvailable     int     // Наибольшее число одновременно доступных триггеров
+	RefillInterval   float64 // Секунд между пополнениями
+	TemplateCooldown float64 // Секунд, через которые сработавший шаблон снова дает триггер
+}
+
+// DefaultTriggerConfig возвращает настройки пополнения триггеров по умолчанию
+func DefaultTriggerConfig() TriggerConfig {
+	return TriggerConfig{
+		MaxAvailable:     5,
+		RefillInterval:   30.0,
+		TemplateCooldown: 300.0,
+	}
+}
+
+// Validate проверяет настройки пополнения триггеров
+func (tc TriggerConfig) Validate() error {
+	if tc.MaxAvailable < 1 {
+		return fmt.Errorf("MaxAvailable must be at least 1, got %d", tc.MaxAvailable)
+	}
+	if tc.RefillInterval <= 0 {
+		return fmt.Errorf("RefillInterval must be positive, got %.2f", tc.RefillInterval)
+	}
+	if tc.TemplateCooldown < 0 {
+		return fmt.Errorf("TemplateCooldown must not be negative, got %.2f", tc.TemplateCooldown)
+	}
+	return nil
+}
+
+// SetTriggerConfig проверяет и применяет настройки пополнения триггеров
+func (mm *MetamorphosisManager) SetTriggerConfig(config TriggerConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid trigger config: %v", err)
+	}
+
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.triggerConfig = config
+	return nil
+}
+
+// SetTriggerRegenInterval задает, как часто пополняются доступные триггеры
+func (mm *MetamorphosisManager) SetTriggerRegenInterval(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.triggerConfig.RefillInterval = d.Seconds()
+}
+
+// SetMaxAvailableTriggers задает предел одновременно доступных триггеров.
+// Лишние триггеры не удаляются, но новые не появляются, пока их число не упадет.
+func (mm *MetamorphosisManager) SetMaxAvailableTriggers(n int) {
+	if n < 1 {
+		return
+	}
+
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.triggerConfig.MaxAvailable = n
+}
+
+// regenerateTriggers отсчитывает паузы шаблонов и раз в RefillInterval пополняет
+// триггеры до предела. Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) regenerateTriggers(deltaTime float64) {
+	for templateID, remaining := range mm.templateCooldowns {
+		if remaining -= deltaTime; remaining <= 0 {
+			delete(mm.templateCooldowns, templateID)
+		} else {
+			mm.templateCooldowns[templateID] = remaining
+		}
+	}
+
+	mm.triggerRefillTimer += deltaTime
+	if mm.triggerRefillTimer < mm.triggerConfig.RefillInterval {
+		return
+	}
+	mm.triggerRefillTimer = 0
+
+	mm.refillTriggers()
+}
+
+// refillTriggers создает триггеры из шаблонов до предела доступных, выбирая шаблоны
+// по весу (см. triggerWeight). Шаблоны на паузе и шаблоны, чей триггер еще ждет
+// срабатывания, пропускаются.
+func (mm *MetamorphosisManager) refillTriggers() {
+	if len(mm.availableTriggers) >= mm.triggerConfig.MaxAvailable {
+		return
+	}
+
+	pending := make(map[string]bool, len(mm.availableTriggers))
+	for _, trigger := range mm.availableTriggers {
+		pending[trigger.TemplateID] = true
+	}
+
+	// Собираем кандидатов в постоянном порядке, чтобы выбор зависел только от случая
+	candidates := make([]string, 0, len(mm.triggerTemplates))
+	for id := range mm.triggerTemplates {
+		if _, cooling := mm.templateCooldowns[id]; !cooling && !pending[id] {
+			candidates = append(candidates, id)
+		}
+	}
+	sort.Strings(candidates)
+
+	weights := make([]float64, len(candidates))
+	total := 0.0
+	for i, id := range candidates {
+		weights[i] = mm.triggerWeight(mm.triggerTemplates[id])
+		total += weights[i]
+	}
+
+	for len(candidates) > 0 && len(mm.availableTriggers) < mm.triggerConfig.MaxAvailable {
+		// Выбираем шаблон пропорционально весу, без повторов
+		pick := len(candidates) - 1
+		roll := mm.rng.Float64() * total
+		for i, weight := range weights {
+			if roll < weight {
+				pick = i
+				break
+			}
+			roll -= weight
+		}
+		id := candidates[pick]
+
+		total -= weights[pick]
+		candidates = append(candidates[:pick], candidates[pick+1:]...)
+		weights = append(weights[:pick], weights[pick+1:]...)
+
+		// Создаем копию триггера
+		trigger := *mm.triggerTemplates[id]
+		trigger.ID = fmt.Sprintf("%s_%s", id, generateUUID())
+		trigger.TemplateID = id
+
+		mm.availableTriggers[trigger.ID] = &trigger
+	}
+}
+
+// triggerWeight возвращает вес шаблона при пополнении: чем выше приоритет, тем
+// чаще он выбирается. Шаблоны порядка текущей фазы выбираются чаще, а шаблоны
+// еще недоступного порядка - редко, чтобы не занимать места впустую.
+func (mm *MetamorphosisManager) triggerWeight(template *MetamorphTrigger) float64 {
+	weight := math.Max(template.Priority, MinTriggerWeight)
+
+	order := getEffectOrderForTrigger(template)
+	switch {
+	case !mm.isOrderAllowed(order):
+		weight *= LockedTriggerWeight
+	case int(order) == mm.transformationPhase:
+		weight *= PhaseTriggerBias
+	}
+	return weight
+}
+
+// consumeTrigger убирает сработавший триггер и ставит его шаблон на паузу.
+// Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) consumeTrigger(trigger *MetamorphTrigger) {
+	delete(mm.availableTriggers, trigger.ID)
+	if trigger.TemplateID != "" && mm.triggerConfig.TemplateCooldown > 0 {
+		mm.templateCooldowns[trigger.TemplateID] = mm.triggerConfig.TemplateCooldown
+	}
+}
@@ -0,0 +1,144 @@
+package metamorphosis
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newTriggerTestManager создает менеджер с одним шаблоном эффекта первого порядка
+// и count шаблонами триггеров, которые срабатывают всегда
+func newTriggerTestManager(t *testing.T, count int, config TriggerConfig) *MetamorphosisManager {
+	t.Helper()
+
+	mm, _ := newTestManager(t)
+	mm.triggerTemplates = make(map[string]*MetamorphTrigger)
+	mm.effectTemplates = make(map[string]*MetamorphEffect)
+	setTestBudget(mm, 10000)
+
+	mm.RegisterEffectTemplate(&MetamorphEffect{ID: "test_shimmer", Name: "Shimmer", Order: OrderFirst, Category: "visual", Intensity: 0.3})
+	for i := 0; i < count; i++ {
+		mm.RegisterTriggerTemplate(&MetamorphTrigger{
+			ID:       fmt.Sprintf("test_trigger_%d", i),
+			Type:     "test",
+			Priority: 0.3,
+			Check:    func(world *ecs.World, state *WorldState) bool { return true },
+		})
+	}
+	if err := mm.SetTriggerConfig(config); err != nil {
+		t.Fatalf("SetTriggerConfig: %v", err)
+	}
+	return mm
+}
+
+// availableCount возвращает число доступных триггеров
+func availableCount(mm *MetamorphosisManager) int {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	return len(mm.availableTriggers)
+}
+
+func TestMetamorphosesResumeAfterTemplateCooldown(t *testing.T) {
+	mm := newTriggerTestManager(t, 2, TriggerConfig{MaxAvailable: 5, RefillInterval: 10, TemplateCooldown: 60})
+	mm.mutex.Lock()
+	mm.generateInitialTriggers()
+	mm.mutex.Unlock()
+	if count := availableCount(mm); count != 2 {
+		t.Fatalf("%d initial triggers, want 2", count)
+	}
+
+	// Каждое обновление срабатывает не больше одного триггера
+	mm.Update(1)
+	mm.Update(1)
+	if count, effects := availableCount(mm), len(mm.GetActiveEffects()); count != 0 || effects != 2 {
+		t.Fatalf("%d triggers and %d effects after both fired, want 0 and 2", count, effects)
+	}
+
+	// Пополнения идут, но оба шаблона еще на паузе
+	for i := 0; i < 30; i++ {
+		mm.Update(1)
+	}
+	if count, effects := availableCount(mm), len(mm.GetActiveEffects()); count != 0 || effects != 2 {
+		t.Fatalf("%d triggers and %d effects while templates cool down, want 0 and 2", count, effects)
+	}
+
+	// Пауза прошла: следующее пополнение возвращает триггеры, и метаморфозы продолжаются
+	for i := 0; i < 40; i++ {
+		mm.Update(1)
+	}
+	if effects := len(mm.GetActiveEffects()); effects <= 2 {
+		t.Errorf("%d effects after the template cooldown, want new metamorphoses", effects)
+	}
+}
+
+func TestTriggerPoolRefillsToCap(t *testing.T) {
+	mm := newTriggerTestManager(t, 8, TriggerConfig{MaxAvailable: 5, RefillInterval: 10, TemplateCooldown: 0})
+	mm.SetMaxAvailableTriggers(5)
+
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.refillTriggers()
+	if len(mm.availableTriggers) != 5 {
+		t.Fatalf("%d triggers after the first refill, want the cap of 5", len(mm.availableTriggers))
+	}
+
+	// Три триггера срабатывают
+	consumed := 0
+	for _, trigger := range mm.availableTriggers {
+		if consumed == 3 {
+			break
+		}
+		mm.consumeTrigger(trigger)
+		consumed++
+	}
+
+	mm.regenerateTriggers(9)
+	if len(mm.availableTriggers) != 2 {
+		t.Fatalf("%d triggers before the interval passed, want 2", len(mm.availableTriggers))
+	}
+	mm.regenerateTriggers(1)
+	if len(mm.availableTriggers) != 5 {
+		t.Fatalf("%d triggers after the interval, want the pool refilled to 5", len(mm.availableTriggers))
+	}
+
+	// Сколько бы пополнений ни прошло, предел не превышается, а шаблоны не повторяются
+	for i := 0; i < 20; i++ {
+		mm.regenerateTriggers(10)
+		if len(mm.availableTriggers) > 5 {
+			t.Fatalf("%d triggers available, over the cap of 5", len(mm.availableTriggers))
+		}
+	}
+	templates := make(map[string]bool)
+	for _, trigger := range mm.availableTriggers {
+		if templates[trigger.TemplateID] {
+			t.Errorf("template %s has two pending triggers", trigger.TemplateID)
+		}
+		templates[trigger.TemplateID] = true
+	}
+}
+
+func TestTriggerWeightFavorsCurrentPhase(t *testing.T) {
+	mm, _ := newTestManager(t)
+	mm.transformationPhase = 1
+
+	current := &MetamorphTrigger{ID: "current", Priority: 0.3}
+	locked := &MetamorphTrigger{ID: "locked", Priority: 0.7}
+	idle := &MetamorphTrigger{ID: "idle", Priority: 0}
+
+	for _, c := range []struct {
+		trigger *MetamorphTrigger
+		want    float64
+	}{
+		{current, 0.3 * PhaseTriggerBias},
+		{locked, 0.7 * LockedTriggerWeight},
+		{idle, MinTriggerWeight * PhaseTriggerBias},
+	} {
+		if got := mm.triggerWeight(c.trigger); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("weight of %s trigger = %.3f, want %.3f", c.trigger.ID, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package metamorphosis
+
+import (
+	"fmt"
+	"strings"
+
+	"echo-taiga/internal/engine"
+)
+
+// Допустимые значения полей шаблонов
+var (
+	knownEffectCategories = []string{"visual", "audio", "environment", "physics", "entity", "reality"}
+	knownAreaTypes        = []string{"sphere", "box", "cylinder", "path"}
+	knownTriggerTypes     = []string{"time", "location", "action", "event", "threshold", "ritual"}
+)
+
+// ValidateEffectTemplate проверяет обязательные поля шаблона эффекта
+func ValidateEffectTemplate(effect *MetamorphEffect) []engine.TemplateError {
+	errors := make([]engine.TemplateError, 0)
+
+	if strings.TrimSpace(effect.ID) == "" {
+		errors = append(errors, engine.TemplateError{Field: "ID", Message: "must not be empty"})
+	}
+
+	if effect.Order < OrderFirst || effect.Order > OrderFifth {
+		errors = append(errors, engine.TemplateError{
+			Field:   "Order",
+			Message: fmt.Sprintf("must be between %d and %d, got %d", OrderFirst, OrderFifth, effect.Order),
+		})
+	}
+
+	if !containsString(knownEffectCategories, effect.Category) {
+		errors = append(errors, engine.TemplateError{
+			Field:   "Category",
+			Message: fmt.Sprintf("unknown category %q (expected one of %s)", effect.Category, strings.Join(knownEffectCategories, ", ")),
+		})
+	}
+
+	if effect.Intensity < 0 || effect.Intensity > 1 {
+		errors = append(errors, engine.TemplateError{
+			Field:   "Intensity",
+			Message: fmt.Sprintf("must be between 0 and 1, got %.2f", effect.Intensity),
+		})
+	}
+
+	if variation := effect.Variation; variation != nil {
+		if variation.Intensity < 0 || variation.Intensity > 1 {
+			errors = append(errors, engine.TemplateError{
+				Field:   "Variation.Intensity",
+				Message: fmt.Sprintf("must be between 0 and 1, got %.2f", variation.Intensity),
+			})
+		}
+		if variation.Duration < 0 || variation.Duration >= 1 {
+			errors = append(errors, engine.TemplateError{
+				Field:   "Variation.Duration",
+				Message: fmt.Sprintf("must be at least 0 and below 1, got %.2f", variation.Duration),
+			})
+		}
+		if variation.Radius < 0 || variation.Radius >= 1 {
+			errors = append(errors, engine.TemplateError{
+				Field:   "Variation.Radius",
+				Message: fmt.Sprintf("must be at least 0 and below 1, got %.2f", variation.Radius),
+			})
+		}
+	}
+
+	if effect.AffectedArea != nil && !containsString(knownAreaTypes, effect.AffectedArea.Type) {
+		errors = append(errors, engine.TemplateError{
+			Field:   "AffectedArea.Type",
+			Message: fmt.Sprintf("unknown area type %q (expected one of %s)", effect.AffectedArea.Type, strings.Join(knownAreaTypes, ", ")),
+		})
+	}
+
+	return errors
+}
+
+// ValidateTriggerTemplate проверяет обязательные поля шаблона триггера
+func ValidateTriggerTemplate(trigger *MetamorphTrigger) []engine.TemplateError {
+	errors := make([]engine.TemplateError, 0)
+
+	if strings.TrimSpace(trigger.ID) == "" {
+		errors = append(errors, engine.TemplateError{Field: "ID", Message: "must not be empty"})
+	}
+
+	if !containsString(knownTriggerTypes, trigger.Type) {
+		errors = append(errors, engine.TemplateError{
+			Field:   "Type",
+			Message: fmt.Sprintf("unknown trigger type %q (expected one of %s)", trigger.Type, strings.Join(knownTriggerTypes, ", ")),
+		})
+	}
+
+	if trigger.Priority < 0 || trigger.Priority > 1 {
+		errors = append(errors, engine.TemplateError{
+			Field:   "Priority",
+			Message: fmt.Sprintf("must be between 0 and 1, got %.2f", trigger.Priority),
+		})
+	}
+
+	switch trigger.Type {
+	case "location":
+		if _, hasTag := trigger.Conditions["requires_tag"]; trigger.Location == nil && !hasTag {
+			errors = append(errors, engine.TemplateError{Field: "Location", Message: "location triggers need a Location or a requires_tag condition"})
+		}
+	case "action":
+		if trigger.ActionType == "" {
+			errors = append(errors, engine.TemplateError{Field: "ActionType", Message: "required for action triggers"})
+		}
+	case "event":
+		if trigger.EventType == "" {
+			errors = append(errors, engine.TemplateError{Field: "EventType", Message: "required for event triggers"})
+		}
+	case "threshold":
+		if trigger.ThresholdType == "" {
+			errors = append(errors, engine.TemplateError{Field: "ThresholdType", Message: "required for threshold triggers"})
+		}
+	}
+
+	return errors
+}
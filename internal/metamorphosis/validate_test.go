@@ -0,0 +1,71 @@
+package metamorphosis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTemplate записывает JSON-шаблон в каталог и возвращает путь к файлу
+func writeTemplate(t *testing.T, dir, name, data string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestInvalidEffectTemplatesAreReported(t *testing.T) {
+	mm, _ := newTestManager(t)
+	dir := t.TempDir()
+
+	valid := writeTemplate(t, dir, "valid.json", `{"ID": "fog", "Order": 1, "Category": "visual", "Intensity": 0.5}`)
+	badOrder := writeTemplate(t, dir, "bad_order.json", `{"ID": "rift", "Order": 9, "Category": "reality", "Intensity": 0.5}`)
+	noID := writeTemplate(t, dir, "no_id.json", `{"Order": 2, "Category": "environment", "Intensity": 0.5}`)
+	broken := writeTemplate(t, dir, "broken.json", `{"ID": "fog",`)
+
+	if err := mm.LoadEffectTemplates(dir); err != nil {
+		t.Fatalf("LoadEffectTemplates() = %v", err)
+	}
+	report := mm.GetLoadReport()
+
+	if report.Loaded != 1 || len(report.ErrorsForFile(valid)) != 0 {
+		t.Errorf("loaded %d templates with errors %v, want only the valid one", report.Loaded, report.ErrorsForFile(valid))
+	}
+	if errors := report.ErrorsForFile(badOrder); len(errors) != 1 || errors[0].Field != "Order" {
+		t.Errorf("errors for the bad order = %v, want one Order error", errors)
+	}
+	if errors := report.ErrorsForFile(noID); len(errors) != 1 || errors[0].Field != "ID" {
+		t.Errorf("errors for the missing ID = %v, want one ID error", errors)
+	}
+	if errors := report.ErrorsForFile(broken); len(errors) != 1 || errors[0].Field != "" {
+		t.Errorf("errors for the broken file = %v, want one parse error", errors)
+	}
+
+	mm.mutex.RLock()
+	_, rift := mm.effectTemplates["rift"]
+	mm.mutex.RUnlock()
+	if rift {
+		t.Error("template with an invalid order was registered")
+	}
+}
+
+func TestLoadReportIsACopy(t *testing.T) {
+	mm, _ := newTestManager(t)
+	dir := t.TempDir()
+	writeTemplate(t, dir, "no_id.json", `{"Order": 2, "Category": "environment"}`)
+
+	if err := mm.LoadEffectTemplates(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	report := mm.GetLoadReport()
+	report.Errors = report.Errors[:0]
+	report.Loaded = 42
+
+	if again := mm.GetLoadReport(); !again.HasErrors() || again.Loaded != 0 {
+		t.Errorf("changing a returned report changed the manager's: %+v", again)
+	}
+}
@@ -0,0 +1,52 @@
+package metamorphosis
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// EffectVariation - разброс параметров экземпляров эффекта относительно шаблона,
+// чтобы повторные срабатывания одного шаблона выглядели по-разному.
+// Шаблон без разброса копируется как есть.
+type EffectVariation struct {
+	Intensity float64 // Наибольшее отклонение интенсивности (абсолютное)
+	Duration  float64 // Наибольшее отклонение длительности (доля длительности шаблона)
+	Radius    float64 // Наибольшее отклонение радиуса области (доля радиуса шаблона)
+}
+
+// varyEffect разбрасывает параметры эффекта, созданного из шаблона, в пределах
+// Variation. Разброс зависит только от ID эффекта, поэтому эффект, восстановленный
+// из сохранения по тому же ID, получает те же параметры.
+func varyEffect(effect *MetamorphEffect) {
+	variation := effect.Variation
+	if variation == nil {
+		return
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(effect.ID))
+	r := rand.New(rand.NewSource(int64(hash.Sum64())))
+
+	// Отклонение в пределах [-1, 1]
+	spread := func() float64 {
+		return r.Float64()*2 - 1
+	}
+
+	if variation.Intensity > 0 {
+		effect.Intensity = math.Max(0, math.Min(1, effect.Intensity+variation.Intensity*spread()))
+	}
+
+	// Постоянные эффекты остаются постоянными
+	if variation.Duration > 0 && effect.Duration > 0 {
+		effect.Duration = time.Duration(float64(effect.Duration) * (1 + variation.Duration*spread()))
+	}
+
+	// Область копируется: шаблон и другие экземпляры делят ее
+	if variation.Radius > 0 && effect.AffectedArea != nil && effect.AffectedArea.Radius > 0 {
+		area := *effect.AffectedArea
+		area.Radius *= 1 + variation.Radius*spread()
+		effect.AffectedArea = &area
+	}
+}
@@ -0,0 +1,87 @@
+package metamorphosis
+
+import (
+	"testing"
+	"time"
+)
+
+// newVariedTemplate создает шаблон с разбросом всех параметров
+func newVariedTemplate(variation *EffectVariation) *MetamorphEffect {
+	return &MetamorphEffect{
+		ID:           "shimmer",
+		Intensity:    0.5,
+		Duration:     10 * time.Minute,
+		AffectedArea: &AffectedArea{Type: "sphere", Radius: 20},
+		Variation:    variation,
+	}
+}
+
+// instanceOf копирует шаблон под новым ID и разбрасывает параметры
+func instanceOf(template *MetamorphEffect, id string) MetamorphEffect {
+	effect := *template
+	effect.ID = id
+	varyEffect(&effect)
+	return effect
+}
+
+func TestVaryEffectInstancesDiffer(t *testing.T) {
+	template := newVariedTemplate(&EffectVariation{Intensity: 0.2, Duration: 0.3, Radius: 0.25})
+
+	a := instanceOf(template, "shimmer_1")
+	b := instanceOf(template, "shimmer_2")
+	if a.Intensity == b.Intensity || a.Duration == b.Duration || a.AffectedArea.Radius == b.AffectedArea.Radius {
+		t.Errorf("instances did not vary: %v/%v, %v/%v, %v/%v",
+			a.Intensity, b.Intensity, a.Duration, b.Duration, a.AffectedArea.Radius, b.AffectedArea.Radius)
+	}
+
+	// Разброс остается в заданных пределах
+	for _, effect := range []MetamorphEffect{a, b} {
+		if effect.Intensity < 0.3 || effect.Intensity > 0.7 {
+			t.Errorf("intensity %v outside 0.5±0.2", effect.Intensity)
+		}
+		if effect.Duration < 7*time.Minute || effect.Duration > 13*time.Minute {
+			t.Errorf("duration %v outside 10m±30%%", effect.Duration)
+		}
+		if effect.AffectedArea.Radius < 15 || effect.AffectedArea.Radius > 25 {
+			t.Errorf("radius %v outside 20±25%%", effect.AffectedArea.Radius)
+		}
+	}
+
+	// Шаблон не меняется, хотя область экземпляры получили по указателю
+	if template.Intensity != 0.5 || template.Duration != 10*time.Minute || template.AffectedArea.Radius != 20 {
+		t.Errorf("template changed: %+v, radius %v", template, template.AffectedArea.Radius)
+	}
+}
+
+func TestVaryEffectIsStableForID(t *testing.T) {
+	template := newVariedTemplate(&EffectVariation{Intensity: 0.2, Duration: 0.3, Radius: 0.25})
+
+	// Эффект, восстановленный из сохранения, получает те же параметры
+	a := instanceOf(template, "shimmer_1")
+	b := instanceOf(template, "shimmer_1")
+	if a.Intensity != b.Intensity || a.Duration != b.Duration || a.AffectedArea.Radius != b.AffectedArea.Radius {
+		t.Errorf("same ID varied differently: %+v vs %+v", a, b)
+	}
+}
+
+func TestVaryEffectWithoutVariationCopiesTemplate(t *testing.T) {
+	template := newVariedTemplate(nil)
+
+	effect := instanceOf(template, "shimmer_1")
+	if effect.Intensity != template.Intensity || effect.Duration != template.Duration {
+		t.Errorf("got intensity %v and duration %v, want the template's %v and %v",
+			effect.Intensity, effect.Duration, template.Intensity, template.Duration)
+	}
+	if effect.AffectedArea != template.AffectedArea {
+		t.Errorf("area was copied without variation")
+	}
+}
+
+func TestVaryEffectKeepsPermanentEffectsPermanent(t *testing.T) {
+	template := newVariedTemplate(&EffectVariation{Duration: 0.5})
+	template.Duration = 0
+
+	if effect := instanceOf(template, "shimmer_1"); effect.Duration != 0 {
+		t.Errorf("permanent effect got duration %v", effect.Duration)
+	}
+}
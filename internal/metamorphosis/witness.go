@@ -0,0 +1,82 @@
+package metamorphosis
+
+import "echo-taiga/internal/engine/ecs"
+
+// Параметры наблюдения метаморфоз игроком
+const (
+	MinWitnessOrder        = OrderThird // Минимальный порядок эффекта, который запоминается игроком
+	DefaultWitnessDistance = 192.0      // Дальность наблюдения (радиус активных чанков)
+)
+
+// LineOfSight проверяет прямую видимость между точками (например, world.World)
+type LineOfSight interface {
+	HasLineOfSight(from, to ecs.Vector3) bool
+}
+
+// SetLineOfSight задает проверку видимости для наблюдения эффектов.
+// Без нее эффект считается увиденным, если он в пределах дальности наблюдения.
+func (mm *MetamorphosisManager) SetLineOfSight(provider LineOfSight) {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	mm.lineOfSight = provider
+}
+
+// queueWitness откладывает проверку, видел ли игрок применение эффекта, до
+// освобождения мьютекса (см. flushWitnessed): проверка видимости обращается к
+// миру, а OnEffectWitnessed - к другим системам со своими мьютексами.
+// Вызывается при захваченном мьютексе.
+func (mm *MetamorphosisManager) queueWitness(effect *MetamorphEffect) {
+	if mm.OnEffectWitnessed == nil || effect.Order < MinWitnessOrder || mm.fastForwarding {
+		return
+	}
+	mm.witnessQueue = append(mm.witnessQueue, effect.Clone())
+}
+
+// flushWitnessed вызывает OnEffectWitnessed для отложенных эффектов, которые видел
+// игрок. Вызывается без захваченного мьютекса.
+func (mm *MetamorphosisManager) flushWitnessed() {
+	mm.mutex.Lock()
+	queue := mm.witnessQueue
+	mm.witnessQueue = nil
+	callback := mm.OnEffectWitnessed
+	lineOfSight := mm.lineOfSight
+	witnessDistance := mm.witnessDistance
+	mm.mutex.Unlock()
+
+	if callback == nil {
+		return
+	}
+	for _, effect := range queue {
+		if mm.isWitnessed(effect, lineOfSight, witnessDistance) {
+			callback(effect)
+		}
+	}
+}
+
+// isWitnessed проверяет, находится ли эффект в поле зрения игрока.
+// Эффекты без области действуют повсюду и видны всегда.
+func (mm *MetamorphosisManager) isWitnessed(effect *MetamorphEffect, lineOfSight LineOfSight, witnessDistance float64) bool {
+	if effect.AffectedArea == nil {
+		return true
+	}
+
+	playerEntities := mm.world.GetEntitiesWithTag(TagPlayer)
+	if len(playerEntities) == 0 {
+		return false
+	}
+	transform, has := ecs.ComponentAs[*ecs.TransformComponent](playerEntities[0], ecs.TransformComponentID)
+	if !has {
+		return false
+	}
+
+	center := effect.AffectedArea.Center
+	distance := transform.Position.Distance(center) - effect.AffectedArea.Radius
+	if distance > witnessDistance {
+		return false
+	}
+	if distance <= 0 || lineOfSight == nil {
+		return true
+	}
+	return lineOfSight.HasLineOfSight(transform.Position, center)
+}
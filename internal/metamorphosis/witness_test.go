@@ -0,0 +1,98 @@
+package metamorphosis
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// lockingSight - проверка видимости, которая, как мир, обращается к менеджеру.
+// Вызов при захваченном мьютексе менеджера заблокировал бы ее навсегда.
+type lockingSight struct {
+	mm      *MetamorphosisManager
+	visible bool
+	calls   int
+}
+
+func (ls *lockingSight) HasLineOfSight(from, to ecs.Vector3) bool {
+	ls.calls++
+	ls.mm.GetAnomalyBudget()
+	return ls.visible
+}
+
+// newWitnessTestManager создает менеджер с игроком в начале координат и
+// шаблоном эффекта третьего порядка
+func newWitnessTestManager(t *testing.T, visible bool) (*MetamorphosisManager, *lockingSight, *[]string) {
+	t.Helper()
+
+	mm, world := newTestManager(t)
+	setTestBudget(mm, 1000)
+	mm.orderThresholds[OrderThird] = 0
+	mm.RegisterEffectTemplate(&MetamorphEffect{ID: "rift", Name: "Rift", Order: OrderThird, Category: "reality", Intensity: 0.5})
+
+	addTestPlayer(world, ecs.Vector3{})
+
+	sight := &lockingSight{mm: mm, visible: visible}
+	mm.SetLineOfSight(sight)
+
+	witnessed := make([]string, 0)
+	mm.OnEffectWitnessed = func(effect *MetamorphEffect) {
+		// Получатель обращается к менеджеру так же, как система символов
+		mm.GetActiveEffects()
+		witnessed = append(witnessed, effect.Name)
+	}
+	return mm, sight, &witnessed
+}
+
+// requestWithin запрашивает эффект, проваливая тест при взаимной блокировке
+func requestWithin(t *testing.T, mm *MetamorphosisManager, request EffectRequest) {
+	t.Helper()
+
+	done := make(chan error)
+	go func() {
+		_, err := mm.RequestEffect(request)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("RequestEffect: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("RequestEffect did not return: witness check ran under mm.mutex")
+	}
+}
+
+func TestWitnessedEffectNotifiesAfterUnlock(t *testing.T) {
+	mm, sight, witnessed := newWitnessTestManager(t, true)
+
+	requestWithin(t, mm, EffectRequest{Order: OrderThird, Center: ecs.Vector3{X: 60}, Radius: 10, Intensity: 0.5})
+
+	if sight.calls != 1 {
+		t.Errorf("line of sight checked %d times, want 1", sight.calls)
+	}
+	if len(*witnessed) != 1 || (*witnessed)[0] != "Rift" {
+		t.Errorf("witnessed %v, want [Rift]", *witnessed)
+	}
+}
+
+func TestEffectBehindTerrainIsNotWitnessed(t *testing.T) {
+	mm, _, witnessed := newWitnessTestManager(t, false)
+
+	requestWithin(t, mm, EffectRequest{Order: OrderThird, Center: ecs.Vector3{X: 60}, Radius: 10, Intensity: 0.5})
+
+	if len(*witnessed) != 0 {
+		t.Errorf("witnessed %v through terrain", *witnessed)
+	}
+}
+
+func TestDistantEffectSkipsLineOfSight(t *testing.T) {
+	mm, sight, witnessed := newWitnessTestManager(t, true)
+
+	requestWithin(t, mm, EffectRequest{Order: OrderThird, Center: ecs.Vector3{X: DefaultWitnessDistance + 100}, Radius: 10, Intensity: 0.5})
+
+	if sight.calls != 0 || len(*witnessed) != 0 {
+		t.Errorf("effect beyond witness distance: %d sight checks, witnessed %v", sight.calls, *witnessed)
+	}
+}
@@ -0,0 +1,78 @@
+package metamorphosis
+
+import (
+	"math"
+	"sort"
+)
+
+// GetEffectiveWorldRules возвращает действующие правила мира: изменения WorldChanges
+// всех активных глобальных эффектов (без области воздействия), сведенные в одно значение
+// на правило (см. combineWorldChanges). Эффекты с областью меняют правила только у себя
+// и в общий набор не входят.
+func (mm *MetamorphosisManager) GetEffectiveWorldRules() map[string]float64 {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	effects := make([]*MetamorphEffect, 0, len(mm.activeEffects))
+	for _, effect := range mm.activeEffects {
+		if effect.AffectedArea == nil && !effect.faulted && len(effect.WorldChanges) > 0 {
+			effects = append(effects, effect)
+		}
+	}
+
+	return combineWorldChanges(effects)
+}
+
+// TimeFlowRule - правило мира, задающее темп игрового времени (1 - обычный)
+const TimeFlowRule = "time.flow"
+
+// TimeFlow возвращает темп игрового времени, заданный глобальными эффектами.
+// Без эффектов время течет обычно; отрицательный темп останавливает время.
+func (mm *MetamorphosisManager) TimeFlow() float64 {
+	flow, exists := mm.GetEffectiveWorldRules()[TimeFlowRule]
+	if !exists {
+		return 1.0
+	}
+	return math.Max(0, flow)
+}
+
+// combineWorldChanges сводит изменения правил нескольких эффектов. Правило задает эффект
+// старшего порядка; значения эффектов одного порядка усредняются с весом по интенсивности.
+// Эффекты перебираются по ID, так что результат не зависит от порядка карты.
+func combineWorldChanges(effects []*MetamorphEffect) map[string]float64 {
+	sorted := append([]*MetamorphEffect(nil), effects...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	type ruleContribution struct {
+		order  OrderLevel
+		sum    float64 // Сумма значений с весами
+		weight float64 // Сумма весов
+	}
+	contributions := make(map[string]*ruleContribution)
+
+	for _, effect := range sorted {
+		// Эффект нулевой интенсивности все равно участвует, но с малым весом
+		weight := effect.Intensity
+		if weight <= 0 {
+			weight = 1e-6
+		}
+
+		for rule, value := range effect.WorldChanges {
+			contribution, exists := contributions[rule]
+			if !exists || effect.Order > contribution.order {
+				contributions[rule] = &ruleContribution{order: effect.Order, sum: value * weight, weight: weight}
+				continue
+			}
+			if effect.Order == contribution.order {
+				contribution.sum += value * weight
+				contribution.weight += weight
+			}
+		}
+	}
+
+	rules := make(map[string]float64, len(contributions))
+	for rule, contribution := range contributions {
+		rules[rule] = contribution.sum / contribution.weight
+	}
+	return rules
+}
@@ -0,0 +1,71 @@
+package metamorphosis
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTimeFlowFollowsGlobalEffects(t *testing.T) {
+	mm, _ := newTestManager(t)
+	if flow := mm.TimeFlow(); flow != 1.0 {
+		t.Fatalf("TimeFlow() without effects = %v, want 1", flow)
+	}
+
+	applyTestEffect(mm, &MetamorphEffect{
+		ID: "slow_1", Name: "Slow", Order: OrderSecond, Category: "reality", Intensity: 1.0,
+		WorldChanges: map[string]float64{TimeFlowRule: 0.5},
+	})
+	if flow := mm.TimeFlow(); flow != 0.5 {
+		t.Errorf("TimeFlow() under a slowing effect = %v, want 0.5", flow)
+	}
+
+	// Эффект с областью меняет время только у себя
+	applyTestEffect(mm, &MetamorphEffect{
+		ID: "stop_1", Name: "Stop", Order: OrderThird, Category: "reality", Intensity: 1.0,
+		WorldChanges: map[string]float64{TimeFlowRule: -1},
+		AffectedArea: &AffectedArea{Type: "sphere", Radius: 10},
+	})
+	if flow := mm.TimeFlow(); flow != 0.5 {
+		t.Errorf("TimeFlow() with a local effect = %v, want 0.5", flow)
+	}
+}
+
+func TestGravityOfTwoGlobalEffectsCombines(t *testing.T) {
+	rules := func(ids ...string) float64 {
+		mm, _ := newTestManager(t)
+		effects := map[string]*MetamorphEffect{
+			"light_1": {ID: "light_1", Name: "Light", Order: OrderFourth, Category: "reality", Intensity: 1.0,
+				WorldChanges: map[string]float64{"physics.gravity": 0.5}},
+			"heavy_1": {ID: "heavy_1", Name: "Heavy", Order: OrderFourth, Category: "reality", Intensity: 0.5,
+				WorldChanges: map[string]float64{"physics.gravity": 2.0}},
+		}
+		for _, id := range ids {
+			applyTestEffect(mm, effects[id])
+		}
+		return mm.GetEffectiveWorldRules()["physics.gravity"]
+	}
+
+	// Значения усредняются с весом по интенсивности: (0.5*1 + 2*0.5) / 1.5
+	want := 1.0
+	for _, order := range [][]string{{"light_1", "heavy_1"}, {"heavy_1", "light_1"}} {
+		if got := rules(order...); math.Abs(got-want) > 1e-9 {
+			t.Errorf("gravity with effects applied as %v = %v, want %v", order, got, want)
+		}
+	}
+}
+
+func TestHigherOrderEffectSetsGravity(t *testing.T) {
+	mm, _ := newTestManager(t)
+	applyTestEffect(mm, &MetamorphEffect{
+		ID: "light_1", Name: "Light", Order: OrderFourth, Category: "reality", Intensity: 1.0,
+		WorldChanges: map[string]float64{"physics.gravity": 0.5},
+	})
+	applyTestEffect(mm, &MetamorphEffect{
+		ID: "drift_1", Name: "Drift", Order: OrderFifth, Category: "reality", Intensity: 0.1,
+		WorldChanges: map[string]float64{"physics.gravity": 0.2},
+	})
+
+	if got := mm.GetEffectiveWorldRules()["physics.gravity"]; math.Abs(got-0.2) > 1e-9 {
+		t.Errorf("gravity = %v, want the fifth-order 0.2", got)
+	}
+}
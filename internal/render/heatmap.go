@@ -0,0 +1,97 @@
+package render
+
+import (
+	"image"
+	"image/color"
+
+	"echo-taiga/internal/world"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// heatmapResolution - количество ячеек тепловой карты по стороне чанка
+const heatmapResolution = 16
+
+// heatmapImage - отрисованное поле аномальности чанка и поколение полей,
+// для которого оно отрисовано (см. world.AnomalyFieldGeneration)
+type heatmapImage struct {
+	generation uint64
+	image      *ebiten.Image
+}
+
+// SetHeatmapEnabled включает или выключает отрисовку тепловой карты аномалий
+func (r *Renderer) SetHeatmapEnabled(enabled bool) {
+	r.heatmapEnabled = enabled
+}
+
+// renderHeatmap отрисовывает интенсивность аномалий поверх активных чанков.
+// При выключенной тепловой карте рисуются только поля, открытые ритуалами.
+// Изображения полей перерисовываются, только когда поля изменились, а
+// изображения чанков, которые больше не рисуются, освобождаются.
+func (r *Renderer) renderHeatmap(screen *ebiten.Image, gameWorld *world.World) {
+	playerPos := gameWorld.PlayerPosition
+	generation := gameWorld.AnomalyFieldGeneration()
+	if r.heatmapImages == nil {
+		r.heatmapImages = make(map[[2]int]heatmapImage)
+	}
+
+	drawn := make(map[[2]int]bool)
+	chunks, _ := gameWorld.ActiveChunkEntities()
+	for _, chunk := range chunks {
+		pos := chunk.Position
+		if !r.heatmapEnabled && !gameWorld.IsAnomalyFieldRevealed(pos) {
+			continue
+		}
+		drawn[pos] = true
+
+		cached, exists := r.heatmapImages[pos]
+		if !exists || cached.generation != generation {
+			if exists {
+				cached.image.Dispose()
+			}
+			cached = heatmapImage{generation: generation, image: drawHeatmapField(gameWorld.GetAnomalyField(pos, heatmapResolution))}
+			r.heatmapImages[pos] = cached
+		}
+
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(
+			float64(pos[0])*world.ChunkSize-playerPos.X+float64(r.config.WindowWidth)/2,
+			float64(pos[1])*world.ChunkSize-playerPos.Z+float64(r.config.WindowHeight)/2,
+		)
+		screen.DrawImage(cached.image, op)
+	}
+
+	for pos, cached := range r.heatmapImages {
+		if !drawn[pos] {
+			cached.image.Dispose()
+			delete(r.heatmapImages, pos)
+		}
+	}
+}
+
+// drawHeatmapField рисует поле аномальности чанка на отдельном изображении
+func drawHeatmapField(field [][]float64) *ebiten.Image {
+	cellSize := int(world.ChunkSize) / heatmapResolution
+
+	overlay := ebiten.NewImage(int(world.ChunkSize), int(world.ChunkSize))
+	for x := 0; x < heatmapResolution && x < len(field); x++ {
+		for z := 0; z < heatmapResolution && z < len(field[x]); z++ {
+			intensity := field[x][z]
+			if intensity <= 0 {
+				continue
+			}
+
+			// От холодного синего к горячему красному
+			cellColor := color.RGBA{
+				R: uint8(255 * intensity),
+				G: 0,
+				B: uint8(255 * (1 - intensity)),
+				A: uint8(60 + 120*intensity),
+			}
+
+			cell := overlay.SubImage(image.Rect(x*cellSize, z*cellSize, (x+1)*cellSize, (z+1)*cellSize)).(*ebiten.Image)
+			cell.Fill(cellColor)
+		}
+	}
+	return overlay
+}
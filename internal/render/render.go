@@ -17,6 +17,13 @@ type Renderer struct {
 	config         *config.Config
 	pixelFont      *ebiten.Image
 	backgroundTile *ebiten.Image
+
+	// Отладочная тепловая карта аномалий
+	heatmapEnabled bool
+	heatmapImages  map[[2]int]heatmapImage // Отрисованные поля чанков (см. renderHeatmap)
+
+	// Доля пройденного шага симуляции для сглаживания движения сущностей
+	interpolation float64
 }
 
 // NewRenderer создает новый рендерер
@@ -34,6 +41,12 @@ func NewRenderer(cfg *config.Config) (*Renderer, error) {
 	return renderer, nil
 }
 
+// SetInterpolation задает долю пройденного шага симуляции (0-1), с которой
+// сущности отрисовываются между предыдущим и текущим положением
+func (r *Renderer) SetInterpolation(alpha float64) {
+	r.interpolation = alpha
+}
+
 // initializeResources инициализирует графические ресурсы
 func (r *Renderer) initializeResources() error {
 	// Создаем базовый тайл для фона
@@ -60,6 +73,9 @@ func (r *Renderer) Render(screen *ebiten.Image, gameWorld *world.World, player *
 	// Рисуем чанки
 	r.renderChunks(screen, gameWorld)
 
+	// Тепловая карта аномалий поверх ландшафта (и поля, открытые ритуалами)
+	r.renderHeatmap(screen, gameWorld)
+
 	// Рисуем сущности
 	r.renderEntities(screen, gameWorld)
 
@@ -167,8 +183,9 @@ func (r *Renderer) renderChunkTerrain(screen *ebiten.Image, chunk *world.Chunk,
 
 // renderEntities отрисовывает сущности в мире
 func (r *Renderer) renderEntities(screen *ebiten.Image, gameWorld *world.World) {
-	// Получаем все активные чанки
-	for _, chunk := range gameWorld.ActiveChunks {
+	// Получаем снимок сущностей всех активных чанков
+	chunks, _ := gameWorld.ActiveChunkEntities()
+	for _, chunk := range chunks {
 		// Отрисовываем сущности чанка
 		for _, entityID := range chunk.Entities {
 			entity, exists := gameWorld.ECSWorld.GetEntity(entityID)
@@ -199,10 +216,11 @@ func (r *Renderer) renderEntities(screen *ebiten.Image, gameWorld *world.World)
 			entityImage := ebiten.NewImage(10, 10)
 			entityImage.Fill(render.Color)
 
+			position := transform.InterpolatedPosition(r.interpolation)
 			op := &ebiten.DrawImageOptions{}
 			op.GeoM.Translate(
-				transform.Position.X-gameWorld.PlayerPosition.X+float64(r.config.WindowWidth)/2,
-				transform.Position.Z-gameWorld.PlayerPosition.Z+float64(r.config.WindowHeight)/2,
+				position.X-gameWorld.PlayerPosition.X+float64(r.config.WindowWidth)/2,
+				position.Z-gameWorld.PlayerPosition.Z+float64(r.config.WindowHeight)/2,
 			)
 
 			screen.DrawImage(entityImage, op)
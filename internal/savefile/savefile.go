@@ -0,0 +1,199 @@
+package savefile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// File name suffixes written next to each save
+const (
+	ChecksumSuffix = ".sha256"
+	BackupSuffix   = ".bak"
+	tempSuffix     = ".tmp"
+)
+
+// ErrCorrupt is returned when neither a save nor its backup passes the checksum
+var ErrCorrupt = errors.New("save file is corrupt and no valid backup exists")
+
+// gzipMagic starts every gzip stream; JSON saves never start with it
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// readable makes saves indented and uncompressed (see SetReadable)
+var readable atomic.Bool
+
+// SetReadable switches new saves to indented, uncompressed JSON for debugging.
+// Read accepts compressed and uncompressed saves either way.
+func SetReadable(enabled bool) {
+	readable.Store(enabled)
+}
+
+// Marshal encodes v for a save: compact JSON, or indented JSON when readable
+// saves are enabled
+func Marshal(v interface{}) ([]byte, error) {
+	if readable.Load() {
+		return json.MarshalIndent(v, "", "  ")
+	}
+	return json.Marshal(v)
+}
+
+// Checksum returns the hex SHA-256 of data
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Write saves data to path atomically with a companion checksum. The data is
+// gzip-compressed unless readable saves are enabled, and the checksum covers
+// the bytes on disk. The previous save and its checksum are kept as a backup.
+func Write(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	if !readable.Load() {
+		compressed, err := compress(data)
+		if err != nil {
+			return fmt.Errorf("failed to compress %s: %v", path, err)
+		}
+		data = compressed
+	}
+
+	// Keep the previous save (if it is valid) as the backup
+	if _, err := read(path); err == nil {
+		if err := rotate(path); err != nil {
+			return fmt.Errorf("failed to back up %s: %v", path, err)
+		}
+	}
+
+	if err := writeAtomic(path+ChecksumSuffix, []byte(Checksum(data))); err != nil {
+		return err
+	}
+	return writeAtomic(path, data)
+}
+
+// Read loads a save written by Write, decompressing it if needed. If the save
+// is missing, truncated or does not match its checksum, the backup is loaded
+// instead. Saves without a checksum (written before checksums existed) and
+// uncompressed saves are accepted as they are.
+func Read(path string) ([]byte, error) {
+	data, err := read(path)
+	if err == nil {
+		return data, nil
+	}
+
+	backup, backupErr := read(path + BackupSuffix)
+	if backupErr == nil {
+		return backup, nil
+	}
+
+	if os.IsNotExist(err) && os.IsNotExist(backupErr) {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%s: %w", path, ErrCorrupt)
+}
+
+// Exists checks whether a save or its backup is present
+func Exists(path string) bool {
+	if _, err := os.Stat(path); err == nil {
+		return true
+	}
+	_, err := os.Stat(path + BackupSuffix)
+	return err == nil
+}
+
+// read loads a single save file, verifies its checksum and decompresses it
+func read(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := ioutil.ReadFile(path + ChecksumSuffix)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil && strings.TrimSpace(string(expected)) != Checksum(data) {
+		return nil, fmt.Errorf("%s: checksum mismatch", path)
+	}
+
+	if !bytes.HasPrefix(data, gzipMagic) {
+		return data, nil
+	}
+	data, err = decompress(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return data, nil
+}
+
+// compress gzips data
+func compress(data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// decompress reads a gzip stream written by compress
+func decompress(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// rotate moves the current save and its checksum to the backup slot
+func rotate(path string) error {
+	err := copyFile(path+ChecksumSuffix, path+BackupSuffix+ChecksumSuffix)
+	if os.IsNotExist(err) {
+		// Saves from before checksums: the backup has none either
+		err = os.Remove(path + BackupSuffix + ChecksumSuffix)
+		if os.IsNotExist(err) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return copyFile(path, path+BackupSuffix)
+}
+
+// copyFile atomically replaces dst with the contents of src
+func copyFile(src, dst string) error {
+	data, err := ioutil.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return writeAtomic(dst, data)
+}
+
+// writeAtomic writes data to a temporary file and renames it over path, so a
+// crash never leaves a partially written file behind
+func writeAtomic(path string, data []byte) error {
+	tempPath := path + tempSuffix
+	if err := ioutil.WriteFile(tempPath, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package savefile
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setReadable switches readable saves for the duration of a test
+func setReadable(t *testing.T, enabled bool) {
+	t.Helper()
+
+	previous := readable.Load()
+	SetReadable(enabled)
+	t.Cleanup(func() { SetReadable(previous) })
+}
+
+func TestCompressedAndUncompressedSavesLoadSideBySide(t *testing.T) {
+	dir := t.TempDir()
+	compressedPath := filepath.Join(dir, "symbols.json")
+	readablePath := filepath.Join(dir, "rituals.json")
+	legacyPath := filepath.Join(dir, "knowledge.json")
+
+	setReadable(t, false)
+	if err := Write(compressedPath, []byte(`{"kind":"compressed"}`)); err != nil {
+		t.Fatalf("Write compressed: %v", err)
+	}
+	setReadable(t, true)
+	if err := Write(readablePath, []byte(`{"kind":"readable"}`)); err != nil {
+		t.Fatalf("Write readable: %v", err)
+	}
+	// A save from before checksums and compression
+	if err := ioutil.WriteFile(legacyPath, []byte(`{"kind":"legacy"}`), 0644); err != nil {
+		t.Fatalf("WriteFile legacy: %v", err)
+	}
+
+	onDisk, err := ioutil.ReadFile(compressedPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !bytes.HasPrefix(onDisk, gzipMagic) {
+		t.Errorf("save written without readable saves is not gzip-compressed")
+	}
+
+	for path, want := range map[string]string{
+		compressedPath: `{"kind":"compressed"}`,
+		readablePath:   `{"kind":"readable"}`,
+		legacyPath:     `{"kind":"legacy"}`,
+	} {
+		data, err := Read(path)
+		if err != nil {
+			t.Errorf("Read(%s): %v", filepath.Base(path), err)
+			continue
+		}
+		if string(data) != want {
+			t.Errorf("Read(%s) = %s, want %s", filepath.Base(path), data, want)
+		}
+	}
+}
+
+func TestCompressedSaveFallsBackToUncompressedBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "world.json")
+
+	// The old save is readable, the new one compressed
+	setReadable(t, true)
+	if err := Write(path, []byte(`{"version":1}`)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	setReadable(t, false)
+	if err := Write(path, []byte(`{"version":2}`)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// Corrupt the compressed save: its checksum no longer matches
+	if err := ioutil.WriteFile(path, append([]byte{}, gzipMagic...), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	data, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if string(data) != `{"version":1}` {
+		t.Errorf("Read = %s, want the uncompressed backup", data)
+	}
+}
+
+// truncate cuts a file in half, like a crash in the middle of writing it
+func truncate(t *testing.T, path string) {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if err := ioutil.WriteFile(path, data[:len(data)/2], 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+func TestTruncatedSaveLoadsBackup(t *testing.T) {
+	for _, readableSaves := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "metamorphosis_state.json")
+		setReadable(t, readableSaves)
+
+		if err := Write(path, []byte(`{"cycles":1}`)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := Write(path, []byte(`{"cycles":2}`)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		truncate(t, path)
+
+		data, err := Read(path)
+		if err != nil {
+			t.Fatalf("Read of truncated save (readable %v): %v", readableSaves, err)
+		}
+		if string(data) != `{"cycles":1}` {
+			t.Errorf("Read of truncated save (readable %v) = %s, want the backup", readableSaves, data)
+		}
+	}
+}
+
+func TestTruncatedSaveWithoutBackupIsCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "symbols.json")
+	setReadable(t, false)
+
+	if err := Write(path, []byte(`{"symbols":[]}`)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	truncate(t, path)
+
+	if _, err := Read(path); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Read of truncated save without backup = %v, want ErrCorrupt", err)
+	}
+	if _, err := Read(filepath.Join(filepath.Dir(path), "missing.json")); !os.IsNotExist(err) {
+		t.Errorf("Read of missing save = %v, want a not-exist error", err)
+	}
+}
+
+func TestWriteKeepsOneBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profile.json")
+	setReadable(t, true)
+
+	for _, version := range []string{`{"v":1}`, `{"v":2}`, `{"v":3}`} {
+		if err := Write(path, []byte(version)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	backup, err := read(path + BackupSuffix)
+	if err != nil {
+		t.Fatalf("read backup: %v", err)
+	}
+	if string(backup) != `{"v":2}` {
+		t.Errorf("backup = %s, want the previous save", backup)
+	}
+	if _, err := os.Stat(path + tempSuffix); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind after writing: %v", err)
+	}
+}
+
+func TestMarshalFollowsReadableSaves(t *testing.T) {
+	value := map[string]int{"day": 3}
+
+	setReadable(t, false)
+	compact, err := Marshal(value)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	setReadable(t, true)
+	indented, err := Marshal(value)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	if string(compact) != `{"day":3}` {
+		t.Errorf("compact Marshal = %s", compact)
+	}
+	if !bytes.Contains(indented, []byte("\n  ")) {
+		t.Errorf("readable Marshal = %s, want indented JSON", indented)
+	}
+}
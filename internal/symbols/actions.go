@@ -0,0 +1,82 @@
+package symbols
+
+import (
+	"fmt"
+	"math"
+)
+
+// ActionConfig controls how closely the player must follow a ritual's steps
+type ActionConfig struct {
+	SimpleMode bool    // Skip the check for casual play: every ritual counts as performed by the book
+	MinFactor  float64 // Share of the success chance kept when none of the steps match
+}
+
+// DefaultActionConfig returns the default action check settings
+func DefaultActionConfig() ActionConfig {
+	return ActionConfig{
+		SimpleMode: false,
+		MinFactor:  0.4,
+	}
+}
+
+// Validate checks that the action check settings are within usable ranges
+func (ac ActionConfig) Validate() error {
+	if ac.MinFactor < 0 || ac.MinFactor > 1 {
+		return fmt.Errorf("MinFactor must be between 0 and 1, got %.2f", ac.MinFactor)
+	}
+	return nil
+}
+
+// SetActionConfig validates and applies new action check settings
+func (sm *Manager) SetActionConfig(config ActionConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid action config: %v", err)
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.Actions = config
+	return nil
+}
+
+// actionFactor scales a ritual's success chance by how well the performed steps
+// follow its required actions. Rituals without actions and simple mode always
+// get the full chance.
+func (ac ActionConfig) actionFactor(ritual *Ritual, performed []string) float64 {
+	if ac.SimpleMode || len(ritual.Actions) == 0 {
+		return 1.0
+	}
+	return ac.MinFactor + (1.0-ac.MinFactor)*actionAccuracy(ritual.Actions, performed)
+}
+
+// actionAccuracy rates a performed sequence against the required one (0-1): the
+// longest run of steps done in the right order, over the longer of the two
+// sequences, so that missing, extra and scrambled steps all count against it
+func actionAccuracy(required, performed []string) float64 {
+	longest := math.Max(float64(len(required)), float64(len(performed)))
+	if longest == 0 {
+		return 1.0
+	}
+	return float64(commonSubsequence(required, performed)) / longest
+}
+
+// commonSubsequence returns the length of the longest common subsequence of two sequences
+func commonSubsequence(a, b []string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			switch {
+			case a[i] == b[j]:
+				current[j+1] = previous[j] + 1
+			case previous[j+1] > current[j]:
+				current[j+1] = previous[j+1]
+			default:
+				current[j+1] = current[j]
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
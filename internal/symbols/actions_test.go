@@ -0,0 +1,65 @@
+package symbols
+
+import "testing"
+
+// actionRitual returns a ritual with three steps to perform in order
+func actionRitual() *Ritual {
+	return &Ritual{ID: "test_kindling", Actions: []string{"draw circle", "light fire", "chant"}}
+}
+
+func TestActionsInOrderKeepFullChance(t *testing.T) {
+	config := DefaultActionConfig()
+	ritual := actionRitual()
+
+	if factor := config.actionFactor(ritual, ritual.Actions); factor != 1.0 {
+		t.Errorf("steps in order scaled the chance by %.3f, want 1", factor)
+	}
+	if factor := config.actionFactor(&Ritual{ID: "test_plain"}, nil); factor != 1.0 {
+		t.Errorf("ritual without actions scaled the chance by %.3f, want 1", factor)
+	}
+}
+
+func TestSloppyActionsLowerChance(t *testing.T) {
+	config := DefaultActionConfig()
+	ritual := actionRitual()
+
+	tests := []struct {
+		name      string
+		performed []string
+		want      float64
+	}{
+		{"scrambled", []string{"chant", "light fire", "draw circle"}, 1.0 / 3},
+		{"one missing", []string{"draw circle", "chant"}, 2.0 / 3},
+		{"one extra", []string{"draw circle", "light fire", "spit", "chant"}, 3.0 / 4},
+		{"none performed", nil, 0},
+		{"all wrong", []string{"dance", "sing", "sleep"}, 0},
+	}
+	for _, tt := range tests {
+		want := config.MinFactor + (1-config.MinFactor)*tt.want
+		factor := config.actionFactor(ritual, tt.performed)
+		if diff := factor - want; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("%s: factor %.3f, want %.3f", tt.name, factor, want)
+		}
+		if factor >= 1.0 {
+			t.Errorf("%s: kept the full chance", tt.name)
+		}
+	}
+}
+
+func TestSimpleModeSkipsActionCheck(t *testing.T) {
+	sm, _ := newTestManager(t, 1)
+	config := DefaultActionConfig()
+	config.SimpleMode = true
+	if err := sm.SetActionConfig(config); err != nil {
+		t.Fatalf("SetActionConfig: %v", err)
+	}
+
+	if factor := sm.Actions.actionFactor(actionRitual(), []string{"chant"}); factor != 1.0 {
+		t.Errorf("simple mode scaled the chance by %.3f, want 1", factor)
+	}
+
+	config.MinFactor = 1.5
+	if err := sm.SetActionConfig(config); err == nil {
+		t.Errorf("MinFactor 1.5 accepted")
+	}
+}
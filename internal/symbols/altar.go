@@ -0,0 +1,94 @@
+package symbols
+
+import (
+	"fmt"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// ConsecrateAltar dedicates an altar entity to a ritual so offerings can be placed on it
+func (sm *Manager) ConsecrateAltar(altarID ecs.EntityID, ritualID string) error {
+	entity, exists := sm.world.GetEntity(altarID)
+	if !exists {
+		return fmt.Errorf("altar %s does not exist", altarID)
+	}
+
+	ritual := sm.RitualRegistry.GetRitual(ritualID)
+	if ritual == nil {
+		return fmt.Errorf("ritual %s does not exist", ritualID)
+	}
+
+	entity.AddComponent(ecs.NewRitualAltarComponent(ritual.ID, ritual.RequiredItems))
+	return nil
+}
+
+// PlaceOffering places an item on an altar. Items the altar's ritual does not need are rejected.
+func (sm *Manager) PlaceOffering(altarID ecs.EntityID, item string) error {
+	altar, err := sm.getAltar(altarID)
+	if err != nil {
+		return err
+	}
+
+	if !altar.AddOffering(item) {
+		return fmt.Errorf("altar %s does not accept %q for ritual %s", altarID, item, altar.RitualID)
+	}
+
+	return nil
+}
+
+// GetAltarProgress returns the share of offerings placed on an altar (0-1) and whether its ritual is ready
+func (sm *Manager) GetAltarProgress(altarID ecs.EntityID) (float64, bool) {
+	altar, err := sm.getAltar(altarID)
+	if err != nil {
+		return 0.0, false
+	}
+
+	return altar.Progress(), altar.Ready
+}
+
+// PerformRitualAtAltar performs the altar's ritual using the offerings placed on it
+// and the steps the player performed. The offerings are consumed whether or not
+// the ritual succeeds, except for those a near miss returns, which stay on the altar.
+func (sm *Manager) PerformRitualAtAltar(altarID ecs.EntityID, performedActions []string) (RitualResult, error) {
+	altar, err := sm.getAltar(altarID)
+	if err != nil {
+		return RitualResult{}, err
+	}
+
+	if !altar.Ready {
+		return RitualResult{}, fmt.Errorf("altar %s is missing offerings for ritual %s", altarID, altar.RitualID)
+	}
+
+	ritual := sm.RitualRegistry.GetRitual(altar.RitualID)
+	if ritual == nil {
+		return RitualResult{}, fmt.Errorf("ritual %s does not exist", altar.RitualID)
+	}
+
+	entity, _ := sm.world.GetEntity(altarID)
+	location, _ := entityPosition(entity)
+
+	items := append([]string(nil), altar.Offerings...)
+	result := sm.PerformRitual(ritual, location, items, performedActions)
+
+	altar.Clear()
+	for _, item := range result.Refunded {
+		altar.AddOffering(item)
+	}
+
+	return result, nil
+}
+
+// getAltar returns the altar component of an entity
+func (sm *Manager) getAltar(altarID ecs.EntityID) (*ecs.RitualAltarComponent, error) {
+	entity, exists := sm.world.GetEntity(altarID)
+	if !exists {
+		return nil, fmt.Errorf("altar %s does not exist", altarID)
+	}
+
+	altarComp, has := entity.GetComponent(ecs.RitualAltarComponentID)
+	if !has {
+		return nil, fmt.Errorf("entity %s is not a consecrated altar", altarID)
+	}
+
+	return altarComp.(*ecs.RitualAltarComponent), nil
+}
@@ -0,0 +1,92 @@
+package symbols
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newAltarTestManager creates a manager with an altar consecrated to a ritual
+// that needs a bone and two feathers
+func newAltarTestManager(t *testing.T) (*Manager, ecs.EntityID) {
+	t.Helper()
+
+	sm, world := newTestManager(t, 1, testSymbol("test_hush", "elemental"))
+	sm.RitualRegistry.AddRitual(&Ritual{
+		ID:              "test_offering",
+		Name:            "Offering",
+		RequiredSymbols: []string{"test_hush"},
+		RequiredItems:   []string{"bone", "feather", "feather"},
+		Difficulty:      0.5,
+		SuccessChance:   0.8,
+		IsDiscovered:    true,
+	})
+
+	altar := ecs.NewEntity()
+	altar.AddComponent(ecs.NewTransformComponent(ecs.Vector3{X: 5}))
+	world.AddEntity(altar)
+	if err := sm.ConsecrateAltar(altar.ID, "test_offering"); err != nil {
+		t.Fatalf("ConsecrateAltar: %v", err)
+	}
+	return sm, altar.ID
+}
+
+func TestAltarIsReadyOnceEveryOfferingIsPlaced(t *testing.T) {
+	sm, altar := newAltarTestManager(t)
+
+	for i, item := range []string{"feather", "bone", "feather"} {
+		if _, ready := sm.GetAltarProgress(altar); ready {
+			t.Fatalf("altar ready after %d of 3 offerings", i)
+		}
+		if err := sm.PlaceOffering(altar, item); err != nil {
+			t.Fatalf("PlaceOffering(%q): %v", item, err)
+		}
+	}
+
+	progress, ready := sm.GetAltarProgress(altar)
+	if !ready || progress != 1.0 {
+		t.Errorf("progress %v, ready %v after all offerings, want 1 and ready", progress, ready)
+	}
+}
+
+func TestAltarRejectsWrongAndSurplusOfferings(t *testing.T) {
+	sm, altar := newAltarTestManager(t)
+
+	if err := sm.PlaceOffering(altar, "pebble"); err == nil {
+		t.Errorf("altar accepted an item its ritual does not need")
+	}
+	if err := sm.PlaceOffering(altar, "bone"); err != nil {
+		t.Fatalf("PlaceOffering(bone): %v", err)
+	}
+	if err := sm.PlaceOffering(altar, "bone"); err == nil {
+		t.Errorf("altar accepted a second bone for a ritual that needs one")
+	}
+	if progress, _ := sm.GetAltarProgress(altar); progress != 1.0/3.0 {
+		t.Errorf("progress %v after one accepted offering, want 1/3", progress)
+	}
+}
+
+func TestPerformingAtAltarUsesItsOfferings(t *testing.T) {
+	sm, altar := newAltarTestManager(t)
+
+	if _, err := sm.PerformRitualAtAltar(altar, nil); err == nil {
+		t.Errorf("performed the ritual at an altar without offerings")
+	}
+
+	for _, item := range []string{"bone", "feather", "feather"} {
+		sm.PlaceOffering(altar, item)
+	}
+	result, err := sm.PerformRitualAtAltar(altar, nil)
+	if err != nil {
+		t.Fatalf("PerformRitualAtAltar: %v", err)
+	}
+
+	// Offerings are used up, except for any a near miss returns
+	progress, _ := sm.GetAltarProgress(altar)
+	if want := float64(len(result.Refunded)) / 3.0; progress != want {
+		t.Errorf("progress %v after the ritual (%d refunded), want %v", progress, len(result.Refunded), want)
+	}
+	if got := sm.RitualRegistry.GetRitual("test_offering").TimesPerformed; got != 1 {
+		t.Errorf("ritual performed %d times, want 1", got)
+	}
+}
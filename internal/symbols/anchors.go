@@ -0,0 +1,46 @@
+package symbols
+
+import "echo-taiga/internal/engine/ecs"
+
+// Stability anchor settings for ritual effects
+const (
+	DefaultAnchorStrength = 2     // Lowest metamorphosis order an anchor blocks, unless the effect sets one
+	AnchorRechargeRadius  = 10.0  // Anchors within this distance of the ritual are recharged
+	AnchorChargePerValue  = 100.0 // Charge restored per unit of a recharge effect's value
+)
+
+// AnchorKeeper places and recharges stability anchors in the world
+type AnchorKeeper interface {
+	PlaceAnchor(position ecs.Vector3, strength int) *ecs.Entity
+	RechargeAnchors(position ecs.Vector3, radius, amount float64) int
+}
+
+// SetAnchorKeeper sets the system that carries out stability anchor effects of rituals
+func (sm *Manager) SetAnchorKeeper(keeper AnchorKeeper) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.anchorKeeper = keeper
+}
+
+// applyAnchorEffects places anchors for "create_anchor" effects and recharges nearby
+// anchors for "recharge_anchor" effects. Must be called with sm.mutex held.
+func (sm *Manager) applyAnchorEffects(effects []RitualEffect, location ecs.Vector3) {
+	if sm.anchorKeeper == nil {
+		return
+	}
+
+	for _, effect := range effects {
+		switch effect.Type {
+		case "create_anchor":
+			strength := effect.MetamorphOrder
+			if strength <= 0 {
+				strength = DefaultAnchorStrength
+			}
+			sm.anchorKeeper.PlaceAnchor(location, strength)
+
+		case "recharge_anchor":
+			sm.anchorKeeper.RechargeAnchors(location, AnchorRechargeRadius, effect.Value*AnchorChargePerValue)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package symbols
+
+import (
+	"fmt"
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// anomalyReportStep is how far a decaying area anomaly must move before the
+// receiver hears about it; decay runs every frame and would otherwise report
+// every area on every frame
+const anomalyReportStep = 0.01
+
+// AnomalyReceiver is implemented by systems that track per-area anomaly levels
+// (e.g. metamorphosis.MetamorphosisManager)
+type AnomalyReceiver interface {
+	SetLocalAnomalyLevel(areaID string, level float64)
+}
+
+// AnomalyFeedbackConfig controls how studied symbols destabilize the world around them
+type AnomalyFeedbackConfig struct {
+	Enabled         bool    // Whether symbol discoveries affect local anomaly levels
+	AreaSize        float64 // Size of an anomaly area in world units
+	BaseAmount      float64 // Anomaly added per discovered symbol
+	PowerMultiplier float64 // Extra anomaly per unit of symbol power
+	MaxLevel        float64 // Cap for the symbol contribution in a single area
+	DecayRate       float64 // Anomaly lost per second in every area
+}
+
+// DefaultAnomalyFeedbackConfig returns the default anomaly feedback settings
+func DefaultAnomalyFeedbackConfig() AnomalyFeedbackConfig {
+	return AnomalyFeedbackConfig{
+		Enabled:         true,
+		AreaSize:        64.0, // Matches the world chunk size
+		BaseAmount:      0.1,
+		PowerMultiplier: 0.1,
+		MaxLevel:        1.0,
+		DecayRate:       0.001,
+	}
+}
+
+// SetAnomalyReceiver sets the system that receives symbol-driven anomaly levels
+func (sm *Manager) SetAnomalyReceiver(receiver AnomalyReceiver) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.anomalyReceiver = receiver
+}
+
+// GetAreaAnomaly returns the current symbol contribution to an area's anomaly level
+func (sm *Manager) GetAreaAnomaly(areaID string) float64 {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	return sm.areaAnomaly[areaID]
+}
+
+// AreaIDForLocation returns the ID of the anomaly area enclosing a world position
+func (sm *Manager) AreaIDForLocation(location ecs.Vector3) string {
+	areaSize := sm.AnomalyFeedback.AreaSize
+	if areaSize <= 0 {
+		areaSize = DefaultAnomalyFeedbackConfig().AreaSize
+	}
+
+	areaX := int(math.Floor(location.X / areaSize))
+	areaZ := int(math.Floor(location.Z / areaSize))
+	return fmt.Sprintf("%d_%d", areaX, areaZ)
+}
+
+// addSymbolAnomaly raises the anomaly level of the area where a symbol was discovered.
+// Must be called with sm.mutex held.
+func (sm *Manager) addSymbolAnomaly(symbol *Symbol, location ecs.Vector3) {
+	config := sm.AnomalyFeedback
+	if !config.Enabled {
+		return
+	}
+
+	// Resonating symbols feed the area's anomaly harder
+	power := symbol.Power * sm.symbolResonance(symbol)
+	sm.raiseAreaAnomaly(location, config.BaseAmount+power*config.PowerMultiplier)
+}
+
+// raiseAreaAnomaly raises the anomaly level of the area around a location, up to
+// the configured cap. Must be called with sm.mutex held.
+func (sm *Manager) raiseAreaAnomaly(location ecs.Vector3, amount float64) {
+	areaID := sm.AreaIDForLocation(location)
+	level := math.Min(sm.AnomalyFeedback.MaxLevel, sm.areaAnomaly[areaID]+amount)
+	sm.areaAnomaly[areaID] = level
+	sm.reportAreaAnomaly(areaID, level)
+}
+
+// reportAreaAnomaly passes an area's anomaly level on to the receiver.
+// Must be called with sm.mutex held.
+func (sm *Manager) reportAreaAnomaly(areaID string, level float64) {
+	if level == 0 {
+		delete(sm.reportedAnomaly, areaID)
+	} else {
+		sm.reportedAnomaly[areaID] = level
+	}
+
+	if sm.anomalyReceiver != nil {
+		sm.anomalyReceiver.SetLocalAnomalyLevel(areaID, level)
+	}
+}
+
+// decaySymbolAnomaly lets symbol-driven anomalies fade over time. The receiver
+// hears of an area once its level has dropped by anomalyReportStep since the
+// last report, and once more when it reaches zero.
+func (sm *Manager) decaySymbolAnomaly(deltaTime float64) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	decay := sm.AnomalyFeedback.DecayRate * deltaTime
+	if decay <= 0 {
+		return
+	}
+
+	for areaID, level := range sm.areaAnomaly {
+		newLevel := math.Max(0.0, level-decay)
+
+		if newLevel == 0 || sm.reportedAnomaly[areaID]-newLevel >= anomalyReportStep {
+			sm.reportAreaAnomaly(areaID, newLevel)
+		}
+
+		if newLevel == 0 {
+			delete(sm.areaAnomaly, areaID)
+		} else {
+			sm.areaAnomaly[areaID] = newLevel
+		}
+	}
+}
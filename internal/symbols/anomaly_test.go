@@ -0,0 +1,82 @@
+package symbols
+
+import (
+	"fmt"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// recordingReceiver remembers every anomaly level passed on to it
+type recordingReceiver struct {
+	calls  int
+	levels map[string]float64
+}
+
+func (rr *recordingReceiver) SetLocalAnomalyLevel(areaID string, level float64) {
+	rr.calls++
+	rr.levels[areaID] = level
+}
+
+// newAnomalyTestManager creates an in-memory manager that reports area anomalies to a recorder
+func newAnomalyTestManager(t *testing.T) (*Manager, *recordingReceiver) {
+	t.Helper()
+
+	sm, _ := newTestManager(t, 1)
+	receiver := &recordingReceiver{levels: make(map[string]float64)}
+	sm.SetAnomalyReceiver(receiver)
+	return sm, receiver
+}
+
+func TestDiscoveriesRaiseTheirAreaAnomaly(t *testing.T) {
+	sm, receiver := newAnomalyTestManager(t)
+
+	studied := ecs.Vector3{X: 10, Z: 10}
+	for i := 0; i < 3; i++ {
+		symbol := &Symbol{ID: fmt.Sprintf("test_rune_%d", i), Name: "Rune", SymbolType: "arcane", Power: 0.5}
+		sm.Registry.AddSymbol(symbol)
+		sm.DiscoverSymbol(symbol, studied)
+	}
+
+	studiedArea := sm.AreaIDForLocation(studied)
+	untouchedArea := sm.AreaIDForLocation(ecs.Vector3{X: 500, Z: 500})
+	if studiedLevel, untouched := sm.GetAreaAnomaly(studiedArea), sm.GetAreaAnomaly(untouchedArea); studiedLevel <= untouched {
+		t.Errorf("area %s with three discoveries has anomaly %.3f, untouched area %s has %.3f",
+			studiedArea, studiedLevel, untouchedArea, untouched)
+	}
+	if receiver.levels[studiedArea] != sm.GetAreaAnomaly(studiedArea) {
+		t.Errorf("receiver has level %.3f for %s, want %.3f", receiver.levels[studiedArea], studiedArea, sm.GetAreaAnomaly(studiedArea))
+	}
+	if _, reported := receiver.levels[untouchedArea]; reported {
+		t.Errorf("untouched area %s was reported", untouchedArea)
+	}
+}
+
+func TestAnomalyDecayIsReportedInSteps(t *testing.T) {
+	sm, receiver := newAnomalyTestManager(t)
+	sm.AnomalyFeedback.DecayRate = 0.001
+
+	location := ecs.Vector3{X: 10, Z: 10}
+	areaID := sm.AreaIDForLocation(location)
+	sm.mutex.Lock()
+	sm.raiseAreaAnomaly(location, 0.5)
+	sm.mutex.Unlock()
+	receiver.calls = 0
+
+	// 100 frames of decay lower the level by 0.1
+	for i := 0; i < 100; i++ {
+		sm.decaySymbolAnomaly(1)
+	}
+	if receiver.calls < 5 || receiver.calls > 15 {
+		t.Errorf("decay over 100 frames made %d reports, want about one per %.2f", receiver.calls, anomalyReportStep)
+	}
+	if diff := receiver.levels[areaID] - sm.GetAreaAnomaly(areaID); diff < 0 || diff >= anomalyReportStep {
+		t.Errorf("reported level %.4f lags the actual %.4f by more than a step", receiver.levels[areaID], sm.GetAreaAnomaly(areaID))
+	}
+
+	// Fading out completely is always reported
+	sm.decaySymbolAnomaly(1000)
+	if level, reported := receiver.levels[areaID]; !reported || level != 0 {
+		t.Errorf("faded area reported at %.4f (reported %v), want 0", level, reported)
+	}
+}
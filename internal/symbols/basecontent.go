@@ -0,0 +1,27 @@
+package symbols
+
+// BaseContent adds base symbols, rituals and effect templates on top of the defaults (e.g. a content pack)
+type BaseContent interface {
+	ApplyToSymbols(manager *Manager)
+}
+
+// AddBaseContent layers content over the default base symbols and rituals.
+// Call it before Initialize: the content is applied right after the defaults
+// load, so saved state and the initial content already see it.
+func (sm *Manager) AddBaseContent(content BaseContent) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.baseContent = append(sm.baseContent, content)
+}
+
+// applyBaseContent applies the layered content in the order it was added
+func (sm *Manager) applyBaseContent() {
+	sm.mutex.RLock()
+	layers := append([]BaseContent(nil), sm.baseContent...)
+	sm.mutex.RUnlock()
+
+	for _, content := range layers {
+		content.ApplyToSymbols(sm)
+	}
+}
@@ -0,0 +1,70 @@
+package symbols
+
+import (
+	"fmt"
+	"math"
+)
+
+// KnowledgeDecayConfig controls how symbol knowledge fades without practice
+type KnowledgeDecayConfig struct {
+	PerDay float64 // Knowledge lost per day (24 hours) of play or absence
+	Floor  float64 // Knowledge at or below this level is never forgotten
+}
+
+// DefaultKnowledgeDecayConfig returns the default knowledge decay settings
+func DefaultKnowledgeDecayConfig() KnowledgeDecayConfig {
+	return KnowledgeDecayConfig{
+		PerDay: 0.05,
+		Floor:  0.3,
+	}
+}
+
+// Validate checks that the knowledge decay settings are within usable ranges
+func (kc KnowledgeDecayConfig) Validate() error {
+	if kc.PerDay < 0 {
+		return fmt.Errorf("PerDay must not be negative, got %.2f", kc.PerDay)
+	}
+	if kc.Floor < 0 || kc.Floor > 1 {
+		return fmt.Errorf("Floor must be between 0 and 1, got %.2f", kc.Floor)
+	}
+	return nil
+}
+
+// SetKnowledgeDecayConfig replaces the knowledge decay settings
+func (sm *Manager) SetKnowledgeDecayConfig(config KnowledgeDecayConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid knowledge decay config: %v", err)
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.KnowledgeDecay = config
+	return nil
+}
+
+// decayKnowledge lets symbol knowledge above the decay floor fade over deltaTime
+// seconds. Ritual proficiency is kept.
+func (sm *Manager) decayKnowledge(deltaTime float64) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	amount := sm.KnowledgeDecay.PerDay * deltaTime / (24 * 60 * 60)
+	if amount <= 0 {
+		return
+	}
+
+	for id, level := range sm.playerKnowledge {
+		if level <= sm.KnowledgeDecay.Floor {
+			continue
+		}
+		symbol := sm.Registry.GetSymbol(id)
+		if symbol == nil {
+			continue
+		}
+
+		level = math.Max(sm.KnowledgeDecay.Floor, level-amount)
+		sm.playerKnowledge[id] = level
+		symbol.KnowledgeLevel = level
+	}
+}
@@ -0,0 +1,60 @@
+package symbols
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFastForwardDecaysSymbolKnowledge(t *testing.T) {
+	sm, _ := newTestManager(t, 1, testSymbol("test_frost", "elemental"), testSymbol("test_thaw", "elemental"))
+
+	sm.mutex.Lock()
+	sm.playerKnowledge["test_frost"] = 0.8
+	sm.playerKnowledge["test_thaw"] = 0.2   // Below the floor: never forgotten
+	sm.playerKnowledge["test_ritual"] = 0.9 // Ritual proficiency does not decay
+	sm.mutex.Unlock()
+
+	sm.FastForward(8 * 60 * 60)
+
+	want := 0.8 - sm.KnowledgeDecay.PerDay/3
+	if got := sm.GetKnowledgeLevel("test_frost"); math.Abs(got-want) > 1e-9 {
+		t.Errorf("knowledge of test_frost after 8 hours = %v, want %v", got, want)
+	}
+	if got := sm.Registry.GetSymbol("test_frost").KnowledgeLevel; math.Abs(got-want) > 1e-9 {
+		t.Errorf("symbol test_frost shows knowledge %v, want %v", got, want)
+	}
+	if got := sm.GetKnowledgeLevel("test_thaw"); got != 0.2 {
+		t.Errorf("knowledge below the floor changed to %v", got)
+	}
+	if got := sm.GetKnowledgeLevel("test_ritual"); got != 0.9 {
+		t.Errorf("ritual proficiency changed to %v", got)
+	}
+}
+
+func TestKnowledgeDecayStopsAtFloor(t *testing.T) {
+	sm, _ := newTestManager(t, 1, testSymbol("test_ember", "elemental"))
+
+	sm.mutex.Lock()
+	sm.playerKnowledge["test_ember"] = 0.5
+	sm.mutex.Unlock()
+
+	// A month away
+	sm.FastForward(30 * 24 * 60 * 60)
+
+	if got := sm.GetKnowledgeLevel("test_ember"); got != sm.KnowledgeDecay.Floor {
+		t.Errorf("knowledge after a month = %v, want the floor %v", got, sm.KnowledgeDecay.Floor)
+	}
+}
+
+func TestKnowledgeDecayConfigValidate(t *testing.T) {
+	if err := DefaultKnowledgeDecayConfig().Validate(); err != nil {
+		t.Errorf("default config is invalid: %v", err)
+	}
+
+	sm, _ := newTestManager(t, 1)
+	for _, config := range []KnowledgeDecayConfig{{PerDay: -1, Floor: 0.3}, {PerDay: 0.1, Floor: 1.5}} {
+		if err := sm.SetKnowledgeDecayConfig(config); err == nil {
+			t.Errorf("SetKnowledgeDecayConfig(%+v) accepted an invalid config", config)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package symbols
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// LineOfSight checks whether terrain hides one point from another (e.g. world.World)
+type LineOfSight interface {
+	HasLineOfSight(from, to ecs.Vector3) bool
+}
+
+// queuedDiscovery is a symbol the player has found but not yet discovered
+type queuedDiscovery struct {
+	entityID ecs.EntityID
+	location ecs.Vector3 // Where the player stood when finding the symbol
+}
+
+// DiscoveryConfig paces symbol discovery, so walking through a cluster of symbols
+// reveals them one by one instead of all at once, and decides what counts as
+// having seen a symbol
+type DiscoveryConfig struct {
+	MaxPerUpdate int     // Most symbols discovered in a single update
+	Cooldown     float64 // Seconds after an update that discovered symbols before the next can
+
+	FieldOfView       float64 // Width of the player's view cone in degrees
+	TouchRadius       float64 // Symbols this close are discovered whichever way the player faces
+	GlimpseRangeScale float64 // Symbols in view within this many discovery radii are glimpsed
+	GlimpseKnowledge  float64 // Knowledge granted by glimpsing a symbol
+
+	FirstOfTypeKnowledge float64 // Initial knowledge of the first symbol discovered of its type
+}
+
+// DefaultDiscoveryConfig returns the default discovery pacing
+func DefaultDiscoveryConfig() DiscoveryConfig {
+	return DiscoveryConfig{
+		MaxPerUpdate: 1,
+		Cooldown:     1.0,
+
+		FieldOfView:       110.0,
+		TouchRadius:       1.5,
+		GlimpseRangeScale: 4.0,
+		GlimpseKnowledge:  0.05,
+
+		FirstOfTypeKnowledge: 0.25,
+	}
+}
+
+// Validate checks that the discovery pacing settings are usable
+func (dc DiscoveryConfig) Validate() error {
+	if dc.MaxPerUpdate < 1 {
+		return fmt.Errorf("MaxPerUpdate must be at least 1, got %d", dc.MaxPerUpdate)
+	}
+	if dc.Cooldown < 0 {
+		return fmt.Errorf("Cooldown must not be negative, got %.2f", dc.Cooldown)
+	}
+	if dc.FieldOfView <= 0 || dc.FieldOfView > 360 {
+		return fmt.Errorf("FieldOfView must be in (0, 360], got %.2f", dc.FieldOfView)
+	}
+	if dc.TouchRadius < 0 {
+		return fmt.Errorf("TouchRadius must not be negative, got %.2f", dc.TouchRadius)
+	}
+	if dc.GlimpseRangeScale < 1 {
+		return fmt.Errorf("GlimpseRangeScale must be at least 1, got %.2f", dc.GlimpseRangeScale)
+	}
+	if dc.GlimpseKnowledge < 0 || dc.GlimpseKnowledge > SketchedSymbolKnowledge {
+		return fmt.Errorf("GlimpseKnowledge must be between 0 and %.2f, got %.2f", SketchedSymbolKnowledge, dc.GlimpseKnowledge)
+	}
+	if dc.FirstOfTypeKnowledge < 0.1 || dc.FirstOfTypeKnowledge > 1 {
+		return fmt.Errorf("FirstOfTypeKnowledge must be between 0.1 and 1, got %.2f", dc.FirstOfTypeKnowledge)
+	}
+	return nil
+}
+
+// SetDiscoveryConfig validates and applies new discovery pacing settings
+func (sm *Manager) SetDiscoveryConfig(config DiscoveryConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid discovery config: %v", err)
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.Discovery = config
+	return nil
+}
+
+// SetLineOfSight sets the terrain check for spotting symbols. Without it, any
+// symbol in the view cone counts as seen.
+func (sm *Manager) SetLineOfSight(provider LineOfSight) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.lineOfSight = provider
+}
+
+// inViewCone checks whether a point lies within the horizontal view cone of an
+// observer facing forward
+func (dc DiscoveryConfig) inViewCone(observer, forward, point ecs.Vector3) bool {
+	dx, dz := point.X-observer.X, point.Z-observer.Z
+	distance := math.Hypot(dx, dz)
+	facing := math.Hypot(forward.X, forward.Z)
+	if distance == 0 || facing == 0 {
+		return true
+	}
+
+	cos := (dx*forward.X + dz*forward.Z) / (distance * facing)
+	return cos >= math.Cos(dc.FieldOfView/2*math.Pi/180)
+}
+
+// canSee checks whether the player can see a point: it must be in the view cone
+// and not hidden by terrain
+func (sm *Manager) canSee(playerPos, forward, point ecs.Vector3) bool {
+	if !sm.Discovery.inViewCone(playerPos, forward, point) {
+		return false
+	}
+	return sm.lineOfSight == nil || sm.lineOfSight.HasLineOfSight(playerPos, point)
+}
+
+// glimpseSymbol grants a little knowledge of a symbol seen from afar, which may
+// be enough to sketch it in the journal
+func (sm *Manager) glimpseSymbol(symbol *Symbol) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.grantGlimpse(symbol)
+}
+
+// grantGlimpse grants glimpse knowledge of a symbol. Must be called with sm.mutex held.
+func (sm *Manager) grantGlimpse(symbol *Symbol) {
+	previous := sm.playerKnowledge[symbol.ID]
+	level := math.Min(previous+sm.Discovery.GlimpseKnowledge, 1)
+	sm.playerKnowledge[symbol.ID] = level
+
+	if previous < SketchedSymbolKnowledge && level >= SketchedSymbolKnowledge && sm.OnSymbolSketched != nil {
+		sm.OnSymbolSketched(symbol)
+	}
+	if sm.OnSymbolGlimpsed != nil {
+		sm.OnSymbolGlimpsed(symbol)
+	}
+}
+
+// queueSymbolDiscoveries queues undiscovered symbol entities the player has found,
+// nearest first: those within touch radius, and those within discovery range that
+// the player can see. Symbols seen further away are glimpsed once instead. Hidden
+// symbols (RequiresSight) are only found within touch radius.
+// Symbols already waiting keep their place and the spot they were found from.
+func (sm *Manager) queueSymbolDiscoveries(playerPos, forward ecs.Vector3) {
+	type candidate struct {
+		id       ecs.EntityID
+		distance float64
+	}
+	candidates := make([]candidate, 0)
+
+	for _, entity := range sm.world.GetEntitiesWithComponent(ecs.SymbolComponentID) {
+		symbol, has := ecs.ComponentAs[*ecs.SymbolComponent](entity, ecs.SymbolComponentID)
+		if !has || symbol.Discovered || sm.discoveryQueued[entity.ID] {
+			continue
+		}
+
+		entityPos, has := entityPosition(entity)
+		if !has {
+			continue
+		}
+
+		distance := playerPos.Distance(entityPos)
+		switch {
+		case distance <= sm.Discovery.TouchRadius:
+			candidates = append(candidates, candidate{id: entity.ID, distance: distance})
+
+		case symbol.RequiresSight || distance > symbol.DiscoveryRadius*sm.Discovery.GlimpseRangeScale:
+			continue
+
+		case !sm.canSee(playerPos, forward, entityPos):
+			continue
+
+		case distance <= symbol.DiscoveryRadius:
+			candidates = append(candidates, candidate{id: entity.ID, distance: distance})
+
+		case !sm.glimpsedSymbols[entity.ID]:
+			sm.glimpsedSymbols[entity.ID] = true
+			if regSymbol := sm.Registry.GetSymbol(symbol.SymbolID); regSymbol != nil {
+				sm.glimpseSymbol(regSymbol)
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].id < candidates[j].id
+	})
+
+	for _, c := range candidates {
+		sm.discoveryQueue = append(sm.discoveryQueue, queuedDiscovery{entityID: c.id, location: playerPos})
+		sm.discoveryQueued[c.id] = true
+	}
+}
+
+// discoverQueuedSymbols discovers queued symbols within the per-update budget,
+// once the cooldown since the last discoveries has passed. The rest wait for later
+// updates. Each symbol is discovered where the player found it, not where the
+// player has walked to since.
+func (sm *Manager) discoverQueuedSymbols() {
+	discovered := 0
+	for discovered < sm.Discovery.MaxPerUpdate && len(sm.discoveryQueue) > 0 && sm.discoveryCooldown <= 0 {
+		queued := sm.discoveryQueue[0]
+		sm.discoveryQueue = sm.discoveryQueue[1:]
+		delete(sm.discoveryQueued, queued.entityID)
+
+		// The symbol may have been removed or discovered another way meanwhile
+		entity, exists := sm.world.GetEntity(queued.entityID)
+		if !exists {
+			continue
+		}
+		symbol, has := ecs.ComponentAs[*ecs.SymbolComponent](entity, ecs.SymbolComponentID)
+		if !has || symbol.Discovered {
+			continue
+		}
+
+		// Discover the symbol
+		symbol.Discovered = true
+		discovered++
+
+		// Get the registered symbol
+		if regSymbol := sm.Registry.GetSymbol(symbol.SymbolID); regSymbol != nil {
+			sm.DiscoverSymbol(regSymbol, queued.location)
+		}
+	}
+
+	if discovered > 0 {
+		sm.discoveryCooldown = sm.Discovery.Cooldown
+	}
+}
@@ -0,0 +1,132 @@
+package symbols
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// queuedDiscoveries queues what a player at the origin facing +X has found and
+// reports which symbols were queued
+func queuedDiscoveries(sm *Manager, entities ...*ecs.Entity) map[ecs.EntityID]bool {
+	sm.queueSymbolDiscoveries(ecs.Vector3{}, ecs.Vector3{X: 1})
+
+	queued := make(map[ecs.EntityID]bool)
+	for _, entity := range entities {
+		queued[entity.ID] = sm.discoveryQueued[entity.ID]
+	}
+	return queued
+}
+
+// placeSymbol carves a symbol with a discovery radius of 3 at a position
+func placeSymbol(sm *Manager, world *ecs.World, id string, position ecs.Vector3, hidden bool) *ecs.Entity {
+	entity := addHiddenSymbol(sm, world, id, 0)
+	transform, _ := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
+	transform.Position = position
+	symbol, _ := ecs.ComponentAs[*ecs.SymbolComponent](entity, ecs.SymbolComponentID)
+	symbol.DiscoveryRadius = 3
+	symbol.RequiresSight = hidden
+	return entity
+}
+
+func TestDiscoveryNeedsSightOrTouch(t *testing.T) {
+	sm, world := newTestManager(t, 1)
+
+	ahead := placeSymbol(sm, world, "test_ahead", ecs.Vector3{X: 2.5}, false)
+	behind := placeSymbol(sm, world, "test_behind", ecs.Vector3{X: -2.5}, false)
+	touched := placeSymbol(sm, world, "test_touched", ecs.Vector3{X: -1}, false)
+
+	queued := queuedDiscoveries(sm, ahead, behind, touched)
+	if !queued[ahead.ID] {
+		t.Errorf("symbol in view was not found")
+	}
+	if queued[behind.ID] {
+		t.Errorf("symbol behind the player was found")
+	}
+	if !queued[touched.ID] {
+		t.Errorf("symbol within touch radius was not found")
+	}
+}
+
+func TestHiddenSymbolIsFoundOnlyByTouch(t *testing.T) {
+	sm, world := newTestManager(t, 1)
+	glimpsed := 0
+	sm.OnSymbolGlimpsed = func(*Symbol) { glimpsed++ }
+
+	buried := placeSymbol(sm, world, "test_buried", ecs.Vector3{X: 2.5}, true)
+	far := placeSymbol(sm, world, "test_far_buried", ecs.Vector3{X: 8}, true)
+	dug := placeSymbol(sm, world, "test_dug", ecs.Vector3{X: 1}, true)
+
+	queued := queuedDiscoveries(sm, buried, far, dug)
+	if queued[buried.ID] || queued[far.ID] {
+		t.Errorf("hidden symbol was found from afar: %v", queued)
+	}
+	if !queued[dug.ID] {
+		t.Errorf("hidden symbol within touch radius was not found")
+	}
+	if glimpsed != 0 {
+		t.Errorf("hidden symbol was glimpsed %d times", glimpsed)
+	}
+}
+
+func TestSymbolInViewFarAwayIsGlimpsedOnce(t *testing.T) {
+	sm, world := newTestManager(t, 1)
+	glimpsed := 0
+	sm.OnSymbolGlimpsed = func(*Symbol) { glimpsed++ }
+
+	// Beyond the discovery radius but within GlimpseRangeScale radii
+	distant := placeSymbol(sm, world, "test_distant", ecs.Vector3{X: 8}, false)
+
+	if queuedDiscoveries(sm, distant)[distant.ID] {
+		t.Errorf("distant symbol was discovered")
+	}
+	queuedDiscoveries(sm, distant)
+	if glimpsed != 1 {
+		t.Errorf("distant symbol was glimpsed %d times, want once", glimpsed)
+	}
+	if sm.GetKnowledgeLevel("test_distant") <= 0 {
+		t.Errorf("glimpse granted no knowledge")
+	}
+}
+
+func TestSimultaneousDiscoveriesAreSpreadOverUpdates(t *testing.T) {
+	sm, world := newTestManager(t, 1)
+	clock := setTestClock(sm)
+	config := DefaultDiscoveryConfig()
+	config.MaxPerUpdate = 1
+	config.Cooldown = 0
+	if err := sm.SetDiscoveryConfig(config); err != nil {
+		t.Fatalf("SetDiscoveryConfig: %v", err)
+	}
+
+	player := addTestPlayer(world, ecs.Vector3{})
+	playerTransform, _ := ecs.ComponentAs[*ecs.TransformComponent](player, ecs.TransformComponentID)
+
+	// Three symbols within touch radius of the spot the player walks into
+	ids := []string{"test_first", "test_second", "test_third"}
+	for i, id := range ids {
+		placeSymbol(sm, world, id, ecs.Vector3{X: 0.3 * float64(i+1)}, false)
+	}
+
+	for update := 1; update <= len(ids); update++ {
+		clock.Advance(time.Second)
+		sm.Update(1.0)
+
+		discovered := 0
+		for _, id := range ids {
+			if symbol := sm.Registry.GetSymbol(id); symbol.IsDiscovered {
+				discovered++
+				if symbol.DiscoveryLocation != (ecs.Vector3{}) {
+					t.Errorf("%s discovered at %v, want the spot where it was found", id, symbol.DiscoveryLocation)
+				}
+			}
+		}
+		if discovered != update {
+			t.Fatalf("after update %d, %d symbols discovered, want %d", update, discovered, update)
+		}
+
+		// The player walks on while the rest wait their turn
+		playerTransform.Position = ecs.Vector3{X: 100 * float64(update)}
+	}
+}
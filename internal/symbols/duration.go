@@ -0,0 +1,45 @@
+package symbols
+
+import "math"
+
+// Powerful, well-understood symbols make ritual effects last longer
+const (
+	DurationPowerWeight     = 0.5 // Extra duration share per unit of average symbol power
+	DurationKnowledgeWeight = 0.5 // Extra duration share per unit of average symbol knowledge
+	MaxDurationScale        = 2.0 // Effects never last more than this many times their base duration
+)
+
+// symbolDurationScale returns how much longer the effects of a ritual with the
+// given symbols last, from the symbols' average power and the player's average
+// knowledge of them (1 to MaxDurationScale). Must be called with sm.mutex held.
+func (sm *Manager) symbolDurationScale(symbolIDs []string) float64 {
+	power, knowledge, count := 0.0, 0.0, 0
+	for _, symbolID := range uniqueStrings(symbolIDs) {
+		symbol := sm.Registry.GetSymbol(symbolID)
+		if symbol == nil {
+			continue
+		}
+		power += symbol.Power
+		knowledge += sm.playerKnowledge[symbolID]
+		count++
+	}
+	if count == 0 {
+		return 1.0
+	}
+
+	scale := 1.0 + DurationPowerWeight*power/float64(count) + DurationKnowledgeWeight*knowledge/float64(count)
+	return math.Min(MaxDurationScale, scale)
+}
+
+// extendEffectDurations returns copies of the effects with their durations
+// multiplied. Instant and open-ended effects (zero duration) are unchanged.
+func extendEffectDurations(effects []RitualEffect, scale float64) []RitualEffect {
+	extended := make([]RitualEffect, len(effects))
+	for i, effect := range effects {
+		if effect.Duration > 0 {
+			effect.Duration = int(float64(effect.Duration) * scale)
+		}
+		extended[i] = effect
+	}
+	return extended
+}
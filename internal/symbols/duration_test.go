@@ -0,0 +1,71 @@
+package symbols
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// performDurationRitual performs a ritual with one timed effect over two symbols
+// of the given power and player knowledge, and returns the effects the applier got
+func performDurationRitual(t *testing.T, power, knowledge float64) []RitualEffect {
+	t.Helper()
+
+	first, second := testSymbol("test_root", "nature"), testSymbol("test_bough", "nature")
+	first.Power, second.Power = power, power
+	sm, _ := newTestManager(t, 1, first, second)
+	sm.playerKnowledge[first.ID] = knowledge
+	sm.playerKnowledge[second.ID] = knowledge
+	applier := &recordingApplier{}
+	sm.SetRitualEffectApplier(applier)
+
+	ritual := &Ritual{
+		ID: "test_long_fog", Name: "Long Fog", RequiredSymbols: []string{first.ID, second.ID},
+		SuccessChance: 0.8, IsDiscovered: true,
+		Effects: []RitualEffect{
+			{Type: "fog", Target: "area", Value: 0.5, Duration: 100},
+			{Type: "sanity", Target: "player", Value: 5},
+		},
+	}
+	sm.RitualRegistry.AddRitual(ritual)
+
+	// A large chance modifier makes the ritual succeed
+	if result := sm.performRitual(ritual, ecs.Vector3{}, nil, nil, 1.0, 100); !result.Outcome.Succeeded() {
+		t.Fatalf("outcome %v, want a success", result.Outcome)
+	}
+	return applier.effects
+}
+
+func TestStrongWellKnownSymbolsLengthenEffects(t *testing.T) {
+	weak := performDurationRitual(t, 0.1, 0)
+	strong := performDurationRitual(t, 1.0, 1.0)
+	if len(weak) != 2 || len(strong) != 2 {
+		t.Fatalf("applied %d and %d effects, want both ritual effects each time", len(weak), len(strong))
+	}
+
+	if weak[0].Duration != 105 {
+		t.Errorf("weak, unknown symbols made the effect last %d seconds, want 105", weak[0].Duration)
+	}
+	if strong[0].Duration != 200 {
+		t.Errorf("strong, well-known symbols made the effect last %d seconds, want the 2x cap of 200", strong[0].Duration)
+	}
+
+	// Instant effects stay instant
+	if weak[1].Duration != 0 || strong[1].Duration != 0 {
+		t.Errorf("instant effect got durations %d and %d, want 0", weak[1].Duration, strong[1].Duration)
+	}
+}
+
+func TestDurationScaleIgnoresUnknownSymbols(t *testing.T) {
+	sm, _ := newTestManager(t, 1, testSymbol("test_root", "nature"))
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	if scale := sm.symbolDurationScale(nil); scale != 1.0 {
+		t.Errorf("scale without symbols = %v, want 1", scale)
+	}
+	// Power 0.5, no knowledge; the missing and repeated symbols do not count
+	if scale := sm.symbolDurationScale([]string{"test_root", "test_missing", "test_root"}); scale != 1.25 {
+		t.Errorf("scale = %v, want 1.25", scale)
+	}
+}
@@ -0,0 +1,85 @@
+package symbols
+
+import "echo-taiga/internal/engine/ecs"
+
+// RitualEffectApplier is implemented by systems that carry out ritual effects in the world
+type RitualEffectApplier interface {
+	ApplyRitualEffect(effect RitualEffect, location ecs.Vector3)
+}
+
+// SetRitualEffectApplier sets the system that carries out ritual and rune word effects
+func (sm *Manager) SetRitualEffectApplier(applier RitualEffectApplier) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.effectApplier = applier
+}
+
+// LoadedArea reports which positions lie in loaded chunks (e.g. world.World)
+type LoadedArea interface {
+	IsPositionLoaded(position ecs.Vector3) bool
+	ClampToLoaded(position ecs.Vector3) ecs.Vector3
+}
+
+// SetLoadedArea sets the source of loaded chunks that ritual spawn effects are kept within
+func (sm *Manager) SetLoadedArea(area LoadedArea) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.loadedArea = area
+}
+
+// spawnLocation returns where a spawn effect of a ritual at a location may bring
+// its entities: the location moved into the nearest loaded chunk, and false if
+// there is none. Must be called with sm.mutex held.
+func (sm *Manager) spawnLocation(location ecs.Vector3) (ecs.Vector3, bool) {
+	if sm.loadedArea == nil {
+		return location, true
+	}
+	location = sm.loadedArea.ClampToLoaded(location)
+	return location, sm.loadedArea.IsPositionLoaded(location)
+}
+
+// applyRitualEffects scales effects by magnitude, forwards wards to the ward receiver
+// (wards and attunements also ease forbidden knowledge exposure), places or recharges
+// stability anchors, resolves knowledge, foresight and symbol transformation
+// effects (acting on symbolIDs), forces the weather the dominant symbol calls for,
+// applies player stat effects through the player bridge and hands every effect to
+// the effect applier. Returns the scaled effects and those the applier could not be
+// given: spawns with no loaded chunk near the ritual.
+// Must be called with sm.mutex held.
+func (sm *Manager) applyRitualEffects(effects []RitualEffect, location ecs.Vector3, magnitude float64, symbolIDs []string) (scaled, skipped []RitualEffect) {
+	scaled = scaleEffectValues(effects, magnitude)
+
+	sm.applyWardEffects(scaled, location)
+	sm.applyExposureRelief(scaled)
+	sm.applyAnchorEffects(scaled, location)
+	sm.applySymbolTransforms(scaled, symbolIDs)
+	sm.applyWeatherEffects(scaled, symbolIDs)
+	sm.applyKnowledgeEffects(scaled, location)
+
+	for _, effect := range scaled {
+		if effect.Type == "knowledge" && effect.Target == "future" {
+			sm.grantVisions(effect.Value)
+		}
+	}
+
+	sm.applyPlayerEffects(scaled)
+
+	if sm.effectApplier != nil {
+		for _, effect := range scaled {
+			effectLocation := location
+			if effect.Type == "spawn" || effect.Type == "spawn_hostile" {
+				// Spawned entities must not land in chunks that are not loaded
+				var loaded bool
+				if effectLocation, loaded = sm.spawnLocation(location); !loaded {
+					skipped = append(skipped, effect)
+					continue
+				}
+			}
+			sm.effectApplier.ApplyRitualEffect(effect, effectLocation)
+		}
+	}
+
+	return scaled, skipped
+}
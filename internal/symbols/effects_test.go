@@ -0,0 +1,51 @@
+package symbols
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// unloadedArea is a world with no chunks loaded
+type unloadedArea struct{}
+
+func (unloadedArea) IsPositionLoaded(position ecs.Vector3) bool     { return false }
+func (unloadedArea) ClampToLoaded(position ecs.Vector3) ecs.Vector3 { return position }
+
+func TestSpawnWithoutLoadedChunksIsReportedAsSkipped(t *testing.T) {
+	sm, _ := newTestManager(t, 1, testSymbol("test_call", "primal"))
+	applier := &recordingApplier{}
+	sm.SetRitualEffectApplier(applier)
+	sm.SetLoadedArea(unloadedArea{})
+
+	ritual := &Ritual{
+		ID:              "test_summoning",
+		Name:            "Summoning",
+		RequiredSymbols: []string{"test_call"},
+		Difficulty:      0.2,
+		SuccessChance:   0.9,
+		IsDiscovered:    true,
+		Effects: []RitualEffect{
+			{ID: "calm", Type: "sanity", Target: "player", Value: 5},
+			{ID: "guardian", Type: "spawn", Target: "entity", Value: 1, SpawnEntityType: "wisp", SpawnCount: 1},
+		},
+	}
+	sm.RitualRegistry.AddRitual(ritual)
+
+	result := sm.performRitual(ritual, ecs.Vector3{}, nil, ritual.Actions, 1.0, 100)
+	if result.Outcome != RitualSucceeded && result.Outcome != RitualCritical {
+		t.Fatalf("outcome = %v, want a success", result.Outcome)
+	}
+
+	if len(result.Skipped) != 1 || result.Skipped[0].ID != "guardian" {
+		t.Errorf("skipped = %+v, want the guardian spawn", result.Skipped)
+	}
+	for _, effect := range applier.effects {
+		if effect.ID == "guardian" {
+			t.Errorf("spawn with no loaded chunk nearby reached the effect applier")
+		}
+	}
+	if len(applier.effects) != 1 {
+		t.Errorf("applier got %d effects, want the sanity effect only", len(applier.effects))
+	}
+}
@@ -0,0 +1,304 @@
+package symbols
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/savefile"
+)
+
+// exposureFile stores forbidden knowledge exposure per symbol
+const exposureFile = "exposure.json"
+
+// Exposure thresholds, in the order they are crossed
+const (
+	ExposureSanity        = iota + 1 // Drains the observer's sanity
+	ExposureHallucination            // Nearby things start to look wrong
+	ExposureStalker                  // Something notices the observer
+)
+
+// HallucinationRequester creates the small self-inflicted metamorphosis that makes the
+// surroundings of an overexposed observer look wrong (e.g. an adapter over
+// metamorphosis.MetamorphosisManager requesting a first-order visual effect)
+type HallucinationRequester interface {
+	RequestHallucination(center ecs.Vector3, radius, intensity float64, duration time.Duration, symbolID string) bool
+}
+
+// StalkerSummoner sends a stalker after the observer (e.g. fear.Director)
+type StalkerSummoner interface {
+	SummonStalker(position ecs.Vector3, intensity float64) bool
+}
+
+// ExposureConfig controls how observing void and heavily distorted symbols bites back
+type ExposureConfig struct {
+	Enabled        bool
+	MinDistortion  float64 // Symbols at least this distorted are forbidden, as are all void symbols
+	Rate           float64 // Exposure gained per second of observation
+	DecayPerDay    float64 // Exposure lost per day (24 hours) of play or absence
+	KnowledgeScale float64 // Knowledge of forbidden symbols grows this many times faster
+
+	SanityAt        float64 // Exposure thresholds of the three consequences
+	HallucinationAt float64
+	StalkerAt       float64
+
+	SanityDrain           float64       // Sanity lost when crossing the first threshold
+	HallucinationRadius   float64       // Radius of the hallucination around the observer
+	HallucinationDuration time.Duration // How long the hallucination lasts
+	WardRelief            float64       // Exposure removed per unit of ward or attunement value
+}
+
+// DefaultExposureConfig returns the default forbidden knowledge settings
+func DefaultExposureConfig() ExposureConfig {
+	return ExposureConfig{
+		Enabled:        true,
+		MinDistortion:  0.6,
+		Rate:           0.01,
+		DecayPerDay:    0.5,
+		KnowledgeScale: 3.0,
+
+		SanityAt:        0.3,
+		HallucinationAt: 0.6,
+		StalkerAt:       0.9,
+
+		SanityDrain:           10.0,
+		HallucinationRadius:   12.0,
+		HallucinationDuration: 2 * time.Minute,
+		WardRelief:            0.5,
+	}
+}
+
+// Validate checks that the exposure settings are within usable ranges
+func (ec ExposureConfig) Validate() error {
+	if ec.MinDistortion < 0 || ec.MinDistortion > 1 {
+		return fmt.Errorf("MinDistortion must be between 0 and 1, got %.2f", ec.MinDistortion)
+	}
+	if ec.Rate < 0 || ec.DecayPerDay < 0 || ec.WardRelief < 0 {
+		return fmt.Errorf("exposure rates must not be negative")
+	}
+	if ec.KnowledgeScale < 1 {
+		return fmt.Errorf("KnowledgeScale must be at least 1, got %.2f", ec.KnowledgeScale)
+	}
+	if !(0 < ec.SanityAt && ec.SanityAt < ec.HallucinationAt && ec.HallucinationAt < ec.StalkerAt && ec.StalkerAt <= 1) {
+		return fmt.Errorf("exposure thresholds must increase within (0, 1], got %.2f, %.2f, %.2f",
+			ec.SanityAt, ec.HallucinationAt, ec.StalkerAt)
+	}
+	return nil
+}
+
+// thresholds returns the exposure thresholds, indexed by threshold level - 1
+func (ec ExposureConfig) thresholds() [3]float64 {
+	return [3]float64{ec.SanityAt, ec.HallucinationAt, ec.StalkerAt}
+}
+
+// SymbolExposure is the accumulated exposure to one forbidden symbol
+type SymbolExposure struct {
+	Exposure float64 `json:"exposure"`
+	Crossed  int     `json:"crossed"` // Highest threshold crossed since exposure last fell below it
+}
+
+// SetExposureConfig validates and applies new exposure settings
+func (sm *Manager) SetExposureConfig(config ExposureConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid exposure config: %v", err)
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.Exposure = config
+	return nil
+}
+
+// SetHallucinationRequester sets the system that creates hallucinations of overexposed observers
+func (sm *Manager) SetHallucinationRequester(requester HallucinationRequester) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.hallucinations = requester
+}
+
+// SetStalkerSummoner sets the system that sends stalkers after overexposed observers
+func (sm *Manager) SetStalkerSummoner(summoner StalkerSummoner) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.stalkers = summoner
+}
+
+// GetExposure returns the accumulated exposure to a symbol (0-1)
+func (sm *Manager) GetExposure(symbolID string) float64 {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	if exposure, exists := sm.exposure[symbolID]; exists {
+		return exposure.Exposure
+	}
+	return 0
+}
+
+// isForbidden checks whether studying a symbol exposes the observer to forbidden knowledge
+func (ec ExposureConfig) isForbidden(symbol *Symbol) bool {
+	return symbol.SymbolType == "void" || symbol.Distortion >= ec.MinDistortion
+}
+
+// observeForbiddenSymbols adds exposure for every discovered forbidden symbol within
+// discovery range of the player. A symbol counts once however many of its
+// inscriptions are in range. The faster learning of forbidden symbols is left to
+// updateSymbolKnowledge.
+func (sm *Manager) observeForbiddenSymbols(playerPos ecs.Vector3, elapsed float64) {
+	if !sm.Exposure.Enabled || elapsed <= 0 {
+		return
+	}
+
+	observed := make(map[string]bool)
+
+	for _, entity := range sm.world.GetEntitiesWithComponent(ecs.SymbolComponentID) {
+		symbolComp, has := ecs.ComponentAs[*ecs.SymbolComponent](entity, ecs.SymbolComponentID)
+		if !has || !symbolComp.Discovered {
+			continue
+		}
+		entityPos, has := entityPosition(entity)
+		if !has || playerPos.Distance(entityPos) > symbolComp.DiscoveryRadius {
+			continue
+		}
+
+		symbol := sm.Registry.GetSymbol(symbolComp.SymbolID)
+		if symbol == nil || observed[symbol.ID] || !sm.Exposure.isForbidden(symbol) {
+			continue
+		}
+		observed[symbol.ID] = true
+
+		sm.addExposure(symbol, playerPos, sm.Exposure.Rate*elapsed)
+	}
+}
+
+// addExposure raises the exposure to a symbol and fires the consequence of every
+// threshold crossed on the way, once per crossing
+func (sm *Manager) addExposure(symbol *Symbol, observer ecs.Vector3, amount float64) {
+	sm.mutex.Lock()
+	state, exists := sm.exposure[symbol.ID]
+	if !exists {
+		state = &SymbolExposure{}
+		sm.exposure[symbol.ID] = state
+	}
+	state.Exposure = math.Min(1.0, state.Exposure+amount)
+
+	crossed := make([]int, 0)
+	for level, threshold := range sm.Exposure.thresholds() {
+		if state.Exposure >= threshold && state.Crossed < level+1 {
+			crossed = append(crossed, level+1)
+			state.Crossed = level + 1
+		}
+	}
+	sm.mutex.Unlock()
+
+	for _, level := range crossed {
+		sm.exposureConsequence(symbol, observer, level)
+	}
+}
+
+// exposureConsequence carries out the consequence of crossing an exposure threshold
+func (sm *Manager) exposureConsequence(symbol *Symbol, observer ecs.Vector3, level int) {
+	sm.mutex.Lock()
+	config := sm.Exposure
+	switch level {
+	case ExposureSanity:
+		sm.applyPlayerEffects([]RitualEffect{{
+			Type:        "player_harm",
+			Target:      PlayerStatSanity,
+			Value:       -config.SanityDrain,
+			Tags:        []string{"forbidden_knowledge"},
+			Description: "The symbol's shape keeps unfolding behind your eyes",
+		}})
+
+	case ExposureHallucination:
+		if sm.hallucinations != nil {
+			sm.hallucinations.RequestHallucination(observer, config.HallucinationRadius, 0.3, config.HallucinationDuration, symbol.ID)
+		}
+
+	case ExposureStalker:
+		if sm.stalkers != nil {
+			sm.stalkers.SummonStalker(observer, 0.8)
+		}
+	}
+	callback := sm.OnExposureThreshold
+	sm.mutex.Unlock()
+
+	if callback != nil {
+		callback(symbol, level)
+	}
+}
+
+// decayExposure lets exposure fade over deltaTime seconds. Thresholds the exposure
+// falls below can be crossed, and fire, again.
+func (sm *Manager) decayExposure(deltaTime float64) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.reduceExposure(sm.Exposure.DecayPerDay * deltaTime / (24 * 60 * 60))
+}
+
+// reduceExposure lowers the exposure to every symbol. Must be called with sm.mutex held.
+func (sm *Manager) reduceExposure(amount float64) {
+	if amount <= 0 {
+		return
+	}
+
+	thresholds := sm.Exposure.thresholds()
+	for id, state := range sm.exposure {
+		state.Exposure = math.Max(0, state.Exposure-amount)
+		for state.Crossed > 0 && state.Exposure < thresholds[state.Crossed-1] {
+			state.Crossed--
+		}
+		if state.Exposure == 0 {
+			delete(sm.exposure, id)
+		}
+	}
+}
+
+// applyExposureRelief lets wards and attunements wash away exposure.
+// Must be called with sm.mutex held.
+func (sm *Manager) applyExposureRelief(effects []RitualEffect) {
+	for _, effect := range effects {
+		if effect.Type == "ward" || effect.Type == "attunement" || containsString(effect.Tags, "attunement") {
+			sm.reduceExposure(math.Abs(effect.Value) * sm.Exposure.WardRelief)
+		}
+	}
+}
+
+// loadExposure loads forbidden knowledge exposure. A missing file is not an error.
+func (sm *Manager) loadExposure() error {
+	path := filepath.Join(sm.Registry.savePath, exposureFile)
+	if !sm.Registry.storage.Exists(path) {
+		return nil
+	}
+
+	data, err := sm.Registry.storage.ReadSave(path)
+	if err != nil {
+		return err
+	}
+
+	exposure := make(map[string]*SymbolExposure)
+	if err := json.Unmarshal(data, &exposure); err != nil {
+		return err
+	}
+
+	sm.exposure = exposure
+	return nil
+}
+
+// saveExposure saves forbidden knowledge exposure
+func (sm *Manager) saveExposure() error {
+	sm.mutex.RLock()
+	data, err := savefile.Marshal(sm.exposure)
+	sm.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	return sm.Registry.storage.WriteSave(filepath.Join(sm.Registry.savePath, exposureFile), data)
+}
@@ -0,0 +1,116 @@
+package symbols
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// exposureRecorder counts the consequences of crossing exposure thresholds
+type exposureRecorder struct {
+	sanity         int
+	hallucinations int
+	stalkers       int
+}
+
+func (er *exposureRecorder) ApplyPlayerEffect(effect RitualEffect) {
+	if effect.Target == PlayerStatSanity && effect.Value < 0 {
+		er.sanity++
+	}
+}
+
+func (er *exposureRecorder) RequestHallucination(center ecs.Vector3, radius, intensity float64, duration time.Duration, symbolID string) bool {
+	er.hallucinations++
+	return true
+}
+
+func (er *exposureRecorder) SummonStalker(position ecs.Vector3, intensity float64) bool {
+	er.stalkers++
+	return true
+}
+
+// newExposureTestManager creates a manager with a discovered void symbol whose
+// exposure consequences are recorded
+func newExposureTestManager(t *testing.T) (*Manager, *ecs.World, *Symbol, *exposureRecorder) {
+	t.Helper()
+
+	void := &Symbol{ID: "test_void", Name: "Hollow", SymbolType: "void", Complexity: 0.5, IsDiscovered: true}
+	sm, world := newTestManager(t, 1, void)
+	recorder := &exposureRecorder{}
+	sm.SetPlayerBridge(recorder)
+	sm.SetHallucinationRequester(recorder)
+	sm.SetStalkerSummoner(recorder)
+	return sm, world, void, recorder
+}
+
+func TestExposureThresholdsFireOncePerCrossing(t *testing.T) {
+	sm, _, void, recorder := newExposureTestManager(t)
+	levels := make([]int, 0)
+	sm.OnExposureThreshold = func(symbol *Symbol, level int) {
+		levels = append(levels, level)
+	}
+
+	// Creep up to full exposure and linger there
+	for i := 0; i < 40; i++ {
+		sm.addExposure(void, ecs.Vector3{}, 0.05)
+	}
+	if recorder.sanity != 1 || recorder.hallucinations != 1 || recorder.stalkers != 1 {
+		t.Fatalf("consequences %+v after one climb, want each once", *recorder)
+	}
+	if len(levels) != 3 || levels[0] != ExposureSanity || levels[1] != ExposureHallucination || levels[2] != ExposureStalker {
+		t.Errorf("thresholds crossed %v, want sanity, hallucination, stalker", levels)
+	}
+
+	// Falling below the upper thresholds lets them fire again on the next climb
+	sm.mutex.Lock()
+	sm.reduceExposure(0.5)
+	sm.mutex.Unlock()
+	for i := 0; i < 20; i++ {
+		sm.addExposure(void, ecs.Vector3{}, 0.05)
+	}
+	if recorder.sanity != 1 || recorder.hallucinations != 2 || recorder.stalkers != 2 {
+		t.Errorf("consequences %+v after falling to 0.5 and climbing again, want sanity once and the others twice", *recorder)
+	}
+}
+
+func TestForbiddenSymbolIsLearnedAndObservedOnce(t *testing.T) {
+	sm, world, void, _ := newExposureTestManager(t)
+	plain := &Symbol{ID: "test_plain", Name: "Birch", SymbolType: "nature", Complexity: 0.5, IsDiscovered: true}
+	sm.Registry.AddSymbol(plain)
+	sm.playerKnowledge[void.ID] = 0.1
+	sm.playerKnowledge[plain.ID] = 0.1
+
+	// Two inscriptions of the void symbol and one of the plain symbol around the player
+	for _, placed := range []struct {
+		symbol *Symbol
+		x      float64
+	}{{void, 1}, {void, -1}, {plain, 2}} {
+		inscription := ecs.NewEntity()
+		inscription.AddComponent(ecs.NewTransformComponent(ecs.Vector3{X: placed.x}))
+		component := ecs.NewSymbolComponent(placed.symbol.ID, placed.symbol.SymbolType, 0.5, 0.5)
+		component.Discovered = true
+		component.DiscoveryRadius = 5
+		inscription.AddComponent(component)
+		world.AddEntity(inscription)
+	}
+
+	// One update's worth of observation and learning
+	elapsed := 10.0
+	sm.observeForbiddenSymbols(ecs.Vector3{}, elapsed)
+	sm.updateSymbolKnowledge(elapsed)
+
+	voidGain := sm.GetKnowledgeLevel(void.ID) - 0.1
+	plainGain := sm.GetKnowledgeLevel(plain.ID) - 0.1
+	if plainGain <= 0 || math.Abs(voidGain/plainGain-sm.Exposure.KnowledgeScale) > 1e-9 {
+		t.Errorf("void symbol learned %.3g times faster than a plain one, want %.1f",
+			voidGain/plainGain, sm.Exposure.KnowledgeScale)
+	}
+	if exposure := sm.GetExposure(void.ID); math.Abs(exposure-sm.Exposure.Rate*elapsed) > 1e-9 {
+		t.Errorf("exposure %.3f after %.0fs next to two inscriptions, want %.3f", exposure, elapsed, sm.Exposure.Rate*elapsed)
+	}
+	if exposure := sm.GetExposure(plain.ID); exposure != 0 {
+		t.Errorf("plain symbol exposure %.3f, want 0", exposure)
+	}
+}
@@ -0,0 +1,32 @@
+//go:build dev
+
+package symbols
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/framebudget"
+)
+
+// frameTime is one frame at 60 frames per second
+const frameTime = time.Second / 60
+
+// BenchmarkSymbolManagerUpdate is a symbol manager frame with 1,000 symbol entities
+func BenchmarkSymbolManagerUpdate(b *testing.B) {
+	sm, _ := newTestManager(b, 1)
+	clock := setTestClock(sm)
+	if err := sm.PopulateSynthetic(1000, 1); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		clock.Advance(frameTime)
+		sm.Update(frameTime.Seconds())
+	}
+}
+
+func TestSymbolManagerUpdateBudget(t *testing.T) {
+	framebudget.Check(t, "SymbolManagerUpdate", BenchmarkSymbolManagerUpdate)
+}
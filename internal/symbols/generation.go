@@ -0,0 +1,51 @@
+package symbols
+
+import "fmt"
+
+// GenerationConfig controls how much content the procedural generators produce
+type GenerationConfig struct {
+	SymbolsPerType   int // Generated variations of each base symbol
+	MinRitualSymbols int // Fewest symbols a generated ritual requires
+	MaxRitualSymbols int // Most symbols a generated ritual requires
+	MaxRitualEffects int // Most effects a generated ritual produces
+}
+
+// DefaultGenerationConfig returns the default generation settings
+func DefaultGenerationConfig() GenerationConfig {
+	return GenerationConfig{
+		SymbolsPerType:   3,
+		MinRitualSymbols: 2,
+		MaxRitualSymbols: 4,
+		MaxRitualEffects: 2,
+	}
+}
+
+// Validate checks that the generation settings are within usable ranges
+func (gc GenerationConfig) Validate() error {
+	if gc.SymbolsPerType < 1 {
+		return fmt.Errorf("SymbolsPerType must be at least 1, got %d", gc.SymbolsPerType)
+	}
+	if gc.MinRitualSymbols < 1 {
+		return fmt.Errorf("MinRitualSymbols must be at least 1, got %d", gc.MinRitualSymbols)
+	}
+	if gc.MaxRitualSymbols < gc.MinRitualSymbols {
+		return fmt.Errorf("MaxRitualSymbols (%d) must not be less than MinRitualSymbols (%d)", gc.MaxRitualSymbols, gc.MinRitualSymbols)
+	}
+	if gc.MaxRitualEffects < 1 {
+		return fmt.Errorf("MaxRitualEffects must be at least 1, got %d", gc.MaxRitualEffects)
+	}
+	return nil
+}
+
+// SetGenerationConfig validates and applies new generation settings
+func (sm *Manager) SetGenerationConfig(config GenerationConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid generation config: %v", err)
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.Generation = config
+	return nil
+}
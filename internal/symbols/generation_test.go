@@ -0,0 +1,146 @@
+package symbols
+
+import "testing"
+
+func TestGenerationConfigValidation(t *testing.T) {
+	sm, _ := newTestManager(t, 1)
+	for _, tt := range []struct {
+		name   string
+		modify func(*GenerationConfig)
+	}{
+		{"no symbols per type", func(gc *GenerationConfig) { gc.SymbolsPerType = 0 }},
+		{"no ritual symbols", func(gc *GenerationConfig) { gc.MinRitualSymbols = 0 }},
+		{"max below min", func(gc *GenerationConfig) { gc.MinRitualSymbols, gc.MaxRitualSymbols = 4, 3 }},
+		{"no ritual effects", func(gc *GenerationConfig) { gc.MaxRitualEffects = 0 }},
+	} {
+		config := DefaultGenerationConfig()
+		tt.modify(&config)
+		if err := sm.SetGenerationConfig(config); err == nil {
+			t.Errorf("%s: config %+v accepted", tt.name, config)
+		}
+	}
+	if sm.Generation != DefaultGenerationConfig() {
+		t.Errorf("rejected configs changed the generation settings to %+v", sm.Generation)
+	}
+}
+
+func TestGenerationCountsFollowConfig(t *testing.T) {
+	sm, _ := newTestManager(t, 7)
+	baseTypes := []string{"protection", "nature", "void"}
+	for _, symbolType := range baseTypes {
+		sm.Registry.AddBaseSymbol(testSymbol("base_"+symbolType, symbolType))
+	}
+	for _, id := range []string{"warding", "calling", "binding", "opening"} {
+		sm.RitualRegistry.AddBaseRitual(&Ritual{ID: id, Name: id, RequiredLocation: "forest", SuccessChance: 0.8})
+	}
+
+	config := GenerationConfig{SymbolsPerType: 5, MinRitualSymbols: 3, MaxRitualSymbols: 4, MaxRitualEffects: 1}
+	if err := sm.SetGenerationConfig(config); err != nil {
+		t.Fatalf("SetGenerationConfig: %v", err)
+	}
+	sm.GenerateInitialContent()
+
+	for _, symbolType := range baseTypes {
+		if got := len(sm.Registry.GetSymbolsByType(symbolType)); got != 5 {
+			t.Errorf("generated %d %s symbols, want 5", got, symbolType)
+		}
+	}
+
+	rituals := sm.RitualRegistry.GetAllRituals()
+	if len(rituals) != 4 {
+		t.Fatalf("generated %d rituals, want 4", len(rituals))
+	}
+	for _, ritual := range rituals {
+		if n := len(ritual.RequiredSymbols); n < config.MinRitualSymbols || n > config.MaxRitualSymbols {
+			t.Errorf("ritual %s requires %d symbols, want %d-%d", ritual.ID, n, config.MinRitualSymbols, config.MaxRitualSymbols)
+		}
+		if len(ritual.Effects) > config.MaxRitualEffects {
+			t.Errorf("ritual %s has %d effects, want at most %d", ritual.ID, len(ritual.Effects), config.MaxRitualEffects)
+		}
+	}
+}
+
+func TestGenerationWithoutSymbolsMakesNoRituals(t *testing.T) {
+	sm, _ := newTestManager(t, 7)
+	sm.RitualRegistry.AddBaseRitual(&Ritual{ID: "warding", Name: "warding", RequiredLocation: "forest", SuccessChance: 0.8})
+
+	if ritual := sm.GenerateRitual(sm.RitualRegistry.baseRituals[0]); ritual != nil {
+		t.Fatalf("GenerateRitual without symbols = %s, want nil", ritual.ID)
+	}
+
+	sm.GenerateInitialContent()
+	if rituals := sm.RitualRegistry.GetAllRituals(); len(rituals) != 0 {
+		t.Errorf("generated %d rituals without symbols, want none", len(rituals))
+	}
+}
+
+func TestAddNilRitualIsIgnored(t *testing.T) {
+	sm, _ := newTestManager(t, 1)
+
+	sm.RitualRegistry.AddRitual(nil)
+	if rituals := sm.RitualRegistry.GetAllRituals(); len(rituals) != 0 {
+		t.Fatalf("registry holds %d rituals after AddRitual(nil), want none", len(rituals))
+	}
+
+	// Lookups and searches still work over the registry
+	if ritual := sm.RitualRegistry.GetRitual(""); ritual != nil {
+		t.Errorf("GetRitual(\"\") = %v, want nil", ritual)
+	}
+	if found := sm.RitualRegistry.Search("ritual", false); len(found) != 0 {
+		t.Errorf("Search found %v, want nothing", ritualIDs(found))
+	}
+}
+
+func TestUnknownSymbolTypeFallsBackToFirstBaseSymbol(t *testing.T) {
+	sm, _ := newTestManager(t, 3)
+	first := testSymbol("base_protection", "protection")
+	first.LoreText = "Cut above every door in the old villages"
+	second := testSymbol("base_nature", "nature")
+	second.LoreText = "Grown, not carved"
+	sm.Registry.AddBaseSymbol(first)
+	sm.Registry.AddBaseSymbol(second)
+
+	if template := sm.templateSymbol("nature"); template != second {
+		t.Errorf("template for nature = %s, want the nature base symbol", template.ID)
+	}
+	if template := sm.templateSymbol("void"); template != first {
+		t.Errorf("template for an unknown type = %s, want the first base symbol", template.ID)
+	}
+
+	symbol := sm.GenerateSymbol("void", 0)
+	if symbol == first || symbol.LoreText != first.LoreText {
+		t.Fatalf("generated symbol %+v, want a new symbol carrying the first base symbol's lore", symbol)
+	}
+	if symbol.SymbolType != "void" {
+		t.Errorf("generated symbol type %q, want void", symbol.SymbolType)
+	}
+
+	// Changing the generated symbol leaves the template alone
+	symbol.LoreText = "rewritten"
+	symbol.Meanings = append(symbol.Meanings, "rewritten")
+	if first.LoreText != "Cut above every door in the old villages" || len(first.Meanings) != 0 {
+		t.Errorf("editing the generated symbol changed its template to %+v", first)
+	}
+}
+
+func TestSymbolWithoutBaseSymbolsIsStandalone(t *testing.T) {
+	sm, _ := newTestManager(t, 3)
+
+	template := sm.templateSymbol("void")
+	if template.ID == "" || template.Name == "" || len(template.Meanings) == 0 {
+		t.Errorf("default template %+v is incomplete", template)
+	}
+	if again := sm.templateSymbol("void"); again == template {
+		t.Errorf("default template is shared between calls")
+	}
+
+	first, second := sm.GenerateSymbol("void", 0), sm.GenerateSymbol("void", 1)
+	if first == second || first.ID == second.ID {
+		t.Errorf("generated symbols %s and %s are not distinct", first.ID, second.ID)
+	}
+	for _, symbol := range []*Symbol{first, second} {
+		if symbol.ID == "" || symbol.Name == "" || symbol.SymbolType != "void" || len(symbol.Meanings) == 0 {
+			t.Errorf("generated symbol %+v is not a valid symbol", symbol)
+		}
+	}
+}
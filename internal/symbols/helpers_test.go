@@ -0,0 +1,50 @@
+package symbols
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// testEpoch is the time fake test clocks start at
+var testEpoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newTestManager creates an in-memory manager over a new world, seeded with
+// seed and holding the given symbols
+func newTestManager(tb testing.TB, seed int64, symbols ...*Symbol) (*Manager, *ecs.World) {
+	tb.Helper()
+
+	world := ecs.NewWorld()
+	sm := NewManagerInMemory(world)
+	sm.SetWorldSeed(seed)
+	for _, symbol := range symbols {
+		sm.Registry.AddSymbol(symbol)
+	}
+	return sm, world
+}
+
+// testSymbol returns a discovered symbol of middling complexity and power
+func testSymbol(id, symbolType string) *Symbol {
+	return &Symbol{ID: id, Name: id, SymbolType: symbolType, Complexity: 0.5, Power: 0.5, IsDiscovered: true}
+}
+
+// setTestClock gives the manager a fake clock starting at testEpoch
+func setTestClock(sm *Manager) *engine.FakeClock {
+	clock := engine.NewFakeClock(testEpoch)
+	sm.SetClock(clock)
+	return clock
+}
+
+// addTestPlayer adds a player at a position, with any extra components
+func addTestPlayer(world *ecs.World, position ecs.Vector3, components ...ecs.Component) *ecs.Entity {
+	player := ecs.NewEntity()
+	player.AddTag(ecs.RegisterTag(TagPlayer, "actor", ""))
+	player.AddComponent(ecs.NewTransformComponent(position))
+	for _, component := range components {
+		player.AddComponent(component)
+	}
+	world.AddEntity(player)
+	return player
+}
@@ -0,0 +1,189 @@
+package symbols
+
+import "math"
+
+// Default knowledge transfer settings
+const (
+	DefaultKnowledgeTransferRate = 0.2  // Share of a ritual's knowledge gain passed to related rituals
+	DefaultKnowledgeTransferCap  = 0.35 // Transfer alone never lifts a ritual to the 0.4 discovery threshold
+	ritualDiscoveryThreshold     = 0.4  // Average knowledge needed to discover a ritual
+	minSharedSymbolsForRelation  = 2    // Rituals sharing this many symbols are related
+
+	SymbolUseKnowledge     = 0.05 // Knowledge of each symbol channeled by a successful ritual
+	SymbolFailureKnowledge = 0.01 // Knowledge of each symbol channeled by a failed ritual
+)
+
+// symbolUseGain returns the knowledge of its symbols that a ritual outcome teaches.
+// Better outcomes teach more; failures still teach a little.
+func symbolUseGain(outcome RitualOutcome) float64 {
+	switch outcome {
+	case RitualCritical:
+		return 1.5 * SymbolUseKnowledge
+	case RitualSucceeded:
+		return SymbolUseKnowledge
+	case RitualPartialSuccess:
+		return 0.5 * SymbolUseKnowledge
+	default:
+		return SymbolFailureKnowledge
+	}
+}
+
+// channelRitualSymbols deepens the player's knowledge of the symbols a performed
+// ritual channeled, which may in turn discover related rituals.
+// Must be called with sm.mutex held.
+func (sm *Manager) channelRitualSymbols(ritual *Ritual, outcome RitualOutcome) {
+	gain := symbolUseGain(outcome)
+	for _, symbolID := range uniqueStrings(ritual.RequiredSymbols) {
+		sm.increaseKnowledgeLocked(symbolID, gain)
+	}
+}
+
+// GetRelatedRituals returns the IDs of rituals that share knowledge with the given ritual
+func (rr *RitualRegistry) GetRelatedRituals(ritualID string) []string {
+	rr.mutex.RLock()
+	defer rr.mutex.RUnlock()
+
+	related := rr.relatedRituals[ritualID]
+	result := make([]string, 0, len(related))
+	for id := range related {
+		result = append(result, id)
+	}
+	return result
+}
+
+// indexRelatedRituals links a newly added ritual to the rituals it is related to:
+// those sharing at least two required symbols, or the same location within the same lineage.
+// Must be called with rr.mutex held, after the ritual has been added to ritualsBySymbol.
+func (rr *RitualRegistry) indexRelatedRituals(ritual *Ritual) {
+	// Count shared symbols using the symbol index
+	sharedSymbols := make(map[string]int)
+	for _, symbolID := range uniqueStrings(ritual.RequiredSymbols) {
+		for _, other := range rr.ritualsBySymbol[symbolID] {
+			if other.ID != ritual.ID {
+				sharedSymbols[other.ID]++
+			}
+		}
+	}
+
+	for otherID, count := range sharedSymbols {
+		if count >= minSharedSymbolsForRelation {
+			rr.linkRituals(ritual.ID, otherID)
+		}
+	}
+
+	// Same location within the same lineage
+	lineageKey := ritual.RequiredLocation + "|" + rr.lineageRoot(ritual)
+	for _, other := range rr.ritualsByLineage[lineageKey] {
+		if other.ID != ritual.ID {
+			rr.linkRituals(ritual.ID, other.ID)
+		}
+	}
+	rr.ritualsByLineage[lineageKey] = append(rr.ritualsByLineage[lineageKey], ritual)
+}
+
+// linkRituals records a symmetric relation between two rituals
+func (rr *RitualRegistry) linkRituals(a, b string) {
+	if rr.relatedRituals[a] == nil {
+		rr.relatedRituals[a] = make(map[string]bool)
+	}
+	if rr.relatedRituals[b] == nil {
+		rr.relatedRituals[b] = make(map[string]bool)
+	}
+	rr.relatedRituals[a][b] = true
+	rr.relatedRituals[b][a] = true
+}
+
+// lineageRoot follows ParentRitual links up to the oldest known ancestor
+func (rr *RitualRegistry) lineageRoot(ritual *Ritual) string {
+	root := ritual.ID
+	parentID := ritual.ParentRitual
+	visited := map[string]bool{ritual.ID: true}
+
+	for parentID != "" && !visited[parentID] {
+		visited[parentID] = true
+		root = parentID
+
+		parent, exists := rr.rituals[parentID]
+		if !exists {
+			break
+		}
+		parentID = parent.ParentRitual
+	}
+
+	return root
+}
+
+// transferRitualKnowledge passes part of a ritual's knowledge gain to related rituals.
+// Must be called with sm.mutex held.
+func (sm *Manager) transferRitualKnowledge(ritualID string, gain float64) {
+	if gain <= 0 || sm.KnowledgeTransferRate <= 0 {
+		return
+	}
+
+	transfer := gain * sm.KnowledgeTransferRate
+	for _, relatedID := range sm.RitualRegistry.GetRelatedRituals(ritualID) {
+		current := sm.playerKnowledge[relatedID]
+		if current >= sm.KnowledgeTransferCap {
+			continue
+		}
+
+		newLevel := math.Min(sm.KnowledgeTransferCap, current+transfer)
+		sm.playerKnowledge[relatedID] = newLevel
+
+		if related := sm.RitualRegistry.GetRitual(relatedID); related != nil {
+			related.KnowledgeLevel = newLevel
+		}
+	}
+}
+
+// RevealRitualKnowledge raises the player's knowledge of every discovered ritual by boost
+// and discovers undiscovered rituals whose symbols are all known once the boost
+// carries them over the discovery threshold
+func (sm *Manager) RevealRitualKnowledge(boost float64) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.revealRitualKnowledge(boost)
+}
+
+// revealRitualKnowledge is RevealRitualKnowledge without locking.
+// Must be called with sm.mutex held.
+func (sm *Manager) revealRitualKnowledge(boost float64) {
+	if boost <= 0 {
+		return
+	}
+
+	for _, ritual := range sm.RitualRegistry.GetDiscoveredRituals() {
+		newLevel := math.Min(1.0, sm.playerKnowledge[ritual.ID]+boost)
+		sm.playerKnowledge[ritual.ID] = newLevel
+		ritual.KnowledgeLevel = newLevel
+	}
+
+	for _, ritual := range sm.RitualRegistry.GetUndiscoveredRituals() {
+		if len(ritual.RequiredSymbols) == 0 {
+			continue
+		}
+
+		// Only rituals whose symbols the player already knows can be revealed
+		totalKnowledge := 0.0
+		symbolsKnown := true
+		for _, symbolID := range ritual.RequiredSymbols {
+			symbol := sm.Registry.GetSymbol(symbolID)
+			if symbol == nil || !symbol.IsDiscovered {
+				symbolsKnown = false
+				break
+			}
+			totalKnowledge += sm.playerKnowledge[symbolID]
+		}
+		if !symbolsKnown {
+			continue
+		}
+
+		averageKnowledge := totalKnowledge / float64(len(ritual.RequiredSymbols))
+		priorKnowledge := sm.playerKnowledge[ritual.ID]
+
+		if averageKnowledge+priorKnowledge+boost >= ritualDiscoveryThreshold {
+			sm.discoverRitual(ritual, math.Max(0.1, priorKnowledge))
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package symbols
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newKnowledgeTestManager creates an in-memory manager with two discovered
+// symbols, a discovered ritual using both and an undiscovered ritual sharing them
+func newKnowledgeTestManager(t *testing.T) (*Manager, *Ritual, *Ritual) {
+	t.Helper()
+
+	sm, _ := newTestManager(t, 1, testSymbol("test_ash", "elemental"), testSymbol("test_ember", "elemental"))
+
+	known := &Ritual{ID: "test_kindling", Name: "Kindling", RequiredSymbols: []string{"test_ash", "test_ember"}, Difficulty: 0.5, IsDiscovered: true}
+	hidden := &Ritual{ID: "test_pyre", Name: "Pyre", RequiredSymbols: []string{"test_ember", "test_ash"}, Difficulty: 0.5}
+	sm.RitualRegistry.AddRitual(known)
+	sm.RitualRegistry.AddRitual(hidden)
+	return sm, known, hidden
+}
+
+// withinDeadline fails the test if fn does not return in time, which is how a
+// re-entrant lock on sm.mutex shows up
+func withinDeadline(t *testing.T, name string, fn func()) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%s did not return: sm.mutex deadlock", name)
+	}
+}
+
+func TestSymbolKnowledgeDiscoversRitual(t *testing.T) {
+	sm, _, hidden := newKnowledgeTestManager(t)
+
+	withinDeadline(t, "IncreaseKnowledge", func() {
+		sm.IncreaseKnowledge("test_ash", 0.5)
+		sm.IncreaseKnowledge("test_ember", 0.5)
+	})
+
+	if !hidden.IsDiscovered {
+		t.Errorf("ritual %s not discovered with symbol knowledge %.2f and %.2f",
+			hidden.ID, sm.GetKnowledgeLevel("test_ash"), sm.GetKnowledgeLevel("test_ember"))
+	}
+}
+
+func TestPerformingRitualTeachesItsSymbols(t *testing.T) {
+	sm, known, hidden := newKnowledgeTestManager(t)
+
+	// Just below the discovery threshold: any outcome teaches enough to cross it
+	sm.mutex.Lock()
+	sm.playerKnowledge["test_ash"] = 0.395
+	sm.playerKnowledge["test_ember"] = 0.395
+	sm.mutex.Unlock()
+
+	var result RitualResult
+	withinDeadline(t, "PerformRitualWithSkill", func() {
+		result = sm.PerformRitualWithSkill(known, ecs.Vector3{}, nil, 1.0)
+	})
+
+	for _, id := range known.RequiredSymbols {
+		if level := sm.GetKnowledgeLevel(id); level <= 0.395 {
+			t.Errorf("knowledge of %s = %.4f after a %v ritual, want more than 0.395", id, level, result.Outcome)
+		}
+	}
+	if !hidden.IsDiscovered {
+		t.Errorf("performing %s did not discover the related ritual %s", known.ID, hidden.ID)
+	}
+}
+
+// assertKnowledge checks the player's knowledge of id to within rounding
+func assertKnowledge(t *testing.T, sm *Manager, id string, want float64) {
+	t.Helper()
+
+	if got := sm.GetKnowledgeLevel(id); math.Abs(got-want) > 1e-9 {
+		t.Errorf("knowledge of %s = %.4f, want %.4f", id, got, want)
+	}
+}
+
+func TestRitualKnowledgeTransfersToRelatedRituals(t *testing.T) {
+	sm, known, hidden := newKnowledgeTestManager(t)
+
+	// 0 -> 0.5: a fifth of the 0.5 gain carries over
+	sm.IncreaseKnowledge(known.ID, 0.5)
+	assertKnowledge(t, sm, known.ID, 0.5)
+	assertKnowledge(t, sm, hidden.ID, 0.1)
+
+	// 0.5 -> 0.8 with diminishing returns: a fifth of the 0.3 gain
+	sm.IncreaseKnowledge(known.ID, 0.5)
+	assertKnowledge(t, sm, known.ID, 0.8)
+	assertKnowledge(t, sm, hidden.ID, 0.16)
+
+	if hidden.IsDiscovered {
+		t.Errorf("transfer alone discovered %s", hidden.ID)
+	}
+}
+
+func TestRitualKnowledgeTransferIsCapped(t *testing.T) {
+	sm, known, hidden := newKnowledgeTestManager(t)
+	sm.KnowledgeTransferRate = 1
+
+	sm.IncreaseKnowledge(known.ID, 0.5)
+	assertKnowledge(t, sm, hidden.ID, DefaultKnowledgeTransferCap)
+
+	sm.IncreaseKnowledge(known.ID, 0.5)
+	assertKnowledge(t, sm, hidden.ID, DefaultKnowledgeTransferCap)
+
+	if hidden.IsDiscovered {
+		t.Errorf("capped transfer discovered %s", hidden.ID)
+	}
+}
+
+func TestRitualsRelateThroughLineageAtTheSameLocation(t *testing.T) {
+	rr := NewRitualRegistry("", NewRegistry(""))
+	rr.AddRitual(&Ritual{ID: "test_grove", RequiredLocation: LocationForest, RequiredSymbols: []string{"a", "b"}})
+	rr.AddRitual(&Ritual{ID: "test_grove_evolved", RequiredLocation: LocationForest, RequiredSymbols: []string{"c", "d"}, ParentRitual: "test_grove", EvolutionLevel: 1})
+	rr.AddRitual(&Ritual{ID: "test_thicket", RequiredLocation: LocationForest, RequiredSymbols: []string{"e", "f"}})
+	rr.AddRitual(&Ritual{ID: "test_spring", RequiredLocation: LocationWater, RequiredSymbols: []string{"g", "h"}, ParentRitual: "test_grove", EvolutionLevel: 1})
+
+	related := rr.GetRelatedRituals("test_grove")
+	if len(related) != 1 || related[0] != "test_grove_evolved" {
+		t.Errorf("rituals related to test_grove = %v, want only its evolution at the same location", related)
+	}
+}
+
+func TestSymbolUseGainFollowsOutcome(t *testing.T) {
+	tests := []struct {
+		outcome RitualOutcome
+		want    float64
+	}{
+		{RitualCritical, 1.5 * SymbolUseKnowledge},
+		{RitualSucceeded, SymbolUseKnowledge},
+		{RitualPartialSuccess, 0.5 * SymbolUseKnowledge},
+		{RitualNearMiss, SymbolFailureKnowledge},
+		{RitualCatastrophic, SymbolFailureKnowledge},
+	}
+	for _, tt := range tests {
+		if got := symbolUseGain(tt.outcome); got != tt.want {
+			t.Errorf("symbolUseGain(%v) = %v, want %v", tt.outcome, got, tt.want)
+		}
+	}
+}
+
+func TestRevealRitualKnowledgeRaisesDiscoveredRituals(t *testing.T) {
+	sm, known, hidden := newKnowledgeTestManager(t)
+	sm.mutex.Lock()
+	sm.playerKnowledge[known.ID] = 0.3
+	sm.mutex.Unlock()
+
+	withinDeadline(t, "RevealRitualKnowledge", func() { sm.RevealRitualKnowledge(0.2) })
+
+	assertKnowledge(t, sm, known.ID, 0.5)
+	if known.KnowledgeLevel != sm.GetKnowledgeLevel(known.ID) {
+		t.Errorf("ritual knowledge level %v differs from player knowledge %v", known.KnowledgeLevel, sm.GetKnowledgeLevel(known.ID))
+	}
+	if hidden.IsDiscovered {
+		t.Errorf("boost of 0.2 discovered %s with no prior knowledge", hidden.ID)
+	}
+}
+
+func TestRevealRitualKnowledgeDiscoversRitualOneStepAway(t *testing.T) {
+	sm, _, hidden := newKnowledgeTestManager(t)
+	var discovered []string
+	sm.OnRitualDiscovered = func(ritual *Ritual) { discovered = append(discovered, ritual.ID) }
+	sm.mutex.Lock()
+	sm.playerKnowledge[hidden.ID] = ritualDiscoveryThreshold - 0.1
+	sm.mutex.Unlock()
+
+	// Short of the threshold nothing is discovered
+	sm.RevealRitualKnowledge(0.05)
+	if hidden.IsDiscovered {
+		t.Fatalf("boost short of the threshold discovered %s", hidden.ID)
+	}
+
+	sm.RevealRitualKnowledge(0.1)
+	if !hidden.IsDiscovered || len(discovered) != 1 || discovered[0] != hidden.ID {
+		t.Errorf("ritual discovered %v, callbacks %v; want %s discovered once", hidden.IsDiscovered, discovered, hidden.ID)
+	}
+	if !containsRitual(sm.RitualRegistry.GetDiscoveredRituals(), hidden.ID) {
+		t.Errorf("%s missing from the discovered rituals", hidden.ID)
+	}
+}
+
+func TestRevealRitualKnowledgeNeedsKnownSymbols(t *testing.T) {
+	sm, _, hidden := newKnowledgeTestManager(t)
+	sm.Registry.GetSymbol("test_ember").IsDiscovered = false
+
+	sm.RevealRitualKnowledge(1)
+	if hidden.IsDiscovered {
+		t.Errorf("%s discovered although one of its symbols is unknown", hidden.ID)
+	}
+}
+
+// containsRitual reports whether a ritual with the ID is in the list
+func containsRitual(rituals []*Ritual, id string) bool {
+	for _, ritual := range rituals {
+		if ritual.ID == id {
+			return true
+		}
+	}
+	return false
+}
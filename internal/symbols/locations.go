@@ -0,0 +1,160 @@
+package symbols
+
+import (
+	"fmt"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Camp detection settings
+const (
+	CampMaxDistance = 6.0  // Maximum distance between a lit campfire and a shelter
+	CampRadius      = 8.0  // Radius of the registered camp volume
+	WardRadius      = 15.0 // Radius of the safe zone created by a warding ritual
+)
+
+// LocationVolume is a dynamic ritual location that is not tied to a single entity tag
+type LocationVolume struct {
+	ID       string      // Unique identifier
+	Location string      // Ritual location type ("camp", ...)
+	Center   ecs.Vector3 // Center of the volume
+	Radius   float64     // Radius of the volume
+}
+
+// WardReceiver is implemented by systems that consume ward effects
+// (e.g. fear.Director's safe zones)
+type WardReceiver interface {
+	AddWardZone(center ecs.Vector3, radius, strength, duration float64)
+}
+
+// SetWardReceiver sets the system that receives ward ritual effects
+func (sm *Manager) SetWardReceiver(receiver WardReceiver) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.wardReceiver = receiver
+}
+
+// RegisterLocationVolume adds or replaces a ritual location volume
+func (sm *Manager) RegisterLocationVolume(volume *LocationVolume) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.locationVolumes[volume.ID] = volume
+}
+
+// UnregisterLocationVolume removes a ritual location volume
+func (sm *Manager) UnregisterLocationVolume(id string) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	delete(sm.locationVolumes, id)
+}
+
+// GetLocationVolumes returns all registered location volumes
+func (sm *Manager) GetLocationVolumes() []*LocationVolume {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	result := make([]*LocationVolume, 0, len(sm.locationVolumes))
+	for _, volume := range sm.locationVolumes {
+		result = append(result, volume)
+	}
+	return result
+}
+
+// isInLocationVolume checks if a position lies in a volume of the given location type.
+// Must be called with sm.mutex held.
+func (sm *Manager) isInLocationVolume(location string, position ecs.Vector3) bool {
+	for _, volume := range sm.locationVolumes {
+		if volume.Location == location && position.Distance(volume.Center) <= volume.Radius {
+			return true
+		}
+	}
+	return false
+}
+
+// updateCampVolumes registers a camp wherever a lit campfire has a shelter nearby,
+// and unregisters camps whose fire went out or whose shelter is gone
+func (sm *Manager) updateCampVolumes() {
+	campfires := sm.world.GetEntitiesWithTag(TagCampfire)
+	shelters := sm.world.GetEntitiesWithTag(TagShelter)
+
+	camps := make(map[string]*LocationVolume)
+	for _, campfire := range campfires {
+		if !isCampfireLit(campfire) {
+			continue
+		}
+
+		firePos, ok := entityPosition(campfire)
+		if !ok {
+			continue
+		}
+
+		for _, shelter := range shelters {
+			shelterPos, ok := entityPosition(shelter)
+			if !ok || firePos.Distance(shelterPos) > CampMaxDistance {
+				continue
+			}
+
+			id := fmt.Sprintf("camp_%s", campfire.ID)
+			camps[id] = &LocationVolume{
+				ID:       id,
+				Location: LocationCamp,
+				Center:   firePos.Add(shelterPos).Multiply(0.5),
+				Radius:   CampRadius,
+			}
+			break
+		}
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	// Remove camps that no longer exist
+	for id, volume := range sm.locationVolumes {
+		if volume.Location != LocationCamp {
+			continue
+		}
+		if _, exists := camps[id]; !exists {
+			delete(sm.locationVolumes, id)
+		}
+	}
+
+	// Register current camps
+	for id, volume := range camps {
+		sm.locationVolumes[id] = volume
+	}
+}
+
+// applyWardEffects forwards successful ward effects to the ward receiver.
+// Must be called with sm.mutex held.
+func (sm *Manager) applyWardEffects(effects []RitualEffect, location ecs.Vector3) {
+	if sm.wardReceiver == nil {
+		return
+	}
+
+	for _, effect := range effects {
+		if effect.Type == "ward" {
+			sm.wardReceiver.AddWardZone(location, WardRadius, effect.Value, float64(effect.Duration))
+		}
+	}
+}
+
+// isCampfireLit checks whether a campfire's light is burning
+func isCampfireLit(entity *ecs.Entity) bool {
+	lightComp, has := entity.GetComponent(ecs.LightComponentID)
+	if !has {
+		return false
+	}
+	return lightComp.(*ecs.LightComponent).Intensity > 0
+}
+
+// entityPosition returns the position of an entity with a transform
+func entityPosition(entity *ecs.Entity) (ecs.Vector3, bool) {
+	transformComp, has := entity.GetComponent(ecs.TransformComponentID)
+	if !has {
+		return ecs.Vector3{}, false
+	}
+	return transformComp.(*ecs.TransformComponent).Position, true
+}
@@ -0,0 +1,98 @@
+package symbols
+
+import (
+	"image/color"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// addCampStructure places a campfire or shelter at a position
+func addCampStructure(world *ecs.World, tag string, position ecs.Vector3, components ...ecs.Component) *ecs.Entity {
+	entity := ecs.NewEntity()
+	entity.AddTag(ecs.RegisterTag(tag, "camp", ""))
+	entity.AddComponent(ecs.NewTransformComponent(position))
+	for _, component := range components {
+		entity.AddComponent(component)
+	}
+	world.AddEntity(entity)
+	return entity
+}
+
+// campVolumes returns the registered camp volumes
+func campVolumes(sm *Manager) []*LocationVolume {
+	var camps []*LocationVolume
+	for _, volume := range sm.GetLocationVolumes() {
+		if volume.Location == LocationCamp {
+			camps = append(camps, volume)
+		}
+	}
+	return camps
+}
+
+func TestCampRegistersWhileTheFireBurns(t *testing.T) {
+	sm, world := newTestManager(t, 1)
+	fire := ecs.NewLightComponent(color.RGBA{R: 255, A: 255}, 1.0, 6.0)
+	addCampStructure(world, TagCampfire, ecs.Vector3{}, fire)
+	addCampStructure(world, TagShelter, ecs.Vector3{X: 4})
+
+	sm.updateCampVolumes()
+	camps := campVolumes(sm)
+	if len(camps) != 1 {
+		t.Fatalf("registered %d camps, want 1", len(camps))
+	}
+	if camps[0].Center != (ecs.Vector3{X: 2}) {
+		t.Errorf("camp centered at %v, want halfway between fire and shelter", camps[0].Center)
+	}
+	sm.mutex.RLock()
+	inCamp := sm.isInLocationVolume(LocationCamp, ecs.Vector3{X: 2, Z: 5})
+	sm.mutex.RUnlock()
+	if !inCamp {
+		t.Errorf("position inside the camp radius is not a camp location")
+	}
+
+	// Putting the fire out unregisters the camp
+	fire.Intensity = 0
+	sm.updateCampVolumes()
+	if camps := campVolumes(sm); len(camps) != 0 {
+		t.Errorf("camp %+v stayed registered after the fire went out", camps[0])
+	}
+}
+
+func TestShelterTooFarFromTheFireMakesNoCamp(t *testing.T) {
+	sm, world := newTestManager(t, 1)
+	addCampStructure(world, TagCampfire, ecs.Vector3{}, ecs.NewLightComponent(color.RGBA{R: 255, A: 255}, 1.0, 6.0))
+	addCampStructure(world, TagShelter, ecs.Vector3{X: CampMaxDistance + 1})
+
+	sm.updateCampVolumes()
+	if camps := campVolumes(sm); len(camps) != 0 {
+		t.Errorf("registered camp %+v for a shelter out of reach", camps[0])
+	}
+}
+
+// recordingWard remembers the ward zones it was given
+type recordingWard struct {
+	strengths, durations []float64
+}
+
+func (rw *recordingWard) AddWardZone(center ecs.Vector3, radius, strength, duration float64) {
+	rw.strengths = append(rw.strengths, strength)
+	rw.durations = append(rw.durations, duration)
+}
+
+func TestWardEffectsReachTheWardReceiver(t *testing.T) {
+	sm, _ := newTestManager(t, 1)
+	ward := &recordingWard{}
+	sm.SetWardReceiver(ward)
+
+	sm.mutex.Lock()
+	sm.applyWardEffects([]RitualEffect{
+		{Type: "ward", Value: 0.6, Duration: 300},
+		{Type: "player", Target: "health", Value: 10},
+	}, ecs.Vector3{})
+	sm.mutex.Unlock()
+
+	if len(ward.strengths) != 1 || ward.strengths[0] != 0.6 || ward.durations[0] != 300 {
+		t.Errorf("ward zones %+v, want one of strength 0.6 lasting 300 seconds", *ward)
+	}
+}
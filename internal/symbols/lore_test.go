@@ -0,0 +1,65 @@
+package symbols
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// undiscoveredSymbol returns a symbol of the given type the player has yet to find
+func undiscoveredSymbol(id, symbolType, lore string) *Symbol {
+	symbol := testSymbol(id, symbolType)
+	symbol.IsDiscovered = false
+	symbol.LoreText = lore
+	return symbol
+}
+
+func TestFirstSymbolOfATypeGrantsLoreAndMoreKnowledge(t *testing.T) {
+	first := undiscoveredSymbol("test_hollow", "void", "It was always underneath.")
+	second := undiscoveredSymbol("test_rift", "void", "")
+	sm, _ := newTestManager(t, 1, first, second)
+
+	type discovery struct {
+		lore        string
+		firstOfType bool
+	}
+	var discoveries []discovery
+	sm.OnFirstDiscovery = func(symbol *Symbol, lore string, firstOfType bool) {
+		discoveries = append(discoveries, discovery{lore, firstOfType})
+	}
+
+	if _, unlocked := sm.Lore(first.ID); unlocked {
+		t.Errorf("lore of an undiscovered symbol is already unlocked")
+	}
+
+	sm.DiscoverSymbol(first, ecs.Vector3{})
+	sm.DiscoverSymbol(second, ecs.Vector3{})
+
+	if first.KnowledgeLevel != sm.Discovery.FirstOfTypeKnowledge {
+		t.Errorf("first void symbol knowledge = %.2f, want %.2f", first.KnowledgeLevel, sm.Discovery.FirstOfTypeKnowledge)
+	}
+	if second.KnowledgeLevel != 0.1 || second.KnowledgeLevel >= first.KnowledgeLevel {
+		t.Errorf("second void symbol knowledge = %.2f, want the usual 0.1", second.KnowledgeLevel)
+	}
+
+	want := []discovery{{"It was always underneath.", true}, {"", false}}
+	if len(discoveries) != len(want) || discoveries[0] != want[0] || discoveries[1] != want[1] {
+		t.Errorf("OnFirstDiscovery got %+v, want %+v", discoveries, want)
+	}
+	if lore, unlocked := sm.Lore(first.ID); !unlocked || lore != first.LoreText {
+		t.Errorf("Lore(%s) = %q, %v after discovery", first.ID, lore, unlocked)
+	}
+	if _, unlocked := sm.Lore(second.ID); unlocked {
+		t.Errorf("symbol without lore reports unlocked lore")
+	}
+}
+
+func TestFirstOfTypeKnowledgeValidation(t *testing.T) {
+	for _, knowledge := range []float64{0.05, 1.5} {
+		config := DefaultDiscoveryConfig()
+		config.FirstOfTypeKnowledge = knowledge
+		if config.Validate() == nil {
+			t.Errorf("FirstOfTypeKnowledge %.2f passed validation", knowledge)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package symbols
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"echo-taiga/internal/savefile"
+)
+
+// masteryFile stores the ritual almanac
+const masteryFile = "mastery.json"
+
+// Mastery ranks and the bonuses they grant
+const (
+	MasteryNovice     = iota // No bonus
+	MasteryApprentice        // No bonus yet, but the ritual is listed as practiced
+	MasteryAdept             // The ritual takes MasteryTimeScale of its usual time
+	MasteryExpert            // One required item becomes optional
+	MasteryMaster            // Successful performances play a distinct completion cue
+)
+
+// MasteryTimeScale is the share of a ritual's duration left from MasteryAdept on
+const MasteryTimeScale = 0.9
+
+// masteryThresholds are the mastery scores needed for ranks 1 to 4
+var masteryThresholds = [...]float64{1, 3, 6, 10}
+
+// RitualMastery is a ritual's entry in the almanac
+type RitualMastery struct {
+	RitualID             string        `json:"ritual_id"`
+	Attempts             int           `json:"attempts"`
+	Successes            int           `json:"successes"`
+	Score                float64       `json:"score"` // Successes weighted by outcome and difficulty
+	Rank                 int           `json:"rank"`
+	BestOutcome          RitualOutcome `json:"best_outcome"`
+	FastestTime          time.Duration `json:"fastest_time"` // Quickest successful session, 0 if none
+	FailureStreak        int           `json:"failure_streak"`
+	LongestFailureStreak int           `json:"longest_failure_streak"`
+}
+
+// outcomeQuality orders outcomes from worst to best
+func outcomeQuality(outcome RitualOutcome) int {
+	switch outcome {
+	case RitualCatastrophic:
+		return 0
+	case RitualFailed:
+		return 1
+	case RitualNearMiss:
+		return 2
+	case RitualPartialSuccess:
+		return 3
+	case RitualSucceeded:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// masteryGain returns the mastery score earned by an outcome. Harder rituals
+// teach more; failures teach nothing.
+func masteryGain(outcome RitualOutcome, difficulty float64) float64 {
+	weight := 0.5 + difficulty
+	switch outcome {
+	case RitualCritical:
+		return 1.5 * weight
+	case RitualSucceeded:
+		return weight
+	case RitualPartialSuccess:
+		return 0.5 * weight
+	default:
+		return 0
+	}
+}
+
+// masteryRankFor returns the rank reached with a mastery score
+func masteryRankFor(score float64) int {
+	rank := MasteryNovice
+	for i, threshold := range masteryThresholds {
+		if score >= threshold {
+			rank = i + 1
+		}
+	}
+	return rank
+}
+
+// masteryTimeScale returns the factor applied to a ritual's duration at a rank
+func masteryTimeScale(rank int) float64 {
+	if rank >= MasteryAdept {
+		return MasteryTimeScale
+	}
+	return 1.0
+}
+
+// masteryOptionalItem returns the required item an expert may leave out, or ""
+func masteryOptionalItem(ritual *Ritual, rank int) string {
+	if rank < MasteryExpert || len(ritual.RequiredItems) == 0 {
+		return ""
+	}
+	return ritual.RequiredItems[len(ritual.RequiredItems)-1]
+}
+
+// optionalItem returns the required item the player's mastery of a ritual lets them leave out, or ""
+func (sm *Manager) optionalItem(ritual *Ritual) string {
+	return masteryOptionalItem(ritual, sm.RitualRegistry.MasteryRank(ritual.ID))
+}
+
+// GetMastery returns the almanac entry of a ritual
+func (rr *RitualRegistry) GetMastery(ritualID string) RitualMastery {
+	rr.mutex.RLock()
+	defer rr.mutex.RUnlock()
+
+	if mastery, exists := rr.mastery[ritualID]; exists {
+		return *mastery
+	}
+	return RitualMastery{RitualID: ritualID}
+}
+
+// GetAlmanac returns the almanac entries of every ritual performed at least
+// once, highest rank first
+func (rr *RitualRegistry) GetAlmanac() []RitualMastery {
+	rr.mutex.RLock()
+	defer rr.mutex.RUnlock()
+
+	almanac := make([]RitualMastery, 0, len(rr.mastery))
+	for _, mastery := range rr.mastery {
+		almanac = append(almanac, *mastery)
+	}
+	sort.Slice(almanac, func(i, j int) bool {
+		if almanac[i].Rank != almanac[j].Rank {
+			return almanac[i].Rank > almanac[j].Rank
+		}
+		if almanac[i].Score != almanac[j].Score {
+			return almanac[i].Score > almanac[j].Score
+		}
+		return almanac[i].RitualID < almanac[j].RitualID
+	})
+	return almanac
+}
+
+// MasteryRank returns the mastery rank of a ritual
+func (rr *RitualRegistry) MasteryRank(ritualID string) int {
+	rr.mutex.RLock()
+	defer rr.mutex.RUnlock()
+
+	if mastery, exists := rr.mastery[ritualID]; exists {
+		return mastery.Rank
+	}
+	return MasteryNovice
+}
+
+// recordOutcome adds a performance to the ritual's almanac entry and returns
+// its rank, and whether the performance raised it
+func (rr *RitualRegistry) recordOutcome(ritual *Ritual, outcome RitualOutcome) (int, bool) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	mastery := rr.masteryEntry(ritual.ID)
+	if mastery.Attempts == 0 || outcomeQuality(outcome) > outcomeQuality(mastery.BestOutcome) {
+		mastery.BestOutcome = outcome
+	}
+	mastery.Attempts++
+
+	if outcome.Succeeded() {
+		mastery.Successes++
+		mastery.FailureStreak = 0
+	} else {
+		mastery.FailureStreak++
+		if mastery.FailureStreak > mastery.LongestFailureStreak {
+			mastery.LongestFailureStreak = mastery.FailureStreak
+		}
+	}
+
+	mastery.Score += masteryGain(outcome, ritual.Difficulty)
+	rank := masteryRankFor(mastery.Score)
+	rankedUp := rank > mastery.Rank
+	mastery.Rank = rank
+
+	return rank, rankedUp
+}
+
+// recordCompletionTime notes how long a successful ritual session took
+func (rr *RitualRegistry) recordCompletionTime(ritualID string, elapsed time.Duration) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	mastery := rr.masteryEntry(ritualID)
+	if mastery.FastestTime == 0 || elapsed < mastery.FastestTime {
+		mastery.FastestTime = elapsed
+	}
+}
+
+// masteryEntry returns the ritual's almanac entry, creating it if needed.
+// Must be called with rr.mutex held.
+func (rr *RitualRegistry) masteryEntry(ritualID string) *RitualMastery {
+	mastery, exists := rr.mastery[ritualID]
+	if !exists {
+		mastery = &RitualMastery{RitualID: ritualID}
+		rr.mastery[ritualID] = mastery
+	}
+	return mastery
+}
+
+// loadMastery loads the almanac. A missing file is not an error.
+// Must be called with rr.mutex held.
+func (rr *RitualRegistry) loadMastery() error {
+	path := filepath.Join(rr.savePath, masteryFile)
+	if !rr.storage.Exists(path) {
+		return nil
+	}
+
+	data, err := rr.storage.ReadSave(path)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]*RitualMastery, 0)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	rr.mastery = make(map[string]*RitualMastery, len(entries))
+	for _, mastery := range entries {
+		rr.mastery[mastery.RitualID] = mastery
+	}
+	return nil
+}
+
+// saveMastery saves the almanac. Must be called with rr.mutex held.
+func (rr *RitualRegistry) saveMastery() error {
+	entries := make([]*RitualMastery, 0, len(rr.mastery))
+	for _, mastery := range rr.mastery {
+		entries = append(entries, mastery)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].RitualID < entries[j].RitualID })
+
+	data, err := savefile.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return rr.storage.WriteSave(filepath.Join(rr.savePath, masteryFile), data)
+}
@@ -0,0 +1,123 @@
+package symbols
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// generationRand derives the random stream of one piece of generated content
+// from the world seed and the given keys. The same world always produces the
+// same names and descriptions; the returned seed is stored as GenerationSeed.
+func (sm *Manager) generationRand(keys ...interface{}) (*rand.Rand, int64) {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%d", sm.worldSeed)
+	for _, key := range keys {
+		fmt.Fprintf(hash, "|%v", key)
+	}
+
+	seed := int64(hash.Sum64())
+	return rand.New(rand.NewSource(seed)), seed
+}
+
+// nameKey normalizes a display name for collision checks and lookups
+func nameKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// distinguishName returns name unchanged if taken reports it free. Otherwise it
+// appends an epithet made from the first meaning not already in the name, and
+// falls back to a numeral once the meanings run out.
+func distinguishName(name string, meanings []string, taken func(string) bool) string {
+	if !taken(name) {
+		return name
+	}
+
+	lowerName := strings.ToLower(name)
+	for _, meaning := range meanings {
+		if meaning == "" || strings.Contains(lowerName, strings.ToLower(meaning)) {
+			continue
+		}
+
+		candidate := fmt.Sprintf("%s, Bearer of %s", name, capitalize(meaning))
+		if !taken(candidate) {
+			return candidate
+		}
+	}
+
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s %d", name, n)
+		if !taken(candidate) {
+			return candidate
+		}
+	}
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	first, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(first)) + s[size:]
+}
+
+// claimSymbolName makes the symbol's name unique among registered symbols and
+// indexes it. Callers must hold sr.mutex.
+func (sr *Registry) claimSymbolName(symbol *Symbol) {
+	if previous, exists := sr.symbols[symbol.ID]; exists {
+		delete(sr.symbolNames, nameKey(previous.Name))
+	}
+
+	symbol.Name = distinguishName(symbol.Name, symbol.Meanings, func(name string) bool {
+		id, exists := sr.symbolNames[nameKey(name)]
+		return exists && id != symbol.ID
+	})
+	sr.symbolNames[nameKey(symbol.Name)] = symbol.ID
+}
+
+// FindByName returns the symbol with the given display name, ignoring case
+func (sr *Registry) FindByName(name string) *Symbol {
+	sr.mutex.RLock()
+	defer sr.mutex.RUnlock()
+
+	id, exists := sr.symbolNames[nameKey(name)]
+	if !exists {
+		return nil
+	}
+	return sr.symbols[id]
+}
+
+// claimRitualName makes the ritual's name unique among registered rituals,
+// drawing epithets from the meanings of its primary symbol. Callers must hold
+// rr.mutex.
+func (rr *RitualRegistry) claimRitualName(ritual *Ritual) {
+	if previous, exists := rr.rituals[ritual.ID]; exists {
+		delete(rr.ritualNames, nameKey(previous.Name))
+	}
+
+	var meanings []string
+	if rr.Registry != nil && len(ritual.RequiredSymbols) > 0 {
+		if primary := rr.Registry.GetSymbol(ritual.RequiredSymbols[0]); primary != nil {
+			meanings = primary.Meanings
+		}
+	}
+
+	ritual.Name = distinguishName(ritual.Name, meanings, func(name string) bool {
+		id, exists := rr.ritualNames[nameKey(name)]
+		return exists && id != ritual.ID
+	})
+	rr.ritualNames[nameKey(ritual.Name)] = ritual.ID
+}
+
+// FindByName returns the ritual with the given display name, ignoring case
+func (rr *RitualRegistry) FindByName(name string) *Ritual {
+	rr.mutex.RLock()
+	defer rr.mutex.RUnlock()
+
+	id, exists := rr.ritualNames[nameKey(name)]
+	if !exists {
+		return nil
+	}
+	return rr.rituals[id]
+}
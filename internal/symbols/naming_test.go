@@ -0,0 +1,70 @@
+package symbols
+
+import "testing"
+
+func TestGeneratedSymbolNamesStayUnique(t *testing.T) {
+	sm, _ := newTestManager(t, 3)
+	types := []string{"protection", "nature", "void", "elemental"}
+
+	for seed := 0; seed < 500; seed++ {
+		sm.Registry.AddSymbol(sm.GenerateSymbol(types[seed%len(types)], seed))
+	}
+
+	names := make(map[string]string)
+	for _, symbol := range sm.Registry.GetAllSymbols() {
+		key := nameKey(symbol.Name)
+		if other, taken := names[key]; taken {
+			t.Errorf("symbols %s and %s share the name %q", other, symbol.ID, symbol.Name)
+		}
+		names[key] = symbol.ID
+
+		if found := sm.Registry.FindByName(symbol.Name); found != symbol {
+			t.Errorf("FindByName(%q) did not return symbol %s", symbol.Name, symbol.ID)
+		}
+	}
+	if len(names) != 500 {
+		t.Errorf("registered %d symbols, want 500", len(names))
+	}
+}
+
+func TestGeneratedNamesFollowTheWorldSeed(t *testing.T) {
+	first, _ := newTestManager(t, 11)
+	second, _ := newTestManager(t, 11)
+	other, _ := newTestManager(t, 12)
+
+	a, b := first.GenerateSymbol("nature", 4), second.GenerateSymbol("nature", 4)
+	if a.ID != b.ID || a.Name != b.Name || a.Description != b.Description {
+		t.Errorf("the same world generated %q (%s) and %q (%s)", a.Name, a.ID, b.Name, b.ID)
+	}
+	if c := other.GenerateSymbol("nature", 4); c.ID == a.ID && c.Description == a.Description {
+		t.Errorf("worlds 11 and 12 generated the same symbol %s", c.ID)
+	}
+}
+
+func TestNameCollisionGetsEpithetFromMeanings(t *testing.T) {
+	sm, _ := newTestManager(t, 1)
+	first := &Symbol{ID: "first", Name: "Hidden Rune of the Void", SymbolType: "void", Meanings: []string{"void", "silence"}}
+	second := &Symbol{ID: "second", Name: "Hidden Rune of the Void", SymbolType: "void", Meanings: []string{"void", "silence"}}
+	third := &Symbol{ID: "third", Name: "hidden rune of the void", SymbolType: "void", Meanings: []string{"silence"}}
+	sm.Registry.AddSymbol(first)
+	sm.Registry.AddSymbol(second)
+	sm.Registry.AddSymbol(third)
+
+	if first.Name != "Hidden Rune of the Void" {
+		t.Errorf("the first symbol was renamed to %q", first.Name)
+	}
+	// "void" already appears in the name, so the epithet comes from the next meaning
+	if second.Name != "Hidden Rune of the Void, Bearer of Silence" {
+		t.Errorf("colliding symbol named %q, want the Silence epithet", second.Name)
+	}
+	// Once the meanings run out a numeral tells the names apart
+	if third.Name != "hidden rune of the void 2" {
+		t.Errorf("third colliding symbol named %q, want a numeral", third.Name)
+	}
+
+	// Re-adding a symbol under its own name keeps it
+	sm.Registry.AddSymbol(second)
+	if second.Name != "Hidden Rune of the Void, Bearer of Silence" {
+		t.Errorf("re-adding renamed the symbol to %q", second.Name)
+	}
+}
@@ -0,0 +1,15 @@
+package symbols
+
+// FastForward advances the symbol system over time the player spent away.
+// Only the slow processes that need no player run: symbol-driven anomalies,
+// forbidden knowledge exposure and unpracticed symbol knowledge fade.
+func (sm *Manager) FastForward(deltaTime float64) {
+	if deltaTime <= 0 {
+		return
+	}
+
+	sm.decaySymbolAnomaly(deltaTime)
+	sm.decayExposure(deltaTime)
+	sm.decayKnowledge(deltaTime)
+	sm.publishSnapshot()
+}
@@ -0,0 +1,194 @@
+package symbols
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// RitualResult is the outcome of a performed ritual
+type RitualResult struct {
+	Outcome  RitualOutcome
+	Effects  []RitualEffect
+	Refunded []string       // Offerings returned to the performer after a near miss
+	Skipped  []RitualEffect // Effects that could not be carried out, e.g. spawns with no loaded chunk nearby
+
+	MasteryCue bool // A mastered ritual succeeded; play its completion cue
+}
+
+// OutcomeTierConfig controls the critical and catastrophic ends of ritual rolls
+type OutcomeTierConfig struct {
+	CriticalMargin       float64 // Rolls at least this far below the success chance are critical
+	CriticalScale        float64 // Multiplier for the effect values of a critical success
+	CriticalKnowledge    float64 // Extra ritual knowledge granted by a critical success
+	CriticalEvolveChance float64 // Chance that a critical success evolves the ritual at once
+
+	NearMissMargin float64 // Failures within this much of the partial success band are near misses
+	NearMissScale  float64 // Share of the failure effects' value suffered on a near miss
+	RefundShare    float64 // Share of the consumed offerings returned on a near miss
+
+	CatastropheMargin  float64 // Rolls more than this above the success chance are catastrophic
+	CatastropheScale   float64 // Multiplier for the failure effects of a catastrophe
+	CatastropheAnomaly float64 // Local anomaly raised where a catastrophe happens
+}
+
+// DefaultOutcomeTierConfig returns the default outcome tier settings
+func DefaultOutcomeTierConfig() OutcomeTierConfig {
+	return OutcomeTierConfig{
+		CriticalMargin:       0.25,
+		CriticalScale:        1.5,
+		CriticalKnowledge:    0.1,
+		CriticalEvolveChance: 0.5,
+
+		NearMissMargin: 0.1,
+		NearMissScale:  0.5,
+		RefundShare:    0.5,
+
+		CatastropheMargin:  0.3,
+		CatastropheScale:   2.0,
+		CatastropheAnomaly: 0.2,
+	}
+}
+
+// Validate checks that the outcome tier settings are within usable ranges
+func (tc OutcomeTierConfig) Validate() error {
+	if tc.CriticalMargin < 0 || tc.CriticalMargin > 1 {
+		return fmt.Errorf("CriticalMargin must be between 0 and 1, got %.2f", tc.CriticalMargin)
+	}
+	if tc.CriticalScale < 1 {
+		return fmt.Errorf("CriticalScale must be at least 1, got %.2f", tc.CriticalScale)
+	}
+	if tc.CriticalEvolveChance < 0 || tc.CriticalEvolveChance > 1 {
+		return fmt.Errorf("CriticalEvolveChance must be between 0 and 1, got %.2f", tc.CriticalEvolveChance)
+	}
+	if tc.NearMissMargin < 0 || tc.NearMissMargin > 1 {
+		return fmt.Errorf("NearMissMargin must be between 0 and 1, got %.2f", tc.NearMissMargin)
+	}
+	if tc.NearMissScale <= 0 || tc.NearMissScale > 1 {
+		return fmt.Errorf("NearMissScale must be in (0, 1], got %.2f", tc.NearMissScale)
+	}
+	if tc.RefundShare < 0 || tc.RefundShare > 1 {
+		return fmt.Errorf("RefundShare must be between 0 and 1, got %.2f", tc.RefundShare)
+	}
+	if tc.CatastropheMargin < 0 {
+		return fmt.Errorf("CatastropheMargin must not be negative, got %.2f", tc.CatastropheMargin)
+	}
+	if tc.CatastropheScale < 1 {
+		return fmt.Errorf("CatastropheScale must be at least 1, got %.2f", tc.CatastropheScale)
+	}
+	if tc.CatastropheAnomaly < 0 || tc.CatastropheAnomaly > 1 {
+		return fmt.Errorf("CatastropheAnomaly must be between 0 and 1, got %.2f", tc.CatastropheAnomaly)
+	}
+	return nil
+}
+
+// SetOutcomeTierConfig validates and applies new outcome tier settings
+func (sm *Manager) SetOutcomeTierConfig(config OutcomeTierConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid outcome tier config: %v", err)
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.OutcomeTiers = config
+	return nil
+}
+
+// rollOutcome maps a roll against the success chance to a ritual outcome.
+// Near misses take precedence over catastrophes when the bands overlap.
+func (sm *Manager) rollOutcome(roll, successChance float64) RitualOutcome {
+	tiers := sm.OutcomeTiers
+	partialEnd := successChance + sm.PartialSuccess.Margin
+
+	switch {
+	case roll < successChance-tiers.CriticalMargin:
+		return RitualCritical
+	case roll < successChance:
+		return RitualSucceeded
+	case roll < partialEnd:
+		return RitualPartialSuccess
+	case roll < partialEnd+tiers.NearMissMargin:
+		return RitualNearMiss
+	case roll > successChance+tiers.CatastropheMargin:
+		return RitualCatastrophic
+	default:
+		return RitualFailed
+	}
+}
+
+// criticalEffects returns the ritual's primary effects amplified
+func (tc OutcomeTierConfig) criticalEffects(ritual *Ritual) []RitualEffect {
+	effects := make([]RitualEffect, 0, len(ritual.Effects))
+	for _, effect := range ritual.Effects {
+		effect.Value *= tc.CriticalScale
+		effect.Tags = append(append([]string(nil), effect.Tags...), "critical")
+		effects = append(effects, effect)
+	}
+	return effects
+}
+
+// nearMissEffects returns the ritual's failure effects weakened
+func (tc OutcomeTierConfig) nearMissEffects(ritual *Ritual) []RitualEffect {
+	effects := make([]RitualEffect, 0, len(ritual.FailureEffects))
+	for _, effect := range ritual.FailureEffects {
+		effect.Value *= tc.NearMissScale
+		effect.Duration = int(float64(effect.Duration) * tc.NearMissScale)
+		if effect.SpawnCount > 1 {
+			effect.SpawnCount = int(math.Max(1, math.Floor(float64(effect.SpawnCount)*tc.NearMissScale)))
+		}
+		effect.Tags = append(append([]string(nil), effect.Tags...), "near_miss")
+		effects = append(effects, effect)
+	}
+	return effects
+}
+
+// catastropheEffects returns the ritual's failure effects at doubled severity, followed
+// by a hostile drawn from its failure pool (or a generic hostile spirit)
+func (tc OutcomeTierConfig) catastropheEffects(ritual *Ritual, r *rand.Rand) []RitualEffect {
+	effects := make([]RitualEffect, 0, len(ritual.FailureEffects)+1)
+	hostiles := make([]RitualEffect, 0)
+	for _, effect := range ritual.FailureEffects {
+		if effect.Type == "spawn_hostile" {
+			hostiles = append(hostiles, effect)
+		}
+
+		effect.Value *= tc.CatastropheScale
+		effect.SpawnCount = int(float64(effect.SpawnCount) * tc.CatastropheScale)
+		effect.Tags = append(append([]string(nil), effect.Tags...), "catastrophe")
+		effects = append(effects, effect)
+	}
+
+	var hostile RitualEffect
+	if len(hostiles) > 0 {
+		hostile = hostiles[r.Intn(len(hostiles))]
+	} else {
+		hostile = RitualEffect{
+			Type:            "spawn_hostile",
+			Target:          "entity",
+			Description:     "The ritual tears open and something hostile crawls through",
+			Value:           0.5,
+			Duration:        1800,
+			SpawnEntityType: "hostile_spirit",
+			SpawnRadius:     5.0,
+		}
+	}
+	hostile.SpawnCount = 1
+	hostile.Tags = append(append([]string(nil), hostile.Tags...), "catastrophe", "hostile")
+
+	return append(effects, hostile)
+}
+
+// refundedItems returns the share of consumed offerings given back after a near miss.
+// Only offerings the ritual required are consumed, so only those can be returned.
+func (tc OutcomeTierConfig) refundedItems(ritual *Ritual, items []string) []string {
+	consumed := make([]string, 0, len(items))
+	for _, item := range items {
+		if containsString(ritual.RequiredItems, item) {
+			consumed = append(consumed, item)
+		}
+	}
+
+	count := int(math.Floor(float64(len(consumed)) * tc.RefundShare))
+	return consumed[:count]
+}
@@ -0,0 +1,162 @@
+package symbols
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// outcomeTestChance is the success chance the tier tests scale rituals to:
+// every tier has a band of rolls at 0.5
+const outcomeTestChance = 0.5
+
+// newOutcomeTestManager creates a manager seeded with seed and a discovered
+// ritual with one effect, one failure effect and one hostile failure effect
+func newOutcomeTestManager(t *testing.T, seed int64) (*Manager, *Ritual) {
+	t.Helper()
+
+	sm, _ := newTestManager(t, seed, testSymbol("test_hush", "elemental"))
+
+	ritual := &Ritual{
+		ID:              "test_lull",
+		Name:            "Lull",
+		RequiredSymbols: []string{"test_hush"},
+		Difficulty:      0.5,
+		SuccessChance:   0.8,
+		IsDiscovered:    true,
+		Effects: []RitualEffect{
+			{Type: "sanity", Target: "player", Value: 10},
+		},
+		FailureEffects: []RitualEffect{
+			{Type: "sanity", Target: "player", Value: -10, Duration: 60},
+			{Type: "spawn_hostile", Target: "entity", Value: 0.4, SpawnEntityType: "wisp", SpawnCount: 2},
+		},
+	}
+	sm.RitualRegistry.AddRitual(ritual)
+	return sm, ritual
+}
+
+// performAtTestChance performs the ritual with its success chance scaled to outcomeTestChance
+func performAtTestChance(sm *Manager, ritual *Ritual) RitualResult {
+	sm.mutex.Lock()
+	base := sm.successChance(ritual, ecs.Vector3{}, nil, 1.0, sm.RitualSynergy(ritual)) * sm.Actions.actionFactor(ritual, ritual.Actions)
+	sm.mutex.Unlock()
+
+	return sm.performRitual(ritual, ecs.Vector3{}, nil, ritual.Actions, 1.0, outcomeTestChance/base)
+}
+
+// seedForOutcome finds a world seed whose first ritual roll lands in the outcome's band
+func seedForOutcome(t *testing.T, sm *Manager, outcome RitualOutcome) int64 {
+	t.Helper()
+
+	for seed := int64(1); seed <= 1000; seed++ {
+		roll := engine.NewRandStream(seed, RandStream).Float64()
+		if sm.rollOutcome(roll, outcomeTestChance) == outcome {
+			return seed
+		}
+	}
+	t.Fatalf("no seed in 1..1000 rolls %v", outcome)
+	return 0
+}
+
+func TestRollOutcomeBands(t *testing.T) {
+	sm, _ := newTestManager(t, 1)
+	tests := []struct {
+		roll float64
+		want RitualOutcome
+	}{
+		{0.1, RitualCritical},
+		{0.3, RitualSucceeded},
+		{0.6, RitualPartialSuccess},
+		{0.7, RitualNearMiss},
+		{0.78, RitualFailed},
+		{0.9, RitualCatastrophic},
+	}
+	for _, tt := range tests {
+		if got := sm.rollOutcome(tt.roll, outcomeTestChance); got != tt.want {
+			t.Errorf("rollOutcome(%.2f, %.2f) = %v, want %v", tt.roll, outcomeTestChance, got, tt.want)
+		}
+	}
+}
+
+func TestSeededRitualOutcomeTiers(t *testing.T) {
+	probe, _ := newOutcomeTestManager(t, 1)
+
+	tests := []struct {
+		outcome RitualOutcome
+		check   func(t *testing.T, result RitualResult)
+	}{
+		{RitualCritical, func(t *testing.T, result RitualResult) {
+			if len(result.Effects) != 1 || !containsString(result.Effects[0].Tags, "critical") {
+				t.Errorf("critical effects = %+v, want the amplified sanity effect", result.Effects)
+			}
+		}},
+		{RitualSucceeded, func(t *testing.T, result RitualResult) {
+			if len(result.Effects) != 1 || containsString(result.Effects[0].Tags, "critical") {
+				t.Errorf("success effects = %+v, want the plain sanity effect", result.Effects)
+			}
+		}},
+		{RitualNearMiss, func(t *testing.T, result RitualResult) {
+			for _, effect := range result.Effects {
+				if !containsString(effect.Tags, "near_miss") {
+					t.Errorf("near miss effect %s is not tagged near_miss", effect.Type)
+				}
+			}
+		}},
+		{RitualCatastrophic, func(t *testing.T, result RitualResult) {
+			last := result.Effects[len(result.Effects)-1]
+			if last.Type != "spawn_hostile" || last.SpawnEntityType != "wisp" || last.SpawnCount != 1 {
+				t.Errorf("catastrophe hostile = %+v, want one wisp from the failure pool", last)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		seed := seedForOutcome(t, probe, tt.outcome)
+
+		// The same seed gives the same tier every time
+		for run := 0; run < 2; run++ {
+			sm, ritual := newOutcomeTestManager(t, seed)
+			result := performAtTestChance(sm, ritual)
+			if result.Outcome != tt.outcome {
+				t.Fatalf("seed %d run %d: outcome %v, want %v", seed, run, result.Outcome, tt.outcome)
+			}
+			tt.check(t, result)
+		}
+	}
+}
+
+func TestNearMissRefundsHalfTheOfferings(t *testing.T) {
+	ritual := &Ritual{ID: "test_offering", RequiredItems: []string{"bone", "feather", "salt", "ash"}}
+	items := []string{"bone", "feather", "salt", "ash", "pebble"}
+
+	refunded := DefaultOutcomeTierConfig().refundedItems(ritual, items)
+	if len(refunded) != 2 {
+		t.Fatalf("refunded %v, want 2 of the 4 required offerings", refunded)
+	}
+	for _, item := range refunded {
+		if item == "pebble" {
+			t.Errorf("refunded %q, which the ritual never consumed", item)
+		}
+	}
+}
+
+func TestCriticalAndNearMissScaleEffects(t *testing.T) {
+	tiers := DefaultOutcomeTierConfig()
+	ritual := &Ritual{
+		Effects:        []RitualEffect{{Type: "sanity", Value: 10}},
+		FailureEffects: []RitualEffect{{Type: "sanity", Value: -10, Duration: 60}},
+	}
+
+	if critical := tiers.criticalEffects(ritual); critical[0].Value != 10*tiers.CriticalScale {
+		t.Errorf("critical value = %v, want %v", critical[0].Value, 10*tiers.CriticalScale)
+	}
+	nearMiss := tiers.nearMissEffects(ritual)
+	if nearMiss[0].Value != -10*tiers.NearMissScale || nearMiss[0].Duration != int(60*tiers.NearMissScale) {
+		t.Errorf("near miss effect = %+v, want value %v for %d seconds", nearMiss[0], -10*tiers.NearMissScale, int(60*tiers.NearMissScale))
+	}
+	if ritual.Effects[0].Value != 10 || ritual.FailureEffects[0].Value != -10 {
+		t.Errorf("scaling changed the ritual's own effects")
+	}
+}
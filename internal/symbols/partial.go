@@ -0,0 +1,121 @@
+package symbols
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// RitualOutcome is how well a performed ritual went
+type RitualOutcome int
+
+const (
+	RitualFailed         RitualOutcome = iota // Only failure effects
+	RitualPartialSuccess                      // Diluted effects plus a minor complication
+	RitualSucceeded                           // Full effects
+	RitualCritical                            // Amplified effects and bonus knowledge (see OutcomeTierConfig)
+	RitualNearMiss                            // Weakened failure effects, half of the offerings returned
+	RitualCatastrophic                        // Doubled failure effects, a hostile and a surge of anomaly
+)
+
+// String returns the outcome name
+func (o RitualOutcome) String() string {
+	switch o {
+	case RitualCritical:
+		return "critical"
+	case RitualSucceeded:
+		return "success"
+	case RitualPartialSuccess:
+		return "partial"
+	case RitualNearMiss:
+		return "near_miss"
+	case RitualCatastrophic:
+		return "catastrophe"
+	default:
+		return "failure"
+	}
+}
+
+// Succeeded checks whether the ritual's effects took hold, fully or partially
+func (o RitualOutcome) Succeeded() bool {
+	return o == RitualCritical || o == RitualSucceeded || o == RitualPartialSuccess
+}
+
+// PartialSuccessConfig controls the near-miss band between success and failure
+type PartialSuccessConfig struct {
+	Margin            float64 // Rolls within this much above the success chance partially succeed
+	EffectScale       float64 // Share of the primary effects' value kept on a partial success
+	ComplicationScale float64 // Share of a failure effect's value carried by the complication
+}
+
+// DefaultPartialSuccessConfig returns the default partial success settings
+func DefaultPartialSuccessConfig() PartialSuccessConfig {
+	return PartialSuccessConfig{
+		Margin:            0.15,
+		EffectScale:       0.5,
+		ComplicationScale: 0.3,
+	}
+}
+
+// Validate checks that the partial success settings are within usable ranges
+func (pc PartialSuccessConfig) Validate() error {
+	if pc.Margin < 0 || pc.Margin > 1 {
+		return fmt.Errorf("Margin must be between 0 and 1, got %.2f", pc.Margin)
+	}
+	if pc.EffectScale <= 0 || pc.EffectScale > 1 {
+		return fmt.Errorf("EffectScale must be in (0, 1], got %.2f", pc.EffectScale)
+	}
+	if pc.ComplicationScale <= 0 || pc.ComplicationScale > 1 {
+		return fmt.Errorf("ComplicationScale must be in (0, 1], got %.2f", pc.ComplicationScale)
+	}
+	return nil
+}
+
+// SetPartialSuccessConfig validates and applies new partial success settings
+func (sm *Manager) SetPartialSuccessConfig(config PartialSuccessConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid partial success config: %v", err)
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.PartialSuccess = config
+	return nil
+}
+
+// partialEffects returns the ritual's primary effects scaled down, followed by a
+// minor complication drawn from its failure effects
+func (pc PartialSuccessConfig) partialEffects(ritual *Ritual, r *rand.Rand) []RitualEffect {
+	effects := make([]RitualEffect, 0, len(ritual.Effects)+1)
+	for _, effect := range ritual.Effects {
+		effect.Value *= pc.EffectScale
+		effect.Duration = int(float64(effect.Duration) * pc.EffectScale)
+		effect.Tags = append(append([]string(nil), effect.Tags...), "partial")
+		effects = append(effects, effect)
+	}
+
+	return append(effects, pc.complication(ritual, r))
+}
+
+// complication returns a weakened failure effect of the ritual, or a brief
+// unease when the ritual has no failure effects
+func (pc PartialSuccessConfig) complication(ritual *Ritual, r *rand.Rand) RitualEffect {
+	if len(ritual.FailureEffects) == 0 {
+		return RitualEffect{
+			Type:        "player_harm",
+			Target:      "sanity",
+			Description: "The ritual leaves the performer uneasy",
+			Value:       -5.0 * pc.ComplicationScale,
+			Tags:        []string{"complication", "negative"},
+		}
+	}
+
+	complication := ritual.FailureEffects[r.Intn(len(ritual.FailureEffects))]
+	complication.Value *= pc.ComplicationScale
+	complication.Duration = int(float64(complication.Duration) * pc.ComplicationScale)
+	if complication.SpawnCount > 1 {
+		complication.SpawnCount = 1
+	}
+	complication.Tags = append(append([]string(nil), complication.Tags...), "complication")
+	return complication
+}
@@ -0,0 +1,81 @@
+package symbols
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPartialSuccessDilutesEffectsAndAddsComplication(t *testing.T) {
+	probe, _ := newOutcomeTestManager(t, 1)
+	sm, ritual := newOutcomeTestManager(t, seedForOutcome(t, probe, RitualPartialSuccess))
+	full := ritual.Effects[0]
+
+	result := performAtTestChance(sm, ritual)
+	if result.Outcome != RitualPartialSuccess || !result.Outcome.Succeeded() {
+		t.Fatalf("outcome %v, want a partial success", result.Outcome)
+	}
+	if len(result.Effects) != 2 {
+		t.Fatalf("effects %+v, want the diluted effect and a complication", result.Effects)
+	}
+
+	diluted, complication := result.Effects[0], result.Effects[1]
+	if diluted.Value >= full.Value || diluted.Value != full.Value*sm.PartialSuccess.EffectScale {
+		t.Errorf("partial effect value %v, want %v scaled by %v", diluted.Value, full.Value, sm.PartialSuccess.EffectScale)
+	}
+	if !containsString(diluted.Tags, "partial") {
+		t.Errorf("partial effect tags %v, want partial", diluted.Tags)
+	}
+	if !containsString(complication.Tags, "complication") {
+		t.Fatalf("second effect %+v is not a complication", complication)
+	}
+	for _, failure := range ritual.FailureEffects {
+		if complication.Type == failure.Type && complication.Value == failure.Value {
+			t.Errorf("complication %+v is a full failure effect", complication)
+		}
+	}
+}
+
+func TestComplicationWeakensFailureEffect(t *testing.T) {
+	config := DefaultPartialSuccessConfig()
+	ritual := &Ritual{
+		FailureEffects: []RitualEffect{{Type: "spawn_hostile", Value: 0.4, Duration: 100, SpawnCount: 3}},
+	}
+
+	complication := config.complication(ritual, rand.New(rand.NewSource(1)))
+	if complication.Value != 0.4*config.ComplicationScale || complication.Duration != int(100*config.ComplicationScale) {
+		t.Errorf("complication %+v, want value and duration scaled by %v", complication, config.ComplicationScale)
+	}
+	if complication.SpawnCount != 1 {
+		t.Errorf("complication spawns %d creatures, want 1", complication.SpawnCount)
+	}
+	if ritual.FailureEffects[0].Value != 0.4 || len(ritual.FailureEffects[0].Tags) != 0 {
+		t.Errorf("complication changed the ritual's own failure effect to %+v", ritual.FailureEffects[0])
+	}
+
+	// Without failure effects the complication is a brief unease
+	unease := config.complication(&Ritual{}, rand.New(rand.NewSource(1)))
+	if unease.Target != "sanity" || unease.Value >= 0 {
+		t.Errorf("complication without failure effects %+v, want a small sanity loss", unease)
+	}
+}
+
+func TestPartialSuccessConfigValidation(t *testing.T) {
+	sm, _ := newTestManager(t, 1)
+	for _, config := range []PartialSuccessConfig{
+		{Margin: -0.1, EffectScale: 0.5, ComplicationScale: 0.3},
+		{Margin: 0.15, EffectScale: 0, ComplicationScale: 0.3},
+		{Margin: 0.15, EffectScale: 0.5, ComplicationScale: 1.5},
+	} {
+		if err := sm.SetPartialSuccessConfig(config); err == nil {
+			t.Errorf("config %+v accepted", config)
+		}
+	}
+	if sm.PartialSuccess != DefaultPartialSuccessConfig() {
+		t.Errorf("rejected configs changed the settings to %+v", sm.PartialSuccess)
+	}
+
+	// A zero margin turns the partial band off
+	if err := sm.SetPartialSuccessConfig(PartialSuccessConfig{EffectScale: 0.5, ComplicationScale: 0.3}); err != nil {
+		t.Errorf("zero margin rejected: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package symbols
+
+import (
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Player stats that ritual effects can change
+const (
+	PlayerStatHealth = "health"
+	PlayerStatSanity = "sanity"
+	PlayerStatEnergy = "energy"
+)
+
+// PlayerBridge applies ritual effects aimed at the player's stats
+type PlayerBridge interface {
+	ApplyPlayerEffect(effect RitualEffect)
+}
+
+// SetPlayerBridge sets the system that applies player effects of rituals
+func (sm *Manager) SetPlayerBridge(bridge PlayerBridge) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.playerBridge = bridge
+}
+
+// applyPlayerEffects hands player-targeted effects to the player bridge.
+// Must be called with sm.mutex held.
+func (sm *Manager) applyPlayerEffects(effects []RitualEffect) {
+	if sm.playerBridge == nil {
+		return
+	}
+	for _, effect := range effects {
+		if isPlayerStatEffect(effect) {
+			sm.playerBridge.ApplyPlayerEffect(effect)
+		}
+	}
+}
+
+// isPlayerStatEffect checks whether an effect changes one of the player's stats
+func isPlayerStatEffect(effect RitualEffect) bool {
+	if effect.Type != "player" && effect.Type != "player_harm" {
+		return false
+	}
+	switch effect.Target {
+	case PlayerStatHealth, PlayerStatSanity, PlayerStatEnergy:
+		return true
+	}
+	return false
+}
+
+// ecsPlayerBridge applies player effects to the components of the player entity
+type ecsPlayerBridge struct {
+	world *ecs.World
+}
+
+// NewPlayerBridge creates a bridge that applies player effects to the entity tagged
+// as the player: health to its HealthComponent, sanity and energy to its SurvivalComponent.
+// Positive values heal or restore up to the maximum, negative values deal damage.
+func NewPlayerBridge(world *ecs.World) PlayerBridge {
+	return &ecsPlayerBridge{world: world}
+}
+
+// ApplyPlayerEffect implements PlayerBridge
+func (pb *ecsPlayerBridge) ApplyPlayerEffect(effect RitualEffect) {
+	players := pb.world.GetEntitiesWithTag(TagPlayer)
+	if len(players) == 0 {
+		return
+	}
+	player := players[0]
+
+	switch effect.Target {
+	case PlayerStatHealth:
+		health, has := ecs.ComponentAs[*ecs.HealthComponent](player, ecs.HealthComponentID)
+		if !has {
+			return
+		}
+		if effect.Value >= 0 {
+			health.Heal(effect.Value)
+		} else {
+			health.TakeDamage(-effect.Value, "ritual")
+		}
+
+	case PlayerStatSanity:
+		survival, has := ecs.ComponentAs[*ecs.SurvivalComponent](player, ecs.SurvivalComponentID)
+		if has {
+			survival.SanityLevel = math.Max(0, math.Min(100, survival.SanityLevel+effect.Value))
+		}
+
+	case PlayerStatEnergy:
+		// Energy is the opposite of fatigue
+		survival, has := ecs.ComponentAs[*ecs.SurvivalComponent](player, ecs.SurvivalComponentID)
+		if has {
+			survival.Fatigue = math.Max(0, math.Min(100, survival.Fatigue-effect.Value))
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package symbols
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newPlayerTestManager creates the outcome test ritual with player stat effects,
+// a player with the given health and a bridge applying effects to it
+func newPlayerTestManager(t *testing.T, seed int64, currentHealth float64) (*Manager, *Ritual, *ecs.HealthComponent) {
+	t.Helper()
+
+	sm, ritual := newOutcomeTestManager(t, seed)
+	ritual.Effects = []RitualEffect{{Type: "player", Target: PlayerStatHealth, Value: 30}}
+	ritual.FailureEffects = []RitualEffect{{Type: "player_harm", Target: PlayerStatHealth, Value: -500}}
+
+	health := ecs.NewHealthComponent(100)
+	health.CurrentHealth = currentHealth
+	addTestPlayer(sm.world, ecs.Vector3{}, health)
+	sm.SetPlayerBridge(NewPlayerBridge(sm.world))
+	return sm, ritual, health
+}
+
+func TestHealRitualRaisesPlayerHealthTowardMax(t *testing.T) {
+	probe, _ := newOutcomeTestManager(t, 1)
+	seed := seedForOutcome(t, probe, RitualSucceeded)
+
+	sm, ritual, health := newPlayerTestManager(t, seed, 40)
+	if result := performAtTestChance(sm, ritual); result.Outcome != RitualSucceeded {
+		t.Fatalf("outcome %v, want success", result.Outcome)
+	}
+	if health.CurrentHealth <= 40 || health.CurrentHealth > health.MaxHealth {
+		t.Errorf("health after healing = %.1f, want above 40 and at most %.0f", health.CurrentHealth, health.MaxHealth)
+	}
+
+	// Healing never goes past the maximum
+	sm, ritual, health = newPlayerTestManager(t, seed, 95)
+	performAtTestChance(sm, ritual)
+	if health.CurrentHealth != health.MaxHealth {
+		t.Errorf("health after healing from 95 = %.1f, want the maximum %.0f", health.CurrentHealth, health.MaxHealth)
+	}
+}
+
+func TestFailedRitualHarmStopsAtZeroHealth(t *testing.T) {
+	probe, _ := newOutcomeTestManager(t, 1)
+	seed := seedForOutcome(t, probe, RitualFailed)
+
+	sm, ritual, health := newPlayerTestManager(t, seed, 40)
+	if result := performAtTestChance(sm, ritual); result.Outcome != RitualFailed {
+		t.Fatalf("outcome %v, want failure", result.Outcome)
+	}
+	if health.CurrentHealth != 0 {
+		t.Errorf("health after the backlash = %.1f, want 0", health.CurrentHealth)
+	}
+}
+
+func TestPlayerBridgeRestoresSanityAndEnergyWithinBounds(t *testing.T) {
+	world := ecs.NewWorld()
+	survival := ecs.NewSurvivalComponent()
+	survival.SanityLevel = 90
+	survival.Fatigue = 10
+	addTestPlayer(world, ecs.Vector3{}, survival)
+
+	bridge := NewPlayerBridge(world)
+	bridge.ApplyPlayerEffect(RitualEffect{Type: "player", Target: PlayerStatSanity, Value: 25})
+	bridge.ApplyPlayerEffect(RitualEffect{Type: "player", Target: PlayerStatEnergy, Value: 25})
+	if survival.SanityLevel != 100 || survival.Fatigue != 0 {
+		t.Errorf("sanity %.0f, fatigue %.0f after restoring, want 100 and 0", survival.SanityLevel, survival.Fatigue)
+	}
+
+	bridge.ApplyPlayerEffect(RitualEffect{Type: "player_harm", Target: PlayerStatSanity, Value: -150})
+	bridge.ApplyPlayerEffect(RitualEffect{Type: "player_harm", Target: PlayerStatEnergy, Value: -150})
+	if survival.SanityLevel != 0 || survival.Fatigue != 100 {
+		t.Errorf("sanity %.0f, fatigue %.0f after draining, want 0 and 100", survival.SanityLevel, survival.Fatigue)
+	}
+}
+
+func TestOnlyPlayerStatEffectsReachTheBridge(t *testing.T) {
+	for _, tt := range []struct {
+		effect RitualEffect
+		want   bool
+	}{
+		{RitualEffect{Type: "player", Target: PlayerStatHealth}, true},
+		{RitualEffect{Type: "player_harm", Target: PlayerStatEnergy}, true},
+		{RitualEffect{Type: "player", Target: "luck"}, false},
+		{RitualEffect{Type: "sanity", Target: "player"}, false},
+	} {
+		if got := isPlayerStatEffect(tt.effect); got != tt.want {
+			t.Errorf("isPlayerStatEffect(%s/%s) = %v, want %v", tt.effect.Type, tt.effect.Target, got, tt.want)
+		}
+	}
+}
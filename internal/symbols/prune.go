@@ -0,0 +1,357 @@
+package symbols
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/savefile"
+)
+
+// PruneConfig controls garbage collection of generated content the player never
+// came across. A pruned symbol leaves its recipe behind and is generated again
+// the moment anything asks for it; a base ritual whose generated rituals were all
+// pruned gets a fresh one on the next load. Dropping unused content keeps saves
+// small without losing anything the player saw.
+type PruneConfig struct {
+	Enabled bool
+	MaxAge  time.Duration // Unused generated symbols and rituals older than this are removed
+}
+
+// DefaultPruneConfig returns the default pruning settings
+func DefaultPruneConfig() PruneConfig {
+	return PruneConfig{
+		Enabled: true,
+		MaxAge:  30 * 24 * time.Hour,
+	}
+}
+
+// Validate checks that the pruning settings are usable
+func (c PruneConfig) Validate() error {
+	if c.MaxAge <= 0 {
+		return fmt.Errorf("prune max age must be positive, got %v", c.MaxAge)
+	}
+	return nil
+}
+
+// SetPruneConfig validates and applies new pruning settings
+func (sm *Manager) SetPruneConfig(config PruneConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid prune config: %v", err)
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.Prune = config
+	return nil
+}
+
+// SymbolRecipe holds the inputs a generated symbol was made from
+type SymbolRecipe struct {
+	Type    string `json:"type"`    // Symbol type
+	Seed    int    `json:"seed"`    // Generation index within the type
+	Variant int    `json:"variant"` // Symbols of the type in the registry at generation time
+}
+
+// PruneGeneratedContent removes undiscovered generated rituals and symbols older
+// than Prune.MaxAge that nothing references: no knowledge, mastery, rumor, site,
+// session, exposure, rune word, lineage or world entity. Templates and content
+// without a generation time (from older saves) are always kept, and so are
+// symbols without a recipe. Returns the number of removed symbols and rituals.
+func (sm *Manager) PruneGeneratedContent() (symbols, rituals int) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	if !sm.Prune.Enabled {
+		return 0, 0
+	}
+	cutoff := sm.clock.Now().Add(-sm.Prune.MaxAge)
+
+	// Rituals first: removed rituals no longer hold on to their symbols
+	rr := sm.RitualRegistry
+	rr.mutex.Lock()
+	referencedRituals := sm.referencedRituals()
+	for id, ritual := range rr.rituals {
+		if ritual.IsDiscovered || !prunable(ritual.GeneratedAt, cutoff) || referencedRituals[id] {
+			continue
+		}
+		rr.removeRitual(ritual)
+		rituals++
+	}
+	referencedSymbols := sm.referencedSymbols()
+	rr.mutex.Unlock()
+
+	sr := sm.Registry
+	sr.mutex.Lock()
+	for _, symbol := range sr.symbols {
+		for _, related := range symbol.RelatedSymbols {
+			referencedSymbols[related] = true
+		}
+	}
+	for id, symbol := range sr.symbols {
+		if symbol.IsDiscovered || symbol.KnowledgeLevel > 0 || symbol.Recipe == nil ||
+			!prunable(symbol.GeneratedAt, cutoff) || referencedSymbols[id] {
+			continue
+		}
+		sr.removeSymbol(symbol)
+		sr.pruned[id] = *symbol.Recipe
+		symbols++
+	}
+	sr.mutex.Unlock()
+
+	return symbols, rituals
+}
+
+// replenishRituals generates a ritual for each base ritual none of whose
+// generated rituals are left, as happens after all of them were pruned.
+// A generated ritual takes its base's location and has no ParentRitual,
+// which only evolutions set.
+func (sm *Manager) replenishRituals() {
+	rr := sm.RitualRegistry
+
+	rr.mutex.RLock()
+	bases := make([]*Ritual, 0)
+	for _, base := range rr.baseRituals {
+		generated := false
+		for _, ritual := range rr.rituals {
+			if !ritual.GeneratedAt.IsZero() && ritual.ParentRitual == "" && ritual.RequiredLocation == base.RequiredLocation {
+				generated = true
+				break
+			}
+		}
+		if !generated {
+			bases = append(bases, base)
+		}
+	}
+	rr.mutex.RUnlock()
+
+	for _, base := range bases {
+		if ritual := sm.GenerateRitual(base); ritual != nil {
+			rr.AddRitual(ritual)
+		}
+	}
+}
+
+// prunable reports whether content generated at the given time is old enough to
+// be removed. Content without a generation time is never removed.
+func prunable(generatedAt, cutoff time.Time) bool {
+	return !generatedAt.IsZero() && generatedAt.Before(cutoff)
+}
+
+// referencedRituals collects the IDs of rituals that anything refers to.
+// Callers must hold sm.mutex and sm.RitualRegistry.mutex.
+func (sm *Manager) referencedRituals() map[string]bool {
+	rr := sm.RitualRegistry
+	referenced := make(map[string]bool)
+
+	for id, knowledge := range sm.playerKnowledge {
+		if knowledge > 0 {
+			referenced[id] = true
+		}
+	}
+	for id := range rr.mastery {
+		referenced[id] = true
+	}
+	for id := range rr.rumoredRituals {
+		referenced[id] = true
+	}
+	for id := range sm.ancientSites.Rumored {
+		referenced[id] = true
+	}
+	for _, site := range sm.ancientSites.Sites {
+		referenced[site.RitualID] = true
+	}
+	for _, session := range sm.ritualSessions {
+		referenced[session.Ritual.ID] = true
+	}
+	for _, base := range rr.baseRituals {
+		referenced[base.ID] = true
+	}
+	for id, evolutions := range rr.ritualEvolutionMap {
+		referenced[id] = true
+		for _, evolved := range evolutions {
+			referenced[evolved] = true
+		}
+	}
+	for _, ritual := range rr.rituals {
+		referenced[ritual.ParentRitual] = true
+		for _, step := range ritual.EvolutionPath {
+			referenced[step] = true
+		}
+	}
+
+	return referenced
+}
+
+// referencedSymbols collects the IDs of symbols that rituals, the player or the
+// world refer to. Callers must hold sm.mutex and sm.RitualRegistry.mutex.
+func (sm *Manager) referencedSymbols() map[string]bool {
+	referenced := make(map[string]bool)
+
+	for id, knowledge := range sm.playerKnowledge {
+		if knowledge > 0 {
+			referenced[id] = true
+		}
+	}
+	for _, ritual := range sm.RitualRegistry.rituals {
+		for _, symbolID := range ritual.RequiredSymbols {
+			referenced[symbolID] = true
+		}
+	}
+	for _, base := range sm.Registry.baseSymbols {
+		referenced[base.ID] = true
+	}
+	for id := range sm.exposure {
+		referenced[id] = true
+	}
+	for _, site := range sm.ancientSites.Sites {
+		referenced[site.SymbolID] = true
+	}
+
+	// Rune words are keyed by their symbol sequences
+	markSequence := func(key string) {
+		for _, symbolID := range strings.Split(key, ">") {
+			referenced[symbolID] = true
+		}
+	}
+	for key := range sm.runeWords.KnownWords {
+		markSequence(key)
+	}
+	for key := range sm.runeWords.UseCounts {
+		markSequence(key)
+	}
+	for key, sequences := range sm.runeWords.FailedWords {
+		markSequence(key)
+		for _, sequence := range sequences {
+			markSequence(sequence)
+		}
+	}
+	for symbolID, partnerID := range sm.runeWords.HintedSymbol {
+		referenced[symbolID] = true
+		referenced[partnerID] = true
+	}
+
+	// Symbols placed in the world
+	if sm.world != nil {
+		for _, entity := range sm.world.GetEntitiesWithComponent(ecs.SymbolComponentID) {
+			if symbol, has := ecs.ComponentAs[*ecs.SymbolComponent](entity, ecs.SymbolComponentID); has {
+				referenced[symbol.SymbolID] = true
+			}
+		}
+	}
+
+	return referenced
+}
+
+// removeRitual drops a ritual and its index entries. Callers must hold rr.mutex.
+func (rr *RitualRegistry) removeRitual(ritual *Ritual) {
+	delete(rr.rituals, ritual.ID)
+	delete(rr.discoveredRituals, ritual.ID)
+	if rr.ritualNames[nameKey(ritual.Name)] == ritual.ID {
+		delete(rr.ritualNames, nameKey(ritual.Name))
+	}
+
+	for _, index := range []map[string][]*Ritual{rr.ritualsBySymbol, rr.ritualsByEffect, rr.ritualsByLocation, rr.ritualsByLineage} {
+		for key, rituals := range index {
+			index[key] = withoutRitual(rituals, ritual.ID)
+		}
+	}
+
+	for related := range rr.relatedRituals[ritual.ID] {
+		delete(rr.relatedRituals[related], ritual.ID)
+	}
+	delete(rr.relatedRituals, ritual.ID)
+}
+
+// removeSymbol drops a symbol and its index entries. Callers must hold sr.mutex.
+func (sr *Registry) removeSymbol(symbol *Symbol) {
+	delete(sr.symbols, symbol.ID)
+	delete(sr.discoveredSymbols, symbol.ID)
+	if sr.symbolNames[nameKey(symbol.Name)] == symbol.ID {
+		delete(sr.symbolNames, nameKey(symbol.Name))
+	}
+
+	byType := sr.symbolsByType[symbol.SymbolType]
+	for i, other := range byType {
+		if other.ID == symbol.ID {
+			sr.symbolsByType[symbol.SymbolType] = append(byType[:i:i], byType[i+1:]...)
+			break
+		}
+	}
+}
+
+// idTaken reports whether a symbol ID is in use, either by a symbol or by a
+// pruned symbol that may come back
+func (sr *Registry) idTaken(id string) bool {
+	sr.mutex.RLock()
+	defer sr.mutex.RUnlock()
+
+	_, exists := sr.symbols[id]
+	_, pruned := sr.pruned[id]
+	return exists || pruned
+}
+
+// restorePruned generates a pruned symbol again from its recipe and returns it,
+// or nil if the symbol was never pruned
+func (sr *Registry) restorePruned(id string) *Symbol {
+	sr.mutex.Lock()
+	recipe, pruned := sr.pruned[id]
+	if !pruned || sr.regenerate == nil {
+		sr.mutex.Unlock()
+		return nil
+	}
+	delete(sr.pruned, id)
+	regenerate := sr.regenerate
+	sr.mutex.Unlock()
+
+	// Generation reads the registry, so it runs without the lock
+	symbol := regenerate(recipe)
+	if symbol.ID != id {
+		return nil
+	}
+	sr.AddSymbol(symbol)
+	return symbol
+}
+
+// savePruned writes the recipes of pruned symbols. Must be called with sr.mutex held.
+func (sr *Registry) savePruned() error {
+	data, err := savefile.Marshal(sr.pruned)
+	if err != nil {
+		return err
+	}
+	return sr.storage.WriteSave(filepath.Join(sr.savePath, "pruned.json"), data)
+}
+
+// readPrunedSymbols reads the recipes of pruned symbols. A save without them
+// has pruned nothing.
+func readPrunedSymbols(storage Storage, savePath string) (map[string]SymbolRecipe, error) {
+	pruned := make(map[string]SymbolRecipe)
+
+	prunedPath := filepath.Join(savePath, "pruned.json")
+	if !storage.Exists(prunedPath) {
+		return pruned, nil
+	}
+	data, err := storage.ReadSave(prunedPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &pruned); err != nil {
+		return nil, fmt.Errorf("invalid pruned symbols: %v", err)
+	}
+	return pruned, nil
+}
+
+// withoutRitual returns the rituals except the one with the given ID
+func withoutRitual(rituals []*Ritual, id string) []*Ritual {
+	kept := rituals[:0:0]
+	for _, ritual := range rituals {
+		if ritual.ID != id {
+			kept = append(kept, ritual)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,108 @@
+package symbols
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newPruneTestManager creates a manager with four generated symbols a month
+// old: one the player knows, one carved in the world, one a rune word uses and
+// one nothing refers to
+func newPruneTestManager(t *testing.T, world *ecs.World, storage Storage) (*Manager, map[string]*Symbol, *engine.FakeClock) {
+	t.Helper()
+
+	sm := NewManagerWithStorage(world, "save", storage)
+	sm.SetWorldSeed(42)
+	clock := setTestClock(sm)
+
+	symbols := make(map[string]*Symbol)
+	for i, role := range []string{"known", "carved", "rune", "unused"} {
+		symbol := sm.GenerateSymbol("elemental", i)
+		sm.Registry.AddSymbol(symbol)
+		symbols[role] = symbol
+	}
+
+	sm.mutex.Lock()
+	sm.playerKnowledge[symbols["known"].ID] = 0.4
+	sm.runeWords.KnownWords[symbols["rune"].ID+">"+symbols["known"].ID] = "ward"
+	sm.mutex.Unlock()
+
+	carved := ecs.NewEntity()
+	carved.AddComponent(ecs.NewSymbolComponent(symbols["carved"].ID, "elemental", 0.5, 0.5))
+	world.AddEntity(carved)
+
+	clock.Advance(sm.Prune.MaxAge + 24*time.Hour)
+	return sm, symbols, clock
+}
+
+func TestPruneKeepsReferencedSymbols(t *testing.T) {
+	sm, symbols, _ := newPruneTestManager(t, ecs.NewWorld(), NewMemoryStorage())
+
+	if removed, _ := sm.PruneGeneratedContent(); removed != 1 {
+		t.Errorf("pruned %d symbols, want only the unused one", removed)
+	}
+	for _, role := range []string{"known", "carved", "rune"} {
+		sm.Registry.mutex.RLock()
+		_, kept := sm.Registry.symbols[symbols[role].ID]
+		sm.Registry.mutex.RUnlock()
+		if !kept {
+			t.Errorf("%s symbol %s was pruned", role, symbols[role].ID)
+		}
+	}
+	if !sm.Registry.idTaken(symbols["unused"].ID) {
+		t.Errorf("pruned symbol left no recipe behind")
+	}
+}
+
+func TestPruneKeepsFreshAndTemplateSymbols(t *testing.T) {
+	sm, _, _ := newPruneTestManager(t, ecs.NewWorld(), NewMemoryStorage())
+	fresh := sm.GenerateSymbol("void", 0)
+	sm.Registry.AddSymbol(fresh)
+	sm.Registry.AddSymbol(&Symbol{ID: "legacy_rune", Name: "Legacy Rune", SymbolType: "arcane", GeneratedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+	sm.PruneGeneratedContent()
+
+	if sm.Registry.GetSymbol(fresh.ID) != fresh {
+		t.Errorf("symbol generated just now was pruned")
+	}
+	if sm.Registry.GetSymbol("legacy_rune") == nil {
+		t.Errorf("symbol without a recipe was pruned")
+	}
+}
+
+func TestPrunedSymbolIsGeneratedAgainAfterLoad(t *testing.T) {
+	storage := NewMemoryStorage()
+	sm, symbols, _ := newPruneTestManager(t, ecs.NewWorld(), storage)
+	unused := symbols["unused"]
+
+	sm.PruneGeneratedContent()
+	if err := sm.Registry.SaveState(); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+
+	loaded := NewManagerWithStorage(ecs.NewWorld(), "save", storage)
+	loaded.SetWorldSeed(42)
+	if err := loaded.Registry.LoadState(); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if len(loaded.Registry.GetAllSymbols()) != 3 {
+		t.Fatalf("loaded %d symbols, want the 3 kept ones", len(loaded.Registry.GetAllSymbols()))
+	}
+
+	restored := loaded.Registry.GetSymbol(unused.ID)
+	if restored == nil {
+		t.Fatalf("pruned symbol %s was not generated again", unused.ID)
+	}
+	if restored.GenerationSeed != unused.GenerationSeed || restored.Power != unused.Power || restored.VisualID != unused.VisualID {
+		t.Errorf("regenerated symbol differs: %+v, want %+v", restored, unused)
+	}
+	if loaded.Registry.GetSymbol(unused.ID) != restored {
+		t.Errorf("symbol generated again on the second lookup")
+	}
+	if loaded.Registry.GetSymbol("never_existed") != nil {
+		t.Errorf("lookup of an unknown ID produced a symbol")
+	}
+}
@@ -0,0 +1,349 @@
+package symbols
+
+import (
+	"sort"
+	"strings"
+)
+
+// Discovery states of a ritual in the codex
+const (
+	RitualStateDiscovered = "discovered" // The player knows the ritual
+	RitualStateRumored    = "rumored"    // The player has only heard rumors of it
+	RitualStateUnknown    = "unknown"    // The player knows nothing of it
+)
+
+// Orders for query results. Ties are broken by name, then by ID.
+const (
+	SortByName          = ""               // Alphabetical by name
+	SortByDifficulty    = "difficulty"     // Easiest first
+	SortByMastery       = "mastery"        // Least practiced first (rank, then successes)
+	SortByLastPerformed = "last_performed" // Least recently performed first
+	SortByKnowledge     = "knowledge"      // Least studied first (symbols)
+	SortByDistortion    = "distortion"     // Least distorted first (symbols)
+)
+
+// ValueRange is an inclusive band of values, such as knowledge from 0.5 to 1
+type ValueRange struct {
+	Min float64
+	Max float64
+}
+
+// contains checks whether a value lies within the band
+func (vr *ValueRange) contains(value float64) bool {
+	return vr == nil || (value >= vr.Min && value <= vr.Max)
+}
+
+// RitualQuery selects rituals for the codex. Every field is optional; the
+// rituals returned match all the fields that are set.
+type RitualQuery struct {
+	LocationType   string // Performed at this location type
+	EffectType     string // Produces an effect of this type
+	UsesSymbol     string // Requires this symbol
+	State          string // RitualStateDiscovered, RitualStateRumored or RitualStateUnknown
+	EvolutionLevel *int   // Has evolved exactly this many times
+
+	SortBy     string // One of the SortBy orders that apply to rituals
+	Descending bool   // Reverse the order
+
+	Offset int // Number of matching rituals to skip
+	Limit  int // Largest number of rituals to return (0 for all)
+}
+
+// Query returns the rituals matching the query in a stable order
+func (rr *RitualRegistry) Query(q RitualQuery) []*Ritual {
+	rr.mutex.RLock()
+	defer rr.mutex.RUnlock()
+
+	results := make([]*Ritual, 0)
+	seen := make(map[string]bool)
+	for _, ritual := range rr.queryCandidates(q) {
+		if seen[ritual.ID] || !rr.matchesQuery(ritual, q) {
+			continue
+		}
+		seen[ritual.ID] = true
+		results = append(results, ritual)
+	}
+
+	rr.sortRituals(results, q.SortBy, q.Descending)
+	return paginate(results, q.Offset, q.Limit)
+}
+
+// queryCandidates returns the smallest indexed set that can hold the query's
+// rituals. Must be called with rr.mutex held.
+func (rr *RitualRegistry) queryCandidates(q RitualQuery) []*Ritual {
+	var candidates []*Ritual
+	indexed := false
+	narrow := func(rituals []*Ritual) {
+		if !indexed || len(rituals) < len(candidates) {
+			candidates = rituals
+			indexed = true
+		}
+	}
+
+	if q.LocationType != "" {
+		narrow(rr.ritualsByLocation[q.LocationType])
+	}
+	if q.EffectType != "" {
+		narrow(rr.ritualsByEffect[q.EffectType])
+	}
+	if q.UsesSymbol != "" {
+		narrow(rr.ritualsBySymbol[q.UsesSymbol])
+	}
+	if q.State == RitualStateDiscovered {
+		narrow(ritualValues(rr.discoveredRituals))
+	}
+	if q.State == RitualStateRumored {
+		rumored := make([]*Ritual, 0, len(rr.rumoredRituals))
+		for id := range rr.rumoredRituals {
+			if ritual, exists := rr.rituals[id]; exists {
+				rumored = append(rumored, ritual)
+			}
+		}
+		narrow(rumored)
+	}
+
+	if !indexed {
+		return ritualValues(rr.rituals)
+	}
+	return candidates
+}
+
+// matchesQuery checks a ritual against every field of the query.
+// Must be called with rr.mutex held.
+func (rr *RitualRegistry) matchesQuery(ritual *Ritual, q RitualQuery) bool {
+	if q.LocationType != "" && ritual.RequiredLocation != q.LocationType {
+		return false
+	}
+	if q.EffectType != "" && !hasEffectType(ritual.Effects, q.EffectType) {
+		return false
+	}
+	if q.UsesSymbol != "" && !containsString(ritual.RequiredSymbols, q.UsesSymbol) {
+		return false
+	}
+	if q.State != "" && rr.discoveryState(ritual) != q.State {
+		return false
+	}
+	if q.EvolutionLevel != nil && ritual.EvolutionLevel != *q.EvolutionLevel {
+		return false
+	}
+	return true
+}
+
+// discoveryState returns what the player knows of a ritual. Must be called with rr.mutex held.
+func (rr *RitualRegistry) discoveryState(ritual *Ritual) string {
+	switch {
+	case ritual.IsDiscovered:
+		return RitualStateDiscovered
+	case rr.rumoredRituals[ritual.ID]:
+		return RitualStateRumored
+	default:
+		return RitualStateUnknown
+	}
+}
+
+// markRumored records that the player has heard rumors of a ritual
+func (rr *RitualRegistry) markRumored(ritualID string) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	rr.rumoredRituals[ritualID] = true
+}
+
+// sortRituals orders rituals by the given key. Must be called with rr.mutex held.
+func (rr *RitualRegistry) sortRituals(rituals []*Ritual, sortBy string, descending bool) {
+	compare := func(a, b *Ritual) int {
+		switch sortBy {
+		case SortByDifficulty:
+			return compareFloats(a.Difficulty, b.Difficulty)
+		case SortByMastery:
+			return rr.compareMastery(a.ID, b.ID)
+		case SortByLastPerformed:
+			switch {
+			case a.LastPerformTime.Before(b.LastPerformTime):
+				return -1
+			case b.LastPerformTime.Before(a.LastPerformTime):
+				return 1
+			}
+		}
+		return 0
+	}
+
+	sort.SliceStable(rituals, func(i, j int) bool {
+		order := compare(rituals[i], rituals[j])
+		if order == 0 {
+			order = strings.Compare(rituals[i].Name, rituals[j].Name)
+		}
+		if order == 0 {
+			order = strings.Compare(rituals[i].ID, rituals[j].ID)
+		}
+		if descending {
+			return order > 0
+		}
+		return order < 0
+	})
+}
+
+// compareMastery compares two rituals by mastery rank, then by successes.
+// Must be called with rr.mutex held.
+func (rr *RitualRegistry) compareMastery(aID, bID string) int {
+	a, b := rr.mastery[aID], rr.mastery[bID]
+	var aRank, bRank, aSuccesses, bSuccesses int
+	if a != nil {
+		aRank, aSuccesses = a.Rank, a.Successes
+	}
+	if b != nil {
+		bRank, bSuccesses = b.Rank, b.Successes
+	}
+
+	if aRank != bRank {
+		return aRank - bRank
+	}
+	return aSuccesses - bSuccesses
+}
+
+// SymbolQuery selects symbols for the codex. Every field is optional; the
+// symbols returned match all the fields that are set.
+type SymbolQuery struct {
+	SymbolType     string      // Of this type
+	Meaning        string      // Has this meaning (case-insensitive)
+	DiscoveredOnly bool        // Only symbols the player has discovered
+	Knowledge      *ValueRange // Knowledge level within this band
+	Distortion     *ValueRange // Distortion within this band
+
+	SortBy     string // SortByName, SortByKnowledge or SortByDistortion
+	Descending bool   // Reverse the order
+
+	Offset int // Number of matching symbols to skip
+	Limit  int // Largest number of symbols to return (0 for all)
+}
+
+// Query returns the symbols matching the query in a stable order
+func (sr *Registry) Query(q SymbolQuery) []*Symbol {
+	sr.mutex.RLock()
+	defer sr.mutex.RUnlock()
+
+	var candidates []*Symbol
+	switch {
+	case q.SymbolType != "":
+		candidates = sr.symbolsByType[q.SymbolType]
+	case q.DiscoveredOnly:
+		candidates = symbolValues(sr.discoveredSymbols)
+	default:
+		candidates = symbolValues(sr.symbols)
+	}
+
+	results := make([]*Symbol, 0)
+	for _, symbol := range candidates {
+		if sr.matchesQuery(symbol, q) {
+			results = append(results, symbol)
+		}
+	}
+
+	sortSymbols(results, q.SortBy, q.Descending)
+	return paginate(results, q.Offset, q.Limit)
+}
+
+// matchesQuery checks a symbol against every field of the query
+func (sr *Registry) matchesQuery(symbol *Symbol, q SymbolQuery) bool {
+	if q.SymbolType != "" && symbol.SymbolType != q.SymbolType {
+		return false
+	}
+	if q.Meaning != "" && !hasMeaning(symbol.Meanings, q.Meaning) {
+		return false
+	}
+	if q.DiscoveredOnly && !symbol.IsDiscovered {
+		return false
+	}
+	return q.Knowledge.contains(symbol.KnowledgeLevel) && q.Distortion.contains(symbol.Distortion)
+}
+
+// sortSymbols orders symbols by the given key, then by name and ID
+func sortSymbols(symbols []*Symbol, sortBy string, descending bool) {
+	sort.SliceStable(symbols, func(i, j int) bool {
+		a, b := symbols[i], symbols[j]
+
+		order := 0
+		switch sortBy {
+		case SortByKnowledge:
+			order = compareFloats(a.KnowledgeLevel, b.KnowledgeLevel)
+		case SortByDistortion:
+			order = compareFloats(a.Distortion, b.Distortion)
+		}
+		if order == 0 {
+			order = strings.Compare(a.Name, b.Name)
+		}
+		if order == 0 {
+			order = strings.Compare(a.ID, b.ID)
+		}
+
+		if descending {
+			return order > 0
+		}
+		return order < 0
+	})
+}
+
+// paginate returns the page of results starting at offset, at most limit long (0 for no limit)
+func paginate[T any](results []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(results) {
+		return results[:0]
+	}
+
+	results = results[offset:]
+	if limit > 0 && limit < len(results) {
+		results = results[:limit]
+	}
+	return results
+}
+
+// compareFloats returns -1, 0 or 1 as a is less than, equal to or greater than b
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// hasEffectType checks whether any of the effects is of the given type
+func hasEffectType(effects []RitualEffect, effectType string) bool {
+	for _, effect := range effects {
+		if effect.Type == effectType {
+			return true
+		}
+	}
+	return false
+}
+
+// hasMeaning checks whether a symbol has a meaning, ignoring case
+func hasMeaning(meanings []string, meaning string) bool {
+	for _, m := range meanings {
+		if strings.EqualFold(m, meaning) {
+			return true
+		}
+	}
+	return false
+}
+
+// ritualValues returns the rituals of a map
+func ritualValues(rituals map[string]*Ritual) []*Ritual {
+	result := make([]*Ritual, 0, len(rituals))
+	for _, ritual := range rituals {
+		result = append(result, ritual)
+	}
+	return result
+}
+
+// symbolValues returns the symbols of a map
+func symbolValues(symbols map[string]*Symbol) []*Symbol {
+	result := make([]*Symbol, 0, len(symbols))
+	for _, symbol := range symbols {
+		result = append(result, symbol)
+	}
+	return result
+}
@@ -0,0 +1,278 @@
+package symbols
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newQueryRituals creates a ritual registry covering every value the ritual
+// query filters on: two locations, two effect types, two symbols, all three
+// discovery states and two evolution levels
+func newQueryRituals() *RitualRegistry {
+	rituals := NewRitualRegistry("", NewRegistry(""))
+	add := func(id, location, effect string, symbols []string, discovered bool, evolution int, difficulty float64) {
+		rituals.AddRitual(&Ritual{
+			ID: id, Name: id, RequiredLocation: location, RequiredSymbols: symbols,
+			Effects:      []RitualEffect{{Type: effect}},
+			IsDiscovered: discovered, EvolutionLevel: evolution, Difficulty: difficulty,
+		})
+	}
+
+	add("r_ash", "forest", "ward", []string{"sym_a"}, true, 0, 0.4)
+	add("r_birch", "forest", "sanity", []string{"sym_a", "sym_b"}, false, 1, 0.2)
+	add("r_cairn", "cave", "ward", []string{"sym_b"}, false, 0, 0.8)
+	add("r_dusk", "cave", "sanity", []string{"sym_a"}, true, 1, 0.4)
+	add("r_elk", "forest", "ward", []string{"sym_b"}, false, 1, 0.6)
+	add("r_fen", "cave", "sanity", []string{"sym_b"}, false, 0, 0.1)
+
+	rituals.markRumored("r_birch")
+	rituals.markRumored("r_fen")
+	return rituals
+}
+
+// expectedRitualIDs lists, by name, the rituals a query should select, checked
+// field by field without the registry's indexes
+func expectedRitualIDs(rr *RitualRegistry, q RitualQuery) []string {
+	ids := make([]string, 0)
+	for _, ritual := range rr.rituals {
+		state := RitualStateUnknown
+		if ritual.IsDiscovered {
+			state = RitualStateDiscovered
+		} else if rr.rumoredRituals[ritual.ID] {
+			state = RitualStateRumored
+		}
+
+		switch {
+		case q.LocationType != "" && ritual.RequiredLocation != q.LocationType:
+		case q.EffectType != "" && ritual.Effects[0].Type != q.EffectType:
+		case q.UsesSymbol != "" && !containsString(ritual.RequiredSymbols, q.UsesSymbol):
+		case q.State != "" && state != q.State:
+		case q.EvolutionLevel != nil && ritual.EvolutionLevel != *q.EvolutionLevel:
+		default:
+			ids = append(ids, ritual.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestRitualQueryFilterCombinations(t *testing.T) {
+	rituals := newQueryRituals()
+	levels := []*int{nil, new(int), new(int)}
+	*levels[2] = 1
+
+	combinations := 0
+	for _, location := range []string{"", "forest", "cave", "swamp"} {
+		for _, effect := range []string{"", "ward", "sanity"} {
+			for _, symbol := range []string{"", "sym_a", "sym_b"} {
+				for _, state := range []string{"", RitualStateDiscovered, RitualStateRumored, RitualStateUnknown} {
+					for _, level := range levels {
+						q := RitualQuery{LocationType: location, EffectType: effect, UsesSymbol: symbol, State: state, EvolutionLevel: level}
+						got := strings.Join(ritualIDs(rituals.Query(q)), ",")
+						want := strings.Join(expectedRitualIDs(rituals, q), ",")
+						if got != want {
+							t.Errorf("Query(%+v) = [%s], want [%s]", q, got, want)
+						}
+						combinations++
+					}
+				}
+			}
+		}
+	}
+	if combinations != 432 {
+		t.Errorf("checked %d filter combinations, want 432", combinations)
+	}
+}
+
+func TestRitualQuerySortsWithStableTies(t *testing.T) {
+	rituals := newQueryRituals()
+	epoch := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"r_fen", "r_ash", "r_elk"} {
+		rituals.rituals[id].LastPerformTime = epoch.Add(time.Duration(i) * time.Hour)
+	}
+	rituals.mastery["r_cairn"] = &RitualMastery{RitualID: "r_cairn", Rank: 1, Successes: 2}
+	rituals.mastery["r_ash"] = &RitualMastery{RitualID: "r_ash", Rank: 1, Successes: 5}
+	rituals.mastery["r_dusk"] = &RitualMastery{RitualID: "r_dusk", Successes: 3}
+
+	tests := []struct {
+		sortBy     string
+		descending bool
+		want       string
+	}{
+		{SortByName, false, "r_ash,r_birch,r_cairn,r_dusk,r_elk,r_fen"},
+		{SortByName, true, "r_fen,r_elk,r_dusk,r_cairn,r_birch,r_ash"},
+		// r_ash and r_dusk share a difficulty and fall back to name order
+		{SortByDifficulty, false, "r_fen,r_birch,r_ash,r_dusk,r_elk,r_cairn"},
+		{SortByMastery, false, "r_birch,r_elk,r_fen,r_dusk,r_cairn,r_ash"},
+		{SortByLastPerformed, false, "r_birch,r_cairn,r_dusk,r_fen,r_ash,r_elk"},
+		{SortByLastPerformed, true, "r_elk,r_ash,r_fen,r_dusk,r_cairn,r_birch"},
+	}
+	for _, tt := range tests {
+		got := strings.Join(ritualIDs(rituals.Query(RitualQuery{SortBy: tt.sortBy, Descending: tt.descending})), ",")
+		if got != tt.want {
+			t.Errorf("sort %q descending=%v = [%s], want [%s]", tt.sortBy, tt.descending, got, tt.want)
+		}
+	}
+}
+
+func TestQueryPagination(t *testing.T) {
+	rituals := newQueryRituals()
+
+	tests := []struct {
+		offset, limit int
+		want          string
+	}{
+		{0, 0, "r_ash,r_birch,r_cairn,r_dusk,r_elk,r_fen"},
+		{0, 2, "r_ash,r_birch"},
+		{2, 2, "r_cairn,r_dusk"},
+		{4, 10, "r_elk,r_fen"},
+		{6, 2, ""},
+		{-3, 1, "r_ash"},
+	}
+	for _, tt := range tests {
+		got := strings.Join(ritualIDs(rituals.Query(RitualQuery{Offset: tt.offset, Limit: tt.limit})), ",")
+		if got != tt.want {
+			t.Errorf("offset %d limit %d = [%s], want [%s]", tt.offset, tt.limit, got, tt.want)
+		}
+	}
+
+	// Pages follow the filter and the order
+	page := ritualIDs(rituals.Query(RitualQuery{LocationType: "forest", SortBy: SortByDifficulty, Offset: 1, Limit: 1}))
+	if len(page) != 1 || page[0] != "r_ash" {
+		t.Errorf("second easiest forest ritual = %v, want [r_ash]", page)
+	}
+}
+
+// newQuerySymbols creates a symbol registry covering every value the symbol
+// query filters on, with knowledge and distortion on the band edges
+func newQuerySymbols() *Registry {
+	registry := NewRegistry("")
+	add := func(id, symbolType string, meanings []string, discovered bool, knowledge, distortion float64) {
+		registry.AddSymbol(&Symbol{
+			ID: id, Name: id, SymbolType: symbolType, Meanings: meanings,
+			IsDiscovered: discovered, KnowledgeLevel: knowledge, Distortion: distortion,
+		})
+	}
+
+	add("s_antler", "protection", []string{"warmth"}, true, 0.5, 0.0)
+	add("s_brand", "protection", []string{"frost", "warmth"}, false, 0.1, 0.2)
+	add("s_coil", "nature", []string{"Frost"}, true, 0.9, 0.7)
+	add("s_drift", "nature", []string{"warmth"}, false, 0.0, 1.0)
+	add("s_ember", "protection", nil, true, 1.0, 0.2)
+	return registry
+}
+
+// expectedSymbolIDs lists, by name, the symbols a query should select
+func expectedSymbolIDs(sr *Registry, q SymbolQuery) []string {
+	within := func(band *ValueRange, value float64) bool {
+		return band == nil || (band.Min <= value && value <= band.Max)
+	}
+
+	ids := make([]string, 0)
+	for _, symbol := range sr.symbols {
+		meaningFound := q.Meaning == ""
+		for _, meaning := range symbol.Meanings {
+			meaningFound = meaningFound || strings.EqualFold(meaning, q.Meaning)
+		}
+
+		switch {
+		case q.SymbolType != "" && symbol.SymbolType != q.SymbolType:
+		case !meaningFound:
+		case q.DiscoveredOnly && !symbol.IsDiscovered:
+		case !within(q.Knowledge, symbol.KnowledgeLevel) || !within(q.Distortion, symbol.Distortion):
+		default:
+			ids = append(ids, symbol.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestSymbolQueryFilterCombinations(t *testing.T) {
+	registry := newQuerySymbols()
+	knowledgeBands := []*ValueRange{nil, {Min: 0, Max: 0.5}, {Min: 0.5, Max: 1}}
+	distortionBands := []*ValueRange{nil, {Min: 0, Max: 0.2}, {Min: 0.2, Max: 1}}
+
+	combinations := 0
+	for _, symbolType := range []string{"", "protection", "nature", "void"} {
+		for _, meaning := range []string{"", "WARMTH", "frost", "silence"} {
+			for _, discoveredOnly := range []bool{false, true} {
+				for _, knowledge := range knowledgeBands {
+					for _, distortion := range distortionBands {
+						q := SymbolQuery{SymbolType: symbolType, Meaning: meaning, DiscoveredOnly: discoveredOnly,
+							Knowledge: knowledge, Distortion: distortion}
+						got := strings.Join(searchedSymbolIDs(registry.Query(q)), ",")
+						want := strings.Join(expectedSymbolIDs(registry, q), ",")
+						if got != want {
+							t.Errorf("Query(%s) = [%s], want [%s]", describeSymbolQuery(q), got, want)
+						}
+						combinations++
+					}
+				}
+			}
+		}
+	}
+	if combinations != 288 {
+		t.Errorf("checked %d filter combinations, want 288", combinations)
+	}
+}
+
+// describeSymbolQuery prints a symbol query with its bands
+func describeSymbolQuery(q SymbolQuery) string {
+	band := func(vr *ValueRange) string {
+		if vr == nil {
+			return "any"
+		}
+		return fmt.Sprintf("%.1f-%.1f", vr.Min, vr.Max)
+	}
+	return fmt.Sprintf("type=%q meaning=%q discovered=%v knowledge=%s distortion=%s",
+		q.SymbolType, q.Meaning, q.DiscoveredOnly, band(q.Knowledge), band(q.Distortion))
+}
+
+func TestSymbolQuerySortsWithStableTies(t *testing.T) {
+	registry := newQuerySymbols()
+
+	tests := []struct {
+		q    SymbolQuery
+		want string
+	}{
+		{SymbolQuery{}, "s_antler,s_brand,s_coil,s_drift,s_ember"},
+		{SymbolQuery{SortBy: SortByKnowledge}, "s_drift,s_brand,s_antler,s_coil,s_ember"},
+		{SymbolQuery{SortBy: SortByKnowledge, Descending: true}, "s_ember,s_coil,s_antler,s_brand,s_drift"},
+		// s_brand and s_ember share a distortion and fall back to name order
+		{SymbolQuery{SortBy: SortByDistortion}, "s_antler,s_brand,s_ember,s_coil,s_drift"},
+		{SymbolQuery{SortBy: SortByDistortion, Offset: 1, Limit: 2}, "s_brand,s_ember"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(searchedSymbolIDs(registry.Query(tt.q)), ","); got != tt.want {
+			t.Errorf("Query(%+v) = [%s], want [%s]", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestQueriesTakeOnlyReadLocks(t *testing.T) {
+	rituals := newQueryRituals()
+	registry := newQuerySymbols()
+
+	// Another reader holds both registries; a query that wanted the write lock would block
+	rituals.mutex.RLock()
+	defer rituals.mutex.RUnlock()
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	done := make(chan struct{})
+	go func() {
+		rituals.Query(RitualQuery{State: RitualStateRumored, SortBy: SortByMastery})
+		registry.Query(SymbolQuery{DiscoveredOnly: true})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("queries blocked behind a reader")
+	}
+}
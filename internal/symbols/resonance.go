@@ -0,0 +1,215 @@
+package symbols
+
+import (
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Resonance settings
+const (
+	ResonanceStrength        = 0.5  // Extra power of a symbol at the height of its resonance
+	ResonanceWidth           = 0.08 // Share of a day over which a time-of-day peak fades
+	ResonanceSuccessScale    = 0.5  // Share of a ritual's resonance that affects its success chance
+	ResonanceRevealKnowledge = 0.7  // Knowledge at which the codex shows a symbol's schedule
+	ResonanceInterval        = 0.2  // Seconds between updates of resonating symbol entities
+	resonanceSource          = "resonance"
+)
+
+// Times of day (0 is midnight) symbols resonate with
+const (
+	Midnight = 0.0
+	Dawn     = 0.25
+	Dusk     = 0.75
+)
+
+// elementalWeather lists the weather each elemental meaning resonates with
+var elementalWeather = map[string][]string{
+	"water": {"rain", "storm"},
+	"air":   {"storm"},
+	"ice":   {"snow"},
+	"earth": {"fog"},
+	"fire":  {"clear"},
+}
+
+// ResonanceSchedule describes when a symbol grows stronger
+type ResonanceSchedule struct {
+	Peaks    []float64 // Times of day (0-1) of full resonance
+	Weather  []string  // Weather of full resonance
+	Strength float64   // Extra power at full resonance
+}
+
+// EnvironmentSource reports the time of day and weather symbols resonate with (e.g. world.World)
+type EnvironmentSource interface {
+	GetGlobalTimeOfDay() float64
+	GetWeatherCondition() string
+}
+
+// SetEnvironmentSource sets the source of the time of day and weather
+func (sm *Manager) SetEnvironmentSource(source EnvironmentSource) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.environment = source
+}
+
+// resonanceForType returns the resonance schedule of a symbol type: void symbols
+// peak at midnight, primal ones at dawn and dusk and elemental ones in the
+// weather matching their meanings. Other types do not resonate.
+func resonanceForType(symbolType string, meanings []string) *ResonanceSchedule {
+	switch symbolType {
+	case "void":
+		return &ResonanceSchedule{Peaks: []float64{Midnight}, Strength: ResonanceStrength}
+	case "primal":
+		return &ResonanceSchedule{Peaks: []float64{Dawn, Dusk}, Strength: ResonanceStrength}
+	case "elemental":
+		weather := make([]string, 0)
+		for _, meaning := range meanings {
+			for _, kind := range elementalWeather[meaning] {
+				if !containsString(weather, kind) {
+					weather = append(weather, kind)
+				}
+			}
+		}
+		if len(weather) == 0 {
+			weather = append(weather, "storm")
+		}
+		return &ResonanceSchedule{Weather: weather, Strength: ResonanceStrength}
+	default:
+		return nil
+	}
+}
+
+// Resonance returns the multiplier of a symbol's power at a time of day and in
+// a weather: 1 outside its schedule, up to 1 + Strength at its peaks
+func (rs *ResonanceSchedule) Resonance(timeOfDay float64, weather string) float64 {
+	if rs == nil {
+		return 1.0
+	}
+
+	level := 0.0
+	if containsString(rs.Weather, weather) {
+		level = 1.0
+	}
+	for _, peak := range rs.Peaks {
+		// Distance around the clock, so that 0.98 is close to midnight
+		distance := math.Abs(timeOfDay - peak)
+		distance = math.Min(distance, 1-distance)
+		level = math.Max(level, 1-distance/ResonanceWidth)
+	}
+	return 1.0 + rs.Strength*level
+}
+
+// GetCurrentResonance returns the multiplier of a symbol's power at a time of day and in a weather
+func (sm *Manager) GetCurrentResonance(symbolID string, timeOfDay float64, weather string) float64 {
+	symbol := sm.Registry.GetSymbol(symbolID)
+	if symbol == nil {
+		return 1.0
+	}
+	return symbol.Resonance.Resonance(timeOfDay, weather)
+}
+
+// symbolResonance returns the multiplier of a symbol's power in the current
+// environment and with the player's resonating tablets. Must be called with sm.mutex held.
+func (sm *Manager) symbolResonance(symbol *Symbol) float64 {
+	if symbol == nil {
+		return 1.0
+	}
+	resonance := 1.0 + sm.tabletBoost[symbol.ID]
+	if sm.environment != nil {
+		resonance *= symbol.Resonance.Resonance(sm.environment.GetGlobalTimeOfDay(), sm.environment.GetWeatherCondition())
+	}
+	return resonance
+}
+
+// ritualResonance returns the average resonance of a ritual's symbols in the
+// current environment. Must be called with sm.mutex held.
+func (sm *Manager) ritualResonance(ritual *Ritual) float64 {
+	total, count := 0.0, 0
+	for _, id := range ritual.RequiredSymbols {
+		if symbol := sm.Registry.GetSymbol(id); symbol != nil {
+			total += sm.symbolResonance(symbol)
+			count++
+		}
+	}
+	if count == 0 {
+		return 1.0
+	}
+	return total / float64(count)
+}
+
+// resonanceChance returns the multiplier of a ritual's success chance for its resonance
+func resonanceChance(resonance float64) float64 {
+	return 1.0 + (resonance-1.0)*ResonanceSuccessScale
+}
+
+// ResonanceSystem makes symbol entities pulse with their symbol's resonance.
+// The light is scaled by the change in resonance rather than set outright, so
+// other systems dimming or restoring the same light keep their effect.
+type ResonanceSystem struct {
+	manager *Manager
+	applied map[ecs.EntityID]float64 // Resonance each symbol entity's light is currently scaled by
+	elapsed float64
+}
+
+// NewResonanceSystem creates the system that shows symbol resonance
+func NewResonanceSystem(manager *Manager) *ResonanceSystem {
+	return &ResonanceSystem{
+		manager: manager,
+		applied: make(map[ecs.EntityID]float64),
+	}
+}
+
+// RequiredComponents implements ecs.System
+func (rs *ResonanceSystem) RequiredComponents() []ecs.ComponentID {
+	return []ecs.ComponentID{ecs.SymbolComponentID}
+}
+
+// Update brightens the light and glow of symbol entities in proportion to their resonance
+func (rs *ResonanceSystem) Update(deltaTime float64) {
+	rs.elapsed += deltaTime
+	if rs.elapsed < ResonanceInterval {
+		return
+	}
+	rs.elapsed = 0
+
+	seen := make(map[ecs.EntityID]bool, len(rs.applied))
+	for _, entity := range rs.manager.world.GetEntitiesWithComponent(ecs.SymbolComponentID) {
+		symbolComp, has := ecs.ComponentAs[*ecs.SymbolComponent](entity, ecs.SymbolComponentID)
+		if !has {
+			continue
+		}
+
+		rs.manager.mutex.RLock()
+		resonance := rs.manager.symbolResonance(rs.manager.Registry.GetSymbol(symbolComp.SymbolID))
+		rs.manager.mutex.RUnlock()
+
+		if light, has := ecs.ComponentAs[*ecs.LightComponent](entity, ecs.LightComponentID); has {
+			// The light without resonance is whatever it is now, less the resonance applied last time
+			previous, known := rs.applied[entity.ID]
+			if !known {
+				previous = 1.0
+			}
+			if resonance != previous {
+				light.Intensity = light.Intensity / previous * resonance
+			}
+			rs.applied[entity.ID] = resonance
+			seen[entity.ID] = true
+		}
+
+		if render, has := ecs.ComponentAs[*ecs.RenderComponent](entity, ecs.RenderComponentID); has {
+			if resonance > 1.0 {
+				render.Effects.AddEffect("glow", math.Min(1.0, resonance-1.0), resonanceSource)
+			} else {
+				render.Effects.RemoveEffect("glow", resonanceSource)
+			}
+		}
+	}
+
+	// Forget symbol entities that are gone
+	for id := range rs.applied {
+		if !seen[id] {
+			delete(rs.applied, id)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package symbols
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// fakeEnvironment reports a settable time of day and weather
+type fakeEnvironment struct {
+	timeOfDay float64
+	weather   string
+}
+
+func (fe *fakeEnvironment) GetGlobalTimeOfDay() float64 { return fe.timeOfDay }
+func (fe *fakeEnvironment) GetWeatherCondition() string { return fe.weather }
+
+func TestResonanceScalesLightWithoutOverwritingIt(t *testing.T) {
+	sm, world := newTestManager(t, 1)
+	env := &fakeEnvironment{timeOfDay: Midnight, weather: "clear"}
+	sm.SetEnvironmentSource(env)
+	sm.Registry.AddSymbol(&Symbol{ID: "test_hollow", SymbolType: "void", Resonance: resonanceForType("void", nil)})
+
+	entity := ecs.NewEntity()
+	entity.AddComponent(ecs.NewSymbolComponent("test_hollow", "void", 0.5, 0.5))
+	light := ecs.NewLightComponent(color.RGBA{A: 255}, 1.0, 5.0)
+	entity.AddComponent(light)
+	world.AddEntity(entity)
+
+	rs := NewResonanceSystem(sm)
+	expect := func(step string, want float64) {
+		t.Helper()
+		rs.Update(ResonanceInterval)
+		if math.Abs(light.Intensity-want) > 1e-9 {
+			t.Errorf("%s: intensity = %v, want %v", step, light.Intensity, want)
+		}
+	}
+
+	expect("midnight", 1.0+ResonanceStrength)
+
+	// A scare dims the light by half while the symbol resonates
+	light.Intensity *= 0.5
+	expect("dimmed at midnight", 0.5*(1.0+ResonanceStrength))
+
+	env.timeOfDay = 0.5
+	expect("dimmed at noon", 0.5)
+
+	// The scare ends and restores the light
+	light.Intensity = 1.0
+	env.timeOfDay = Midnight
+	expect("restored at midnight", 1.0+ResonanceStrength)
+}
+
+// resonantSymbol returns a discovered symbol with its type's resonance schedule
+func resonantSymbol(id, symbolType string, meanings ...string) *Symbol {
+	symbol := testSymbol(id, symbolType)
+	symbol.Meanings = meanings
+	symbol.Resonance = resonanceForType(symbolType, meanings)
+	return symbol
+}
+
+func TestCurrentResonanceFollowsTimeAndWeather(t *testing.T) {
+	sm, _ := newTestManager(t, 1,
+		resonantSymbol("test_hollow", "void"),
+		resonantSymbol("test_antler", "primal"),
+		resonantSymbol("test_tide", "elemental", "water"),
+		resonantSymbol("test_gale", "elemental"),
+		resonantSymbol("test_rune", "arcane"),
+	)
+	peak := 1.0 + ResonanceStrength
+
+	tests := []struct {
+		symbolID  string
+		timeOfDay float64
+		weather   string
+		want      float64
+	}{
+		{"test_hollow", Midnight, "clear", peak},
+		{"test_hollow", 0.98, "clear", 1.0 + ResonanceStrength*0.75},
+		{"test_hollow", 0.04, "storm", 1.0 + ResonanceStrength*0.5},
+		{"test_hollow", 0.5, "clear", 1.0},
+		{"test_antler", Dawn, "clear", peak},
+		{"test_antler", Dusk, "fog", peak},
+		{"test_antler", Midnight, "clear", 1.0},
+		{"test_antler", 0.5, "storm", 1.0},
+		{"test_tide", 0.5, "rain", peak},
+		{"test_tide", Midnight, "storm", peak},
+		{"test_tide", Midnight, "snow", 1.0},
+		// Elemental symbols without a weather meaning resonate with storms
+		{"test_gale", 0.5, "storm", peak},
+		{"test_gale", 0.5, "rain", 1.0},
+		{"test_rune", Midnight, "storm", 1.0},
+		{"test_missing", Midnight, "storm", 1.0},
+	}
+	for _, tt := range tests {
+		if got := sm.GetCurrentResonance(tt.symbolID, tt.timeOfDay, tt.weather); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("resonance of %s at %.2f in %s = %v, want %v", tt.symbolID, tt.timeOfDay, tt.weather, got, tt.want)
+		}
+	}
+}
+
+func TestForecastChanceIncludesResonanceAndMatchesTheRoll(t *testing.T) {
+	// newVoidRitualManager creates a manager seeded with seed and a void ritual,
+	// under the given time of day
+	newVoidRitualManager := func(seed int64, timeOfDay float64) (*Manager, *Ritual) {
+		sm, _ := newTestManager(t, seed, resonantSymbol("test_hollow", "void"))
+		sm.SetEnvironmentSource(&fakeEnvironment{timeOfDay: timeOfDay, weather: "clear"})
+		ritual := &Ritual{
+			ID:              "test_vigil",
+			Name:            "Vigil",
+			RequiredSymbols: []string{"test_hollow"},
+			Difficulty:      0.5,
+			SuccessChance:   0.6,
+			IsDiscovered:    true,
+			Effects:         []RitualEffect{{Type: "sanity", Target: "player", Value: 5}},
+		}
+		sm.RitualRegistry.AddRitual(ritual)
+		return sm, ritual
+	}
+	forecast := func(timeOfDay float64) float64 {
+		sm, ritual := newVoidRitualManager(1, timeOfDay)
+		return sm.ForecastRitual(ritual, ecs.Vector3{}, nil).SuccessChance
+	}
+
+	atMidnight, atNoon := forecast(Midnight), forecast(0.5)
+	if want := atNoon * resonanceChance(1.0+ResonanceStrength); math.Abs(atMidnight-want) > 1e-9 {
+		t.Fatalf("forecast at midnight = %v, want %v (noon %v with full resonance)", atMidnight, want, atNoon)
+	}
+
+	// Find a roll that resonance turns from one outcome into another
+	sm, _ := newVoidRitualManager(1, Midnight)
+	seed := int64(0)
+	for candidate := int64(1); candidate <= 1000 && seed == 0; candidate++ {
+		roll := engine.NewRandStream(candidate, RandStream).Float64()
+		if sm.rollOutcome(roll, atMidnight) != sm.rollOutcome(roll, atNoon) {
+			seed = candidate
+		}
+	}
+	if seed == 0 {
+		t.Fatalf("no seed in 1..1000 rolls differently at %v and %v", atMidnight, atNoon)
+	}
+
+	// The performed ritual is graded against the chance the forecast showed
+	roll := engine.NewRandStream(seed, RandStream).Float64()
+	for _, tt := range []struct {
+		timeOfDay float64
+		chance    float64
+	}{{Midnight, atMidnight}, {0.5, atNoon}} {
+		sm, ritual := newVoidRitualManager(seed, tt.timeOfDay)
+		result := sm.PerformRitual(ritual, ecs.Vector3{}, nil, ritual.Actions)
+		if want := sm.rollOutcome(roll, tt.chance); result.Outcome != want {
+			t.Errorf("ritual at %.2f rolled %v, want %v from the forecast chance %v", tt.timeOfDay, result.Outcome, want, tt.chance)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package symbols
+
+import (
+	"math"
+	"sort"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Reach of "knowledge" ritual effects
+const (
+	RevealRadiusScale  = 100.0 // Radius of a symbol reveal per unit of effect value
+	RevealAreaDuration = 300.0 // Seconds a revealed anomaly field stays on the map
+)
+
+// SymbolMarker puts symbols revealed by rituals on the map (e.g. world.World)
+type SymbolMarker interface {
+	MarkSymbolSighted(position ecs.Vector3, label string)
+}
+
+// AreaRevealer shows the anomaly field around a point on the map for a while (e.g. world.World)
+type AreaRevealer interface {
+	RevealAnomalyField(position ecs.Vector3, duration float64)
+}
+
+// SetSymbolMarker sets where symbols revealed by rituals are marked
+func (sm *Manager) SetSymbolMarker(marker SymbolMarker) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.symbolMarker = marker
+}
+
+// SetAreaRevealer sets what reveals the anomaly field for area knowledge effects
+func (sm *Manager) SetAreaRevealer(revealer AreaRevealer) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.areaRevealer = revealer
+}
+
+// applyKnowledgeEffects resolves "knowledge" effects by target: "symbols" reveals
+// symbols around the location, "rituals" nudges the player toward the next ritual
+// and "area" reveals the anomaly field of the location's chunk. Each reveal is
+// reported through OnKnowledgeRevealed. Must be called with sm.mutex held.
+func (sm *Manager) applyKnowledgeEffects(effects []RitualEffect, location ecs.Vector3) {
+	for _, effect := range effects {
+		if effect.Type != "knowledge" || effect.Value <= 0 {
+			continue
+		}
+
+		revealed := 0
+		switch effect.Target {
+		case "symbols":
+			revealed = sm.revealNearbySymbols(location, effect.Value)
+		case "rituals":
+			revealed = sm.nudgeClosestRitual(effect.Value)
+		case "area":
+			if sm.areaRevealer == nil {
+				continue
+			}
+			sm.areaRevealer.RevealAnomalyField(location, RevealAreaDuration)
+			revealed = 1
+		default:
+			continue
+		}
+
+		if sm.OnKnowledgeRevealed != nil {
+			sm.OnKnowledgeRevealed(effect.Target, location, revealed)
+		}
+	}
+}
+
+// revealNearbySymbols reveals symbol entities within value*RevealRadiusScale of
+// the location: undiscovered ones are glimpsed and marked on the map, discovered
+// ones are understood a little better. Returns the number of symbols reached.
+func (sm *Manager) revealNearbySymbols(location ecs.Vector3, value float64) int {
+	radius := value * RevealRadiusScale
+	revealed := 0
+
+	for _, entity := range sm.world.GetEntitiesWithComponent(ecs.SymbolComponentID) {
+		symbolComp, has := ecs.ComponentAs[*ecs.SymbolComponent](entity, ecs.SymbolComponentID)
+		if !has {
+			continue
+		}
+		position, has := entityPosition(entity)
+		if !has || location.Distance(position) > radius {
+			continue
+		}
+
+		symbol := sm.Registry.GetSymbol(symbolComp.SymbolID)
+		if symbolComp.Discovered {
+			if symbol != nil {
+				sm.addSymbolKnowledge(symbol, value)
+			}
+			revealed++
+			continue
+		}
+
+		if !sm.glimpsedSymbols[entity.ID] {
+			sm.glimpsedSymbols[entity.ID] = true
+			if symbol != nil {
+				sm.grantGlimpse(symbol)
+			}
+		}
+		if sm.symbolMarker != nil {
+			label := symbolComp.SymbolType
+			if symbol != nil {
+				label = symbol.Name
+			}
+			sm.symbolMarker.MarkSymbolSighted(position, label)
+		}
+		revealed++
+	}
+
+	return revealed
+}
+
+// nudgeClosestRitual grants knowledge of the symbols of the undiscovered ritual
+// the player is closest to discovering. Returns the number of symbols nudged.
+func (sm *Manager) nudgeClosestRitual(value float64) int {
+	rituals := sm.RitualRegistry.GetUndiscoveredRituals()
+	sort.Slice(rituals, func(i, j int) bool { return rituals[i].ID < rituals[j].ID })
+
+	var closest *Ritual
+	best := -1.0
+	for _, ritual := range rituals {
+		if len(ritual.RequiredSymbols) == 0 {
+			continue
+		}
+
+		progress := sm.playerKnowledge[ritual.ID]
+		for _, symbolID := range ritual.RequiredSymbols {
+			progress += sm.playerKnowledge[symbolID] / float64(len(ritual.RequiredSymbols))
+		}
+		if progress > best {
+			closest, best = ritual, progress
+		}
+	}
+	if closest == nil {
+		return 0
+	}
+
+	nudged := 0
+	for _, symbolID := range uniqueStrings(closest.RequiredSymbols) {
+		if symbol := sm.Registry.GetSymbol(symbolID); symbol != nil {
+			sm.addSymbolKnowledge(symbol, value)
+			nudged++
+		}
+	}
+	return nudged
+}
+
+// addSymbolKnowledge raises the player's knowledge of a symbol, sketching it in
+// the journal once an undiscovered symbol is known well enough. Must be called
+// with sm.mutex held.
+func (sm *Manager) addSymbolKnowledge(symbol *Symbol, amount float64) {
+	previous := sm.playerKnowledge[symbol.ID]
+	level := math.Min(previous+amount, 1)
+	sm.playerKnowledge[symbol.ID] = level
+	if symbol.IsDiscovered {
+		symbol.KnowledgeLevel = level
+	}
+
+	if !symbol.IsDiscovered && previous < SketchedSymbolKnowledge && level >= SketchedSymbolKnowledge && sm.OnSymbolSketched != nil {
+		sm.OnSymbolSketched(symbol)
+	}
+}
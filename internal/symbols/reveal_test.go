@@ -0,0 +1,85 @@
+package symbols
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// recordingMarker remembers the labels of the symbols marked on the map
+type recordingMarker struct {
+	labels []string
+}
+
+func (rm *recordingMarker) MarkSymbolSighted(position ecs.Vector3, label string) {
+	rm.labels = append(rm.labels, label)
+}
+
+// addHiddenSymbol carves an undiscovered symbol into the world at a distance from the origin
+func addHiddenSymbol(sm *Manager, world *ecs.World, id string, distance float64) *ecs.Entity {
+	sm.Registry.AddSymbol(&Symbol{ID: id, Name: id, SymbolType: "elemental"})
+
+	entity := ecs.NewEntity()
+	entity.AddComponent(ecs.NewTransformComponent(ecs.Vector3{X: distance}))
+	entity.AddComponent(ecs.NewSymbolComponent(id, "elemental", 0.5, 0.5))
+	world.AddEntity(entity)
+	return entity
+}
+
+// applyKnowledge applies a "knowledge" effect at the origin
+func applyKnowledge(sm *Manager, target string, value float64) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.applyKnowledgeEffects([]RitualEffect{{Type: "knowledge", Target: target, Value: value}}, ecs.Vector3{})
+}
+
+func TestRevealSymbolsReachesOnlySymbolsInRange(t *testing.T) {
+	sm, world := newTestManager(t, 1)
+	marker := &recordingMarker{}
+	sm.SetSymbolMarker(marker)
+
+	// Value 0.5 reaches 50 units
+	near := addHiddenSymbol(sm, world, "test_near", 10)
+	edge := addHiddenSymbol(sm, world, "test_edge", 49)
+	far := addHiddenSymbol(sm, world, "test_far", 80)
+	applyKnowledge(sm, "symbols", 0.5)
+
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+	for _, entity := range []*ecs.Entity{near, edge} {
+		if !sm.glimpsedSymbols[entity.ID] {
+			t.Errorf("symbol in range %s was not glimpsed", entity.ID)
+		}
+	}
+	if sm.glimpsedSymbols[far.ID] {
+		t.Errorf("symbol out of range was glimpsed")
+	}
+	if len(marker.labels) != 2 {
+		t.Errorf("marked %v, want the two symbols in range", marker.labels)
+	}
+}
+
+func TestRevealRitualsOnlyNudgesTheClosestRitual(t *testing.T) {
+	sm, _ := newTestManager(t, 1, &Symbol{ID: "test_ash", Name: "Ash", IsDiscovered: true}, &Symbol{ID: "test_ember", Name: "Ember", IsDiscovered: true})
+	known := &Ritual{ID: "test_kindling", Name: "Kindling", RequiredSymbols: []string{"test_ash"}, Difficulty: 0.5, IsDiscovered: true}
+	hidden := &Ritual{ID: "test_pyre", Name: "Pyre", RequiredSymbols: []string{"test_ember"}, Difficulty: 0.5}
+	sm.RitualRegistry.AddRitual(known)
+	sm.RitualRegistry.AddRitual(hidden)
+
+	sm.mutex.Lock()
+	sm.playerKnowledge["test_kindling"] = 0.2
+	sm.mutex.Unlock()
+
+	applyKnowledge(sm, "rituals", 0.1)
+
+	if got := sm.GetKnowledgeLevel("test_kindling"); got != 0.2 {
+		t.Errorf("discovered ritual knowledge = %v, want it untouched at 0.2", got)
+	}
+	if got := sm.GetKnowledgeLevel("test_ember"); got < 0.1-1e-9 {
+		t.Errorf("symbol of the closest ritual has knowledge %v, want at least 0.1", got)
+	}
+	if hidden.IsDiscovered {
+		t.Errorf("nudge discovered the ritual outright")
+	}
+}
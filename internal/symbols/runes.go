@@ -0,0 +1,369 @@
+package symbols
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/savefile"
+)
+
+// Rune word tuning
+const (
+	MinRuneWordLength = 2
+	MaxRuneWordLength = 3
+
+	runeWordValidPercent  = 40  // Share of meaning-compatible sequences that form a word
+	RuneWordMagnitude     = 0.3 // Rune words are much weaker than full rituals
+	runeWordFatigueCost   = 5.0 // Fatigue added by a successful cast (0-100 scale)
+	runeWordFizzleSanity  = 3.0 // Sanity lost when a word fizzles (0-100 scale)
+	runeWordHintThreshold = 3   // Distinct failed words sharing a symbol before a hint
+
+	runeWordsFile = "rune_words.json"
+)
+
+// Kinds of rune word effects
+var runeWordKinds = []string{"light", "ward_pulse", "calm_animals"}
+
+// RuneWordHint points the player toward a valid word
+type RuneWordHint struct {
+	SymbolID  string // Symbol the player keeps failing with
+	PartnerID string // A symbol it forms a valid word with
+}
+
+// RuneWordResult describes the outcome of casting a rune word
+type RuneWordResult struct {
+	Word            []string       // Traced symbol sequence
+	Kind            string         // Effect kind ("" if the word fizzled)
+	Success         bool           // Whether the sequence formed a valid word
+	NewlyDiscovered bool           // Whether this was the first successful cast
+	Effects         []RitualEffect // Applied effects at rune word magnitude
+	Skipped         []RitualEffect // Effects that could not be carried out (see RitualResult.Skipped)
+	FatigueCost     float64        // Fatigue added to the caster
+	SanityCost      float64        // Sanity taken from the caster
+	Hint            *RuneWordHint  // Granted after repeated failures (may be nil)
+}
+
+// CastReceiver is implemented by systems that track player casting
+// (e.g. fear.Director)
+type CastReceiver interface {
+	RecordRuneWordCast(position ecs.Vector3, word []string, success bool)
+}
+
+// runeWordState is the persisted rune word discovery and usage state
+type runeWordState struct {
+	KnownWords   map[string]string   // Discovered word key -> effect kind
+	UseCounts    map[string]int      // Successful casts per word key
+	FailedWords  map[string][]string // Distinct fizzled sequences by key
+	HintedSymbol map[string]string   // Symbol -> partner already hinted
+}
+
+// newRuneWordState creates an empty rune word state
+func newRuneWordState() *runeWordState {
+	return &runeWordState{
+		KnownWords:   make(map[string]string),
+		UseCounts:    make(map[string]int),
+		FailedWords:  make(map[string][]string),
+		HintedSymbol: make(map[string]string),
+	}
+}
+
+// RandStream is the name of the manager's random stream (see engine.NewRandStream)
+const RandStream = "symbols"
+
+// SetWorldSeed sets the seed that decides which symbol sequences form rune words,
+// and derives the manager's random stream for ritual rolls from it
+func (sm *Manager) SetWorldSeed(seed int64) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.worldSeed = seed
+	sm.rng = engine.NewRandStream(seed, RandStream)
+}
+
+// SetCastReceiver sets the system notified when the player casts rune words
+func (sm *Manager) SetCastReceiver(receiver CastReceiver) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.castReceiver = receiver
+}
+
+// GetKnownRuneWords returns the rune words the player has discovered
+func (sm *Manager) GetKnownRuneWords() map[string]string {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	result := make(map[string]string, len(sm.runeWords.KnownWords))
+	for key, kind := range sm.runeWords.KnownWords {
+		result[key] = kind
+	}
+	return result
+}
+
+// CastRuneWord traces a sequence of 2-3 discovered symbols. Valid words produce a
+// minor instant effect at a fatigue cost; anything else fizzles and costs sanity.
+func (sm *Manager) CastRuneWord(sequence []string) (RuneWordResult, error) {
+	result := RuneWordResult{Word: sequence}
+
+	if len(sequence) < MinRuneWordLength || len(sequence) > MaxRuneWordLength {
+		return result, fmt.Errorf("rune word must have %d-%d symbols, got %d", MinRuneWordLength, MaxRuneWordLength, len(sequence))
+	}
+
+	symbolList := make([]*Symbol, 0, len(sequence))
+	for _, symbolID := range sequence {
+		symbol := sm.Registry.GetSymbol(symbolID)
+		if symbol == nil {
+			return result, fmt.Errorf("unknown symbol: %s", symbolID)
+		}
+		if !symbol.IsDiscovered {
+			return result, fmt.Errorf("symbol not discovered: %s", symbolID)
+		}
+		symbolList = append(symbolList, symbol)
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	// The caster's position anchors the effects
+	player := sm.getPlayerEntity()
+	var location ecs.Vector3
+	if player != nil {
+		location, _ = entityPosition(player)
+	}
+
+	key := runeWordKey(sequence)
+	kind, valid := sm.runeWordKind(symbolList)
+
+	if valid {
+		result.Success = true
+		result.Kind = kind
+		result.FatigueCost = runeWordFatigueCost
+
+		if _, known := sm.runeWords.KnownWords[key]; !known {
+			sm.runeWords.KnownWords[key] = kind
+			result.NewlyDiscovered = true
+		}
+		sm.runeWords.UseCounts[key]++
+
+		result.Effects, result.Skipped = sm.applyRitualEffects(runeWordEffects(kind, symbolList), location, RuneWordMagnitude, sequence)
+	} else {
+		result.SanityCost = runeWordFizzleSanity
+		result.Hint = sm.recordFailedWord(key, sequence)
+	}
+
+	sm.applyCastCost(player, result.FatigueCost, result.SanityCost)
+
+	if sm.castReceiver != nil {
+		sm.castReceiver.RecordRuneWordCast(location, sequence, result.Success)
+	}
+
+	return result, nil
+}
+
+// runeWordKind decides deterministically, per world seed, whether a sequence forms a word
+func (sm *Manager) runeWordKind(sequence []*Symbol) (string, bool) {
+	// Consecutive symbols must share meaning
+	for i := 1; i < len(sequence); i++ {
+		if sequence[i].ID == sequence[i-1].ID || !sm.meaningsCompatible(sequence[i-1], sequence[i]) {
+			return "", false
+		}
+	}
+
+	ids := make([]string, len(sequence))
+	for i, symbol := range sequence {
+		ids[i] = symbol.ID
+	}
+
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%d|%s", sm.worldSeed, runeWordKey(ids))
+	value := hash.Sum64()
+
+	if value%100 >= runeWordValidPercent {
+		return "", false
+	}
+	return runeWordKinds[(value/100)%uint64(len(runeWordKinds))], true
+}
+
+// meaningsCompatible checks whether two symbols share a meaning or a meaning group
+func (sm *Manager) meaningsCompatible(a, b *Symbol) bool {
+	for _, meaning := range a.Meanings {
+		if containsString(b.Meanings, meaning) {
+			return true
+		}
+	}
+
+	sm.Registry.mutex.RLock()
+	defer sm.Registry.mutex.RUnlock()
+
+	for _, group := range sm.Registry.meaningGroups {
+		hasA, hasB := false, false
+		for _, meaning := range a.Meanings {
+			hasA = hasA || containsString(group, meaning)
+		}
+		for _, meaning := range b.Meanings {
+			hasB = hasB || containsString(group, meaning)
+		}
+		if hasA && hasB {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordFailedWord remembers a fizzled sequence and returns a hint once a symbol
+// has appeared in enough distinct failures. Must be called with sm.mutex held.
+func (sm *Manager) recordFailedWord(key string, sequence []string) *RuneWordHint {
+	sm.runeWords.FailedWords[key] = sequence
+
+	for _, symbolID := range uniqueStrings(sequence) {
+		if _, hinted := sm.runeWords.HintedSymbol[symbolID]; hinted {
+			continue
+		}
+
+		failures := 0
+		for _, failed := range sm.runeWords.FailedWords {
+			if containsString(failed, symbolID) {
+				failures++
+			}
+		}
+		if failures < runeWordHintThreshold {
+			continue
+		}
+
+		if partnerID := sm.findRuneWordPartner(symbolID); partnerID != "" {
+			sm.runeWords.HintedSymbol[symbolID] = partnerID
+			return &RuneWordHint{SymbolID: symbolID, PartnerID: partnerID}
+		}
+	}
+
+	return nil
+}
+
+// findRuneWordPartner finds a discovered symbol that forms a two-symbol word with the given one
+func (sm *Manager) findRuneWordPartner(symbolID string) string {
+	symbol := sm.Registry.GetSymbol(symbolID)
+	if symbol == nil {
+		return ""
+	}
+
+	discovered := sm.Registry.GetDiscoveredSymbols()
+	sort.Slice(discovered, func(i, j int) bool {
+		return discovered[i].ID < discovered[j].ID
+	})
+
+	for _, other := range discovered {
+		if _, valid := sm.runeWordKind([]*Symbol{symbol, other}); valid {
+			return other.ID
+		}
+		if _, valid := sm.runeWordKind([]*Symbol{other, symbol}); valid {
+			return other.ID
+		}
+	}
+
+	return ""
+}
+
+// applyCastCost charges the caster's survival stats. Must be called with sm.mutex held.
+func (sm *Manager) applyCastCost(player *ecs.Entity, fatigue, sanity float64) {
+	if player == nil {
+		return
+	}
+
+	survivalComp, has := player.GetComponent(ecs.SurvivalComponentID)
+	if !has {
+		return
+	}
+
+	survival := survivalComp.(*ecs.SurvivalComponent)
+	survival.Fatigue = math.Min(100, survival.Fatigue+fatigue)
+	survival.SanityLevel = math.Max(0, survival.SanityLevel-sanity)
+}
+
+// getPlayerEntity returns the player entity, if present
+func (sm *Manager) getPlayerEntity() *ecs.Entity {
+	players := sm.world.GetEntitiesWithTag(TagPlayer)
+	if len(players) == 0 {
+		return nil
+	}
+	return players[0]
+}
+
+// runeWordEffects builds the full-strength effects of a rune word kind
+func runeWordEffects(kind string, sequence []*Symbol) []RitualEffect {
+	power := 0.0
+	for _, symbol := range sequence {
+		power += symbol.Power
+	}
+	power /= float64(len(sequence))
+
+	switch kind {
+	case "light":
+		return []RitualEffect{{
+			Type:        "light",
+			Target:      "area",
+			Value:       power,
+			Duration:    30,
+			Description: "A brief glow pushes back the darkness",
+		}}
+	case "ward_pulse":
+		return []RitualEffect{{
+			Type:        "ward",
+			Target:      "scare_intensity",
+			Value:       power,
+			Duration:    60,
+			Description: "A short pulse of protection around the caster",
+		}}
+	default:
+		return []RitualEffect{{
+			Type:        "calm",
+			Target:      "animals",
+			Value:       power,
+			Duration:    120,
+			Description: "Nearby animals grow calm",
+		}}
+	}
+}
+
+// runeWordKey returns the storage key of a symbol sequence (order matters)
+func runeWordKey(sequence []string) string {
+	return strings.Join(sequence, ">")
+}
+
+// loadRuneWords loads rune word state. A missing file is not an error.
+func (sm *Manager) loadRuneWords() error {
+	path := filepath.Join(sm.Registry.savePath, runeWordsFile)
+	if !sm.Registry.storage.Exists(path) {
+		return nil
+	}
+
+	data, err := sm.Registry.storage.ReadSave(path)
+	if err != nil {
+		return err
+	}
+
+	state := newRuneWordState()
+	if err := json.Unmarshal(data, state); err != nil {
+		return err
+	}
+
+	sm.runeWords = state
+	return nil
+}
+
+// saveRuneWords saves rune word state
+func (sm *Manager) saveRuneWords() error {
+	data, err := savefile.Marshal(sm.runeWords)
+	if err != nil {
+		return err
+	}
+
+	return sm.Registry.storage.WriteSave(filepath.Join(sm.Registry.savePath, runeWordsFile), data)
+}
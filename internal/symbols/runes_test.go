@@ -0,0 +1,190 @@
+package symbols
+
+import (
+	"math"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// recordingApplier remembers the ritual effects it was asked to carry out
+type recordingApplier struct {
+	effects []RitualEffect
+}
+
+func (ra *recordingApplier) ApplyRitualEffect(effect RitualEffect, location ecs.Vector3) {
+	ra.effects = append(ra.effects, effect)
+}
+
+// recordingCaster remembers the rune word casts it was told about
+type recordingCaster struct {
+	successes []bool
+}
+
+func (rc *recordingCaster) RecordRuneWordCast(position ecs.Vector3, word []string, success bool) {
+	rc.successes = append(rc.successes, success)
+}
+
+// newRuneTestManager creates a manager whose discovered symbols all share the
+// meaning "light", plus "stone" symbols that share no meaning with them
+func newRuneTestManager(t *testing.T, seed int64) (*Manager, *ecs.SurvivalComponent) {
+	t.Helper()
+
+	var symbolList []*Symbol
+	for _, id := range []string{"light_a", "light_b", "light_c", "light_d", "light_e", "light_f"} {
+		symbol := testSymbol(id, "nature")
+		symbol.Meanings = []string{"light"}
+		symbolList = append(symbolList, symbol)
+	}
+	for _, id := range []string{"stone_a", "stone_b", "stone_c"} {
+		symbol := testSymbol(id, "earth")
+		symbol.Meanings = []string{"stone"}
+		symbolList = append(symbolList, symbol)
+	}
+
+	sm, world := newTestManager(t, seed, symbolList...)
+	survival := ecs.NewSurvivalComponent()
+	addTestPlayer(world, ecs.Vector3{}, survival)
+	return sm, survival
+}
+
+// findRuneWord returns a valid two-symbol word of the "light" symbols
+func findRuneWord(t *testing.T, sm *Manager) []string {
+	t.Helper()
+
+	ids := []string{"light_a", "light_b", "light_c", "light_d", "light_e", "light_f"}
+	for _, first := range ids {
+		for _, second := range ids {
+			pair := []*Symbol{sm.Registry.GetSymbol(first), sm.Registry.GetSymbol(second)}
+			if _, valid := sm.runeWordKind(pair); valid {
+				return []string{first, second}
+			}
+		}
+	}
+	t.Fatalf("no valid rune word among the test symbols")
+	return nil
+}
+
+func TestRuneWordCastAppliesWeakenedEffect(t *testing.T) {
+	sm, survival := newRuneTestManager(t, 11)
+	applier := &recordingApplier{}
+	caster := &recordingCaster{}
+	sm.SetRitualEffectApplier(applier)
+	sm.SetCastReceiver(caster)
+
+	word := findRuneWord(t, sm)
+	result, err := sm.CastRuneWord(word)
+	if err != nil {
+		t.Fatalf("CastRuneWord: %v", err)
+	}
+	if !result.Success || !result.NewlyDiscovered || result.Kind == "" {
+		t.Fatalf("cast %+v, want a newly discovered word", result)
+	}
+	if len(applier.effects) != 1 || math.Abs(applier.effects[0].Value-0.5*RuneWordMagnitude) > 1e-9 {
+		t.Errorf("applied effects %+v, want one at rune word magnitude %v", applier.effects, 0.5*RuneWordMagnitude)
+	}
+	if survival.Fatigue != runeWordFatigueCost || survival.SanityLevel != 100 {
+		t.Errorf("caster fatigue %v sanity %v, want fatigue %v and no sanity loss", survival.Fatigue, survival.SanityLevel, runeWordFatigueCost)
+	}
+	if len(caster.successes) != 1 || !caster.successes[0] {
+		t.Errorf("cast receiver saw %v, want one successful cast", caster.successes)
+	}
+
+	// A known word is not discovered twice
+	again, _ := sm.CastRuneWord(word)
+	if !again.Success || again.NewlyDiscovered {
+		t.Errorf("second cast %+v, want a success that is not new", again)
+	}
+}
+
+func TestRuneWordsAreDeterministicPerSeed(t *testing.T) {
+	first, _ := newRuneTestManager(t, 11)
+	second, _ := newRuneTestManager(t, 11)
+
+	word := findRuneWord(t, first)
+	firstResult, _ := first.CastRuneWord(word)
+	secondResult, _ := second.CastRuneWord(word)
+	if !secondResult.Success || secondResult.Kind != firstResult.Kind {
+		t.Errorf("same seed cast %+v and %+v, want the same word", firstResult, secondResult)
+	}
+}
+
+func TestRuneWordRejectsBadSequences(t *testing.T) {
+	sm, survival := newRuneTestManager(t, 11)
+	hidden := testSymbol("hidden", "nature")
+	hidden.IsDiscovered = false
+	sm.Registry.AddSymbol(hidden)
+
+	for _, sequence := range [][]string{
+		{"light_a"},
+		{"light_a", "light_b", "light_c", "light_d"},
+		{"light_a", "missing"},
+		{"light_a", "hidden"},
+	} {
+		if _, err := sm.CastRuneWord(sequence); err == nil {
+			t.Errorf("cast of %v was accepted", sequence)
+		}
+	}
+	if survival.Fatigue != 0 || survival.SanityLevel != 100 {
+		t.Errorf("rejected casts cost fatigue %v and sanity %v", survival.Fatigue, 100-survival.SanityLevel)
+	}
+}
+
+func TestRepeatedFizzlesGrantHint(t *testing.T) {
+	sm, survival := newRuneTestManager(t, 11)
+	caster := &recordingCaster{}
+	sm.SetCastReceiver(caster)
+
+	// Symbols that share no meaning never form a word
+	var hint *RuneWordHint
+	for i, other := range []string{"stone_a", "stone_b", "stone_c"} {
+		result, err := sm.CastRuneWord([]string{"light_a", other})
+		if err != nil {
+			t.Fatalf("CastRuneWord: %v", err)
+		}
+		if result.Success || result.SanityCost != runeWordFizzleSanity {
+			t.Fatalf("cast %+v, want a fizzle costing %v sanity", result, runeWordFizzleSanity)
+		}
+		if i < runeWordHintThreshold-1 && result.Hint != nil {
+			t.Fatalf("hint %+v granted after %d failures", *result.Hint, i+1)
+		}
+		hint = result.Hint
+	}
+
+	if survival.SanityLevel != 100-3*runeWordFizzleSanity {
+		t.Errorf("sanity %v after three fizzles, want %v", survival.SanityLevel, 100-3*runeWordFizzleSanity)
+	}
+	if hint == nil || hint.SymbolID != "light_a" {
+		t.Fatalf("hint %+v after three failures, want one for light_a", hint)
+	}
+	hinted, _ := sm.CastRuneWord([]string{"light_a", hint.PartnerID})
+	reversed, _ := sm.CastRuneWord([]string{hint.PartnerID, "light_a"})
+	if !hinted.Success && !reversed.Success {
+		t.Errorf("hinted partner %s forms no word with light_a", hint.PartnerID)
+	}
+	if caster.successes[0] {
+		t.Errorf("cast receiver recorded a fizzle as a success")
+	}
+}
+
+func TestRuneWordStatePersists(t *testing.T) {
+	storage := NewMemoryStorage()
+	sm := NewManagerWithStorage(ecs.NewWorld(), "save", storage)
+	sm.runeWords.KnownWords["light_a>light_b"] = "light"
+	sm.runeWords.UseCounts["light_a>light_b"] = 2
+	sm.runeWords.FailedWords["light_a>stone_a"] = []string{"light_a", "stone_a"}
+	if err := sm.saveRuneWords(); err != nil {
+		t.Fatalf("saveRuneWords: %v", err)
+	}
+
+	loaded := NewManagerWithStorage(ecs.NewWorld(), "save", storage)
+	if err := loaded.loadRuneWords(); err != nil {
+		t.Fatalf("loadRuneWords: %v", err)
+	}
+	if loaded.GetKnownRuneWords()["light_a>light_b"] != "light" || loaded.runeWords.UseCounts["light_a>light_b"] != 2 {
+		t.Errorf("loaded known words %v and uses %v", loaded.runeWords.KnownWords, loaded.runeWords.UseCounts)
+	}
+	if len(loaded.runeWords.FailedWords["light_a>stone_a"]) != 2 {
+		t.Errorf("loaded failed words %v", loaded.runeWords.FailedWords)
+	}
+}
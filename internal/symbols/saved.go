@@ -0,0 +1,89 @@
+package symbols
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+)
+
+// SavedContent is the saved symbol and ritual state of a save, read without
+// building a manager or an ECS world (e.g. to compare two saves)
+type SavedContent struct {
+	Symbols   []*Symbol
+	Rituals   []*Ritual
+	Knowledge map[string]float64 // Player knowledge by symbol or ritual ID
+}
+
+// ReadSavedContent reads the symbols, rituals and player knowledge saved under
+// savePath, the same directory a Manager is created with
+func ReadSavedContent(savePath string) (*SavedContent, error) {
+	storage := DiskStorage{}
+	symbolsPath := filepath.Join(savePath, "symbols")
+
+	symbols, err := readSavedSymbols(storage, symbolsPath)
+	if err != nil {
+		return nil, err
+	}
+	rituals, err := readSavedRituals(storage, filepath.Join(savePath, "rituals"))
+	if err != nil {
+		return nil, err
+	}
+
+	knowledge := make(map[string]float64)
+	knowledgePath := filepath.Join(symbolsPath, "player_knowledge.json")
+	if storage.Exists(knowledgePath) {
+		data, err := storage.ReadSave(knowledgePath)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &knowledge); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SavedContent{Symbols: symbols, Rituals: rituals, Knowledge: knowledge}, nil
+}
+
+// readSavedSymbols reads the symbols saved in a registry's save directory
+func readSavedSymbols(storage Storage, savePath string) ([]*Symbol, error) {
+	symbolsPath := filepath.Join(savePath, "symbols.json")
+
+	// Check if file exists
+	if !storage.Exists(symbolsPath) {
+		return nil, fmt.Errorf("symbols file does not exist")
+	}
+
+	// Load file (falls back to the backup if the save is corrupt)
+	data, err := storage.ReadSave(symbolsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []*Symbol
+	if err := json.Unmarshal(data, &symbols); err != nil {
+		return nil, err
+	}
+	return symbols, nil
+}
+
+// readSavedRituals reads the rituals saved in a ritual registry's save directory
+func readSavedRituals(storage Storage, savePath string) ([]*Ritual, error) {
+	ritualsPath := filepath.Join(savePath, "rituals.json")
+
+	// Check if file exists
+	if !storage.Exists(ritualsPath) {
+		return nil, fmt.Errorf("rituals file does not exist")
+	}
+
+	// Load file (falls back to the backup if the save is corrupt)
+	data, err := storage.ReadSave(ritualsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var rituals []*Ritual
+	if err := json.Unmarshal(data, &rituals); err != nil {
+		return nil, err
+	}
+	return rituals, nil
+}
@@ -0,0 +1,132 @@
+package symbols
+
+import (
+	"sort"
+	"strings"
+)
+
+// Search match quality, best first
+const (
+	matchNone = iota
+	matchDescription
+	matchTag
+	matchNamePart
+	matchNameExact
+)
+
+// searchHit is a search result and how well it matched
+type searchHit struct {
+	id      string
+	name    string
+	quality int
+}
+
+// matchQuality rates how well the query matches an entry's name, tags and
+// description. The query must already be normalized with nameKey.
+func matchQuality(query, name, description string, tags []string) int {
+	lowerName := nameKey(name)
+	switch {
+	case lowerName == query:
+		return matchNameExact
+	case strings.Contains(lowerName, query):
+		return matchNamePart
+	}
+
+	for _, tag := range tags {
+		if strings.Contains(strings.ToLower(tag), query) {
+			return matchTag
+		}
+	}
+
+	if strings.Contains(strings.ToLower(description), query) {
+		return matchDescription
+	}
+	return matchNone
+}
+
+// sortHits orders hits by match quality, then by name and ID for a stable journal
+func sortHits(hits []searchHit) {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].quality != hits[j].quality {
+			return hits[i].quality > hits[j].quality
+		}
+		if hits[i].name != hits[j].name {
+			return hits[i].name < hits[j].name
+		}
+		return hits[i].id < hits[j].id
+	})
+}
+
+// Search returns the symbols whose name, meanings or description contain the
+// query, ignoring case. Name matches rank above meaning matches, which rank above
+// description matches. With discoveredOnly set, undiscovered symbols are skipped.
+func (sr *Registry) Search(query string, discoveredOnly bool) []*Symbol {
+	query = nameKey(query)
+	if query == "" {
+		return make([]*Symbol, 0)
+	}
+
+	sr.mutex.RLock()
+	defer sr.mutex.RUnlock()
+
+	candidates := sr.symbols
+	if discoveredOnly {
+		candidates = sr.discoveredSymbols
+	}
+
+	hits := make([]searchHit, 0)
+	for id, symbol := range candidates {
+		if quality := matchQuality(query, symbol.Name, symbol.Description, symbol.Meanings); quality != matchNone {
+			hits = append(hits, searchHit{id: id, name: symbol.Name, quality: quality})
+		}
+	}
+	sortHits(hits)
+
+	results := make([]*Symbol, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, candidates[hit.id])
+	}
+	return results
+}
+
+// Search returns the rituals whose name, effect tags or description contain the
+// query, ignoring case. Name matches rank above tag matches, which rank above
+// description matches. With discoveredOnly set, undiscovered rituals are skipped.
+func (rr *RitualRegistry) Search(query string, discoveredOnly bool) []*Ritual {
+	query = nameKey(query)
+	if query == "" {
+		return make([]*Ritual, 0)
+	}
+
+	rr.mutex.RLock()
+	defer rr.mutex.RUnlock()
+
+	candidates := rr.rituals
+	if discoveredOnly {
+		candidates = rr.discoveredRituals
+	}
+
+	hits := make([]searchHit, 0)
+	for id, ritual := range candidates {
+		if quality := matchQuality(query, ritual.Name, ritual.Description, ritualTags(ritual)); quality != matchNone {
+			hits = append(hits, searchHit{id: id, name: ritual.Name, quality: quality})
+		}
+	}
+	sortHits(hits)
+
+	results := make([]*Ritual, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, candidates[hit.id])
+	}
+	return results
+}
+
+// ritualTags collects the tags and types of a ritual's effects
+func ritualTags(ritual *Ritual) []string {
+	tags := make([]string, 0)
+	for _, effect := range ritual.Effects {
+		tags = append(tags, effect.Type)
+		tags = append(tags, effect.Tags...)
+	}
+	return tags
+}
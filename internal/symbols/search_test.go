@@ -0,0 +1,91 @@
+package symbols
+
+import "testing"
+
+// ritualIDs lists the IDs of rituals in order
+func ritualIDs(rituals []*Ritual) []string {
+	ids := make([]string, 0, len(rituals))
+	for _, ritual := range rituals {
+		ids = append(ids, ritual.ID)
+	}
+	return ids
+}
+
+// searchedSymbolIDs lists the IDs of symbols in order
+func searchedSymbolIDs(symbols []*Symbol) []string {
+	ids := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		ids = append(ids, symbol.ID)
+	}
+	return ids
+}
+
+// newSearchRegistries creates registries with a few rituals and symbols to search
+func newSearchRegistries() (*Registry, *RitualRegistry) {
+	registry := NewRegistry("")
+	rituals := NewRitualRegistry("", registry)
+
+	rituals.AddRitual(&Ritual{ID: "mist_veil", Name: "Mist Veil", Description: "Calls a cold fog over the clearing", IsDiscovered: true})
+	rituals.AddRitual(&Ritual{ID: "fog", Name: "Fog", Description: "The oldest rite", IsDiscovered: true})
+	rituals.AddRitual(&Ritual{ID: "ember_ward", Name: "Ember Ward", Description: "Keeps the night away",
+		Effects: []RitualEffect{{Type: "ward", Tags: []string{"protection"}}}, IsDiscovered: true})
+	rituals.AddRitual(&Ritual{ID: "hidden_fog", Name: "Foghorn", Description: "Not yet known"})
+
+	registry.AddSymbol(&Symbol{ID: "eye", Name: "Watching Eye", Meanings: []string{"vigilance"}, IsDiscovered: true})
+	registry.AddSymbol(&Symbol{ID: "spiral", Name: "Spiral", Description: "Drawn to keep watch over sleepers", IsDiscovered: true})
+	registry.AddSymbol(&Symbol{ID: "antler", Name: "Antler", Meanings: []string{"vigil"}})
+	return registry, rituals
+}
+
+func TestRitualSearchFindsDescriptionWords(t *testing.T) {
+	_, rituals := newSearchRegistries()
+
+	got := ritualIDs(rituals.Search("CLEARING", true))
+	if len(got) != 1 || got[0] != "mist_veil" {
+		t.Errorf("Search(CLEARING) = %v, want [mist_veil]", got)
+	}
+
+	got = ritualIDs(rituals.Search("protection", true))
+	if len(got) != 1 || got[0] != "ember_ward" {
+		t.Errorf("Search(protection) = %v, want the ritual with that effect tag", got)
+	}
+
+	if got := rituals.Search("  ", false); len(got) != 0 {
+		t.Errorf("blank query found %v, want nothing", ritualIDs(got))
+	}
+}
+
+func TestRitualSearchRanksNameAboveDescription(t *testing.T) {
+	_, rituals := newSearchRegistries()
+
+	got := ritualIDs(rituals.Search("fog", true))
+	want := []string{"fog", "mist_veil"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Search(fog) = %v, want the exact name before the description match %v", got, want)
+	}
+
+	// Undiscovered rituals only show up without the flag, ranked as a partial name match
+	got = ritualIDs(rituals.Search("fog", false))
+	want = []string{"fog", "hidden_fog", "mist_veil"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("Search(fog) with undiscovered = %v, want %v", got, want)
+	}
+}
+
+func TestSymbolSearchRanksNamesAndSkipsUndiscovered(t *testing.T) {
+	registry, _ := newSearchRegistries()
+
+	got := searchedSymbolIDs(registry.Search("watch", true))
+	want := []string{"eye", "spiral"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Search(watch) = %v, want name match before description match %v", got, want)
+	}
+
+	got = searchedSymbolIDs(registry.Search("vigil", true))
+	if len(got) != 1 || got[0] != "eye" {
+		t.Errorf("Search(vigil) among discovered = %v, want [eye]", got)
+	}
+	if got := searchedSymbolIDs(registry.Search("vigil", false)); len(got) != 2 {
+		t.Errorf("Search(vigil) = %v, want both meanings including the undiscovered antler", got)
+	}
+}
@@ -0,0 +1,282 @@
+package symbols
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// DisturbanceConfig controls how hostile creatures disturb rituals in progress
+type DisturbanceConfig struct {
+	Radius         float64 // Hostiles within this distance disturb the ritual
+	CancelRadius   float64 // Aggressive hostiles this close cancel the ritual outright
+	CancelAggress  float64 // Minimum aggression that can cancel a ritual
+	BuildRate      float64 // Disturbance gained per second from a hostile at point blank
+	SuccessPenalty float64 // Share of success chance lost at full disturbance
+}
+
+// DefaultDisturbanceConfig returns the default disturbance settings
+func DefaultDisturbanceConfig() DisturbanceConfig {
+	return DisturbanceConfig{
+		Radius:         15.0,
+		CancelRadius:   3.0,
+		CancelAggress:  0.8,
+		BuildRate:      0.5,
+		SuccessPenalty: 0.6,
+	}
+}
+
+// Aggression of hostile creatures by AI type
+var aiAggression = map[string]float64{
+	"aggressive": 1.0,
+	"smart":      0.8,
+	"neutral":    0.4,
+	"scared":     0.1,
+	"passive":    0.0,
+}
+
+// defaultAggression is used for hostiles without an AI component or with an unknown AI type
+const defaultAggression = 0.6
+
+// SessionSuppressionMargin is how much longer than its duration a ritual session
+// may hold off scares before the hold expires on its own
+const SessionSuppressionMargin = 30 * time.Second
+
+// ScareSuppressor holds off scares during scripted moments (e.g. an adapter over
+// fear.Director.Suppress). The hold ends when release is called or maxDuration passes.
+type ScareSuppressor interface {
+	SuppressScares(reason string, maxDuration time.Duration) (release func())
+}
+
+// RitualSession is a ritual being performed over time
+type RitualSession struct {
+	ID          string
+	Ritual      *Ritual
+	Location    ecs.Vector3
+	Items       []string
+	PlayerSkill float64 // TrackedSkill, or a fixed skill (0-1) for scripted scenarios
+	Duration    time.Duration
+	Actions     []string       // Steps the player has performed so far (see PerformAction)
+	Forecast    RitualForecast // How the ritual looked set to go when it began
+
+	elapsed     time.Duration
+	disturbance float64
+
+	Disturbed bool           // A hostile has come close during the session
+	Cancelled bool           // The session was cancelled before completion
+	Completed bool           // The ritual was performed at the end of the session
+	Success   bool           // Whether the completed ritual succeeded (fully or partially)
+	Outcome   RitualOutcome  // How well the completed ritual went
+	Effects   []RitualEffect // Effects of the completed ritual
+	Refunded  []string       // Items returned by a near miss
+
+	MasteryCue bool // The ritual is mastered and succeeded
+
+	releaseScares func() // Ends the scare suppression held for the session
+}
+
+// Disturbance returns how disturbed the session is (0-1)
+func (rs *RitualSession) Disturbance() float64 {
+	return rs.disturbance
+}
+
+// Progress returns the share of the session that has elapsed (0-1)
+func (rs *RitualSession) Progress() float64 {
+	if rs.Duration <= 0 {
+		return 1.0
+	}
+	return math.Min(1.0, float64(rs.elapsed)/float64(rs.Duration))
+}
+
+// IsActive checks whether the session is still in progress
+func (rs *RitualSession) IsActive() bool {
+	return !rs.Cancelled && !rs.Completed
+}
+
+// PerformAction records a step the player performs during the session. The
+// steps are checked against the ritual's actions when the ritual completes.
+func (rs *RitualSession) PerformAction(action string) {
+	if rs.IsActive() {
+		rs.Actions = append(rs.Actions, action)
+	}
+}
+
+// Cancel stops the session without performing the ritual
+func (rs *RitualSession) Cancel() {
+	if rs.IsActive() {
+		rs.Cancelled = true
+	}
+}
+
+// successModifier returns the factor applied to the ritual's success chance
+func (rs *RitualSession) successModifier(penalty float64) float64 {
+	return 1.0 - penalty*rs.disturbance
+}
+
+// SetScareSuppressor sets the system asked to hold off scares during rituals and visions
+func (sm *Manager) SetScareSuppressor(suppressor ScareSuppressor) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.scareSuppressor = suppressor
+}
+
+// holdScares asks the scare suppressor to hold off scares for a scripted moment
+// and returns the function that ends the hold. Must be called with sm.mutex held.
+func (sm *Manager) holdScares(reason string, maxDuration time.Duration) func() {
+	if sm.scareSuppressor == nil {
+		return func() {}
+	}
+	return sm.scareSuppressor.SuppressScares(reason, maxDuration)
+}
+
+// uniqueSessionID returns an ID for a new session of the ritual, stamped with the
+// manager's clock. Sessions begun at the same instant get a numbered suffix.
+// Must be called with sm.mutex held.
+func (sm *Manager) uniqueSessionID(ritualID string) string {
+	id := fmt.Sprintf("%s_%d", ritualID, sm.clock.Now().UnixNano())
+	for n := 2; sm.ritualSessions[id] != nil; n++ {
+		id = fmt.Sprintf("%s_%d_%d", ritualID, sm.clock.Now().UnixNano(), n)
+	}
+	return id
+}
+
+// BeginRitual starts a long ritual at a location. The ritual is performed once
+// the duration elapses, unless danger cancels it first. Adepts of the ritual
+// finish it sooner. The ritual is performed with the player's ritual skill at
+// completion; set the session's PlayerSkill to override it.
+func (sm *Manager) BeginRitual(ritual *Ritual, location ecs.Vector3, items []string, duration time.Duration) *RitualSession {
+	duration = time.Duration(float64(duration) * masteryTimeScale(sm.RitualRegistry.MasteryRank(ritual.ID)))
+	forecast := sm.ForecastRitual(ritual, location, items)
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	session := &RitualSession{
+		ID:          sm.uniqueSessionID(ritual.ID),
+		Ritual:      ritual,
+		Location:    location,
+		Items:       items,
+		PlayerSkill: TrackedSkill,
+		Duration:    duration,
+		Forecast:    forecast,
+	}
+
+	// Scares wait until the ritual and its completion cue are over
+	session.releaseScares = sm.holdScares("ritual:"+ritual.ID, duration+SessionSuppressionMargin)
+
+	sm.ritualSessions[session.ID] = session
+	return session
+}
+
+// GetRitualSessions returns the rituals currently in progress
+func (sm *Manager) GetRitualSessions() []*RitualSession {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	result := make([]*RitualSession, 0, len(sm.ritualSessions))
+	for _, session := range sm.ritualSessions {
+		result = append(result, session)
+	}
+	return result
+}
+
+// updateRitualSessions advances sessions, applies disturbances and performs
+// the rituals whose time has come
+func (sm *Manager) updateRitualSessions(deltaTime float64) {
+	sm.mutex.Lock()
+
+	finished := make([]*RitualSession, 0)
+	if len(sm.ritualSessions) > 0 {
+		hostiles := sm.world.GetEntitiesWithTag(TagHostile)
+
+		for id, session := range sm.ritualSessions {
+			if session.IsActive() {
+				sm.disturbSession(session, hostiles, deltaTime)
+				session.elapsed += time.Duration(deltaTime * float64(time.Second))
+			}
+
+			if !session.IsActive() || session.elapsed >= session.Duration {
+				finished = append(finished, session)
+				delete(sm.ritualSessions, id)
+			}
+		}
+	}
+
+	penalty := sm.Disturbance.SuccessPenalty
+	sm.mutex.Unlock()
+
+	// Perform the rituals outside the lock: performRitual takes it itself
+	for _, session := range finished {
+		if !session.Cancelled {
+			result := sm.performRitual(session.Ritual, session.Location, session.Items, session.Actions, session.PlayerSkill, session.successModifier(penalty))
+			session.Outcome, session.Effects, session.Refunded = result.Outcome, result.Effects, result.Refunded
+			session.Success = result.Outcome.Succeeded()
+			session.MasteryCue = result.MasteryCue
+			session.Completed = true
+
+			if session.Success {
+				sm.RitualRegistry.recordCompletionTime(session.Ritual.ID, session.elapsed)
+			}
+		}
+
+		if sm.OnSessionEnded != nil {
+			sm.OnSessionEnded(session)
+		}
+
+		if session.releaseScares != nil {
+			session.releaseScares()
+		}
+	}
+}
+
+// disturbSession raises a session's disturbance from nearby hostiles, or cancels it
+// when an aggressive creature comes too close. Must be called with sm.mutex held.
+func (sm *Manager) disturbSession(session *RitualSession, hostiles []*ecs.Entity, deltaTime float64) {
+	config := sm.Disturbance
+	if config.Radius <= 0 {
+		return
+	}
+
+	for _, hostile := range hostiles {
+		position, ok := entityPosition(hostile)
+		if !ok {
+			continue
+		}
+
+		distance := position.Distance(session.Location)
+		if distance > config.Radius {
+			continue
+		}
+
+		aggression := entityAggression(hostile)
+		if aggression <= 0 {
+			continue
+		}
+
+		session.Disturbed = true
+
+		if distance <= config.CancelRadius && aggression >= config.CancelAggress {
+			session.Cancel()
+			return
+		}
+
+		proximity := 1.0 - distance/config.Radius
+		session.disturbance = math.Min(1.0, session.disturbance+proximity*aggression*config.BuildRate*deltaTime)
+	}
+}
+
+// entityAggression returns how aggressive a creature is (0-1)
+func entityAggression(entity *ecs.Entity) float64 {
+	aiComp, has := entity.GetComponent(ecs.AIComponentID)
+	if !has {
+		return defaultAggression
+	}
+
+	if aggression, known := aiAggression[aiComp.(*ecs.AIComponent).AIType]; known {
+		return aggression
+	}
+	return defaultAggression
+}
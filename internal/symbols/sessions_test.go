@@ -0,0 +1,107 @@
+package symbols
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+func TestSessionShowsRitualForecast(t *testing.T) {
+	sm, ritual := newOutcomeTestManager(t, 1)
+	sm.Registry.AddSymbol(&Symbol{ID: "test_bloom", Name: "Bloom", SymbolType: "nature", Power: 0.5, IsDiscovered: true, Meanings: []string{"life"}})
+	sm.Registry.GetSymbol("test_hush").Meanings = []string{"growth"}
+	ritual.RequiredSymbols = append(ritual.RequiredSymbols, "test_bloom")
+
+	want := sm.ForecastRitual(ritual, ecs.Vector3{}, nil)
+	session := sm.BeginRitual(ritual, ecs.Vector3{}, nil, time.Minute)
+	if session.Forecast != want {
+		t.Errorf("session forecast %+v, want %+v", session.Forecast, want)
+	}
+
+	sm.publishSnapshot()
+	sessions := sm.Snapshot().Sessions
+	if len(sessions) != 1 || sessions[0].Forecast != want {
+		t.Fatalf("snapshot sessions %+v, want one with forecast %+v", sessions, want)
+	}
+	if sessions[0].Forecast.Synergy <= 0 {
+		t.Errorf("life and growth show synergy %v, want it above 0", sessions[0].Forecast.Synergy)
+	}
+}
+
+// addTestHostile adds a hostile creature with the given AI type at a position
+func addTestHostile(world *ecs.World, position ecs.Vector3, aiType string) *ecs.Entity {
+	hostile := ecs.NewEntity()
+	hostile.AddTag(ecs.RegisterTag(TagHostile, "disposition", ""))
+	hostile.AddComponent(ecs.NewTransformComponent(position))
+	hostile.AddComponent(ecs.NewAIComponent(aiType, 20))
+	world.AddEntity(hostile)
+	return hostile
+}
+
+func TestHostileNearRitualRaisesDisturbance(t *testing.T) {
+	sm, ritual := newOutcomeTestManager(t, 1)
+	near := sm.BeginRitual(ritual, ecs.Vector3{}, nil, time.Minute)
+	far := sm.BeginRitual(ritual, ecs.Vector3{X: 1000}, nil, time.Minute)
+
+	addTestHostile(sm.world, ecs.Vector3{X: 6}, "neutral")
+	sm.updateRitualSessions(1.0)
+
+	if !near.Disturbed || near.Disturbance() <= 0 {
+		t.Fatalf("session next to a hostile: disturbed %v, disturbance %v", near.Disturbed, near.Disturbance())
+	}
+	if far.Disturbed || far.Disturbance() != 0 {
+		t.Errorf("session out of reach: disturbed %v, disturbance %v", far.Disturbed, far.Disturbance())
+	}
+	if !near.IsActive() {
+		t.Errorf("neutral hostile outside the cancel radius cancelled the ritual")
+	}
+
+	penalty := sm.Disturbance.SuccessPenalty
+	if near.successModifier(penalty) >= far.successModifier(penalty) {
+		t.Errorf("success modifier %v for the disturbed session, want below the undisturbed %v",
+			near.successModifier(penalty), far.successModifier(penalty))
+	}
+}
+
+func TestAggressiveHostileCancelsRitual(t *testing.T) {
+	sm, ritual := newOutcomeTestManager(t, 1)
+	var ended []*RitualSession
+	sm.OnSessionEnded = func(session *RitualSession) {
+		ended = append(ended, session)
+	}
+	session := sm.BeginRitual(ritual, ecs.Vector3{}, nil, time.Minute)
+
+	addTestHostile(sm.world, ecs.Vector3{X: sm.Disturbance.CancelRadius / 2}, "aggressive")
+	sm.updateRitualSessions(0.1)
+
+	if !session.Cancelled || session.Completed {
+		t.Fatalf("session cancelled %v, completed %v; want cancelled without completing", session.Cancelled, session.Completed)
+	}
+	if len(ended) != 1 || ended[0] != session {
+		t.Errorf("OnSessionEnded got %d sessions, want the cancelled one", len(ended))
+	}
+	if sessions := sm.GetRitualSessions(); len(sessions) != 0 {
+		t.Errorf("%d sessions still in progress after the cancel", len(sessions))
+	}
+}
+
+func TestSessionIDsFollowTheClockAndStayUnique(t *testing.T) {
+	sm, ritual := newOutcomeTestManager(t, 1)
+	clock := setTestClock(sm)
+
+	first := sm.BeginRitual(ritual, ecs.Vector3{}, nil, time.Minute)
+	second := sm.BeginRitual(ritual, ecs.Vector3{X: 5}, nil, time.Minute)
+
+	want := fmt.Sprintf("%s_%d", ritual.ID, clock.Now().UnixNano())
+	if first.ID != want {
+		t.Errorf("session ID %q, want %q stamped with the manager's clock", first.ID, want)
+	}
+	if second.ID == first.ID {
+		t.Errorf("two sessions begun at the same instant share the ID %q", first.ID)
+	}
+	if len(sm.ritualSessions) != 2 {
+		t.Errorf("%d sessions tracked, want both", len(sm.ritualSessions))
+	}
+}
@@ -0,0 +1,144 @@
+package symbols
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// maxMetamorphOrder is the highest order a ritual metamorphosis can have
+const maxMetamorphOrder = 3
+
+// FailureSeverityConfig controls how much more dangerous failures of evolved
+// rituals are. Base rituals (evolution level 0) are not affected.
+type FailureSeverityConfig struct {
+	LevelScale     float64 // Extra failure effect value per evolution level
+	MaxPowerWeight float64 // Per level, share of the gap to the strongest symbol's power added to the average
+	LevelsPerOrder int     // Evolution levels per extra order of an unstable metamorphosis and extra hostile
+}
+
+// DefaultFailureSeverityConfig returns the default failure severity settings
+func DefaultFailureSeverityConfig() FailureSeverityConfig {
+	return FailureSeverityConfig{
+		LevelScale:     0.3,
+		MaxPowerWeight: 0.5,
+		LevelsPerOrder: 1,
+	}
+}
+
+// Validate checks that the failure severity settings are within usable ranges
+func (fc FailureSeverityConfig) Validate() error {
+	if fc.LevelScale < 0 || fc.LevelScale > 2 {
+		return fmt.Errorf("LevelScale must be between 0 and 2, got %.2f", fc.LevelScale)
+	}
+	if fc.MaxPowerWeight < 0 || fc.MaxPowerWeight > 1 {
+		return fmt.Errorf("MaxPowerWeight must be between 0 and 1, got %.2f", fc.MaxPowerWeight)
+	}
+	if fc.LevelsPerOrder < 1 {
+		return fmt.Errorf("LevelsPerOrder must be at least 1, got %d", fc.LevelsPerOrder)
+	}
+	return nil
+}
+
+// SetFailureSeverityConfig validates and applies new failure severity settings
+func (sm *Manager) SetFailureSeverityConfig(config FailureSeverityConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid failure severity config: %v", err)
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.FailureSeverity = config
+	return nil
+}
+
+// failureSeverity is how hard a failed ritual strikes back
+type failureSeverity struct {
+	Power float64 // Symbol power the failure effect is built from
+	Scale float64 // Multiplier for the failure effect's value
+	Order int     // Order of an unstable metamorphosis
+}
+
+// severity returns the failure severity of a ritual of an evolution level whose
+// symbols have the given powers. At level 0 it is the average power with no
+// extra scaling, so base rituals keep their mild failures.
+func (fc FailureSeverityConfig) severity(powers []float64, level int) failureSeverity {
+	average, strongest := 0.0, 0.0
+	for _, power := range powers {
+		average += power
+		strongest = math.Max(strongest, power)
+	}
+	if len(powers) > 0 {
+		average /= float64(len(powers))
+	}
+
+	levelsPerOrder := fc.LevelsPerOrder
+	if levelsPerOrder < 1 {
+		levelsPerOrder = 1
+	}
+
+	weight := math.Min(1.0, fc.MaxPowerWeight*float64(level))
+	return failureSeverity{
+		Power: average + (strongest-average)*weight,
+		Scale: 1.0 + fc.LevelScale*float64(level),
+		Order: int(math.Min(maxMetamorphOrder, float64(1+level/levelsPerOrder))),
+	}
+}
+
+// ritualFailureSeverity returns the failure severity of a ritual with the given symbols
+func (sm *Manager) ritualFailureSeverity(symbolIDs []string, level int) failureSeverity {
+	powers := make([]float64, 0, len(symbolIDs))
+	for _, id := range symbolIDs {
+		if symbol := sm.Registry.GetSymbol(id); symbol != nil {
+			powers = append(powers, symbol.Power)
+		}
+	}
+	return sm.FailureSeverity.severity(powers, level)
+}
+
+// generateFailureEffect generates an effect for a failed ritual
+func generateFailureEffect(location string, severity failureSeverity, r *rand.Rand) RitualEffect {
+	// Failure effects are usually negative
+	effectTypes := []string{"player_harm", "metamorphosis_unstable", "spawn_hostile"}
+	return failureEffectOfType(effectTypes[r.Intn(len(effectTypes))], location, severity, r)
+}
+
+// failureEffectOfType generates a failure effect of the given type
+func failureEffectOfType(effectType, location string, severity failureSeverity, r *rand.Rand) RitualEffect {
+	power := severity.Power
+	effect := RitualEffect{
+		Type: effectType,
+		Tags: []string{"failure", "negative", location},
+	}
+
+	// Set specific values based on effect type
+	switch effectType {
+	case "player_harm":
+		targets := []string{"health", "sanity"}
+		effect.Target = targets[r.Intn(len(targets))]
+		effect.Description = fmt.Sprintf("The ritual backfires, damaging the performer's %s", effect.Target)
+		effect.Value = -(10.0 + power*30.0) // -10 to -40 points (negative for damage)
+		effect.Duration = 0                 // Instant effect
+
+	case "metamorphosis_unstable":
+		effect.Target = "area"
+		effect.Description = "The ritual creates an unstable, chaotic metamorphosis"
+		effect.Value = 0.2 + power*0.4          // 0.2 to 0.6 intensity
+		effect.Duration = int(600 + power*1800) // 10 to 40 minutes
+		effect.MetamorphOrder = severity.Order
+		effect.MetamorphArea = 5.0 + power*15.0
+
+	case "spawn_hostile":
+		effect.Target = "entity"
+		effect.Description = "The ritual summons hostile entities"
+		effect.Value = 0.3 + power*0.5          // 0.3 to 0.8 strength
+		effect.Duration = int(900 + power*1800) // 15 to 45 minutes
+		effect.SpawnEntityType = "hostile_spirit"
+		effect.SpawnCount = int(power*2) + severity.Order // 1 to 3 entities for base rituals
+		effect.SpawnRadius = 3.0 + power*7.0
+	}
+
+	effect.Value *= severity.Scale
+	return effect
+}
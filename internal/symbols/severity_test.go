@@ -0,0 +1,95 @@
+package symbols
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// newSeverityTestManager holds a weak and a strong symbol and a base ritual using
+// both, whose failures are generated from their severity
+func newSeverityTestManager(t *testing.T) (*Manager, *Ritual) {
+	t.Helper()
+
+	weak := testSymbol("test_ember", "elemental")
+	weak.Power = 0.3
+	strong := testSymbol("test_maw", "void")
+	strong.Power = 0.9
+	sm, _ := newTestManager(t, 1, weak, strong)
+
+	symbolIDs := []string{weak.ID, strong.ID}
+	severity := sm.ritualFailureSeverity(symbolIDs, 0)
+	r := rand.New(rand.NewSource(1))
+	base := &Ritual{
+		ID:              "test_binding",
+		Name:            "Binding",
+		RequiredSymbols: symbolIDs,
+		Difficulty:      0.3,
+		SuccessChance:   0.8,
+	}
+	for _, effectType := range []string{"player_harm", "metamorphosis_unstable", "spawn_hostile"} {
+		base.FailureEffects = append(base.FailureEffects, failureEffectOfType(effectType, "", severity, r))
+	}
+	return sm, base
+}
+
+func TestEvolvedRitualFailsHarderThanItsBase(t *testing.T) {
+	sm, base := newSeverityTestManager(t)
+	evolved := sm.GenerateEvolvedRitual(base)
+
+	if len(evolved.FailureEffects) != len(base.FailureEffects) {
+		t.Fatalf("evolved ritual has %d failure effects, want %d", len(evolved.FailureEffects), len(base.FailureEffects))
+	}
+	for i, baseEffect := range base.FailureEffects {
+		effect := evolved.FailureEffects[i]
+		if math.Abs(effect.Value) <= math.Abs(baseEffect.Value) {
+			t.Errorf("%s: evolved magnitude %.2f, want above the base %.2f", effect.Type, math.Abs(effect.Value), math.Abs(baseEffect.Value))
+		}
+		switch effect.Type {
+		case "metamorphosis_unstable":
+			if effect.MetamorphOrder <= baseEffect.MetamorphOrder {
+				t.Errorf("evolved metamorphosis of order %d, want above the base %d", effect.MetamorphOrder, baseEffect.MetamorphOrder)
+			}
+		case "spawn_hostile":
+			if effect.SpawnCount <= baseEffect.SpawnCount {
+				t.Errorf("evolved failure spawns %d hostiles, want more than the base %d", effect.SpawnCount, baseEffect.SpawnCount)
+			}
+		}
+	}
+}
+
+func TestBaseFailureSeverityIsTheAveragePower(t *testing.T) {
+	config := DefaultFailureSeverityConfig()
+
+	base := config.severity([]float64{0.3, 0.9}, 0)
+	if math.Abs(base.Power-0.6) > 1e-9 || base.Scale != 1 || base.Order != 1 {
+		t.Errorf("level 0 severity %+v, want the average power 0.6 unscaled at order 1", base)
+	}
+
+	// Each level moves the power toward the strongest symbol, capped there
+	levelOne := config.severity([]float64{0.3, 0.9}, 1)
+	if math.Abs(levelOne.Power-0.75) > 1e-9 || levelOne.Scale != 1+config.LevelScale || levelOne.Order != 2 {
+		t.Errorf("level 1 severity %+v, want power 0.75, scale %.1f, order 2", levelOne, 1+config.LevelScale)
+	}
+	high := config.severity([]float64{0.3, 0.9}, 10)
+	if math.Abs(high.Power-0.9) > 1e-9 || high.Order != maxMetamorphOrder {
+		t.Errorf("level 10 severity %+v, want the strongest power 0.9 at order %d", high, maxMetamorphOrder)
+	}
+}
+
+func TestFailureSeverityConfigValidation(t *testing.T) {
+	sm, _ := newTestManager(t, 1)
+	invalid := []FailureSeverityConfig{
+		{LevelScale: -0.1, MaxPowerWeight: 0.5, LevelsPerOrder: 1},
+		{LevelScale: 0.3, MaxPowerWeight: 1.5, LevelsPerOrder: 1},
+		{LevelScale: 0.3, MaxPowerWeight: 0.5, LevelsPerOrder: 0},
+	}
+	for _, config := range invalid {
+		if err := sm.SetFailureSeverityConfig(config); err == nil {
+			t.Errorf("SetFailureSeverityConfig(%+v) accepted an invalid config", config)
+		}
+	}
+	if sm.FailureSeverity != DefaultFailureSeverityConfig() {
+		t.Errorf("rejected configs changed the failure severity to %+v", sm.FailureSeverity)
+	}
+}
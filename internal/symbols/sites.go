@@ -0,0 +1,188 @@
+package symbols
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/savefile"
+)
+
+// AncientSiteKnowledge is the symbol knowledge granted by an ancient site's revelation
+const AncientSiteKnowledge = 0.15
+
+const ancientSitesFile = "ancient_sites.json"
+
+// Flavor text for ancient sites that have nothing (more) to reveal
+var ancientSiteFlavor = []string{
+	"The stones stand silent, their message already spent",
+	"Moss has crept over the carvings you once read",
+	"A faint hum lingers between the stones",
+	"The circle feels watched, but says nothing more",
+}
+
+// AncientSiteRevelation is the outcome of interacting with an ancient site
+type AncientSiteRevelation struct {
+	RitualID string // Rumored ritual ("" if nothing has been revealed yet)
+	SymbolID string // Symbol whose knowledge was granted
+	Revealed bool   // Whether this interaction revealed something new
+	Text     string // Text shown to the player
+}
+
+// ancientSiteRecord is a resolved ancient site
+type ancientSiteRecord struct {
+	RitualID string
+	SymbolID string
+}
+
+// ancientSiteState is the persisted state of visited ancient sites and rumored rituals
+type ancientSiteState struct {
+	Sites   map[string]ancientSiteRecord // Resolved sites by seed
+	Rumored map[string]bool              // Rituals the player has heard rumors of
+}
+
+// newAncientSiteState creates an empty ancient site state
+func newAncientSiteState() *ancientSiteState {
+	return &ancientSiteState{
+		Sites:   make(map[string]ancientSiteRecord),
+		Rumored: make(map[string]bool),
+	}
+}
+
+// InteractWithAncientSite resolves an ancient site on first interaction: one of the
+// rituals performed at the site's location becomes rumored and the player learns a
+// little about one of its symbols. Both are chosen from the site's seed, so every
+// player of a world seed gets the same revelation at the same circle. Repeat visits
+// only give flavor text. If no matching ritual exists yet, the site stays unresolved.
+func (sm *Manager) InteractWithAncientSite(site *ecs.AncientSiteComponent) AncientSiteRevelation {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	key := strconv.FormatInt(site.Seed, 10)
+	if record, visited := sm.ancientSites.Sites[key]; visited {
+		site.RitualID = record.RitualID
+		site.SymbolID = record.SymbolID
+		site.Resolved = true
+
+		return AncientSiteRevelation{
+			RitualID: record.RitualID,
+			SymbolID: record.SymbolID,
+			Text:     ancientSiteFlavor[uint64(site.Seed)%uint64(len(ancientSiteFlavor))],
+		}
+	}
+
+	ritual, symbolID := sm.resolveAncientSite(site)
+	if ritual == nil {
+		return AncientSiteRevelation{Text: "The carvings are worn and strange; perhaps they will mean more later"}
+	}
+
+	sm.ancientSites.Sites[key] = ancientSiteRecord{RitualID: ritual.ID, SymbolID: symbolID}
+	sm.ancientSites.Rumored[ritual.ID] = true
+	sm.RitualRegistry.markRumored(ritual.ID)
+
+	if symbolID != "" {
+		level := math.Min(1.0, sm.playerKnowledge[symbolID]+AncientSiteKnowledge)
+		sm.playerKnowledge[symbolID] = level
+		if symbol := sm.Registry.GetSymbol(symbolID); symbol != nil {
+			symbol.KnowledgeLevel = level
+		}
+	}
+
+	site.RitualID = ritual.ID
+	site.SymbolID = symbolID
+	site.Resolved = true
+
+	return AncientSiteRevelation{
+		RitualID: ritual.ID,
+		SymbolID: symbolID,
+		Revealed: true,
+		Text:     "The stones whisper of a rite: " + ritual.Name,
+	}
+}
+
+// resolveAncientSite picks the site's ritual and symbol from its seed.
+// Must be called with sm.mutex held.
+func (sm *Manager) resolveAncientSite(site *ecs.AncientSiteComponent) (*Ritual, string) {
+	candidates := make([]*Ritual, 0)
+	for _, ritual := range sm.RitualRegistry.GetAllRituals() {
+		if ritual.RequiredLocation == site.Location {
+			candidates = append(candidates, ritual)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, ""
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	r := rand.New(rand.NewSource(site.Seed))
+	ritual := candidates[r.Intn(len(candidates))]
+
+	symbolID := ""
+	if len(ritual.RequiredSymbols) > 0 {
+		symbolID = ritual.RequiredSymbols[r.Intn(len(ritual.RequiredSymbols))]
+	}
+
+	return ritual, symbolID
+}
+
+// IsRitualRumored checks whether the player has heard rumors of a ritual
+func (sm *Manager) IsRitualRumored(ritualID string) bool {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	return sm.ancientSites.Rumored[ritualID]
+}
+
+// GetRumoredRituals returns the IDs of rituals revealed as rumors, sorted
+func (sm *Manager) GetRumoredRituals() []string {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	result := make([]string, 0, len(sm.ancientSites.Rumored))
+	for id := range sm.ancientSites.Rumored {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// loadAncientSites loads ancient site state. A missing file is not an error.
+func (sm *Manager) loadAncientSites() error {
+	path := filepath.Join(sm.Registry.savePath, ancientSitesFile)
+	if !sm.Registry.storage.Exists(path) {
+		return nil
+	}
+
+	data, err := sm.Registry.storage.ReadSave(path)
+	if err != nil {
+		return err
+	}
+
+	state := newAncientSiteState()
+	if err := json.Unmarshal(data, state); err != nil {
+		return err
+	}
+
+	sm.ancientSites = state
+	for id := range state.Rumored {
+		sm.RitualRegistry.markRumored(id)
+	}
+	return nil
+}
+
+// saveAncientSites saves ancient site state
+func (sm *Manager) saveAncientSites() error {
+	data, err := savefile.Marshal(sm.ancientSites)
+	if err != nil {
+		return err
+	}
+
+	return sm.Registry.storage.WriteSave(filepath.Join(sm.Registry.savePath, ancientSitesFile), data)
+}
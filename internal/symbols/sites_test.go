@@ -0,0 +1,62 @@
+package symbols
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+func TestAncientSiteResolvesLazily(t *testing.T) {
+	sm, _ := newTestManager(t, 1, &Symbol{ID: "test_reed", Name: "Reed", SymbolType: "natural", Complexity: 0.3, Power: 0.3})
+	site := ecs.NewAncientSiteComponent(42, "water")
+
+	// Ритуалов этого места еще нет: круг остается неразгаданным
+	first := sm.InteractWithAncientSite(site)
+	if first.Revealed || site.Resolved {
+		t.Fatalf("site resolved with no matching ritual: %+v", first)
+	}
+
+	sm.RitualRegistry.AddRitual(&Ritual{ID: "test_tide", Name: "Tide", RequiredSymbols: []string{"test_reed"}, RequiredLocation: "water", Difficulty: 0.3})
+
+	second := sm.InteractWithAncientSite(site)
+	if !second.Revealed || second.RitualID != "test_tide" || second.SymbolID != "test_reed" {
+		t.Fatalf("second visit = %+v, want test_tide and test_reed revealed", second)
+	}
+	if !site.Resolved || site.RitualID != "test_tide" {
+		t.Errorf("site component not resolved: %+v", site)
+	}
+	if !sm.IsRitualRumored("test_tide") {
+		t.Errorf("test_tide is not rumored after the revelation")
+	}
+	if level := sm.GetKnowledgeLevel("test_reed"); level != AncientSiteKnowledge {
+		t.Errorf("knowledge of test_reed = %v, want %v", level, AncientSiteKnowledge)
+	}
+
+	// Повторный визит дает только описание
+	third := sm.InteractWithAncientSite(site)
+	if third.Revealed || third.RitualID != "test_tide" {
+		t.Errorf("third visit = %+v, want flavor for test_tide", third)
+	}
+	if level := sm.GetKnowledgeLevel("test_reed"); level != AncientSiteKnowledge {
+		t.Errorf("repeat visit changed knowledge to %v", level)
+	}
+}
+
+func TestAncientSiteRevelationFollowsSeed(t *testing.T) {
+	reveal := func(seed int64) AncientSiteRevelation {
+		sm, _ := newTestManager(t, 1)
+		for _, id := range []string{"test_moss", "test_bark", "test_root"} {
+			sm.Registry.AddSymbol(&Symbol{ID: id, Name: id, SymbolType: "natural", Complexity: 0.3, Power: 0.3})
+		}
+		for _, id := range []string{"test_grove", "test_canopy", "test_thicket"} {
+			sm.RitualRegistry.AddRitual(&Ritual{ID: id, Name: id, RequiredSymbols: []string{"test_moss", "test_bark", "test_root"}, RequiredLocation: "forest", Difficulty: 0.3})
+		}
+		return sm.InteractWithAncientSite(ecs.NewAncientSiteComponent(seed, "forest"))
+	}
+
+	for seed := int64(1); seed <= 10; seed++ {
+		if a, b := reveal(seed), reveal(seed); a.RitualID != b.RitualID || a.SymbolID != b.SymbolID {
+			t.Errorf("seed %d revealed %s/%s and %s/%s", seed, a.RitualID, a.SymbolID, b.RitualID, b.SymbolID)
+		}
+	}
+}
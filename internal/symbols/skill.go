@@ -0,0 +1,165 @@
+package symbols
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+
+	"echo-taiga/internal/savefile"
+)
+
+const ritualSkillFile = "ritual_skill.json"
+
+// TrackedSkill in place of a player skill means the skill the player has earned
+// (see GetRitualSkill); any value from 0 to 1 overrides it for scripted scenarios
+const TrackedSkill = -1.0
+
+// RitualSkillConfig controls how the player's ritual skill grows
+type RitualSkillConfig struct {
+	Initial        float64 // Skill of a player who has never succeeded at a ritual
+	Gain           float64 // Share of the remaining overall skill gained per success
+	LocationGain   float64 // Share of the remaining skill at a location type gained per success there
+	PartialShare   float64 // Share of the gains a partial success gives
+	LocationWeight float64 // Weight of the location type's skill in the effective skill
+}
+
+// DefaultRitualSkillConfig returns the default ritual skill settings
+func DefaultRitualSkillConfig() RitualSkillConfig {
+	return RitualSkillConfig{
+		Initial:        0.3,
+		Gain:           0.04,
+		LocationGain:   0.1,
+		PartialShare:   0.5,
+		LocationWeight: 0.6,
+	}
+}
+
+// Validate checks that the ritual skill settings are within usable ranges
+func (rc RitualSkillConfig) Validate() error {
+	if rc.Initial < 0 || rc.Initial > 1 {
+		return fmt.Errorf("Initial must be between 0 and 1, got %.2f", rc.Initial)
+	}
+	if rc.Gain < 0 || rc.Gain > 1 {
+		return fmt.Errorf("Gain must be between 0 and 1, got %.2f", rc.Gain)
+	}
+	if rc.LocationGain < 0 || rc.LocationGain > 1 {
+		return fmt.Errorf("LocationGain must be between 0 and 1, got %.2f", rc.LocationGain)
+	}
+	if rc.PartialShare < 0 || rc.PartialShare > 1 {
+		return fmt.Errorf("PartialShare must be between 0 and 1, got %.2f", rc.PartialShare)
+	}
+	if rc.LocationWeight < 0 || rc.LocationWeight > 1 {
+		return fmt.Errorf("LocationWeight must be between 0 and 1, got %.2f", rc.LocationWeight)
+	}
+	return nil
+}
+
+// SetRitualSkillConfig validates and applies new ritual skill settings
+func (sm *Manager) SetRitualSkillConfig(config RitualSkillConfig) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid ritual skill config: %v", err)
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.Skill = config
+	return nil
+}
+
+// RitualSkill is the skill the player has earned performing rituals
+type RitualSkill struct {
+	Overall    float64            `json:"overall"`
+	ByLocation map[string]float64 `json:"by_location"` // Skill by ritual location type (forest, cave, ...)
+}
+
+// newRitualSkill returns the skill of a player who has never performed a ritual
+func newRitualSkill(initial float64) *RitualSkill {
+	return &RitualSkill{Overall: initial, ByLocation: make(map[string]float64)}
+}
+
+// GetRitualSkill returns the player's effective skill (0-1) for rituals of a
+// location type: the overall skill blended with the skill at that location type
+func (sm *Manager) GetRitualSkill(locationType string) float64 {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	return sm.ritualSkillFor(locationType)
+}
+
+// ritualSkillFor returns the effective skill for a location type. Must be called with sm.mutex held.
+func (sm *Manager) ritualSkillFor(locationType string) float64 {
+	location, practiced := sm.ritualSkill.ByLocation[locationType]
+	if !practiced {
+		location = sm.Skill.Initial
+	}
+	weight := sm.Skill.LocationWeight
+	return sm.ritualSkill.Overall*(1-weight) + location*weight
+}
+
+// resolveSkill returns the skill a ritual is performed with: the tracked skill
+// for its location type, or the override. Must be called with sm.mutex held.
+func (sm *Manager) resolveSkill(ritual *Ritual, playerSkill float64) float64 {
+	if playerSkill < 0 {
+		return sm.ritualSkillFor(ritual.RequiredLocation)
+	}
+	return math.Min(1.0, playerSkill)
+}
+
+// growRitualSkill raises the player's skill after a successful performance.
+// Each success closes a share of the gap to full skill. Must be called with sm.mutex held.
+func (sm *Manager) growRitualSkill(ritual *Ritual, outcome RitualOutcome) {
+	if !outcome.Succeeded() {
+		return
+	}
+	share := 1.0
+	if outcome == RitualPartialSuccess {
+		share = sm.Skill.PartialShare
+	}
+
+	skill := sm.ritualSkill
+	skill.Overall += (1 - skill.Overall) * sm.Skill.Gain * share
+
+	location, practiced := skill.ByLocation[ritual.RequiredLocation]
+	if !practiced {
+		location = sm.Skill.Initial
+	}
+	skill.ByLocation[ritual.RequiredLocation] = location + (1-location)*sm.Skill.LocationGain*share
+}
+
+// loadRitualSkill loads the player's ritual skill
+func (sm *Manager) loadRitualSkill() error {
+	path := filepath.Join(sm.Registry.savePath, ritualSkillFile)
+	if !sm.Registry.storage.Exists(path) {
+		return nil
+	}
+
+	data, err := sm.Registry.storage.ReadSave(path)
+	if err != nil {
+		return err
+	}
+
+	skill := newRitualSkill(sm.Skill.Initial)
+	if err := json.Unmarshal(data, skill); err != nil {
+		return err
+	}
+	if skill.ByLocation == nil {
+		skill.ByLocation = make(map[string]float64)
+	}
+
+	sm.ritualSkill = skill
+	return nil
+}
+
+// saveRitualSkill saves the player's ritual skill
+func (sm *Manager) saveRitualSkill() error {
+	sm.mutex.RLock()
+	data, err := savefile.Marshal(sm.ritualSkill)
+	sm.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	return sm.Registry.storage.WriteSave(filepath.Join(sm.Registry.savePath, ritualSkillFile), data)
+}
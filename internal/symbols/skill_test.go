@@ -0,0 +1,74 @@
+package symbols
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newSkillTestManager creates a manager with two forest rituals and a cave ritual
+// sharing one symbol
+func newSkillTestManager(t *testing.T) (*Manager, *Ritual, *Ritual, *Ritual) {
+	t.Helper()
+
+	sm, _ := newTestManager(t, 1, testSymbol("test_bark", "nature"))
+	ritual := func(id, location string) *Ritual {
+		r := &Ritual{ID: id, Name: id, RequiredSymbols: []string{"test_bark"}, RequiredLocation: location,
+			Difficulty: 0.5, SuccessChance: 0.3, IsDiscovered: true}
+		sm.RitualRegistry.AddRitual(r)
+		return r
+	}
+	return sm, ritual("test_grove", "forest"), ritual("test_thicket", "forest"), ritual("test_hollow", "cave")
+}
+
+func TestForestSuccessesRaiseForestRitualChance(t *testing.T) {
+	sm, grove, thicket, hollow := newSkillTestManager(t)
+	initial := sm.GetRitualSkill("forest")
+	thicketBefore := sm.ForecastRitual(thicket, ecs.Vector3{}, nil).SuccessChance
+	hollowBefore := sm.ForecastRitual(hollow, ecs.Vector3{}, nil).SuccessChance
+
+	// A large chance modifier makes every performance succeed
+	for i := 0; i < 5; i++ {
+		if result := sm.performRitual(grove, ecs.Vector3{}, nil, grove.Actions, TrackedSkill, 100); !result.Outcome.Succeeded() {
+			t.Fatalf("performance %d: outcome %v, want a success", i, result.Outcome)
+		}
+	}
+
+	forest, cave := sm.GetRitualSkill("forest"), sm.GetRitualSkill("cave")
+	if forest <= initial {
+		t.Fatalf("forest skill %.3f after five successes, want above %.3f", forest, initial)
+	}
+	if cave >= forest {
+		t.Errorf("cave skill %.3f, want below the practiced forest skill %.3f", cave, forest)
+	}
+
+	// The thicket was never performed, so only the skill raises its chance
+	thicketAfter := sm.ForecastRitual(thicket, ecs.Vector3{}, nil).SuccessChance
+	hollowAfter := sm.ForecastRitual(hollow, ecs.Vector3{}, nil).SuccessChance
+	if thicketAfter <= thicketBefore {
+		t.Errorf("forest ritual chance %.3f -> %.3f, want it to rise with forest skill", thicketBefore, thicketAfter)
+	}
+	if thicketAfter-thicketBefore <= hollowAfter-hollowBefore {
+		t.Errorf("forest ritual gained %.3f, cave ritual %.3f; want forest practice to help forest rituals most",
+			thicketAfter-thicketBefore, hollowAfter-hollowBefore)
+	}
+}
+
+func TestSkillOverrideAndFailuresLeaveTrackedSkillAlone(t *testing.T) {
+	sm, grove, _, _ := newSkillTestManager(t)
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	if got := sm.resolveSkill(grove, 0.9); got != 0.9 {
+		t.Errorf("override skill resolved to %.2f, want 0.9", got)
+	}
+	if got := sm.resolveSkill(grove, 2); got != 1 {
+		t.Errorf("override above full skill resolved to %.2f, want 1", got)
+	}
+
+	// Failures do not train the skill
+	sm.growRitualSkill(grove, RitualFailed)
+	if got := sm.resolveSkill(grove, TrackedSkill); got != sm.Skill.Initial {
+		t.Errorf("tracked skill %.3f after a failure, want the initial %.3f", got, sm.Skill.Initial)
+	}
+}
@@ -0,0 +1,133 @@
+package symbols
+
+import (
+	"sort"
+	"time"
+)
+
+// SymbolView is an immutable description of a discovered symbol for the UI
+type SymbolView struct {
+	ID             string
+	Name           string
+	Description    string
+	SymbolType     string
+	Power          float64
+	Distortion     float64
+	KnowledgeLevel float64
+	Meanings       []string
+	Resonance      *ResonanceSchedule // Shown once KnowledgeLevel reaches ResonanceRevealKnowledge
+}
+
+// RitualView is an immutable description of a discovered ritual for the UI
+type RitualView struct {
+	ID               string
+	Name             string
+	Description      string
+	RequiredLocation string
+	RequiredSymbols  []string
+	RequiredItems    []RitualItemSpec // Preferred items and the categories that may replace them
+	KnowledgeLevel   float64
+	TimesPerformed   int
+	TimesSucceeded   int
+}
+
+// SessionView is an immutable description of a ritual in progress for the UI
+type SessionView struct {
+	ID          string
+	RitualID    string
+	Progress    float64
+	Disturbance float64
+	Disturbed   bool
+	Forecast    RitualForecast // Synergy and odds of the ritual when the session began
+}
+
+// StateSnapshot is a deep copy of the symbol manager's state that the UI
+// reads without locking. Snapshots must not be modified.
+type StateSnapshot struct {
+	Taken     time.Time
+	Symbols   []SymbolView // Discovered symbols, sorted by ID
+	Rituals   []RitualView // Discovered rituals, sorted by ID
+	Sessions  []SessionView
+	Knowledge map[string]float64
+}
+
+// Snapshot returns the latest state snapshot without taking the manager's
+// mutex. It is refreshed once per frame and is nil before the first update.
+func (sm *Manager) Snapshot() *StateSnapshot {
+	return sm.snapshot.Load()
+}
+
+// publishSnapshot builds and publishes a state snapshot
+func (sm *Manager) publishSnapshot() {
+	snapshot := &StateSnapshot{Taken: sm.clock.Now()}
+
+	sm.Registry.mutex.RLock()
+	snapshot.Symbols = make([]SymbolView, 0, len(sm.Registry.discoveredSymbols))
+	for _, symbol := range sm.Registry.discoveredSymbols {
+		snapshot.Symbols = append(snapshot.Symbols, SymbolView{
+			ID:             symbol.ID,
+			Name:           symbol.Name,
+			Description:    symbol.Description,
+			SymbolType:     symbol.SymbolType,
+			Power:          symbol.Power,
+			Distortion:     symbol.Distortion,
+			KnowledgeLevel: symbol.KnowledgeLevel,
+			Meanings:       append([]string(nil), symbol.Meanings...),
+			Resonance:      revealedResonance(symbol),
+		})
+	}
+	sm.Registry.mutex.RUnlock()
+
+	sm.RitualRegistry.mutex.RLock()
+	snapshot.Rituals = make([]RitualView, 0, len(sm.RitualRegistry.discoveredRituals))
+	for _, ritual := range sm.RitualRegistry.discoveredRituals {
+		snapshot.Rituals = append(snapshot.Rituals, RitualView{
+			ID:               ritual.ID,
+			Name:             ritual.Name,
+			Description:      ritual.Description,
+			RequiredLocation: ritual.RequiredLocation,
+			RequiredSymbols:  append([]string(nil), ritual.RequiredSymbols...),
+			RequiredItems:    RitualItemSpecs(ritual),
+			KnowledgeLevel:   ritual.KnowledgeLevel,
+			TimesPerformed:   ritual.TimesPerformed,
+			TimesSucceeded:   ritual.TimesSucceeded,
+		})
+	}
+	sm.RitualRegistry.mutex.RUnlock()
+
+	sort.Slice(snapshot.Symbols, func(i, j int) bool { return snapshot.Symbols[i].ID < snapshot.Symbols[j].ID })
+	sort.Slice(snapshot.Rituals, func(i, j int) bool { return snapshot.Rituals[i].ID < snapshot.Rituals[j].ID })
+
+	sm.mutex.RLock()
+	snapshot.Knowledge = make(map[string]float64, len(sm.playerKnowledge))
+	for id, level := range sm.playerKnowledge {
+		snapshot.Knowledge[id] = level
+	}
+	snapshot.Sessions = make([]SessionView, 0, len(sm.ritualSessions))
+	for _, session := range sm.ritualSessions {
+		snapshot.Sessions = append(snapshot.Sessions, SessionView{
+			ID:          session.ID,
+			RitualID:    session.Ritual.ID,
+			Progress:    session.Progress(),
+			Disturbance: session.Disturbance(),
+			Disturbed:   session.Disturbed,
+			Forecast:    session.Forecast,
+		})
+	}
+	sm.mutex.RUnlock()
+
+	sm.snapshot.Store(snapshot)
+}
+
+// revealedResonance returns a copy of a symbol's resonance schedule if the player
+// knows the symbol well enough to have noticed it
+func revealedResonance(symbol *Symbol) *ResonanceSchedule {
+	if symbol.Resonance == nil || symbol.KnowledgeLevel < ResonanceRevealKnowledge {
+		return nil
+	}
+	return &ResonanceSchedule{
+		Peaks:    append([]float64(nil), symbol.Resonance.Peaks...),
+		Weather:  append([]string(nil), symbol.Resonance.Weather...),
+		Strength: symbol.Resonance.Strength,
+	}
+}
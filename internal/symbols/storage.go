@@ -0,0 +1,178 @@
+package symbols
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/savefile"
+)
+
+// Storage abstracts the file operations of the symbol system, so it can run
+// from disk or entirely in memory
+type Storage interface {
+	ReadFile(path string) ([]byte, error)     // Reads a template or data file
+	WriteFile(path string, data []byte) error // Writes a template or data file
+	ReadSave(path string) ([]byte, error)     // Reads a save, verifying its integrity
+	WriteSave(path string, data []byte) error // Writes a save safely
+	Exists(path string) bool                  // Checks whether a file, save or directory exists
+	MkdirAll(path string) error               // Creates a directory and its parents
+	ListFiles(dir string) ([]string, error)   // Names of the files directly in dir, sorted
+}
+
+// DiskStorage keeps symbol data in files on disk
+type DiskStorage struct{}
+
+// ReadFile implements Storage
+func (DiskStorage) ReadFile(path string) ([]byte, error) {
+	return ioutil.ReadFile(path)
+}
+
+// WriteFile implements Storage
+func (DiskStorage) WriteFile(path string, data []byte) error {
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// ReadSave implements Storage (see savefile.Read)
+func (DiskStorage) ReadSave(path string) ([]byte, error) {
+	return savefile.Read(path)
+}
+
+// WriteSave implements Storage (see savefile.Write)
+func (DiskStorage) WriteSave(path string, data []byte) error {
+	return savefile.Write(path, data)
+}
+
+// Exists implements Storage
+func (DiskStorage) Exists(path string) bool {
+	return savefile.Exists(path)
+}
+
+// MkdirAll implements Storage
+func (DiskStorage) MkdirAll(path string) error {
+	return os.MkdirAll(path, os.ModePerm)
+}
+
+// ListFiles implements Storage
+func (DiskStorage) ListFiles(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// MemoryStorage keeps symbol data in memory, for headless servers and tests
+type MemoryStorage struct {
+	files map[string][]byte
+	dirs  map[string]bool
+	mutex sync.RWMutex
+}
+
+// NewMemoryStorage creates an empty in-memory storage
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		files: make(map[string][]byte),
+		dirs:  make(map[string]bool),
+	}
+}
+
+// ReadFile implements Storage
+func (ms *MemoryStorage) ReadFile(path string) ([]byte, error) {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
+	data, exists := ms.files[filepath.Clean(path)]
+	if !exists {
+		return nil, &os.PathError{Op: "read", Path: path, Err: os.ErrNotExist}
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// WriteFile implements Storage. Parent directories are created as needed.
+func (ms *MemoryStorage) WriteFile(path string, data []byte) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	path = filepath.Clean(path)
+	ms.files[path] = append([]byte(nil), data...)
+	ms.addDirs(filepath.Dir(path))
+	return nil
+}
+
+// ReadSave implements Storage. Memory cannot be half-written, so saves are plain files.
+func (ms *MemoryStorage) ReadSave(path string) ([]byte, error) {
+	return ms.ReadFile(path)
+}
+
+// WriteSave implements Storage
+func (ms *MemoryStorage) WriteSave(path string, data []byte) error {
+	return ms.WriteFile(path, data)
+}
+
+// Exists implements Storage
+func (ms *MemoryStorage) Exists(path string) bool {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
+	path = filepath.Clean(path)
+	_, isFile := ms.files[path]
+	return isFile || ms.dirs[path]
+}
+
+// MkdirAll implements Storage
+func (ms *MemoryStorage) MkdirAll(path string) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	ms.addDirs(filepath.Clean(path))
+	return nil
+}
+
+// ListFiles implements Storage
+func (ms *MemoryStorage) ListFiles(dir string) ([]string, error) {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
+	dir = filepath.Clean(dir)
+	if !ms.dirs[dir] {
+		return nil, &os.PathError{Op: "open", Path: dir, Err: os.ErrNotExist}
+	}
+
+	names := make([]string, 0)
+	for path := range ms.files {
+		if filepath.Dir(path) == dir {
+			names = append(names, filepath.Base(path))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// addDirs records a directory and all of its parents. Must be called with ms.mutex held.
+func (ms *MemoryStorage) addDirs(dir string) {
+	for !ms.dirs[dir] {
+		ms.dirs[dir] = true
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+}
+
+// NewManagerInMemory creates a symbol manager that never touches the filesystem.
+// Templates are generated into and saves are kept in a fresh MemoryStorage.
+func NewManagerInMemory(world *ecs.World) *Manager {
+	return NewManagerWithStorage(world, "", NewMemoryStorage())
+}
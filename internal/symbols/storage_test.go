@@ -0,0 +1,95 @@
+package symbols
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// symbolIDs returns the sorted IDs of all registered symbols
+func symbolIDs(sm *Manager) []string {
+	ids := make([]string, 0)
+	for _, symbol := range sm.Registry.GetAllSymbols() {
+		ids = append(ids, symbol.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestInMemoryManagerRoundTripsWithoutDisk(t *testing.T) {
+	// A path that would be created by any disk access
+	savePath := filepath.Join(t.TempDir(), "symbols")
+	storage := NewMemoryStorage()
+
+	sm := NewManagerWithStorage(ecs.NewWorld(), savePath, storage)
+	sm.SetWorldSeed(5)
+	if err := sm.Initialize(); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	if len(sm.Registry.GetAllSymbols()) == 0 || len(sm.RitualRegistry.GetAllRituals()) == 0 {
+		t.Fatalf("initialization generated %d symbols and %d rituals", len(sm.Registry.GetAllSymbols()), len(sm.RitualRegistry.GetAllRituals()))
+	}
+
+	sm.IncreaseKnowledge("marker", 0.4)
+	if err := sm.SaveState(); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+
+	loaded := NewManagerWithStorage(ecs.NewWorld(), savePath, storage)
+	loaded.SetWorldSeed(5)
+	if err := loaded.Initialize(); err != nil {
+		t.Fatalf("Initialize from memory: %v", err)
+	}
+	if got, want := symbolIDs(loaded), symbolIDs(sm); !reflect.DeepEqual(got, want) {
+		t.Errorf("reloaded symbols %v, want %v", got, want)
+	}
+	if got, want := len(loaded.RitualRegistry.GetAllRituals()), len(sm.RitualRegistry.GetAllRituals()); got != want {
+		t.Errorf("reloaded %d rituals, want %d", got, want)
+	}
+	if level := loaded.GetKnowledgeLevel("marker"); level != 0.4 {
+		t.Errorf("reloaded knowledge %v, want 0.4", level)
+	}
+
+	if _, err := os.Stat(savePath); !os.IsNotExist(err) {
+		t.Errorf("in-memory manager touched the disk at %s (stat error %v)", savePath, err)
+	}
+}
+
+func TestMemoryStorageFilesAndDirectories(t *testing.T) {
+	storage := NewMemoryStorage()
+	for path, write := range map[string]func(string, []byte) error{
+		"saves/symbols/b.json":        storage.WriteFile,
+		"saves/symbols/a.json":        storage.WriteSave,
+		"saves/symbols/nested/c.json": storage.WriteFile,
+	} {
+		if err := write(path, []byte(filepath.Base(path)[:1])); err != nil {
+			t.Fatalf("writing %s: %v", path, err)
+		}
+	}
+
+	if !storage.Exists("saves") || !storage.Exists("saves/symbols/") || !storage.Exists("saves/symbols/a.json") {
+		t.Errorf("written files or their parent directories do not exist")
+	}
+	names, err := storage.ListFiles("saves/symbols")
+	if err != nil || !reflect.DeepEqual(names, []string{"a.json", "b.json"}) {
+		t.Errorf("ListFiles = %v, %v; want the two files directly in the directory", names, err)
+	}
+
+	// Reads return copies
+	data, _ := storage.ReadSave("saves/symbols/a.json")
+	data[0] = 'x'
+	if again, _ := storage.ReadFile("saves/symbols/a.json"); string(again) != "a" {
+		t.Errorf("changing read data changed the stored file to %q", again)
+	}
+
+	if _, err := storage.ReadFile("saves/missing.json"); !os.IsNotExist(err) {
+		t.Errorf("reading a missing file returned %v, want a not-exist error", err)
+	}
+	if _, err := storage.ListFiles("elsewhere"); !os.IsNotExist(err) {
+		t.Errorf("listing a missing directory returned %v, want a not-exist error", err)
+	}
+}
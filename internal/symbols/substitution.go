@@ -0,0 +1,128 @@
+package symbols
+
+// Ritual item substitution settings
+const (
+	DefaultSubstitutionPenalty = 0.1 // Success chance and effect value lost per substituted item
+	ExactItemsDifficulty       = 0.7 // Evolved rituals at least this difficult accept only exact items
+)
+
+// ritualItemTypes are the item categories rituals draw their requirements from
+var ritualItemTypes = []string{"herb", "mineral", "bone", "fluid", "cloth", "tool"}
+
+// ritualItems lists the items of each category
+var ritualItems = map[string][]string{
+	"herb": {
+		"wild sage", "moonflower", "bloodroot", "ghost moss", "twisted bramble",
+		"whispering fern", "black lotus", "dreamer's weed", "starleaf", "nightshade",
+	},
+	"mineral": {
+		"quartz crystal", "red ochre", "black salt", "fool's gold", "lodestone",
+		"obsidian shard", "amber fragment", "cave pearl", "thunderstone", "silver dust",
+	},
+	"bone": {
+		"small animal skull", "bird bone", "vertebrae", "antler fragment", "tooth",
+		"jawbone", "carved bone", "hollow bone", "charred bone", "ancient remains",
+	},
+	"fluid": {
+		"clear spring water", "morning dew", "blood (animal)", "rendered fat", "tree sap",
+		"fermented berries", "pine resin", "mushroom extract", "flower essence", "rainwater",
+	},
+	"cloth": {
+		"red cloth strip", "black silk square", "woven grass mat", "dyed linen", "burial shroud",
+		"embroidered patch", "unspun wool", "spider silk", "ceremonial banner", "charred rags",
+	},
+	"tool": {
+		"bone needle", "stone knife", "wooden bowl", "copper wire", "clay vessel",
+		"leather pouch", "glass vial", "carved stick", "stone mortar", "wooden flute",
+	},
+}
+
+// genericRitualItems are items of no particular category; they cannot be substituted
+var genericRitualItems = []string{
+	"mysterious artifact", "symbolic object", "personal token", "natural rarity", "found curiosity",
+}
+
+// itemCategories maps every categorized item to its category
+var itemCategories = func() map[string]string {
+	categories := make(map[string]string)
+	for category, items := range ritualItems {
+		for _, item := range items {
+			categories[item] = category
+		}
+	}
+	return categories
+}()
+
+// ItemCategory returns the category of an item, or "" if it has none
+func ItemCategory(item string) string {
+	return itemCategories[item]
+}
+
+// RitualItemSpec describes a required ritual item
+type RitualItemSpec struct {
+	Preferred string // The exact item, which gives the full effect
+	Category  string // Any item of this category may stand in for it; "" if none may
+}
+
+// RitualItemSpecs describes the required items of a ritual and what may replace them
+func RitualItemSpecs(ritual *Ritual) []RitualItemSpec {
+	specs := make([]RitualItemSpec, 0, len(ritual.RequiredItems))
+	for _, item := range ritual.RequiredItems {
+		specs = append(specs, RitualItemSpec{Preferred: item, Category: ritual.ItemCategories[item]})
+	}
+	return specs
+}
+
+// matchRitualItems checks the offered items against a ritual's requirements. Exact
+// items are matched first; each remaining requirement may be met by an unused item
+// of its category. optionalItem may be left out entirely. Returns how many items
+// were substituted and whether every requirement was met.
+func matchRitualItems(ritual *Ritual, items []string, optionalItem string) (int, bool) {
+	used := make([]bool, len(items))
+	unmet := make([]string, 0)
+	for _, required := range ritual.RequiredItems {
+		if required == optionalItem {
+			continue
+		}
+		if i := unusedItem(items, used, func(item string) bool { return item == required }); i >= 0 {
+			used[i] = true
+		} else {
+			unmet = append(unmet, required)
+		}
+	}
+
+	substitutions := 0
+	for _, required := range unmet {
+		category := ritual.ItemCategories[required]
+		if category == "" {
+			return substitutions, false
+		}
+		i := unusedItem(items, used, func(item string) bool { return ItemCategory(item) == category })
+		if i < 0 {
+			return substitutions, false
+		}
+		used[i] = true
+		substitutions++
+	}
+	return substitutions, true
+}
+
+// unusedItem returns the index of the first unused item that matches, or -1
+func unusedItem(items []string, used []bool, matches func(item string) bool) int {
+	for i, item := range items {
+		if !used[i] && matches(item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// substitutionFactor returns the multiplier for success chance and effect value
+// of a ritual performed with substituted items
+func (sm *Manager) substitutionFactor(substitutions int) float64 {
+	factor := 1.0 - sm.SubstitutionPenalty*float64(substitutions)
+	if factor < 0 {
+		return 0
+	}
+	return factor
+}
@@ -0,0 +1,121 @@
+package symbols
+
+import (
+	"math"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newSubstitutionRitual returns a ritual needing ghost moss (any herb will do),
+// quartz crystal (any mineral) and a personal token (nothing else will do)
+func newSubstitutionRitual() *Ritual {
+	return &Ritual{
+		ID:            "test_substitution",
+		RequiredItems: []string{"ghost moss", "quartz crystal", "personal token"},
+		ItemCategories: map[string]string{
+			"ghost moss":     "herb",
+			"quartz crystal": "mineral",
+		},
+		SuccessChance: 0.8,
+		Difficulty:    0.3,
+	}
+}
+
+func TestMatchRitualItemsExactSubstitutedAndMixed(t *testing.T) {
+	ritual := newSubstitutionRitual()
+	tests := []struct {
+		name          string
+		items         []string
+		substitutions int
+		valid         bool
+	}{
+		{"exact", []string{"ghost moss", "quartz crystal", "personal token"}, 0, true},
+		{"substituted", []string{"bloodroot", "lodestone", "personal token"}, 2, true},
+		{"mixed", []string{"lodestone", "personal token", "ghost moss"}, 1, true},
+		// The exact herb is used for itself, so it cannot stand in for the mineral as well
+		{"one herb for two slots", []string{"ghost moss", "personal token"}, 0, false},
+		{"wrong category", []string{"ghost moss", "bird bone", "personal token"}, 0, false},
+		{"uncategorized item missing", []string{"ghost moss", "quartz crystal", "found curiosity"}, 0, false},
+	}
+	for _, tt := range tests {
+		substitutions, valid := matchRitualItems(ritual, tt.items, "")
+		if valid != tt.valid || (valid && substitutions != tt.substitutions) {
+			t.Errorf("%s: matchRitualItems(%v) = %d, %v; want %d, %v",
+				tt.name, tt.items, substitutions, valid, tt.substitutions, tt.valid)
+		}
+	}
+}
+
+func TestSubstitutesLowerTheSuccessChance(t *testing.T) {
+	sm, _ := newTestManager(t, 1)
+	ritual := newSubstitutionRitual()
+
+	chance := func(items ...string) float64 {
+		sm.mutex.Lock()
+		defer sm.mutex.Unlock()
+		return sm.successChance(ritual, ecs.Vector3{}, items, 1.0, 0)
+	}
+	exact := chance("ghost moss", "quartz crystal", "personal token")
+	mixed := chance("ghost moss", "lodestone", "personal token")
+	substituted := chance("bloodroot", "lodestone", "personal token")
+
+	penalty := sm.SubstitutionPenalty
+	if math.Abs(mixed-exact*(1-penalty)) > 1e-9 {
+		t.Errorf("one substitute: chance %.4f, want %.4f", mixed, exact*(1-penalty))
+	}
+	if math.Abs(substituted-exact*(1-2*penalty)) > 1e-9 {
+		t.Errorf("two substitutes: chance %.4f, want %.4f", substituted, exact*(1-2*penalty))
+	}
+}
+
+func TestItemSpecsNamePreferredItemAndCategory(t *testing.T) {
+	specs := RitualItemSpecs(newSubstitutionRitual())
+	want := []RitualItemSpec{
+		{Preferred: "ghost moss", Category: "herb"},
+		{Preferred: "quartz crystal", Category: "mineral"},
+		{Preferred: "personal token"},
+	}
+	if len(specs) != len(want) {
+		t.Fatalf("specs %+v, want %+v", specs, want)
+	}
+	for i := range want {
+		if specs[i] != want[i] {
+			t.Errorf("spec %d = %+v, want %+v", i, specs[i], want[i])
+		}
+	}
+}
+
+func TestEvolvedRitualKeepsCategoriesOfItemsItAlreadyNeeds(t *testing.T) {
+	// The base ritual needs every categorized item, none of which may be substituted,
+	// so any item an evolution adds is already required
+	base := &Ritual{ID: "test_hoard", Name: "Hoard", Difficulty: 0.2, SuccessChance: 0.8, ItemCategories: map[string]string{}}
+	for _, category := range ritualItemTypes {
+		base.RequiredItems = append(base.RequiredItems, ritualItems[category]...)
+	}
+
+	for seed := int64(1); seed <= 40; seed++ {
+		sm, _ := newTestManager(t, seed)
+		evolved := sm.GenerateEvolvedRitual(base)
+		if len(evolved.RequiredItems) != len(base.RequiredItems) {
+			t.Errorf("seed %d: evolution changed %d required items to %d", seed, len(base.RequiredItems), len(evolved.RequiredItems))
+		}
+		if len(evolved.ItemCategories) != 0 {
+			t.Errorf("seed %d: evolution made exact-only items substitutable: %v", seed, evolved.ItemCategories)
+		}
+	}
+}
+
+func TestDemandingEvolvedRitualsAcceptExactItemsOnly(t *testing.T) {
+	sm, _ := newTestManager(t, 1)
+	base := newSubstitutionRitual()
+	base.Difficulty = ExactItemsDifficulty
+
+	evolved := sm.GenerateEvolvedRitual(base)
+	if len(evolved.ItemCategories) != 0 {
+		t.Errorf("evolved ritual of difficulty %.2f accepts substitutes %v", evolved.Difficulty, evolved.ItemCategories)
+	}
+	if _, valid := matchRitualItems(evolved, []string{"bloodroot", "lodestone", "personal token"}, ""); valid {
+		t.Errorf("demanding evolved ritual accepted substitutes")
+	}
+}
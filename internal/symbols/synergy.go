@@ -0,0 +1,164 @@
+package symbols
+
+import (
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Meaning synergy settings
+const (
+	SynergySuccessBonus = 0.2  // Success chance gained (or lost) at full synergy (or clash)
+	SynergyPowerBonus   = 0.25 // Extra effect value of a fully harmonious ritual
+	ClashSeverity       = 0.5  // Extra failure effect value of a fully clashing ritual
+	ClashAnomaly        = 0.1  // Local anomaly raised by performing a fully clashing ritual
+	relatedMeaningScore = 0.5  // Synergy of meanings from the same meaning group
+)
+
+// complementaryMeanings are meanings that strengthen each other in a ritual
+var complementaryMeanings = meaningPairs(
+	"life", "growth",
+	"birth", "life",
+	"death", "decay",
+	"fire", "air",
+	"water", "ice",
+	"earth", "wood",
+	"knowledge", "wisdom",
+	"secrets", "mysteries",
+	"courage", "strength",
+	"love", "joy",
+	"dream", "mind",
+	"creation", "transformation",
+)
+
+// opposingMeanings are meanings that pull a ritual apart
+var opposingMeanings = meaningPairs(
+	"order", "chaos",
+	"creation", "destruction",
+	"light", "darkness",
+	"life", "death",
+	"growth", "decay",
+	"strength", "weakness",
+	"fire", "water",
+	"fear", "courage",
+	"love", "hate",
+	"joy", "sorrow",
+	"anger", "peace",
+	"spirit", "matter",
+)
+
+// meaningPairs builds a symmetric lookup of meaning pairs
+func meaningPairs(meanings ...string) map[[2]string]bool {
+	pairs := make(map[[2]string]bool, len(meanings))
+	for i := 0; i+1 < len(meanings); i += 2 {
+		pairs[[2]string{meanings[i], meanings[i+1]}] = true
+		pairs[[2]string{meanings[i+1], meanings[i]}] = true
+	}
+	return pairs
+}
+
+// RitualForecast estimates how a ritual would go if performed now
+type RitualForecast struct {
+	RitualID      string
+	Synergy       float64 // -1 (clashing meanings) to 1 (harmonious meanings)
+	SuccessChance float64 // Effective chance of a full success
+	Power         float64 // Multiplier for the value of the ritual's effects on success (synergy, substitutes and resonance)
+	Severity      float64 // Multiplier for the value of the ritual's failure effects
+}
+
+// ForecastRitual estimates the synergy and the effective success chance of a ritual
+// performed at a location with the given items and the player's ritual skill
+func (sm *Manager) ForecastRitual(ritual *Ritual, location ecs.Vector3, items []string) RitualForecast {
+	synergy := sm.RitualSynergy(ritual)
+
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	substitutions, _ := matchRitualItems(ritual, items, sm.optionalItem(ritual))
+	return RitualForecast{
+		RitualID:      ritual.ID,
+		Synergy:       synergy,
+		SuccessChance: math.Min(1.0, sm.successChance(ritual, location, items, TrackedSkill, synergy)),
+		Power:         synergyPower(synergy) * sm.substitutionFactor(substitutions) * sm.ritualResonance(ritual),
+		Severity:      clashSeverity(synergy),
+	}
+}
+
+// RitualSynergy returns how well the meanings of a ritual's symbols fit together,
+// averaged over every pair of its symbols: -1 when they all clash, 1 when they
+// all complement each other
+func (sm *Manager) RitualSynergy(ritual *Ritual) float64 {
+	symbols := make([]*Symbol, 0, len(ritual.RequiredSymbols))
+	for _, id := range ritual.RequiredSymbols {
+		if symbol := sm.Registry.GetSymbol(id); symbol != nil {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	total, pairs := 0.0, 0
+	for i := 0; i < len(symbols); i++ {
+		for j := i + 1; j < len(symbols); j++ {
+			total += sm.symbolSynergy(symbols[i], symbols[j])
+			pairs++
+		}
+	}
+
+	if pairs == 0 {
+		return 0
+	}
+	return total / float64(pairs)
+}
+
+// symbolSynergy rates a pair of symbols by their meanings. A single opposing
+// pair of meanings makes the symbols clash; otherwise they are as harmonious
+// as their best-matching meanings.
+func (sm *Manager) symbolSynergy(a, b *Symbol) float64 {
+	best := 0.0
+	for _, meaningA := range a.Meanings {
+		for _, meaningB := range b.Meanings {
+			pair := [2]string{meaningA, meaningB}
+			switch {
+			case opposingMeanings[pair]:
+				return -1
+			case meaningA == meaningB || complementaryMeanings[pair]:
+				best = 1
+			case best < relatedMeaningScore && sm.sameMeaningGroup(meaningA, meaningB):
+				best = relatedMeaningScore
+			}
+		}
+	}
+	return best
+}
+
+// sameMeaningGroup checks whether two meanings belong to the same meaning group
+func (sm *Manager) sameMeaningGroup(a, b string) bool {
+	sm.Registry.mutex.RLock()
+	defer sm.Registry.mutex.RUnlock()
+
+	for _, group := range sm.Registry.meaningGroups {
+		if containsString(group, a) && containsString(group, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// synergyPower returns the multiplier for successful effects of a ritual
+func synergyPower(synergy float64) float64 {
+	return 1.0 + SynergyPowerBonus*math.Max(0, synergy)
+}
+
+// clashSeverity returns the multiplier for failure effects of a ritual
+func clashSeverity(synergy float64) float64 {
+	return 1.0 + ClashSeverity*math.Max(0, -synergy)
+}
+
+// scaleEffectValues returns copies of the effects with their values multiplied
+func scaleEffectValues(effects []RitualEffect, magnitude float64) []RitualEffect {
+	scaled := make([]RitualEffect, len(effects))
+	for i, effect := range effects {
+		effect.Value *= magnitude
+		scaled[i] = effect
+	}
+	return scaled
+}
@@ -0,0 +1,49 @@
+//go:build dev
+
+package symbols
+
+import (
+	"fmt"
+	"math/rand"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// syntheticExtent is the side of the square synthetic symbol entities are scattered over
+const syntheticExtent = 256.0
+
+// PopulateSynthetic fills the manager with synthetic state for profiling Update:
+// a player at the origin and entityCount symbol entities, each carrying its own
+// registered symbol, about half of them discovered (dev builds only)
+func (sm *Manager) PopulateSynthetic(entityCount int, seed int64) error {
+	ecs.RegisterTag(TagPlayer, "actor", "")
+	if _, err := ecs.PopulateSynthetic(sm.world, 1, 0, seed, TagPlayer); err != nil {
+		return err
+	}
+
+	entities, err := ecs.PopulateSynthetic(sm.world, entityCount, syntheticExtent, seed)
+	if err != nil {
+		return fmt.Errorf("failed to populate synthetic entities: %v", err)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	types := []string{"protection", "nature", "elemental", "void"}
+	for i, entity := range entities {
+		symbol := &Symbol{
+			ID:           fmt.Sprintf("synthetic_%d", i),
+			Name:         fmt.Sprintf("Synthetic symbol %d", i),
+			SymbolType:   types[i%len(types)],
+			Complexity:   0.2 + rng.Float64()*0.6,
+			Power:        0.2 + rng.Float64()*0.6,
+			Meanings:     []string{types[i%len(types)]},
+			IsDiscovered: rng.Intn(2) == 0,
+		}
+		sm.Registry.AddSymbol(symbol)
+
+		symbolComp := ecs.NewSymbolComponent(symbol.ID, symbol.SymbolType, symbol.Complexity, symbol.Power)
+		symbolComp.Discovered = symbol.IsDiscovered
+		entity.AddComponent(symbolComp)
+	}
+
+	return nil
+}
@@ -3,15 +3,18 @@ package symbols
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"math"
 	"math/rand"
-	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"echo-taiga/internal/engine"
 	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/savefile"
 )
 
 // Symbol represents a mystical symbol that can be discovered and used in rituals
@@ -25,6 +28,7 @@ type Symbol struct {
 	Meanings       []string // Conceptual meanings associated with the symbol
 	RelatedSymbols []string // IDs of related symbols
 	VisualID       string   // ID for the visual representation
+	LoreText       string   // Lore fragment unlocked when the symbol is discovered, if any
 
 	// Discovery information
 	IsDiscovered      bool        // Whether the player has discovered this symbol
@@ -33,18 +37,24 @@ type Symbol struct {
 	KnowledgeLevel    float64     // 0-1: Player's understanding of the symbol
 
 	// Procedural generation parameters
-	GenerationSeed int64   // Seed used to generate this symbol
-	Distortion     float64 // 0-1: How distorted/corrupted the symbol is
+	GenerationSeed int64         // Seed used to generate this symbol
+	GeneratedAt    time.Time     // When the symbol was generated (zero for templates)
+	Recipe         *SymbolRecipe // Inputs the symbol was generated from (nil for templates)
+	Distortion     float64       // 0-1: How distorted/corrupted the symbol is
 
 	// Execution/gameplay effects
 	RitualModifiers map[string]float64 // Modifiers when used in rituals
 	WorldEffects    map[string]float64 // Direct effects on the world when observed
+
+	// When the symbol grows stronger with the time of day and weather (nil if never)
+	Resonance *ResonanceSchedule
 }
 
 // Registry manages all symbols in the game
 type Registry struct {
 	symbols           map[string]*Symbol // All symbols by ID
 	discoveredSymbols map[string]*Symbol // Only discovered symbols
+	symbolNames       map[string]string  // Symbol IDs by normalized display name
 
 	// Organization by categories
 	symbolsByType map[string][]*Symbol // Symbols organized by type
@@ -55,7 +65,13 @@ type Registry struct {
 	meaningGroups  map[string][]string // Groups of related meanings
 
 	// Loading/saving data
-	savePath string // Path for saving/loading data
+	savePath   string             // Path for saving/loading data
+	storage    Storage            // Where data is read from and written to
+	loadReport *engine.LoadReport // Problems found while loading templates
+
+	// Generated symbols removed by pruning and how to generate them again
+	pruned     map[string]SymbolRecipe
+	regenerate func(recipe SymbolRecipe) *Symbol
 
 	mutex sync.RWMutex // Mutex for thread safety
 }
@@ -90,10 +106,17 @@ type SymbolTransformation struct {
 type RitualRegistry struct {
 	rituals           map[string]*Ritual // All rituals by ID
 	discoveredRituals map[string]*Ritual // Only discovered rituals
+	ritualNames       map[string]string  // Ritual IDs by normalized display name
 
 	// Organization by categories
-	ritualsBySymbol map[string][]*Ritual // Rituals that use a particular symbol
-	ritualsByEffect map[string][]*Ritual // Rituals that produce a particular effect type
+	ritualsBySymbol   map[string][]*Ritual // Rituals that use a particular symbol
+	ritualsByEffect   map[string][]*Ritual // Rituals that produce a particular effect type
+	ritualsByLocation map[string][]*Ritual // Rituals performed at a particular location type
+	rumoredRituals    map[string]bool      // Rituals the player has heard rumors of
+
+	// Knowledge transfer graph
+	ritualsByLineage map[string][]*Ritual       // Rituals by location and lineage root
+	relatedRituals   map[string]map[string]bool // Rituals that share knowledge
 
 	// Procedural generation parameters
 	baseRituals     []*Ritual               // Template rituals for generation
@@ -102,8 +125,13 @@ type RitualRegistry struct {
 	// Evolution tracking
 	ritualEvolutionMap map[string][]string // Maps rituals to potential evolutions
 
+	// Almanac: performance statistics and mastery ranks by ritual ID
+	mastery map[string]*RitualMastery
+
 	// Loading/saving data
-	savePath string // Path for saving/loading data
+	savePath   string             // Path for saving/loading data
+	storage    Storage            // Where data is read from and written to
+	loadReport *engine.LoadReport // Problems found while loading templates
 
 	// Symbol reference (needed for ritual generation)
 	Registry *Registry
@@ -122,10 +150,132 @@ type Manager struct {
 	playerKnowledge map[string]float64 // Knowledge level for each discovered symbol/ritual
 	lastRitualCheck time.Time          // Time of last ritual check (for performance)
 
+	// Amount of procedurally generated content
+	Generation GenerationConfig
+
+	// Share of ritual knowledge passed on to related rituals
+	KnowledgeTransferRate float64
+	KnowledgeTransferCap  float64
+
+	// Share of success chance and effect value lost per substituted ritual item
+	SubstitutionPenalty float64
+
+	// Extra danger of failing evolved rituals
+	FailureSeverity FailureSeverityConfig
+
+	// The player's ritual skill, grown by successful rituals
+	Skill       RitualSkillConfig
+	ritualSkill *RitualSkill
+
+	// Feedback from symbol study into the world's local anomaly levels
+	AnomalyFeedback AnomalyFeedbackConfig
+	anomalyReceiver AnomalyReceiver
+	areaAnomaly     map[string]float64 // Symbol-driven anomaly contribution by area
+	reportedAnomaly map[string]float64 // Levels last passed on to anomalyReceiver
+
+	// Dynamic ritual locations (camps, ...) and ward effect consumer
+	locationVolumes map[string]*LocationVolume
+	wardReceiver    WardReceiver
+
+	// Carries out ritual effects in the world
+	effectApplier RitualEffectApplier
+	loadedArea    LoadedArea // Keeps spawn effects within loaded chunks
+
+	// Rune words: quick-cast symbol sequences decided by the world seed
+	worldSeed    int64
+	rng          *rand.Rand // Ritual rolls, derived from the world seed (see SetWorldSeed)
+	runeWords    *runeWordState
+	castReceiver CastReceiver
+
+	// Foresight: forecasts of upcoming metamorphoses shown as visions
+	visionSource VisionSource
+	lastVisions  []Vision
+
+	// Ancient sites already resolved and the rituals they revealed as rumors
+	ancientSites *ancientSiteState
+
+	// Long rituals in progress and how danger disturbs them
+	Disturbance    DisturbanceConfig
+	ritualSessions map[string]*RitualSession
+
+	// Holds off scares while rituals and visions play out
+	scareSuppressor ScareSuppressor
+
+	// Near-miss rolls that yield diluted effects
+	PartialSuccess PartialSuccessConfig
+
+	// Critical successes, near misses and catastrophes
+	OutcomeTiers OutcomeTierConfig
+
+	// How closely the player must follow a ritual's steps
+	Actions ActionConfig
+
+	// Garbage collection of unused generated content when saving
+	Prune PruneConfig
+
+	// Content layered over the default base symbols and rituals (see AddBaseContent)
+	baseContent []BaseContent
+
+	// Applies player-targeted effects (health, sanity, energy)
+	playerBridge PlayerBridge
+
+	// Places and recharges stability anchors
+	anchorKeeper AnchorKeeper
+
+	// Forces the weather for weather effects of rituals
+	weatherController WeatherController
+
+	// Map markers and anomaly field reveals for knowledge effects of rituals
+	symbolMarker SymbolMarker
+	areaRevealer AreaRevealer
+
+	// Time of day and weather that symbols resonate with
+	environment EnvironmentSource
+
+	// Pacing of symbol discovery and the symbols waiting to be discovered
+	Discovery         DiscoveryConfig
+	discoveryQueue    []queuedDiscovery
+	discoveryQueued   map[ecs.EntityID]bool
+	discoveryCooldown float64
+	lineOfSight       LineOfSight
+	glimpsedSymbols   map[ecs.EntityID]bool // Symbol entities already glimpsed from afar
+
+	// Forbidden knowledge: exposure to void and distorted symbols and its consequences
+	Exposure           ExposureConfig
+	exposure           map[string]*SymbolExposure
+	observationElapsed float64
+	hallucinations     HallucinationRequester
+	stalkers           StalkerSummoner
+
+	// Symbol knowledge fades without practice
+	KnowledgeDecay KnowledgeDecayConfig
+
+	// Carried tablets resonating with nearby inscriptions and sites of their symbols
+	tabletResonances map[ecs.EntityID]TabletResonance // By inscription or site entity
+	tabletBoost      map[string]float64               // Extra power of resonating symbols
+
 	// Callbacks for game events
-	OnSymbolDiscovered func(symbol *Symbol)
-	OnRitualDiscovered func(ritual *Ritual)
-	OnRitualPerformed  func(ritual *Ritual, success bool, effects []RitualEffect)
+	OnSymbolDiscovered  func(symbol *Symbol)
+	OnFirstDiscovery    func(symbol *Symbol, lore string, firstOfType bool) // A symbol was discovered; lore is its unlocked LoreText
+	OnRitualDiscovered  func(ritual *Ritual)
+	OnRitualPerformed   func(ritual *Ritual, outcome RitualOutcome, effects []RitualEffect)
+	OnVisionsGranted    func(visions []Vision)
+	OnExposureThreshold func(symbol *Symbol, level int) // A forbidden symbol's exposure crossed a threshold
+	OnSessionEnded      func(session *RitualSession)
+	OnMasteryRankUp     func(ritual *Ritual, rank int) // A ritual reached a new mastery rank
+	OnSymbolTransformed func(symbol *Symbol)
+	OnSymbolSketched    func(symbol *Symbol)
+	OnSymbolGlimpsed    func(symbol *Symbol)                                 // An undiscovered symbol was seen from afar
+	OnKnowledgeRevealed func(target string, location ecs.Vector3, count int) // A knowledge effect revealed symbols, ritual hints or an area
+
+	// Called when a carried tablet starts or stops resonating with an inscription or site
+	OnTabletResonanceBegan func(resonance TabletResonance)
+	OnTabletResonanceEnded func(resonance TabletResonance)
+
+	// Latest read-only copy of the state for the UI
+	snapshot atomic.Pointer[StateSnapshot]
+
+	clock engine.Clock // Source of the current time (see SetClock)
 
 	mutex sync.RWMutex // Mutex for thread safety
 }
@@ -135,11 +285,15 @@ func NewRegistry(savePath string) *Registry {
 	return &Registry{
 		symbols:           make(map[string]*Symbol),
 		discoveredSymbols: make(map[string]*Symbol),
+		symbolNames:       make(map[string]string),
 		symbolsByType:     make(map[string][]*Symbol),
 		baseSymbols:       make([]*Symbol, 0),
 		symbolPatterns:    make([]SymbolPattern, 0),
 		meaningGroups:     make(map[string][]string),
 		savePath:          savePath,
+		storage:           DiskStorage{},
+		loadReport:        &engine.LoadReport{},
+		pruned:            make(map[string]SymbolRecipe),
 	}
 }
 
@@ -148,50 +302,128 @@ func NewRitualRegistry(savePath string, Registry *Registry) *RitualRegistry {
 	return &RitualRegistry{
 		rituals:            make(map[string]*Ritual),
 		discoveredRituals:  make(map[string]*Ritual),
+		ritualNames:        make(map[string]string),
 		ritualsBySymbol:    make(map[string][]*Ritual),
 		ritualsByEffect:    make(map[string][]*Ritual),
+		ritualsByLocation:  make(map[string][]*Ritual),
+		rumoredRituals:     make(map[string]bool),
+		ritualsByLineage:   make(map[string][]*Ritual),
+		relatedRituals:     make(map[string]map[string]bool),
 		baseRituals:        make([]*Ritual, 0),
 		effectTemplates:    make(map[string]RitualEffect),
 		ritualEvolutionMap: make(map[string][]string),
+		mastery:            make(map[string]*RitualMastery),
 		savePath:           savePath,
+		storage:            DiskStorage{},
+		loadReport:         &engine.LoadReport{},
 		Registry:           Registry,
 	}
 }
 
-// NewManager creates a new symbol manager
+// NewManager creates a new symbol manager that keeps its data on disk
 func NewManager(world *ecs.World, savePath string) *Manager {
+	return NewManagerWithStorage(world, savePath, DiskStorage{})
+}
+
+// NewManagerWithStorage creates a new symbol manager that keeps its data in storage
+func NewManagerWithStorage(world *ecs.World, savePath string, storage Storage) *Manager {
 	Registry := NewRegistry(filepath.Join(savePath, "symbols"))
+	Registry.storage = storage
 	ritualRegistry := NewRitualRegistry(filepath.Join(savePath, "rituals"), Registry)
+	ritualRegistry.storage = storage
 
-	return &Manager{
+	clock := engine.RealClock{}
+	sm := &Manager{
 		Registry:        Registry,
 		RitualRegistry:  ritualRegistry,
 		world:           world,
 		playerKnowledge: make(map[string]float64),
-		lastRitualCheck: time.Now(),
+		lastRitualCheck: clock.Now(),
+		clock:           clock,
+		rng:             engine.NewRandStream(time.Now().UnixNano(), RandStream),
+		AnomalyFeedback: DefaultAnomalyFeedbackConfig(),
+
+		Generation: DefaultGenerationConfig(),
+		runeWords:  newRuneWordState(),
+
+		ancientSites: newAncientSiteState(),
+
+		Disturbance:    DefaultDisturbanceConfig(),
+		ritualSessions: make(map[string]*RitualSession),
+		PartialSuccess: DefaultPartialSuccessConfig(),
+		OutcomeTiers:   DefaultOutcomeTierConfig(),
+		Actions:        DefaultActionConfig(),
+		Prune:          DefaultPruneConfig(),
+
+		Discovery:       DefaultDiscoveryConfig(),
+		discoveryQueued: make(map[ecs.EntityID]bool),
+		glimpsedSymbols: make(map[ecs.EntityID]bool),
+
+		Exposure: DefaultExposureConfig(),
+		exposure: make(map[string]*SymbolExposure),
+
+		KnowledgeDecay: DefaultKnowledgeDecayConfig(),
+
+		tabletResonances: make(map[ecs.EntityID]TabletResonance),
+		tabletBoost:      make(map[string]float64),
+
+		KnowledgeTransferRate: DefaultKnowledgeTransferRate,
+		KnowledgeTransferCap:  DefaultKnowledgeTransferCap,
+		SubstitutionPenalty:   DefaultSubstitutionPenalty,
+		FailureSeverity:       DefaultFailureSeverityConfig(),
+		Skill:                 DefaultRitualSkillConfig(),
+		ritualSkill:           newRitualSkill(DefaultRitualSkillConfig().Initial),
+		areaAnomaly:           make(map[string]float64),
+		reportedAnomaly:       make(map[string]float64),
+		locationVolumes:       make(map[string]*LocationVolume),
 	}
+
+	// Pruned symbols are generated again when something asks for them
+	Registry.regenerate = sm.generateSymbol
+	return sm
+}
+
+// SetClock sets the source of time for discoveries, ritual performances and
+// snapshots. The ritual discovery throttle restarts from the new clock's current time.
+func (sm *Manager) SetClock(clock engine.Clock) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.clock = clock
+	sm.lastRitualCheck = clock.Now()
 }
 
 // Initialize initializes the symbol manager
 func (sm *Manager) Initialize() error {
+	return sm.InitializeWithProgress(engine.NopProgress{})
+}
+
+// InitializeWithProgress initializes the symbol manager, reporting each loading phase
+func (sm *Manager) InitializeWithProgress(progress engine.ProgressReporter) error {
 	// Load base symbols and patterns
+	progress.ReportProgress("base_symbols", 0)
 	err := sm.Registry.LoadBaseSymbols()
 	if err != nil {
 		return fmt.Errorf("failed to load base symbols: %v", err)
 	}
 
 	// Load base rituals and effect templates
+	progress.ReportProgress("base_rituals", 0.3)
 	err = sm.RitualRegistry.LoadBaseRituals()
 	if err != nil {
 		return fmt.Errorf("failed to load base rituals: %v", err)
 	}
+	sm.applyBaseContent()
 
 	// Try to load saved state
+	progress.ReportProgress("symbol_state", 0.6)
 	err = sm.LoadState()
 	if err != nil {
 		// If there's no saved state, generate initial content
+		progress.ReportProgress("initial_symbols", 0.7)
 		sm.GenerateInitialContent()
 	}
+	progress.ReportProgress("symbol_state", 1)
 
 	// Register this as a system in the ECS world
 	sm.world.AddSystem(sm)
@@ -213,13 +445,16 @@ func (sm *Manager) LoadState() error {
 		return err
 	}
 
+	// Lineages emptied by pruning get a ritual again
+	sm.replenishRituals()
+
 	// Load player knowledge
 	knowledgePath := filepath.Join(sm.Registry.savePath, "player_knowledge.json")
-	if _, err := os.Stat(knowledgePath); os.IsNotExist(err) {
+	if !sm.Registry.storage.Exists(knowledgePath) {
 		return fmt.Errorf("player knowledge file does not exist")
 	}
 
-	data, err := ioutil.ReadFile(knowledgePath)
+	data, err := sm.Registry.storage.ReadSave(knowledgePath)
 	if err != nil {
 		return err
 	}
@@ -229,11 +464,30 @@ func (sm *Manager) LoadState() error {
 		return err
 	}
 
-	return nil
+	// Load rune word discoveries
+	if err := sm.loadRuneWords(); err != nil {
+		return err
+	}
+
+	// Load visited ancient sites
+	if err := sm.loadAncientSites(); err != nil {
+		return err
+	}
+
+	// Load forbidden knowledge exposure
+	if err := sm.loadExposure(); err != nil {
+		return err
+	}
+
+	// Load ritual skill
+	return sm.loadRitualSkill()
 }
 
 // SaveState saves the current state of the symbol manager
 func (sm *Manager) SaveState() error {
+	// Drop unused generated content (see PruneConfig)
+	sm.PruneGeneratedContent()
+
 	// Save symbols
 	err := sm.Registry.SaveState()
 	if err != nil {
@@ -251,79 +505,128 @@ func (sm *Manager) SaveState() error {
 
 	// Make sure directory exists
 	dir := filepath.Dir(knowledgePath)
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		err = os.MkdirAll(dir, os.ModePerm)
+	if !sm.Registry.storage.Exists(dir) {
+		err := sm.Registry.storage.MkdirAll(dir)
 		if err != nil {
 			return err
 		}
 	}
 
 	// Serialize and save
-	data, err := json.MarshalIndent(sm.playerKnowledge, "", "  ")
+	data, err := savefile.Marshal(sm.playerKnowledge)
 	if err != nil {
 		return err
 	}
 
-	err = ioutil.WriteFile(knowledgePath, data, 0644)
+	err = sm.Registry.storage.WriteSave(knowledgePath, data)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	// Save rune word discoveries
+	if err := sm.saveRuneWords(); err != nil {
+		return err
+	}
+
+	// Save visited ancient sites
+	if err := sm.saveAncientSites(); err != nil {
+		return err
+	}
+
+	// Save forbidden knowledge exposure
+	if err := sm.saveExposure(); err != nil {
+		return err
+	}
+
+	// Save ritual skill
+	return sm.saveRitualSkill()
+}
+
+// GetLoadReport returns a copy of the combined template load results of both registries
+func (sm *Manager) GetLoadReport() *engine.LoadReport {
+	sm.Registry.mutex.RLock()
+	report := sm.Registry.loadReport.Clone()
+	sm.Registry.mutex.RUnlock()
+
+	sm.RitualRegistry.mutex.RLock()
+	report.Merge(sm.RitualRegistry.loadReport)
+	sm.RitualRegistry.mutex.RUnlock()
+
+	return report
 }
 
 // GenerateInitialContent generates the initial symbols and rituals
 func (sm *Manager) GenerateInitialContent() {
 	// Generate symbols based on base templates
 	for _, baseSymbol := range sm.Registry.baseSymbols {
-		for i := 0; i < 3; i++ { // Generate a few variations of each base symbol
+		for i := 0; i < sm.Generation.SymbolsPerType; i++ { // Generate a few variations of each base symbol
 			symbol := sm.GenerateSymbol(baseSymbol.SymbolType, i)
 			sm.Registry.AddSymbol(symbol)
 		}
 	}
 
-	// Generate basic rituals that use these symbols
+	// Generate basic rituals that use these symbols; without symbols there are none
 	for _, baseRitual := range sm.RitualRegistry.baseRituals {
 		ritual := sm.GenerateRitual(baseRitual)
+		if ritual == nil {
+			continue
+		}
 		sm.RitualRegistry.AddRitual(ritual)
 	}
 }
 
-// GenerateSymbol creates a new procedurally generated symbol
-func (sm *Manager) GenerateSymbol(symbolType string, seed int) *Symbol {
-	// Get a base symbol of the specified type as a template
-	var baseSymbol *Symbol
+// templateSymbol returns the base symbol a generated symbol of a type is modeled
+// on: the first base symbol of that type, else the first base symbol of any type,
+// else a fresh minimal symbol. The template is only read, never returned.
+func (sm *Manager) templateSymbol(symbolType string) *Symbol {
 	for _, s := range sm.Registry.baseSymbols {
 		if s.SymbolType == symbolType {
-			baseSymbol = s
-			break
+			return s
 		}
 	}
 
-	if baseSymbol == nil {
-		// Fall back to any base symbol if the specified type isn't found
-		if len(sm.Registry.baseSymbols) > 0 {
-			baseSymbol = sm.Registry.baseSymbols[0]
-		} else {
-			// Create a minimal base symbol if none exist
-			baseSymbol = &Symbol{
-				ID:          "default_symbol",
-				Name:        "Unknown Symbol",
-				Description: "A mysterious symbol of unknown origin",
-				SymbolType:  "arcane",
-				Complexity:  0.5,
-				Power:       0.5,
-				Meanings:    []string{"mystery", "unknown"},
-				VisualID:    "default_symbol_visual",
-			}
-		}
+	// Fall back to any base symbol if the specified type isn't found
+	if len(sm.Registry.baseSymbols) > 0 {
+		return sm.Registry.baseSymbols[0]
+	}
+
+	// Create a minimal base symbol if none exist
+	return &Symbol{
+		ID:          "default_symbol",
+		Name:        "Unknown Symbol",
+		Description: "A mysterious symbol of unknown origin",
+		SymbolType:  "arcane",
+		Complexity:  0.5,
+		Power:       0.5,
+		Meanings:    []string{"mystery", "unknown"},
+		VisualID:    "default_symbol_visual",
 	}
+}
+
+// GenerateSymbol creates a new procedurally generated symbol. The result is
+// always a new instance, even when no base symbols are loaded.
+func (sm *Manager) GenerateSymbol(symbolType string, seed int) *Symbol {
+	variant := len(sm.Registry.GetSymbolsByType(symbolType))
+	return sm.generateSymbol(SymbolRecipe{Type: symbolType, Seed: seed, Variant: variant})
+}
+
+// generateSymbol creates the symbol described by a recipe. The same recipe in
+// the same world yields the same symbol, which is how pruned symbols come back.
+func (sm *Manager) generateSymbol(recipe SymbolRecipe) *Symbol {
+	symbolType, seed := recipe.Type, recipe.Seed
+
+	// Get a base symbol of the specified type as a template
+	baseSymbol := sm.templateSymbol(symbolType)
 
 	// Generate a new symbol based on the template
-	r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(seed)))
+	// All randomness comes from the world seed, so the same world yields the same symbols
+	r, generationSeed := sm.generationRand("symbol", symbolType, seed, recipe.Variant)
 
 	// Create unique ID
-	symbolID := fmt.Sprintf("%s_%s_%d", symbolType, generateRandomString(4), seed)
+	symbolID := fmt.Sprintf("%s_%s_%d", symbolType, generateRandomString(r, 4), seed)
+	for sm.Registry.idTaken(symbolID) {
+		symbolID = fmt.Sprintf("%s_%s_%d", symbolType, generateRandomString(r, 4), seed)
+	}
 
 	// Create variation of the name
 	nameAdjectives := []string{"Ancient", "Mystic", "Hidden", "Forgotten", "Primal", "Eldritch", "Secret", "Twisted", "Eternal", "Undying"}
@@ -364,9 +667,12 @@ func (sm *Manager) GenerateSymbol(symbolType string, seed int) *Symbol {
 		Meanings:       meanings,
 		RelatedSymbols: []string{},
 		VisualID:       visualID,
+		LoreText:       baseSymbol.LoreText,
 		IsDiscovered:   false,
 		KnowledgeLevel: 0.0,
-		GenerationSeed: time.Now().UnixNano() + int64(seed),
+		GenerationSeed: generationSeed,
+		GeneratedAt:    sm.clock.Now(),
+		Recipe:         &recipe,
 		Distortion:     r.Float64() * 0.3, // Some small random distortion
 		RitualModifiers: map[string]float64{
 			"power":     0.8 + r.Float64()*0.4,
@@ -376,17 +682,9 @@ func (sm *Manager) GenerateSymbol(symbolType string, seed int) *Symbol {
 		WorldEffects: map[string]float64{},
 	}
 
-	// Add some type-specific world effects
-	switch symbolType {
-	case "elemental":
-		symbol.WorldEffects["elemental_resonance"] = 0.5 + r.Float64()*0.5
-	case "arcane":
-		symbol.WorldEffects["magic_amplification"] = 0.4 + r.Float64()*0.6
-	case "primal":
-		symbol.WorldEffects["nature_connection"] = 0.6 + r.Float64()*0.4
-	case "void":
-		symbol.WorldEffects["reality_distortion"] = 0.7 + r.Float64()*0.3
-	}
+	// Add some type-specific world effects and the times the symbol resonates
+	deriveTypeWorldEffects(symbol, r)
+	symbol.Resonance = resonanceForType(symbolType, meanings)
 
 	return symbol
 }
@@ -398,6 +696,9 @@ func (sm *Manager) GenerateRitual(baseRitual *Ritual) *Ritual {
 	if len(availableSymbols) == 0 {
 		// Fall back to all symbols if none match the location
 		availableSymbols = sm.Registry.GetAllSymbols()
+		sort.Slice(availableSymbols, func(i, j int) bool {
+			return availableSymbols[i].ID < availableSymbols[j].ID
+		})
 	}
 
 	if len(availableSymbols) == 0 {
@@ -405,10 +706,12 @@ func (sm *Manager) GenerateRitual(baseRitual *Ritual) *Ritual {
 		return nil
 	}
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	variant := len(sm.RitualRegistry.GetAllRituals())
+	r, generationSeed := sm.generationRand("ritual", baseRitual.ID, variant)
 
-	// Determine how many symbols to require (2-4)
-	symbolCount := 2 + r.Intn(3)
+	// Determine how many symbols to require
+	generation := sm.Generation
+	symbolCount := generation.MinRitualSymbols + r.Intn(generation.MaxRitualSymbols-generation.MinRitualSymbols+1)
 	if symbolCount > len(availableSymbols) {
 		symbolCount = len(availableSymbols)
 	}
@@ -430,9 +733,15 @@ func (sm *Manager) GenerateRitual(baseRitual *Ritual) *Ritual {
 	for i := 0; i < symbolCount; i++ {
 		requiredSymbols = append(requiredSymbols, availableSymbols[symbolIndices[i]].ID)
 	}
+	if len(requiredSymbols) == 0 {
+		return nil
+	}
 
 	// Create unique ID
-	ritualID := fmt.Sprintf("ritual_%s_%s", baseRitual.RequiredLocation, generateRandomString(6))
+	ritualID := fmt.Sprintf("ritual_%s_%s", baseRitual.RequiredLocation, generateRandomString(r, 6))
+	for sm.RitualRegistry.GetRitual(ritualID) != nil {
+		ritualID = fmt.Sprintf("ritual_%s_%s", baseRitual.RequiredLocation, generateRandomString(r, 6))
+	}
 
 	// Create name
 	nameAdjectives := []string{"Ritual", "Ceremony", "Rite", "Invocation", "Summoning", "Binding", "Banishing", "Awakening"}
@@ -460,12 +769,15 @@ func (sm *Manager) GenerateRitual(baseRitual *Ritual) *Ritual {
 	// Generate required items
 	itemCount := r.Intn(3) // 0 to 2 items
 	requiredItems := make([]string, 0, itemCount)
+	itemCategories := make(map[string]string, itemCount)
 
-	itemTypes := []string{"herb", "mineral", "bone", "fluid", "cloth", "tool"}
 	for i := 0; i < itemCount; i++ {
-		itemType := itemTypes[r.Intn(len(itemTypes))]
+		itemType := ritualItemTypes[r.Intn(len(ritualItemTypes))]
 		item := generateRitualItem(itemType, r)
 		requiredItems = append(requiredItems, item)
+
+		// Any item of the same type can stand in for it, at a cost
+		itemCategories[item] = itemType
 	}
 
 	// Generate actions
@@ -480,7 +792,7 @@ func (sm *Manager) GenerateRitual(baseRitual *Ritual) *Ritual {
 	}
 
 	// Generate effects
-	effectCount := 1 + r.Intn(2) // 1 to 2 effects
+	effectCount := 1 + r.Intn(generation.MaxRitualEffects) // 1 to MaxRitualEffects effects
 	effects := make([]RitualEffect, 0, effectCount)
 
 	// Calculate total power of the symbols
@@ -493,19 +805,24 @@ func (sm *Manager) GenerateRitual(baseRitual *Ritual) *Ritual {
 	}
 	averagePower := totalPower / float64(len(requiredSymbols))
 
-	// Generate primary effect based on ritual location
-	primaryEffect := generatePrimaryEffect(baseRitual.RequiredLocation, averagePower, r)
-	effects = append(effects, primaryEffect)
+	if len(baseRitual.Effects) > 0 {
+		// Base rituals with fixed effects keep them
+		effects = append(effects, baseRitual.Effects...)
+	} else {
+		// Generate primary effect based on ritual location
+		primaryEffect := generatePrimaryEffect(baseRitual.RequiredLocation, averagePower, r)
+		effects = append(effects, primaryEffect)
 
-	// Add additional effects if needed
-	if effectCount > 1 {
-		secondaryEffect := generateSecondaryEffect(baseRitual.RequiredLocation, averagePower*0.7, r)
-		effects = append(effects, secondaryEffect)
+		// Add additional effects if needed
+		for i := 1; i < effectCount; i++ {
+			secondaryEffect := generateSecondaryEffect(baseRitual.RequiredLocation, averagePower*0.7, r)
+			effects = append(effects, secondaryEffect)
+		}
 	}
 
 	// Generate failure effects
 	failureEffects := make([]RitualEffect, 0, 1)
-	failureEffect := generateFailureEffect(baseRitual.RequiredLocation, averagePower, r)
+	failureEffect := generateFailureEffect(baseRitual.RequiredLocation, sm.ritualFailureSeverity(requiredSymbols, 0), r)
 	failureEffects = append(failureEffects, failureEffect)
 
 	// Create the ritual
@@ -515,6 +832,7 @@ func (sm *Manager) GenerateRitual(baseRitual *Ritual) *Ritual {
 		Description:      ritualDesc,
 		RequiredSymbols:  requiredSymbols,
 		RequiredItems:    requiredItems,
+		ItemCategories:   itemCategories,
 		RequiredLocation: baseRitual.RequiredLocation,
 		Actions:          actions,
 		Difficulty:       0.3 + r.Float64()*0.5, // Between 0.3 and 0.8
@@ -526,7 +844,8 @@ func (sm *Manager) GenerateRitual(baseRitual *Ritual) *Ritual {
 		KnowledgeLevel:   0.0,
 		TimesPerformed:   0,
 		TimesSucceeded:   0,
-		GenerationSeed:   time.Now().UnixNano(),
+		GenerationSeed:   generationSeed,
+		GeneratedAt:      sm.clock.Now(),
 		EvolutionPath:    []string{},
 		ParentRitual:     "",
 		EvolutionLevel:   0,
@@ -542,57 +861,59 @@ func (sm *Manager) RequiredComponents() []ecs.ComponentID {
 
 // Update is called once per frame
 func (sm *Manager) Update(deltaTime float64) {
+	defer sm.publishSnapshot()
+
+	// Let anomalies caused by studied symbols fade
+	sm.decaySymbolAnomaly(deltaTime)
+
+	// Advance long rituals and let danger disturb them
+	sm.updateRitualSessions(deltaTime)
+
+	// Discoveries are spaced out by a cooldown
+	sm.discoveryCooldown = math.Max(0, sm.discoveryCooldown-deltaTime)
+
+	// Exposure to forbidden symbols fades over days
+	sm.decayExposure(deltaTime)
+	sm.observationElapsed += deltaTime
+
+	// Unpracticed symbol knowledge fades over days
+	sm.decayKnowledge(deltaTime)
+
 	// Check for nearby symbols that the player can discover
 	// Only check every 0.5 seconds to avoid performance issues
-	if time.Since(sm.lastRitualCheck) < 500*time.Millisecond {
+	if sm.clock.Now().Sub(sm.lastRitualCheck) < 500*time.Millisecond {
 		return
 	}
-	sm.lastRitualCheck = time.Now()
+	sm.lastRitualCheck = sm.clock.Now()
+
+	// Register or drop camps as campfires are lit and put out
+	sm.updateCampVolumes()
 
 	// Get player entity
-	playerEntities := sm.world.GetEntitiesWithTag("player")
+	playerEntities := sm.world.GetEntitiesWithTag(TagPlayer)
 	if len(playerEntities) == 0 {
 		return
 	}
 	player := playerEntities[0]
 
-	// Get player position
-	playerTransform, has := player.GetComponent(ecs.TransformComponentID)
+	// Get player position and facing
+	playerTransform, has := ecs.ComponentAs[*ecs.TransformComponent](player, ecs.TransformComponentID)
 	if !has {
 		return
 	}
-	playerPos := playerTransform.(*ecs.TransformComponent).Position
-
-	// Check for symbols in the world
-	symbolEntities := sm.world.GetEntitiesWithComponent(ecs.SymbolComponentID)
-	for _, entity := range symbolEntities {
-		symbolComp, _ := entity.GetComponent(ecs.SymbolComponentID)
-		symbol := symbolComp.(*ecs.SymbolComponent)
-
-		// Skip if already discovered
-		if symbol.Discovered {
-			continue
-		}
+	playerPos := playerTransform.Position
 
-		// Check if player is close enough to discover
-		entityTransform, has := entity.GetComponent(ecs.TransformComponentID)
-		if !has {
-			continue
-		}
-		entityPos := entityTransform.(*ecs.TransformComponent).Position
+	// Queue symbols the player has seen or touched, then discover
+	// them at a deliberate pace
+	sm.queueSymbolDiscoveries(playerPos, playerTransform.Forward())
+	sm.discoverQueuedSymbols()
 
-		distance := playerPos.Distance(entityPos)
-		if distance <= symbol.DiscoveryRadius {
-			// Discover the symbol
-			symbol.Discovered = true
+	// Lingering near forbidden symbols exposes the player to them
+	sm.observeForbiddenSymbols(playerPos, sm.observationElapsed)
 
-			// Get the registered symbol
-			regSymbol := sm.Registry.GetSymbol(symbol.SymbolID)
-			if regSymbol != nil {
-				sm.DiscoverSymbol(regSymbol, playerPos)
-			}
-		}
-	}
+	// Carried tablets resonate with inscriptions and sites of their symbols
+	sm.updateTabletResonance(player, playerPos, sm.observationElapsed)
+	sm.observationElapsed = 0
 
 	// Update symbol knowledge levels
 	sm.updateSymbolKnowledge(deltaTime)
@@ -608,100 +929,220 @@ func (sm *Manager) DiscoverSymbol(symbol *Symbol, location ecs.Vector3) {
 		return
 	}
 
+	// The first symbol of a never-before-seen type is a revelation
+	firstOfType := !sm.Registry.hasDiscoveredType(symbol.SymbolType)
+	initialKnowledge := 0.1
+	if firstOfType {
+		initialKnowledge = sm.Discovery.FirstOfTypeKnowledge
+	}
+
 	// Mark as discovered
 	symbol.IsDiscovered = true
-	symbol.DiscoveryTime = time.Now()
+	symbol.DiscoveryTime = sm.clock.Now()
 	symbol.DiscoveryLocation = location
-	symbol.KnowledgeLevel = 0.1 // Initial understanding
+	// Initial understanding, or what witnessed metamorphoses already taught
+	symbol.KnowledgeLevel = math.Max(initialKnowledge, sm.playerKnowledge[symbol.ID])
 
 	// Add to discovered symbols
 	sm.Registry.discoveredSymbols[symbol.ID] = symbol
 
 	// Initialize knowledge level
-	sm.playerKnowledge[symbol.ID] = 0.1
+	sm.playerKnowledge[symbol.ID] = symbol.KnowledgeLevel
+
+	// Studying symbols destabilizes the surrounding area
+	sm.addSymbolAnomaly(symbol, location)
 
 	// Check for new ritual discoveries based on this symbol
 	sm.checkForRitualDiscoveries()
 
-	// Trigger callback if set
+	// Trigger callbacks if set
+	if sm.OnFirstDiscovery != nil {
+		sm.OnFirstDiscovery(symbol, symbol.LoreText, firstOfType)
+	}
 	if sm.OnSymbolDiscovered != nil {
 		sm.OnSymbolDiscovered(symbol)
 	}
 }
 
-// PerformRitual attempts to perform a ritual
-func (sm *Manager) PerformRitual(ritual *Ritual, location ecs.Vector3, items []string, playerSkill float64) (bool, []RitualEffect) {
+// Lore returns the lore fragment unlocked by a discovered symbol
+func (sm *Manager) Lore(symbolID string) (string, bool) {
+	symbol := sm.Registry.GetSymbol(symbolID)
+	if symbol == nil || !symbol.IsDiscovered || symbol.LoreText == "" {
+		return "", false
+	}
+	return symbol.LoreText, true
+}
+
+// PerformRitual attempts to perform a ritual with the player's ritual skill and
+// reports how well it went. Steps performed out of the ritual's order, missing or
+// extra lower the success chance, unless Actions.SimpleMode is set.
+func (sm *Manager) PerformRitual(ritual *Ritual, location ecs.Vector3, items, performedActions []string) RitualResult {
+	return sm.performRitual(ritual, location, items, performedActions, TrackedSkill, 1.0)
+}
+
+// PerformRitualWithSkill performs a ritual with a fixed skill (0-1) instead of the
+// player's, for scripted scenarios. The ritual's steps are performed by the book.
+// Successes still train the player's skill.
+func (sm *Manager) PerformRitualWithSkill(ritual *Ritual, location ecs.Vector3, items []string, playerSkill float64) RitualResult {
+	return sm.performRitual(ritual, location, items, ritual.Actions, playerSkill, 1.0)
+}
+
+// performRitual performs a ritual with its success chance scaled by chanceModifier
+// and by how well performedActions follow the ritual's steps. A negative
+// playerSkill (TrackedSkill) uses the player's ritual skill.
+func (sm *Manager) performRitual(ritual *Ritual, location ecs.Vector3, items, performedActions []string, playerSkill, chanceModifier float64) RitualResult {
 	sm.mutex.Lock()
 	defer sm.mutex.Unlock()
 
 	// Update ritual stats
 	ritual.TimesPerformed++
-	ritual.LastPerformTime = time.Now()
+	ritual.LastPerformTime = sm.clock.Now()
 
-	// Check if this is a valid location
-	locationValid := checkRitualLocation(ritual.RequiredLocation, location, sm.world)
+	// Meanings that complement each other strengthen the ritual; clashing ones destabilize it
+	synergy := sm.RitualSynergy(ritual)
 
-	// Check if all required items are present
-	itemsValid := true
-	for _, requiredItem := range ritual.RequiredItems {
-		if !containsString(items, requiredItem) {
-			itemsValid = false
-			break
-		}
-	}
+	// Location, items, skill, knowledge and synergy set the success chance;
+	// disturbances during long rituals and sloppy steps lower it further
+	successChance := sm.successChance(ritual, location, items, playerSkill, synergy) * chanceModifier
+	successChance *= sm.Actions.actionFactor(ritual, performedActions)
 
-	// Calculate success chance
-	successChance := ritual.SuccessChance
-
-	// Location and items affect success
-	if !locationValid {
-		successChance *= 0.5
-	}
-	if !itemsValid {
-		successChance *= 0.7
+	// A clash of meanings tears at the surroundings whatever the outcome
+	if synergy < 0 {
+		sm.raiseAreaAnomaly(location, ClashAnomaly*-synergy)
 	}
+	substitutions, _ := matchRitualItems(ritual, items, sm.optionalItem(ritual))
+	power := synergyPower(synergy) * sm.substitutionFactor(substitutions) * sm.ritualResonance(ritual)
+	severity := clashSeverity(synergy)
 
-	// Player skill affects success
-	successChance *= (0.5 + 0.5*playerSkill)
-
-	// Player knowledge of the ritual affects success
-	knowledgeLevel := sm.GetKnowledgeLevel(ritual.ID)
-	successChance *= (0.5 + 0.5*knowledgeLevel)
+	// Powerful, well-understood symbols make successful effects last longer
+	durationScale := sm.symbolDurationScale(ritual.RequiredSymbols)
 
 	// Random factor
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	roll := r.Float64()
+	roll := sm.rng.Float64()
 
-	// Check for success
-	success := roll < successChance
+	// Check for success; the margin of the roll grades the outcome
+	outcome := sm.rollOutcome(roll, successChance)
+	result := RitualResult{Outcome: outcome}
 
 	var effects []RitualEffect
-	if success {
+	switch outcome {
+	case RitualCritical:
+		// Amplified effects count as a success and may evolve the ritual at once
+		ritual.TimesSucceeded++
+		effects, result.Skipped = sm.applyRitualEffects(extendEffectDurations(sm.OutcomeTiers.criticalEffects(ritual), durationScale), location, power, ritual.RequiredSymbols)
+
+		sm.increaseKnowledgeLocked(ritual.ID, 0.1+sm.OutcomeTiers.CriticalKnowledge)
+
+		if len(ritual.EvolutionPath) > 0 &&
+			(ritual.TimesSucceeded >= 3 || sm.rng.Float64() < sm.OutcomeTiers.CriticalEvolveChance) {
+			sm.EvolveRitual(ritual)
+		}
+
+	case RitualSucceeded:
 		// Ritual succeeded
 		ritual.TimesSucceeded++
-		effects = ritual.Effects
+		// Carry out the effects (wards protect the ritual site from scares)
+		effects, result.Skipped = sm.applyRitualEffects(extendEffectDurations(ritual.Effects, durationScale), location, power, ritual.RequiredSymbols)
 
 		// Increase knowledge
-		sm.IncreaseKnowledge(ritual.ID, 0.1)
+		sm.increaseKnowledgeLocked(ritual.ID, 0.1)
 
 		// Check for ritual evolution
 		if ritual.TimesSucceeded >= 3 && len(ritual.EvolutionPath) > 0 {
 			sm.EvolveRitual(ritual)
 		}
-	} else {
-		// Ritual failed
-		effects = ritual.FailureEffects
+
+	case RitualPartialSuccess:
+		// Diluted effects with a minor complication; does not count toward evolution
+		effects, result.Skipped = sm.applyRitualEffects(extendEffectDurations(sm.PartialSuccess.partialEffects(ritual, sm.rng), durationScale), location, power, ritual.RequiredSymbols)
+
+		// Near misses teach a little more than failures
+		sm.increaseKnowledgeLocked(ritual.ID, 0.075)
+
+	case RitualNearMiss:
+		// Weakened failure effects, and part of the offerings survive
+		effects = scaleEffectValues(sm.OutcomeTiers.nearMissEffects(ritual), severity)
+		result.Refunded = sm.OutcomeTiers.refundedItems(ritual, items)
+		sm.applyPlayerEffects(effects)
+
+		sm.increaseKnowledgeLocked(ritual.ID, 0.05)
+
+	case RitualCatastrophic:
+		// Doubled failure effects, a hostile from the failure pool and a surge of anomaly
+		effects = scaleEffectValues(sm.OutcomeTiers.catastropheEffects(ritual, sm.rng), severity)
+		sm.raiseAreaAnomaly(location, sm.OutcomeTiers.CatastropheAnomaly)
+		sm.applyPlayerEffects(effects)
+
+		sm.increaseKnowledgeLocked(ritual.ID, 0.05)
+
+	default:
+		// Ritual failed; the backlash still hurts the performer
+		effects = scaleEffectValues(ritual.FailureEffects, severity)
+		sm.applyPlayerEffects(effects)
 
 		// Still gain some knowledge
-		sm.IncreaseKnowledge(ritual.ID, 0.05)
+		sm.increaseKnowledgeLocked(ritual.ID, 0.05)
+	}
+	result.Effects = effects
+
+	// Channeling the symbols deepens the understanding of them
+	sm.channelRitualSymbols(ritual, outcome)
+
+	// Successes train the player's ritual skill
+	sm.growRitualSkill(ritual, outcome)
+
+	// Record the performance in the almanac
+	rank, rankedUp := sm.RitualRegistry.recordOutcome(ritual, outcome)
+	result.MasteryCue = outcome.Succeeded() && rank >= MasteryMaster
+	if rankedUp && sm.OnMasteryRankUp != nil {
+		sm.OnMasteryRankUp(ritual, rank)
 	}
 
 	// Trigger callback if set
 	if sm.OnRitualPerformed != nil {
-		sm.OnRitualPerformed(ritual, success, effects)
+		sm.OnRitualPerformed(ritual, outcome, effects)
+	}
+
+	return result
+}
+
+// successChance returns the chance of a ritual succeeding at a location with the
+// given items, before disturbances. A negative playerSkill (TrackedSkill) uses the
+// player's ritual skill. Must be called with sm.mutex held.
+func (sm *Manager) successChance(ritual *Ritual, location ecs.Vector3, items []string, playerSkill, synergy float64) float64 {
+	// Check if this is a valid location
+	locationValid := checkRitualLocation(ritual.RequiredLocation, location, sm.world) ||
+		sm.isInLocationVolume(ritual.RequiredLocation, location)
+
+	// Check if all required items are present; experts may leave one out
+	substitutions, itemsValid := matchRitualItems(ritual, items, sm.optionalItem(ritual))
+
+	successChance := ritual.SuccessChance
+
+	// Location and items affect success
+	if !locationValid {
+		successChance *= 0.5
 	}
+	if !itemsValid {
+		successChance *= 0.7
+	}
+
+	// Substitutes work, but not as well as the preferred items
+	successChance *= sm.substitutionFactor(substitutions)
 
-	return success, effects
+	// Player skill affects success
+	successChance *= (0.5 + 0.5*sm.resolveSkill(ritual, playerSkill))
+
+	// Player knowledge of the ritual affects success
+	successChance *= (0.5 + 0.5*sm.playerKnowledge[ritual.ID])
+
+	// Symbol meaning synergy affects success
+	successChance *= (1.0 + SynergySuccessBonus*synergy)
+
+	// Symbols resonating with the time of day or weather steady the ritual
+	successChance *= resonanceChance(sm.ritualResonance(ritual))
+
+	return successChance
 }
 
 // GetKnowledgeLevel returns the player's knowledge level for a symbol or ritual
@@ -721,6 +1162,12 @@ func (sm *Manager) IncreaseKnowledge(id string, amount float64) {
 	sm.mutex.Lock()
 	defer sm.mutex.Unlock()
 
+	sm.increaseKnowledgeLocked(id, amount)
+}
+
+// increaseKnowledgeLocked increases the player's knowledge of a symbol or ritual.
+// Must be called with sm.mutex held.
+func (sm *Manager) increaseKnowledgeLocked(id string, amount float64) {
 	// Get current knowledge
 	currentLevel := sm.playerKnowledge[id]
 
@@ -738,6 +1185,9 @@ func (sm *Manager) IncreaseKnowledge(id string, amount float64) {
 		symbol.KnowledgeLevel = newLevel
 	} else if ritual := sm.RitualRegistry.GetRitual(id); ritual != nil {
 		ritual.KnowledgeLevel = newLevel
+
+		// Learning a ritual makes related rituals easier to grasp
+		sm.transferRitualKnowledge(id, newLevel-currentLevel)
 	}
 
 	// Check for potential ritual discoveries
@@ -784,10 +1234,10 @@ func (sm *Manager) EvolveRitual(ritual *Ritual) {
 
 // GenerateEvolvedRitual creates an evolved version of a ritual
 func (sm *Manager) GenerateEvolvedRitual(baseRitual *Ritual) *Ritual {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r, generationSeed := sm.generationRand("evolved", baseRitual.ID, baseRitual.EvolutionLevel+1)
 
 	// Create unique ID
-	evolvedID := fmt.Sprintf("%s_evolved_%s", baseRitual.ID, generateRandomString(4))
+	evolvedID := fmt.Sprintf("%s_evolved_%s", baseRitual.ID, generateRandomString(r, 4))
 
 	// Create evolved name
 	evolvedAdjectives := []string{"Advanced", "Greater", "Empowered", "Ascended", "Refined", "Mastered"}
@@ -825,16 +1275,20 @@ func (sm *Manager) GenerateEvolvedRitual(baseRitual *Ritual) *Ritual {
 	// Keep the same items, but might add one more
 	evolvedItems := make([]string, len(baseRitual.RequiredItems))
 	copy(evolvedItems, baseRitual.RequiredItems)
+	evolvedCategories := make(map[string]string, len(baseRitual.ItemCategories))
+	for item, category := range baseRitual.ItemCategories {
+		evolvedCategories[item] = category
+	}
 
 	// 30% chance to add another item
 	if r.Float64() < 0.3 {
-		itemTypes := []string{"herb", "mineral", "bone", "fluid", "cloth", "tool"}
-		itemType := itemTypes[r.Intn(len(itemTypes))]
+		itemType := ritualItemTypes[r.Intn(len(ritualItemTypes))]
 		newItem := generateRitualItem(itemType, r)
 
-		// Make sure it's not a duplicate
+		// Make sure it's not a duplicate; an item already required keeps its category
 		if !containsString(evolvedItems, newItem) {
 			evolvedItems = append(evolvedItems, newItem)
+			evolvedCategories[newItem] = itemType
 		}
 	}
 
@@ -855,14 +1309,14 @@ func (sm *Manager) GenerateEvolvedRitual(baseRitual *Ritual) *Ritual {
 	for i, effect := range baseRitual.Effects {
 		// Create enhanced copy of the effect
 		enhancedEffect := effect
-		enhancedEffect.Value *= 1.3 + r.Float64()*0.3    // 1.3x to 1.6x stronger
-		enhancedEffect.Duration *= 1.5 + r.Float64()*0.5 // 1.5x to 2.0x longer
+		enhancedEffect.Value *= 1.3 + r.Float64()*0.3                                             // 1.3x to 1.6x stronger
+		enhancedEffect.Duration = int(float64(enhancedEffect.Duration) * (1.5 + r.Float64()*0.5)) // 1.5x to 2.0x longer
 
 		evolvedEffects[i] = enhancedEffect
 	}
 
 	// 30% chance to add another effect
-	if r.Float64() < 0.3 {
+	if r.Float64() < 0.3 && len(evolvedSymbols) > 0 {
 		// Calculate average power of the symbols
 		totalPower := 0.0
 		for _, symbolID := range evolvedSymbols {
@@ -877,14 +1331,32 @@ func (sm *Manager) GenerateEvolvedRitual(baseRitual *Ritual) *Ritual {
 		evolvedEffects = append(evolvedEffects, newEffect)
 	}
 
-	// Enhanced failure effects
+	// Evolved water and cave rituals learn to reshape the symbols they invoke
+	if !hasSymbolTransform(evolvedEffects) {
+		power := 0.0
+		for _, symbolID := range evolvedSymbols {
+			if symbol := sm.Registry.GetSymbol(symbolID); symbol != nil {
+				power += symbol.Power / float64(len(evolvedSymbols))
+			}
+		}
+		if effect, ok := symbolTransformEffect(baseRitual.RequiredLocation, power); ok {
+			evolvedEffects = append(evolvedEffects, effect)
+		}
+	}
+
+	// Failures of an evolved ritual strike back with its strongest symbols and higher orders
+	severity := sm.ritualFailureSeverity(evolvedSymbols, baseRitual.EvolutionLevel+1)
 	evolvedFailureEffects := make([]RitualEffect, len(baseRitual.FailureEffects))
 	for i, effect := range baseRitual.FailureEffects {
-		// Create enhanced copy of the effect
-		enhancedEffect := effect
-		enhancedEffect.Value *= 1.2 // Slightly more dangerous
-
-		evolvedFailureEffects[i] = enhancedEffect
+		switch effect.Type {
+		case "player_harm", "metamorphosis_unstable", "spawn_hostile":
+			evolvedFailureEffects[i] = failureEffectOfType(effect.Type, baseRitual.RequiredLocation, severity, r)
+		default:
+			// Hand-written failure effects only grow more dangerous
+			enhancedEffect := effect
+			enhancedEffect.Value *= 1.0 + sm.FailureSeverity.LevelScale
+			evolvedFailureEffects[i] = enhancedEffect
+		}
 	}
 
 	// Create the evolved ritual
@@ -894,6 +1366,7 @@ func (sm *Manager) GenerateEvolvedRitual(baseRitual *Ritual) *Ritual {
 		Description:      evolvedDesc,
 		RequiredSymbols:  evolvedSymbols,
 		RequiredItems:    evolvedItems,
+		ItemCategories:   evolvedCategories,
 		RequiredLocation: baseRitual.RequiredLocation,
 		Actions:          evolvedActions,
 		Difficulty:       baseRitual.Difficulty * 1.2,   // More difficult
@@ -905,16 +1378,23 @@ func (sm *Manager) GenerateEvolvedRitual(baseRitual *Ritual) *Ritual {
 		KnowledgeLevel:   0,
 		TimesPerformed:   0,
 		TimesSucceeded:   0,
-		GenerationSeed:   time.Now().UnixNano(),
+		GenerationSeed:   generationSeed,
+		GeneratedAt:      sm.clock.Now(),
 		EvolutionPath:    []string{},
 		ParentRitual:     baseRitual.ID,
 		EvolutionLevel:   baseRitual.EvolutionLevel + 1,
 	}
 
+	// Demanding rituals no longer tolerate substitutes
+	if evolvedRitual.Difficulty >= ExactItemsDifficulty {
+		evolvedRitual.ItemCategories = map[string]string{}
+	}
+
 	return evolvedRitual
 }
 
-// checkForRitualDiscoveries checks if the player has discovered enough symbols to learn new rituals
+// checkForRitualDiscoveries checks if the player has discovered enough symbols to learn new rituals.
+// Must be called with sm.mutex held.
 func (sm *Manager) checkForRitualDiscoveries() {
 	// Get all undiscovered rituals
 	undiscoveredRituals := sm.RitualRegistry.GetUndiscoveredRituals()
@@ -931,28 +1411,41 @@ func (sm *Manager) checkForRitualDiscoveries() {
 				break
 			}
 
-			knowledge := sm.GetKnowledgeLevel(symbolID)
-			totalKnowledge += knowledge
+			totalKnowledge += sm.playerKnowledge[symbolID]
 		}
 
+		// Knowledge carried over from related rituals helps discovery
+		averageKnowledge := 0.0
+		if len(ritual.RequiredSymbols) > 0 {
+			averageKnowledge = totalKnowledge / float64(len(ritual.RequiredSymbols))
+		}
+		priorKnowledge := sm.playerKnowledge[ritual.ID]
+
 		// Need an average knowledge of at least 0.4 to discover the ritual
-		if canDiscover && totalKnowledge/float64(len(ritual.RequiredSymbols)) >= 0.4 {
-			// Discover the ritual
-			ritual.IsDiscovered = true
-			sm.RitualRegistry.discoveredRituals[ritual.ID] = ritual
-
-			// Initial knowledge
-			ritual.KnowledgeLevel = 0.1
-			sm.playerKnowledge[ritual.ID] = 0.1
-
-			// Trigger callback if set
-			if sm.OnRitualDiscovered != nil {
-				sm.OnRitualDiscovered(ritual)
-			}
+		if canDiscover && averageKnowledge+priorKnowledge >= ritualDiscoveryThreshold {
+			sm.discoverRitual(ritual, math.Max(0.1, priorKnowledge))
 		}
 	}
 }
 
+// discoverRitual marks a ritual as discovered with the given initial knowledge.
+// Must be called with sm.mutex held.
+func (sm *Manager) discoverRitual(ritual *Ritual, initialKnowledge float64) {
+	ritual.IsDiscovered = true
+
+	sm.RitualRegistry.mutex.Lock()
+	sm.RitualRegistry.discoveredRituals[ritual.ID] = ritual
+	sm.RitualRegistry.mutex.Unlock()
+
+	ritual.KnowledgeLevel = initialKnowledge
+	sm.playerKnowledge[ritual.ID] = initialKnowledge
+
+	// Trigger callback if set
+	if sm.OnRitualDiscovered != nil {
+		sm.OnRitualDiscovered(ritual)
+	}
+}
+
 // updateSymbolKnowledge updates the knowledge levels based on study and usage
 func (sm *Manager) updateSymbolKnowledge(deltaTime float64) {
 	// Get all discovered symbols
@@ -964,8 +1457,11 @@ func (sm *Manager) updateSymbolKnowledge(deltaTime float64) {
 	// Update knowledge based on complexity and time
 	// This simulates the player gradually learning more about symbols they've discovered
 	for _, symbol := range discoveredSymbols {
-		// Knowledge increases more slowly for complex symbols
+		// Knowledge increases more slowly for complex symbols, and faster for forbidden ones
 		knowledgeGain := (0.0001 * deltaTime) / (symbol.Complexity * 2)
+		if sm.Exposure.isForbidden(symbol) {
+			knowledgeGain *= sm.Exposure.KnowledgeScale
+		}
 		sm.IncreaseKnowledge(symbol.ID, knowledgeGain)
 	}
 
@@ -985,8 +1481,8 @@ func (sr *Registry) LoadBaseSymbols() error {
 	basePath := filepath.Join(sr.savePath, "base")
 
 	// Create directory if it doesn't exist
-	if _, err := os.Stat(basePath); os.IsNotExist(err) {
-		err = os.MkdirAll(basePath, os.ModePerm)
+	if !sr.storage.Exists(basePath) {
+		err := sr.storage.MkdirAll(basePath)
 		if err != nil {
 			return err
 		}
@@ -999,39 +1495,43 @@ func (sr *Registry) LoadBaseSymbols() error {
 	}
 
 	// Load files
-	files, err := ioutil.ReadDir(basePath)
+	files, err := sr.storage.ListFiles(basePath)
 	if err != nil {
 		return err
 	}
 
 	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
-
-		if filepath.Ext(file.Name()) == ".json" {
+		if filepath.Ext(file) == ".json" {
 			// Load symbol
-			filePath := filepath.Join(basePath, file.Name())
-			data, err := ioutil.ReadFile(filePath)
+			filePath := filepath.Join(basePath, file)
+			data, err := sr.storage.ReadFile(filePath)
 			if err != nil {
+				sr.loadReport.AddError(engine.TemplateError{File: filePath, Message: err.Error()})
 				continue
 			}
 
 			var symbol Symbol
 			err = json.Unmarshal(data, &symbol)
 			if err != nil {
+				sr.loadReport.AddError(engine.TemplateError{File: filePath, Message: err.Error()})
+				continue
+			}
+
+			// Skip symbols with missing or invalid fields
+			if sr.loadReport.AddValidationErrors(filePath, ValidateSymbol(&symbol)) {
 				continue
 			}
 
 			// Add to base symbols
 			sr.baseSymbols = append(sr.baseSymbols, &symbol)
+			sr.loadReport.Loaded++
 		}
 	}
 
 	// Load patterns
 	patternsPath := filepath.Join(sr.savePath, "patterns")
-	if _, err := os.Stat(patternsPath); os.IsNotExist(err) {
-		err = os.MkdirAll(patternsPath, os.ModePerm)
+	if !sr.storage.Exists(patternsPath) {
+		err := sr.storage.MkdirAll(patternsPath)
 		if err != nil {
 			return err
 		}
@@ -1044,27 +1544,25 @@ func (sr *Registry) LoadBaseSymbols() error {
 	}
 
 	// Load pattern files
-	files, err = ioutil.ReadDir(patternsPath)
+	files, err = sr.storage.ListFiles(patternsPath)
 	if err != nil {
 		return err
 	}
 
 	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
-
-		if filepath.Ext(file.Name()) == ".json" {
+		if filepath.Ext(file) == ".json" {
 			// Load pattern
-			filePath := filepath.Join(patternsPath, file.Name())
-			data, err := ioutil.ReadFile(filePath)
+			filePath := filepath.Join(patternsPath, file)
+			data, err := sr.storage.ReadFile(filePath)
 			if err != nil {
+				sr.loadReport.AddError(engine.TemplateError{File: filePath, Message: err.Error()})
 				continue
 			}
 
 			var pattern SymbolPattern
 			err = json.Unmarshal(data, &pattern)
 			if err != nil {
+				sr.loadReport.AddError(engine.TemplateError{File: filePath, Message: err.Error()})
 				continue
 			}
 
@@ -1075,7 +1573,7 @@ func (sr *Registry) LoadBaseSymbols() error {
 
 	// Load meaning groups
 	meaningsPath := filepath.Join(sr.savePath, "meanings.json")
-	if _, err := os.Stat(meaningsPath); os.IsNotExist(err) {
+	if !sr.storage.Exists(meaningsPath) {
 		// Create default meanings
 		err = sr.CreateDefaultMeanings(meaningsPath)
 		if err != nil {
@@ -1084,7 +1582,7 @@ func (sr *Registry) LoadBaseSymbols() error {
 	}
 
 	// Load meanings
-	data, err := ioutil.ReadFile(meaningsPath)
+	data, err := sr.storage.ReadFile(meaningsPath)
 	if err != nil {
 		return err
 	}
@@ -1110,6 +1608,7 @@ func (sr *Registry) CreateDefaultBaseSymbols(basePath string) error {
 			Power:       0.6,
 			Meanings:    []string{"elements", "nature", "force"},
 			VisualID:    "base_elemental_visual",
+			LoreText:    "The old trappers cut this mark into their doorposts so the storm would know whose house it passed.",
 			RitualModifiers: map[string]float64{
 				"power":     1.2,
 				"stability": 0.8,
@@ -1128,6 +1627,7 @@ func (sr *Registry) CreateDefaultBaseSymbols(basePath string) error {
 			Power:       0.7,
 			Meanings:    []string{"magic", "knowledge", "power"},
 			VisualID:    "base_arcane_visual",
+			LoreText:    "Whoever carved it first counted the strokes; every copy since has had one stroke too many.",
 			RitualModifiers: map[string]float64{
 				"power":     1.3,
 				"stability": 0.7,
@@ -1146,6 +1646,7 @@ func (sr *Registry) CreateDefaultBaseSymbols(basePath string) error {
 			Power:       0.8,
 			Meanings:    []string{"life", "death", "growth"},
 			VisualID:    "base_primal_visual",
+			LoreText:    "Found on antlers shed far from any herd. The taiga remembers what it grew before the people came.",
 			RitualModifiers: map[string]float64{
 				"power":     1.1,
 				"stability": 1.0,
@@ -1164,6 +1665,7 @@ func (sr *Registry) CreateDefaultBaseSymbols(basePath string) error {
 			Power:       0.9,
 			Meanings:    []string{"void", "chaos", "transformation"},
 			VisualID:    "base_void_visual",
+			LoreText:    "It is not drawn, only uncovered. Where the bark peels back, it was always underneath.",
 			RitualModifiers: map[string]float64{
 				"power":     1.5,
 				"stability": 0.5,
@@ -1183,7 +1685,7 @@ func (sr *Registry) CreateDefaultBaseSymbols(basePath string) error {
 			return err
 		}
 
-		err = ioutil.WriteFile(filePath, data, 0644)
+		err = sr.storage.WriteFile(filePath, data)
 		if err != nil {
 			return err
 		}
@@ -1317,7 +1819,7 @@ func (sr *Registry) CreateDefaultPatterns(patternsPath string) error {
 			return err
 		}
 
-		err = ioutil.WriteFile(filePath, data, 0644)
+		err = sr.storage.WriteFile(filePath, data)
 		if err != nil {
 			return err
 		}
@@ -1346,14 +1848,14 @@ func (sr *Registry) CreateDefaultMeanings(meaningsPath string) error {
 
 	// Create directory if needed
 	dir := filepath.Dir(meaningsPath)
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		err = os.MkdirAll(dir, os.ModePerm)
+	if !sr.storage.Exists(dir) {
+		err := sr.storage.MkdirAll(dir)
 		if err != nil {
 			return err
 		}
 	}
 
-	err = ioutil.WriteFile(meaningsPath, data, 0644)
+	err = sr.storage.WriteFile(meaningsPath, data)
 	if err != nil {
 		return err
 	}
@@ -1366,32 +1868,26 @@ func (sr *Registry) LoadState() error {
 	sr.mutex.Lock()
 	defer sr.mutex.Unlock()
 
-	symbolsPath := filepath.Join(sr.savePath, "symbols.json")
-
-	// Check if file exists
-	if _, err := os.Stat(symbolsPath); os.IsNotExist(err) {
-		return fmt.Errorf("symbols file does not exist")
-	}
-
-	// Load file
-	data, err := ioutil.ReadFile(symbolsPath)
+	symbols, err := readSavedSymbols(sr.storage, sr.savePath)
 	if err != nil {
 		return err
 	}
 
-	var symbols []*Symbol
-	err = json.Unmarshal(data, &symbols)
+	pruned, err := readPrunedSymbols(sr.storage, sr.savePath)
 	if err != nil {
 		return err
 	}
+	sr.pruned = pruned
 
 	// Clear existing symbols
 	sr.symbols = make(map[string]*Symbol)
 	sr.discoveredSymbols = make(map[string]*Symbol)
+	sr.symbolNames = make(map[string]string)
 	sr.symbolsByType = make(map[string][]*Symbol)
 
 	// Add loaded symbols
 	for _, symbol := range symbols {
+		sr.claimSymbolName(symbol)
 		sr.symbols[symbol.ID] = symbol
 
 		// Add to symbols by type
@@ -1418,8 +1914,8 @@ func (sr *Registry) SaveState() error {
 
 	// Make sure directory exists
 	dir := filepath.Dir(symbolsPath)
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		err = os.MkdirAll(dir, os.ModePerm)
+	if !sr.storage.Exists(dir) {
+		err := sr.storage.MkdirAll(dir)
 		if err != nil {
 			return err
 		}
@@ -1432,24 +1928,26 @@ func (sr *Registry) SaveState() error {
 	}
 
 	// Serialize and save
-	data, err := json.MarshalIndent(symbols, "", "  ")
+	data, err := savefile.Marshal(symbols)
 	if err != nil {
 		return err
 	}
 
-	err = ioutil.WriteFile(symbolsPath, data, 0644)
+	err = sr.storage.WriteSave(symbolsPath, data)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return sr.savePruned()
 }
 
-// AddSymbol adds a symbol to the registry
+// AddSymbol adds a symbol to the registry. A symbol whose name is already
+// taken gets an epithet from its meanings so display names stay unique.
 func (sr *Registry) AddSymbol(symbol *Symbol) {
 	sr.mutex.Lock()
 	defer sr.mutex.Unlock()
 
+	sr.claimSymbolName(symbol)
 	sr.symbols[symbol.ID] = symbol
 
 	// Add to symbols by type
@@ -1464,12 +1962,42 @@ func (sr *Registry) AddSymbol(symbol *Symbol) {
 	}
 }
 
-// GetSymbol returns a symbol by ID
-func (sr *Registry) GetSymbol(id string) *Symbol {
+// AddBaseSymbol adds a template symbol, replacing any base symbol with the same ID
+func (sr *Registry) AddBaseSymbol(symbol *Symbol) {
+	sr.mutex.Lock()
+	defer sr.mutex.Unlock()
+
+	for i, base := range sr.baseSymbols {
+		if base.ID == symbol.ID {
+			sr.baseSymbols[i] = symbol
+			return
+		}
+	}
+
+	sr.baseSymbols = append(sr.baseSymbols, symbol)
+}
+
+// GetBaseSymbols returns all template symbols
+func (sr *Registry) GetBaseSymbols() []*Symbol {
 	sr.mutex.RLock()
 	defer sr.mutex.RUnlock()
 
-	return sr.symbols[id]
+	result := make([]*Symbol, len(sr.baseSymbols))
+	copy(result, sr.baseSymbols)
+	return result
+}
+
+// GetSymbol returns a symbol by ID. A symbol removed by pruning is generated
+// again from its recipe (see PruneGeneratedContent).
+func (sr *Registry) GetSymbol(id string) *Symbol {
+	sr.mutex.RLock()
+	symbol := sr.symbols[id]
+	sr.mutex.RUnlock()
+
+	if symbol != nil {
+		return symbol
+	}
+	return sr.restorePruned(id)
 }
 
 // GetAllSymbols returns all symbols
@@ -1498,6 +2026,19 @@ func (sr *Registry) GetDiscoveredSymbols() []*Symbol {
 	return symbols
 }
 
+// hasDiscoveredType checks whether any symbol of a type has been discovered
+func (sr *Registry) hasDiscoveredType(symbolType string) bool {
+	sr.mutex.RLock()
+	defer sr.mutex.RUnlock()
+
+	for _, symbol := range sr.discoveredSymbols {
+		if symbol.SymbolType == symbolType {
+			return true
+		}
+	}
+	return false
+}
+
 // GetSymbolsByType returns symbols of a specific type
 func (sr *Registry) GetSymbolsByType(symbolType string) []*Symbol {
 	sr.mutex.RLock()
@@ -1511,6 +2052,20 @@ func (sr *Registry) GetSymbolsByType(symbolType string) []*Symbol {
 	return symbols
 }
 
+// SymbolIDsOfType returns the IDs of symbols of a type in sorted order, so that
+// the world can carve registry symbols deterministically
+func (sr *Registry) SymbolIDsOfType(symbolType string) []string {
+	sr.mutex.RLock()
+	defer sr.mutex.RUnlock()
+
+	ids := make([]string, 0, len(sr.symbolsByType[symbolType]))
+	for _, symbol := range sr.symbolsByType[symbolType] {
+		ids = append(ids, symbol.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 // Helper functions for ritual registry
 
 // LoadBaseRituals loads base rituals from files
@@ -1518,8 +2073,8 @@ func (rr *RitualRegistry) LoadBaseRituals() error {
 	basePath := filepath.Join(rr.savePath, "base")
 
 	// Create directory if it doesn't exist
-	if _, err := os.Stat(basePath); os.IsNotExist(err) {
-		err = os.MkdirAll(basePath, os.ModePerm)
+	if !rr.storage.Exists(basePath) {
+		err := rr.storage.MkdirAll(basePath)
 		if err != nil {
 			return err
 		}
@@ -1532,39 +2087,43 @@ func (rr *RitualRegistry) LoadBaseRituals() error {
 	}
 
 	// Load files
-	files, err := ioutil.ReadDir(basePath)
+	files, err := rr.storage.ListFiles(basePath)
 	if err != nil {
 		return err
 	}
 
 	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
-
-		if filepath.Ext(file.Name()) == ".json" {
+		if filepath.Ext(file) == ".json" {
 			// Load ritual
-			filePath := filepath.Join(basePath, file.Name())
-			data, err := ioutil.ReadFile(filePath)
+			filePath := filepath.Join(basePath, file)
+			data, err := rr.storage.ReadFile(filePath)
 			if err != nil {
+				rr.loadReport.AddError(engine.TemplateError{File: filePath, Message: err.Error()})
 				continue
 			}
 
 			var ritual Ritual
 			err = json.Unmarshal(data, &ritual)
 			if err != nil {
+				rr.loadReport.AddError(engine.TemplateError{File: filePath, Message: err.Error()})
+				continue
+			}
+
+			// Skip rituals with missing or invalid fields
+			if rr.loadReport.AddValidationErrors(filePath, ValidateRitual(&ritual)) {
 				continue
 			}
 
 			// Add to base rituals
 			rr.baseRituals = append(rr.baseRituals, &ritual)
+			rr.loadReport.Loaded++
 		}
 	}
 
 	// Load effect templates
 	effectsPath := filepath.Join(rr.savePath, "effects")
-	if _, err := os.Stat(effectsPath); os.IsNotExist(err) {
-		err = os.MkdirAll(effectsPath, os.ModePerm)
+	if !rr.storage.Exists(effectsPath) {
+		err := rr.storage.MkdirAll(effectsPath)
 		if err != nil {
 			return err
 		}
@@ -1577,32 +2136,30 @@ func (rr *RitualRegistry) LoadBaseRituals() error {
 	}
 
 	// Load effect files
-	files, err = ioutil.ReadDir(effectsPath)
+	files, err = rr.storage.ListFiles(effectsPath)
 	if err != nil {
 		return err
 	}
 
 	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
-
-		if filepath.Ext(file.Name()) == ".json" {
+		if filepath.Ext(file) == ".json" {
 			// Load effect
-			filePath := filepath.Join(effectsPath, file.Name())
-			data, err := ioutil.ReadFile(filePath)
+			filePath := filepath.Join(effectsPath, file)
+			data, err := rr.storage.ReadFile(filePath)
 			if err != nil {
+				rr.loadReport.AddError(engine.TemplateError{File: filePath, Message: err.Error()})
 				continue
 			}
 
 			var effect RitualEffect
 			err = json.Unmarshal(data, &effect)
 			if err != nil {
+				rr.loadReport.AddError(engine.TemplateError{File: filePath, Message: err.Error()})
 				continue
 			}
 
 			// Add to effect templates
-			rr.effectTemplates[file.Name()[:len(file.Name())-5]] = effect
+			rr.effectTemplates[file[:len(file)-5]] = effect
 		}
 	}
 
@@ -1619,7 +2176,7 @@ func (rr *RitualRegistry) CreateDefaultBaseRituals(basePath string) error {
 			Description:      "A ritual performed in the forest",
 			RequiredSymbols:  []string{},
 			RequiredItems:    []string{},
-			RequiredLocation: "forest",
+			RequiredLocation: LocationForest,
 			Actions:          []string{},
 			Difficulty:       0.5,
 			TimeRequired:     60,
@@ -1634,7 +2191,7 @@ func (rr *RitualRegistry) CreateDefaultBaseRituals(basePath string) error {
 			Description:      "A ritual performed near water",
 			RequiredSymbols:  []string{},
 			RequiredItems:    []string{},
-			RequiredLocation: "water",
+			RequiredLocation: LocationWater,
 			Actions:          []string{},
 			Difficulty:       0.6,
 			TimeRequired:     90,
@@ -1649,7 +2206,7 @@ func (rr *RitualRegistry) CreateDefaultBaseRituals(basePath string) error {
 			Description:      "A ritual performed in a cave",
 			RequiredSymbols:  []string{},
 			RequiredItems:    []string{},
-			RequiredLocation: "cave",
+			RequiredLocation: LocationCave,
 			Actions:          []string{},
 			Difficulty:       0.7,
 			TimeRequired:     120,
@@ -1664,7 +2221,7 @@ func (rr *RitualRegistry) CreateDefaultBaseRituals(basePath string) error {
 			Description:      "A ritual performed in an open clearing",
 			RequiredSymbols:  []string{},
 			RequiredItems:    []string{},
-			RequiredLocation: "clearing",
+			RequiredLocation: LocationClearing,
 			Actions:          []string{},
 			Difficulty:       0.4,
 			TimeRequired:     45,
@@ -1679,7 +2236,7 @@ func (rr *RitualRegistry) CreateDefaultBaseRituals(basePath string) error {
 			Description:      "A ritual performed on a hill",
 			RequiredSymbols:  []string{},
 			RequiredItems:    []string{},
-			RequiredLocation: "hill",
+			RequiredLocation: LocationHill,
 			Actions:          []string{},
 			Difficulty:       0.5,
 			TimeRequired:     60,
@@ -1688,6 +2245,78 @@ func (rr *RitualRegistry) CreateDefaultBaseRituals(basePath string) error {
 			FailureEffects:   []RitualEffect{},
 			EvolutionPath:    []string{},
 		},
+		{
+			ID:               "base_camp_ward",
+			Name:             "Warding Ritual",
+			Description:      "A ritual performed at a camp to keep the night at bay",
+			RequiredSymbols:  []string{},
+			RequiredItems:    []string{},
+			RequiredLocation: LocationCamp,
+			Actions:          []string{},
+			Difficulty:       0.3,
+			TimeRequired:     30,
+			Effects: []RitualEffect{
+				{
+					Type:        "ward",
+					Target:      "scare_intensity",
+					Value:       0.6,
+					Duration:    900, // 15 minutes
+					Tags:        []string{"ward", LocationCamp},
+					Description: "Weakens anything that tries to frighten those resting at the camp",
+				},
+			},
+			SuccessChance:  0.8,
+			FailureEffects: []RitualEffect{},
+			EvolutionPath:  []string{},
+		},
+		{
+			ID:               "base_camp_dream",
+			Name:             "Dream Ritual",
+			Description:      "A ritual performed at a camp before sleep",
+			RequiredSymbols:  []string{},
+			RequiredItems:    []string{},
+			RequiredLocation: LocationCamp,
+			Actions:          []string{},
+			Difficulty:       0.4,
+			TimeRequired:     45,
+			Effects: []RitualEffect{
+				{
+					Type:        "player",
+					Target:      "vision_chance",
+					Value:       0.3,
+					Duration:    0, // Until the next sleep
+					Tags:        []string{"dream", "vision", LocationCamp},
+					Description: "Makes visions more likely during the next sleep",
+				},
+			},
+			SuccessChance:  0.7,
+			FailureEffects: []RitualEffect{},
+			EvolutionPath:  []string{},
+		},
+		{
+			ID:               "base_camp_preservation",
+			Name:             "Preservation Ritual",
+			Description:      "A ritual performed at a camp over gathered provisions",
+			RequiredSymbols:  []string{},
+			RequiredItems:    []string{},
+			RequiredLocation: LocationCamp,
+			Actions:          []string{},
+			Difficulty:       0.3,
+			TimeRequired:     30,
+			Effects: []RitualEffect{
+				{
+					Type:        "item",
+					Target:      "freshness",
+					Value:       0.5,
+					Duration:    86400, // One day
+					Tags:        []string{"preservation", "food", LocationCamp},
+					Description: "Slows the spoiling of food kept at the camp",
+				},
+			},
+			SuccessChance:  0.8,
+			FailureEffects: []RitualEffect{},
+			EvolutionPath:  []string{},
+		},
 	}
 
 	// Save base rituals
@@ -1698,7 +2327,7 @@ func (rr *RitualRegistry) CreateDefaultBaseRituals(basePath string) error {
 			return err
 		}
 
-		err = ioutil.WriteFile(filePath, data, 0644)
+		err = rr.storage.WriteFile(filePath, data)
 		if err != nil {
 			return err
 		}
@@ -1775,6 +2404,29 @@ func (rr *RitualRegistry) CreateDefaultEffects(effectsPath string) error {
 				Description: "Restores some of the player's sanity",
 			},
 		},
+		{
+			ID: "create_anchor",
+			Effect: RitualEffect{
+				Type:           "create_anchor",
+				Target:         "area",
+				Value:          1.0,
+				Duration:       0, // Lasts until its charge runs out
+				Tags:           []string{"anchor", "stability", "positive"},
+				Description:    "Raises a stability anchor that holds back metamorphosis around it",
+				MetamorphOrder: 2,
+			},
+		},
+		{
+			ID: "recharge_anchor",
+			Effect: RitualEffect{
+				Type:        "recharge_anchor",
+				Target:      "area",
+				Value:       0.5,
+				Duration:    0, // Instant
+				Tags:        []string{"anchor", "stability", "positive"},
+				Description: "Restores the charge of nearby stability anchors and mends cracked ones",
+			},
+		},
 		{
 			ID: "spawn_friendly",
 			Effect: RitualEffect{
@@ -1847,7 +2499,7 @@ func (rr *RitualRegistry) CreateDefaultEffects(effectsPath string) error {
 			return err
 		}
 
-		err = ioutil.WriteFile(filePath, data, 0644)
+		err = rr.storage.WriteFile(filePath, data)
 		if err != nil {
 			return err
 		}
@@ -1861,21 +2513,7 @@ func (rr *RitualRegistry) LoadState() error {
 	rr.mutex.Lock()
 	defer rr.mutex.Unlock()
 
-	ritualsPath := filepath.Join(rr.savePath, "rituals.json")
-
-	// Check if file exists
-	if _, err := os.Stat(ritualsPath); os.IsNotExist(err) {
-		return fmt.Errorf("rituals file does not exist")
-	}
-
-	// Load file
-	data, err := ioutil.ReadFile(ritualsPath)
-	if err != nil {
-		return err
-	}
-
-	var rituals []*Ritual
-	err = json.Unmarshal(data, &rituals)
+	rituals, err := readSavedRituals(rr.storage, rr.savePath)
 	if err != nil {
 		return err
 	}
@@ -1883,11 +2521,14 @@ func (rr *RitualRegistry) LoadState() error {
 	// Clear existing rituals
 	rr.rituals = make(map[string]*Ritual)
 	rr.discoveredRituals = make(map[string]*Ritual)
+	rr.ritualNames = make(map[string]string)
 	rr.ritualsBySymbol = make(map[string][]*Ritual)
 	rr.ritualsByEffect = make(map[string][]*Ritual)
+	rr.ritualsByLocation = make(map[string][]*Ritual)
 
 	// Add loaded rituals
 	for _, ritual := range rituals {
+		rr.claimRitualName(ritual)
 		rr.rituals[ritual.ID] = ritual
 
 		// Add to rituals by symbol
@@ -1906,13 +2547,16 @@ func (rr *RitualRegistry) LoadState() error {
 			rr.ritualsByEffect[effect.Type] = append(rr.ritualsByEffect[effect.Type], ritual)
 		}
 
+		// Add to rituals by location type
+		rr.ritualsByLocation[ritual.RequiredLocation] = append(rr.ritualsByLocation[ritual.RequiredLocation], ritual)
+
 		// Add to discovered rituals if discovered
 		if ritual.IsDiscovered {
 			rr.discoveredRituals[ritual.ID] = ritual
 		}
 	}
 
-	return nil
+	return rr.loadMastery()
 }
 
 // SaveState saves the state of the ritual registry
@@ -1924,8 +2568,8 @@ func (rr *RitualRegistry) SaveState() error {
 
 	// Make sure directory exists
 	dir := filepath.Dir(ritualsPath)
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		err = os.MkdirAll(dir, os.ModePerm)
+	if !rr.storage.Exists(dir) {
+		err := rr.storage.MkdirAll(dir)
 		if err != nil {
 			return err
 		}
@@ -1938,24 +2582,30 @@ func (rr *RitualRegistry) SaveState() error {
 	}
 
 	// Serialize and save
-	data, err := json.MarshalIndent(rituals, "", "  ")
+	data, err := savefile.Marshal(rituals)
 	if err != nil {
 		return err
 	}
 
-	err = ioutil.WriteFile(ritualsPath, data, 0644)
+	err = rr.storage.WriteSave(ritualsPath, data)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return rr.saveMastery()
 }
 
-// AddRitual adds a ritual to the registry
+// AddRitual adds a ritual to the registry. A ritual whose name is already
+// taken gets an epithet from its primary symbol's meanings. A nil ritual is ignored.
 func (rr *RitualRegistry) AddRitual(ritual *Ritual) {
+	if ritual == nil {
+		return
+	}
+
 	rr.mutex.Lock()
 	defer rr.mutex.Unlock()
 
+	rr.claimRitualName(ritual)
 	rr.rituals[ritual.ID] = ritual
 
 	// Add to rituals by symbol
@@ -1974,12 +2624,51 @@ func (rr *RitualRegistry) AddRitual(ritual *Ritual) {
 		rr.ritualsByEffect[effect.Type] = append(rr.ritualsByEffect[effect.Type], ritual)
 	}
 
+	// Add to rituals by location type
+	rr.ritualsByLocation[ritual.RequiredLocation] = append(rr.ritualsByLocation[ritual.RequiredLocation], ritual)
+
+	// Link to related rituals for knowledge transfer
+	rr.indexRelatedRituals(ritual)
+
 	// Add to discovered rituals if discovered
 	if ritual.IsDiscovered {
 		rr.discoveredRituals[ritual.ID] = ritual
 	}
 }
 
+// AddBaseRitual adds a template ritual, replacing any base ritual with the same ID
+func (rr *RitualRegistry) AddBaseRitual(ritual *Ritual) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	for i, base := range rr.baseRituals {
+		if base.ID == ritual.ID {
+			rr.baseRituals[i] = ritual
+			return
+		}
+	}
+
+	rr.baseRituals = append(rr.baseRituals, ritual)
+}
+
+// GetBaseRituals returns all template rituals
+func (rr *RitualRegistry) GetBaseRituals() []*Ritual {
+	rr.mutex.RLock()
+	defer rr.mutex.RUnlock()
+
+	result := make([]*Ritual, len(rr.baseRituals))
+	copy(result, rr.baseRituals)
+	return result
+}
+
+// AddEffectTemplate adds or replaces a ritual effect template
+func (rr *RitualRegistry) AddEffectTemplate(id string, effect RitualEffect) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	rr.effectTemplates[id] = effect
+}
+
 // GetRitual returns a ritual by ID
 func (rr *RitualRegistry) GetRitual(id string) *Ritual {
 	rr.mutex.RLock()
@@ -2057,12 +2746,11 @@ func (rr *RitualRegistry) GetRitualsByEffect(effectType string) []*Ritual {
 
 // Utility functions
 
-// generateRandomString generates a random string of the specified length
-func generateRandomString(length int) string {
+// generateRandomString generates a random string of the specified length from r
+func generateRandomString(r *rand.Rand, length int) string {
 	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
 	result := make([]byte, length)
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	for i := 0; i < length; i++ {
 		result[i] = chars[r.Intn(len(chars))]
 	}
@@ -2303,6 +2991,9 @@ func generateRitualAdjective(location string, r *rand.Rand) string {
 	case "hill":
 		adjectives := []string{"elevated", "windswept", "overlooking", "ancient", "watching", "boundary"}
 		return adjectives[r.Intn(len(adjectives))]
+	case "camp":
+		adjectives := []string{"hearthside", "sheltered", "smoldering", "guarded", "humble", "watchful"}
+		return adjectives[r.Intn(len(adjectives))]
 	default:
 		adjectives := []string{"mysterious", "powerful", "arcane", "secret", "forgotten", "complex"}
 		return adjectives[r.Intn(len(adjectives))]
@@ -2359,6 +3050,15 @@ func generateRitualEffect(location string, r *rand.Rand) string {
 			"sends messages to distant powers",
 		}
 		return effects[r.Intn(len(effects))]
+	case "camp":
+		effects := []string{
+			"draws a circle of warmth against the dark",
+			"binds the fire's light to those who rest beside it",
+			"turns the camp into a refuge for the night",
+			"lets the smoke carry dreams beyond the trees",
+			"keeps what was gathered from the taiga's hunger",
+		}
+		return effects[r.Intn(len(effects))]
 	default:
 		effects := []string{
 			"channels powers beyond mortal understanding",
@@ -2384,56 +3084,20 @@ func generateLocationDescription(location string) string {
 		return "an open area free from trees and obstructions"
 	case "hill":
 		return "an elevated position with a view of the surroundings"
+	case "camp":
+		return "a camp with a burning fire and a shelter beside it"
 	default:
 		return "a special place with the right energies"
 	}
 }
 
-// generateRitualItem generates a ritual item description
+// generateRitualItem generates a ritual item of a type (see ritualItems)
 func generateRitualItem(itemType string, r *rand.Rand) string {
-	switch itemType {
-	case "herb":
-		herbs := []string{
-			"wild sage", "moonflower", "bloodroot", "ghost moss", "twisted bramble",
-			"whispering fern", "black lotus", "dreamer's weed", "starleaf", "nightshade",
-		}
-		return herbs[r.Intn(len(herbs))]
-	case "mineral":
-		minerals := []string{
-			"quartz crystal", "red ochre", "black salt", "fool's gold", "lodestone",
-			"obsidian shard", "amber fragment", "cave pearl", "thunderstone", "silver dust",
-		}
-		return minerals[r.Intn(len(minerals))]
-	case "bone":
-		bones := []string{
-			"small animal skull", "bird bone", "vertebrae", "antler fragment", "tooth",
-			"jawbone", "carved bone", "hollow bone", "charred bone", "ancient remains",
-		}
-		return bones[r.Intn(len(bones))]
-	case "fluid":
-		fluids := []string{
-			"clear spring water", "morning dew", "blood (animal)", "rendered fat", "tree sap",
-			"fermented berries", "pine resin", "mushroom extract", "flower essence", "rainwater",
-		}
-		return fluids[r.Intn(len(fluids))]
-	case "cloth":
-		cloths := []string{
-			"red cloth strip", "black silk square", "woven grass mat", "dyed linen", "burial shroud",
-			"embroidered patch", "unspun wool", "spider silk", "ceremonial banner", "charred rags",
-		}
-		return cloths[r.Intn(len(cloths))]
-	case "tool":
-		tools := []string{
-			"bone needle", "stone knife", "wooden bowl", "copper wire", "clay vessel",
-			"leather pouch", "glass vial", "carved stick", "stone mortar", "wooden flute",
-		}
-		return tools[r.Intn(len(tools))]
-	default:
-		items := []string{
-			"mysterious artifact", "symbolic object", "personal token", "natural rarity", "found curiosity",
-		}
-		return items[r.Intn(len(items))]
+	items, known := ritualItems[itemType]
+	if !known {
+		items = genericRitualItems
 	}
+	return items[r.Intn(len(items))]
 }
 
 // generateRitualAction generates a ritual action description
@@ -2649,7 +3313,7 @@ func generatePrimaryEffect(location string, power float64, r *rand.Rand) RitualE
 		area := 10.0 + power*30.0         // 10 to 40 meter radius
 
 		effect.Value = 0.3 + power*0.7 // 0.3 to 1.0 intensity
-		effect.Duration = duration
+		effect.Duration = int(duration)
 		effect.MetamorphOrder = order
 		effect.MetamorphArea = area
 
@@ -2662,8 +3326,8 @@ func generatePrimaryEffect(location string, power float64, r *rand.Rand) RitualE
 
 		spawnCount := 1 + int(power*3) // 1 to 4 entities
 
-		effect.Value = 0.5 + power*0.5      // 0.5 to 1.0 strength
-		effect.Duration = 1800 + power*3600 // 30 minutes to 1.5 hours
+		effect.Value = 0.5 + power*0.5           // 0.5 to 1.0 strength
+		effect.Duration = int(1800 + power*3600) // 30 minutes to 1.5 hours
 		effect.SpawnEntityType = entityType
 		effect.SpawnCount = spawnCount
 		effect.SpawnRadius = 5.0 + power*10.0
@@ -2691,8 +3355,8 @@ func generatePrimaryEffect(location string, power float64, r *rand.Rand) RitualE
 		effect.Duration = 0            // Instant effect
 
 	case "weather":
-		effect.Value = 0.5 + power*0.5      // 0.5 to 1.0 intensity
-		effect.Duration = 3600 + power*7200 // 1 to 3 hours
+		effect.Value = 0.5 + power*0.5           // 0.5 to 1.0 intensity
+		effect.Duration = int(3600 + power*7200) // 1 to 3 hours
 	}
 
 	return effect
@@ -2738,50 +3402,8 @@ func generateSecondaryEffect(location string, power float64, r *rand.Rand) Ritua
 	case "weather":
 		effect.Target = "local"
 		effect.Description = "Causes a subtle shift in local conditions"
-		effect.Value = 0.3 + power*0.3      // 0.3 to 0.6 intensity
-		effect.Duration = 1800 + power*3600 // 30 minutes to 1.5 hours
-	}
-
-	return effect
-}
-
-// generateFailureEffect generates an effect for a failed ritual
-func generateFailureEffect(location string, power float64, r *rand.Rand) RitualEffect {
-	// Failure effects are usually negative
-	effectTypes := []string{"player_harm", "metamorphosis_unstable", "spawn_hostile"}
-	effectType := effectTypes[r.Intn(len(effectTypes))]
-
-	// Create the effect
-	effect := RitualEffect{
-		Type: effectType,
-		Tags: []string{"failure", "negative", location},
-	}
-
-	// Set specific values based on effect type
-	switch effectType {
-	case "player_harm":
-		targets := []string{"health", "sanity"}
-		effect.Target = targets[r.Intn(len(targets))]
-		effect.Description = fmt.Sprintf("The ritual backfires, damaging the performer's %s", effect.Target)
-		effect.Value = -(10.0 + power*30.0) // -10 to -40 points (negative for damage)
-		effect.Duration = 0                 // Instant effect
-
-	case "metamorphosis_unstable":
-		effect.Target = "area"
-		effect.Description = "The ritual creates an unstable, chaotic metamorphosis"
-		effect.Value = 0.2 + power*0.4     // 0.2 to 0.6 intensity
-		effect.Duration = 600 + power*1800 // 10 to 40 minutes
-		effect.MetamorphOrder = 1          // Always low order, but chaotic
-		effect.MetamorphArea = 5.0 + power*15.0
-
-	case "spawn_hostile":
-		effect.Target = "entity"
-		effect.Description = "The ritual summons hostile entities"
-		effect.Value = 0.3 + power*0.5     // 0.3 to 0.8 strength
-		effect.Duration = 900 + power*1800 // 15 to 45 minutes
-		effect.SpawnEntityType = "hostile_spirit"
-		effect.SpawnCount = 1 + int(power*2) // 1 to 3 entities
-		effect.SpawnRadius = 3.0 + power*7.0
+		effect.Value = 0.3 + power*0.3           // 0.3 to 0.6 intensity
+		effect.Duration = int(1800 + power*3600) // 30 minutes to 1.5 hours
 	}
 
 	return effect
@@ -2790,7 +3412,7 @@ func generateFailureEffect(location string, power float64, r *rand.Rand) RitualE
 // checkRitualLocation checks if a location is valid for a ritual
 func checkRitualLocation(requiredLocation string, position ecs.Vector3, world *ecs.World) bool {
 	// Check environment entities in the vicinity
-	entities := world.GetEntitiesWithTag("environment")
+	entities := world.GetEntitiesWithTag(TagEnvironment)
 
 	// Search radius
 	radius := 10.0
@@ -0,0 +1,206 @@
+package symbols
+
+import (
+	"fmt"
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Carrying a tablet of a symbol close to an inscription or ancient site of the
+// same symbol makes them resonate: the symbol grows stronger and easier to learn
+const (
+	TabletResonanceRadius = 5.0   // Distance from an inscription or site at which a carried tablet resonates
+	TabletResonanceBoost  = 0.2   // Extra power of a symbol for each inscription or site it resonates with
+	TabletResonanceCap    = 0.5   // Most extra power tablet resonance gives a symbol, however many sites
+	TabletKnowledgeRate   = 0.002 // Knowledge of a resonating symbol gained per second
+	TabletExposureRate    = 0.02  // Exposure to a resonating void symbol gained per second, instead of knowledge
+	TabletWeight          = 0.5   // Inventory weight of a tablet
+	tabletResonanceSource = "tablet_resonance"
+)
+
+// TabletResonance is a carried tablet resonating with an inscription or ancient site of its symbol
+type TabletResonance struct {
+	SymbolID string
+	Site     ecs.EntityID // Inscription or ancient site entity
+	Position ecs.Vector3
+}
+
+// NewTablet creates a tablet item inscribed with a symbol. The player carries it
+// in their inventory.
+func NewTablet(symbol *Symbol) *ecs.Entity {
+	tablet := ecs.NewEntity()
+	tablet.AddTag(TagTablet)
+	tablet.AddComponent(ecs.NewTabletComponent(symbol.ID))
+	return tablet
+}
+
+// CopyToTablet copies a discovered symbol onto a new tablet in the carrier's
+// inventory, e.g. when the player studies an inscription of the symbol
+func (sm *Manager) CopyToTablet(carrier *ecs.Entity, symbolID string) (*ecs.Entity, error) {
+	symbol := sm.Registry.GetSymbol(symbolID)
+	if symbol == nil {
+		return nil, fmt.Errorf("symbol %s not found", symbolID)
+	}
+	if !symbol.IsDiscovered {
+		return nil, fmt.Errorf("symbol %s is not discovered", symbolID)
+	}
+
+	inventory, has := ecs.ComponentAs[*ecs.InventoryComponent](carrier, ecs.InventoryComponentID)
+	if !has {
+		return nil, fmt.Errorf("entity %s has no inventory", carrier.ID)
+	}
+	if carriedTablets(sm.world, carrier)[symbolID] {
+		return nil, fmt.Errorf("a tablet of symbol %s is already carried", symbolID)
+	}
+
+	tablet := NewTablet(symbol)
+	if !inventory.AddItem(tablet.ID, TabletWeight) {
+		return nil, fmt.Errorf("no room in the inventory for a tablet")
+	}
+	sm.world.AddEntity(tablet)
+	return tablet, nil
+}
+
+// carriedTablets returns the symbols of the tablets in a carrier's inventory
+func carriedTablets(world *ecs.World, carrier *ecs.Entity) map[string]bool {
+	inventory, has := ecs.ComponentAs[*ecs.InventoryComponent](carrier, ecs.InventoryComponentID)
+	if !has {
+		return nil
+	}
+
+	tablets := make(map[string]bool)
+	for _, itemID := range inventory.Items {
+		item, exists := world.GetEntity(itemID)
+		if !exists || !item.HasTag(TagTablet) {
+			continue
+		}
+		if tabletComp, has := ecs.ComponentAs[*ecs.TabletComponent](item, ecs.TabletComponentID); has {
+			tablets[tabletComp.SymbolID] = true
+		}
+	}
+	return tablets
+}
+
+// findTabletResonances returns the inscriptions and ancient sites within
+// TabletResonanceRadius of the player whose symbol the player carries a tablet of
+func (sm *Manager) findTabletResonances(player *ecs.Entity, playerPos ecs.Vector3) map[ecs.EntityID]TabletResonance {
+	resonances := make(map[ecs.EntityID]TabletResonance)
+	tablets := carriedTablets(sm.world, player)
+	if len(tablets) == 0 {
+		return resonances
+	}
+
+	consider := func(entity *ecs.Entity, symbolID string) {
+		if !tablets[symbolID] {
+			return
+		}
+		position, has := entityPosition(entity)
+		if !has || playerPos.Distance(position) > TabletResonanceRadius {
+			return
+		}
+		resonances[entity.ID] = TabletResonance{SymbolID: symbolID, Site: entity.ID, Position: position}
+	}
+
+	for _, entity := range sm.world.GetEntitiesWithComponent(ecs.SymbolComponentID) {
+		if symbolComp, has := ecs.ComponentAs[*ecs.SymbolComponent](entity, ecs.SymbolComponentID); has {
+			consider(entity, symbolComp.SymbolID)
+		}
+	}
+	for _, entity := range sm.world.GetEntitiesWithComponent(ecs.AncientSiteComponentID) {
+		if site, has := ecs.ComponentAs[*ecs.AncientSiteComponent](entity, ecs.AncientSiteComponentID); has && site.SymbolID != "" {
+			consider(entity, site.SymbolID)
+		}
+	}
+	return resonances
+}
+
+// updateTabletResonance starts and ends resonances between the player's tablets
+// and nearby inscriptions and sites, boosts the power of resonating symbols and
+// lets the player learn them for the elapsed seconds. Resonating with a void
+// symbol raises exposure instead of knowledge.
+func (sm *Manager) updateTabletResonance(player *ecs.Entity, playerPos ecs.Vector3, elapsed float64) {
+	current := sm.findTabletResonances(player, playerPos)
+
+	sm.mutex.Lock()
+	var began, ended []TabletResonance
+	for id, resonance := range current {
+		if _, exists := sm.tabletResonances[id]; !exists {
+			began = append(began, resonance)
+		}
+	}
+	for id, resonance := range sm.tabletResonances {
+		if _, exists := current[id]; !exists {
+			ended = append(ended, resonance)
+		}
+	}
+	sm.tabletResonances = current
+
+	// Each resonating site adds to its symbol's power, up to the cap
+	sm.tabletBoost = make(map[string]float64)
+	for _, resonance := range current {
+		sm.tabletBoost[resonance.SymbolID] = math.Min(TabletResonanceCap, sm.tabletBoost[resonance.SymbolID]+TabletResonanceBoost)
+	}
+	boosted := sm.tabletBoost
+	onBegan, onEnded := sm.OnTabletResonanceBegan, sm.OnTabletResonanceEnded
+	sm.mutex.Unlock()
+
+	for _, resonance := range ended {
+		sm.showTabletResonance(resonance.Site, false)
+		if onEnded != nil {
+			onEnded(resonance)
+		}
+	}
+	for _, resonance := range began {
+		sm.showTabletResonance(resonance.Site, true)
+		if onBegan != nil {
+			onBegan(resonance)
+		}
+	}
+
+	if elapsed <= 0 {
+		return
+	}
+	for symbolID := range boosted {
+		symbol := sm.Registry.GetSymbol(symbolID)
+		if symbol == nil {
+			continue
+		}
+		if symbol.SymbolType == "void" {
+			if sm.Exposure.Enabled {
+				sm.addExposure(symbol, playerPos, TabletExposureRate*elapsed)
+			}
+			continue
+		}
+		sm.IncreaseKnowledge(symbolID, TabletKnowledgeRate*elapsed)
+	}
+}
+
+// showTabletResonance makes a resonating inscription or site glow, or stops it
+func (sm *Manager) showTabletResonance(site ecs.EntityID, resonating bool) {
+	entity, exists := sm.world.GetEntity(site)
+	if !exists {
+		return
+	}
+	render, has := ecs.ComponentAs[*ecs.RenderComponent](entity, ecs.RenderComponentID)
+	if !has {
+		return
+	}
+	if resonating {
+		render.Effects.AddEffect("glow", TabletResonanceCap, tabletResonanceSource)
+	} else {
+		render.Effects.RemoveEffect("glow", tabletResonanceSource)
+	}
+}
+
+// GetTabletResonances returns the current resonances of carried tablets
+func (sm *Manager) GetTabletResonances() []TabletResonance {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	resonances := make([]TabletResonance, 0, len(sm.tabletResonances))
+	for _, resonance := range sm.tabletResonances {
+		resonances = append(resonances, resonance)
+	}
+	return resonances
+}
@@ -0,0 +1,116 @@
+package symbols
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newTabletTestManager creates a manager with a discovered symbol and a player
+// carrying an inventory at the origin
+func newTabletTestManager(t *testing.T) (*Manager, *ecs.World, *ecs.Entity, *engine.FakeClock) {
+	t.Helper()
+
+	sm, world := newTestManager(t, 1, testSymbol("test_frost", "elemental"))
+	clock := setTestClock(sm)
+	player := addTestPlayer(world, ecs.Vector3{}, ecs.NewInventoryComponent(4, 10))
+	return sm, world, player, clock
+}
+
+// addInscription carves a symbol into the world at a position
+func addInscription(world *ecs.World, symbolID string, position ecs.Vector3) *ecs.Entity {
+	inscription := ecs.NewEntity()
+	inscription.AddComponent(ecs.NewTransformComponent(position))
+	inscription.AddComponent(ecs.NewSymbolComponent(symbolID, "elemental", 0.5, 0.5))
+	world.AddEntity(inscription)
+	return inscription
+}
+
+// tabletBoostOf returns the resonance boost of a symbol
+func tabletBoostOf(sm *Manager, symbolID string) float64 {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+	return sm.tabletBoost[symbolID]
+}
+
+func TestCopyToTabletNeedsDiscoveredSymbol(t *testing.T) {
+	sm, _, player, _ := newTabletTestManager(t)
+	sm.Registry.AddSymbol(&Symbol{ID: "test_ash", SymbolType: "elemental"})
+
+	if _, err := sm.CopyToTablet(player, "test_ash"); err == nil {
+		t.Errorf("copied an undiscovered symbol onto a tablet")
+	}
+	if _, err := sm.CopyToTablet(player, "test_frost"); err != nil {
+		t.Fatalf("CopyToTablet: %v", err)
+	}
+	if _, err := sm.CopyToTablet(player, "test_frost"); err == nil {
+		t.Errorf("copied a second tablet of the same symbol")
+	}
+}
+
+func TestTabletIsNotAWorldSymbol(t *testing.T) {
+	sm, world, player, _ := newTabletTestManager(t)
+
+	tablet, err := sm.CopyToTablet(player, "test_frost")
+	if err != nil {
+		t.Fatalf("CopyToTablet: %v", err)
+	}
+	for _, entity := range world.GetEntitiesWithComponent(ecs.SymbolComponentID) {
+		if entity.ID == tablet.ID {
+			t.Errorf("tablet shows up among world symbols")
+		}
+	}
+}
+
+func TestTabletResonanceBoostsUpToCapAndEndsWhenWalkingAway(t *testing.T) {
+	sm, world, player, clock := newTabletTestManager(t)
+	var began, ended int
+	sm.OnTabletResonanceBegan = func(TabletResonance) { began++ }
+	sm.OnTabletResonanceEnded = func(TabletResonance) { ended++ }
+
+	for i := 0; i < 3; i++ {
+		addInscription(world, "test_frost", ecs.Vector3{X: float64(i)})
+	}
+	update := func() {
+		clock.Advance(time.Second)
+		sm.Update(1)
+	}
+
+	// Without a tablet nothing resonates
+	update()
+	if began != 0 || tabletBoostOf(sm, "test_frost") != 0 {
+		t.Fatalf("resonance without a tablet: began %d, boost %v", began, tabletBoostOf(sm, "test_frost"))
+	}
+
+	if _, err := sm.CopyToTablet(player, "test_frost"); err != nil {
+		t.Fatalf("CopyToTablet: %v", err)
+	}
+	knowledge := sm.GetKnowledgeLevel("test_frost")
+	update()
+	if began != 3 {
+		t.Errorf("began %d resonances, want 3", began)
+	}
+	// Three inscriptions would give 0.6, the cap holds it at 0.5
+	if got := tabletBoostOf(sm, "test_frost"); got != TabletResonanceCap {
+		t.Errorf("boost = %v, want the cap %v", got, TabletResonanceCap)
+	}
+	if sm.GetKnowledgeLevel("test_frost") <= knowledge {
+		t.Errorf("knowledge did not grow while resonating")
+	}
+
+	// Walking away ends every resonance and the boost
+	transform, _ := ecs.ComponentAs[*ecs.TransformComponent](player, ecs.TransformComponentID)
+	transform.Position = ecs.Vector3{X: 100}
+	update()
+	if ended != 3 {
+		t.Errorf("ended %d resonances, want 3", ended)
+	}
+	if got := tabletBoostOf(sm, "test_frost"); got != 0 {
+		t.Errorf("boost = %v after walking away, want 0", got)
+	}
+	if resonances := sm.GetTabletResonances(); len(resonances) != 0 {
+		t.Errorf("%d resonances left after walking away", len(resonances))
+	}
+}
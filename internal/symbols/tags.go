@@ -0,0 +1,37 @@
+package symbols
+
+import "echo-taiga/internal/engine/ecs"
+
+// Entity tags queried by the symbol system
+const (
+	TagPlayer      = "player"
+	TagEnvironment = "environment"
+	TagHostile     = "hostile" // Creatures that disturb rituals in progress
+	TagTablet      = "tablet"  // Carried items inscribed with a symbol (see CopyToTablet)
+)
+
+// Ritual location tags. Environment entities carrying one of these tags
+// make a valid place for rituals that require that location.
+const (
+	LocationForest   = "forest"
+	LocationWater    = "water"
+	LocationCave     = "cave"
+	LocationClearing = "clearing"
+	LocationHill     = "hill"
+	LocationCamp     = "camp" // Registered dynamically from a lit campfire and a shelter
+)
+
+// Player-made camp structures
+const (
+	TagCampfire = "campfire"
+	TagShelter  = "shelter"
+)
+
+func init() {
+	ecs.DeclareTagQuery(TagPlayer, TagEnvironment, TagHostile)
+	ecs.DeclareTagQuery(LocationForest, LocationWater, LocationCave, LocationClearing, LocationHill)
+	ecs.DeclareTagQuery(TagCampfire, TagShelter)
+
+	ecs.RegisterTag(TagTablet, "item", "Табличка с начертанным символом")
+	ecs.DeclareTagQuery(TagTablet)
+}
@@ -0,0 +1,233 @@
+package symbols
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Symbol transformation effects: Type "symbol", Target "transform". The effect's
+// tags decide what happens to the targeted symbol.
+const (
+	SymbolEffectType      = "symbol"
+	SymbolTransformTarget = "transform"
+
+	TagPurify    = "purify"    // Lowers the symbol's distortion by the effect value
+	TagTransmute = "transmute" // Shifts the symbol's type; a symbol type tag picks the new type
+)
+
+// Distortion bounds that change how a symbol is described
+const (
+	cleanDistortion   = 0.1
+	warpedDistortion  = 0.5
+	transmuteCostRate = 0.5 // Share of the effect value added as distortion by a transmutation
+)
+
+// symbolTypeOrder is the cycle transmutations follow when no type is named
+var symbolTypeOrder = []string{"elemental", "arcane", "primal", "void"}
+
+// symbolTypeWorldEffects lists the world effect each symbol type carries and its range
+var symbolTypeWorldEffects = map[string]struct {
+	Key       string
+	Min, Span float64
+}{
+	"elemental": {"elemental_resonance", 0.5, 0.5},
+	"arcane":    {"magic_amplification", 0.4, 0.6},
+	"primal":    {"nature_connection", 0.6, 0.4},
+	"void":      {"reality_distortion", 0.7, 0.3},
+}
+
+// deriveTypeWorldEffects replaces the symbol's type-specific world effects with
+// those of its current type
+func deriveTypeWorldEffects(symbol *Symbol, r *rand.Rand) {
+	if symbol.WorldEffects == nil {
+		symbol.WorldEffects = make(map[string]float64)
+	}
+	for _, effect := range symbolTypeWorldEffects {
+		delete(symbol.WorldEffects, effect.Key)
+	}
+	if effect, ok := symbolTypeWorldEffects[symbol.SymbolType]; ok {
+		symbol.WorldEffects[effect.Key] = effect.Min + r.Float64()*effect.Span
+	}
+}
+
+// applySymbolTransforms carries out symbol transformation effects on the targeted
+// symbols. Must be called with sm.mutex held.
+func (sm *Manager) applySymbolTransforms(effects []RitualEffect, symbolIDs []string) {
+	for _, effect := range effects {
+		if effect.Type != SymbolEffectType || effect.Target != SymbolTransformTarget {
+			continue
+		}
+
+		purify := containsString(effect.Tags, TagPurify)
+		transmute := containsString(effect.Tags, TagTransmute)
+
+		symbol := sm.transformTarget(effect, symbolIDs, transmute && !purify)
+		if symbol == nil {
+			continue
+		}
+
+		if purify {
+			sm.purifySymbol(symbol, effect.Value)
+		}
+		if transmute {
+			sm.transmuteSymbol(symbol, transmuteType(symbol.SymbolType, effect.Tags), effect.Value)
+		}
+	}
+}
+
+// transformTarget picks the discovered symbol a transformation acts on: the one named
+// by the effect's ID or, failing that, the most distorted of the given symbols
+// (the first one when transmuting)
+func (sm *Manager) transformTarget(effect RitualEffect, symbolIDs []string, firstFound bool) *Symbol {
+	if effect.ID != "" {
+		if symbol := sm.Registry.GetSymbol(effect.ID); symbol != nil && symbol.IsDiscovered {
+			return symbol
+		}
+	}
+
+	var target *Symbol
+	for _, symbolID := range symbolIDs {
+		symbol := sm.Registry.GetSymbol(symbolID)
+		if symbol == nil || !symbol.IsDiscovered {
+			continue
+		}
+		if firstFound {
+			return symbol
+		}
+		if target == nil || symbol.Distortion > target.Distortion {
+			target = symbol
+		}
+	}
+	return target
+}
+
+// transmuteType returns the type named in tags, or the next type in the cycle
+func transmuteType(current string, tags []string) string {
+	for _, symbolType := range symbolTypeOrder {
+		if symbolType != current && containsString(tags, symbolType) {
+			return symbolType
+		}
+	}
+	for i, symbolType := range symbolTypeOrder {
+		if symbolType == current {
+			return symbolTypeOrder[(i+1)%len(symbolTypeOrder)]
+		}
+	}
+	return symbolTypeOrder[0]
+}
+
+// purifySymbol lowers a symbol's distortion and rewrites its description
+func (sm *Manager) purifySymbol(symbol *Symbol, amount float64) {
+	if amount <= 0 || symbol.Distortion <= 0 {
+		return
+	}
+
+	symbol.Distortion -= amount
+	if symbol.Distortion < 0 {
+		symbol.Distortion = 0
+	}
+
+	r, _ := sm.generationRand("symbol_purify", symbol.ID, fmt.Sprintf("%.2f", symbol.Distortion))
+	symbol.Description = describeSymbol(symbol.SymbolType, symbol.Distortion, r)
+
+	if sm.OnSymbolTransformed != nil {
+		sm.OnSymbolTransformed(symbol)
+	}
+}
+
+// transmuteSymbol shifts a symbol to another type. Its type-specific world effects
+// and description are re-derived, and the shift leaves it slightly more distorted.
+func (sm *Manager) transmuteSymbol(symbol *Symbol, newType string, strength float64) {
+	if newType == symbol.SymbolType {
+		return
+	}
+
+	sm.Registry.retypeSymbol(symbol, newType)
+
+	symbol.Distortion += strength * transmuteCostRate
+	if symbol.Distortion > 1 {
+		symbol.Distortion = 1
+	}
+
+	r, _ := sm.generationRand("symbol_transmute", symbol.ID, newType)
+	deriveTypeWorldEffects(symbol, r)
+	symbol.Resonance = resonanceForType(newType, symbol.Meanings)
+	symbol.Description = describeSymbol(newType, symbol.Distortion, r)
+
+	if sm.OnSymbolTransformed != nil {
+		sm.OnSymbolTransformed(symbol)
+	}
+}
+
+// retypeSymbol changes a symbol's type and moves it to the matching type index
+func (sr *Registry) retypeSymbol(symbol *Symbol, newType string) {
+	sr.mutex.Lock()
+	defer sr.mutex.Unlock()
+
+	oldList := sr.symbolsByType[symbol.SymbolType]
+	for i, s := range oldList {
+		if s.ID == symbol.ID {
+			sr.symbolsByType[symbol.SymbolType] = append(oldList[:i:i], oldList[i+1:]...)
+			break
+		}
+	}
+
+	symbol.SymbolType = newType
+	sr.symbolsByType[newType] = append(sr.symbolsByType[newType], symbol)
+}
+
+// describeSymbol writes a symbol description for its type and distortion
+func describeSymbol(symbolType string, distortion float64, r *rand.Rand) string {
+	descTemplates := []string{
+		"A %s symbol that %s when observed closely. It seems to %s.",
+		"This %s marking appears to %s. Those who study it report %s.",
+		"A %s sigil that %s. Legend says it was %s.",
+	}
+
+	description := fmt.Sprintf(descTemplates[r.Intn(len(descTemplates))],
+		generateSymbolAdjective(symbolType, r),
+		generateSymbolVerb(symbolType, r),
+		generateSymbolEffect(symbolType, r))
+
+	switch {
+	case distortion < cleanDistortion:
+		description += " Its lines are clean and still."
+	case distortion > warpedDistortion:
+		description += " Its lines are warped and seem to crawl at the edge of vision."
+	}
+	return description
+}
+
+// symbolTransformEffect returns the symbol transformation an evolved ritual gains
+// at its location, if any: water cleanses symbols, caves twist them toward the void
+func symbolTransformEffect(location string, power float64) (RitualEffect, bool) {
+	switch location {
+	case LocationWater:
+		return RitualEffect{
+			Type:        SymbolEffectType,
+			Target:      SymbolTransformTarget,
+			Tags:        []string{SymbolEffectType, TagPurify, location},
+			Description: "Washes the corruption from the symbols it invokes",
+			Value:       0.2 + power*0.3, // 0.2 to 0.5 less distortion
+		}, true
+	case LocationCave:
+		return RitualEffect{
+			Type:        SymbolEffectType,
+			Target:      SymbolTransformTarget,
+			Tags:        []string{SymbolEffectType, TagTransmute, "void", location},
+			Description: "Draws the symbols it invokes down into the void",
+			Value:       0.1 + power*0.2, // 0.1 to 0.3 strength
+		}, true
+	}
+	return RitualEffect{}, false
+}
+
+// hasSymbolTransform checks whether effects already include a symbol transformation
+func hasSymbolTransform(effects []RitualEffect) bool {
+	for _, effect := range effects {
+		if effect.Type == SymbolEffectType && effect.Target == SymbolTransformTarget {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package symbols
+
+import (
+	"strings"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// performTransformRitual performs a sure-to-succeed ritual over symbol carrying a
+// single symbol transformation effect with the given tags and value
+func performTransformRitual(t *testing.T, sm *Manager, symbol *Symbol, value float64, tags ...string) RitualResult {
+	t.Helper()
+
+	ritual := &Ritual{
+		ID:              "test_rite_" + tags[0],
+		Name:            "Rite",
+		RequiredSymbols: []string{symbol.ID},
+		Difficulty:      0.2,
+		SuccessChance:   0.9,
+		IsDiscovered:    true,
+		Effects: []RitualEffect{
+			{Type: SymbolEffectType, Target: SymbolTransformTarget, Tags: tags, Value: value},
+		},
+	}
+	sm.RitualRegistry.AddRitual(ritual)
+
+	result := sm.performRitual(ritual, ecs.Vector3{}, nil, ritual.Actions, 1.0, 100)
+	if result.Outcome != RitualSucceeded && result.Outcome != RitualCritical {
+		t.Fatalf("outcome = %v, want a success", result.Outcome)
+	}
+	return result
+}
+
+func TestPurifyingRitualCleansCorruptedSymbol(t *testing.T) {
+	symbol := testSymbol("test_rot", "void")
+	symbol.Distortion = 0.8
+	symbol.Description = "A corrupted sigil."
+	sm, _ := newTestManager(t, 1, symbol)
+
+	var transformed []string
+	sm.OnSymbolTransformed = func(symbol *Symbol) { transformed = append(transformed, symbol.ID) }
+
+	performTransformRitual(t, sm, symbol, 0.75, TagPurify)
+
+	if symbol.Distortion >= 0.8 {
+		t.Fatalf("distortion = %v after purification, want below 0.8", symbol.Distortion)
+	}
+	if symbol.SymbolType != "void" {
+		t.Errorf("purification changed the type to %q", symbol.SymbolType)
+	}
+	if symbol.Description == "A corrupted sigil." || symbol.Description == "" {
+		t.Errorf("description = %q, want it rewritten", symbol.Description)
+	}
+	if symbol.Distortion < cleanDistortion && !strings.HasSuffix(symbol.Description, "Its lines are clean and still.") {
+		t.Errorf("description of a clean symbol = %q, want it to read clean", symbol.Description)
+	}
+	if len(transformed) != 1 || transformed[0] != symbol.ID {
+		t.Errorf("OnSymbolTransformed calls = %v, want one for %s", transformed, symbol.ID)
+	}
+}
+
+func TestTransmutingRitualRetypesSymbol(t *testing.T) {
+	symbol := testSymbol("test_ember", "elemental")
+	symbol.WorldEffects = map[string]float64{"elemental_resonance": 0.7, "warmth": 0.3}
+	sm, _ := newTestManager(t, 1, symbol)
+
+	performTransformRitual(t, sm, symbol, 0.2, TagTransmute, "void")
+
+	if symbol.SymbolType != "void" {
+		t.Fatalf("type = %q after transmutation, want void", symbol.SymbolType)
+	}
+	if _, kept := symbol.WorldEffects["elemental_resonance"]; kept {
+		t.Errorf("world effects %v still carry the elemental effect", symbol.WorldEffects)
+	}
+	if value := symbol.WorldEffects["reality_distortion"]; value < 0.7 || value > 1.0 {
+		t.Errorf("reality_distortion = %v, want the void range 0.7-1.0", value)
+	}
+	if symbol.WorldEffects["warmth"] != 0.3 {
+		t.Errorf("warmth = %v, want effects unrelated to the type kept", symbol.WorldEffects["warmth"])
+	}
+	if symbol.Distortion <= 0 {
+		t.Errorf("distortion = %v, want the transmutation to leave a mark", symbol.Distortion)
+	}
+
+	// The registry's type index follows the new type
+	if len(sm.Registry.GetSymbolsByType("elemental")) != 0 || len(sm.Registry.GetSymbolsByType("void")) != 1 {
+		t.Errorf("type index not updated: elemental %d, void %d",
+			len(sm.Registry.GetSymbolsByType("elemental")), len(sm.Registry.GetSymbolsByType("void")))
+	}
+}
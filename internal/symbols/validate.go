@@ -0,0 +1,78 @@
+package symbols
+
+import (
+	"fmt"
+	"strings"
+
+	"echo-taiga/internal/engine"
+)
+
+// Known values for validated template fields
+var (
+	knownSymbolTypes     = []string{"elemental", "arcane", "primal", "void"}
+	knownRitualLocations = []string{LocationForest, LocationWater, LocationCave, LocationClearing, LocationHill, LocationCamp}
+)
+
+// ValidateSymbol checks the required fields of a base symbol
+func ValidateSymbol(symbol *Symbol) []engine.TemplateError {
+	errors := make([]engine.TemplateError, 0)
+
+	if strings.TrimSpace(symbol.ID) == "" {
+		errors = append(errors, engine.TemplateError{Field: "ID", Message: "must not be empty"})
+	}
+
+	if !containsString(knownSymbolTypes, symbol.SymbolType) {
+		errors = append(errors, engine.TemplateError{
+			Field:   "SymbolType",
+			Message: fmt.Sprintf("unknown symbol type %q (expected one of %s)", symbol.SymbolType, strings.Join(knownSymbolTypes, ", ")),
+		})
+	}
+
+	if symbol.Complexity < 0 || symbol.Complexity > 1 {
+		errors = append(errors, engine.TemplateError{
+			Field:   "Complexity",
+			Message: fmt.Sprintf("must be between 0 and 1, got %.2f", symbol.Complexity),
+		})
+	}
+
+	if symbol.Power < 0 || symbol.Power > 1 {
+		errors = append(errors, engine.TemplateError{
+			Field:   "Power",
+			Message: fmt.Sprintf("must be between 0 and 1, got %.2f", symbol.Power),
+		})
+	}
+
+	return errors
+}
+
+// ValidateRitual checks the required fields of a base ritual
+func ValidateRitual(ritual *Ritual) []engine.TemplateError {
+	errors := make([]engine.TemplateError, 0)
+
+	if strings.TrimSpace(ritual.ID) == "" {
+		errors = append(errors, engine.TemplateError{Field: "ID", Message: "must not be empty"})
+	}
+
+	if !containsString(knownRitualLocations, ritual.RequiredLocation) {
+		errors = append(errors, engine.TemplateError{
+			Field:   "RequiredLocation",
+			Message: fmt.Sprintf("unknown location %q (expected one of %s)", ritual.RequiredLocation, strings.Join(knownRitualLocations, ", ")),
+		})
+	}
+
+	if ritual.Difficulty < 0 || ritual.Difficulty > 1 {
+		errors = append(errors, engine.TemplateError{
+			Field:   "Difficulty",
+			Message: fmt.Sprintf("must be between 0 and 1, got %.2f", ritual.Difficulty),
+		})
+	}
+
+	if ritual.SuccessChance < 0 || ritual.SuccessChance > 1 {
+		errors = append(errors, engine.TemplateError{
+			Field:   "SuccessChance",
+			Message: fmt.Sprintf("must be between 0 and 1, got %.2f", ritual.SuccessChance),
+		})
+	}
+
+	return errors
+}
@@ -0,0 +1,68 @@
+package symbols
+
+import (
+	"math"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// MaxVisionHorizon is how far ahead a full-strength foresight ritual can see
+const MaxVisionHorizon = 30 * time.Minute
+
+// VisionSequenceDuration is how long scares are held off while visions are shown
+const VisionSequenceDuration = 15 * time.Second
+
+// Vision is a glimpse of an upcoming metamorphosis granted by a foresight ritual
+type Vision struct {
+	Description string        // What the player sees
+	Location    *ecs.Vector3  // Where it will happen (nil if unclear)
+	ETA         time.Duration // How soon it may happen
+	Likelihood  float64       // How likely it is (0-1)
+}
+
+// VisionSource is implemented by systems that can forecast upcoming metamorphoses
+// (e.g. an adapter over metamorphosis.MetamorphosisManager)
+type VisionSource interface {
+	ForecastVisions(horizon time.Duration) []Vision
+}
+
+// SetVisionSource sets the system queried by "future"-targeted knowledge effects
+func (sm *Manager) SetVisionSource(source VisionSource) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.visionSource = source
+}
+
+// GetLastVisions returns the visions granted by the most recent foresight effect
+func (sm *Manager) GetLastVisions() []Vision {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	result := make([]Vision, len(sm.lastVisions))
+	copy(result, sm.lastVisions)
+	return result
+}
+
+// grantVisions queries the vision source over a horizon proportional to the effect
+// strength and surfaces the result. Must be called with sm.mutex held.
+func (sm *Manager) grantVisions(strength float64) {
+	if sm.visionSource == nil {
+		return
+	}
+
+	horizon := time.Duration(math.Max(0, math.Min(1, strength)) * float64(MaxVisionHorizon))
+	if horizon <= 0 {
+		return
+	}
+
+	sm.lastVisions = sm.visionSource.ForecastVisions(horizon)
+
+	// The vision sequence has a fixed length: its hold simply expires
+	sm.holdScares("vision", VisionSequenceDuration)
+
+	if sm.OnVisionsGranted != nil {
+		sm.OnVisionsGranted(sm.lastVisions)
+	}
+}
@@ -0,0 +1,78 @@
+package symbols
+
+// WeatherEffectType is the ritual effect type that forces the weather
+const WeatherEffectType = "weather"
+
+// weatherByMeaning maps symbol meanings to the weather a ritual calls down.
+// Meanings are checked in the dominant symbol's order.
+var weatherByMeaning = map[string]string{
+	"fire":      "clear",
+	"magma":     "clear",
+	"light":     "clear",
+	"water":     "rain",
+	"steam":     "fog",
+	"smoke":     "fog",
+	"ice":       "snow",
+	"air":       "cloudy",
+	"lightning": "storm",
+	"void":      "distortion_fog",
+	"darkness":  "distortion_fog",
+	"chaos":     "distortion_fog",
+}
+
+// WeatherController forces the weather for a while (e.g. world.World). The
+// newest lease wins; interrupted leases resume once it runs out.
+type WeatherController interface {
+	LeaseWeather(condition string, duration float64, source string) uint64
+}
+
+// SetWeatherController sets the system that carries out weather effects of rituals
+func (sm *Manager) SetWeatherController(controller WeatherController) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.weatherController = controller
+}
+
+// applyWeatherEffects forces the weather for "weather" effects: the dominant
+// (most powerful) symbol's meanings pick the condition and the effect's
+// Duration is the lease. Effects without a duration or a matching meaning do
+// nothing. Must be called with sm.mutex held.
+func (sm *Manager) applyWeatherEffects(effects []RitualEffect, symbolIDs []string) {
+	if sm.weatherController == nil {
+		return
+	}
+
+	for _, effect := range effects {
+		if effect.Type != WeatherEffectType || effect.Duration <= 0 {
+			continue
+		}
+
+		condition := sm.ritualWeather(symbolIDs)
+		if condition == "" {
+			continue
+		}
+		sm.weatherController.LeaseWeather(condition, float64(effect.Duration), "ritual")
+	}
+}
+
+// ritualWeather returns the weather called down by the dominant symbol, if any
+func (sm *Manager) ritualWeather(symbolIDs []string) string {
+	var dominant *Symbol
+	for _, symbolID := range symbolIDs {
+		symbol := sm.Registry.GetSymbol(symbolID)
+		if symbol != nil && (dominant == nil || symbol.Power > dominant.Power) {
+			dominant = symbol
+		}
+	}
+	if dominant == nil {
+		return ""
+	}
+
+	for _, meaning := range dominant.Meanings {
+		if condition, exists := weatherByMeaning[meaning]; exists {
+			return condition
+		}
+	}
+	return ""
+}
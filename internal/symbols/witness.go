@@ -0,0 +1,144 @@
+package symbols
+
+import (
+	"math"
+	"sort"
+)
+
+// Witnessing metamorphoses ("the world teaches you")
+const (
+	MinWitnessKnowledge     = 0.05 // Knowledge granted by a faint, barely noticed effect
+	MaxWitnessKnowledge     = 0.2  // Knowledge granted by the strongest effects
+	SketchedSymbolKnowledge = 0.15 // Undiscovered symbols known this well are sketched in the journal
+	minWitnessOrder         = 3
+	maxWitnessOrder         = 5
+)
+
+// MetamorphEffectInfo describes a metamorphosis the player has witnessed
+type MetamorphEffectInfo struct {
+	ID           string
+	Name         string
+	Category     string // Effect category: "reality", "entity", "physics", ...
+	Order        int    // Metamorphosis order (1-5)
+	Intensity    float64
+	WorldChanges map[string]float64
+}
+
+// categorySymbolTypes maps metamorphosis categories to the symbol types they echo
+var categorySymbolTypes = map[string]string{
+	"reality":     "void",
+	"physics":     "void",
+	"entity":      "primal",
+	"environment": "elemental",
+	"visual":      "arcane",
+	"audio":       "arcane",
+}
+
+// witnessChance returns how likely an effect is to teach the player a symbol
+func witnessChance(effect MetamorphEffectInfo) float64 {
+	if effect.Order < minWitnessOrder {
+		return 0
+	}
+	order := math.Min(float64(effect.Order-minWitnessOrder+1)/float64(maxWitnessOrder-minWitnessOrder+1), 1)
+	intensity := math.Max(0, math.Min(1, effect.Intensity))
+	return order * (0.5 + 0.5*intensity)
+}
+
+// witnessCandidates returns undiscovered symbols related to an effect, sorted by ID.
+// Symbols whose world effects share keys with the effect's world changes are
+// preferred over symbols matched by category alone.
+func (sm *Manager) witnessCandidates(effect MetamorphEffectInfo) []*Symbol {
+	overlapping := make([]*Symbol, 0)
+	byCategory := make([]*Symbol, 0)
+	symbolType := categorySymbolTypes[effect.Category]
+
+	for _, symbol := range sm.Registry.GetAllSymbols() {
+		if symbol.IsDiscovered {
+			continue
+		}
+		overlap := false
+		for key := range symbol.WorldEffects {
+			if _, exists := effect.WorldChanges[key]; exists {
+				overlap = true
+				break
+			}
+		}
+		if overlap {
+			overlapping = append(overlapping, symbol)
+		} else if symbolType != "" && symbol.SymbolType == symbolType {
+			byCategory = append(byCategory, symbol)
+		}
+	}
+
+	candidates := overlapping
+	if len(candidates) == 0 {
+		candidates = byCategory
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ID < candidates[j].ID
+	})
+	return candidates
+}
+
+// WitnessEffect is called when the player sees a powerful metamorphosis take hold.
+// With a chance scaled by the effect's order and intensity it grants knowledge of
+// one related undiscovered symbol, possibly sketching it in the journal. The outcome
+// depends only on the effect ID and the world seed. Returns the symbol's ID, or ""
+// if the effect taught nothing.
+func (sm *Manager) WitnessEffect(effect MetamorphEffectInfo) string {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	r, _ := sm.generationRand("witness", effect.ID)
+	if r.Float64() >= witnessChance(effect) {
+		return ""
+	}
+
+	candidates := sm.witnessCandidates(effect)
+	if len(candidates) == 0 {
+		return ""
+	}
+	symbol := candidates[r.Intn(len(candidates))]
+
+	// Stronger effects teach more
+	strength := math.Max(0, math.Min(1, effect.Intensity))
+	amount := MinWitnessKnowledge + (MaxWitnessKnowledge-MinWitnessKnowledge)*strength
+
+	previous := sm.playerKnowledge[symbol.ID]
+	level := math.Min(previous+amount, 1)
+	sm.playerKnowledge[symbol.ID] = level
+
+	if previous < SketchedSymbolKnowledge && level >= SketchedSymbolKnowledge && sm.OnSymbolSketched != nil {
+		sm.OnSymbolSketched(symbol)
+	}
+
+	return symbol.ID
+}
+
+// IsSymbolSketched checks whether an undiscovered symbol is known well enough
+// from witnessed metamorphoses to be sketched in the journal
+func (sm *Manager) IsSymbolSketched(symbolID string) bool {
+	symbol := sm.Registry.GetSymbol(symbolID)
+	if symbol == nil || symbol.IsDiscovered {
+		return false
+	}
+	return sm.GetKnowledgeLevel(symbolID) >= SketchedSymbolKnowledge
+}
+
+// GetSketchedSymbols returns the IDs of sketched symbols, sorted
+func (sm *Manager) GetSketchedSymbols() []string {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	result := make([]string, 0)
+	for id, level := range sm.playerKnowledge {
+		if level < SketchedSymbolKnowledge {
+			continue
+		}
+		if symbol := sm.Registry.GetSymbol(id); symbol != nil && !symbol.IsDiscovered {
+			result = append(result, id)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,305 @@
+package telemetry
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Event kinds recorded during a session
+const (
+	KindScare  = "scare"
+	KindRitual = "ritual"
+	KindPhase  = "phase"
+	KindDeath  = "death"
+	KindThreat = "threat"
+)
+
+// DefaultMaxEvents caps the event log so long sessions stay cheap.
+// Summary counters keep counting past the cap.
+const DefaultMaxEvents = 10000
+
+// Event is a single recorded gameplay event
+type Event struct {
+	Time          time.Time `json:"time"`
+	Elapsed       float64   `json:"elapsed"`                 // Seconds since the session started
+	Kind          string    `json:"kind"`                    // One of the Kind constants
+	Name          string    `json:"name"`                    // Scare type, ritual ID, ...
+	Value         float64   `json:"value"`                   // Intensity, success (0/1), new phase or cycle count
+	Effectiveness float64   `json:"effectiveness,omitempty"` // How well a scare landed (0-1), once assessed
+	Detail        string    `json:"detail,omitempty"`
+}
+
+// Summary holds aggregate counts for a session
+type Summary struct {
+	Started            time.Time          `json:"started"`
+	Duration           float64            `json:"duration"`
+	Scares             int                `json:"scares"`
+	AssessedScares     int                `json:"assessed_scares"`
+	ScareEffectiveness map[string]float64 `json:"scare_effectiveness"` // Average effectiveness by scare type
+	RitualAttempts     int                `json:"ritual_attempts"`
+	RitualSuccesses    int                `json:"ritual_successes"`
+	PhaseChanges       int                `json:"phase_changes"`
+	Deaths             int                `json:"deaths"`
+	ThreatCrossings    int                `json:"threat_crossings"`
+	Cycles             int                `json:"cycles"`
+	DroppedEvents      int                `json:"dropped_events"`
+
+	SimulatedTicksPerSecond float64 `json:"simulated_ticks_per_second"` // Over the last second of real time
+	DroppedTicks            int     `json:"dropped_ticks"`              // Simulation ticks skipped after long stalls
+}
+
+// Telemetry collects playtest events for later analysis. A nil *Telemetry is
+// valid and records nothing, so callers can keep collection optional.
+type Telemetry struct {
+	MaxEvents int // Events beyond this are counted but not stored
+
+	start      time.Time
+	events     []Event
+	scareIndex map[string]int // Scare ID -> index in events
+	summary    Summary
+
+	effectivenessSum   map[string]float64
+	effectivenessCount map[string]int
+
+	mutex sync.Mutex
+}
+
+// New creates a collector for a session starting now
+func New() *Telemetry {
+	now := time.Now()
+	return &Telemetry{
+		MaxEvents:          DefaultMaxEvents,
+		start:              now,
+		events:             make([]Event, 0, 256),
+		scareIndex:         make(map[string]int),
+		summary:            Summary{Started: now},
+		effectivenessSum:   make(map[string]float64),
+		effectivenessCount: make(map[string]int),
+	}
+}
+
+// RecordScare records a triggered scare
+func (t *Telemetry) RecordScare(id, scareType string, intensity float64) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.summary.Scares++
+	if t.record(Event{Kind: KindScare, Name: scareType, Value: intensity, Detail: id}) {
+		t.scareIndex[id] = len(t.events) - 1
+	}
+}
+
+// RecordScareEffectiveness records how the player reacted to a scare
+func (t *Telemetry) RecordScareEffectiveness(id, scareType string, effectiveness float64) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.summary.AssessedScares++
+	t.effectivenessSum[scareType] += effectiveness
+	t.effectivenessCount[scareType]++
+
+	if index, exists := t.scareIndex[id]; exists {
+		t.events[index].Effectiveness = effectiveness
+	}
+}
+
+// RecordRitual records a ritual attempt and its outcome
+func (t *Telemetry) RecordRitual(ritualID string, success bool, effectCount int) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.summary.RitualAttempts++
+	value := 0.0
+	if success {
+		t.summary.RitualSuccesses++
+		value = 1
+	}
+	t.record(Event{Kind: KindRitual, Name: ritualID, Value: value, Detail: fmt.Sprintf("%d effects", effectCount)})
+}
+
+// RecordPhaseChange records a metamorphosis phase transition
+func (t *Telemetry) RecordPhaseChange(oldPhase, newPhase int) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.summary.PhaseChanges++
+	t.record(Event{Kind: KindPhase, Name: fmt.Sprintf("%d->%d", oldPhase, newPhase), Value: float64(newPhase)})
+}
+
+// SetTickStats records the current simulation tick rate and the total number of
+// ticks dropped so far
+func (t *Telemetry) SetTickStats(ticksPerSecond float64, droppedTicks int) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.summary.SimulatedTicksPerSecond = ticksPerSecond
+	t.summary.DroppedTicks = droppedTicks
+}
+
+// RecordDeath records a player death and the cycle count it led to
+func (t *Telemetry) RecordDeath(cycles int) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.summary.Deaths++
+	t.summary.Cycles = cycles
+	t.record(Event{Kind: KindDeath, Name: "player", Value: float64(cycles)})
+}
+
+// RecordThreatBand records the player's threat level moving into another band
+func (t *Telemetry) RecordThreatBand(previousBand, band int, level float64) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.summary.ThreatCrossings++
+	t.record(Event{Kind: KindThreat, Name: fmt.Sprintf("%d->%d", previousBand, band), Value: level})
+}
+
+// record stores an event, reporting false if the log is full.
+// Callers must hold t.mutex.
+func (t *Telemetry) record(event Event) bool {
+	if t.MaxEvents > 0 && len(t.events) >= t.MaxEvents {
+		t.summary.DroppedEvents++
+		return false
+	}
+
+	event.Time = time.Now()
+	event.Elapsed = event.Time.Sub(t.start).Seconds()
+	t.events = append(t.events, event)
+	return true
+}
+
+// GetSummary returns aggregate counts for the session so far
+func (t *Telemetry) GetSummary() Summary {
+	if t == nil {
+		return Summary{}
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.snapshotSummary()
+}
+
+// snapshotSummary copies the summary. Callers must hold t.mutex.
+func (t *Telemetry) snapshotSummary() Summary {
+	summary := t.summary
+	summary.Duration = time.Since(t.start).Seconds()
+	summary.ScareEffectiveness = make(map[string]float64, len(t.effectivenessSum))
+	for scareType, sum := range t.effectivenessSum {
+		summary.ScareEffectiveness[scareType] = sum / float64(t.effectivenessCount[scareType])
+	}
+	return summary
+}
+
+// GetEvents returns a copy of the recorded events
+func (t *Telemetry) GetEvents() []Event {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	events := make([]Event, len(t.events))
+	copy(events, t.events)
+	return events
+}
+
+// ExportJSON writes the session summary and events to path
+func (t *Telemetry) ExportJSON(path string) error {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	export := struct {
+		Summary Summary `json:"summary"`
+		Events  []Event `json:"events"`
+	}{
+		Summary: t.snapshotSummary(),
+		Events:  t.events,
+	}
+	data, err := json.MarshalIndent(export, "", "  ")
+	t.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode telemetry: %v", err)
+	}
+
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write telemetry: %v", err)
+	}
+	return nil
+}
+
+// ExportCSV writes the recorded events to path, one row per event
+func (t *Telemetry) ExportCSV(path string) error {
+	if t == nil {
+		return nil
+	}
+	events := t.GetEvents()
+
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create telemetry file: %v", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"time", "elapsed", "kind", "name", "value", "effectiveness", "detail"})
+	for _, event := range events {
+		writer.Write([]string{
+			event.Time.Format(time.RFC3339Nano),
+			strconv.FormatFloat(event.Elapsed, 'f', 3, 64),
+			event.Kind,
+			event.Name,
+			strconv.FormatFloat(event.Value, 'f', -1, 64),
+			strconv.FormatFloat(event.Effectiveness, 'f', -1, 64),
+			event.Detail,
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write telemetry: %v", err)
+	}
+	return nil
+}
+
+// ensureDir creates the directory that will hold path
+func ensureDir(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create telemetry directory: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package telemetry
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// simulateSession records a short playtest: three scares, one ritual, a phase
+// change, a threat crossing and a death
+func simulateSession(t *Telemetry) {
+	t.RecordScare("scare_1", "whisper", 0.4)
+	t.RecordScare("scare_2", "shadow_glimpse", 0.6)
+	t.RecordScare("scare_3", "whisper", 0.8)
+	t.RecordScareEffectiveness("scare_1", "whisper", 0.5)
+	t.RecordScareEffectiveness("scare_3", "whisper", 0.9)
+	t.RecordRitual("base_forest", true, 2)
+	t.RecordPhaseChange(0, 1)
+	t.RecordThreatBand(0, 2, 0.6)
+	t.RecordDeath(1)
+}
+
+// countKinds counts events by kind
+func countKinds(kinds []string) map[string]int {
+	counts := make(map[string]int)
+	for _, kind := range kinds {
+		counts[kind]++
+	}
+	return counts
+}
+
+// wantCounts are the events simulateSession records
+var wantCounts = map[string]int{KindScare: 3, KindRitual: 1, KindPhase: 1, KindThreat: 1, KindDeath: 1}
+
+func checkCounts(t *testing.T, source string, counts map[string]int) {
+	t.Helper()
+	for kind, want := range wantCounts {
+		if counts[kind] != want {
+			t.Errorf("%s has %d %s events, want %d", source, counts[kind], kind, want)
+		}
+	}
+}
+
+func TestSessionExportsMatchingCounts(t *testing.T) {
+	telemetry := New()
+	simulateSession(telemetry)
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "out", "session.json")
+	if err := telemetry.ExportJSON(jsonPath); err != nil {
+		t.Fatalf("ExportJSON: %v", err)
+	}
+	data, err := ioutil.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("failed to read JSON export: %v", err)
+	}
+	var export struct {
+		Summary Summary `json:"summary"`
+		Events  []Event `json:"events"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("failed to decode JSON export: %v", err)
+	}
+
+	kinds := make([]string, 0, len(export.Events))
+	for _, event := range export.Events {
+		kinds = append(kinds, event.Kind)
+	}
+	checkCounts(t, "JSON export", countKinds(kinds))
+
+	summary := export.Summary
+	if summary.Scares != 3 || summary.AssessedScares != 2 || summary.RitualAttempts != 1 || summary.RitualSuccesses != 1 ||
+		summary.PhaseChanges != 1 || summary.ThreatCrossings != 1 || summary.Deaths != 1 || summary.Cycles != 1 {
+		t.Errorf("summary %+v does not match the session", summary)
+	}
+	if got := summary.ScareEffectiveness["whisper"]; got < 0.7-1e-9 || got > 0.7+1e-9 {
+		t.Errorf("average whisper effectiveness = %v, want 0.7", got)
+	}
+	if export.Events[0].Effectiveness != 0.5 {
+		t.Errorf("first scare effectiveness = %v, want the assessed 0.5", export.Events[0].Effectiveness)
+	}
+
+	csvPath := filepath.Join(dir, "session.csv")
+	if err := telemetry.ExportCSV(csvPath); err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+	file, err := os.Open(csvPath)
+	if err != nil {
+		t.Fatalf("failed to open CSV export: %v", err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV export: %v", err)
+	}
+	if len(rows) != len(export.Events)+1 {
+		t.Fatalf("CSV export has %d rows, want a header and %d events", len(rows), len(export.Events))
+	}
+	kinds = kinds[:0]
+	for _, row := range rows[1:] {
+		kinds = append(kinds, row[2])
+	}
+	checkCounts(t, "CSV export", countKinds(kinds))
+}
+
+func TestEventLogIsCapped(t *testing.T) {
+	telemetry := New()
+	telemetry.MaxEvents = 2
+	simulateSession(telemetry)
+
+	if events := telemetry.GetEvents(); len(events) != 2 {
+		t.Errorf("stored %d events, want the cap of 2", len(events))
+	}
+	summary := telemetry.GetSummary()
+	if summary.Scares != 3 || summary.Deaths != 1 || summary.DroppedEvents != 5 {
+		t.Errorf("summary %+v, want counters to keep counting past the cap", summary)
+	}
+}
+
+func TestNilTelemetryRecordsNothing(t *testing.T) {
+	var telemetry *Telemetry
+	simulateSession(telemetry)
+
+	if events := telemetry.GetEvents(); events != nil {
+		t.Errorf("nil telemetry returned events %v", events)
+	}
+	if err := telemetry.ExportJSON(filepath.Join(t.TempDir(), "session.json")); err != nil {
+		t.Errorf("nil telemetry export failed: %v", err)
+	}
+}
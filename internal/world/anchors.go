@@ -0,0 +1,238 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/savefile"
+)
+
+// Параметры якорей стабильности
+const (
+	AnchorRadius         = 15.0  // Радиус подавления метаморфоз
+	AnchorMaxCharge      = 100.0 // Заряд нового якоря
+	AnchorLandmarkChance = 0.25  // Шанс найти якорь у древнего круга камней
+
+	anchorSeedSalt = 0x51ab1e // Смешивается с сидом круга, чтобы не сдвигать генерацию чанка
+)
+
+// AnchorRecord - сохраненное состояние якоря стабильности
+type AnchorRecord struct {
+	Position  ecs.Vector3 `json:"position"`
+	Radius    float64     `json:"radius"`
+	Strength  int         `json:"strength"`
+	Charge    float64     `json:"charge"`
+	MaxCharge float64     `json:"max_charge"`
+	Cracked   bool        `json:"cracked"`
+}
+
+// createAnchor создает якорь стабильности - камень, удерживающий реальность вокруг себя
+func createAnchor(world *ecs.World, position ecs.Vector3, strength int) *ecs.Entity {
+	anchor := ecs.NewEntity()
+
+	// Добавляем базовые компоненты
+	anchor.AddComponent(ecs.NewTransformComponent(position))
+	anchor.AddComponent(ecs.NewRenderComponent("stability_anchor", "stability_anchor_texture"))
+
+	// Якорь вкопан в землю
+	physicsComp := ecs.NewPhysicsComponent(500, true)
+	physicsComp.ColliderSize = ecs.Vector3{X: 0.8, Y: 1.8, Z: 0.8}
+	anchor.AddComponent(physicsComp)
+
+	anchor.AddComponent(ecs.NewAnchorComponent(AnchorRadius, strength, AnchorMaxCharge))
+
+	// Добавляем интерактивный компонент
+	anchor.AddComponent(ecs.NewInteractableComponent("examine", "Осмотреть якорь", 2.0))
+
+	// Холодный ровный свет
+	lightComp := ecs.NewLightComponent(color.RGBA{R: 170, G: 210, B: 255, A: 255}, 0.4, 6.0)
+	anchor.AddComponent(lightComp)
+
+	// Добавляем теги
+	anchor.AddTag(TagAnchor)
+	anchor.AddTag(TagSpecial)
+	anchor.AddTag(TagInteractive)
+
+	// Добавляем сущность в мир
+	world.AddEntity(anchor)
+
+	return anchor
+}
+
+// spawnLandmarkAnchor изредка ставит якорь у древнего круга камней.
+// Шанс определяется сидом круга, поэтому не влияет на остальную генерацию чанка.
+func (w *World) spawnLandmarkAnchor(chunk *Chunk, circlePosition ecs.Vector3, circleSeed int64) {
+	r := rand.New(rand.NewSource(circleSeed ^ anchorSeedSalt))
+	if r.Float64() >= AnchorLandmarkChance {
+		return
+	}
+
+	worldX := float64(chunk.Position[0] * ChunkSize)
+	worldZ := float64(chunk.Position[1] * ChunkSize)
+
+	// Якорь стоит в центре круга
+	position := circlePosition
+	position.Y = chunk.Terrain.GetHeightAt(position.X-worldX, position.Z-worldZ)
+
+	anchorEntity := createAnchor(w.ECSWorld, position, 2+r.Intn(2))
+	w.addChunkEntities(chunk, anchorEntity.ID)
+}
+
+// PlaceAnchor ставит новый якорь стабильности, не пропускающий метаморфозы
+// порядка strength и выше, и добавляет его в чанк
+func (w *World) PlaceAnchor(position ecs.Vector3, strength int) *ecs.Entity {
+	chunk := w.GetChunkAtPosition(position.X, position.Z)
+
+	anchorEntity := createAnchor(w.ECSWorld, position, strength)
+
+	w.chunkMutex.Lock()
+	w.addChunkEntities(chunk, anchorEntity.ID)
+	w.bumpGeneration()
+	w.chunkMutex.Unlock()
+
+	w.invalidateAnomalyFieldsAround(chunk.Position[0], chunk.Position[1])
+	return anchorEntity
+}
+
+// RechargeAnchors восполняет заряд якорей в радиусе от точки. Треснувшие якоря
+// снова начинают действовать. Возвращает число заряженных якорей.
+func (w *World) RechargeAnchors(position ecs.Vector3, radius, amount float64) int {
+	recharged := 0
+	for _, entity := range w.ECSWorld.GetEntitiesWithComponent(ecs.AnchorComponentID) {
+		anchor, has := ecs.ComponentAs[*ecs.AnchorComponent](entity, ecs.AnchorComponentID)
+		if !has {
+			continue
+		}
+		transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
+		if !has || math.Hypot(transform.Position.X-position.X, transform.Position.Z-position.Z) > radius {
+			continue
+		}
+
+		anchor.Recharge(amount)
+		if render, has := ecs.ComponentAs[*ecs.RenderComponent](entity, ecs.RenderComponentID); has && !anchor.Cracked {
+			render.Effects.RemoveEffect("cracked", ecs.EffectSourceBase)
+		}
+		recharged++
+	}
+
+	if recharged > 0 {
+		w.invalidateAnomalyFields()
+	}
+	return recharged
+}
+
+// restoreAnchors заменяет якоря только что сгенерированного чанка сохраненными.
+// Возвращает false, если для чанка нет сохраненного состояния.
+func (w *World) restoreAnchors(chunk *Chunk) bool {
+	records, saved := w.savedAnchors[chunk.Position]
+	if !saved {
+		return false
+	}
+	delete(w.savedAnchors, chunk.Position)
+
+	for _, record := range records {
+		anchorEntity := createAnchor(w.ECSWorld, record.Position, record.Strength)
+		anchor, _ := ecs.ComponentAs[*ecs.AnchorComponent](anchorEntity, ecs.AnchorComponentID)
+		anchor.Radius = record.Radius
+		anchor.MaxCharge = record.MaxCharge
+		anchor.Charge = record.Charge
+		anchor.Cracked = record.Cracked
+		if record.Cracked {
+			if render, has := ecs.ComponentAs[*ecs.RenderComponent](anchorEntity, ecs.RenderComponentID); has {
+				render.Effects.AddEffect("cracked", 1.0, ecs.EffectSourceBase)
+			}
+		}
+		w.addChunkEntities(chunk, anchorEntity.ID)
+	}
+	return true
+}
+
+// anchorRecords собирает состояние всех якорей по чанкам: существующих в мире
+// и загруженных для чанков, которые еще не генерировались
+func (w *World) anchorRecords() map[string][]AnchorRecord {
+	records := make(map[string][]AnchorRecord)
+	for pos, saved := range w.savedAnchors {
+		records[chunkKey(pos[0], pos[1])] = append([]AnchorRecord(nil), saved...)
+	}
+
+	// Чанк с сохраненным состоянием, даже пустым, не получает якорей генерации повторно
+	w.chunkMutex.RLock()
+	for pos, chunk := range w.Chunks {
+		if chunk.IsGenerated {
+			key := chunkKey(pos[0], pos[1])
+			if _, exists := records[key]; !exists {
+				records[key] = make([]AnchorRecord, 0)
+			}
+		}
+	}
+	w.chunkMutex.RUnlock()
+
+	for _, entity := range w.ECSWorld.GetEntitiesWithComponent(ecs.AnchorComponentID) {
+		anchor, has := ecs.ComponentAs[*ecs.AnchorComponent](entity, ecs.AnchorComponentID)
+		if !has {
+			continue
+		}
+		transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
+		if !has {
+			continue
+		}
+
+		key := chunkKey(int(math.Floor(transform.Position.X/ChunkSize)), int(math.Floor(transform.Position.Z/ChunkSize)))
+		records[key] = append(records[key], AnchorRecord{
+			Position:  transform.Position,
+			Radius:    anchor.Radius,
+			Strength:  anchor.Strength,
+			Charge:    anchor.Charge,
+			MaxCharge: anchor.MaxCharge,
+			Cracked:   anchor.Cracked,
+		})
+	}
+	return records
+}
+
+// SaveAnchors сохраняет состояние якорей стабильности
+func (w *World) SaveAnchors(savePath string) error {
+	if err := os.MkdirAll(savePath, os.ModePerm); err != nil {
+		return err
+	}
+
+	data, err := savefile.Marshal(w.anchorRecords())
+	if err != nil {
+		return err
+	}
+	return savefile.Write(filepath.Join(savePath, "anchors.json"), data)
+}
+
+// LoadAnchors загружает состояние якорей стабильности. Вызывается до генерации
+// первых чанков: якоря восстанавливаются при генерации своих чанков.
+// Отсутствие файла не считается ошибкой.
+func (w *World) LoadAnchors(savePath string) error {
+	data, err := savefile.Read(filepath.Join(savePath, "anchors.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var records map[string][]AnchorRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return fmt.Errorf("invalid anchors: %v", err)
+	}
+
+	w.savedAnchors = make(map[[2]int][]AnchorRecord, len(records))
+	for key, chunkRecords := range records {
+		var x, z int
+		if _, err := fmt.Sscanf(key, "%d,%d", &x, &z); err != nil {
+			return fmt.Errorf("invalid anchor chunk %q: %v", key, err)
+		}
+		w.savedAnchors[[2]int{x, z}] = chunkRecords
+	}
+	return nil
+}
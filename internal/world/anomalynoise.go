@@ -0,0 +1,142 @@
+package world
+
+import (
+	"fmt"
+	"math"
+)
+
+// AnomalyNoiseParams задает фрактальный шум, который искажает уровни аномальности
+// чанков: вместо плавного градиента от центра мира порча образует острова и прожилки.
+// Шум имеет нулевое среднее, поэтому средняя аномальность региона от него не меняется.
+type AnomalyNoiseParams struct {
+	Octaves     int     // Число октав (1 - крупные пятна, больше - рваные края и прожилки)
+	Lacunarity  float64 // Рост частоты от октавы к октаве
+	Persistence float64 // Доля амплитуды, сохраняемая следующей октавой
+	Scale       float64 // Размер самых крупных пятен (в чанках)
+	Amplitude   float64 // Наибольшее отклонение уровня аномальности от базового
+}
+
+// DefaultAnomalyNoiseParams возвращает параметры шума аномальности по умолчанию
+func DefaultAnomalyNoiseParams() AnomalyNoiseParams {
+	return AnomalyNoiseParams{
+		Octaves:     4,
+		Lacunarity:  2.0,
+		Persistence: 0.5,
+		Scale:       8.0,
+		Amplitude:   0.15,
+	}
+}
+
+// Validate проверяет корректность параметров шума
+func (p AnomalyNoiseParams) Validate() error {
+	if p.Octaves < 1 || p.Octaves > 8 {
+		return fmt.Errorf("anomaly noise octaves must be in [1, 8], got %d", p.Octaves)
+	}
+	if p.Lacunarity < 1 {
+		return fmt.Errorf("anomaly noise lacunarity must be at least 1, got %v", p.Lacunarity)
+	}
+	if p.Persistence <= 0 || p.Persistence > 1 {
+		return fmt.Errorf("anomaly noise persistence must be in (0, 1], got %v", p.Persistence)
+	}
+	if p.Scale <= 0 {
+		return fmt.Errorf("anomaly noise scale must be positive, got %v", p.Scale)
+	}
+	if p.Amplitude < 0 || p.Amplitude > 1 {
+		return fmt.Errorf("anomaly noise amplitude must be in [0, 1], got %v", p.Amplitude)
+	}
+	return nil
+}
+
+// SetAnomalyNoiseParams задает параметры шума аномальности и пересчитывает
+// уровни уже сгенерированных чанков
+func (w *World) SetAnomalyNoiseParams(params AnomalyNoiseParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	w.AnomalyNoise = params
+
+	w.chunkMutex.Lock()
+	for _, chunk := range w.Chunks {
+		w.refreshChunkAnomalyLevel(chunk)
+	}
+	w.chunkMutex.Unlock()
+	w.invalidateAnomalyFields()
+	return nil
+}
+
+// chunkAnomalyBaseline возвращает уровень аномальности чанка без распространения
+// порчи: общий уровень чанка, искаженный фрактальным шумом
+func (w *World) chunkAnomalyBaseline(chunk *Chunk) float64 {
+	baseline := calculateChunkAnomalyLevel(chunk, w.GlobalAnomalyLevel)
+	baseline += w.anomalyNoiseAt(float64(chunk.Position[0])+0.5, float64(chunk.Position[1])+0.5)
+	return math.Max(0.0, math.Min(1.0, baseline))
+}
+
+// refreshChunkAnomalyLevel пересчитывает базовый уровень аномальности чанка.
+// Уровень чанка сдвигается вместе с базовым, сохраняя расползшуюся порчу.
+func (w *World) refreshChunkAnomalyLevel(chunk *Chunk) {
+	baseline := w.chunkAnomalyBaseline(chunk)
+	chunk.AnomalyLevel = math.Max(0.0, math.Min(1.0, chunk.AnomalyLevel+baseline-chunk.anomalyBaseline))
+	chunk.anomalyBaseline = baseline
+
+	// В безопасной зоне аномальность почти нулевая
+	if w.IsInSafeZone(chunk.Position[0], chunk.Position[1]) {
+		chunk.anomalyBaseline = math.Min(chunk.anomalyBaseline, SafeZoneAnomalyLevel)
+		chunk.AnomalyLevel = math.Min(chunk.AnomalyLevel, SafeZoneAnomalyLevel)
+	}
+}
+
+// anomalyNoiseAt возвращает отклонение уровня аномальности в точке (координаты в чанках).
+// Значение определяется сидом мира и лежит в диапазоне [-Amplitude, Amplitude].
+func (w *World) anomalyNoiseAt(x, z float64) float64 {
+	params := w.AnomalyNoise
+	if params.Amplitude == 0 || params.Octaves < 1 || params.Scale <= 0 {
+		return 0
+	}
+
+	frequency := 1.0 / params.Scale
+	amplitude := 1.0
+	total, norm := 0.0, 0.0
+	for octave := 0; octave < params.Octaves; octave++ {
+		total += valueNoise(x*frequency, z*frequency, w.Seed+int64(octave)*7919) * amplitude
+		norm += amplitude
+		frequency *= params.Lacunarity
+		amplitude *= params.Persistence
+	}
+
+	return total / norm * params.Amplitude
+}
+
+// valueNoise - сглаженный решеточный шум со значениями в [-1, 1] и нулевым средним
+func valueNoise(x, z float64, seed int64) float64 {
+	x0 := math.Floor(x)
+	z0 := math.Floor(z)
+	tx := smoothstep(x - x0)
+	tz := smoothstep(z - z0)
+
+	ix, iz := int64(x0), int64(z0)
+	top := lerp(latticeValue(ix, iz, seed), latticeValue(ix+1, iz, seed), tx)
+	bottom := lerp(latticeValue(ix, iz+1, seed), latticeValue(ix+1, iz+1, seed), tx)
+	return lerp(top, bottom, tz)
+}
+
+// latticeValue возвращает псевдослучайное значение узла решетки в [-1, 1]
+func latticeValue(x, z, seed int64) float64 {
+	h := uint64(seed) ^ uint64(x)*0x9E3779B97F4A7C15 ^ uint64(z)*0xC2B2AE3D27D4EB4F
+	h ^= h >> 33
+	h *= 0xFF51AFD7ED558CCD
+	h ^= h >> 33
+	h *= 0xC4CEB9FE1A85EC53
+	h ^= h >> 33
+	return float64(h>>11)/float64(1<<53)*2 - 1
+}
+
+// smoothstep сглаживает интерполяцию между узлами решетки
+func smoothstep(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+// lerp линейно интерполирует между a и b
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
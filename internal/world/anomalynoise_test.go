@@ -0,0 +1,126 @@
+package world
+
+import (
+	"math"
+	"testing"
+)
+
+// noiseStats возвращает среднее и дисперсию шума аномальности по центрам чанков
+// квадрата size x size
+func noiseStats(w *World, size int) (mean, variance float64) {
+	values := make([]float64, 0, size*size)
+	for x := 0; x < size; x++ {
+		for z := 0; z < size; z++ {
+			value := w.anomalyNoiseAt(float64(x)+0.5, float64(z)+0.5)
+			values = append(values, value)
+			mean += value
+		}
+	}
+	mean /= float64(len(values))
+	for _, value := range values {
+		variance += (value - mean) * (value - mean)
+	}
+	return mean, variance / float64(len(values))
+}
+
+// localVariance возвращает средний квадрат разницы шума соседних чанков квадрата size x size
+func localVariance(w *World, size int) float64 {
+	total := 0.0
+	for x := 0; x < size-1; x++ {
+		for z := 0; z < size; z++ {
+			diff := w.anomalyNoiseAt(float64(x)+1.5, float64(z)+0.5) - w.anomalyNoiseAt(float64(x)+0.5, float64(z)+0.5)
+			total += diff * diff
+		}
+	}
+	return total / float64((size-1)*size)
+}
+
+func TestAnomalyNoiseOctavesChangeVarianceNotMean(t *testing.T) {
+	smooth := &World{Seed: 42, AnomalyNoise: DefaultAnomalyNoiseParams()}
+	smooth.AnomalyNoise.Octaves = 1
+	veiny := &World{Seed: 42, AnomalyNoise: DefaultAnomalyNoiseParams()}
+	veiny.AnomalyNoise.Octaves = 6
+
+	const size = 64
+	smoothMean, smoothVariance := noiseStats(smooth, size)
+	veinyMean, veinyVariance := noiseStats(veiny, size)
+	amplitude := smooth.AnomalyNoise.Amplitude
+	if math.Abs(smoothMean) > amplitude*0.25 || math.Abs(veinyMean) > amplitude*0.25 {
+		t.Errorf("mean noise %v with one octave and %v with six, want both near zero", smoothMean, veinyMean)
+	}
+	if math.Abs(veinyVariance-smoothVariance) < smoothVariance*0.2 {
+		t.Errorf("variance %v with six octaves, want it clearly apart from %v with one", veinyVariance, smoothVariance)
+	}
+
+	// Больше октав - рваные края: большая доля разброса приходится на соседние чанки
+	smoothRoughness := localVariance(smooth, size) / smoothVariance
+	veinyRoughness := localVariance(veiny, size) / veinyVariance
+	if veinyRoughness <= smoothRoughness {
+		t.Errorf("neighbor share of variance %v with six octaves, want above %v with one", veinyRoughness, smoothRoughness)
+	}
+}
+
+func TestAnomalyNoiseFollowsTheWorldSeed(t *testing.T) {
+	first := &World{Seed: 7, AnomalyNoise: DefaultAnomalyNoiseParams()}
+	second := &World{Seed: 7, AnomalyNoise: DefaultAnomalyNoiseParams()}
+	other := &World{Seed: 8, AnomalyNoise: DefaultAnomalyNoiseParams()}
+
+	differs := false
+	for x := -10.0; x < 10; x += 1.5 {
+		for z := -10.0; z < 10; z += 1.5 {
+			a := first.anomalyNoiseAt(x, z)
+			if b := second.anomalyNoiseAt(x, z); a != b {
+				t.Fatalf("noise at (%v, %v) is %v and %v in the same world", x, z, a, b)
+			}
+			if math.Abs(a) > first.AnomalyNoise.Amplitude {
+				t.Fatalf("noise %v at (%v, %v) exceeds the amplitude", a, x, z)
+			}
+			differs = differs || other.anomalyNoiseAt(x, z) != a
+		}
+	}
+	if !differs {
+		t.Errorf("worlds 7 and 8 have the same anomaly noise")
+	}
+}
+
+func TestSetAnomalyNoiseParamsValidatesAndRefreshesChunks(t *testing.T) {
+	w := &World{Seed: 3, Chunks: make(map[[2]int]*Chunk), AnomalyNoise: DefaultAnomalyNoiseParams(), anomalyFields: newAnomalyFieldCache()}
+	chunk := &Chunk{Position: [2]int{5, 2}}
+	w.Chunks[chunk.Position] = chunk
+
+	for _, modify := range []func(*AnomalyNoiseParams){
+		func(p *AnomalyNoiseParams) { p.Octaves = 0 },
+		func(p *AnomalyNoiseParams) { p.Octaves = 9 },
+		func(p *AnomalyNoiseParams) { p.Lacunarity = 0.5 },
+		func(p *AnomalyNoiseParams) { p.Persistence = 0 },
+		func(p *AnomalyNoiseParams) { p.Scale = 0 },
+		func(p *AnomalyNoiseParams) { p.Amplitude = 1.5 },
+	} {
+		params := DefaultAnomalyNoiseParams()
+		modify(&params)
+		if err := w.SetAnomalyNoiseParams(params); err == nil {
+			t.Errorf("params %+v accepted", params)
+		}
+	}
+	if w.AnomalyNoise != DefaultAnomalyNoiseParams() {
+		t.Errorf("rejected params changed the noise to %+v", w.AnomalyNoise)
+	}
+
+	// Без шума уровень чанка равен его уровню без распространения порчи
+	flat := DefaultAnomalyNoiseParams()
+	flat.Amplitude = 0
+	if err := w.SetAnomalyNoiseParams(flat); err != nil {
+		t.Fatalf("SetAnomalyNoiseParams: %v", err)
+	}
+	if want := calculateChunkAnomalyLevel(chunk, w.GlobalAnomalyLevel); chunk.AnomalyLevel != want {
+		t.Errorf("chunk anomaly %v without noise, want %v", chunk.AnomalyLevel, want)
+	}
+
+	// Новый шум сдвигает уровень уже сгенерированного чанка
+	if err := w.SetAnomalyNoiseParams(DefaultAnomalyNoiseParams()); err != nil {
+		t.Fatalf("SetAnomalyNoiseParams: %v", err)
+	}
+	if want := w.chunkAnomalyBaseline(chunk); chunk.AnomalyLevel != want {
+		t.Errorf("chunk anomaly %v with noise, want %v", chunk.AnomalyLevel, want)
+	}
+}
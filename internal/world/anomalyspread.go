@@ -0,0 +1,183 @@
+package world
+
+import (
+	"fmt"
+	"math"
+)
+
+// Распространение аномальности: раз в игровой час уровни известных чанков
+// выравниваются с соседями, растут от источников порчи внутри чанка и без
+// источников медленно возвращаются к базовому уровню
+const (
+	AnomalySpreadInterval = 1000.0 / 24.0 // Раз в игровой час (сутки длятся ~1000 секунд)
+	AnomalySpreadRate     = 0.5           // Доля разницы с соседями, выравниваемая за шаг при проводимости 1
+	AnomalySourceRate     = 0.05          // Рост уровня за шаг от источника силы 1
+	AnomalyDecayRate      = 0.02          // Доля отклонения от базового уровня, теряемая за шаг без источников
+	AnomalySourceLevel    = 0.5           // Области с такой аномальностью сами питают порчу
+
+	// Ключ таблицы проводимости для чанков с реками и озерами
+	WaterConductivity = "water"
+)
+
+// neighborOffsets - смещения четырех соседей чанка
+var neighborOffsets = [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+
+// DefaultAnomalyConductivity возвращает проводимость аномальности по типам биомов (0-1).
+// Пустота передает порчу быстрее всего, вода ее останавливает.
+func DefaultAnomalyConductivity() map[string]float64 {
+	return map[string]float64{
+		"taiga":           0.5,
+		"marsh":           0.3,
+		"rocky":           0.4,
+		"distorted":       0.8,
+		"void":            1.0,
+		WaterConductivity: 0.0,
+	}
+}
+
+// SetAnomalyConductivity задает проводимость аномальности по типам биомов.
+// Биомы, которых нет в таблице, проводят порчу как тайга.
+func (w *World) SetAnomalyConductivity(conductivity map[string]float64) error {
+	for biome, value := range conductivity {
+		if value < 0 || value > 1 {
+			return fmt.Errorf("anomaly conductivity of %q must be in [0, 1], got %v", biome, value)
+		}
+	}
+	w.AnomalyConductivity = conductivity
+	return nil
+}
+
+// conductivityOf возвращает проводимость чанка: реки и озера ослабляют ее до
+// проводимости воды
+func (w *World) conductivityOf(chunk *Chunk) float64 {
+	conductivity, exists := w.AnomalyConductivity[chunk.BiomeType]
+	if !exists {
+		conductivity = w.AnomalyConductivity["taiga"]
+	}
+
+	if chunk.Terrain != nil {
+		for _, feature := range chunk.Terrain.Features {
+			if feature.Type == "river" || feature.Type == "lake" {
+				if water, exists := w.AnomalyConductivity[WaterConductivity]; exists {
+					conductivity = math.Min(conductivity, water)
+				}
+				break
+			}
+		}
+	}
+	return conductivity
+}
+
+// advanceAnomalySpread отсчитывает игровые часы и распространяет аномальность
+func (w *World) advanceAnomalySpread(deltaTime float64) {
+	w.anomalySpreadTimer += deltaTime
+	if w.anomalySpreadTimer < AnomalySpreadInterval {
+		return
+	}
+	w.anomalySpreadTimer -= AnomalySpreadInterval
+
+	w.spreadAnomaly()
+}
+
+// spreadAnomaly выполняет один шаг распространения по всем известным чанкам.
+// Обмен между соседями симметричен и определяется меньшей из их проводимостей,
+// поэтому сам по себе не меняет суммарную аномальность.
+func (w *World) spreadAnomaly() {
+	// Источники собираются до блокировки чанков: они читают менеджер метаморфоз
+	sources := w.anomalySources()
+
+	w.chunkMutex.Lock()
+	defer w.chunkMutex.Unlock()
+
+	levels := make(map[[2]int]float64, len(w.Chunks))
+	conductivity := make(map[[2]int]float64, len(w.Chunks))
+	for pos, chunk := range w.Chunks {
+		levels[pos] = chunk.AnomalyLevel
+		conductivity[pos] = w.conductivityOf(chunk)
+	}
+
+	for pos, chunk := range w.Chunks {
+		level := levels[pos]
+
+		// Выравнивание с соседями
+		flow := 0.0
+		for _, offset := range neighborOffsets {
+			neighborPos := [2]int{pos[0] + offset[0], pos[1] + offset[1]}
+			neighborLevel, exists := levels[neighborPos]
+			if !exists {
+				continue
+			}
+			flow += math.Min(conductivity[pos], conductivity[neighborPos]) * (neighborLevel - level)
+		}
+		level += AnomalySpreadRate * flow / float64(len(neighborOffsets))
+
+		// Источники питают порчу, без них она оседает к базовому уровню
+		if source := sources[pos]; source > 0 {
+			level += AnomalySourceRate * source * (1.0 - level)
+		} else {
+			level += AnomalyDecayRate * (chunk.anomalyBaseline - level)
+		}
+
+		chunk.AnomalyLevel = math.Max(0.0, math.Min(1.0, level))
+		if w.IsInSafeZone(pos[0], pos[1]) {
+			chunk.AnomalyLevel = math.Min(chunk.AnomalyLevel, SafeZoneAnomalyLevel)
+		}
+	}
+
+	w.invalidateAnomalyFields()
+}
+
+// anomalySources возвращает силу источников порчи по чанкам: активные эффекты
+// метаморфоз с областью, центр которой лежит в чанке, и области с аномальностью
+// не ниже AnomalySourceLevel
+func (w *World) anomalySources() map[[2]int]float64 {
+	sources := make(map[[2]int]float64)
+	if w.MetamorphManager == nil {
+		return sources
+	}
+
+	for _, effect := range w.MetamorphManager.GetActiveEffects() {
+		if effect.AffectedArea == nil {
+			continue
+		}
+		center := effect.AffectedArea.Center
+		pos := [2]int{int(math.Floor(center.X / ChunkSize)), int(math.Floor(center.Z / ChunkSize))}
+		sources[pos] += effect.Intensity
+	}
+
+	for id, level := range w.MetamorphManager.GetLocalAnomalyLevels() {
+		if level < AnomalySourceLevel {
+			continue
+		}
+		if x, z, ok := parseAreaID(id); ok {
+			sources[[2]int{x, z}] += level
+		}
+	}
+
+	return sources
+}
+
+// neighborAnomalyLevel возвращает средний уровень аномальности известных соседей,
+// взвешенный проводимостью границы с чанком. Так новый чанк продолжает порчу,
+// успевшую расползтись к краю известного мира. Второе значение ложно, если
+// соседей нет или ни одна граница не проводит порчу.
+// Вызывается при захваченном chunkMutex.
+func (w *World) neighborAnomalyLevel(chunk *Chunk) (float64, bool) {
+	own := w.conductivityOf(chunk)
+
+	level, weight := 0.0, 0.0
+	for _, delta := range neighborOffsets {
+		neighbor, exists := w.Chunks[[2]int{chunk.Position[0] + delta[0], chunk.Position[1] + delta[1]}]
+		if !exists {
+			continue
+		}
+		conductivity := math.Min(own, w.conductivityOf(neighbor))
+		level += conductivity * neighbor.AnomalyLevel
+		weight += conductivity
+	}
+
+	if weight == 0 {
+		return 0, false
+	}
+	return level / weight, true
+}
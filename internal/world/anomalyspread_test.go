@@ -0,0 +1,88 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"echo-taiga/internal/world/terrain"
+)
+
+// newSpreadGrid создает мир из ряда чанков тайги с заданными уровнями,
+// базовый уровень каждого чанка равен его начальному
+func newSpreadGrid(levels ...float64) *World {
+	w := &World{Chunks: make(map[[2]int]*Chunk), AnomalyConductivity: DefaultAnomalyConductivity(), anomalyFields: newAnomalyFieldCache()}
+	for x, level := range levels {
+		pos := [2]int{x, 0}
+		w.Chunks[pos] = &Chunk{Position: pos, BiomeType: "taiga", AnomalyLevel: level, anomalyBaseline: level}
+	}
+	return w
+}
+
+// addRiver прокладывает реку через чанк
+func addRiver(chunk *Chunk) {
+	chunk.Terrain = terrain.NewTerrainData(ChunkSize, ChunkSize)
+	chunk.Terrain.Features = append(chunk.Terrain.Features, terrain.TerrainFeature{Type: "river"})
+}
+
+func totalAnomaly(w *World) float64 {
+	total := 0.0
+	for _, chunk := range w.Chunks {
+		total += chunk.AnomalyLevel
+	}
+	return total
+}
+
+func TestAnomalySpreadIsConservative(t *testing.T) {
+	w := newSpreadGrid(0.9, 0.1, 0.1, 0.1)
+	// Без оседания к базовому уровню обмен между соседями не меняет сумму
+	for _, chunk := range w.Chunks {
+		chunk.anomalyBaseline = chunk.AnomalyLevel
+	}
+	before := totalAnomaly(w)
+
+	w.spreadAnomaly()
+
+	if w.Chunks[[2]int{1, 0}].AnomalyLevel <= 0.1 {
+		t.Errorf("neighbor of the hot chunk stayed at %v", w.Chunks[[2]int{1, 0}].AnomalyLevel)
+	}
+	// Оседание к базовому уровню тянет сумму обратно лишь на долю AnomalyDecayRate
+	if after := totalAnomaly(w); math.Abs(after-before) > AnomalyDecayRate*before {
+		t.Errorf("total anomaly changed from %v to %v", before, after)
+	}
+}
+
+func TestWaterBlocksAnomalySpread(t *testing.T) {
+	w := newSpreadGrid(0.9, 0.1, 0.1)
+	addRiver(w.Chunks[[2]int{1, 0}])
+
+	for i := 0; i < 10; i++ {
+		w.spreadAnomaly()
+	}
+
+	if level := w.Chunks[[2]int{1, 0}].AnomalyLevel; level > 0.1+1e-9 {
+		t.Errorf("river chunk level = %v, want it untouched by its neighbor", level)
+	}
+	if level := w.Chunks[[2]int{2, 0}].AnomalyLevel; level > 0.1+1e-9 {
+		t.Errorf("chunk behind the river level = %v, want 0.1", level)
+	}
+}
+
+func TestNewChunkStartsFromItsNeighbors(t *testing.T) {
+	w := newSpreadGrid(0.8, 0.4)
+	chunk := &Chunk{Position: [2]int{0, 1}, BiomeType: "taiga"}
+
+	level, ok := w.neighborAnomalyLevel(chunk)
+	if !ok || math.Abs(level-0.8) > 1e-9 {
+		t.Errorf("level next to (0,0) = %v, %v, want 0.8", level, ok)
+	}
+
+	// За водой соседей нет, чанк начинает с базового уровня
+	addRiver(chunk)
+	if _, ok := w.neighborAnomalyLevel(chunk); ok {
+		t.Errorf("river chunk inherited its neighbors' level")
+	}
+
+	if _, ok := w.neighborAnomalyLevel(&Chunk{Position: [2]int{10, 10}, BiomeType: "taiga"}); ok {
+		t.Errorf("isolated chunk reported known neighbors")
+	}
+}
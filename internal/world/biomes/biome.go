@@ -0,0 +1,38 @@
+package biomes
+
+// Biome описывает тип биома и то, как он влияет на порождаемые в нем сущности
+type Biome struct {
+	Type BiomeType
+	Name string
+
+	// StabilityModifier умножает метаморфную стабильность сущностей биома:
+	// в искаженном или мертвом лесу (ниже 1) они легче поддаются метаморфозам.
+	// Ноль означает, что множитель не задан.
+	StabilityModifier float64
+}
+
+// defaultStabilityModifiers - множители стабильности стандартных биомов
+var defaultStabilityModifiers = map[BiomeType]float64{
+	"taiga":     1.0,
+	"marsh":     0.9,
+	"rocky":     1.1,
+	"distorted": 0.5, // Искаженный лес порождает нестабильные сущности
+	"void":      0.3,
+}
+
+// NewBiome создает биом с множителем стабильности по умолчанию для его типа
+func NewBiome(biomeType BiomeType, name string) *Biome {
+	return &Biome{
+		Type:              biomeType,
+		Name:              name,
+		StabilityModifier: defaultStabilityModifiers[biomeType],
+	}
+}
+
+// EntityStability возвращает множитель стабильности сущностей биома (1.0, если он не задан)
+func (b *Biome) EntityStability() float64 {
+	if b == nil || b.StabilityModifier <= 0 {
+		return 1.0
+	}
+	return b.StabilityModifier
+}
@@ -262,6 +262,11 @@ func simplexNoise(x, y float64, seed int64) float64 {
 	return math.Sin(x+float64(seed)*0.1)*math.Cos(y+float64(seed)*0.1)*0.5 + 0.5
 }
 
+// RegisterBiome заменяет описание биома, например биомом из пакета контента
+func (bm *BiomeMap) RegisterBiome(biome *Biome) {
+	bm.biomeManager.RegisterBiome(biome)
+}
+
 // GetBiome возвращает подробную информацию о биоме указанного типа
 func (bm *BiomeMap) GetBiome(biomeType BiomeType) *Biome {
 	return bm.biomeManager.GetBiome(biomeType)
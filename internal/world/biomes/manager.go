@@ -0,0 +1,79 @@
+package biomes
+
+// BiomeType - идентификатор типа биома
+type BiomeType string
+
+// Стандартные типы биомов
+const (
+	BiomeTaiga     BiomeType = "taiga"
+	BiomeMarsh     BiomeType = "marsh"
+	BiomeRocky     BiomeType = "rocky"
+	BiomeDistorted BiomeType = "distorted"
+	BiomeVoid      BiomeType = "void"
+)
+
+// Пороги шума, при которых вместо тайги появляются другие биомы
+const (
+	voidAnomalyThreshold      = 0.9
+	distortedAnomalyThreshold = 0.8
+	rockyElevationThreshold   = 0.75
+	marshHumidityThreshold    = 0.7
+)
+
+// IsStandardBiome сообщает, выбирает ли генерация биом этого типа
+func IsStandardBiome(biomeType BiomeType) bool {
+	switch biomeType {
+	case BiomeTaiga, BiomeMarsh, BiomeRocky, BiomeDistorted, BiomeVoid:
+		return true
+	}
+	return false
+}
+
+// BiomeManager хранит описания биомов и выбирает биом по значениям шума
+type BiomeManager struct {
+	biomes map[BiomeType]*Biome
+	seed   int64
+}
+
+// NewBiomeManager создает менеджер со стандартными биомами
+func NewBiomeManager(seed int64) *BiomeManager {
+	bm := &BiomeManager{
+		biomes: make(map[BiomeType]*Biome),
+		seed:   seed,
+	}
+
+	bm.RegisterBiome(NewBiome(BiomeTaiga, "Тайга"))
+	bm.RegisterBiome(NewBiome(BiomeMarsh, "Болото"))
+	bm.RegisterBiome(NewBiome(BiomeRocky, "Скалы"))
+	bm.RegisterBiome(NewBiome(BiomeDistorted, "Искаженный лес"))
+	bm.RegisterBiome(NewBiome(BiomeVoid, "Пустота"))
+
+	return bm
+}
+
+// RegisterBiome добавляет биом или заменяет биом того же типа
+func (bm *BiomeManager) RegisterBiome(biome *Biome) {
+	bm.biomes[biome.Type] = biome
+}
+
+// GetBiome возвращает биом указанного типа (nil, если он не зарегистрирован)
+func (bm *BiomeManager) GetBiome(biomeType BiomeType) *Biome {
+	return bm.biomes[biomeType]
+}
+
+// GetBiomeAtPosition выбирает биом по значениям шума в точке: сильная аномалия
+// искажает лес, высокие места становятся скалами, влажные - болотами
+func (bm *BiomeManager) GetBiomeAtPosition(x, z float64, noiseValues map[string]float64) BiomeType {
+	switch {
+	case noiseValues["anomaly"] > voidAnomalyThreshold:
+		return BiomeVoid
+	case noiseValues["anomaly"] > distortedAnomalyThreshold:
+		return BiomeDistorted
+	case noiseValues["elevation"] > rockyElevationThreshold:
+		return BiomeRocky
+	case noiseValues["humidity"] > marshHumidityThreshold:
+		return BiomeMarsh
+	default:
+		return BiomeTaiga
+	}
+}
@@ -0,0 +1,15 @@
+package world
+
+import "math"
+
+// SetEntityDensityScale задает долю обычных объектов (деревьев, камней, кустов)
+// и животных, создаваемых при генерации чанков (0-1). Особые места и символы
+// от плотности не зависят. Действует на чанки, сгенерированные после вызова.
+func (w *World) SetEntityDensityScale(scale float64) {
+	w.EntityDensityScale = math.Max(0.0, math.Min(1.0, scale))
+}
+
+// scaledEntityCount применяет плотность к числу объектов одного вида в чанке
+func (w *World) scaledEntityCount(count int) int {
+	return int(math.Round(float64(count) * w.EntityDensityScale))
+}
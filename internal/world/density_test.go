@@ -0,0 +1,77 @@
+package world
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// taigaTrees заселяет таежный чанк (3,0) при заданной плотности и возвращает позиции деревьев
+func taigaTrees(scale float64) []ecs.Vector3 {
+	ecsWorld := ecs.NewWorld()
+	w := NewWorld(1, ecsWorld, metamorphosis.NewMetamorphosisManager(ecsWorld, ""))
+	w.SetEntityDensityScale(scale)
+
+	chunk := &Chunk{Position: [2]int{3, 0}, BiomeType: "taiga", IsGenerated: true}
+	chunk.Terrain = w.TerrainGenerator.GenerateChunkTerrain(3, 0, ChunkSize)
+	w.populateChunkWithEntities(chunk)
+
+	trees := make([]ecs.Vector3, 0)
+	for _, id := range chunk.Entities {
+		entity, exists := ecsWorld.GetEntity(id)
+		if !exists || !entity.HasTag(TagTree) {
+			continue
+		}
+		transform, _ := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
+		trees = append(trees, transform.Position)
+	}
+	return trees
+}
+
+func TestHalfDensityHalvesTaigaTrees(t *testing.T) {
+	full := taigaTrees(1.0)
+	half := taigaTrees(0.5)
+
+	if len(full) < 10 {
+		t.Fatalf("full density grew %d trees, want at least 10", len(full))
+	}
+	if diff := 2*len(half) - len(full); diff < -1 || diff > 1 {
+		t.Errorf("half density grew %d trees of %d, want about half", len(half), len(full))
+	}
+
+	// Оставшиеся деревья стоят там же, где при полной плотности
+	for i, position := range half {
+		if position != full[i] {
+			t.Errorf("tree %d at %v with half density, want %v as at full density", i, position, full[i])
+		}
+	}
+}
+
+func TestDensityIsDeterministicForSeed(t *testing.T) {
+	first, second := taigaTrees(0.5), taigaTrees(0.5)
+	if len(first) != len(second) {
+		t.Fatalf("same seed grew %d and %d trees", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("tree %d at %v and %v for the same seed", i, first[i], second[i])
+		}
+	}
+
+	if trees := taigaTrees(0); len(trees) != 0 {
+		t.Errorf("zero density grew %d trees", len(trees))
+	}
+}
+
+func TestSetEntityDensityScaleClamps(t *testing.T) {
+	w := &World{}
+	w.SetEntityDensityScale(1.5)
+	if w.EntityDensityScale != 1 {
+		t.Errorf("scale 1.5 stored as %v, want 1", w.EntityDensityScale)
+	}
+	w.SetEntityDensityScale(-0.2)
+	if w.EntityDensityScale != 0 {
+		t.Errorf("scale -0.2 stored as %v, want 0", w.EntityDensityScale)
+	}
+}
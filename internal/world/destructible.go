@@ -0,0 +1,46 @@
+package world
+
+import (
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// destructibleTags - теги объектов, которые можно сломать
+var destructibleTags = []string{TagTree, TagBush}
+
+// BreakNearestObject ломает ближайшее к точке целое дерево или куст в радиусе:
+// объект получает сломанную модель и тег TagBroken. Возвращает ID сломанного объекта.
+func (w *World) BreakNearestObject(position ecs.Vector3, radius float64) (ecs.EntityID, bool) {
+	var nearest *ecs.Entity
+	nearestDistance := math.Inf(1)
+
+	for _, tag := range destructibleTags {
+		for _, entity := range w.ECSWorld.GetEntitiesWithTag(tag) {
+			if entity.HasTag(TagBroken) {
+				continue
+			}
+			transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
+			if !has {
+				continue
+			}
+
+			distance := transform.Position.Distance(position)
+			if distance <= radius && distance < nearestDistance {
+				nearest = entity
+				nearestDistance = distance
+			}
+		}
+	}
+
+	if nearest == nil {
+		return "", false
+	}
+
+	if render, has := ecs.ComponentAs[*ecs.RenderComponent](nearest, ecs.RenderComponentID); has {
+		render.ModelID += "_broken"
+	}
+	nearest.AddTag(TagBroken)
+
+	return nearest.ID, true
+}
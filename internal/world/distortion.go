@@ -0,0 +1,82 @@
+package world
+
+import "echo-taiga/internal/engine/ecs"
+
+// distortChunkTerrain искажает террейн чанка от имени эффекта. Повторное применение
+// того же эффекта (например, после деактивации и реактивации чанка) игнорируется.
+func distortChunkTerrain(chunk *Chunk, effectID string, intensity float64) {
+	if chunk.Terrain == nil {
+		return
+	}
+
+	if chunk.Distortions == nil {
+		chunk.Distortions = make(map[string][][]float64)
+	}
+	if _, applied := chunk.Distortions[effectID]; applied {
+		return
+	}
+
+	chunk.Distortions[effectID] = chunk.Terrain.ApplyDistortion(intensity)
+}
+
+// revertChunkEffect отменяет искажение террейна, внесенное эффектом, и убирает
+// эффект из списка примененных к чанку
+func revertChunkEffect(chunk *Chunk, effectID string) {
+	if delta, applied := chunk.Distortions[effectID]; applied {
+		if chunk.Terrain != nil {
+			chunk.Terrain.RevertDistortion(delta)
+		}
+		delete(chunk.Distortions, effectID)
+	}
+
+	chunk.MetamorphEffects = removeString(chunk.MetamorphEffects, effectID)
+}
+
+// revertEffectEverywhere откладывает отмену последствий снятого эффекта до
+// следующего обновления мира: менеджер метаморфоз сообщает о снятии при
+// захваченном мьютексе, а ActivateChunk берет блокировки в обратном порядке
+func (w *World) revertEffectEverywhere(effectID string) {
+	w.revertMutex.Lock()
+	defer w.revertMutex.Unlock()
+
+	w.pendingReverts = append(w.pendingReverts, effectID)
+}
+
+// applyPendingReverts отменяет последствия снятых эффектов во всех чанках
+func (w *World) applyPendingReverts() {
+	w.revertMutex.Lock()
+	effectIDs := w.pendingReverts
+	w.pendingReverts = nil
+	w.revertMutex.Unlock()
+
+	if len(effectIDs) == 0 {
+		return
+	}
+
+	w.chunkMutex.Lock()
+	defer w.chunkMutex.Unlock()
+
+	for _, effectID := range effectIDs {
+		for _, chunk := range w.Chunks {
+			revertChunkEffect(chunk, effectID)
+			w.revertChunkTints(chunk, effectID)
+		}
+	}
+}
+
+// revertChunkTints возвращает сущностям чанка цвет, который был до смены цвета эффектом
+func (w *World) revertChunkTints(chunk *Chunk, effectID string) {
+	if w.ECSWorld == nil {
+		return
+	}
+
+	for _, entityID := range chunk.Entities {
+		entity, exists := w.ECSWorld.GetEntity(entityID)
+		if !exists {
+			continue
+		}
+		if render, has := ecs.ComponentAs[*ecs.RenderComponent](entity, ecs.RenderComponentID); has {
+			render.RemoveTint(effectID)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/world/terrain"
+)
+
+func TestRemovedEffectRevertsOnNextUpdate(t *testing.T) {
+	chunk := &Chunk{Position: [2]int{1, 0}, Terrain: terrain.NewTerrainData(ChunkSize, ChunkSize)}
+	original := chunk.Terrain.HeightMap[5][5]
+	distortChunkTerrain(chunk, "quake_1", 1.0)
+	chunk.MetamorphEffects = []string{"quake_1"}
+
+	w := &World{Chunks: map[[2]int]*Chunk{chunk.Position: chunk}}
+
+	// Уведомление о снятии приходит при захваченном мьютексе менеджера;
+	// чанки при этом блокировать нельзя
+	w.chunkMutex.Lock()
+	w.revertEffectEverywhere("quake_1")
+	w.chunkMutex.Unlock()
+	if len(chunk.MetamorphEffects) != 1 {
+		t.Fatalf("effect reverted inside the removal callback")
+	}
+
+	w.applyPendingReverts()
+	if len(chunk.MetamorphEffects) != 0 || len(chunk.Distortions) != 0 {
+		t.Errorf("after applyPendingReverts effects = %v, distortions = %d", chunk.MetamorphEffects, len(chunk.Distortions))
+	}
+	if chunk.Terrain.HeightMap[5][5] != original {
+		t.Errorf("height = %v, want %v restored", chunk.Terrain.HeightMap[5][5], original)
+	}
+}
+
+func TestReactivatedChunkIsNotDistortedAgain(t *testing.T) {
+	ecsWorld := ecs.NewWorld()
+	mm := metamorphosis.NewMetamorphosisManager(ecsWorld, "")
+	mm.SetTransformationPhase(3)
+	mm.RegisterEffectTemplate(&metamorphosis.MetamorphEffect{
+		ID:        "quake",
+		Name:      "Quake",
+		Order:     metamorphosis.OrderSecond,
+		Category:  "environment",
+		Intensity: 1.0,
+		Duration:  time.Hour,
+	})
+	if _, err := mm.RequestEffect(metamorphosis.EffectRequest{
+		Order:      metamorphosis.OrderSecond,
+		Categories: []string{"environment"},
+		Center:     ecs.Vector3{X: ChunkSize * 1.5, Z: ChunkSize / 2},
+		Radius:     ChunkSize,
+		Intensity:  1.0,
+	}); err != nil {
+		t.Fatalf("RequestEffect: %v", err)
+	}
+
+	w := NewWorld(1, ecsWorld, mm)
+	w.ActivateChunk(1, 0)
+	chunk := w.Chunks[[2]int{1, 0}]
+	if len(chunk.MetamorphEffects) != 1 || len(chunk.Distortions) != 1 {
+		t.Fatalf("activated chunk has effects %v and %d distortions, want the quake once", chunk.MetamorphEffects, len(chunk.Distortions))
+	}
+	distorted := make([][]float64, len(chunk.Terrain.HeightMap))
+	for y, row := range chunk.Terrain.HeightMap {
+		distorted[y] = append([]float64(nil), row...)
+	}
+
+	// Каждая реактивация видит эффект в chunk.MetamorphEffects и не искажает рельеф заново
+	for cycle := 1; cycle <= 3; cycle++ {
+		w.DeactivateChunk(1, 0)
+		w.ActivateChunk(1, 0)
+
+		for y, row := range chunk.Terrain.HeightMap {
+			for x, height := range row {
+				if height != distorted[y][x] {
+					t.Fatalf("cycle %d: height at (%d,%d) = %v, want %v unchanged", cycle, x, y, height, distorted[y][x])
+				}
+			}
+		}
+		if len(chunk.MetamorphEffects) != 1 || len(chunk.Distortions) != 1 {
+			t.Errorf("cycle %d: effects %v and %d distortions, want the quake once", cycle, chunk.MetamorphEffects, len(chunk.Distortions))
+		}
+	}
+}
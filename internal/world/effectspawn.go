@@ -0,0 +1,102 @@
+package world
+
+import (
+	"echo-taiga/internal/engine/ecs"
+)
+
+// effectCreatureAnomaly - уровень аномальности, с которым эффекты порождают существ каждого типа
+var effectCreatureAnomaly = map[string]float64{
+	"shadow":    0.3,
+	"wraith":    0.6,
+	"nightmare": 0.9,
+}
+
+// SpawnEffectEntity создает сущность, порожденную эффектом метаморфозы, на
+// поверхности в указанной точке (реализует metamorphosis.EntitySpawner).
+// Эффекты порождают ночных существ (shadow, wraith, nightmare) и аномалии
+// (minor, medium, major); для других типов возвращает nil. Точка за пределами
+// загруженных чанков сдвигается к ближайшему из них; без загруженных чанков
+// сущность не создается.
+func (w *World) SpawnEffectEntity(entityType string, position ecs.Vector3) *ecs.Entity {
+	return w.addEffectEntity(position, func(position ecs.Vector3) *ecs.Entity {
+		switch {
+		case containsString(creatureSpeciesTags, entityType):
+			return newCreature(position, entityType, effectCreatureAnomaly[entityType])
+		case containsString(anomalyTypeTags, entityType):
+			return newAnomaly(position, entityType)
+		default:
+			return nil
+		}
+	})
+}
+
+// addEffectEntity создает одну сущность эффекта (см. addEffectEntities)
+func (w *World) addEffectEntity(position ecs.Vector3, build func(position ecs.Vector3) *ecs.Entity) *ecs.Entity {
+	entities := w.addEffectEntities([]ecs.Vector3{position}, build)
+	if len(entities) == 0 {
+		return nil
+	}
+	return entities[0]
+}
+
+// effectPlacement - сущность эффекта и чанк, в который она попадет
+type effectPlacement struct {
+	chunk    *Chunk
+	position [2]int
+	entity   *ecs.Entity
+}
+
+// addEffectEntities создает сущности эффекта на поверхности в указанных точках
+// и добавляет их в мир одной пачкой, а затем в списки их чанков. Точки за
+// пределами загруженных чанков сдвигаются к ближайшему из них; без загруженных
+// чанков или если build вернул nil, сущность не создается. Чанки при этом не
+// генерируются. Возвращает созданные сущности в порядке точек.
+func (w *World) addEffectEntities(positions []ecs.Vector3, build func(position ecs.Vector3) *ecs.Entity) []*ecs.Entity {
+	placements := make([]effectPlacement, 0, len(positions))
+	for _, position := range positions {
+		position = w.ClampToLoaded(position)
+		pos := chunkPosition(position)
+
+		w.chunkMutex.RLock()
+		chunk, loaded := w.ActiveChunks[pos]
+		w.chunkMutex.RUnlock()
+		if !loaded {
+			continue
+		}
+
+		if chunk.Terrain != nil {
+			position.Y = chunk.Terrain.GetHeightAt(position.X-float64(chunk.Position[0]*ChunkSize), position.Z-float64(chunk.Position[1]*ChunkSize))
+		}
+
+		if entity := build(position); entity != nil {
+			placements = append(placements, effectPlacement{chunk: chunk, position: pos, entity: entity})
+		}
+	}
+	if len(placements) == 0 {
+		return nil
+	}
+
+	w.chunkMutex.Lock()
+	defer w.chunkMutex.Unlock()
+
+	// Чанк мог выгрузиться, пока строились сущности
+	batch := make([]*ecs.Entity, 0, len(placements))
+	chunks := make([]*Chunk, 0, len(placements))
+	for _, placement := range placements {
+		if w.ActiveChunks[placement.position] == placement.chunk {
+			batch = append(batch, placement.entity)
+			chunks = append(chunks, placement.chunk)
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	ids := w.ECSWorld.AddEntities(batch)
+	for i, chunk := range chunks {
+		w.addChunkEntities(chunk, ids[i])
+	}
+	w.bumpGeneration()
+
+	return batch
+}
@@ -0,0 +1,120 @@
+package world
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// FaunaSpecies описывает вид животного: как часто он встречается и как себя ведет
+type FaunaSpecies struct {
+	Weight         float64 // Относительная частота появления среди фауны биома
+	Predator       bool    // Хищник или добыча
+	AIType         string  // Поведение ИИ (aggressive, neutral, scared, ...)
+	DetectionRange float64 // Дальность обнаружения игрока
+	Group          string  // Вид группы ("pack", "herd"); пусто - вид живет поодиночке
+	MaxGroupSize   int     // Наибольший размер группы при появлении
+}
+
+// defaultFauna - животные биомов, не объявивших свою фауну
+var defaultFauna = []string{"deer", "wolf", "rabbit", "fox"}
+
+// defaultFaunaSpecies - вид, не описанный в таблице: мирное животное
+var defaultFaunaSpecies = FaunaSpecies{Weight: 1.0, AIType: "neutral", DetectionRange: 10.0}
+
+// DefaultBiomeFauna возвращает виды животных, обитающих в каждом биоме
+func DefaultBiomeFauna() map[string][]string {
+	return map[string][]string{
+		"taiga": {"deer", "wolf", "rabbit", "fox"},
+		"marsh": {"frog", "snake", "fish"},
+		"rocky": {"goat", "eagle", "wolf"},
+	}
+}
+
+// DefaultFaunaSpecies возвращает описания видов животных
+func DefaultFaunaSpecies() map[string]FaunaSpecies {
+	return map[string]FaunaSpecies{
+		"deer":   {Weight: 3.0, AIType: "scared", DetectionRange: 14.0, Group: "herd", MaxGroupSize: 5},
+		"rabbit": {Weight: 4.0, AIType: "scared", DetectionRange: 12.0},
+		"wolf":   {Weight: 1.0, Predator: true, AIType: "aggressive", DetectionRange: 15.0, Group: "pack", MaxGroupSize: 4},
+		"fox":    {Weight: 1.5, Predator: true, AIType: "neutral", DetectionRange: 12.0},
+		"frog":   {Weight: 4.0, AIType: "scared", DetectionRange: 6.0},
+		"fish":   {Weight: 3.0, AIType: "passive", DetectionRange: 5.0},
+		"snake":  {Weight: 1.0, Predator: true, AIType: "aggressive", DetectionRange: 8.0},
+		"goat":   {Weight: 3.0, AIType: "scared", DetectionRange: 16.0},
+		"eagle":  {Weight: 1.0, Predator: true, AIType: "neutral", DetectionRange: 25.0},
+	}
+}
+
+// faunaSpecies возвращает описание вида (мирное животное для неизвестных видов)
+func (w *World) faunaSpecies(species string) FaunaSpecies {
+	if traits, exists := w.FaunaSpecies[species]; exists {
+		return traits
+	}
+	return defaultFaunaSpecies
+}
+
+// faunaTypes возвращает виды животных, объявленные биомом
+func (w *World) faunaTypes(biomeType string) []string {
+	if types := w.BiomeFauna[biomeType]; len(types) > 0 {
+		return types
+	}
+	return defaultFauna
+}
+
+// chooseFauna выбирает вид животного из фауны биома с учетом частоты видов
+func (w *World) chooseFauna(biomeType string, r *rand.Rand) string {
+	types := w.faunaTypes(biomeType)
+
+	total := 0.0
+	for _, species := range types {
+		total += w.faunaSpecies(species).Weight
+	}
+	if total <= 0 {
+		return types[r.Intn(len(types))]
+	}
+
+	roll := r.Float64() * total
+	for _, species := range types {
+		roll -= w.faunaSpecies(species).Weight
+		if roll < 0 {
+			return species
+		}
+	}
+	return types[len(types)-1]
+}
+
+// groupSpread - на каком расстоянии от вожака появляются остальные члены группы
+const groupSpread = 6.0
+
+// newAnimalGroup создает стаю или стадо вокруг точки появления. Идентификатор
+// группы выводится из чанка и номера животного, поэтому после перезагрузки
+// заново сгенерированный чанк получает те же группы. Размер и расстановка группы
+// берутся из собственного потока группы, чтобы не сдвигать генерацию остального чанка.
+func (w *World) newAnimalGroup(chunk *Chunk, index int, leader ecs.Vector3, species string, traits FaunaSpecies, stability float64) []*ecs.Entity {
+	groupID := fmt.Sprintf("%s_%s_%d_%d_%d", species, traits.Group, chunk.Position[0], chunk.Position[1], index)
+	r := engine.NewRandStream(w.Seed, groupID)
+	size := metamorphosis.MinGroupSize + r.Intn(traits.MaxGroupSize-metamorphosis.MinGroupSize+1)
+
+	worldX := float64(chunk.Position[0] * ChunkSize)
+	worldZ := float64(chunk.Position[1] * ChunkSize)
+
+	members := make([]*ecs.Entity, 0, size)
+	for i := 0; i < size; i++ {
+		position := leader
+		if i > 0 {
+			position.X = math.Max(worldX, math.Min(worldX+ChunkSize, leader.X+(r.Float64()*2-1)*groupSpread))
+			position.Z = math.Max(worldZ, math.Min(worldZ+ChunkSize, leader.Z+(r.Float64()*2-1)*groupSpread))
+			position.Y = chunk.Terrain.GetHeightAt(position.X-worldX, position.Z-worldZ)
+		}
+
+		animal := newAnimal(position, species, traits, stability)
+		animal.AddComponent(ecs.NewGroupComponent(groupID, traits.Group))
+		members = append(members, animal)
+	}
+	return members
+}
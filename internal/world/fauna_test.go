@@ -0,0 +1,84 @@
+package world
+
+import (
+	"math/rand"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newFaunaWorld создает мир только с таблицами фауны
+func newFaunaWorld() *World {
+	return &World{BiomeFauna: DefaultBiomeFauna(), FaunaSpecies: DefaultFaunaSpecies()}
+}
+
+// faunaCounts считает, сколько раз выбран каждый вид биома
+func faunaCounts(w *World, biomeType string, rolls int) map[string]int {
+	r := rand.New(rand.NewSource(1))
+	counts := make(map[string]int)
+	for i := 0; i < rolls; i++ {
+		counts[w.chooseFauna(biomeType, r)]++
+	}
+	return counts
+}
+
+func TestMarshSpawnsOnlyMarshFauna(t *testing.T) {
+	counts := faunaCounts(newFaunaWorld(), "marsh", 1000)
+
+	for species := range counts {
+		if species != "frog" && species != "snake" && species != "fish" {
+			t.Errorf("marsh spawned %q, want only frog, snake or fish", species)
+		}
+	}
+	if counts["wolf"] != 0 {
+		t.Errorf("marsh spawned %d wolves", counts["wolf"])
+	}
+	// Лягушки встречаются чаще змей
+	if counts["frog"] <= counts["snake"] {
+		t.Errorf("marsh spawned %d frogs and %d snakes, want frogs to be more common", counts["frog"], counts["snake"])
+	}
+}
+
+func TestRockySpawnsGoatsAndEagles(t *testing.T) {
+	counts := faunaCounts(newFaunaWorld(), "rocky", 1000)
+
+	if counts["goat"] == 0 || counts["eagle"] == 0 {
+		t.Errorf("rocky spawned %v, want both goats and eagles", counts)
+	}
+	if counts["deer"] != 0 || counts["frog"] != 0 {
+		t.Errorf("rocky spawned fauna of other biomes: %v", counts)
+	}
+}
+
+func TestBiomeWithoutFaunaFallsBackToTaiga(t *testing.T) {
+	counts := faunaCounts(newFaunaWorld(), "ashfield", 1000)
+
+	for species := range counts {
+		if species != "deer" && species != "wolf" && species != "rabbit" && species != "fox" {
+			t.Errorf("biome without fauna spawned %q, want the taiga animals", species)
+		}
+	}
+}
+
+func TestAnimalFollowsItsSpecies(t *testing.T) {
+	w := newFaunaWorld()
+
+	snake := newAnimal(ecs.Vector3{}, "snake", w.faunaSpecies("snake"), 1.0)
+	if !snake.HasTag(TagPredator) || snake.HasTag(TagPrey) {
+		t.Errorf("snake is not tagged as a predator")
+	}
+	ai, _ := ecs.ComponentAs[*ecs.AIComponent](snake, ecs.AIComponentID)
+	if ai == nil || ai.AIType != "aggressive" {
+		t.Errorf("snake AI = %+v, want aggressive", ai)
+	}
+
+	frog := newAnimal(ecs.Vector3{}, "frog", w.faunaSpecies("frog"), 1.0)
+	if !frog.HasTag(TagPrey) || frog.HasTag(TagPredator) {
+		t.Errorf("frog is not tagged as prey")
+	}
+
+	// Неизвестный вид становится мирным животным
+	if traits := w.faunaSpecies("moose"); traits.Predator || traits.AIType != "neutral" {
+		t.Errorf("unknown species = %+v, want a peaceful neutral animal", traits)
+	}
+}
@@ -0,0 +1,42 @@
+//go:build dev
+
+package world
+
+import (
+	"sync"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/engine/framebudget"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// benchWorld - мир с заранее сгенерированными чанками: генерация чанка занимает
+// сотни миллисекунд, поэтому мир строится один раз на все прогоны бенчмарка
+var benchWorld = sync.OnceValue(func() *World {
+	ecsWorld := ecs.NewWorld()
+	w := NewWorld(1, ecsWorld, metamorphosis.NewMetamorphosisManager(ecsWorld, ""))
+	w.PopulateSynthetic(ViewDistance + 1)
+	return w
+})
+
+// BenchmarkWorldUpdateActiveChunks - переход игрока через границу чанков:
+// каждый кадр активируется новый ряд чанков и выгружается старый
+func BenchmarkWorldUpdateActiveChunks(b *testing.B) {
+	w := benchWorld()
+
+	// Точки по обе стороны границы чанков (0, 0) и (1, 0)
+	sides := []ecs.Vector3{{X: ChunkSize - 1, Z: 1}, {X: ChunkSize + 1, Z: 1}}
+	w.SetPlayerPosition(sides[0])
+	w.UpdateActiveChunks()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.SetPlayerPosition(sides[(i+1)%2])
+		w.UpdateActiveChunks()
+	}
+}
+
+func TestWorldUpdateActiveChunksBudget(t *testing.T) {
+	framebudget.Check(t, "WorldUpdateActiveChunks", BenchmarkWorldUpdateActiveChunks)
+}
@@ -0,0 +1,35 @@
+package world
+
+import (
+	"echo-taiga/internal/ai/threat"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Опасность окружения по типам биомов и погоде
+var (
+	biomeHazards = map[string]threat.Hazard{
+		"void":      {Name: "void_ground", Severity: 0.6},
+		"distorted": {Name: "distorted_ground", Severity: 0.2},
+	}
+	weatherHazards = map[string]threat.Hazard{
+		"storm":          {Name: "storm", Severity: 0.3},
+		"distortion_fog": {Name: "distortion_fog", Severity: 0.2},
+	}
+)
+
+// EnvironmentalHazards возвращает опасности окружения в точке (реализует threat.Environment)
+func (w *World) EnvironmentalHazards(position ecs.Vector3) []threat.Hazard {
+	hazards := make([]threat.Hazard, 0)
+
+	if chunk := w.GetChunkAtPosition(position.X, position.Z); chunk != nil {
+		if hazard, exists := biomeHazards[chunk.BiomeType]; exists {
+			hazards = append(hazards, hazard)
+		}
+	}
+
+	if hazard, exists := weatherHazards[w.WeatherCondition]; exists {
+		hazards = append(hazards, hazard)
+	}
+
+	return hazards
+}
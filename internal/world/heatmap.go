@@ -0,0 +1,324 @@
+package world
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// anomalyFieldCache хранит рассчитанные поля аномальности по чанкам
+type anomalyFieldCache struct {
+	fields     map[[2]int]cachedAnomalyField
+	generation uint64 // Увеличивается при каждой инвалидации
+	mutex      sync.Mutex
+
+	// Чанки, поле которых открыто ритуалом, и сколько секунд оно еще видно
+	revealed map[[2]int]float64
+}
+
+// cachedAnomalyField - поле аномальности чанка с заданным разрешением
+type cachedAnomalyField struct {
+	resolution int
+	values     [][]float64
+}
+
+// newAnomalyFieldCache создает пустой кэш полей
+func newAnomalyFieldCache() *anomalyFieldCache {
+	return &anomalyFieldCache{
+		fields:   make(map[[2]int]cachedAnomalyField),
+		revealed: make(map[[2]int]float64),
+	}
+}
+
+// GetAnomalyField возвращает поле интенсивности аномалий чанка на сетке resolution x resolution.
+// Поле складывается из базового уровня чанка, эффектов метаморфоз с учетом затухания
+// и градиента уровней аномальности областей. Значения ограничены диапазоном 0-1.
+// Индексы: field[x][z], узлы расположены в центрах ячеек сетки. Возвращается
+// копия: кэш остается неизменным, что бы вызывающий ни сделал с полем.
+// Для чанка, который еще не сгенерирован, возвращается nil: построение
+// тепловой карты не создает чанки.
+func (w *World) GetAnomalyField(chunkPos [2]int, resolution int) [][]float64 {
+	if resolution <= 0 {
+		return nil
+	}
+
+	// Проверяем кэш
+	w.anomalyFields.mutex.Lock()
+	cached, exists := w.anomalyFields.fields[chunkPos]
+	generation := w.anomalyFields.generation
+	w.anomalyFields.mutex.Unlock()
+
+	if exists && cached.resolution == resolution {
+		return copyField(cached.values)
+	}
+
+	// Расчет идет без блокировки кэша: инвалидация может прийти из менеджера метаморфоз
+	values := w.computeAnomalyField(chunkPos, resolution)
+	if values == nil {
+		return nil
+	}
+
+	// Сохраняем, только если за время расчета кэш не инвалидировали
+	w.anomalyFields.mutex.Lock()
+	if w.anomalyFields.generation == generation {
+		w.anomalyFields.fields[chunkPos] = cachedAnomalyField{resolution: resolution, values: copyField(values)}
+	}
+	w.anomalyFields.mutex.Unlock()
+
+	return values
+}
+
+// AnomalyFieldGeneration возвращает номер поколения полей аномальности. Номер
+// растет при каждой инвалидации: пока он прежний, поля чанков не меняются.
+func (w *World) AnomalyFieldGeneration() uint64 {
+	w.anomalyFields.mutex.Lock()
+	defer w.anomalyFields.mutex.Unlock()
+
+	return w.anomalyFields.generation
+}
+
+// copyField копирует поле аномальности
+func copyField(field [][]float64) [][]float64 {
+	copied := make([][]float64, len(field))
+	for i := range field {
+		copied[i] = append([]float64(nil), field[i]...)
+	}
+	return copied
+}
+
+// computeAnomalyField рассчитывает поле аномальности чанка (nil, если чанк не сгенерирован)
+func (w *World) computeAnomalyField(chunkPos [2]int, resolution int) [][]float64 {
+	w.chunkMutex.RLock()
+	chunk, loaded := w.Chunks[chunkPos]
+	var chunkLevel float64
+	if loaded {
+		chunkLevel = chunk.AnomalyLevel
+	}
+	w.chunkMutex.RUnlock()
+	if !loaded {
+		return nil
+	}
+
+	var effects []*metamorphosis.MetamorphEffect
+	var areaLevels map[string]float64
+	var anchors metamorphosis.AnchorField
+	if w.MetamorphManager != nil {
+		effects = w.MetamorphManager.GetActiveEffects()
+		areaLevels = w.MetamorphManager.GetLocalAnomalyLevels()
+		anchors = w.MetamorphManager.AnchorSuppression()
+	}
+
+	cellSize := ChunkSize / float64(resolution)
+	originX := float64(chunkPos[0]) * ChunkSize
+	originZ := float64(chunkPos[1]) * ChunkSize
+
+	chunkNoise := w.anomalyNoiseAt(float64(chunkPos[0])+0.5, float64(chunkPos[1])+0.5)
+
+	field := make([][]float64, resolution)
+	for i := 0; i < resolution; i++ {
+		field[i] = make([]float64, resolution)
+
+		for j := 0; j < resolution; j++ {
+			x := originX + (float64(i)+0.5)*cellSize
+			z := originZ + (float64(j)+0.5)*cellSize
+
+			// Уровень чанка с мелкими деталями шума внутри чанка
+			value := chunkLevel
+			value += w.anomalyNoiseAt(x/ChunkSize, z/ChunkSize) - chunkNoise
+
+			// Вклад эффектов метаморфоз (поле строится в плоскости центра эффекта)
+			for _, effect := range effects {
+				position := ecs.Vector3{X: x, Z: z}
+				if effect.AffectedArea != nil {
+					position.Y = effect.AffectedArea.Center.Y
+				}
+				value += effect.EffectiveIntensityFor(position)
+			}
+
+			// Градиент уровней аномальности областей
+			value += interpolateAreaLevel(areaLevels, x, z)
+
+			// Якоря стабильности оставляют в поле холодные пятна
+			value *= 1.0 - anchors.At(ecs.Vector3{X: x, Z: z})
+
+			field[i][j] = math.Max(0.0, math.Min(1.0, value))
+		}
+	}
+
+	return field
+}
+
+// interpolateAreaLevel билинейно интерполирует уровни аномальности областей
+// между их центрами. Области совпадают с чанками.
+func interpolateAreaLevel(levels map[string]float64, x, z float64) float64 {
+	if len(levels) == 0 {
+		return 0
+	}
+
+	// Координаты относительно центров областей
+	fx := x/ChunkSize - 0.5
+	fz := z/ChunkSize - 0.5
+	x0 := int(math.Floor(fx))
+	z0 := int(math.Floor(fz))
+	tx := fx - float64(x0)
+	tz := fz - float64(z0)
+
+	level := func(ax, az int) float64 {
+		return levels[areaID(ax, az)]
+	}
+
+	top := level(x0, z0)*(1-tx) + level(x0+1, z0)*tx
+	bottom := level(x0, z0+1)*(1-tx) + level(x0+1, z0+1)*tx
+	return top*(1-tz) + bottom*tz
+}
+
+// areaID возвращает идентификатор области аномальности (совпадает с форматом символов)
+func areaID(x, z int) string {
+	return fmt.Sprintf("%d_%d", x, z)
+}
+
+// parseAreaID разбирает идентификатор области аномальности
+func parseAreaID(id string) (int, int, bool) {
+	parts := strings.Split(id, "_")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	x, errX := strconv.Atoi(parts[0])
+	z, errZ := strconv.Atoi(parts[1])
+	if errX != nil || errZ != nil {
+		return 0, 0, false
+	}
+	return x, z, true
+}
+
+// invalidateAnomalyFields сбрасывает все кэшированные поля
+func (w *World) invalidateAnomalyFields() {
+	w.anomalyFields.mutex.Lock()
+	defer w.anomalyFields.mutex.Unlock()
+
+	w.anomalyFields.fields = make(map[[2]int]cachedAnomalyField)
+	w.anomalyFields.generation++
+}
+
+// invalidateAnomalyFieldsAround сбрасывает поля чанка и его соседей
+// (градиент областей захватывает соседние чанки)
+func (w *World) invalidateAnomalyFieldsAround(chunkX, chunkZ int) {
+	w.anomalyFields.mutex.Lock()
+	defer w.anomalyFields.mutex.Unlock()
+
+	for dx := -1; dx <= 1; dx++ {
+		for dz := -1; dz <= 1; dz++ {
+			delete(w.anomalyFields.fields, [2]int{chunkX + dx, chunkZ + dz})
+		}
+	}
+	w.anomalyFields.generation++
+}
+
+// subscribeAnomalyChanges подписывает кэш полей и террейн чанков на изменения в менеджере метаморфоз
+func (w *World) subscribeAnomalyChanges() {
+	if w.MetamorphManager == nil {
+		return
+	}
+
+	// Подписки других подсистем на те же события сохраняются
+	manager := w.MetamorphManager
+
+	onEffectsChanged := manager.OnEffectsChanged
+	manager.OnEffectsChanged = func(effect *metamorphosis.MetamorphEffect) {
+		w.invalidateAnomalyFields()
+		if onEffectsChanged != nil {
+			onEffectsChanged(effect)
+		}
+	}
+
+	// Снятый эффект возвращает рельеф чанков в исходное состояние
+	onEffectRemoved := manager.OnEffectRemoved
+	manager.OnEffectRemoved = func(effect *metamorphosis.MetamorphEffect) {
+		w.revertEffectEverywhere(effect.ID)
+		if onEffectRemoved != nil {
+			onEffectRemoved(effect)
+		}
+	}
+
+	// Треснувший якорь больше не гасит поле вокруг себя
+	onAnchorCracked := manager.OnAnchorCracked
+	manager.OnAnchorCracked = func(anchorID ecs.EntityID, position ecs.Vector3) {
+		w.invalidateAnomalyFieldsAround(int(math.Floor(position.X/ChunkSize)), int(math.Floor(position.Z/ChunkSize)))
+		if onAnchorCracked != nil {
+			onAnchorCracked(anchorID, position)
+		}
+	}
+
+	onAnomalyLevelChanged := manager.OnAnomalyLevelChanged
+	manager.OnAnomalyLevelChanged = func(id string, level float64) {
+		if x, z, ok := parseAreaID(id); ok {
+			w.invalidateAnomalyFieldsAround(x, z)
+		} else {
+			w.invalidateAnomalyFields()
+		}
+		if onAnomalyLevelChanged != nil {
+			onAnomalyLevelChanged(id, level)
+		}
+	}
+}
+
+// RevealAnomalyField открывает на карте поле аномальности чанка, содержащего
+// точку, на duration секунд, даже если тепловая карта выключена (реализует
+// symbols.AreaRevealer)
+func (w *World) RevealAnomalyField(position ecs.Vector3, duration float64) {
+	w.anomalyFields.mutex.Lock()
+	defer w.anomalyFields.mutex.Unlock()
+
+	chunk := chunkPosition(position)
+	w.anomalyFields.revealed[chunk] = math.Max(w.anomalyFields.revealed[chunk], duration)
+}
+
+// IsAnomalyFieldRevealed проверяет, открыто ли сейчас поле аномальности чанка
+func (w *World) IsAnomalyFieldRevealed(chunkPos [2]int) bool {
+	w.anomalyFields.mutex.Lock()
+	defer w.anomalyFields.mutex.Unlock()
+
+	return w.anomalyFields.revealed[chunkPos] > 0
+}
+
+// advanceRevealedFields отсчитывает время, пока открытые поля остаются видны
+func (w *World) advanceRevealedFields(deltaTime float64) {
+	w.anomalyFields.mutex.Lock()
+	defer w.anomalyFields.mutex.Unlock()
+
+	for chunk, remaining := range w.anomalyFields.revealed {
+		if remaining -= deltaTime; remaining > 0 {
+			w.anomalyFields.revealed[chunk] = remaining
+		} else {
+			delete(w.anomalyFields.revealed, chunk)
+		}
+	}
+}
+
+// SetHeatmapEnabled включает или выключает отображение тепловой карты аномалий
+func (w *World) SetHeatmapEnabled(enabled bool) {
+	w.HeatmapEnabled = enabled
+	if w.OnHeatmapToggled != nil {
+		w.OnHeatmapToggled(enabled)
+	}
+}
+
+// ExecuteDebugCommand выполняет отладочную команду мира
+func (w *World) ExecuteDebugCommand(command string, args []string) (string, error) {
+	switch command {
+	case "world.heatmap":
+		if len(args) != 1 || (args[0] != "on" && args[0] != "off") {
+			return "", fmt.Errorf("usage: world.heatmap <on|off>")
+		}
+		w.SetHeatmapEnabled(args[0] == "on")
+		return fmt.Sprintf("heatmap %s", args[0]), nil
+	}
+
+	return "", fmt.Errorf("unknown command: %s", command)
+}
@@ -0,0 +1,152 @@
+package world
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// newHeatmapWorld создает мир из четырех чанков вокруг начала координат без
+// собственной аномальности и со сферическим эффектом в их общем углу
+func newHeatmapWorld(t *testing.T, radius, intensity float64) (*World, string) {
+	t.Helper()
+
+	mm := metamorphosis.NewMetamorphosisManager(ecs.NewWorld(), t.TempDir())
+	mm.RegisterEffectTemplate(&metamorphosis.MetamorphEffect{ID: "test_glow", Name: "Glow", Order: metamorphosis.OrderFirst, Duration: time.Hour})
+
+	w := &World{Chunks: make(map[[2]int]*Chunk), MetamorphManager: mm, anomalyFields: newAnomalyFieldCache()}
+	for _, pos := range [][2]int{{0, 0}, {-1, 0}, {0, -1}, {-1, -1}} {
+		w.Chunks[pos] = &Chunk{Position: pos, BiomeType: "taiga"}
+	}
+	w.subscribeAnomalyChanges()
+
+	effectID, err := mm.RequestEffect(metamorphosis.EffectRequest{Order: metamorphosis.OrderFirst, Radius: radius, Intensity: intensity})
+	if err != nil {
+		t.Fatalf("RequestEffect: %v", err)
+	}
+	// Без затухания поле внутри сферы равно интенсивности эффекта
+	area := metamorphosis.AffectedArea{Type: "sphere", Radius: radius, Falloff: "none"}
+	if err := mm.MutateEffect(effectID, metamorphosis.EffectPatch{AffectedArea: &area}); err != nil {
+		t.Fatalf("MutateEffect: %v", err)
+	}
+	return w, effectID
+}
+
+func TestSphereOnChunkCornerMakesQuarterDisc(t *testing.T) {
+	const resolution = 8
+	w, _ := newHeatmapWorld(t, 32, 0.6)
+	field := w.GetAnomalyField([2]int{0, 0}, resolution)
+
+	cellSize := ChunkSize / float64(resolution)
+	inside := 0
+	for i := 0; i < resolution; i++ {
+		for j := 0; j < resolution; j++ {
+			x := (float64(i) + 0.5) * cellSize
+			z := (float64(j) + 0.5) * cellSize
+			want := 0.0
+			if math.Hypot(x, z) <= 32 {
+				want = 0.6
+				inside++
+			}
+			if math.Abs(field[i][j]-want) > 1e-9 {
+				t.Errorf("field[%d][%d] = %v, want %v", i, j, field[i][j], want)
+			}
+		}
+	}
+	if inside == 0 || inside == resolution*resolution {
+		t.Fatalf("%d of %d cells inside the sphere, want a quarter disc", inside, resolution*resolution)
+	}
+
+	// Противоположный чанк получает зеркальную четверть круга
+	opposite := w.GetAnomalyField([2]int{-1, -1}, resolution)
+	for i := 0; i < resolution; i++ {
+		for j := 0; j < resolution; j++ {
+			if opposite[resolution-1-i][resolution-1-j] != field[i][j] {
+				t.Fatalf("opposite chunk is not the mirrored quarter disc at [%d][%d]", i, j)
+			}
+		}
+	}
+}
+
+func TestAnomalyFieldCacheFollowsEffectChanges(t *testing.T) {
+	w, effectID := newHeatmapWorld(t, 32, 0.6)
+
+	field := w.GetAnomalyField([2]int{0, 0}, 4)
+	field[0][0] = 1
+	if cached := w.GetAnomalyField([2]int{0, 0}, 4); cached[0][0] != 0.6 {
+		t.Fatalf("changing a returned field changed the cache to %v", cached[0][0])
+	}
+
+	generation := w.AnomalyFieldGeneration()
+	intensity := 0.3
+	if err := w.MetamorphManager.MutateEffect(effectID, metamorphosis.EffectPatch{Intensity: &intensity}); err != nil {
+		t.Fatalf("MutateEffect: %v", err)
+	}
+	if w.AnomalyFieldGeneration() == generation {
+		t.Errorf("mutating the effect did not invalidate the cached fields")
+	}
+	if got := w.GetAnomalyField([2]int{0, 0}, 4)[0][0]; math.Abs(got-0.3) > 1e-9 {
+		t.Errorf("field after the mutation = %v, want 0.3", got)
+	}
+}
+
+func TestHeatmapDebugCommand(t *testing.T) {
+	w := &World{}
+	var toggled []bool
+	w.OnHeatmapToggled = func(enabled bool) { toggled = append(toggled, enabled) }
+
+	if _, err := w.ExecuteDebugCommand("world.heatmap", []string{"on"}); err != nil || !w.HeatmapEnabled {
+		t.Fatalf("world.heatmap on: err %v, enabled %v", err, w.HeatmapEnabled)
+	}
+	if _, err := w.ExecuteDebugCommand("world.heatmap", []string{"maybe"}); err == nil {
+		t.Errorf("world.heatmap accepted an invalid argument")
+	}
+	if _, err := w.ExecuteDebugCommand("world.heatmap", []string{"off"}); err != nil || w.HeatmapEnabled {
+		t.Fatalf("world.heatmap off: err %v, enabled %v", err, w.HeatmapEnabled)
+	}
+	if len(toggled) != 2 || !toggled[0] || toggled[1] {
+		t.Errorf("toggle callbacks %v, want on then off", toggled)
+	}
+}
+
+func TestAnomalyFieldOfMissingChunkGeneratesNothing(t *testing.T) {
+	w, _ := newHeatmapWorld(t, 32, 0.6)
+
+	if field := w.GetAnomalyField([2]int{5, 5}, 4); field != nil {
+		t.Errorf("field of an ungenerated chunk = %v, want nil", field)
+	}
+	if _, generated := w.Chunks[[2]int{5, 5}]; generated {
+		t.Errorf("building the field generated the chunk")
+	}
+}
+
+func TestHeatmapKeepsEarlierSubscribers(t *testing.T) {
+	mm := metamorphosis.NewMetamorphosisManager(ecs.NewWorld(), t.TempDir())
+	calls := make(map[string]int)
+	mm.OnEffectsChanged = func(effect *metamorphosis.MetamorphEffect) { calls["changed"]++ }
+	mm.OnEffectRemoved = func(effect *metamorphosis.MetamorphEffect) { calls["removed"]++ }
+	mm.OnAnchorCracked = func(anchorID ecs.EntityID, position ecs.Vector3) { calls["cracked"]++ }
+	mm.OnAnomalyLevelChanged = func(id string, level float64) { calls["level"]++ }
+
+	w := &World{Chunks: make(map[[2]int]*Chunk), MetamorphManager: mm, anomalyFields: newAnomalyFieldCache()}
+	w.subscribeAnomalyChanges()
+
+	generation := w.AnomalyFieldGeneration()
+	effect := &metamorphosis.MetamorphEffect{ID: "test_glow"}
+	mm.OnEffectsChanged(effect)
+	mm.OnEffectRemoved(effect)
+	mm.OnAnchorCracked(ecs.EntityID("anchor"), ecs.Vector3{})
+	mm.OnAnomalyLevelChanged("global", 0.5)
+
+	for _, event := range []string{"changed", "removed", "cracked", "level"} {
+		if calls[event] != 1 {
+			t.Errorf("earlier %s subscriber called %d times, want once", event, calls[event])
+		}
+	}
+	if w.AnomalyFieldGeneration() == generation {
+		t.Errorf("the world's own subscription did not invalidate the fields")
+	}
+}
@@ -0,0 +1,96 @@
+package world
+
+import (
+	"sort"
+	"sync/atomic"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// ChunkEntities - снимок списка сущностей активного чанка
+type ChunkEntities struct {
+	Position [2]int
+	Entities []ecs.EntityID
+}
+
+// pendingEntity - сущность, созданная во время обхода чанков и ожидающая
+// добавления в список сущностей чанка
+type pendingEntity struct {
+	chunk  *Chunk
+	entity *ecs.Entity
+}
+
+// Generation возвращает счетчик изменений набора активных чанков и их сущностей.
+// Счетчик растет при каждом изменении, так что по нему можно понять, устарел ли снимок.
+func (w *World) Generation() uint64 {
+	return atomic.LoadUint64(&w.generation)
+}
+
+// ActiveChunkEntities возвращает копию списков сущностей активных чанков,
+// упорядоченную по позициям чанков, и поколение, к которому относится снимок.
+// Безопасно вызывать из других горутин одновременно с Update.
+func (w *World) ActiveChunkEntities() ([]ChunkEntities, uint64) {
+	w.chunkMutex.RLock()
+	defer w.chunkMutex.RUnlock()
+
+	result := make([]ChunkEntities, 0, len(w.ActiveChunks))
+	for pos, chunk := range w.ActiveChunks {
+		result = append(result, ChunkEntities{
+			Position: pos,
+			Entities: append([]ecs.EntityID(nil), chunk.Entities...),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Position[0] != result[j].Position[0] {
+			return result[i].Position[0] < result[j].Position[0]
+		}
+		return result[i].Position[1] < result[j].Position[1]
+	})
+
+	return result, w.Generation()
+}
+
+// activeChunkList возвращает копию набора активных чанков для обхода,
+// во время которого набор может измениться
+func (w *World) activeChunkList() []*Chunk {
+	w.chunkMutex.RLock()
+	defer w.chunkMutex.RUnlock()
+
+	chunks := make([]*Chunk, 0, len(w.ActiveChunks))
+	for _, chunk := range w.ActiveChunks {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// queueChunkEntity откладывает добавление новой сущности в мир и чанк до конца обхода чанков
+func (w *World) queueChunkEntity(chunk *Chunk, entity *ecs.Entity) {
+	w.pendingEntities = append(w.pendingEntities, pendingEntity{chunk: chunk, entity: entity})
+}
+
+// flushChunkEntities добавляет отложенные сущности одной пачкой в мир и в их чанки
+func (w *World) flushChunkEntities() {
+	if len(w.pendingEntities) == 0 {
+		return
+	}
+
+	batch := make([]*ecs.Entity, len(w.pendingEntities))
+	for i, pending := range w.pendingEntities {
+		batch[i] = pending.entity
+	}
+	ids := w.ECSWorld.AddEntities(batch)
+
+	w.chunkMutex.Lock()
+	defer w.chunkMutex.Unlock()
+
+	for i, pending := range w.pendingEntities {
+		w.addChunkEntities(pending.chunk, ids[i])
+	}
+	w.pendingEntities = w.pendingEntities[:0]
+	w.bumpGeneration()
+}
+
+// bumpGeneration отмечает изменение активных чанков. Вызывается при захваченном chunkMutex.
+func (w *World) bumpGeneration() {
+	atomic.AddUint64(&w.generation, 1)
+}
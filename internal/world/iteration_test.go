@@ -0,0 +1,78 @@
+package world
+
+import (
+	"sync"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/world/terrain"
+)
+
+// newNightWorld создает ночной мир с тремя активными чанками вне безопасной зоны
+func newNightWorld() *World {
+	ecsWorld := ecs.NewWorld()
+	w := NewWorld(1, ecsWorld, metamorphosis.NewMetamorphosisManager(ecsWorld, ""))
+	w.TimeOfDay = 0.9
+	for x := 10; x < 13; x++ {
+		pos := [2]int{x, 0}
+		w.ActiveChunks[pos] = &Chunk{Position: pos, IsActive: true, Terrain: terrain.NewTerrainData(ChunkSize, ChunkSize)}
+	}
+	return w
+}
+
+func TestSpawnsWhileAnotherGoroutineEnumeratesChunks(t *testing.T) {
+	w := newNightWorld()
+	const frames = 50
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		last := uint64(0)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			chunks, generation := w.ActiveChunkEntities()
+			if generation < last {
+				t.Errorf("generation went back from %d to %d", last, generation)
+				return
+			}
+			last = generation
+			if len(chunks) != 3 || chunks[0].Position[0] > chunks[1].Position[0] {
+				t.Errorf("snapshot %v, want the three chunks in order", chunks)
+				return
+			}
+		}
+	}()
+
+	// За такой шаг в каждом чанке наверняка появляется ночное существо
+	before := w.Generation()
+	for frame := 0; frame < frames; frame++ {
+		for _, chunk := range w.activeChunkList() {
+			w.updateChunk(chunk, 100)
+		}
+		w.flushChunkEntities()
+	}
+	close(done)
+	wg.Wait()
+
+	chunks, generation := w.ActiveChunkEntities()
+	if generation != before+frames {
+		t.Errorf("generation %d after %d flushes from %d", generation, frames, before)
+	}
+	for _, chunk := range chunks {
+		if len(chunk.Entities) < frames {
+			t.Errorf("chunk %v holds %d entities, want at least one per frame", chunk.Position, len(chunk.Entities))
+		}
+		for _, id := range chunk.Entities {
+			if _, exists := w.ECSWorld.GetEntity(id); !exists {
+				t.Errorf("chunk %v lists entity %s missing from the world", chunk.Position, id)
+			}
+		}
+	}
+}
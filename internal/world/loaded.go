@@ -0,0 +1,50 @@
+package world
+
+import (
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// LoadedEdgeMargin - отступ от края загруженной области, на который
+// ClampToLoaded отодвигает точку внутрь чанка
+const LoadedEdgeMargin = 1.0
+
+// IsPositionLoaded проверяет, лежит ли точка в активном (загруженном) чанке.
+// Испуги, эффекты ритуалов и метаморфоз не должны попадать в чанки, которых нет.
+func (w *World) IsPositionLoaded(position ecs.Vector3) bool {
+	pos := [2]int{int(math.Floor(position.X / ChunkSize)), int(math.Floor(position.Z / ChunkSize))}
+
+	w.chunkMutex.RLock()
+	defer w.chunkMutex.RUnlock()
+
+	_, loaded := w.ActiveChunks[pos]
+	return loaded
+}
+
+// ClampToLoaded возвращает ближайшую к точке позицию внутри активных чанков
+// (с отступом LoadedEdgeMargin от края чанка). Загруженная точка возвращается
+// без изменений, как и любая точка, если активных чанков нет.
+func (w *World) ClampToLoaded(position ecs.Vector3) ecs.Vector3 {
+	if w.IsPositionLoaded(position) {
+		return position
+	}
+
+	w.chunkMutex.RLock()
+	defer w.chunkMutex.RUnlock()
+
+	clamped := position
+	nearest := math.Inf(1)
+	for pos := range w.ActiveChunks {
+		minX, minZ := float64(pos[0])*ChunkSize, float64(pos[1])*ChunkSize
+		candidate := position
+		candidate.X = math.Max(minX+LoadedEdgeMargin, math.Min(minX+ChunkSize-LoadedEdgeMargin, position.X))
+		candidate.Z = math.Max(minZ+LoadedEdgeMargin, math.Min(minZ+ChunkSize-LoadedEdgeMargin, position.Z))
+
+		if distance := math.Hypot(candidate.X-position.X, candidate.Z-position.Z); distance < nearest {
+			nearest = distance
+			clamped = candidate
+		}
+	}
+	return clamped
+}
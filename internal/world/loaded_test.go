@@ -0,0 +1,58 @@
+package world
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newRingWorld создает мир с кольцом активных чанков радиуса 1 вокруг начала координат
+func newRingWorld() *World {
+	w := &World{ActiveChunks: make(map[[2]int]*Chunk)}
+	for x := -1; x <= 1; x++ {
+		for z := -1; z <= 1; z++ {
+			w.ActiveChunks[[2]int{x, z}] = &Chunk{Position: [2]int{x, z}}
+		}
+	}
+	return w
+}
+
+func TestClampToLoadedKeepsLoadedPositions(t *testing.T) {
+	w := newRingWorld()
+
+	position := ecs.Vector3{X: ChunkSize*2 - 0.5, Y: 3, Z: -ChunkSize + 0.5}
+	if !w.IsPositionLoaded(position) {
+		t.Fatalf("%v lies in an active chunk", position)
+	}
+	if got := w.ClampToLoaded(position); got != position {
+		t.Errorf("ClampToLoaded(%v) = %v, want it unchanged", position, got)
+	}
+}
+
+func TestClampToLoadedMovesPastTheRingEdgeInside(t *testing.T) {
+	w := newRingWorld()
+
+	// За краем кольца по X, на высоте чанка 0 по Z
+	position := ecs.Vector3{X: ChunkSize*2 + 10, Y: 3, Z: ChunkSize / 2}
+	if w.IsPositionLoaded(position) {
+		t.Fatalf("%v lies outside the ring", position)
+	}
+
+	got := w.ClampToLoaded(position)
+	if !w.IsPositionLoaded(got) {
+		t.Fatalf("ClampToLoaded(%v) = %v, still outside the ring", position, got)
+	}
+	want := ecs.Vector3{X: ChunkSize*2 - LoadedEdgeMargin, Y: 3, Z: ChunkSize / 2}
+	if got != want {
+		t.Errorf("ClampToLoaded(%v) = %v, want %v", position, got, want)
+	}
+}
+
+func TestClampToLoadedWithoutActiveChunks(t *testing.T) {
+	w := &World{ActiveChunks: make(map[[2]int]*Chunk)}
+
+	position := ecs.Vector3{X: 100, Z: 100}
+	if got := w.ClampToLoaded(position); got != position {
+		t.Errorf("ClampToLoaded(%v) = %v with no active chunks, want it unchanged", position, got)
+	}
+}
@@ -0,0 +1,217 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/savefile"
+)
+
+// sightedMarkerTolerance - метка увиденного символа с тем же названием ближе
+// этого расстояния считается меткой того же символа
+const sightedMarkerTolerance = 0.1
+
+// MarkerID - идентификатор метки на карте мира
+type MarkerID uint64
+
+// Виды меток
+const (
+	MarkerRitual        = "ritual"         // Здесь нужно провести ритуал
+	MarkerSymbolSighted = "symbol_sighted" // Здесь видели символ
+	MarkerObjective     = "objective"      // Цель задания
+)
+
+// Marker - метка, привязанная к точке мира: цель задания, место ритуала, увиденный символ
+type Marker struct {
+	ID       MarkerID    `json:"id"`
+	Position ecs.Vector3 `json:"position"`
+	Kind     string      `json:"kind"`
+	Label    string      `json:"label"`
+}
+
+// markerIndex - метки мира по чанкам, чтобы поиск по радиусу не перебирал все метки
+type markerIndex struct {
+	byChunk map[[2]int]map[MarkerID]*Marker
+	chunks  map[MarkerID][2]int
+	nextID  MarkerID
+	mutex   sync.RWMutex
+}
+
+// newMarkerIndex создает пустой набор меток
+func newMarkerIndex() *markerIndex {
+	return &markerIndex{
+		byChunk: make(map[[2]int]map[MarkerID]*Marker),
+		chunks:  make(map[MarkerID][2]int),
+		nextID:  1,
+	}
+}
+
+// add добавляет метку в чанк ее позиции. Вызывается с захваченным мьютексом.
+func (mi *markerIndex) add(marker *Marker) {
+	chunk := chunkPosition(marker.Position)
+	if mi.byChunk[chunk] == nil {
+		mi.byChunk[chunk] = make(map[MarkerID]*Marker)
+	}
+	mi.byChunk[chunk][marker.ID] = marker
+	mi.chunks[marker.ID] = chunk
+
+	if marker.ID >= mi.nextID {
+		mi.nextID = marker.ID + 1
+	}
+}
+
+// AddMarker ставит метку в точке мира и возвращает ее идентификатор
+func (w *World) AddMarker(pos ecs.Vector3, kind, label string) MarkerID {
+	w.markers.mutex.Lock()
+	defer w.markers.mutex.Unlock()
+
+	marker := &Marker{ID: w.markers.nextID, Position: pos, Kind: kind, Label: label}
+	w.markers.add(marker)
+	return marker.ID
+}
+
+// MarkSymbolSighted ставит метку увиденного символа (реализует symbols.SymbolMarker).
+// Символ, уже отмеченный в этой точке, повторно не отмечается; соседние
+// символы с другими названиями получают свои метки.
+func (w *World) MarkSymbolSighted(pos ecs.Vector3, label string) {
+	w.markers.mutex.Lock()
+	defer w.markers.mutex.Unlock()
+
+	for _, marker := range w.markers.byChunk[chunkPosition(pos)] {
+		if marker.Kind == MarkerSymbolSighted && marker.Label == label &&
+			math.Hypot(marker.Position.X-pos.X, marker.Position.Z-pos.Z) <= sightedMarkerTolerance {
+			return
+		}
+	}
+	w.markers.add(&Marker{ID: w.markers.nextID, Position: pos, Kind: MarkerSymbolSighted, Label: label})
+}
+
+// RemoveMarker убирает метку. Возвращает false, если такой метки нет.
+func (w *World) RemoveMarker(id MarkerID) bool {
+	w.markers.mutex.Lock()
+	defer w.markers.mutex.Unlock()
+
+	chunk, exists := w.markers.chunks[id]
+	if !exists {
+		return false
+	}
+
+	delete(w.markers.byChunk[chunk], id)
+	if len(w.markers.byChunk[chunk]) == 0 {
+		delete(w.markers.byChunk, chunk)
+	}
+	delete(w.markers.chunks, id)
+	return true
+}
+
+// GetMarker возвращает копию метки
+func (w *World) GetMarker(id MarkerID) (Marker, bool) {
+	w.markers.mutex.RLock()
+	defer w.markers.mutex.RUnlock()
+
+	chunk, exists := w.markers.chunks[id]
+	if !exists {
+		return Marker{}, false
+	}
+	return *w.markers.byChunk[chunk][id], true
+}
+
+// GetMarkersInRadius возвращает копии меток не дальше radius от точки по
+// горизонтали, от ближайших к дальним
+func (w *World) GetMarkersInRadius(pos ecs.Vector3, radius float64) []Marker {
+	w.markers.mutex.RLock()
+	defer w.markers.mutex.RUnlock()
+
+	center := chunkPosition(pos)
+	reach := int(math.Ceil(radius / ChunkSize))
+
+	type found struct {
+		marker   Marker
+		distance float64
+	}
+	hits := make([]found, 0)
+	for x := center[0] - reach; x <= center[0]+reach; x++ {
+		for z := center[1] - reach; z <= center[1]+reach; z++ {
+			for _, marker := range w.markers.byChunk[[2]int{x, z}] {
+				distance := math.Hypot(marker.Position.X-pos.X, marker.Position.Z-pos.Z)
+				if distance <= radius {
+					hits = append(hits, found{marker: *marker, distance: distance})
+				}
+			}
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].distance != hits[j].distance {
+			return hits[i].distance < hits[j].distance
+		}
+		return hits[i].marker.ID < hits[j].marker.ID
+	})
+
+	markers := make([]Marker, len(hits))
+	for i, hit := range hits {
+		markers[i] = hit.marker
+	}
+	return markers
+}
+
+// SaveMarkers сохраняет метки мира по чанкам
+func (w *World) SaveMarkers(savePath string) error {
+	w.markers.mutex.RLock()
+	records := make(map[string][]Marker, len(w.markers.byChunk))
+	for chunk, markers := range w.markers.byChunk {
+		chunkMarkers := make([]Marker, 0, len(markers))
+		for _, marker := range markers {
+			chunkMarkers = append(chunkMarkers, *marker)
+		}
+		sort.Slice(chunkMarkers, func(i, j int) bool {
+			return chunkMarkers[i].ID < chunkMarkers[j].ID
+		})
+		records[chunkKey(chunk[0], chunk[1])] = chunkMarkers
+	}
+	w.markers.mutex.RUnlock()
+
+	data, err := savefile.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return savefile.Write(filepath.Join(savePath, "markers.json"), data)
+}
+
+// LoadMarkers загружает метки мира. Отсутствие файла не считается ошибкой.
+func (w *World) LoadMarkers(savePath string) error {
+	data, err := savefile.Read(filepath.Join(savePath, "markers.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var records map[string][]Marker
+	if err := json.Unmarshal(data, &records); err != nil {
+		return fmt.Errorf("invalid markers: %v", err)
+	}
+
+	markers := newMarkerIndex()
+	for _, chunkMarkers := range records {
+		for i := range chunkMarkers {
+			marker := chunkMarkers[i]
+			markers.add(&marker)
+		}
+	}
+
+	w.markers.mutex.Lock()
+	defer w.markers.mutex.Unlock()
+
+	w.markers.byChunk = markers.byChunk
+	w.markers.chunks = markers.chunks
+	w.markers.nextID = markers.nextID
+	return nil
+}
@@ -0,0 +1,112 @@
+package world
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+func TestMarkersInRadiusAreNearestFirst(t *testing.T) {
+	w := &World{markers: newMarkerIndex()}
+
+	// Метки в соседних чанках и одна за пределами радиуса
+	near := w.AddMarker(ecs.Vector3{X: ChunkSize - 2, Z: 5}, MarkerRitual, "Mist Veil")
+	across := w.AddMarker(ecs.Vector3{X: ChunkSize + 4, Z: 5}, MarkerObjective, "Find the cairn")
+	w.AddMarker(ecs.Vector3{X: ChunkSize * 3, Z: 5}, MarkerObjective, "Too far")
+
+	markers := w.GetMarkersInRadius(ecs.Vector3{X: ChunkSize, Z: 5}, 10)
+	if len(markers) != 2 || markers[0].ID != near || markers[1].ID != across {
+		t.Fatalf("got %v, want the marker 2 away before the one 4 away", markers)
+	}
+	if markers[0].Kind != MarkerRitual || markers[0].Label != "Mist Veil" {
+		t.Errorf("marker %d = %+v, want the ritual marker as added", near, markers[0])
+	}
+
+	// Результаты - копии, правка не меняет метку мира
+	markers[0].Label = "changed"
+	if marker, _ := w.GetMarker(near); marker.Label != "Mist Veil" {
+		t.Errorf("editing a query result changed the marker to %q", marker.Label)
+	}
+}
+
+func TestRemovedMarkerDisappearsAtOnce(t *testing.T) {
+	w := &World{markers: newMarkerIndex()}
+	id := w.AddMarker(ecs.Vector3{X: 5, Z: 5}, MarkerRitual, "Mist Veil")
+
+	if !w.RemoveMarker(id) {
+		t.Fatalf("RemoveMarker(%d) = false for an existing marker", id)
+	}
+	if markers := w.GetMarkersInRadius(ecs.Vector3{X: 5, Z: 5}, 10); len(markers) != 0 {
+		t.Errorf("removed marker still found: %v", markers)
+	}
+	if _, exists := w.GetMarker(id); exists {
+		t.Errorf("GetMarker found the removed marker")
+	}
+	if w.RemoveMarker(id) {
+		t.Errorf("removing the marker twice reported success")
+	}
+}
+
+func TestMarkersSurviveSaveAndReload(t *testing.T) {
+	savePath := t.TempDir()
+	w := &World{markers: newMarkerIndex()}
+	ritual := w.AddMarker(ecs.Vector3{X: 5, Y: 2, Z: 5}, MarkerRitual, "Mist Veil")
+	objective := w.AddMarker(ecs.Vector3{X: -40, Z: 70}, MarkerObjective, "Find the cairn")
+	if err := w.SaveMarkers(savePath); err != nil {
+		t.Fatalf("SaveMarkers: %v", err)
+	}
+
+	loaded := &World{markers: newMarkerIndex()}
+	if err := loaded.LoadMarkers(savePath); err != nil {
+		t.Fatalf("LoadMarkers: %v", err)
+	}
+	for _, id := range []MarkerID{ritual, objective} {
+		want, _ := w.GetMarker(id)
+		if got, exists := loaded.GetMarker(id); !exists || got != want {
+			t.Errorf("marker %d reloaded as %+v (exists %v), want %+v", id, got, exists, want)
+		}
+	}
+	if markers := loaded.GetMarkersInRadius(ecs.Vector3{X: -40, Z: 70}, 1); len(markers) != 1 || markers[0].ID != objective {
+		t.Errorf("radius query after reload = %v, want the objective", markers)
+	}
+
+	// Новые метки не занимают идентификаторы загруженных
+	if id := loaded.AddMarker(ecs.Vector3{}, MarkerObjective, "New"); id == ritual || id == objective {
+		t.Errorf("new marker reused the loaded ID %d", id)
+	}
+
+	// Мир без сохраненных меток загружается пустым
+	empty := &World{markers: newMarkerIndex()}
+	if err := empty.LoadMarkers(t.TempDir()); err != nil {
+		t.Errorf("LoadMarkers without a save: %v", err)
+	}
+}
+
+func TestMarkSymbolSightedKeepsNeighbouringSymbols(t *testing.T) {
+	w := &World{markers: newMarkerIndex()}
+
+	w.MarkSymbolSighted(ecs.Vector3{X: 10, Z: 10}, "Frost")
+	w.MarkSymbolSighted(ecs.Vector3{X: 10.5, Z: 10}, "Ash")
+	w.MarkSymbolSighted(ecs.Vector3{X: 10, Z: 10}, "Frost")
+
+	markers := w.GetMarkersInRadius(ecs.Vector3{X: 10, Z: 10}, 5)
+	if len(markers) != 2 {
+		t.Fatalf("got %d markers %v, want one for each of the two symbols", len(markers), markers)
+	}
+	for _, marker := range markers {
+		if marker.Kind != MarkerSymbolSighted {
+			t.Errorf("marker %d has kind %q", marker.ID, marker.Kind)
+		}
+	}
+}
+
+func TestMarkSymbolSightedIgnoresOtherMarkerKinds(t *testing.T) {
+	w := &World{markers: newMarkerIndex()}
+
+	w.AddMarker(ecs.Vector3{X: 3, Z: 3}, MarkerObjective, "Frost")
+	w.MarkSymbolSighted(ecs.Vector3{X: 3, Z: 3}, "Frost")
+
+	if markers := w.GetMarkersInRadius(ecs.Vector3{X: 3, Z: 3}, 1); len(markers) != 2 {
+		t.Errorf("got %d markers, want the objective and the sighted symbol", len(markers))
+	}
+}
@@ -0,0 +1,146 @@
+package world
+
+import (
+	"math"
+	"sort"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// MembershipInterval - секунд между проверками принадлежности сущностей чанкам
+const MembershipInterval = 0.5
+
+// chunkPosition возвращает координаты чанка, содержащего мировую позицию
+func chunkPosition(position ecs.Vector3) [2]int {
+	return [2]int{int(math.Floor(position.X / ChunkSize)), int(math.Floor(position.Z / ChunkSize))}
+}
+
+// ChunkOf возвращает координаты чанка, которому принадлежит сущность
+func (w *World) ChunkOf(id ecs.EntityID) ([2]int, bool) {
+	w.chunkMutex.RLock()
+	defer w.chunkMutex.RUnlock()
+
+	pos, exists := w.entityChunks[id]
+	return pos, exists
+}
+
+// addChunkEntities добавляет сущности в список чанка и запоминает, какому чанку
+// они принадлежат. Для активных чанков вызывается при захваченном chunkMutex.
+func (w *World) addChunkEntities(chunk *Chunk, ids ...ecs.EntityID) {
+	chunk.Entities = append(chunk.Entities, ids...)
+	for _, id := range ids {
+		w.entityChunks[id] = chunk.Position
+	}
+}
+
+// removeChunkEntity убирает сущность из списка ее чанка.
+// Вызывается при захваченном chunkMutex.
+func (w *World) removeChunkEntity(id ecs.EntityID) {
+	pos, exists := w.entityChunks[id]
+	if !exists {
+		return
+	}
+	delete(w.entityChunks, id)
+
+	if chunk, exists := w.Chunks[pos]; exists {
+		chunk.Entities = withoutEntity(chunk.Entities, id)
+	}
+}
+
+// withoutEntity возвращает список без указанной сущности, сохраняя порядок остальных
+func withoutEntity(ids []ecs.EntityID, id ecs.EntityID) []ecs.EntityID {
+	for i, other := range ids {
+		if other == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
+
+// updateChunkMembership раз в MembershipInterval сверяет списки сущностей чанков
+// с миром ECS (см. syncChunkMembership)
+func (w *World) updateChunkMembership(deltaTime float64) {
+	w.membershipTimer += deltaTime
+	if w.membershipTimer < MembershipInterval {
+		return
+	}
+	w.membershipTimer = 0
+
+	// Изменения ищутся под блокировкой чтения; запись нужна, только если они есть
+	w.chunkMutex.RLock()
+	changes := w.membershipChanges()
+	w.chunkMutex.RUnlock()
+	if len(changes) == 0 {
+		return
+	}
+
+	w.chunkMutex.Lock()
+	defer w.chunkMutex.Unlock()
+
+	w.applyMembershipChanges(changes)
+	w.bumpGeneration()
+}
+
+// membershipChange - перенос сущности в список другого чанка (nil - сущность исчезла)
+type membershipChange struct {
+	id    ecs.EntityID
+	chunk *Chunk
+}
+
+// syncChunkMembership убирает из чанков исчезнувшие сущности и переносит сущности,
+// перешедшие границу чанка, в список нового чанка. Возвращает, изменилось ли что-то.
+// Вызывается при захваченном на запись chunkMutex.
+func (w *World) syncChunkMembership() bool {
+	changes := w.membershipChanges()
+	w.applyMembershipChanges(changes)
+	return len(changes) > 0
+}
+
+// membershipChanges находит сущности, которые исчезли или перешли в другой чанк.
+// Чанки не генерируются: сущность, ушедшая в еще не созданный чанк, остается в
+// списке прежнего. Вызывается при захваченном chunkMutex.
+func (w *World) membershipChanges() []membershipChange {
+	// Обходим сущности по порядку, чтобы списки чанков не зависели от порядка карты
+	ids := make([]ecs.EntityID, 0, len(w.entityChunks))
+	for id := range w.entityChunks {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var changes []membershipChange
+	for _, id := range ids {
+		entity, exists := w.ECSWorld.GetEntity(id)
+		if !exists {
+			// Сущность погибла или исчезла
+			changes = append(changes, membershipChange{id: id})
+			continue
+		}
+
+		transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
+		if !has {
+			continue
+		}
+
+		pos := chunkPosition(transform.Position)
+		if pos == w.entityChunks[id] {
+			continue
+		}
+
+		// Сущность перешла в соседний чанк
+		if chunk, exists := w.Chunks[pos]; exists {
+			changes = append(changes, membershipChange{id: id, chunk: chunk})
+		}
+	}
+	return changes
+}
+
+// applyMembershipChanges переносит сущности между списками чанков.
+// Вызывается при захваченном на запись chunkMutex.
+func (w *World) applyMembershipChanges(changes []membershipChange) {
+	for _, change := range changes {
+		w.removeChunkEntity(change.id)
+		if change.chunk != nil {
+			w.addChunkEntities(change.chunk, change.id)
+		}
+	}
+}
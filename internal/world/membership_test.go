@@ -0,0 +1,98 @@
+package world
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// newMembershipWorld создает мир с двумя соседними активными чанками (0,0) и (1,0)
+func newMembershipWorld() *World {
+	w := &World{
+		ECSWorld:     ecs.NewWorld(),
+		Chunks:       make(map[[2]int]*Chunk),
+		ActiveChunks: make(map[[2]int]*Chunk),
+		entityChunks: make(map[ecs.EntityID][2]int),
+	}
+	for x := 0; x <= 1; x++ {
+		pos := [2]int{x, 0}
+		chunk := &Chunk{Position: pos, IsActive: true}
+		w.Chunks[pos] = chunk
+		w.ActiveChunks[pos] = chunk
+	}
+	return w
+}
+
+// placeInChunk добавляет сущность в точку и в список ее чанка
+func placeInChunk(w *World, position ecs.Vector3) *ecs.Entity {
+	entity := ecs.NewEntity()
+	entity.AddComponent(ecs.NewTransformComponent(position))
+	w.ECSWorld.AddEntity(entity)
+	w.addChunkEntities(w.Chunks[chunkPosition(position)], entity.ID)
+	return entity
+}
+
+// sameEntities сравнивает список чанка с ожидаемым без учета порядка
+func sameEntities(got []ecs.EntityID, want ...ecs.EntityID) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[ecs.EntityID]bool, len(got))
+	for _, id := range got {
+		seen[id] = true
+	}
+	for _, id := range want {
+		if !seen[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMembershipFollowsMovesAndRemovals(t *testing.T) {
+	w := newMembershipWorld()
+	walker := placeInChunk(w, ecs.Vector3{X: ChunkSize - 1, Z: 5})
+	doomed := placeInChunk(w, ecs.Vector3{X: 5, Z: 5})
+	stayer := placeInChunk(w, ecs.Vector3{X: 10, Z: 10})
+
+	// Животное переходит границу, другое погибает
+	transform, _ := ecs.ComponentAs[*ecs.TransformComponent](walker, ecs.TransformComponentID)
+	transform.Position.X = ChunkSize + 1
+	w.ECSWorld.RemoveEntity(doomed.ID)
+
+	// До конца интервала списки не меняются
+	w.updateChunkMembership(MembershipInterval / 2)
+	if !sameEntities(w.Chunks[[2]int{0, 0}].Entities, walker.ID, doomed.ID, stayer.ID) {
+		t.Fatalf("membership changed before the interval passed: %v", w.Chunks[[2]int{0, 0}].Entities)
+	}
+
+	w.updateChunkMembership(MembershipInterval / 2)
+	if got := w.Chunks[[2]int{0, 0}].Entities; !sameEntities(got, stayer.ID) {
+		t.Errorf("chunk (0,0) lists %v, want only the entity that stayed", got)
+	}
+	if got := w.Chunks[[2]int{1, 0}].Entities; !sameEntities(got, walker.ID) {
+		t.Errorf("chunk (1,0) lists %v, want the entity that walked in", got)
+	}
+	if pos, tracked := w.ChunkOf(walker.ID); !tracked || pos != [2]int{1, 0} {
+		t.Errorf("ChunkOf(walker) = %v, %v; want (1,0)", pos, tracked)
+	}
+	if _, tracked := w.ChunkOf(doomed.ID); tracked {
+		t.Errorf("removed entity is still owned by a chunk")
+	}
+}
+
+func TestMembershipKeepsEntitiesWalkingIntoUngeneratedChunks(t *testing.T) {
+	w := newMembershipWorld()
+	wanderer := placeInChunk(w, ecs.Vector3{X: 5, Z: 5})
+
+	transform, _ := ecs.ComponentAs[*ecs.TransformComponent](wanderer, ecs.TransformComponentID)
+	transform.Position.Z = -5
+	w.updateChunkMembership(MembershipInterval)
+
+	if got := w.Chunks[[2]int{0, 0}].Entities; !sameEntities(got, wanderer.ID) {
+		t.Errorf("chunk (0,0) lists %v, want the wanderer kept until its chunk exists", got)
+	}
+	if _, generated := w.Chunks[[2]int{0, -1}]; generated {
+		t.Errorf("membership sync generated the chunk the wanderer walked into")
+	}
+}
@@ -0,0 +1,44 @@
+package world
+
+import (
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Параметры заглушения звука рельефом
+const (
+	MaxOcclusion          = 0.85 // Звук огибает препятствия, поэтому рельеф не глушит его полностью
+	OcclusionFullDepth    = 6.0  // Глубина, на которую рельеф должен перекрыть луч для полного заглушения
+	occlusionSourceHeight = 1.0  // Высота источника звука над землей
+)
+
+// ComputeOcclusion возвращает, насколько рельеф заглушает звук из точки to для
+// слушателя в точке from: 0 - на открытом месте, MaxOcclusion - за высоким хребтом.
+// Заглушение растет с тем, насколько глубоко рельеф перекрывает прямую от уха до источника.
+// Учитываются только уже сгенерированные чанки.
+func (w *World) ComputeOcclusion(from, to ecs.Vector3) float64 {
+	w.chunkMutex.RLock()
+	defer w.chunkMutex.RUnlock()
+
+	ear := ecs.Vector3{X: from.X, Y: w.groundHeight(from.X, from.Z, from.Y) + EyeHeight, Z: from.Z}
+	source := ecs.Vector3{X: to.X, Y: w.groundHeight(to.X, to.Z, to.Y) + occlusionSourceHeight, Z: to.Z}
+
+	distance := math.Hypot(source.X-ear.X, source.Z-ear.Z)
+	steps := int(distance / sightSampleStep)
+
+	depth := 0.0
+	for i := 1; i < steps; i++ {
+		t := float64(i) / float64(steps)
+		x := ear.X + (source.X-ear.X)*t
+		z := ear.Z + (source.Z-ear.Z)*t
+		rayHeight := ear.Y + (source.Y-ear.Y)*t
+
+		depth = math.Max(depth, w.groundHeight(x, z, math.Inf(-1))-rayHeight)
+	}
+
+	if depth <= 0 {
+		return 0
+	}
+	return MaxOcclusion * math.Min(1.0, depth/OcclusionFullDepth)
+}
@@ -0,0 +1,118 @@
+package world
+
+import (
+	"math/rand"
+	"sort"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// Параметры промотки времени отсутствия игрока
+const (
+	WeatherPeriod          = 600.0 // Секунд между сменами погоды
+	StormForecastLead      = 120.0 // За сколько секунд до бури о ней становится известно
+	MaxOfflineWeatherTurns = 24    // Наибольшее число смен погоды за одно отсутствие
+	OfflineHotspotLevel    = 0.5   // Области с такой аномальностью меняются без игрока
+)
+
+// weatherTransitions - возможная погода после текущей
+var weatherTransitions = map[string][]string{
+	"clear":  {"clear", "clear", "cloudy", "fog"},
+	"cloudy": {"clear", "cloudy", "rain", "fog"},
+	"fog":    {"clear", "cloudy", "fog"},
+	"rain":   {"cloudy", "rain", "storm"},
+	"storm":  {"rain", "cloudy"},
+	"snow":   {"snow", "cloudy", "clear"},
+
+	// Туман искажений бывает только по воле ритуала и рассеивается в обычный
+	"distortion_fog": {"fog", "cloudy"},
+}
+
+// FastForward проматывает время отсутствия игрока: время суток идет дальше,
+// а погода сменяется раз в WeatherPeriod (не больше MaxOfflineWeatherTurns раз).
+// Смены погоды определяются длительностью отсутствия.
+func (w *World) FastForward(deltaTime float64) {
+	if deltaTime <= 0 {
+		return
+	}
+
+	w.advanceTimeOfDay(deltaTime)
+
+	turns := int(deltaTime / WeatherPeriod)
+	if turns > MaxOfflineWeatherTurns {
+		turns = MaxOfflineWeatherTurns
+	}
+
+	r := rand.New(rand.NewSource(w.Seed + int64(deltaTime)))
+	weather := w.NaturalWeather()
+	for i := 0; i < turns; i++ {
+		weather = nextWeather(weather, r)
+	}
+	w.setNaturalWeather(weather)
+}
+
+// PlanWeather выбирает погоду, которая сменит текущую через WeatherPeriod.
+// Погода выбирается заранее, чтобы о буре можно было предупредить (см. ForecastWeather).
+func (w *World) PlanWeather() string {
+	r := rand.New(rand.NewSource(w.Seed + int64(w.Day)*1000 + int64(w.TimeOfDay*1000)))
+	return nextWeather(w.NaturalWeather(), r)
+}
+
+// ForecastWeather объявляет погоду, которая наступит при следующей смене
+func (w *World) ForecastWeather(weather string) {
+	w.Forecast = weather
+}
+
+// TurnWeather сменяет погоду на выбранную заранее (см. PlanWeather), как это
+// происходит раз в WeatherPeriod игрового времени. Пока действует аренда погоды
+// (см. LeaseWeather), меняется только естественная погода.
+func (w *World) TurnWeather(weather string) {
+	w.Forecast = ""
+	w.setNaturalWeather(weather)
+}
+
+// nextWeather выбирает погоду, сменяющую текущую
+func nextWeather(current string, r *rand.Rand) string {
+	options, exists := weatherTransitions[current]
+	if !exists {
+		options = weatherTransitions["clear"]
+	}
+	return options[r.Intn(len(options))]
+}
+
+// AnomalyHotspots возвращает центры областей с аномальностью не ниже OfflineHotspotLevel,
+// от самых аномальных к наименее аномальным
+func (w *World) AnomalyHotspots() []metamorphosis.Hotspot {
+	if w.MetamorphManager == nil {
+		return nil
+	}
+
+	hotspots := make([]metamorphosis.Hotspot, 0)
+	for id, level := range w.MetamorphManager.GetLocalAnomalyLevels() {
+		if level < OfflineHotspotLevel {
+			continue
+		}
+		x, z, ok := parseAreaID(id)
+		if !ok {
+			continue
+		}
+
+		center := ecs.Vector3{X: (float64(x) + 0.5) * ChunkSize, Z: (float64(z) + 0.5) * ChunkSize}
+		w.chunkMutex.RLock()
+		center.Y = w.groundHeight(center.X, center.Z, 0)
+		w.chunkMutex.RUnlock()
+		hotspots = append(hotspots, metamorphosis.Hotspot{Center: center, Level: level})
+	}
+
+	sort.Slice(hotspots, func(i, j int) bool {
+		if hotspots[i].Level != hotspots[j].Level {
+			return hotspots[i].Level > hotspots[j].Level
+		}
+		if hotspots[i].Center.X != hotspots[j].Center.X {
+			return hotspots[i].Center.X < hotspots[j].Center.X
+		}
+		return hotspots[i].Center.Z < hotspots[j].Center.Z
+	})
+	return hotspots
+}
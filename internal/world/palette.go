@@ -0,0 +1,224 @@
+package world
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// palettesData - палитры биомов и категорий эффектов, которыми окрашиваются метаморфозы
+//
+//go:embed palettes.json
+var palettesData []byte
+
+// DefaultPaletteKey - палитра для биомов и категорий без своей палитры
+const DefaultPaletteKey = "default"
+
+// ColorPalette - цвета, в которые метаморфозы окрашивают сущности
+type ColorPalette struct {
+	Colors         []color.RGBA // Цвета, к которым смещается собственный цвет сущности
+	ColorblindSafe []color.RGBA // Цвета, различимые при нарушениях цветового зрения
+}
+
+// ColorPalettes - палитры по биомам и категориям эффектов
+type ColorPalettes struct {
+	Palettes       map[string]ColorPalette
+	ColorblindSafe []color.RGBA // Допустимые цвета доступного режима
+}
+
+// paletteFile - формат файла палитр
+type paletteFile struct {
+	ColorblindSafe []string `json:"colorblind_safe"`
+	Palettes       map[string]struct {
+		Colors         []string `json:"colors"`
+		ColorblindSafe []string `json:"colorblind_safe"`
+	} `json:"palettes"`
+}
+
+// DefaultColorPalettes возвращает палитры из встроенного файла palettes.json
+func DefaultColorPalettes() *ColorPalettes {
+	palettes, err := ParseColorPalettes(palettesData)
+	if err != nil {
+		panic(fmt.Sprintf("world: invalid built-in palettes: %v", err))
+	}
+	return palettes
+}
+
+// ParseColorPalettes разбирает файл палитр. Доступные цвета каждой палитры
+// должны входить в общий набор допустимых цветов, а палитра по умолчанию - быть задана.
+func ParseColorPalettes(data []byte) (*ColorPalettes, error) {
+	var file paletteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	approved, err := parseHexColors(file.ColorblindSafe)
+	if err != nil {
+		return nil, err
+	}
+	if len(approved) == 0 {
+		return nil, fmt.Errorf("no colorblind-safe colors")
+	}
+
+	palettes := &ColorPalettes{
+		Palettes:       make(map[string]ColorPalette, len(file.Palettes)),
+		ColorblindSafe: approved,
+	}
+	for key, entry := range file.Palettes {
+		colors, err := parseHexColors(entry.Colors)
+		if err != nil {
+			return nil, fmt.Errorf("palette %s: %v", key, err)
+		}
+		safe, err := parseHexColors(entry.ColorblindSafe)
+		if err != nil {
+			return nil, fmt.Errorf("palette %s: %v", key, err)
+		}
+		if len(colors) == 0 || len(safe) == 0 {
+			return nil, fmt.Errorf("palette %s needs both colors and colorblind-safe colors", key)
+		}
+		for _, c := range safe {
+			if !containsColor(approved, c) {
+				return nil, fmt.Errorf("palette %s: color %s is not in the colorblind-safe set", key, hexColor(c))
+			}
+		}
+
+		palettes.Palettes[key] = ColorPalette{Colors: colors, ColorblindSafe: safe}
+	}
+	if _, exists := palettes.Palettes[DefaultPaletteKey]; !exists {
+		return nil, fmt.Errorf("no %s palette", DefaultPaletteKey)
+	}
+
+	return palettes, nil
+}
+
+// palette возвращает первую заданную палитру из ключей (категория эффекта, биом, ...)
+func (cp *ColorPalettes) palette(keys ...string) ColorPalette {
+	for _, key := range keys {
+		if palette, exists := cp.Palettes[key]; exists {
+			return palette
+		}
+	}
+	return cp.Palettes[DefaultPaletteKey]
+}
+
+// mutationColor выбирает цвет, в который метаморфоза окрашивает сущность. Обычно
+// оттенок и яркость собственного цвета смещаются к цвету палитры тем сильнее,
+// чем сильнее эффект; в доступном режиме (ColorblindPalettes) берется ровно
+// один из доступных цветов палитры.
+func (w *World) mutationColor(base color.RGBA, intensity float64, r *rand.Rand, keys ...string) color.RGBA {
+	palette := w.Palettes.palette(keys...)
+	if w.ColorblindPalettes {
+		return palette.ColorblindSafe[r.Intn(len(palette.ColorblindSafe))]
+	}
+
+	target := palette.Colors[r.Intn(len(palette.Colors))]
+	return shiftColor(base, target, math.Max(0, math.Min(1, intensity)))
+}
+
+// shiftColor смещает оттенок, насыщенность и яркость цвета к целевому на долю amount
+func shiftColor(base, target color.RGBA, amount float64) color.RGBA {
+	h1, s1, v1 := rgbToHSV(base)
+	h2, s2, v2 := rgbToHSV(target)
+
+	// Оттенок идет по кратчайшей дуге
+	dh := math.Mod(h2-h1+540, 360) - 180
+	h := math.Mod(h1+dh*amount+360, 360)
+	s := s1 + (s2-s1)*amount
+	v := v1 + (v2-v1)*amount
+
+	shifted := hsvToRGB(h, s, v)
+	shifted.A = base.A
+	return shifted
+}
+
+// rgbToHSV переводит цвет в оттенок (0-360), насыщенность и яркость (0-1)
+func rgbToHSV(c color.RGBA) (float64, float64, float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	maxC := math.Max(r, math.Max(g, b))
+	minC := math.Min(r, math.Min(g, b))
+	delta := maxC - minC
+
+	h := 0.0
+	switch {
+	case delta == 0:
+	case maxC == r:
+		h = 60 * math.Mod((g-b)/delta+6, 6)
+	case maxC == g:
+		h = 60 * ((b-r)/delta + 2)
+	default:
+		h = 60 * ((r-g)/delta + 4)
+	}
+
+	s := 0.0
+	if maxC > 0 {
+		s = delta / maxC
+	}
+	return h, s, maxC
+}
+
+// hsvToRGB переводит оттенок, насыщенность и яркость в непрозрачный цвет
+func hsvToRGB(h, s, v float64) color.RGBA {
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return color.RGBA{
+		R: uint8(math.Round((r + m) * 255)),
+		G: uint8(math.Round((g + m) * 255)),
+		B: uint8(math.Round((b + m) * 255)),
+		A: 255,
+	}
+}
+
+// parseHexColors разбирает цвета вида #RRGGBB
+func parseHexColors(values []string) ([]color.RGBA, error) {
+	colors := make([]color.RGBA, 0, len(values))
+	for _, value := range values {
+		hex := strings.TrimPrefix(value, "#")
+		if len(hex) != 6 {
+			return nil, fmt.Errorf("invalid color %q", value)
+		}
+		rgb, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color %q", value)
+		}
+		colors = append(colors, color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 255})
+	}
+	return colors, nil
+}
+
+// hexColor записывает цвет в виде #RRGGBB
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+}
+
+// containsColor проверяет, есть ли цвет в наборе
+func containsColor(colors []color.RGBA, c color.RGBA) bool {
+	for _, candidate := range colors {
+		if candidate == c {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package world
+
+import (
+	"echo-taiga/internal/engine/ecs"
+)
+
+// ShelterRefugeRadius - на каком расстоянии от укрытия игрок считается под его защитой
+const ShelterRefugeRadius = 3.0
+
+// IsRefuge проверяет, может ли игрок перевести дух в точке: в безопасной зоне,
+// в свете костра или под укрытием (реализует threat.Refuge). Свет аномалий
+// убежищем не считается.
+func (w *World) IsRefuge(position ecs.Vector3) bool {
+	chunk := chunkPosition(position)
+	if w.IsInSafeZone(chunk[0], chunk[1]) {
+		return true
+	}
+
+	for _, campfire := range w.ECSWorld.GetEntitiesWithTag(TagCampfire) {
+		transformComp, hasTransform := campfire.GetComponent(ecs.TransformComponentID)
+		lightComp, hasLight := campfire.GetComponent(ecs.LightComponentID)
+		if !hasTransform || !hasLight {
+			continue
+		}
+		light := lightComp.(*ecs.LightComponent)
+		if light.Intensity > 0 && transformComp.(*ecs.TransformComponent).Position.Distance(position) <= light.Range {
+			return true
+		}
+	}
+
+	for _, shelter := range w.ECSWorld.GetEntitiesWithTag(TagShelter) {
+		if transformComp, has := shelter.GetComponent(ecs.TransformComponentID); has {
+			if transformComp.(*ecs.TransformComponent).Position.Distance(position) <= ShelterRefugeRadius {
+				return true
+			}
+		}
+	}
+
+	return false
+}
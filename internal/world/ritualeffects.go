@@ -0,0 +1,181 @@
+package world
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+	"echo-taiga/internal/symbols"
+)
+
+// Параметры эффектов ритуалов в мире
+const (
+	RitualCalmRadius         = 15.0 // Радиус, в котором ритуал успокаивает животных
+	RitualLightRange         = 10.0 // Дальность света, вызванного ритуалом
+	RitualMetamorphRadius    = 10.0 // Радиус метаморфозы ритуала, если эффект его не задает
+	RitualSpawnLifetime      = 1800 // Секунд, которые живут призванные духи без заданной длительности
+	ritualSpiritStability    = 0.2  // Стабильность призванных духов
+	ritualSpiritAnomalyLevel = 0.5  // Аномальность враждебных духов
+)
+
+// ritualLightColor - теплый свет, которым ритуал отгоняет темноту
+var ritualLightColor = color.RGBA{R: 255, G: 220, B: 160, A: 255}
+
+// ApplyRitualEffect выполняет эффект ритуала или рунного слова в мире
+// (реализует symbols.RitualEffectApplier): призывает духов, запрашивает
+// метаморфозы с длительностью эффекта, зажигает временный свет и успокаивает
+// животных. Остальные эффекты система символов применяет сама.
+func (w *World) ApplyRitualEffect(effect symbols.RitualEffect, location ecs.Vector3) {
+	switch effect.Type {
+	case "spawn", "spawn_hostile":
+		w.spawnRitualEntities(effect, location)
+	case "metamorphosis":
+		w.requestRitualMetamorphosis(effect, location)
+	case "light":
+		w.lightRitualArea(effect, location)
+	case "calm":
+		w.calmAnimals(location, RitualCalmRadius)
+	}
+}
+
+// spawnRitualEntities призывает сущности эффекта по кругу радиуса SpawnRadius
+func (w *World) spawnRitualEntities(effect symbols.RitualEffect, location ecs.Vector3) {
+	count := effect.SpawnCount
+	if count < 1 {
+		count = 1
+	}
+	lifetime := float64(effect.Duration)
+	if lifetime <= 0 {
+		lifetime = RitualSpawnLifetime
+	}
+
+	positions := make([]ecs.Vector3, count)
+	for i := range positions {
+		angle := 2 * math.Pi * float64(i) / float64(count)
+		positions[i] = location.Add(ecs.Vector3{
+			X: math.Cos(angle) * effect.SpawnRadius,
+			Z: math.Sin(angle) * effect.SpawnRadius,
+		})
+	}
+
+	// Все духи эффекта добавляются в мир одной пачкой
+	spirits := w.addEffectEntities(positions, func(position ecs.Vector3) *ecs.Entity {
+		return newSpirit(position, effect.SpawnEntityType, effect.Type == "spawn_hostile")
+	})
+	for _, spirit := range spirits {
+		w.expireEntityAfter(spirit.ID, lifetime)
+	}
+}
+
+// requestRitualMetamorphosis создает метаморфозу ритуала вокруг места его
+// проведения. Эффект наследует длительность эффекта ритуала.
+func (w *World) requestRitualMetamorphosis(effect symbols.RitualEffect, location ecs.Vector3) {
+	if w.MetamorphManager == nil {
+		return
+	}
+
+	order := effect.MetamorphOrder
+	radius := effect.MetamorphArea
+	if radius <= 0 {
+		radius = RitualMetamorphRadius
+	}
+
+	request := metamorphosis.EffectRequest{
+		Order:     metamorphosis.OrderLevel(math.Max(1, float64(order))),
+		Center:    location,
+		Radius:    radius,
+		Intensity: math.Max(0.0, math.Min(1.0, effect.Value)),
+		Duration:  time.Duration(effect.Duration) * time.Second,
+		Source:    "ritual:" + effect.ID,
+	}
+
+	// Без бюджета, в неподходящей фазе или у якоря мир не откликается на ритуал
+	w.MetamorphManager.RequestEffect(request)
+}
+
+// lightRitualArea зажигает временный источник света на месте ритуала
+func (w *World) lightRitualArea(effect symbols.RitualEffect, location ecs.Vector3) {
+	entity := w.addEffectEntity(location, func(position ecs.Vector3) *ecs.Entity {
+		light := ecs.NewEntity()
+		light.AddComponent(ecs.NewTransformComponent(position))
+		light.AddComponent(ecs.NewLightComponent(ritualLightColor, math.Max(0.1, effect.Value), RitualLightRange))
+		return light
+	})
+	if entity != nil {
+		w.expireEntityAfter(entity.ID, float64(effect.Duration))
+	}
+}
+
+// calmAnimals успокаивает животных в радиусе: они забывают цель и страх
+func (w *World) calmAnimals(location ecs.Vector3, radius float64) {
+	for _, animal := range w.ECSWorld.GetEntitiesWithTag(TagAnimal) {
+		transform, has := ecs.ComponentAs[*ecs.TransformComponent](animal, ecs.TransformComponentID)
+		if !has || transform.Position.Distance(location) > radius {
+			continue
+		}
+		if ai, has := ecs.ComponentAs[*ecs.AIComponent](animal, ecs.AIComponentID); has {
+			ai.CurrentState = "idle"
+			ai.TargetID = ""
+			ai.FearLevel = 0
+		}
+	}
+}
+
+// newSpirit создает духа, призванного ритуалом, не добавляя в мир.
+// Враждебные духи - ночные существа; дружественные безвредны и светятся.
+func newSpirit(position ecs.Vector3, spiritType string, hostile bool) *ecs.Entity {
+	if hostile || spiritType == "hostile_spirit" {
+		spirit := newCreature(position, "wraith", ritualSpiritAnomalyLevel)
+		spirit.AddTag(spiritType)
+		return spirit
+	}
+
+	spirit := ecs.NewEntity()
+	spirit.AddComponent(ecs.NewTransformComponent(position))
+
+	renderComp := ecs.NewRenderComponent("spirit_"+spiritType, "spirit_"+spiritType+"_texture")
+	renderComp.Effects.AddEffect("glow", 1.0, ecs.EffectSourceBase)
+	renderComp.Effects.AddEffect("transparency", 0.5, ecs.EffectSourceBase)
+	spirit.AddComponent(renderComp)
+
+	physicsComp := ecs.NewPhysicsComponent(0, false)
+	physicsComp.IsTrigger = true
+	spirit.AddComponent(physicsComp)
+
+	spirit.AddComponent(ecs.NewAIComponent("passive", 10.0))
+	spirit.AddComponent(ecs.NewMetamorphicComponent(ritualSpiritStability))
+	spirit.AddComponent(ecs.NewLightComponent(ritualLightColor, 0.4, 6.0))
+
+	spirit.AddTag(TagSpecial)
+	spirit.AddTag(spiritType)
+
+	return spirit
+}
+
+// expireEntityAfter удаляет сущность из мира через lifetime секунд игрового
+// времени; неположительное время оставляет сущность навсегда
+func (w *World) expireEntityAfter(id ecs.EntityID, lifetime float64) {
+	if lifetime <= 0 {
+		return
+	}
+	if w.expiringEntities == nil {
+		w.expiringEntities = make(map[ecs.EntityID]float64)
+	}
+	w.expiringEntities[id] = lifetime
+}
+
+// advanceExpiringEntities удаляет сущности, время жизни которых истекло.
+// Списки чанков освобождает сверка принадлежности (см. syncChunkMembership).
+func (w *World) advanceExpiringEntities(deltaTime float64) {
+	for id, remaining := range w.expiringEntities {
+		remaining -= deltaTime
+		if remaining > 0 {
+			w.expiringEntities[id] = remaining
+			continue
+		}
+		delete(w.expiringEntities, id)
+		w.ECSWorld.RemoveEntity(id)
+	}
+}
@@ -0,0 +1,66 @@
+package world
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/symbols"
+)
+
+// guardianSpirit - тип духа, которого призывают тесты
+var guardianSpirit = ecs.RegisterTag("guardian", "spirit", "")
+
+func TestRitualSpawnAddsSpiritsInOneBatch(t *testing.T) {
+	w := newNightWorld()
+	before := atomic.LoadUint64(&w.generation)
+
+	// Круг призыва в середине чанка 11 задевает соседние чанки 10 и 12
+	center := ecs.Vector3{X: 11.5 * ChunkSize, Z: ChunkSize / 2}
+	w.ApplyRitualEffect(symbols.RitualEffect{
+		Type:            "spawn",
+		SpawnEntityType: guardianSpirit,
+		SpawnCount:      4,
+		SpawnRadius:     ChunkSize * 0.75,
+		Duration:        60,
+	}, center)
+
+	spirits := w.ECSWorld.GetEntitiesWithTag(guardianSpirit)
+	if len(spirits) != 4 {
+		t.Fatalf("ritual summoned %d spirits, want 4", len(spirits))
+	}
+	if got := atomic.LoadUint64(&w.generation) - before; got != 1 {
+		t.Errorf("summoning changed the chunk generation %d times, want once for the batch", got)
+	}
+
+	chunksUsed := make(map[[2]int]bool)
+	for _, spirit := range spirits {
+		pos, tracked := w.entityChunks[spirit.ID]
+		if !tracked {
+			t.Errorf("spirit %s is not in any chunk list", spirit.ID)
+			continue
+		}
+		chunksUsed[pos] = true
+
+		transform, _ := ecs.ComponentAs[*ecs.TransformComponent](spirit, ecs.TransformComponentID)
+		if chunkPosition(transform.Position) != pos {
+			t.Errorf("spirit at %v listed in chunk %v", transform.Position, pos)
+		}
+		if w.expiringEntities[spirit.ID] != 60 {
+			t.Errorf("spirit %s expires in %v seconds, want 60", spirit.ID, w.expiringEntities[spirit.ID])
+		}
+	}
+	if len(chunksUsed) < 2 {
+		t.Errorf("spirits landed in chunks %v, want the circle to span neighbouring chunks", chunksUsed)
+	}
+}
+
+func TestRitualSpawnWithoutLoadedChunksAddsNothing(t *testing.T) {
+	ecsWorld := ecs.NewWorld()
+	w := &World{ECSWorld: ecsWorld, ActiveChunks: make(map[[2]int]*Chunk), entityChunks: make(map[ecs.EntityID][2]int)}
+
+	w.ApplyRitualEffect(symbols.RitualEffect{Type: "spawn", SpawnEntityType: guardianSpirit, SpawnCount: 3}, ecs.Vector3{})
+	if spirits := ecsWorld.GetEntitiesWithTag(guardianSpirit); len(spirits) != 0 {
+		t.Errorf("summoned %d spirits with no chunks loaded, want none", len(spirits))
+	}
+}
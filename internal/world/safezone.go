@@ -0,0 +1,36 @@
+package world
+
+import "math"
+
+// Безопасная зона вокруг начала мира дает новым игрокам спокойное место для старта
+const (
+	DefaultSafeZoneRadius = 2    // Радиус по умолчанию (в чанках)
+	SafeZoneAnomalyLevel  = 0.01 // Предельный уровень аномальности в безопасной зоне
+)
+
+// SetSafeZoneRadius задает радиус безопасной зоны в чанках (0 - зоны нет).
+// Уже сгенерированные чанки внутри зоны успокаиваются сразу.
+func (w *World) SetSafeZoneRadius(chunks int) {
+	if chunks < 0 {
+		chunks = 0
+	}
+	w.SafeZoneRadius = chunks
+
+	w.chunkMutex.Lock()
+	for pos, chunk := range w.Chunks {
+		if w.IsInSafeZone(pos[0], pos[1]) {
+			chunk.anomalyBaseline = math.Min(chunk.anomalyBaseline, SafeZoneAnomalyLevel)
+			chunk.AnomalyLevel = math.Min(chunk.AnomalyLevel, SafeZoneAnomalyLevel)
+		}
+	}
+	w.chunkMutex.Unlock()
+	w.invalidateAnomalyFields()
+}
+
+// IsInSafeZone проверяет, лежит ли чанк внутри безопасной зоны
+func (w *World) IsInSafeZone(chunkX, chunkZ int) bool {
+	if w.SafeZoneRadius <= 0 {
+		return false
+	}
+	return chunkX*chunkX+chunkZ*chunkZ <= w.SafeZoneRadius*w.SafeZoneRadius
+}
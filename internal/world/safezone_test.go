@@ -0,0 +1,76 @@
+package world
+
+import (
+	"strings"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// newSafeZoneWorld создает мир с безопасной зоной заданного радиуса
+func newSafeZoneWorld(radius int) *World {
+	ecsWorld := ecs.NewWorld()
+	w := NewWorld(1, ecsWorld, metamorphosis.NewMetamorphosisManager(ecsWorld, ""))
+	w.SetSafeZoneRadius(radius)
+	return w
+}
+
+// queuedCreatures считает существ, ожидающих добавления в мир
+func queuedCreatures(w *World) int {
+	count := 0
+	for _, pending := range w.pendingEntities {
+		render, has := ecs.ComponentAs[*ecs.RenderComponent](pending.entity, ecs.RenderComponentID)
+		if has && strings.HasPrefix(render.ModelID, "creature_") {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSafeZoneCalmsChunksAndNights(t *testing.T) {
+	w := newSafeZoneWorld(2)
+	inside := w.generateChunk(1, 1)
+	outside := w.generateChunk(3, 0)
+
+	if inside.AnomalyLevel > SafeZoneAnomalyLevel {
+		t.Errorf("anomaly %v inside the safe zone, want at most %v", inside.AnomalyLevel, SafeZoneAnomalyLevel)
+	}
+	if outside.AnomalyLevel <= SafeZoneAnomalyLevel {
+		t.Errorf("anomaly %v just outside the safe zone, want the usual level", outside.AnomalyLevel)
+	}
+
+	// За такой шаг ночное существо появляется наверняка, если ему это разрешено
+	w.TimeOfDay = 0.9
+	w.updateChunk(inside, 100)
+	if n := queuedCreatures(w); n != 0 {
+		t.Errorf("%d night creatures spawned inside the safe zone", n)
+	}
+	w.updateChunk(outside, 100)
+	if n := queuedCreatures(w); n != 1 {
+		t.Errorf("%d night creatures spawned just outside the safe zone, want 1", n)
+	}
+}
+
+func TestSetSafeZoneRadiusCalmsGeneratedChunks(t *testing.T) {
+	w := newSafeZoneWorld(0)
+	chunk := w.generateChunk(3, 0)
+	w.Chunks[chunk.Position] = chunk
+	if w.IsInSafeZone(0, 0) || chunk.AnomalyLevel <= SafeZoneAnomalyLevel {
+		t.Fatalf("radius 0 left a safe zone: origin safe %v, anomaly %v at (3, 0)", w.IsInSafeZone(0, 0), chunk.AnomalyLevel)
+	}
+
+	w.SetSafeZoneRadius(3)
+	if w.SafeZoneRadius != 3 || !w.IsInSafeZone(3, 0) || w.IsInSafeZone(3, 1) {
+		t.Fatalf("radius %d does not cover exactly the chunks within 3", w.SafeZoneRadius)
+	}
+	if chunk.AnomalyLevel > SafeZoneAnomalyLevel {
+		t.Errorf("anomaly %v of a generated chunk inside the new zone, want at most %v", chunk.AnomalyLevel, SafeZoneAnomalyLevel)
+	}
+
+	// Отрицательный радиус отключает зону
+	w.SetSafeZoneRadius(-1)
+	if w.SafeZoneRadius != 0 || w.IsInSafeZone(0, 0) {
+		t.Errorf("negative radius left a safe zone of radius %d", w.SafeZoneRadius)
+	}
+}
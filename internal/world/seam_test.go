@@ -0,0 +1,80 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"echo-taiga/internal/world/biomes"
+	"echo-taiga/internal/world/terrain"
+)
+
+// seamTolerance - допустимый перепад высот на границе чанков
+const seamTolerance = 0.01
+
+// newSeamWorld генерирует только террейн чанков 3x3 вокруг начала мира
+func newSeamWorld(seed int64) *World {
+	w := &World{Seed: seed, Chunks: make(map[[2]int]*Chunk)}
+	w.TerrainGenerator = terrain.NewGenerator(seed, biomes.NewBiomeMap(BiomeRegionSize, seed))
+	w.TerrainGenerator.NeighborTerrain = w.generatedTerrain
+	for x := -1; x <= 1; x++ {
+		for z := -1; z <= 1; z++ {
+			w.Chunks[[2]int{x, z}] = &Chunk{Position: [2]int{x, z}, Terrain: w.TerrainGenerator.GenerateChunkTerrain(x, z, ChunkSize)}
+		}
+	}
+	return w
+}
+
+// worldHeight возвращает высоту клетки по мировым координатам клетки
+func worldHeight(w *World, x, z int) float64 {
+	chunkX := int(math.Floor(float64(x) / ChunkSize))
+	chunkZ := int(math.Floor(float64(z) / ChunkSize))
+	td := w.Chunks[[2]int{chunkX, chunkZ}].Terrain
+	return td.HeightMap[x-chunkX*ChunkSize][z-chunkZ*ChunkSize]
+}
+
+// slopes возвращает наибольшие перепады высот между соседними клетками на
+// границах чанков и внутри них
+func slopes(w *World) (seam, interior float64) {
+	for x := -ChunkSize; x < 2*ChunkSize; x++ {
+		for z := -ChunkSize; z < 2*ChunkSize; z++ {
+			for _, next := range [][2]int{{x + 1, z}, {x, z + 1}} {
+				if next[0] >= 2*ChunkSize || next[1] >= 2*ChunkSize {
+					continue
+				}
+				delta := math.Abs(worldHeight(w, next[0], next[1]) - worldHeight(w, x, z))
+				crossesSeam := (next[0] != x && (next[0]%ChunkSize+ChunkSize)%ChunkSize == 0) ||
+					(next[1] != z && (next[1]%ChunkSize+ChunkSize)%ChunkSize == 0)
+				if crossesSeam {
+					seam = math.Max(seam, delta)
+				} else {
+					interior = math.Max(interior, delta)
+				}
+			}
+		}
+	}
+	return seam, interior
+}
+
+func TestChunkSeamsStayClosed(t *testing.T) {
+	w := newSeamWorld(1)
+	if seam, interior := slopes(w); seam > seamTolerance {
+		t.Errorf("generated terrain: seam step %v (interior steps up to %v)", seam, interior)
+	}
+
+	// Искажение затухает у краев и не разрывает сшитые границы
+	center := w.Chunks[[2]int{0, 0}]
+	center.Terrain.ApplyDistortion(1.0)
+	if seam, _ := slopes(w); seam > seamTolerance {
+		t.Errorf("after distortion: seam step %v", seam)
+	}
+
+	// Пустота сшивается с соседями после преобразования
+	center.Terrain = createVoidTerrain(0, 0)
+	if seam, _ := slopes(w); seam <= seamTolerance {
+		t.Fatalf("unstitched void terrain already matches its neighbors; the check below proves nothing")
+	}
+	w.TerrainGenerator.StitchChunk(center.Terrain, 0, 0)
+	if seam, _ := slopes(w); seam > seamTolerance {
+		t.Errorf("after void conversion: seam step %v", seam)
+	}
+}
@@ -0,0 +1,52 @@
+package world
+
+import (
+	"math"
+
+	"echo-taiga/internal/engine/ecs"
+)
+
+// Параметры проверки прямой видимости
+const (
+	EyeHeight         = 1.7 // Высота глаз наблюдателя над землей
+	sightSampleStep   = 2.0 // Шаг выборки высот вдоль луча
+	sightTargetHeight = 1.0 // Высота точки цели над землей
+)
+
+// HasLineOfSight проверяет, не закрывает ли рельеф точку to от наблюдателя в точке from.
+// Учитываются только уже сгенерированные чанки: неизвестный рельеф видимость не закрывает.
+func (w *World) HasLineOfSight(from, to ecs.Vector3) bool {
+	w.chunkMutex.RLock()
+	defer w.chunkMutex.RUnlock()
+
+	eye := ecs.Vector3{X: from.X, Y: w.groundHeight(from.X, from.Z, from.Y) + EyeHeight, Z: from.Z}
+	target := ecs.Vector3{X: to.X, Y: w.groundHeight(to.X, to.Z, to.Y) + sightTargetHeight, Z: to.Z}
+
+	distance := math.Hypot(target.X-eye.X, target.Z-eye.Z)
+	steps := int(distance / sightSampleStep)
+	for i := 1; i < steps; i++ {
+		t := float64(i) / float64(steps)
+		x := eye.X + (target.X-eye.X)*t
+		z := eye.Z + (target.Z-eye.Z)*t
+		rayHeight := eye.Y + (target.Y-eye.Y)*t
+
+		if w.groundHeight(x, z, math.Inf(-1)) > rayHeight {
+			return false
+		}
+	}
+
+	return true
+}
+
+// groundHeight возвращает высоту рельефа в точке или fallback, если чанк не сгенерирован.
+// Вызывается при захваченном chunkMutex (достаточно чтения).
+func (w *World) groundHeight(x, z, fallback float64) float64 {
+	chunkX := int(math.Floor(x / ChunkSize))
+	chunkZ := int(math.Floor(z / ChunkSize))
+
+	chunk, exists := w.Chunks[[2]int{chunkX, chunkZ}]
+	if !exists || chunk.Terrain == nil {
+		return fallback
+	}
+	return chunk.Terrain.GetHeightAt(x-float64(chunkX*ChunkSize), z-float64(chunkZ*ChunkSize))
+}
@@ -0,0 +1,160 @@
+package world
+
+import (
+	"fmt"
+	"image/color"
+
+	"echo-taiga/internal/engine"
+	"echo-taiga/internal/engine/ecs"
+)
+
+// AncientCircleChance - шанс, что в чанке стоит древний круг камней
+const AncientCircleChance = 0.03
+
+// biomeRitualLocations сопоставляет биомы типам мест ритуалов
+var biomeRitualLocations = map[string]string{
+	"taiga": "forest",
+	"marsh": "water",
+	"rocky": "hill",
+}
+
+// caveLocation - место ритуала у входа в пещеру. Пещеры встречаются только
+// в биомах из caveBiomes, и там забирают часть кругов у обычного типа места.
+const caveLocation = "cave"
+
+// caveBiomes - шанс, что круг в биоме стоит у пещеры
+var caveBiomes = map[string]float64{
+	"rocky": 0.5,
+}
+
+// ritualLocationForBiome возвращает тип места ритуала для биома
+func ritualLocationForBiome(biomeType string) string {
+	if location, exists := biomeRitualLocations[biomeType]; exists {
+		return location
+	}
+	return "forest"
+}
+
+// LandmarkPlanner решает, где стоят древние круги камней. Решение для чанка
+// берется из отдельного потока случайных чисел, зависящего только от сида мира
+// и координат чанка: круги появляются в любом биоме и не сдвигают остальную
+// генерацию чанка.
+type LandmarkPlanner struct {
+	Seed         int64
+	CircleChance float64
+}
+
+// PlannedCircle - древний круг камней, запланированный в чанке
+type PlannedCircle struct {
+	X, Z     float64 // Центр круга в мировых координатах
+	Seed     int64   // Сид откровения круга
+	Location string  // Тип места ритуала
+}
+
+// NewLandmarkPlanner создает планировщик с настройками по умолчанию
+func NewLandmarkPlanner(seed int64) *LandmarkPlanner {
+	return &LandmarkPlanner{
+		Seed:         seed,
+		CircleChance: AncientCircleChance,
+	}
+}
+
+// CircleIn возвращает древний круг камней чанка, если он там есть
+func (lp *LandmarkPlanner) CircleIn(chunkX, chunkZ int, biomeType string) (PlannedCircle, bool) {
+	r := engine.NewRandStream(lp.Seed, fmt.Sprintf("landmark_%d_%d", chunkX, chunkZ))
+	if r.Float64() >= lp.CircleChance {
+		return PlannedCircle{}, false
+	}
+
+	worldX := float64(chunkX * ChunkSize)
+	worldZ := float64(chunkZ * ChunkSize)
+	circle := PlannedCircle{
+		X:        worldX + ChunkSize*0.5 + (r.Float64()-0.5)*ChunkSize*0.5,
+		Z:        worldZ + ChunkSize*0.5 + (r.Float64()-0.5)*ChunkSize*0.5,
+		Seed:     r.Int63(),
+		Location: ritualLocationForBiome(biomeType),
+	}
+
+	// Пещера решается последним броском, чтобы не сдвигать позиции и сиды кругов
+	if chance, exists := caveBiomes[biomeType]; exists && r.Float64() < chance {
+		circle.Location = caveLocation
+	}
+	return circle, true
+}
+
+// spawnAncientCircle ставит древний круг камней, если планировщик отвел его чанку
+func (w *World) spawnAncientCircle(chunk *Chunk, stability float64) {
+	planned, exists := w.LandmarkPlanner.CircleIn(chunk.Position[0], chunk.Position[1], chunk.BiomeType)
+	if !exists {
+		return
+	}
+
+	worldX := float64(chunk.Position[0] * ChunkSize)
+	worldZ := float64(chunk.Position[1] * ChunkSize)
+	position := ecs.Vector3{X: planned.X, Y: chunk.Terrain.GetHeightAt(planned.X-worldX, planned.Z-worldZ), Z: planned.Z}
+
+	circleEntity := createAncientCircle(w.ECSWorld, position, planned.Seed, planned.Location, stability, w.interactWithAncientSite)
+	w.addChunkEntities(chunk, circleEntity.ID)
+
+	// Сохраненное состояние якорей чанка заменяет якоря генерации
+	if _, saved := w.savedAnchors[chunk.Position]; !saved {
+		w.spawnLandmarkAnchor(chunk, position, planned.Seed)
+	}
+}
+
+// interactWithAncientSite передает взаимодействие с кругом камней обработчику мира
+func (w *World) interactWithAncientSite(actor, site *ecs.Entity) bool {
+	if w.OnAncientSiteInteract == nil {
+		return true
+	}
+	return w.OnAncientSiteInteract(actor, site)
+}
+
+// interactWithSymbol передает изучение вырезанного символа обработчику мира
+func (w *World) interactWithSymbol(actor, symbol *ecs.Entity) bool {
+	if w.OnSymbolInteract == nil {
+		return true
+	}
+	return w.OnSymbolInteract(actor, symbol)
+}
+
+// createAncientCircle создает древний круг стоячих камней
+func createAncientCircle(world *ecs.World, position ecs.Vector3, seed int64, location string, stabilityModifier float64, onInteract func(actor, target *ecs.Entity) bool) *ecs.Entity {
+	circle := ecs.NewEntity()
+
+	// Добавляем базовые компоненты
+	circle.AddComponent(ecs.NewTransformComponent(position))
+	circle.AddComponent(ecs.NewRenderComponent("stone_circle", "stone_circle_texture"))
+
+	// Камни не двигаются
+	physicsComp := ecs.NewPhysicsComponent(1000, true)
+	physicsComp.ColliderSize = ecs.Vector3{X: 6.0, Y: 2.5, Z: 6.0}
+	circle.AddComponent(physicsComp)
+
+	// Откровение места определяется его сидом
+	circle.AddComponent(ecs.NewAncientSiteComponent(seed, location))
+
+	// Добавляем интерактивный компонент
+	interactComp := ecs.NewInteractableComponent("examine", "Изучить резьбу на камнях", 4.0)
+	interactComp.InteractCallback = onInteract
+	circle.AddComponent(interactComp)
+
+	// Древние камни очень стабильны
+	circle.AddComponent(ecs.NewMetamorphicComponent(scaledStability(0.9, stabilityModifier)))
+
+	// Добавляем теги
+	circle.AddTag(TagAncientSite)
+	circle.AddTag(TagRitualSite)
+	circle.AddTag(TagEnvironment)
+	circle.AddTag(TagInteractive)
+	circle.AddTag(location)
+
+	// Слабое свечение резьбы
+	lightComp := ecs.NewLightComponent(color.RGBA{R: 150, G: 200, B: 180, A: 255}, 0.2, 8.0)
+	circle.AddComponent(lightComp)
+
+	// Добавляем сущность в мир
+	world.AddEntity(circle)
+
+	return circle
+}
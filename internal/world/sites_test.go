@@ -0,0 +1,73 @@
+package world
+
+import "testing"
+
+func TestLandmarkPlannerIsDeterministic(t *testing.T) {
+	a, b := NewLandmarkPlanner(7), NewLandmarkPlanner(7)
+	for x := -20; x < 20; x++ {
+		for z := -20; z < 20; z++ {
+			first, okFirst := a.CircleIn(x, z, "taiga")
+			second, okSecond := b.CircleIn(x, z, "taiga")
+			if okFirst != okSecond || first != second {
+				t.Fatalf("chunk %d,%d planned %+v/%v and %+v/%v", x, z, first, okFirst, second, okSecond)
+			}
+		}
+	}
+}
+
+func TestLandmarkPlannerPlacesCirclesInEveryBiome(t *testing.T) {
+	planner := NewLandmarkPlanner(11)
+	planner.CircleChance = 1
+
+	for biome, location := range biomeRitualLocations {
+		circle, ok := planner.CircleIn(3, -4, biome)
+		if !ok {
+			t.Fatalf("no circle planned in %s with chance 1", biome)
+		}
+		if circle.Location != location && (circle.Location != caveLocation || caveBiomes[biome] == 0) {
+			t.Errorf("circle in %s has location %q, want %q", biome, circle.Location, location)
+		}
+		if circle.X < 3*ChunkSize || circle.X >= 4*ChunkSize || circle.Z < -4*ChunkSize || circle.Z >= -3*ChunkSize {
+			t.Errorf("circle in %s at %.1f,%.1f lies outside chunk 3,-4", biome, circle.X, circle.Z)
+		}
+	}
+}
+
+func TestLandmarkPlannerPlacesCavesInRockyBiome(t *testing.T) {
+	planner := NewLandmarkPlanner(5)
+	planner.CircleChance = 1
+
+	locations := make(map[string]int)
+	for x := 0; x < 20; x++ {
+		for z := 0; z < 20; z++ {
+			circle, _ := planner.CircleIn(x, z, "rocky")
+			locations[circle.Location]++
+
+			// В тайге пещер нет
+			if other, _ := planner.CircleIn(x, z, "taiga"); other.Location == caveLocation {
+				t.Fatalf("taiga circle at chunk %d,%d is a cave", x, z)
+			}
+		}
+	}
+
+	if locations[caveLocation] == 0 || locations["hill"] == 0 {
+		t.Errorf("rocky circles by location %v, want both caves and hills", locations)
+	}
+}
+
+func TestLandmarkPlannerChance(t *testing.T) {
+	planner := NewLandmarkPlanner(3)
+	circles := 0
+	for x := 0; x < 100; x++ {
+		for z := 0; z < 100; z++ {
+			if _, ok := planner.CircleIn(x, z, "marsh"); ok {
+				circles++
+			}
+		}
+	}
+
+	// 3% от 10000 чанков
+	if circles < 200 || circles > 400 {
+		t.Errorf("%d circles in 10000 chunks, want about %d", circles, int(AncientCircleChance*10000))
+	}
+}
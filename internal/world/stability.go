@@ -0,0 +1,20 @@
+package world
+
+import (
+	"math"
+
+	"echo-taiga/internal/world/biomes"
+)
+
+// biomeStability возвращает множитель стабильности сущностей, который объявляет биом
+func (w *World) biomeStability(biomeType string) float64 {
+	if w.BiomeMap == nil {
+		return 1.0
+	}
+	return w.BiomeMap.GetBiome(biomes.BiomeType(biomeType)).EntityStability()
+}
+
+// scaledStability применяет множитель биома к базовой стабильности сущности
+func scaledStability(base, modifier float64) float64 {
+	return math.Max(0.0, math.Min(1.0, base*modifier))
+}
@@ -0,0 +1,43 @@
+package world
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/world/biomes"
+)
+
+// treeStability возвращает метаморфную стабильность дерева, созданного в биоме
+func treeStability(t *testing.T, biome *biomes.Biome) float64 {
+	t.Helper()
+
+	tree := newTree(ecs.Vector3{}, 0.5, biome.EntityStability())
+	component, has := tree.GetComponent(ecs.MetamorphicComponentID)
+	if !has {
+		t.Fatalf("tree in %s has no metamorphic component", biome.Name)
+	}
+	return component.(*ecs.MetamorphicComponent).Stability
+}
+
+func TestDistortedBiomeSpawnsLessStableTrees(t *testing.T) {
+	taiga := treeStability(t, biomes.NewBiome("taiga", "Taiga"))
+	distorted := treeStability(t, biomes.NewBiome("distorted", "Distorted forest"))
+
+	if taiga != 0.8 {
+		t.Errorf("taiga tree stability = %v, want the base 0.8", taiga)
+	}
+	if distorted >= taiga {
+		t.Errorf("distorted tree stability = %v, want below taiga's %v", distorted, taiga)
+	}
+}
+
+func TestBiomeWithoutModifierKeepsBaseStability(t *testing.T) {
+	if got := (&biomes.Biome{Name: "unknown"}).EntityStability(); got != 1.0 {
+		t.Errorf("EntityStability() without a modifier = %v, want 1", got)
+	}
+
+	var missing *biomes.Biome
+	if got := missing.EntityStability(); got != 1.0 {
+		t.Errorf("EntityStability() of a missing biome = %v, want 1", got)
+	}
+}
@@ -0,0 +1,366 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/savefile"
+)
+
+// SymbolKinds - типы символов мира; каждый открывает свою ветку ритуалов
+var SymbolKinds = []string{"elemental", "arcane", "primal", "void"}
+
+// Настройки планировщика символов по умолчанию
+const (
+	DefaultSymbolRegionSize     = 4 // Размер региона учета (в чанках)
+	DefaultSymbolCoverageRadius = 3 // Радиус вокруг начала мира с гарантией всех типов (в чанках)
+)
+
+// PlannedSymbol - символ, гарантированно размещенный планировщиком
+type PlannedSymbol struct {
+	Kind     string      `json:"kind"`
+	SymbolID string      `json:"symbol_id"` // Символ реестра, вырезанный в мире
+	Chunk    [2]int      `json:"chunk"`
+	Position ecs.Vector3 `json:"position"`
+}
+
+// SymbolCatalog - реестр символов (например, symbols.Registry), из которого
+// планировщик берет символы, вырезанные в мире
+type SymbolCatalog interface {
+	// SymbolIDsOfType возвращает ID символов типа в стабильном порядке
+	SymbolIDsOfType(symbolType string) []string
+}
+
+// SymbolPlacementPlanner распределяет типы символов при генерации мира так,
+// чтобы ни один тип не оставался недоступным. Выбор определяется сидом мира и
+// уже сделанными размещениями; состояние сохраняется вместе с игрой, чтобы
+// продолжение старого сохранения не размещало символы повторно.
+type SymbolPlacementPlanner struct {
+	RegionSize     int `json:"region_size"`
+	CoverageRadius int `json:"coverage_radius"`
+
+	ChunkKinds   map[string][]string `json:"chunk_kinds"`   // Типы символов, выбранные для чанков
+	ChunkSymbols map[string][]string `json:"chunk_symbols"` // Символы реестра, выбранные для чанков
+	Guaranteed   []PlannedSymbol     `json:"guaranteed"`    // Символы, добавленные ради покрытия типов
+
+	regionCounts map[[2]int]map[string]int
+}
+
+// NewSymbolPlacementPlanner создает планировщик с настройками по умолчанию
+func NewSymbolPlacementPlanner() *SymbolPlacementPlanner {
+	return &SymbolPlacementPlanner{
+		RegionSize:     DefaultSymbolRegionSize,
+		CoverageRadius: DefaultSymbolCoverageRadius,
+		ChunkKinds:     make(map[string][]string),
+		ChunkSymbols:   make(map[string][]string),
+		Guaranteed:     make([]PlannedSymbol, 0),
+		regionCounts:   make(map[[2]int]map[string]int),
+	}
+}
+
+// chunkKey возвращает ключ чанка для сохранения
+func chunkKey(chunkX, chunkZ int) string {
+	return fmt.Sprintf("%d,%d", chunkX, chunkZ)
+}
+
+// regionOf возвращает регион учета, которому принадлежит чанк
+func (p *SymbolPlacementPlanner) regionOf(chunkX, chunkZ int) [2]int {
+	size := p.RegionSize
+	if size < 1 {
+		size = 1
+	}
+	return [2]int{
+		int(math.Floor(float64(chunkX) / float64(size))),
+		int(math.Floor(float64(chunkZ) / float64(size))),
+	}
+}
+
+// record учитывает символ указанного типа в регионе чанка
+func (p *SymbolPlacementPlanner) record(chunkX, chunkZ int, kind string) {
+	region := p.regionOf(chunkX, chunkZ)
+	counts, exists := p.regionCounts[region]
+	if !exists {
+		counts = make(map[string]int)
+		p.regionCounts[region] = counts
+	}
+	counts[kind]++
+}
+
+// ChooseKind выбирает тип символа для index-го символа чанка по броску roll (0-1),
+// смещая выбор к типам, которых в регионе меньше всего. Повторная генерация чанка
+// возвращает прежний выбор.
+func (p *SymbolPlacementPlanner) ChooseKind(chunkX, chunkZ, index int, roll float64) string {
+	key := chunkKey(chunkX, chunkZ)
+	if kinds := p.ChunkKinds[key]; index < len(kinds) {
+		return kinds[index]
+	}
+
+	// Вес типа обратно пропорционален числу уже размещенных в регионе символов
+	counts := p.regionCounts[p.regionOf(chunkX, chunkZ)]
+	weights := make([]float64, len(SymbolKinds))
+	total := 0.0
+	for i, kind := range SymbolKinds {
+		weights[i] = 1.0 / float64(1+counts[kind]*counts[kind])
+		total += weights[i]
+	}
+
+	roll *= total
+	kind := SymbolKinds[len(SymbolKinds)-1]
+	for i, weight := range weights {
+		if roll < weight {
+			kind = SymbolKinds[i]
+			break
+		}
+		roll -= weight
+	}
+
+	p.ChunkKinds[key] = append(p.ChunkKinds[key], kind)
+	p.record(chunkX, chunkZ, kind)
+	return kind
+}
+
+// ChooseSymbol возвращает символ реестра типа kind для index-го символа чанка.
+// Выбор запоминается, чтобы повторная генерация чанка вырезала тот же символ.
+func (p *SymbolPlacementPlanner) ChooseSymbol(chunkX, chunkZ, index int, kind string, catalog SymbolCatalog) string {
+	key := chunkKey(chunkX, chunkZ)
+	if ids := p.ChunkSymbols[key]; index < len(ids) {
+		return ids[index]
+	}
+
+	id := symbolFor(kind, fmt.Sprintf("%s/%d", key, index), catalog)
+	p.ChunkSymbols[key] = append(p.ChunkSymbols[key], id)
+	return id
+}
+
+// symbolFor выбирает символ реестра типа kind по ключу места, не тратя бросков
+// генератора чанка. Без символов этого типа в реестре ID строится из типа и места.
+func symbolFor(kind, place string, catalog SymbolCatalog) string {
+	var ids []string
+	if catalog != nil {
+		ids = catalog.SymbolIDsOfType(kind)
+	}
+	if len(ids) == 0 {
+		return kind + "@" + place
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(place))
+	return ids[hash.Sum32()%uint32(len(ids))]
+}
+
+// guaranteedIn возвращает гарантированные символы чанка
+func (p *SymbolPlacementPlanner) guaranteedIn(chunkX, chunkZ int) []PlannedSymbol {
+	result := make([]PlannedSymbol, 0)
+	for _, planned := range p.Guaranteed {
+		if planned.Chunk == [2]int{chunkX, chunkZ} {
+			result = append(result, planned)
+		}
+	}
+	return result
+}
+
+// inCoverage проверяет, лежит ли чанк в радиусе гарантированного покрытия
+func (p *SymbolPlacementPlanner) inCoverage(chunkX, chunkZ int) bool {
+	return chunkX*chunkX+chunkZ*chunkZ <= p.CoverageRadius*p.CoverageRadius
+}
+
+// KindsNearSpawn возвращает число символов каждого типа в радиусе покрытия
+func (p *SymbolPlacementPlanner) KindsNearSpawn() map[string]int {
+	result := make(map[string]int)
+	for key, kinds := range p.ChunkKinds {
+		var chunkX, chunkZ int
+		if _, err := fmt.Sscanf(key, "%d,%d", &chunkX, &chunkZ); err != nil || !p.inCoverage(chunkX, chunkZ) {
+			continue
+		}
+		for _, kind := range kinds {
+			result[kind]++
+		}
+	}
+	for _, planned := range p.Guaranteed {
+		if p.inCoverage(planned.Chunk[0], planned.Chunk[1]) {
+			result[planned.Kind]++
+		}
+	}
+	return result
+}
+
+// rebuildCounts восстанавливает счетчики регионов из сохраненного состояния
+func (p *SymbolPlacementPlanner) rebuildCounts() {
+	p.regionCounts = make(map[[2]int]map[string]int)
+	for key, kinds := range p.ChunkKinds {
+		var chunkX, chunkZ int
+		if _, err := fmt.Sscanf(key, "%d,%d", &chunkX, &chunkZ); err != nil {
+			continue
+		}
+		for _, kind := range kinds {
+			p.record(chunkX, chunkZ, kind)
+		}
+	}
+	for _, planned := range p.Guaranteed {
+		p.record(planned.Chunk[0], planned.Chunk[1], planned.Kind)
+	}
+}
+
+// ensureSymbolCoverage размещает недостающие типы символов в радиусе покрытия
+// вокруг начала мира: у ориентиров (поляны, круги камней), а если их нет - в
+// случайных точках чанков покрытия. Чанки покрытия генерируются при необходимости.
+func (w *World) ensureSymbolCoverage() {
+	planner := w.SymbolPlanner
+	radius := planner.CoverageRadius
+	if radius < 0 {
+		return
+	}
+
+	// Все чанки покрытия должны быть сгенерированы, чтобы учесть их символы
+	chunks := make([][2]int, 0)
+	for x := -radius; x <= radius; x++ {
+		for z := -radius; z <= radius; z++ {
+			if planner.inCoverage(x, z) {
+				w.GetChunkAt(x, z)
+				chunks = append(chunks, [2]int{x, z})
+			}
+		}
+	}
+
+	present := planner.KindsNearSpawn()
+	missing := make([]string, 0)
+	for _, kind := range SymbolKinds {
+		if present[kind] == 0 {
+			missing = append(missing, kind)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	// Ориентиры в порядке удаленности от начала мира
+	landmarks := w.coverageLandmarks()
+
+	r := rand.New(rand.NewSource(w.Seed ^ 0x5ea1ed))
+	for i, kind := range missing {
+		var position ecs.Vector3
+		if i < len(landmarks) {
+			// Символ вырезан рядом с ориентиром
+			position = landmarks[i].Add(ecs.Vector3{X: 1.5, Z: 1.5})
+		} else {
+			chunk := chunks[r.Intn(len(chunks))]
+			position = ecs.Vector3{
+				X: float64(chunk[0]*ChunkSize) + r.Float64()*ChunkSize,
+				Z: float64(chunk[1]*ChunkSize) + r.Float64()*ChunkSize,
+			}
+		}
+
+		chunkPos := [2]int{
+			int(math.Floor(position.X / ChunkSize)),
+			int(math.Floor(position.Z / ChunkSize)),
+		}
+		chunk := w.GetChunkAt(chunkPos[0], chunkPos[1])
+		position.Y = chunk.Terrain.GetHeightAt(position.X-float64(chunkPos[0]*ChunkSize), position.Z-float64(chunkPos[1]*ChunkSize))
+
+		planned := PlannedSymbol{
+			Kind:     kind,
+			SymbolID: symbolFor(kind, fmt.Sprintf("guaranteed/%d", len(planner.Guaranteed)), w.symbolCatalog),
+			Chunk:    chunkPos,
+			Position: position,
+		}
+		planner.Guaranteed = append(planner.Guaranteed, planned)
+		planner.record(chunkPos[0], chunkPos[1], kind)
+
+		w.spawnPlannedSymbol(chunk, planned)
+	}
+}
+
+// coverageLandmarks возвращает позиции мест ритуалов в радиусе покрытия, ближайшие первыми
+func (w *World) coverageLandmarks() []ecs.Vector3 {
+	landmarks := make([]ecs.Vector3, 0)
+	for _, entity := range w.ECSWorld.GetEntitiesWithTag(TagRitualSite) {
+		transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID)
+		if !has {
+			continue
+		}
+		chunkX := int(math.Floor(transform.Position.X / ChunkSize))
+		chunkZ := int(math.Floor(transform.Position.Z / ChunkSize))
+		if w.SymbolPlanner.inCoverage(chunkX, chunkZ) {
+			landmarks = append(landmarks, transform.Position)
+		}
+	}
+
+	origin := ecs.Vector3{}
+	sort.Slice(landmarks, func(i, j int) bool {
+		di, dj := landmarks[i].Distance(origin), landmarks[j].Distance(origin)
+		if di != dj {
+			return di < dj
+		}
+		if landmarks[i].X != landmarks[j].X {
+			return landmarks[i].X < landmarks[j].X
+		}
+		return landmarks[i].Z < landmarks[j].Z
+	})
+	return landmarks
+}
+
+// spawnPlannedSymbol создает гарантированный символ в чанке
+func (w *World) spawnPlannedSymbol(chunk *Chunk, planned PlannedSymbol) {
+	// Сила символа определяется его позицией, чтобы повторное создание давало тот же символ
+	r := rand.New(rand.NewSource(w.Seed + int64(planned.Position.X*1000) + int64(planned.Position.Z)))
+	symbolID := planned.SymbolID
+	if symbolID == "" {
+		// Сохранения до выбора символов реестра
+		symbolID = symbolFor(planned.Kind, chunkKey(planned.Chunk[0], planned.Chunk[1]), w.symbolCatalog)
+	}
+	symbolEntity := createSymbol(w.ECSWorld, planned.Position, r.Float64(), symbolID, w.interactWithSymbol)
+	w.addChunkEntities(chunk, symbolEntity.ID)
+}
+
+// SetSymbolCatalog задает реестр, символы которого вырезаются в мире. Задается
+// до генерации первых чанков, иначе их символы не совпадут с реестром.
+func (w *World) SetSymbolCatalog(catalog SymbolCatalog) {
+	w.symbolCatalog = catalog
+}
+
+// SaveSymbolPlan сохраняет состояние планировщика символов
+func (w *World) SaveSymbolPlan(savePath string) error {
+	if err := os.MkdirAll(savePath, os.ModePerm); err != nil {
+		return err
+	}
+
+	data, err := savefile.Marshal(w.SymbolPlanner)
+	if err != nil {
+		return err
+	}
+	return savefile.Write(filepath.Join(savePath, "symbol_plan.json"), data)
+}
+
+// LoadSymbolPlan загружает состояние планировщика символов. Вызывается до
+// генерации первых чанков; отсутствие файла не считается ошибкой.
+func (w *World) LoadSymbolPlan(savePath string) error {
+	data, err := savefile.Read(filepath.Join(savePath, "symbol_plan.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	planner := NewSymbolPlacementPlanner()
+	if err := json.Unmarshal(data, planner); err != nil {
+		return fmt.Errorf("invalid symbol plan: %v", err)
+	}
+	if planner.ChunkKinds == nil {
+		planner.ChunkKinds = make(map[string][]string)
+	}
+	if planner.ChunkSymbols == nil {
+		planner.ChunkSymbols = make(map[string][]string)
+	}
+	planner.rebuildCounts()
+
+	w.SymbolPlanner = planner
+	return nil
+}
@@ -0,0 +1,94 @@
+package world
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+	"echo-taiga/internal/metamorphosis"
+)
+
+// fakeCatalog - реестр с двумя символами каждого типа
+type fakeCatalog struct{}
+
+func (fakeCatalog) SymbolIDsOfType(symbolType string) []string {
+	return []string{symbolType + "_a", symbolType + "_b"}
+}
+
+// newPlannedWorld создает мир, покрытие которого - один чанк в начале мира:
+// генерация чанка дорогая, а случайно в одном чанке все типы не появятся, так
+// что недостающие размещает планировщик
+func newPlannedWorld(seed int64) *World {
+	ecsWorld := ecs.NewWorld()
+	w := NewWorld(seed, ecsWorld, metamorphosis.NewMetamorphosisManager(ecsWorld, ""))
+	w.SymbolPlanner.CoverageRadius = 0
+	w.SetSymbolCatalog(fakeCatalog{})
+	return w
+}
+
+func TestSymbolCoverageNearSpawnHasEveryKind(t *testing.T) {
+	for _, seed := range []int64{1, 2} {
+		w := newPlannedWorld(seed)
+		w.ensureSymbolCoverage()
+
+		present := w.SymbolPlanner.KindsNearSpawn()
+		for _, kind := range SymbolKinds {
+			if present[kind] == 0 {
+				t.Errorf("seed %d: no %s symbol near spawn (%v)", seed, kind, present)
+			}
+		}
+		if len(w.SymbolPlanner.Guaranteed) == 0 {
+			t.Errorf("seed %d: one chunk covered every kind without guaranteed symbols", seed)
+		}
+		for _, planned := range w.SymbolPlanner.Guaranteed {
+			if !strings.HasPrefix(planned.SymbolID, planned.Kind+"_") {
+				t.Errorf("seed %d: guaranteed %s symbol carved as %q, not a catalog symbol", seed, planned.Kind, planned.SymbolID)
+			}
+		}
+	}
+}
+
+func TestSymbolPlanReloadDoesNotPlaceTwice(t *testing.T) {
+	dir := t.TempDir()
+	w := newPlannedWorld(5)
+	w.ensureSymbolCoverage()
+	if err := w.SaveSymbolPlan(dir); err != nil {
+		t.Fatalf("SaveSymbolPlan: %v", err)
+	}
+
+	reloaded := newPlannedWorld(5)
+	if err := reloaded.LoadSymbolPlan(dir); err != nil {
+		t.Fatalf("LoadSymbolPlan: %v", err)
+	}
+	reloaded.ensureSymbolCoverage()
+
+	if !reflect.DeepEqual(reloaded.SymbolPlanner.Guaranteed, w.SymbolPlanner.Guaranteed) {
+		t.Errorf("guaranteed symbols after reload %+v, want %+v", reloaded.SymbolPlanner.Guaranteed, w.SymbolPlanner.Guaranteed)
+	}
+	if !reflect.DeepEqual(reloaded.SymbolPlanner.ChunkKinds, w.SymbolPlanner.ChunkKinds) {
+		t.Errorf("chunk kinds after reload %v, want %v", reloaded.SymbolPlanner.ChunkKinds, w.SymbolPlanner.ChunkKinds)
+	}
+}
+
+func TestChooseKindFavorsUnderrepresentedKinds(t *testing.T) {
+	planner := NewSymbolPlacementPlanner()
+	for i := 0; i < 3; i++ {
+		planner.record(0, 0, SymbolKinds[0])
+	}
+
+	// Бросок, который без учета региона выбрал бы первый тип
+	kind := planner.ChooseKind(1, 1, 0, 0.1)
+	if kind == SymbolKinds[0] {
+		t.Errorf("chose %s, which already has three symbols in the region", kind)
+	}
+
+	// Повторная генерация чанка возвращает прежний выбор при любом броске
+	if again := planner.ChooseKind(1, 1, 0, 0.99); again != kind {
+		t.Errorf("regenerated chunk chose %s, want %s", again, kind)
+	}
+	first := planner.ChooseSymbol(1, 1, 0, kind, fakeCatalog{})
+	if again := planner.ChooseSymbol(1, 1, 0, kind, fakeCatalog{}); again != first {
+		t.Errorf("regenerated chunk carved %s, want %s", again, first)
+	}
+}
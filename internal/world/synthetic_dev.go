@@ -0,0 +1,19 @@
+//go:build dev
+
+package world
+
+// PopulateSynthetic заранее генерирует чанки в круге радиусом radius чанков
+// вокруг начала координат, чтобы профилирование UpdateActiveChunks измеряло смену
+// активных чанков, а не первую генерацию (только в dev-сборках)
+func (w *World) PopulateSynthetic(radius int) {
+	w.chunkMutex.Lock()
+	defer w.chunkMutex.Unlock()
+
+	for x := -radius; x <= radius; x++ {
+		for z := -radius; z <= radius; z++ {
+			if x*x+z*z <= radius*radius {
+				w.GetChunkAt(x, z)
+			}
+		}
+	}
+}
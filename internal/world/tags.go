@@ -0,0 +1,88 @@
+package world
+
+import "echo-taiga/internal/engine/ecs"
+
+// Теги сущностей, которые создает мир
+const (
+	TagEnvironment = "environment"
+	TagVegetation  = "vegetation"
+	TagTree        = "tree"
+	TagRock        = "rock"
+	TagBush        = "bush"
+	TagClearing    = "clearing"
+	TagRitualSite  = "ritual_site"
+	TagSpecial     = "special"
+	TagSymbol      = "symbol"
+	TagInteractive = "interactive"
+	TagAnimal      = "animal"
+	TagLiving      = "living"
+	TagPredator    = "predator"
+	TagPrey        = "prey"
+	TagMutated     = "mutated"
+	TagCreature    = "creature"
+	TagHostile     = "hostile"
+	TagNight       = "night"
+	TagAnomaly     = "anomaly"
+	TagDistorted   = "distorted"
+	TagVoid        = "void"
+	TagCampfire    = "campfire"
+	TagShelter     = "shelter"
+	TagAltar       = "altar"
+	TagAncientSite = "ancient_site"
+	TagAnchor      = "anchor"
+	TagBroken      = "broken"
+)
+
+// Виды животных, ночных существ и аномалий также используются как теги
+var (
+	animalSpeciesTags   = []string{"deer", "wolf", "rabbit", "fox", "frog", "fish", "snake", "goat", "eagle"}
+	creatureSpeciesTags = []string{"shadow", "wraith", "nightmare"}
+	anomalyTypeTags     = []string{"minor", "medium", "major"}
+)
+
+func init() {
+	ecs.RegisterTag(TagEnvironment, "environment", "Статичный объект окружения, может служить местом ритуала")
+	ecs.RegisterTag(TagVegetation, "environment", "Растительность")
+	ecs.RegisterTag(TagTree, "environment", "Дерево")
+	ecs.RegisterTag(TagRock, "environment", "Камень или валун")
+	ecs.RegisterTag(TagBush, "environment", "Куст")
+	ecs.RegisterTag(TagClearing, "location", "Поляна")
+	ecs.RegisterTag(TagRitualSite, "location", "Место, пригодное для ритуалов")
+	ecs.RegisterTag(TagSpecial, "state", "Особый объект мира")
+	ecs.RegisterTag(TagSymbol, "mystic", "Символ, который можно обнаружить")
+	ecs.RegisterTag(TagInteractive, "state", "Объект, с которым можно взаимодействовать")
+	ecs.RegisterTag(TagAnimal, "fauna", "Животное")
+	ecs.RegisterTag(TagLiving, "fauna", "Живое существо")
+	ecs.RegisterTag(TagPredator, "fauna", "Хищник")
+	ecs.RegisterTag(TagPrey, "fauna", "Добыча")
+	ecs.RegisterTag(TagMutated, "state", "Существо, измененное метаморфозой")
+	ecs.RegisterTag(TagCreature, "fauna", "Аномальное существо")
+	ecs.RegisterTag(TagHostile, "fauna", "Враждебное существо")
+	ecs.RegisterTag(TagNight, "fauna", "Существо, появляющееся ночью")
+	ecs.RegisterTag(TagAnomaly, "mystic", "Аномалия")
+	ecs.RegisterTag(TagDistorted, "state", "Искаженная копия сущности")
+	ecs.RegisterTag(TagVoid, "mystic", "Сущность, затронутая пустотой")
+	ecs.RegisterTag(TagCampfire, "camp", "Костер, поставленный игроком")
+	ecs.RegisterTag(TagShelter, "camp", "Укрытие, построенное игроком")
+	ecs.RegisterTag(TagAltar, "location", "Алтарь для подношений")
+	ecs.RegisterTag(TagAncientSite, "location", "Древний круг стоячих камней")
+	ecs.RegisterTag(TagAnchor, "mystic", "Якорь стабильности, подавляющий метаморфозы")
+	ecs.RegisterTag(TagBroken, "state", "Объект, сломанный во время испуга")
+
+	for _, tag := range animalSpeciesTags {
+		ecs.RegisterTag(tag, "species", "Вид животного")
+	}
+	for _, tag := range creatureSpeciesTags {
+		ecs.RegisterTag(tag, "species", "Вид ночного существа")
+	}
+	for _, tag := range anomalyTypeTags {
+		ecs.RegisterTag(tag, "anomaly_type", "Тип аномалии")
+	}
+	for _, tag := range biomeRitualLocations {
+		ecs.RegisterTag(tag, "location", "Тип места ритуала")
+	}
+	ecs.RegisterTag(caveLocation, "location", "Вход в пещеру")
+
+	// Теги, которые мир запрашивает сам
+	ecs.DeclareTagQuery(TagAnimal, TagCampfire, TagShelter)
+}
@@ -0,0 +1,21 @@
+package world
+
+import (
+	"testing"
+
+	"echo-taiga/internal/engine/ecs"
+
+	// Подсистемы, которые определяют или запрашивают теги; вместе с пакетом
+	// world они заполняют реестр так же, как в игре
+	_ "echo-taiga/internal/ai/fear"
+	_ "echo-taiga/internal/ai/threat"
+	_ "echo-taiga/internal/audio"
+	_ "echo-taiga/internal/entities"
+)
+
+func TestEveryQueriedTagHasADefiner(t *testing.T) {
+	report := ecs.Tags.Report()
+	if len(report.QueriedNotDefined) > 0 {
+		t.Errorf("tags queried but never defined: %v", report.QueriedNotDefined)
+	}
+}
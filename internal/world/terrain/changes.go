@@ -0,0 +1,54 @@
+package terrain
+
+import "sort"
+
+// TileOrigin - состояние клетки до первого изменения после генерации
+type TileOrigin struct {
+	X, Y   int
+	Height float64 // Высота без правок (искажения учитываются, см. ApplyDistortion)
+	Ground string
+}
+
+// TrackChanges начинает запоминать исходное состояние клеток, изменяемых через
+// SetHeight и SetGroundType. Вызывается после генерации: изменения, сделанные
+// до этого, считаются частью сгенерированного террейна.
+func (t *TerrainData) TrackChanges() {
+	t.origins = make(map[[2]int]TileOrigin)
+}
+
+// ChangedTiles возвращает исходное состояние измененных клеток по порядку координат
+func (t *TerrainData) ChangedTiles() []TileOrigin {
+	tiles := make([]TileOrigin, 0, len(t.origins))
+	for _, origin := range t.origins {
+		tiles = append(tiles, origin)
+	}
+	sort.Slice(tiles, func(i, j int) bool {
+		if tiles[i].X != tiles[j].X {
+			return tiles[i].X < tiles[j].X
+		}
+		return tiles[i].Y < tiles[j].Y
+	})
+	return tiles
+}
+
+// noteChange запоминает исходное состояние клетки перед ее первым изменением
+func (t *TerrainData) noteChange(x, y int) {
+	if t.origins == nil {
+		return
+	}
+	key := [2]int{x, y}
+	if _, noted := t.origins[key]; !noted {
+		t.origins[key] = TileOrigin{X: x, Y: y, Height: t.HeightMap[x][y], Ground: t.GroundTypes[x][y]}
+	}
+}
+
+// shiftOrigins сдвигает исходные высоты измененных клеток вместе с искажением,
+// чтобы искажение не считалось правкой
+func (t *TerrainData) shiftOrigins(delta [][]float64, sign float64) {
+	for key, origin := range t.origins {
+		if key[0] < len(delta) && key[1] < len(delta[key[0]]) {
+			origin.Height += sign * delta[key[0]][key[1]]
+			t.origins[key] = origin
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package terrain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestChangedTilesKeepsFirstOrigin(t *testing.T) {
+	td := NewTerrainData(8, 8)
+	td.SetHeight(1, 1, 3) // До отслеживания - часть сгенерированного террейна
+	td.TrackChanges()
+
+	td.SetHeight(2, 5, 4)
+	td.SetHeight(2, 5, 6)
+	td.SetGroundType(0, 3, "rock")
+
+	tiles := td.ChangedTiles()
+	if len(tiles) != 2 {
+		t.Fatalf("ChangedTiles() = %v, want 2 tiles", tiles)
+	}
+	if tiles[0].X != 0 || tiles[0].Y != 3 || tiles[0].Ground != "grass" {
+		t.Errorf("first tile = %+v, want (0,3) originally grass", tiles[0])
+	}
+	if tiles[1].X != 2 || tiles[1].Y != 5 || tiles[1].Height != 0 {
+		t.Errorf("second tile = %+v, want (2,5) originally at height 0", tiles[1])
+	}
+}
+
+func TestDistortionIsNotAChange(t *testing.T) {
+	td := NewTerrainData(16, 16)
+	td.TrackChanges()
+	td.SetHeight(8, 8, 2)
+
+	delta := td.ApplyDistortion(0.8)
+	if len(td.ChangedTiles()) != 1 {
+		t.Fatalf("distortion added changed tiles: %v", td.ChangedTiles())
+	}
+
+	// Правка поверх искажения остается правкой на 2 после отмены искажения
+	origin := td.ChangedTiles()[0]
+	if offset := td.HeightMap[8][8] - origin.Height; math.Abs(offset-2) > 1e-9 {
+		t.Errorf("offset under distortion = %v, want 2", offset)
+	}
+	td.RevertDistortion(delta)
+	origin = td.ChangedTiles()[0]
+	if offset := td.HeightMap[8][8] - origin.Height; math.Abs(offset-2) > 1e-9 {
+		t.Errorf("offset after revert = %v, want 2", offset)
+	}
+}
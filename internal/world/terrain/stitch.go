@@ -0,0 +1,83 @@
+package terrain
+
+// DefaultBorderBlendWidth - ширина полосы у края чанка (в клетках), которая
+// подтягивается к краю соседа
+const DefaultBorderBlendWidth = 8
+
+// NeighborFunc возвращает террейн уже сгенерированного чанка или nil, если его нет
+type NeighborFunc func(chunkX, chunkY int) *TerrainData
+
+// StitchChunk сшивает края террейна чанка с уже сгенерированными соседями.
+// Базовый шум считается в мировых координатах и на границах совпадает сам,
+// поэтому сшивка убирает только швы от постобработки: различий масштаба высот
+// между биомами, особенностей рельефа, обрезанных краем чанка, и преобразования в пустоту.
+func (g *Generator) StitchChunk(terrain *TerrainData, chunkX, chunkY int) {
+	if g.NeighborTerrain == nil || terrain == nil {
+		return
+	}
+
+	width := g.BorderBlendWidth
+	if width <= 0 {
+		return
+	}
+
+	// Соседи по обе стороны каждой оси
+	if left := g.NeighborTerrain(chunkX-1, chunkY); left != nil && left.Height == terrain.Height {
+		for y := 0; y < terrain.Height; y++ {
+			offset := left.HeightMap[left.Width-1][y] - terrain.HeightMap[0][y]
+			for i := 0; i < width && i < terrain.Width; i++ {
+				terrain.HeightMap[i][y] += offset * borderWeight(i, width)
+			}
+		}
+	}
+	if right := g.NeighborTerrain(chunkX+1, chunkY); right != nil && right.Height == terrain.Height {
+		for y := 0; y < terrain.Height; y++ {
+			offset := right.HeightMap[0][y] - terrain.HeightMap[terrain.Width-1][y]
+			for i := 0; i < width && i < terrain.Width; i++ {
+				terrain.HeightMap[terrain.Width-1-i][y] += offset * borderWeight(i, width)
+			}
+		}
+	}
+	if bottom := g.NeighborTerrain(chunkX, chunkY-1); bottom != nil && bottom.Width == terrain.Width {
+		for x := 0; x < terrain.Width; x++ {
+			offset := bottom.HeightMap[x][bottom.Height-1] - terrain.HeightMap[x][0]
+			for i := 0; i < width && i < terrain.Height; i++ {
+				terrain.HeightMap[x][i] += offset * borderWeight(i, width)
+			}
+		}
+	}
+	if top := g.NeighborTerrain(chunkX, chunkY+1); top != nil && top.Width == terrain.Width {
+		for x := 0; x < terrain.Width; x++ {
+			offset := top.HeightMap[x][0] - terrain.HeightMap[x][terrain.Height-1]
+			for i := 0; i < width && i < terrain.Height; i++ {
+				terrain.HeightMap[x][terrain.Height-1-i] += offset * borderWeight(i, width)
+			}
+		}
+	}
+}
+
+// borderWeight возвращает долю смещения для клетки на расстоянии i от края:
+// 1 на самом краю, плавно убывая до 0 на границе полосы сшивки
+func borderWeight(i, width int) float64 {
+	t := float64(i) / float64(width)
+	return 1 - t*t*(3-2*t)
+}
+
+// edgeFalloff возвращает множитель искажений для клетки: 0 на краю чанка и 1 дальше
+// полосы сшивки, чтобы искажения не разрывали уже сшитые границы
+func (t *TerrainData) edgeFalloff(x, y, width int) float64 {
+	distance := x
+	if d := t.Width - 1 - x; d < distance {
+		distance = d
+	}
+	if y < distance {
+		distance = y
+	}
+	if d := t.Height - 1 - y; d < distance {
+		distance = d
+	}
+	if distance >= width {
+		return 1
+	}
+	return 1 - borderWeight(distance, width)
+}
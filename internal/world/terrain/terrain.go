@@ -14,6 +14,8 @@ type TerrainData struct {
 	HeightMap   [][]float64      // Высоты ландшафта
 	GroundTypes [][]string       // Типы поверхности (grass, rock, snow, etc.)
 	Features    []TerrainFeature // Особенности рельефа (cliffs, rivers, etc.)
+
+	origins map[[2]int]TileOrigin // Исходное состояние измененных клеток (см. TrackChanges)
 }
 
 // TerrainFeature представляет особую черту ландшафта
@@ -33,6 +35,10 @@ type Generator struct {
 	HeightScale       float64
 	Random            *rand.Rand
 	FeatureGenerators map[string]func(*TerrainData, int, int, *rand.Rand) // Генераторы особенностей по типам
+
+	// Сшивка краев с соседними чанками (см. StitchChunk)
+	NeighborTerrain  NeighborFunc // Источник уже сгенерированных соседей (задается миром)
+	BorderBlendWidth int          // Ширина полосы сшивки в клетках
 }
 
 // NewTerrainData создает новый пустой террейн указанных размеров
@@ -121,6 +127,7 @@ func (t *TerrainData) SetHeight(x, y int, height float64) {
 	if x < 0 || x >= t.Width || y < 0 || y >= t.Height {
 		return // За пределами террейна
 	}
+	t.noteChange(x, y)
 	t.HeightMap[x][y] = height
 }
 
@@ -129,6 +136,7 @@ func (t *TerrainData) SetGroundType(x, y int, groundType string) {
 	if x < 0 || x >= t.Width || y < 0 || y >= t.Height {
 		return // За пределами террейна
 	}
+	t.noteChange(x, y)
 	t.GroundTypes[x][y] = groundType
 }
 
@@ -137,17 +145,25 @@ func (t *TerrainData) AddFeature(feature TerrainFeature) {
 	t.Features = append(t.Features, feature)
 }
 
-// ApplyDistortion применяет искажение к высотам ландшафта
-func (t *TerrainData) ApplyDistortion(intensity float64) {
+// ApplyDistortion применяет искажение к высотам ландшафта и возвращает внесенные
+// смещения высот, по которым искажение можно отменить (см. RevertDistortion)
+func (t *TerrainData) ApplyDistortion(intensity float64) [][]float64 {
 	// Создаем временный генератор случайных чисел
 	r := rand.New(rand.NewSource(int64(len(t.Features))))
 
+	delta := make([][]float64, t.Width)
+	for x := range delta {
+		delta[x] = make([]float64, t.Height)
+	}
+
 	// Применяем случайные искажения к высотам
 	for x := 0; x < t.Width; x++ {
 		for y := 0; y < t.Height; y++ {
-			// Добавляем случайное отклонение, пропорциональное интенсивности
-			noise := (r.Float64()*2 - 1) * intensity * 5.0
+			// Добавляем случайное отклонение, пропорциональное интенсивности.
+			// У краев чанка искажение затухает, чтобы не появлялись швы с соседями
+			noise := (r.Float64()*2 - 1) * intensity * 5.0 * t.edgeFalloff(x, y, DefaultBorderBlendWidth)
 			t.HeightMap[x][y] += noise
+			delta[x][y] += noise
 		}
 	}
 
@@ -167,11 +183,26 @@ func (t *TerrainData) ApplyDistortion(intensity float64) {
 					// Плавно уменьшаем эффект от центра к краям
 					factor := 1.0 - (distance / radius)
 					factor = factor * factor // Квадратичное затухание для более плавного перехода
+					factor *= t.edgeFalloff(x, y, DefaultBorderBlendWidth)
 					t.HeightMap[x][y] += depth * factor
+					delta[x][y] += depth * factor
 				}
 			}
 		}
 	}
+
+	t.shiftOrigins(delta, 1)
+	return delta
+}
+
+// RevertDistortion отменяет искажение, вычитая ранее внесенные смещения высот
+func (t *TerrainData) RevertDistortion(delta [][]float64) {
+	for x := 0; x < t.Width && x < len(delta); x++ {
+		for y := 0; y < t.Height && y < len(delta[x]); y++ {
+			t.HeightMap[x][y] -= delta[x][y]
+		}
+	}
+	t.shiftOrigins(delta, -1)
 }
 
 // NewGenerator создает новый генератор террейна
@@ -183,6 +214,7 @@ func NewGenerator(seed int64, biomeMap *biomes.BiomeMap) *Generator {
 		HeightScale:       50.0, // Масштаб высот
 		Random:            rand.New(rand.NewSource(seed)),
 		FeatureGenerators: make(map[string]func(*TerrainData, int, int, *rand.Rand)),
+		BorderBlendWidth:  DefaultBorderBlendWidth,
 	}
 
 	// Регистрируем генераторы особенностей ландшафта
@@ -457,7 +489,7 @@ func (g *Generator) GenerateChunkTerrain(chunkX, chunkY, chunkSize int) *Terrain
 	r := rand.New(rand.NewSource(chunkSeed))
 
 	// Определяем тип биома для чанка
-	biomeType := g.BiomeMap.GetBiomeAt(float64(chunkX), float64(chunkY))
+	biomeType := string(g.BiomeMap.GetBiomeAt(float64(chunkX), float64(chunkY)))
 
 	// Генерируем высоты в зависимости от биома
 	g.generateHeights(terrain, chunkX, chunkY, biomeType, r)
@@ -468,6 +500,9 @@ func (g *Generator) GenerateChunkTerrain(chunkX, chunkY, chunkSize int) *Terrain
 	// Добавляем особенности ландшафта
 	g.addTerrainFeatures(terrain, chunkX, chunkY, biomeType, r)
 
+	// Сшиваем края с уже сгенерированными соседями
+	g.StitchChunk(terrain, chunkX, chunkY)
+
 	return terrain
 }
 
@@ -0,0 +1,151 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"echo-taiga/internal/savefile"
+	"echo-taiga/internal/world/terrain"
+)
+
+// terrainDiffEpsilon - изменения высоты меньше этого считаются погрешностью
+const terrainDiffEpsilon = 1e-6
+
+// TerrainTile - клетка террейна чанка, измененная после генерации
+type TerrainTile struct {
+	X      int     `json:"x"`
+	Y      int     `json:"y"`
+	Offset float64 `json:"offset,omitempty"` // Смещение высоты относительно сгенерированной
+	Ground string  `json:"ground,omitempty"` // Новый тип поверхности, если он изменился
+}
+
+// terrainDiff возвращает клетки, измененные после генерации чем-то кроме
+// эффектов метаморфоза. Террейн сам запоминает исходное состояние клеток,
+// которые правили (см. terrain.TrackChanges), поэтому остальные клетки чанка
+// не сравниваются и копия сгенерированного террейна не хранится. Искажения
+// эффектов в разницу не входят: эффекты заново накладывают их при повторном
+// применении к чанку.
+func (chunk *Chunk) terrainDiff() []TerrainTile {
+	t := chunk.Terrain
+	if t == nil {
+		return nil
+	}
+
+	tiles := make([]TerrainTile, 0)
+	for _, origin := range t.ChangedTiles() {
+		tile := TerrainTile{X: origin.X, Y: origin.Y}
+		if offset := t.HeightMap[origin.X][origin.Y] - origin.Height; math.Abs(offset) > terrainDiffEpsilon {
+			tile.Offset = offset
+		}
+		if ground := t.GroundTypes[origin.X][origin.Y]; ground != origin.Ground {
+			tile.Ground = ground
+		}
+		if tile.Offset != 0 || tile.Ground != "" {
+			tiles = append(tiles, tile)
+		}
+	}
+	return tiles
+}
+
+// applyTerrainDiff переносит сохраненные изменения на заново сгенерированный
+// террейн. Изменения вносятся как правки, чтобы попасть в следующую разницу.
+func applyTerrainDiff(t *terrain.TerrainData, tiles []TerrainTile) {
+	for _, tile := range tiles {
+		if tile.X < 0 || tile.X >= t.Width || tile.Y < 0 || tile.Y >= t.Height {
+			continue
+		}
+		t.SetHeight(tile.X, tile.Y, t.HeightMap[tile.X][tile.Y]+tile.Offset)
+		if tile.Ground != "" {
+			t.SetGroundType(tile.X, tile.Y, tile.Ground)
+		}
+	}
+}
+
+// storeTerrainDiff запоминает изменения террейна деактивируемого чанка
+func (w *World) storeTerrainDiff(chunk *Chunk) {
+	if tiles := chunk.terrainDiff(); len(tiles) > 0 {
+		w.terrainDiffs[chunk.Position] = tiles
+	} else {
+		delete(w.terrainDiffs, chunk.Position)
+	}
+}
+
+// restoreTerrainDiff возвращает заново сгенерированному чанку изменения террейна,
+// сохраненные до выгрузки. Вызывается при активации чанка до применения метаморфоз.
+func (w *World) restoreTerrainDiff(chunk *Chunk) {
+	if !chunk.terrainPending {
+		return
+	}
+	chunk.terrainPending = false
+
+	if tiles, exists := w.terrainDiffs[chunk.Position]; exists && chunk.Terrain != nil {
+		applyTerrainDiff(chunk.Terrain, tiles)
+	}
+}
+
+// SaveTerrainDiffs сохраняет изменения террейна всех чанков
+func (w *World) SaveTerrainDiffs(savePath string) error {
+	if err := os.MkdirAll(savePath, os.ModePerm); err != nil {
+		return err
+	}
+
+	// Активные чанки еще не выгружались: берем их текущие изменения
+	for _, chunk := range w.activeChunkList() {
+		w.storeTerrainDiff(chunk)
+	}
+
+	records := make(map[string][]TerrainTile, len(w.terrainDiffs))
+	for pos, tiles := range w.terrainDiffs {
+		records[chunkKey(pos[0], pos[1])] = tiles
+	}
+
+	data, err := savefile.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return savefile.Write(filepath.Join(savePath, "terrain.json"), data)
+}
+
+// LoadTerrainDiffs загружает изменения террейна. Вызывается до генерации первых
+// чанков: изменения возвращаются чанкам при их активации.
+// Отсутствие файла не считается ошибкой.
+func (w *World) LoadTerrainDiffs(savePath string) error {
+	diffs, err := ReadTerrainDiffs(savePath)
+	if err != nil {
+		return err
+	}
+	w.terrainDiffs = diffs
+	return nil
+}
+
+// ReadTerrainDiffs читает сохраненные изменения террейна по чанкам, не создавая
+// мира (например, чтобы сравнить два сохранения). Отсутствие файла не считается
+// ошибкой.
+func ReadTerrainDiffs(savePath string) (map[[2]int][]TerrainTile, error) {
+	diffs := make(map[[2]int][]TerrainTile)
+
+	data, err := savefile.Read(filepath.Join(savePath, "terrain.json"))
+	if os.IsNotExist(err) {
+		return diffs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records map[string][]TerrainTile
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("invalid terrain diffs: %v", err)
+	}
+
+	for key, tiles := range records {
+		var x, z int
+		if _, err := fmt.Sscanf(key, "%d,%d", &x, &z); err != nil {
+			return nil, fmt.Errorf("invalid terrain chunk %q: %v", key, err)
+		}
+		diffs[[2]int{x, z}] = tiles
+	}
+	return diffs, nil
+}
@@ -0,0 +1,53 @@
+package world
+
+import (
+	"testing"
+
+	"echo-taiga/internal/world/terrain"
+)
+
+func TestTerrainDiffCoversChangedTilesOnly(t *testing.T) {
+	chunk := &Chunk{Terrain: terrain.NewTerrainData(ChunkSize, ChunkSize)}
+	chunk.Terrain.TrackChanges()
+
+	distortChunkTerrain(chunk, "quake_1", 1.0)
+	chunk.Terrain.SetHeight(3, 4, chunk.Terrain.HeightMap[3][4]+1.5)
+	chunk.Terrain.SetGroundType(10, 2, "ash")
+	chunk.Terrain.SetGroundType(11, 2, "grass") // Не изменилась
+
+	tiles := chunk.terrainDiff()
+	if len(tiles) != 2 {
+		t.Fatalf("terrainDiff() = %+v, want 2 tiles", tiles)
+	}
+	if tiles[0].X != 3 || tiles[0].Y != 4 || tiles[0].Offset < 1.5-1e-9 || tiles[0].Offset > 1.5+1e-9 {
+		t.Errorf("height tile = %+v, want (3,4) raised by 1.5", tiles[0])
+	}
+	if tiles[1].X != 10 || tiles[1].Ground != "ash" || tiles[1].Offset != 0 {
+		t.Errorf("ground tile = %+v, want (10,2) turned to ash", tiles[1])
+	}
+
+	// На заново сгенерированном террейне разница восстанавливается и снова отслеживается
+	regenerated := terrain.NewTerrainData(ChunkSize, ChunkSize)
+	regenerated.TrackChanges()
+	applyTerrainDiff(regenerated, tiles)
+	restored := (&Chunk{Terrain: regenerated}).terrainDiff()
+	if len(restored) != len(tiles) || restored[0] != tiles[0] || restored[1] != tiles[1] {
+		t.Errorf("diff after restore = %+v, want %+v", restored, tiles)
+	}
+}
+
+func TestVoidConversionResetsTerrainDiff(t *testing.T) {
+	chunk := &Chunk{Position: [2]int{2, -1}, Terrain: terrain.NewTerrainData(ChunkSize, ChunkSize)}
+	chunk.Terrain.TrackChanges()
+	chunk.Terrain.SetHeight(0, 0, 7)
+	distortChunkTerrain(chunk, "rift_1", 1.0)
+
+	(&World{}).convertTerrainToVoid(chunk)
+
+	if chunk.Distortions != nil {
+		t.Errorf("distortions of the old terrain survived the void conversion")
+	}
+	if tiles := chunk.terrainDiff(); len(tiles) != 0 {
+		t.Errorf("terrainDiff() after void conversion has %d tiles, want 0", len(tiles))
+	}
+}
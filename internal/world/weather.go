@@ -0,0 +1,179 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"echo-taiga/internal/savefile"
+)
+
+// WeatherLease - погода, навязанная ритуалом или испугом на время. Пока
+// аренды есть, естественные смены погоды идут незаметно и не видны игроку.
+type WeatherLease struct {
+	ID        uint64  `json:"id"`        // Идентификатор аренды
+	Condition string  `json:"condition"` // Навязанная погода
+	Remaining float64 `json:"remaining"` // Оставшееся игровое время аренды в секундах
+	Source    string  `json:"source"`    // Кто навязал погоду (например, "ritual" или "scare:<ID>")
+}
+
+// weatherRecord - сохраненная погода мира
+type weatherRecord struct {
+	Natural  string         `json:"natural"`            // Естественная погода
+	Forecast string         `json:"forecast,omitempty"` // Объявленная погода следующей смены
+	Leases   []WeatherLease `json:"leases,omitempty"`   // Аренды от самой старой к действующей
+	NextID   uint64         `json:"next_id"`
+}
+
+// weatherLeases - навязанная погода поверх естественной. Действует последняя
+// аренда; прерванные ею аренды не расходуют время и возобновляются, когда она кончится.
+type weatherLeases struct {
+	stack   []*WeatherLease
+	natural string // Естественная погода, которая вернется после всех аренд
+	nextID  uint64
+}
+
+// LeaseWeather навязывает погоду на duration секунд игрового времени и
+// возвращает ID аренды. Новая аренда перекрывает действующую.
+func (w *World) LeaseWeather(condition string, duration float64, source string) uint64 {
+	if len(w.weather.stack) == 0 {
+		w.weather.natural = w.WeatherCondition
+	}
+
+	w.weather.nextID++
+	lease := &WeatherLease{ID: w.weather.nextID, Condition: condition, Remaining: duration, Source: source}
+	w.weather.stack = append(w.weather.stack, lease)
+	w.applyLeasedWeather()
+	return lease.ID
+}
+
+// ReleaseWeather снимает аренду раньше срока. Возвращает false, если такой аренды нет.
+func (w *World) ReleaseWeather(id uint64) bool {
+	for i, lease := range w.weather.stack {
+		if lease.ID == id {
+			w.weather.stack = append(w.weather.stack[:i], w.weather.stack[i+1:]...)
+			w.applyLeasedWeather()
+			return true
+		}
+	}
+	return false
+}
+
+// WeatherLeases возвращает копии действующих аренд, от самой старой к действующей
+func (w *World) WeatherLeases() []WeatherLease {
+	leases := make([]WeatherLease, len(w.weather.stack))
+	for i, lease := range w.weather.stack {
+		leases[i] = *lease
+	}
+	return leases
+}
+
+// NaturalWeather возвращает погоду, которая была бы без аренд
+func (w *World) NaturalWeather() string {
+	if len(w.weather.stack) == 0 {
+		return w.WeatherCondition
+	}
+	return w.weather.natural
+}
+
+// advanceWeatherLeases расходует время действующей аренды. Остаток времени
+// истекшей аренды переходит к той, что возобновляется после нее.
+func (w *World) advanceWeatherLeases(deltaTime float64) {
+	if len(w.weather.stack) == 0 {
+		return
+	}
+
+	expired := false
+	for deltaTime > 0 && len(w.weather.stack) > 0 {
+		top := w.weather.stack[len(w.weather.stack)-1]
+		if top.Remaining > deltaTime {
+			top.Remaining -= deltaTime
+			break
+		}
+		deltaTime -= top.Remaining
+		w.weather.stack = w.weather.stack[:len(w.weather.stack)-1]
+		expired = true
+	}
+
+	if expired {
+		w.applyLeasedWeather()
+	}
+}
+
+// applyLeasedWeather выставляет погоду действующей аренды, а без аренд - естественную
+func (w *World) applyLeasedWeather() {
+	weather := w.weather.natural
+	if len(w.weather.stack) > 0 {
+		weather = w.weather.stack[len(w.weather.stack)-1].Condition
+	}
+	if weather != w.WeatherCondition {
+		w.SetWeatherCondition(weather)
+	}
+}
+
+// setNaturalWeather меняет естественную погоду. Пока действует аренда, игрок
+// увидит ее только после окончания всех аренд.
+func (w *World) setNaturalWeather(weather string) {
+	if len(w.weather.stack) > 0 {
+		w.weather.natural = weather
+		return
+	}
+	if weather != w.WeatherCondition {
+		w.SetWeatherCondition(weather)
+	}
+}
+
+// SaveWeather сохраняет естественную погоду, прогноз и аренды погоды, чтобы
+// навязанная ритуалом погода не кончалась с выходом из игры
+func (w *World) SaveWeather(savePath string) error {
+	if err := os.MkdirAll(savePath, os.ModePerm); err != nil {
+		return err
+	}
+
+	record := weatherRecord{
+		Natural:  w.NaturalWeather(),
+		Forecast: w.Forecast,
+		Leases:   w.WeatherLeases(),
+		NextID:   w.weather.nextID,
+	}
+	data, err := savefile.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return savefile.Write(filepath.Join(savePath, "weather.json"), data)
+}
+
+// LoadWeather загружает погоду мира. Отсутствие файла не считается ошибкой:
+// новый мир начинается с погоды по умолчанию.
+func (w *World) LoadWeather(savePath string) error {
+	data, err := savefile.Read(filepath.Join(savePath, "weather.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var record weatherRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return fmt.Errorf("invalid weather: %v", err)
+	}
+
+	w.weather.stack = make([]*WeatherLease, 0, len(record.Leases))
+	for i := range record.Leases {
+		lease := record.Leases[i]
+		if lease.Remaining <= 0 {
+			continue
+		}
+		if lease.ID > record.NextID {
+			record.NextID = lease.ID
+		}
+		w.weather.stack = append(w.weather.stack, &lease)
+	}
+	w.weather.natural = record.Natural
+	w.weather.nextID = record.NextID
+	w.Forecast = record.Forecast
+	w.applyLeasedWeather()
+	return nil
+}
@@ -0,0 +1,85 @@
+package world
+
+import "testing"
+
+func TestWeatherLeasesSurviveSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	saved := &World{WeatherCondition: "clear", Forecast: "snow"}
+	saved.LeaseWeather("fog", 120, "ritual")
+	saved.LeaseWeather("blizzard", 30, "scare:7")
+	saved.advanceWeatherLeases(10)
+	saved.setNaturalWeather("rain")
+	if err := saved.SaveWeather(dir); err != nil {
+		t.Fatalf("SaveWeather: %v", err)
+	}
+
+	loaded := &World{WeatherCondition: "clear"}
+	if err := loaded.LoadWeather(dir); err != nil {
+		t.Fatalf("LoadWeather: %v", err)
+	}
+	if loaded.WeatherCondition != "blizzard" {
+		t.Errorf("loaded weather %q, want the leased blizzard", loaded.WeatherCondition)
+	}
+	if loaded.NaturalWeather() != "rain" || loaded.Forecast != "snow" {
+		t.Errorf("loaded natural %q and forecast %q, want rain and snow", loaded.NaturalWeather(), loaded.Forecast)
+	}
+	leases := loaded.WeatherLeases()
+	if len(leases) != 2 || leases[1].Remaining != 20 || leases[1].Source != "scare:7" {
+		t.Fatalf("loaded leases %+v", leases)
+	}
+
+	// Новая аренда не должна получить ID уже сохраненной
+	if id := loaded.LeaseWeather("clear", 5, "ritual"); id <= leases[1].ID {
+		t.Errorf("new lease reused ID %d", id)
+	}
+}
+
+func TestExpiredLeaseResumesInterruptedOne(t *testing.T) {
+	w := &World{WeatherCondition: "clear"}
+	fog := w.LeaseWeather("fog", 60, "ritual")
+	w.LeaseWeather("blizzard", 10, "scare:1")
+
+	// Остаток истекшей аренды расходует прерванную
+	w.advanceWeatherLeases(15)
+	if w.WeatherCondition != "fog" {
+		t.Fatalf("weather %q after the blizzard ended, want fog", w.WeatherCondition)
+	}
+	if leases := w.WeatherLeases(); len(leases) != 1 || leases[0].ID != fog || leases[0].Remaining != 55 {
+		t.Errorf("leases after expiry %+v", leases)
+	}
+
+	w.advanceWeatherLeases(55)
+	if w.WeatherCondition != "clear" || len(w.WeatherLeases()) != 0 {
+		t.Errorf("weather %q with %d leases, want clear without leases", w.WeatherCondition, len(w.WeatherLeases()))
+	}
+}
+
+func TestReleaseWeatherRestoresNaturalWeather(t *testing.T) {
+	w := &World{WeatherCondition: "clear"}
+	id := w.LeaseWeather("fog", 60, "ritual")
+	w.setNaturalWeather("snow")
+	if w.WeatherCondition != "fog" {
+		t.Fatalf("natural change showed through the lease: %q", w.WeatherCondition)
+	}
+
+	if !w.ReleaseWeather(id) {
+		t.Fatalf("ReleaseWeather(%d) found no lease", id)
+	}
+	if w.WeatherCondition != "snow" {
+		t.Errorf("weather %q after release, want snow", w.WeatherCondition)
+	}
+	if w.ReleaseWeather(id) {
+		t.Errorf("released the same lease twice")
+	}
+}
+
+func TestLoadWeatherWithoutSaveKeepsWeather(t *testing.T) {
+	w := &World{WeatherCondition: "clear"}
+	if err := w.LoadWeather(t.TempDir()); err != nil {
+		t.Fatalf("LoadWeather without a file: %v", err)
+	}
+	if w.WeatherCondition != "clear" || len(w.WeatherLeases()) != 0 {
+		t.Errorf("new world starts with %q and %d leases", w.WeatherCondition, len(w.WeatherLeases()))
+	}
+}
@@ -5,8 +5,9 @@ import (
 	"math"
 	"math/rand"
 	"strconv"
-	"time"
+	"sync"
 
+	"echo-taiga/internal/engine"
 	"echo-taiga/internal/engine/ecs"
 	"echo-taiga/internal/metamorphosis"
 	"echo-taiga/internal/world/biomes"
@@ -16,6 +17,10 @@ import (
 // ChunkSize определяет размер чанка в игровых единицах
 const ChunkSize = 64
 
+// BiomeRegionSize - сторона области карты биомов в чанках: карта биомов
+// адресуется координатами чанков
+const BiomeRegionSize = 16
+
 // ViewDistance определяет, сколько чанков вокруг игрока должны быть активны
 const ViewDistance = 3
 
@@ -28,8 +33,15 @@ type Chunk struct {
 	IsGenerated      bool
 	IsActive         bool
 	AnomalyLevel     float64 // Уровень аномальности чанка (0-1)
+	anomalyBaseline  float64 // Уровень без распространения порчи, к которому чанк возвращается
 	LastVisited      int64   // Время последнего посещения игроком
 	BiomeType        string  // Тип биома в этом чанке
+
+	// Смещения высот по ID эффектов метаморфоза (для отмены при снятии эффекта)
+	Distortions map[string][][]float64
+
+	// Сохраненные изменения террейна еще не возвращены чанку (см. restoreTerrainDiff)
+	terrainPending bool
 }
 
 // World представляет весь игровой мир
@@ -39,45 +51,146 @@ type World struct {
 	ActiveChunks       map[[2]int]*Chunk
 	BiomeMap           *biomes.BiomeMap
 	TimeOfDay          float64 // 0.0 - 1.0, где 0.0 - полночь, 0.5 - полдень
+	Day                int     // Число прошедших полных суток
 	MetamorphManager   *metamorphosis.MetamorphosisManager
 	ECSWorld           *ecs.World
 	PlayerPosition     ecs.Vector3
 	GlobalAnomalyLevel float64                   // Общий уровень аномальности мира
 	WeatherCondition   string                    // Текущие погодные условия
+	Forecast           string                    // Объявленная погода следующей смены ("" - неизвестна)
 	ChunkEntities      map[[2]int][]ecs.EntityID // Кэш сущностей по чанкам
 	TerrainGenerator   *terrain.Generator
+
+	// Фауна биомов, частота и поведение видов животных
+	BiomeFauna   map[string][]string
+	FaunaSpecies map[string]FaunaSpecies
+
+	// Палитры, которыми метаморфозы окрашивают сущности; в доступном режиме
+	// используются только цвета, различимые при нарушениях цветового зрения
+	Palettes           *ColorPalettes
+	ColorblindPalettes bool
+
+	// Радиус безопасной зоны вокруг начала мира (в чанках, 0 - зоны нет)
+	SafeZoneRadius int
+
+	// Доля обычных объектов и животных, создаваемых при генерации чанка (0-1)
+	EntityDensityScale float64
+
+	// Тепловая карта аномалий
+	HeatmapEnabled   bool
+	OnHeatmapToggled func(enabled bool)
+	anomalyFields    *anomalyFieldCache
+
+	// Взаимодействие с древними кругами камней (обрабатывается системой символов)
+	OnAncientSiteInteract func(actor, site *ecs.Entity) bool
+
+	// Изучение вырезанных в мире символов (обрабатывается системой символов)
+	OnSymbolInteract func(actor, symbol *ecs.Entity) bool
+
+	// Распределение типов символов по миру и реестр, из которого они берутся
+	SymbolPlanner *SymbolPlacementPlanner
+	symbolCatalog SymbolCatalog
+
+	// Размещение древних кругов камней
+	LandmarkPlanner *LandmarkPlanner
+
+	// Фрактальный шум, придающий порче форму островов и прожилок
+	AnomalyNoise AnomalyNoiseParams
+
+	// Проводимость аномальности по типам биомов (см. spreadAnomaly)
+	AnomalyConductivity map[string]float64
+	anomalySpreadTimer  float64
+
+	// Сохраненные якоря стабильности чанков, которые еще не генерировались
+	savedAnchors map[[2]int][]AnchorRecord
+
+	// Изменения террейна выгруженных чанков (см. storeTerrainDiff)
+	terrainDiffs map[[2]int][]TerrainTile
+
+	// Метки целей заданий и ритуалов (см. AddMarker)
+	markers *markerIndex
+
+	// Погода, навязанная ритуалами и испугами (см. LeaseWeather)
+	weather weatherLeases
+
+	// Защита активных чанков и их списков сущностей от чтения из других горутин
+	// (см. ActiveChunkEntities). Изменения увеличивают счетчик поколений.
+	chunkMutex      sync.RWMutex
+	generation      uint64
+	pendingEntities []pendingEntity
+
+	// Снятые эффекты, последствия которых еще не отменены (см. revertEffectEverywhere)
+	revertMutex    sync.Mutex
+	pendingReverts []string
+
+	// Чанк каждой сущности из списков чанков (см. syncChunkMembership)
+	entityChunks map[ecs.EntityID][2]int
+
+	membershipTimer float64
+
+	// Сущности эффектов ритуалов и оставшееся время их жизни (см. expireEntityAfter)
+	expiringEntities map[ecs.EntityID]float64
+
+	// Источник текущего времени (см. SetClock)
+	clock engine.Clock
+
+	// Собственный поток случайных чисел мира, производный от сида; используется
+	// из игрового цикла (Update)
+	rng *rand.Rand
 }
 
-// NewWorld создает новый мир с указанным сидом
-func NewWorld(seed int64, ecsWorld *ecs.World) *World {
+// NewWorld создает новый мир с указанным сидом.
+// Менеджер метаморфоз создается и инициализируется вызывающей стороной,
+// чтобы его состояние сохранялось в активный слот.
+func NewWorld(seed int64, ecsWorld *ecs.World, metamorphManager *metamorphosis.MetamorphosisManager) *World {
 	world := &World{
-		Seed:               seed,
-		Chunks:             make(map[[2]int]*Chunk),
-		ActiveChunks:       make(map[[2]int]*Chunk),
-		TimeOfDay:          0.25, // Начинаем с рассвета
-		ECSWorld:           ecsWorld,
-		ChunkEntities:      make(map[[2]int][]ecs.EntityID),
-		GlobalAnomalyLevel: 0.1, // Начальный низкий уровень аномальности
-		WeatherCondition:   "clear",
+		Seed:                seed,
+		Chunks:              make(map[[2]int]*Chunk),
+		ActiveChunks:        make(map[[2]int]*Chunk),
+		TimeOfDay:           0.25, // Начинаем с рассвета
+		MetamorphManager:    metamorphManager,
+		ECSWorld:            ecsWorld,
+		ChunkEntities:       make(map[[2]int][]ecs.EntityID),
+		entityChunks:        make(map[ecs.EntityID][2]int),
+		terrainDiffs:        make(map[[2]int][]TerrainTile),
+		markers:             newMarkerIndex(),
+		GlobalAnomalyLevel:  0.1, // Начальный низкий уровень аномальности
+		WeatherCondition:    "clear",
+		BiomeFauna:          DefaultBiomeFauna(),
+		FaunaSpecies:        DefaultFaunaSpecies(),
+		Palettes:            DefaultColorPalettes(),
+		SafeZoneRadius:      DefaultSafeZoneRadius,
+		EntityDensityScale:  1.0,
+		anomalyFields:       newAnomalyFieldCache(),
+		SymbolPlanner:       NewSymbolPlacementPlanner(),
+		LandmarkPlanner:     NewLandmarkPlanner(seed),
+		AnomalyNoise:        DefaultAnomalyNoiseParams(),
+		AnomalyConductivity: DefaultAnomalyConductivity(),
+		clock:               engine.RealClock{},
+		rng:                 engine.NewRandStream(seed, RandStream),
 	}
 
+	// Поля аномальности пересчитываются при изменении эффектов и уровней областей
+	world.subscribeAnomalyChanges()
+
 	// Инициализируем биомы
-	world.BiomeMap = biomes.NewBiomeMap(seed)
+	world.BiomeMap = biomes.NewBiomeMap(BiomeRegionSize, seed)
 
 	// Инициализируем генератор террейна
 	world.TerrainGenerator = terrain.NewGenerator(seed, world.BiomeMap)
-
-	// Инициализируем менеджер метаморфоз
-	world.MetamorphManager = metamorphosis.NewMetamorphosisManager(ecsWorld, "saves/metamorphosis")
-	err := world.MetamorphManager.Init()
-	if err != nil {
-		// Логировать ошибку, но продолжить работу
-		println("Failed to initialize metamorphosis manager:", err.Error())
-	}
+	world.TerrainGenerator.NeighborTerrain = world.generatedTerrain
 
 	return world
 }
 
+// RandStream - имя потока случайных чисел мира (см. engine.NewRandStream)
+const RandStream = "world"
+
+// SetClock задает источник времени для отметок посещения чанков
+func (w *World) SetClock(clock engine.Clock) {
+	w.clock = clock
+}
+
 // GetChunkAt возвращает чанк в указанной позиции
 func (w *World) GetChunkAt(x, y int) *Chunk {
 	pos := [2]int{x, y}
@@ -92,6 +205,14 @@ func (w *World) GetChunkAt(x, y int) *Chunk {
 	return chunk
 }
 
+// generatedTerrain возвращает террейн уже сгенерированного чанка, не создавая новый
+func (w *World) generatedTerrain(x, y int) *terrain.TerrainData {
+	if chunk, exists := w.Chunks[[2]int{x, y}]; exists {
+		return chunk.Terrain
+	}
+	return nil
+}
+
 // GetChunkAtPosition возвращает чанк, содержащий указанную мировую позицию
 func (w *World) GetChunkAtPosition(worldX, worldZ float64) *Chunk {
 	// Преобразуем мировые координаты в координаты чанка
@@ -101,57 +222,82 @@ func (w *World) GetChunkAtPosition(worldX, worldZ float64) *Chunk {
 	return w.GetChunkAt(chunkX, chunkZ)
 }
 
+// BiomeAtPosition возвращает биом уже сгенерированного чанка, содержащего
+// позицию. В отличие от GetChunkAtPosition не генерирует чанк, поэтому
+// подходит для опроса каждый кадр.
+func (w *World) BiomeAtPosition(worldX, worldZ float64) (string, bool) {
+	pos := [2]int{int(math.Floor(worldX / ChunkSize)), int(math.Floor(worldZ / ChunkSize))}
+
+	w.chunkMutex.RLock()
+	defer w.chunkMutex.RUnlock()
+
+	chunk, exists := w.Chunks[pos]
+	if !exists {
+		return "", false
+	}
+	return chunk.BiomeType, true
+}
+
 // ActivateChunk активирует чанк для обработки
 func (w *World) ActivateChunk(x, y int) {
+	w.chunkMutex.Lock()
+	defer w.chunkMutex.Unlock()
+
 	chunk := w.GetChunkAt(x, y)
 	pos := [2]int{x, y}
 
 	if !chunk.IsActive {
 		chunk.IsActive = true
 		w.ActiveChunks[pos] = chunk
+		w.bumpGeneration()
 
-		// Загружаем сущности из чанка, если они были сохранены
-		if entities, exists := w.ChunkEntities[pos]; exists {
-			for _, entityID := range entities {
+		// Реактивируем сущности чанка, если он уже был активен
+		if _, stored := w.ChunkEntities[pos]; stored {
+			delete(w.ChunkEntities, pos)
+			for _, entityID := range chunk.Entities {
 				// Получаем сущность из мира ECS
 				entity, exists := w.ECSWorld.GetEntity(entityID)
 				if exists {
 					// Реактивируем сущность
-					if entity.HasComponent(ecs.AIComponentID) {
-						aiComp, _ := entity.GetComponent(ecs.AIComponentID)
-						ai := aiComp.(*ecs.AIComponent)
+					if ai, has := ecs.ComponentAs[*ecs.AIComponent](entity, ecs.AIComponentID); has {
 						ai.SetState("idle") // Сбрасываем состояние ИИ
 					}
 				}
 			}
 		}
 
-		// Применяем эффекты метаморфоза к чанку
+		// Возвращаем изменения террейна, сделанные до выгрузки, и применяем эффекты метаморфоза
+		w.restoreTerrainDiff(chunk)
 		w.applyChunkMetamorphoses(chunk)
 	}
 }
 
 // DeactivateChunk деактивирует чанк
 func (w *World) DeactivateChunk(x, y int) {
+	w.chunkMutex.Lock()
+	defer w.chunkMutex.Unlock()
+
 	pos := [2]int{x, y}
 	chunk, exists := w.Chunks[pos]
 
 	if exists && chunk.IsActive {
 		chunk.IsActive = false
 		delete(w.ActiveChunks, pos)
+		w.bumpGeneration()
 
-		// Сохраняем сущности чанка и удаляем их из активного мира
+		// Сохраняем сущности чанка и изменения его террейна
 		w.storeChunkEntities(chunk)
+		w.storeTerrainDiff(chunk)
 	}
 }
 
-// storeChunkEntities сохраняет ссылки на сущности чанка и деактивирует их
+// storeChunkEntities сохраняет в кэше снимок сущностей деактивируемого чанка.
+// Сущности остаются в списке чанка: он по-прежнему владеет ими.
 func (w *World) storeChunkEntities(chunk *Chunk) {
-	// Сохраняем текущий список сущностей в кэше
-	w.ChunkEntities[[2]int{chunk.Position[0], chunk.Position[1]}] = chunk.Entities
+	// Сначала сверяем списки, чтобы в снимок не попали погибшие и ушедшие сущности
+	w.syncChunkMembership()
 
-	// Очищаем список сущностей в чанке
-	chunk.Entities = []ecs.EntityID{}
+	w.ChunkEntities[chunk.Position] = append([]ecs.EntityID(nil), chunk.Entities...)
 }
 
 // UpdateActiveChunks обновляет список активных чанков вокруг игрока
@@ -180,11 +326,44 @@ func (w *World) UpdateActiveChunks() {
 	}
 
 	// Деактивируем чанки, которые вышли из зоны видимости
-	for pos := range w.ActiveChunks {
-		if !shouldBeActive[pos] {
-			w.DeactivateChunk(pos[0], pos[1])
+	for _, chunk := range w.activeChunkList() {
+		if !shouldBeActive[chunk.Position] {
+			w.DeactivateChunk(chunk.Position[0], chunk.Position[1])
+		}
+	}
+}
+
+// GenerateSpawnRegion заранее генерирует и активирует чанки вокруг точки появления игрока,
+// сообщая о прогрессе по количеству сгенерированных чанков
+func (w *World) GenerateSpawnRegion(center ecs.Vector3, progress engine.ProgressReporter) {
+	w.PlayerPosition = center
+
+	centerX := int(math.Floor(center.X / ChunkSize))
+	centerZ := int(math.Floor(center.Z / ChunkSize))
+
+	// Собираем чанки в радиусе просмотра (та же круглая область, что и в UpdateActiveChunks)
+	positions := make([][2]int, 0)
+	for x := centerX - ViewDistance; x <= centerX+ViewDistance; x++ {
+		for z := centerZ - ViewDistance; z <= centerZ+ViewDistance; z++ {
+			distX := x - centerX
+			distZ := z - centerZ
+			if distX*distX+distZ*distZ <= ViewDistance*ViewDistance {
+				positions = append(positions, [2]int{x, z})
+			}
 		}
 	}
+
+	for i, pos := range positions {
+		engine.ReportCount(progress, "spawn_chunks", i, len(positions))
+		w.ActivateChunk(pos[0], pos[1])
+	}
+
+	// Рядом с началом мира должны встречаться символы всех типов
+	w.chunkMutex.Lock()
+	w.ensureSymbolCoverage()
+	w.bumpGeneration()
+	w.chunkMutex.Unlock()
+	progress.ReportProgress("spawn_chunks", 1)
 }
 
 // Генерирует новый чанк в указанной позиции
@@ -198,15 +377,29 @@ func (w *World) generateChunk(x, y int) *Chunk {
 	}
 
 	// Определяем тип биома для этого чанка
-	biomeType := w.BiomeMap.GetBiomeAt(float64(x), float64(y))
+	biomeType := string(w.BiomeMap.GetBiomeAt(float64(x), float64(y)))
 	chunk.BiomeType = biomeType
 
 	// Генерируем террейн для чанка
 	chunk.Terrain = w.TerrainGenerator.GenerateChunkTerrain(x, y, ChunkSize)
+	chunk.Terrain.TrackChanges()
+	_, chunk.terrainPending = w.terrainDiffs[chunk.Position]
+
+	// Базовый уровень, к которому чанк оседает без источников порчи
+	chunk.anomalyBaseline = w.chunkAnomalyBaseline(chunk)
 
-	// Устанавливаем начальный уровень аномальности на основе удаленности от центра
-	distFromCenter := math.Sqrt(float64(x*x + y*y))
-	chunk.AnomalyLevel = math.Min(1.0, w.GlobalAnomalyLevel+(distFromCenter/10.0)*0.05)
+	// Начальный уровень продолжает порчу известных соседей; базовый уровень
+	// берется, только если соседей нет или их отделяет вода
+	chunk.AnomalyLevel = chunk.anomalyBaseline
+	if level, ok := w.neighborAnomalyLevel(chunk); ok {
+		chunk.AnomalyLevel = level
+	}
+
+	// В безопасной зоне аномальность почти нулевая
+	if w.IsInSafeZone(x, y) {
+		chunk.anomalyBaseline = math.Min(chunk.anomalyBaseline, SafeZoneAnomalyLevel)
+		chunk.AnomalyLevel = math.Min(chunk.AnomalyLevel, SafeZoneAnomalyLevel)
+	}
 
 	// Добавляем базовые сущности в зависимости от биома
 	w.populateChunkWithEntities(chunk)
@@ -223,46 +416,66 @@ func (w *World) populateChunkWithEntities(chunk *Chunk) {
 	worldX := float64(chunk.Position[0] * ChunkSize)
 	worldZ := float64(chunk.Position[1] * ChunkSize)
 
+	// Стабильность сущностей зависит от биома
+	stability := w.biomeStability(chunk.BiomeType)
+
+	// Обычные объекты чанка добавляются в мир одной пачкой
+	batch := make([]*ecs.Entity, 0, 64)
+
 	// Добавляем различные объекты в зависимости от биома
 	switch chunk.BiomeType {
 	case "taiga":
 		// Добавляем деревья
 		treeCount := 10 + r.Intn(15) // 10-24 деревьев на чанк
+		kept := w.scaledEntityCount(treeCount)
 		for i := 0; i < treeCount; i++ {
 			// Случайная позиция внутри чанка
 			x := worldX + r.Float64()*ChunkSize
 			z := worldZ + r.Float64()*ChunkSize
 
+			// При пониженной плотности лишние объекты пропускаются, не сбивая генератор
+			if i >= kept {
+				r.Float64()
+				continue
+			}
+
 			// Получаем высоту местности в этой точке
 			y := chunk.Terrain.GetHeightAt(x-worldX, z-worldZ)
 
 			// Создаем дерево
-			treeEntity := createTree(w.ECSWorld, ecs.Vector3{X: x, Y: y, Z: z}, r.Float64())
-
-			// Добавляем в список сущностей чанка
-			chunk.Entities = append(chunk.Entities, treeEntity.ID)
+			batch = append(batch, newTree(ecs.Vector3{X: x, Y: y, Z: z}, r.Float64(), stability))
 		}
 
 		// Добавляем камни
 		rockCount := 5 + r.Intn(10) // 5-14 камней на чанк
+		kept = w.scaledEntityCount(rockCount)
 		for i := 0; i < rockCount; i++ {
 			x := worldX + r.Float64()*ChunkSize
 			z := worldZ + r.Float64()*ChunkSize
+
+			if i >= kept {
+				r.Float64()
+				continue
+			}
 			y := chunk.Terrain.GetHeightAt(x-worldX, z-worldZ)
 
-			rockEntity := createRock(w.ECSWorld, ecs.Vector3{X: x, Y: y, Z: z}, r.Float64())
-			chunk.Entities = append(chunk.Entities, rockEntity.ID)
+			batch = append(batch, newRock(ecs.Vector3{X: x, Y: y, Z: z}, r.Float64(), stability))
 		}
 
 		// Добавляем кусты
 		bushCount := 8 + r.Intn(12) // 8-19 кустов на чанк
+		kept = w.scaledEntityCount(bushCount)
 		for i := 0; i < bushCount; i++ {
 			x := worldX + r.Float64()*ChunkSize
 			z := worldZ + r.Float64()*ChunkSize
+
+			if i >= kept {
+				r.Float64()
+				continue
+			}
 			y := chunk.Terrain.GetHeightAt(x-worldX, z-worldZ)
 
-			bushEntity := createBush(w.ECSWorld, ecs.Vector3{X: x, Y: y, Z: z}, r.Float64())
-			chunk.Entities = append(chunk.Entities, bushEntity.ID)
+			batch = append(batch, newBush(ecs.Vector3{X: x, Y: y, Z: z}, r.Float64(), stability))
 		}
 
 		// С малой вероятностью добавляем особые объекты
@@ -272,8 +485,7 @@ func (w *World) populateChunkWithEntities(chunk *Chunk) {
 			z := worldZ + ChunkSize*0.5 + (r.Float64()-0.5)*ChunkSize*0.5
 			y := chunk.Terrain.GetHeightAt(x-worldX, z-worldZ)
 
-			clearingEntity := createClearing(w.ECSWorld, ecs.Vector3{X: x, Y: y, Z: z}, r.Float64())
-			chunk.Entities = append(chunk.Entities, clearingEntity.ID)
+			batch = append(batch, newClearing(ecs.Vector3{X: x, Y: y, Z: z}, r.Float64(), stability))
 		}
 
 		// С очень малой вероятностью добавляем символ
@@ -282,8 +494,13 @@ func (w *World) populateChunkWithEntities(chunk *Chunk) {
 			z := worldZ + r.Float64()*ChunkSize
 			y := chunk.Terrain.GetHeightAt(x-worldX, z-worldZ)
 
-			symbolEntity := createSymbol(w.ECSWorld, ecs.Vector3{X: x, Y: y, Z: z}, r.Float64())
-			chunk.Entities = append(chunk.Entities, symbolEntity.ID)
+			// Тип выбирает планировщик, чтобы редкие типы не пропадали из мира,
+			// а символ берется из реестра того же типа
+			randomFactor := r.Float64()
+			kind := w.SymbolPlanner.ChooseKind(chunk.Position[0], chunk.Position[1], 0, randomFactor)
+			symbolID := w.SymbolPlanner.ChooseSymbol(chunk.Position[0], chunk.Position[1], 0, kind, w.symbolCatalog)
+			symbolEntity := createSymbol(w.ECSWorld, ecs.Vector3{X: x, Y: y, Z: z}, randomFactor, symbolID, w.interactWithSymbol)
+			w.addChunkEntities(chunk, symbolEntity.ID)
 		}
 
 	case "marsh":
@@ -297,16 +514,36 @@ func (w *World) populateChunkWithEntities(chunk *Chunk) {
 
 	// Добавляем животных с малой вероятностью
 	if r.Float64() < 0.3 { // 30% шанс для чанка иметь животных
-		animalCount := 1 + r.Intn(3) // 1-3 животных
+		// Животные генерируются последними, поэтому их число просто уменьшается
+		animalCount := w.scaledEntityCount(1 + r.Intn(3)) // 1-3 животных
 		for i := 0; i < animalCount; i++ {
 			x := worldX + r.Float64()*ChunkSize
 			z := worldZ + r.Float64()*ChunkSize
 			y := chunk.Terrain.GetHeightAt(x-worldX, z-worldZ)
 
-			animalEntity := createAnimal(w.ECSWorld, ecs.Vector3{X: x, Y: y, Z: z}, r.Float64())
-			chunk.Entities = append(chunk.Entities, animalEntity.ID)
+			// Вид выбирается из фауны биома с учетом частоты видов
+			species := w.chooseFauna(chunk.BiomeType, r)
+			traits := w.faunaSpecies(species)
+			if traits.Group != "" && traits.MaxGroupSize >= metamorphosis.MinGroupSize {
+				batch = append(batch, w.newAnimalGroup(chunk, i, ecs.Vector3{X: x, Y: y, Z: z}, species, traits, stability)...)
+				continue
+			}
+			batch = append(batch, newAnimal(ecs.Vector3{X: x, Y: y, Z: z}, species, traits, stability))
 		}
 	}
+
+	w.addChunkEntities(chunk, w.ECSWorld.AddEntities(batch)...)
+
+	// Древние круги камней стоят в любом биоме
+	w.spawnAncientCircle(chunk, stability)
+
+	// Символы, ранее добавленные планировщиком ради покрытия типов
+	for _, planned := range w.SymbolPlanner.guaranteedIn(chunk.Position[0], chunk.Position[1]) {
+		w.spawnPlannedSymbol(chunk, planned)
+	}
+
+	// Якоря стабильности из сохранения
+	w.restoreAnchors(chunk)
 }
 
 // applyChunkMetamorphoses применяет эффекты метаморфоза к чанку
@@ -331,13 +568,14 @@ func (w *World) applyChunkMetamorphoses(chunk *Chunk) {
 				chunk.MetamorphEffects = append(chunk.MetamorphEffects, effect.ID)
 
 				// Применяем эффект к террейну и сущностям чанка
-				applyMetamorphEffectToChunk(w.ECSWorld, chunk, effect)
+				w.applyMetamorphEffectToChunk(chunk, effect)
 			}
 		}
 	}
 
 	// Обновляем уровень аномальности чанка
-	chunk.AnomalyLevel = calculateChunkAnomalyLevel(chunk, w.GlobalAnomalyLevel)
+	w.refreshChunkAnomalyLevel(chunk)
+	w.invalidateAnomalyFieldsAround(chunk.Position[0], chunk.Position[1])
 }
 
 // isChunkAffectedByEffect определяет, влияет ли эффект метаморфоза на данный чанк
@@ -380,7 +618,9 @@ func isChunkAffectedByEffect(chunk *Chunk, effect *metamorphosis.MetamorphEffect
 }
 
 // applyMetamorphEffectToChunk применяет эффект метаморфоза к чанку
-func applyMetamorphEffectToChunk(world *ecs.World, chunk *Chunk, effect *metamorphosis.MetamorphEffect) {
+func (w *World) applyMetamorphEffectToChunk(chunk *Chunk, effect *metamorphosis.MetamorphEffect) {
+	world := w.ECSWorld
+
 	// Применяем эффект в зависимости от его типа и порядка
 	switch effect.Order {
 	case metamorphosis.OrderFirst:
@@ -392,15 +632,10 @@ func applyMetamorphEffectToChunk(world *ecs.World, chunk *Chunk, effect *metamor
 			}
 
 			// Если есть компонент рендера, изменяем его
-			if entity.HasComponent(ecs.RenderComponentID) {
-				renderComp, _ := entity.GetComponent(ecs.RenderComponentID)
-				render := renderComp.(*ecs.RenderComponent)
-
+			if render, has := ecs.ComponentAs[*ecs.RenderComponent](entity, ecs.RenderComponentID); has {
 				// Добавляем визуальные эффекты
 				for _, visualEffect := range effect.VisualEffects {
-					if !containsString(render.Effects, visualEffect) {
-						render.Effects = append(render.Effects, visualEffect)
-					}
+					render.Effects.AddEffect(visualEffect, effect.Intensity, effect.ID)
 				}
 
 				// Увеличиваем искажение
@@ -413,10 +648,7 @@ func applyMetamorphEffectToChunk(world *ecs.World, chunk *Chunk, effect *metamor
 			}
 
 			// Если есть компонент звука, изменяем его
-			if entity.HasComponent(ecs.SoundEmitterComponentID) {
-				soundComp, _ := entity.GetComponent(ecs.SoundEmitterComponentID)
-				sound := soundComp.(*ecs.SoundEmitterComponent)
-
+			if sound, has := ecs.ComponentAs[*ecs.SoundEmitterComponent](entity, ecs.SoundEmitterComponentID); has {
 				// Добавляем звуковые эффекты
 				for _, soundEffect := range effect.SoundEffects {
 					if sound.Sounds[soundEffect] != "" {
@@ -436,10 +668,7 @@ func applyMetamorphEffectToChunk(world *ecs.World, chunk *Chunk, effect *metamor
 			}
 
 			// Если есть компонент трансформации, изменяем его
-			if entity.HasComponent(ecs.TransformComponentID) {
-				transformComp, _ := entity.GetComponent(ecs.TransformComponentID)
-				transform := transformComp.(*ecs.TransformComponent)
-
+			if transform, has := ecs.ComponentAs[*ecs.TransformComponent](entity, ecs.TransformComponentID); has {
 				// Случайные изменения масштаба и поворота
 				eIconv, _ := strconv.ParseInt(string(entityID), 10, 64)
 				r := rand.New(rand.NewSource(eIconv))
@@ -454,10 +683,7 @@ func applyMetamorphEffectToChunk(world *ecs.World, chunk *Chunk, effect *metamor
 			}
 
 			// Если есть метаморфный компонент, увеличиваем его аномальность
-			if entity.HasComponent(ecs.MetamorphicComponentID) {
-				metaComp, _ := entity.GetComponent(ecs.MetamorphicComponentID)
-				meta := metaComp.(*ecs.MetamorphicComponent)
-
+			if meta, has := ecs.ComponentAs[*ecs.MetamorphicComponent](entity, ecs.MetamorphicComponentID); has {
 				meta.AbnormalityIndex += 0.1 * effect.Intensity
 				if meta.AbnormalityIndex > 1.0 {
 					meta.AbnormalityIndex = 1.0
@@ -468,7 +694,7 @@ func applyMetamorphEffectToChunk(world *ecs.World, chunk *Chunk, effect *metamor
 		// Изменяем террейн - создаем аномалии рельефа
 		if effect.Category == "environment" {
 			// Например, создаем холмы или впадины
-			chunk.Terrain.ApplyDistortion(effect.Intensity)
+			distortChunkTerrain(chunk, effect.ID, effect.Intensity)
 		}
 
 	case metamorphosis.OrderThird:
@@ -480,10 +706,7 @@ func applyMetamorphEffectToChunk(world *ecs.World, chunk *Chunk, effect *metamor
 			}
 
 			// Изменяем поведение ИИ, если есть
-			if entity.HasComponent(ecs.AIComponentID) {
-				aiComp, _ := entity.GetComponent(ecs.AIComponentID)
-				ai := aiComp.(*ecs.AIComponent)
-
+			if ai, has := ecs.ComponentAs[*ecs.AIComponent](entity, ecs.AIComponentID); has {
 				// Увеличиваем агрессивность в зависимости от интенсивности
 				if effect.Intensity > 0.5 && ai.AIType == "neutral" {
 					ai.AIType = "aggressive"
@@ -495,21 +718,17 @@ func applyMetamorphEffectToChunk(world *ecs.World, chunk *Chunk, effect *metamor
 			}
 
 			// Добавляем новые способности и свойства
-			if entity.HasTag("animal") && effect.Intensity > 0.7 {
+			if entity.HasTag(TagAnimal) && effect.Intensity > 0.7 {
 				// Создаем мутировавшее животное с новыми способностями
-				entity.AddTag("mutated")
+				entity.AddTag(TagMutated)
 
 				// Добавляем свечение
-				if entity.HasComponent(ecs.RenderComponentID) {
-					renderComp, _ := entity.GetComponent(ecs.RenderComponentID)
-					render := renderComp.(*ecs.RenderComponent)
-					render.Effects = append(render.Effects, "glow")
+				if render, has := ecs.ComponentAs[*ecs.RenderComponent](entity, ecs.RenderComponentID); has {
+					render.Effects.AddEffect("glow", effect.Intensity, effect.ID)
 				}
 
 				// Добавляем компонент здоровья или усиливаем его
-				if entity.HasComponent(ecs.HealthComponentID) {
-					healthComp, _ := entity.GetComponent(ecs.HealthComponentID)
-					health := healthComp.(*ecs.HealthComponent)
+				if health, has := ecs.ComponentAs[*ecs.HealthComponent](entity, ecs.HealthComponentID); has {
 					health.MaxHealth *= 1.5
 					health.CurrentHealth = health.MaxHealth
 				}
@@ -532,26 +751,23 @@ func applyMetamorphEffectToChunk(world *ecs.World, chunk *Chunk, effect *metamor
 				continue
 			}
 
-			if entity.HasComponent(ecs.PhysicsComponentID) {
-				physicsComp, _ := entity.GetComponent(ecs.PhysicsComponentID)
-				physics := physicsComp.(*ecs.PhysicsComponent)
-
+			// Глобальные эффекты меняют физику через действующие правила мира
+			// (см. GetEffectiveWorldRules); здесь остаются только местные
+			physics, has := ecs.ComponentAs[*ecs.PhysicsComponent](entity, ecs.PhysicsComponentID)
+			if has && effect.AffectedArea != nil {
 				// Изменяем гравитацию
-				if effect.WorldChanges["physics.gravity"] != 0 {
-					physics.Gravity = effect.WorldChanges["physics.gravity"]
+				if effect.WorldChanges[engine.GravityRule] != 0 {
+					physics.Gravity = effect.WorldChanges[engine.GravityRule]
 				}
 
 				// Изменяем трение
-				if effect.WorldChanges["physics.friction"] != 0 {
-					physics.Friction = effect.WorldChanges["physics.friction"]
+				if effect.WorldChanges[engine.FrictionRule] != 0 {
+					physics.Friction = effect.WorldChanges[engine.FrictionRule]
 				}
 			}
 
 			// Радикально изменяем внешний вид
-			if entity.HasComponent(ecs.RenderComponentID) {
-				renderComp, _ := entity.GetComponent(ecs.RenderComponentID)
-				render := renderComp.(*ecs.RenderComponent)
-
+			if render, has := ecs.ComponentAs[*ecs.RenderComponent](entity, ecs.RenderComponentID); has {
 				// Сильные визуальные искажения
 				if effect.WorldChanges["rendering.distortion"] != 0 {
 					render.Distortion = effect.WorldChanges["rendering.distortion"]
@@ -559,23 +775,18 @@ func applyMetamorphEffectToChunk(world *ecs.World, chunk *Chunk, effect *metamor
 
 				// Добавляем эффекты
 				for _, visualEffect := range effect.VisualEffects {
-					if !containsString(render.Effects, visualEffect) {
-						render.Effects = append(render.Effects, visualEffect)
-					}
+					render.Effects.AddEffect(visualEffect, effect.Intensity, effect.ID)
 				}
 
-				// Изменяем цвет
+				// Изменяем цвет в пределах палитры; снятие эффекта вернет собственный цвет
 				if effect.Category == "reality" {
-					// Радикальная смена цвета
-					render.Color.R = uint8(rand.Intn(255))
-					render.Color.G = uint8(rand.Intn(255))
-					render.Color.B = uint8(rand.Intn(255))
+					render.TintColor(w.mutationColor(render.OwnColor(), effect.Intensity, w.rng, effect.Category, chunk.BiomeType), effect.ID)
 				}
 			}
 		}
 
 		// Изменяем террейн более радикально
-		chunk.Terrain.ApplyDistortion(effect.Intensity * 2)
+		distortChunkTerrain(chunk, effect.ID, effect.Intensity*2)
 		// Можно создать порталы, разломы и т.д.
 
 	case metamorphosis.OrderFifth:
@@ -588,26 +799,23 @@ func applyMetamorphEffectToChunk(world *ecs.World, chunk *Chunk, effect *metamor
 			chunk.BiomeType = "void" // Изменяем биом на "пустоту"
 
 			// Заменяем все обычные объекты на искаженные версии
-			var newEntities []ecs.EntityID
-			for _, entityID := range chunk.Entities {
+			for _, entityID := range append([]ecs.EntityID(nil), chunk.Entities...) {
+				w.removeChunkEntity(entityID)
+
 				entity, exists := world.GetEntity(entityID)
 				if !exists {
 					continue
 				}
 
-				// Удаляем старую сущность
+				// Создаем искаженную версию, пока компоненты старой сущности
+				// не вернулись в пулы, и удаляем старую
+				distorted := w.createDistortedEntity(world, entity)
 				world.RemoveEntity(entityID)
-
-				// Создаем искаженную версию
-				newEntity := createDistortedEntity(world, entity)
-				newEntities = append(newEntities, newEntity.ID)
+				w.addChunkEntities(chunk, distorted.ID)
 			}
 
-			// Обновляем список сущностей
-			chunk.Entities = newEntities
-
 			// Полностью преобразуем террейн
-			chunk.Terrain = createVoidTerrain(chunk.Position[0], chunk.Position[1])
+			w.convertTerrainToVoid(chunk)
 		}
 
 		// Активируем новые механики
@@ -634,21 +842,47 @@ func calculateChunkAnomalyLevel(chunk *Chunk, globalLevel float64) float64 {
 // Update обновляет состояние мира
 func (w *World) Update(deltaTime float64) {
 	// Обновление времени суток
-	w.TimeOfDay += deltaTime * 0.001 // Примерный полный цикл за 1000 секунд
-	if w.TimeOfDay >= 1.0 {
-		w.TimeOfDay -= 1.0
-	}
+	w.advanceTimeOfDay(deltaTime)
+
+	// Навязанная погода держится, пока не истечет аренда
+	w.advanceWeatherLeases(deltaTime)
 
 	// Обновление активных чанков вокруг игрока
 	w.UpdateActiveChunks()
 
-	// Обновление каждого активного чанка
-	for _, chunk := range w.ActiveChunks {
+	// Обновление каждого активного чанка. Обходим копию набора, а созданные
+	// во время обхода сущности добавляем в чанки после него
+	for _, chunk := range w.activeChunkList() {
 		w.updateChunk(chunk, deltaTime)
 	}
+	w.flushChunkEntities()
+
+	// Сверка списков сущностей чанков с их перемещениями и гибелью
+	w.updateChunkMembership(deltaTime)
+
+	// Порча расползается между известными чанками раз в игровой час
+	w.advanceAnomalySpread(deltaTime)
+
+	// Поля аномальности, открытые ритуалами, видны ограниченное время
+	w.advanceRevealedFields(deltaTime)
+
+	// Призванные ритуалами духи и свет исчезают по истечении эффекта
+	w.advanceExpiringEntities(deltaTime)
 
 	// Обновление менеджера метаморфоз
 	w.MetamorphManager.Update(deltaTime)
+
+	// Рельеф и цвет чанков возвращаются после снятия эффектов
+	w.applyPendingReverts()
+}
+
+// advanceTimeOfDay продвигает время суток, отсчитывая прошедшие сутки
+func (w *World) advanceTimeOfDay(deltaTime float64) {
+	w.TimeOfDay += deltaTime * 0.001 // Примерный полный цикл за 1000 секунд
+	for w.TimeOfDay >= 1.0 {
+		w.TimeOfDay -= 1.0
+		w.Day++
+	}
 }
 
 // SetPlayerPosition устанавливает текущую позицию игрока
@@ -666,6 +900,11 @@ func (w *World) SetGlobalAnomalyLevel(level float64) {
 	w.GlobalAnomalyLevel = math.Max(0.0, math.Min(1.0, level))
 }
 
+// GetWeatherCondition возвращает текущие погодные условия
+func (w *World) GetWeatherCondition() string {
+	return w.WeatherCondition
+}
+
 // GetGlobalAnomalyLevel возвращает глобальный уровень аномальности
 func (w *World) GetGlobalAnomalyLevel() float64 {
 	return w.GlobalAnomalyLevel
@@ -688,7 +927,7 @@ func (w *World) updateChunk(chunk *Chunk, deltaTime float64) {
 	chunkZ := int(math.Floor(w.PlayerPosition.Z / ChunkSize))
 
 	if chunkX == chunk.Position[0] && chunkZ == chunk.Position[1] {
-		chunk.LastVisited = int64(time.Now().Unix())
+		chunk.LastVisited = int64(w.clock.Now().Unix())
 	}
 
 	// Обрабатываем эффекты метаморфоза
@@ -714,15 +953,16 @@ func (w *World) updateChunk(chunk *Chunk, deltaTime float64) {
 
 	// Проверяем условия для спавна новых сущностей
 	// Например, с малой вероятностью спавним существ ночью
-	if w.TimeOfDay < 0.25 || w.TimeOfDay > 0.75 { // Ночь
+	// В безопасной зоне ночные существа не появляются
+	if (w.TimeOfDay < 0.25 || w.TimeOfDay > 0.75) && !w.IsInSafeZone(chunk.Position[0], chunk.Position[1]) { // Ночь
 		// Изредка спавним ночных существ
-		if rand.Float64() < 0.01*deltaTime { // Корректируем по deltaTime для независимости от FPS
+		if w.rng.Float64() < 0.01*deltaTime { // Корректируем по deltaTime для независимости от FPS
 			w.spawnNightCreature(chunk)
 		}
 	}
 
 	// С вероятностью, зависящей от уровня аномальности, спавним аномалии
-	if rand.Float64() < chunk.AnomalyLevel*0.005*deltaTime {
+	if w.rng.Float64() < chunk.AnomalyLevel*0.005*deltaTime {
 		w.spawnAnomaly(chunk)
 	}
 }
@@ -733,16 +973,12 @@ func (w *World) spawnNightCreature(chunk *Chunk) {
 	worldX := float64(chunk.Position[0] * ChunkSize)
 	worldZ := float64(chunk.Position[1] * ChunkSize)
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	x := worldX + r.Float64()*ChunkSize
-	z := worldZ + r.Float64()*ChunkSize
+	x := worldX + w.rng.Float64()*ChunkSize
+	z := worldZ + w.rng.Float64()*ChunkSize
 	y := chunk.Terrain.GetHeightAt(x-worldX, z-worldZ)
 
-	// Создаем ночное существо
-	creatureEntity := createNightCreature(w.ECSWorld, ecs.Vector3{X: x, Y: y, Z: z}, chunk.AnomalyLevel)
-
-	// Добавляем в список сущностей чанка
-	chunk.Entities = append(chunk.Entities, creatureEntity.ID)
+	// Создаем ночное существо; в мир и чанк оно попадет после обхода активных чанков
+	w.queueChunkEntity(chunk, newNightCreature(ecs.Vector3{X: x, Y: y, Z: z}, chunk.AnomalyLevel))
 }
 
 // spawnAnomaly создает аномалию в чанке
@@ -751,9 +987,8 @@ func (w *World) spawnAnomaly(chunk *Chunk) {
 	worldX := float64(chunk.Position[0] * ChunkSize)
 	worldZ := float64(chunk.Position[1] * ChunkSize)
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	x := worldX + r.Float64()*ChunkSize
-	z := worldZ + r.Float64()*ChunkSize
+	x := worldX + w.rng.Float64()*ChunkSize
+	z := worldZ + w.rng.Float64()*ChunkSize
 	y := chunk.Terrain.GetHeightAt(x-worldX, z-worldZ)
 
 	// Создаем аномалию
@@ -765,16 +1000,14 @@ func (w *World) spawnAnomaly(chunk *Chunk) {
 		anomalyType = "major"
 	}
 
-	anomalyEntity := createAnomaly(w.ECSWorld, ecs.Vector3{X: x, Y: y, Z: z}, anomalyType)
-
-	// Добавляем в список сущностей чанка
-	chunk.Entities = append(chunk.Entities, anomalyEntity.ID)
+	// В мир и чанк аномалия попадет после обхода активных чанков
+	w.queueChunkEntity(chunk, newAnomaly(ecs.Vector3{X: x, Y: y, Z: z}, anomalyType))
 }
 
 // Вспомогательные функции для создания различных сущностей
 
-// createTree создает дерево в указанной позиции
-func createTree(world *ecs.World, position ecs.Vector3, randomFactor float64) *ecs.Entity {
+// newTree создает дерево в указанной позиции, не добавляя в мир
+func newTree(position ecs.Vector3, randomFactor, stabilityModifier float64) *ecs.Entity {
 	tree := ecs.NewEntity()
 
 	// Добавляем базовые компоненты
@@ -793,13 +1026,13 @@ func createTree(world *ecs.World, position ecs.Vector3, randomFactor float64) *e
 	tree.AddComponent(physicsComp)
 
 	// Добавляем метаморфный компонент с высокой стабильностью
-	metaComp := ecs.NewMetamorphicComponent(0.8) // Достаточно стабильны, но могут меняться
+	metaComp := ecs.NewMetamorphicComponent(scaledStability(0.8, stabilityModifier)) // Достаточно стабильны, но могут меняться
 	tree.AddComponent(metaComp)
 
 	// Добавляем теги
-	tree.AddTag("tree")
-	tree.AddTag("vegetation")
-	tree.AddTag("environment")
+	tree.AddTag(TagTree)
+	tree.AddTag(TagVegetation)
+	tree.AddTag(TagEnvironment)
 
 	// Случайно варьируем размер
 	transformComp, _ := tree.GetComponent(ecs.TransformComponentID)
@@ -808,14 +1041,11 @@ func createTree(world *ecs.World, position ecs.Vector3, randomFactor float64) *e
 	baseScale := 0.8 + randomFactor*0.4 // 0.8 - 1.2
 	transform.Scale = ecs.Vector3{X: baseScale, Y: baseScale + randomFactor*0.3, Z: baseScale}
 
-	// Добавляем сущность в мир
-	world.AddEntity(tree)
-
 	return tree
 }
 
-// createRock создает камень в указанной позиции
-func createRock(world *ecs.World, position ecs.Vector3, randomFactor float64) *ecs.Entity {
+// newRock создает камень в указанной позиции, не добавляя в мир
+func newRock(position ecs.Vector3, randomFactor, stabilityModifier float64) *ecs.Entity {
 	rock := ecs.NewEntity()
 
 	// Добавляем базовые компоненты
@@ -833,12 +1063,12 @@ func createRock(world *ecs.World, position ecs.Vector3, randomFactor float64) *e
 	physicsComp.ColliderSize = ecs.Vector3{X: 1.0, Y: 1.0, Z: 1.0} // Размер коллайдера
 
 	// Добавляем метаморфный компонент с очень высокой стабильностью
-	metaComp := ecs.NewMetamorphicComponent(0.9) // Очень стабильны, редко меняются
+	metaComp := ecs.NewMetamorphicComponent(scaledStability(0.9, stabilityModifier)) // Очень стабильны, редко меняются
 	rock.AddComponent(metaComp)
 
 	// Добавляем теги
-	rock.AddTag("rock")
-	rock.AddTag("environment")
+	rock.AddTag(TagRock)
+	rock.AddTag(TagEnvironment)
 
 	// Случайно варьируем размер и поворот
 	transformComp, _ := rock.GetComponent(ecs.TransformComponentID)
@@ -848,14 +1078,11 @@ func createRock(world *ecs.World, position ecs.Vector3, randomFactor float64) *e
 	transform.Scale = ecs.Vector3{X: baseScale, Y: baseScale * 0.8, Z: baseScale}
 	transform.Rotation = ecs.Vector3{X: 0, Y: randomFactor * 6.28, Z: 0} // Случайный поворот вокруг Y
 
-	// Добавляем сущность в мир
-	world.AddEntity(rock)
-
 	return rock
 }
 
-// createBush создает куст в указанной позиции
-func createBush(world *ecs.World, position ecs.Vector3, randomFactor float64) *ecs.Entity {
+// newBush создает куст в указанной позиции, не добавляя в мир
+func newBush(position ecs.Vector3, randomFactor, stabilityModifier float64) *ecs.Entity {
 	bush := ecs.NewEntity()
 
 	// Добавляем базовые компоненты
@@ -871,13 +1098,13 @@ func createBush(world *ecs.World, position ecs.Vector3, randomFactor float64) *e
 	bush.AddComponent(physicsComp)
 
 	// Добавляем метаморфный компонент со средней стабильностью
-	metaComp := ecs.NewMetamorphicComponent(0.6) // Средне стабильны, могут легко меняться
+	metaComp := ecs.NewMetamorphicComponent(scaledStability(0.6, stabilityModifier)) // Средне стабильны, могут легко меняться
 	bush.AddComponent(metaComp)
 
 	// Добавляем теги
-	bush.AddTag("bush")
-	bush.AddTag("vegetation")
-	bush.AddTag("environment")
+	bush.AddTag(TagBush)
+	bush.AddTag(TagVegetation)
+	bush.AddTag(TagEnvironment)
 
 	// Случайно варьируем размер
 	transformComp, _ := bush.GetComponent(ecs.TransformComponentID)
@@ -886,14 +1113,11 @@ func createBush(world *ecs.World, position ecs.Vector3, randomFactor float64) *e
 	baseScale := 0.7 + randomFactor*0.6 // 0.7 - 1.3
 	transform.Scale = ecs.Vector3{X: baseScale, Y: baseScale, Z: baseScale}
 
-	// Добавляем сущность в мир
-	world.AddEntity(bush)
-
 	return bush
 }
 
-// createClearing создает поляну или особое место
-func createClearing(world *ecs.World, position ecs.Vector3, randomFactor float64) *ecs.Entity {
+// newClearing создает поляну или особое место, не добавляя в мир
+func newClearing(position ecs.Vector3, randomFactor, stabilityModifier float64) *ecs.Entity {
 	clearing := ecs.NewEntity()
 
 	// Добавляем базовые компоненты
@@ -909,28 +1133,25 @@ func createClearing(world *ecs.World, position ecs.Vector3, randomFactor float64
 	clearing.AddComponent(interactComp)
 
 	// Добавляем метаморфный компонент с низкой стабильностью
-	metaComp := ecs.NewMetamorphicComponent(0.3) // Нестабильны, легко меняются
+	metaComp := ecs.NewMetamorphicComponent(scaledStability(0.3, stabilityModifier)) // Нестабильны, легко меняются
 	clearing.AddComponent(metaComp)
 
 	// Добавляем теги
-	clearing.AddTag("clearing")
-	clearing.AddTag("ritual_site")
-	clearing.AddTag("environment")
-	clearing.AddTag("special")
+	clearing.AddTag(TagClearing)
+	clearing.AddTag(TagRitualSite)
+	clearing.AddTag(TagEnvironment)
+	clearing.AddTag(TagSpecial)
 
 	// Добавляем компонент света для создания особой атмосферы
 	lightComp := ecs.NewLightComponent(color.RGBA{R: 200, G: 220, B: 255, A: 255}, 0.5, 10.0)
 	lightComp.Flickering = true
 	clearing.AddComponent(lightComp)
 
-	// Добавляем сущность в мир
-	world.AddEntity(clearing)
-
 	return clearing
 }
 
-// createSymbol создает символ, который игрок может обнаружить
-func createSymbol(world *ecs.World, position ecs.Vector3, randomFactor float64) *ecs.Entity {
+// createSymbol создает символ реестра, который игрок может обнаружить
+func createSymbol(world *ecs.World, position ecs.Vector3, randomFactor float64, symbolID string, onInteract func(actor, target *ecs.Entity) bool) *ecs.Entity {
 	symbol := ecs.NewEntity()
 
 	// Добавляем базовые компоненты
@@ -944,22 +1165,23 @@ func createSymbol(world *ecs.World, position ecs.Vector3, randomFactor float64)
 
 	// Визуальный компонент
 	renderComp := ecs.NewRenderComponent("symbol_"+symbolType, "symbol_"+symbolType+"_texture")
-	renderComp.Effects = append(renderComp.Effects, "glow") // Символы слегка светятся
+	renderComp.Effects.AddEffect("glow", 0.5, ecs.EffectSourceBase) // Символы слегка светятся
 	symbol.AddComponent(renderComp)
 
 	// Компонент символа
-	symbolComp := ecs.NewSymbolComponent(generateSymbolID(randomFactor), symbolType, 0.3+randomFactor*0.7, 0.2+randomFactor*0.8)
+	symbolComp := ecs.NewSymbolComponent(symbolID, symbolType, 0.3+randomFactor*0.7, 0.2+randomFactor*0.8)
 	symbolComp.DiscoveryRadius = 3.0 // Радиус, в котором игрок может обнаружить символ
 	symbol.AddComponent(symbolComp)
 
 	// Добавляем интерактивный компонент
 	interactComp := ecs.NewInteractableComponent("examine", "Изучить символ", 2.0)
+	interactComp.InteractCallback = onInteract
 	symbol.AddComponent(interactComp)
 
 	// Добавляем теги
-	symbol.AddTag("symbol")
-	symbol.AddTag("interactive")
-	symbol.AddTag("special")
+	symbol.AddTag(TagSymbol)
+	symbol.AddTag(TagInteractive)
+	symbol.AddTag(TagSpecial)
 
 	// Добавляем слабый источник света
 	lightComp := ecs.NewLightComponent(color.RGBA{R: 100, G: 100, B: 255, A: 255}, 0.3, 5.0)
@@ -972,17 +1194,13 @@ func createSymbol(world *ecs.World, position ecs.Vector3, randomFactor float64)
 	return symbol
 }
 
-// createAnimal создает животное
-func createAnimal(world *ecs.World, position ecs.Vector3, randomFactor float64) *ecs.Entity {
+// newAnimal создает животное указанного вида, не добавляя в мир
+func newAnimal(position ecs.Vector3, animalType string, species FaunaSpecies, stabilityModifier float64) *ecs.Entity {
 	animal := ecs.NewEntity()
 
 	// Добавляем базовые компоненты
 	animal.AddComponent(ecs.NewTransformComponent(position))
 
-	// Определяем тип животного
-	animalTypes := []string{"deer", "wolf", "rabbit", "fox"}
-	animalType := animalTypes[int(randomFactor*float64(len(animalTypes)))]
-
 	// Визуальный компонент
 	renderComp := ecs.NewRenderComponent("animal_"+animalType, "animal_"+animalType+"_texture")
 	animal.AddComponent(renderComp)
@@ -995,50 +1213,31 @@ func createAnimal(world *ecs.World, position ecs.Vector3, randomFactor float64)
 	healthComp := ecs.NewHealthComponent(100)
 	animal.AddComponent(healthComp)
 
-	// Компонент ИИ с разным поведением в зависимости от типа
-	aiType := "neutral"
-	detectionRange := 10.0
-
-	if animalType == "wolf" {
-		aiType = "aggressive"
-		detectionRange = 15.0
-	} else if animalType == "rabbit" {
-		aiType = "scared"
-		detectionRange = 12.0
-	}
-
-	aiComp := ecs.NewAIComponent(aiType, detectionRange)
+	// Компонент ИИ с поведением вида
+	aiComp := ecs.NewAIComponent(species.AIType, species.DetectionRange)
 	animal.AddComponent(aiComp)
 
 	// Добавляем метаморфный компонент
-	metaComp := ecs.NewMetamorphicComponent(0.5) // Средняя стабильность
+	metaComp := ecs.NewMetamorphicComponent(scaledStability(0.5, stabilityModifier)) // Средняя стабильность
 	animal.AddComponent(metaComp)
 
 	// Добавляем теги
-	animal.AddTag("animal")
+	animal.AddTag(TagAnimal)
 	animal.AddTag(animalType)
-	animal.AddTag("living")
+	animal.AddTag(TagLiving)
 
 	// Если это хищник, добавляем тег
-	if animalType == "wolf" || animalType == "fox" {
-		animal.AddTag("predator")
+	if species.Predator {
+		animal.AddTag(TagPredator)
 	} else {
-		animal.AddTag("prey")
+		animal.AddTag(TagPrey)
 	}
 
-	// Добавляем сущность в мир
-	world.AddEntity(animal)
-
 	return animal
 }
 
-// createNightCreature создает ночное существо
-func createNightCreature(world *ecs.World, position ecs.Vector3, anomalyLevel float64) *ecs.Entity {
-	creature := ecs.NewEntity()
-
-	// Добавляем базовые компоненты
-	creature.AddComponent(ecs.NewTransformComponent(position))
-
+// newNightCreature создает ночное существо, не добавляя в мир
+func newNightCreature(position ecs.Vector3, anomalyLevel float64) *ecs.Entity {
 	// Определяем тип существа в зависимости от уровня аномальности
 	creatureType := "shadow"
 	if anomalyLevel > 0.5 {
@@ -1048,9 +1247,20 @@ func createNightCreature(world *ecs.World, position ecs.Vector3, anomalyLevel fl
 		creatureType = "nightmare"
 	}
 
+	return newCreature(position, creatureType, anomalyLevel)
+}
+
+// newCreature создает ночное существо указанного типа, не добавляя в мир
+func newCreature(position ecs.Vector3, creatureType string, anomalyLevel float64) *ecs.Entity {
+	creature := ecs.NewEntity()
+
+	// Добавляем базовые компоненты
+	creature.AddComponent(ecs.NewTransformComponent(position))
+
 	// Визуальный компонент
 	renderComp := ecs.NewRenderComponent("creature_"+creatureType, "creature_"+creatureType+"_texture")
-	renderComp.Effects = append(renderComp.Effects, "glow", "transparency")
+	renderComp.Effects.AddEffect("glow", 1.0, ecs.EffectSourceBase)
+	renderComp.Effects.AddEffect("transparency", 1.0, ecs.EffectSourceBase)
 	creature.AddComponent(renderComp)
 
 	// Физический компонент
@@ -1079,9 +1289,9 @@ func createNightCreature(world *ecs.World, position ecs.Vector3, anomalyLevel fl
 	creature.AddComponent(soundComp)
 
 	// Добавляем теги
-	creature.AddTag("creature")
-	creature.AddTag("hostile")
-	creature.AddTag("night")
+	creature.AddTag(TagCreature)
+	creature.AddTag(TagHostile)
+	creature.AddTag(TagNight)
 	creature.AddTag(creatureType)
 
 	// Добавляем компонент света для жутких эффектов
@@ -1089,14 +1299,11 @@ func createNightCreature(world *ecs.World, position ecs.Vector3, anomalyLevel fl
 	lightComp.Flickering = true
 	creature.AddComponent(lightComp)
 
-	// Добавляем сущность в мир
-	world.AddEntity(creature)
-
 	return creature
 }
 
-// createAnomaly создает аномалию
-func createAnomaly(world *ecs.World, position ecs.Vector3, anomalyType string) *ecs.Entity {
+// newAnomaly создает аномалию, не добавляя в мир
+func newAnomaly(position ecs.Vector3, anomalyType string) *ecs.Entity {
 	anomaly := ecs.NewEntity()
 
 	// Добавляем базовые компоненты
@@ -1104,7 +1311,7 @@ func createAnomaly(world *ecs.World, position ecs.Vector3, anomalyType string) *
 
 	// Визуальный компонент
 	renderComp := ecs.NewRenderComponent("anomaly_"+anomalyType, "anomaly_"+anomalyType+"_texture")
-	renderComp.Effects = append(renderComp.Effects, "distortion")
+	renderComp.Effects.AddEffect("distortion", 1.0, ecs.EffectSourceBase)
 	anomaly.AddComponent(renderComp)
 
 	// Физический компонент (аномалии могут влиять на физику, но не имеют коллизий)
@@ -1128,9 +1335,9 @@ func createAnomaly(world *ecs.World, position ecs.Vector3, anomalyType string) *
 	anomaly.AddComponent(soundComp)
 
 	// Добавляем теги
-	anomaly.AddTag("anomaly")
-	anomaly.AddTag("interactive")
-	anomaly.AddTag("special")
+	anomaly.AddTag(TagAnomaly)
+	anomaly.AddTag(TagInteractive)
+	anomaly.AddTag(TagSpecial)
 	anomaly.AddTag(anomalyType)
 
 	// Эффекты и параметры в зависимости от типа аномалии
@@ -1141,15 +1348,18 @@ func createAnomaly(world *ecs.World, position ecs.Vector3, anomalyType string) *
 	case "minor":
 		radius = 5.0
 		intensity = 0.3
-		renderComp.Effects = append(renderComp.Effects, "blur")
+		renderComp.Effects.AddEffect("blur", intensity, ecs.EffectSourceBase)
 	case "medium":
 		radius = 8.0
 		intensity = 0.6
-		renderComp.Effects = append(renderComp.Effects, "blur", "color_shift")
+		renderComp.Effects.AddEffect("blur", intensity, ecs.EffectSourceBase)
+		renderComp.Effects.AddEffect("color_shift", intensity, ecs.EffectSourceBase)
 	case "major":
 		radius = 12.0
 		intensity = 0.9
-		renderComp.Effects = append(renderComp.Effects, "blur", "color_shift", "warp")
+		renderComp.Effects.AddEffect("blur", intensity, ecs.EffectSourceBase)
+		renderComp.Effects.AddEffect("color_shift", intensity, ecs.EffectSourceBase)
+		renderComp.Effects.AddEffect("warp", intensity, ecs.EffectSourceBase)
 	}
 
 	// Добавляем компонент света
@@ -1157,26 +1367,115 @@ func createAnomaly(world *ecs.World, position ecs.Vector3, anomalyType string) *
 	lightComp.Flickering = true
 	anomaly.AddComponent(lightComp)
 
+	return anomaly
+}
+
+// CreateCampfire создает костер, поставленный игроком
+func CreateCampfire(world *ecs.World, position ecs.Vector3) *ecs.Entity {
+	campfire := ecs.NewEntity()
+
+	// Добавляем базовые компоненты
+	campfire.AddComponent(ecs.NewTransformComponent(position))
+	campfire.AddComponent(ecs.NewRenderComponent("campfire", "campfire_texture"))
+
+	// Костер горит, пока у него есть свет
+	lightComp := ecs.NewLightComponent(color.RGBA{R: 255, G: 150, B: 60, A: 255}, 1.0, 12.0)
+	lightComp.Flickering = true
+	campfire.AddComponent(lightComp)
+
+	// Потрескивание огня
+	soundComp := ecs.NewSoundEmitterComponent("campfire_crackle", 0.6, 10.0)
+	soundComp.IsLooping = true
+	soundComp.PlayOnStart = true
+	campfire.AddComponent(soundComp)
+
+	// Добавляем интерактивный компонент
+	campfire.AddComponent(ecs.NewInteractableComponent("tend_fire", "Подбросить дров", 2.0))
+
+	// Добавляем теги
+	campfire.AddTag(TagCampfire)
+	campfire.AddTag(TagInteractive)
+
 	// Добавляем сущность в мир
-	world.AddEntity(anomaly)
+	world.AddEntity(campfire)
 
-	return anomaly
+	return campfire
+}
+
+// CreateShelter создает укрытие, построенное игроком
+func CreateShelter(world *ecs.World, position ecs.Vector3) *ecs.Entity {
+	shelter := ecs.NewEntity()
+
+	// Добавляем базовые компоненты
+	shelter.AddComponent(ecs.NewTransformComponent(position))
+	shelter.AddComponent(ecs.NewRenderComponent("shelter", "shelter_texture"))
+
+	// Добавляем физический компонент
+	physicsComp := ecs.NewPhysicsComponent(50, true)
+	physicsComp.ColliderSize = ecs.Vector3{X: 2.0, Y: 1.5, Z: 2.0}
+	shelter.AddComponent(physicsComp)
+
+	// Добавляем интерактивный компонент
+	shelter.AddComponent(ecs.NewInteractableComponent("rest", "Отдохнуть", 2.5))
+
+	// Добавляем теги
+	shelter.AddTag(TagShelter)
+	shelter.AddTag(TagInteractive)
+
+	// Добавляем сущность в мир
+	world.AddEntity(shelter)
+
+	return shelter
+}
+
+// CreateAltar создает ритуальный алтарь, на который можно класть подношения
+func CreateAltar(world *ecs.World, position ecs.Vector3) *ecs.Entity {
+	altar := ecs.NewEntity()
+
+	// Добавляем базовые компоненты
+	altar.AddComponent(ecs.NewTransformComponent(position))
+	altar.AddComponent(ecs.NewRenderComponent("altar", "altar_texture"))
+
+	// Добавляем физический компонент
+	physicsComp := ecs.NewPhysicsComponent(200, true)
+	physicsComp.ColliderSize = ecs.Vector3{X: 1.5, Y: 1.0, Z: 1.0}
+	altar.AddComponent(physicsComp)
+
+	// Добавляем интерактивный компонент
+	altar.AddComponent(ecs.NewInteractableComponent("place_offering", "Положить подношение", 2.0))
+
+	// Добавляем теги
+	altar.AddTag(TagAltar)
+	altar.AddTag(TagRitualSite)
+	altar.AddTag(TagInteractive)
+
+	// Добавляем сущность в мир
+	world.AddEntity(altar)
+
+	return altar
+}
+
+// ExtinguishCampfire гасит костер
+func ExtinguishCampfire(campfire *ecs.Entity) {
+	if lightComp, has := campfire.GetComponent(ecs.LightComponentID); has {
+		lightComp.(*ecs.LightComponent).Intensity = 0
+	}
+	if soundComp, has := campfire.GetComponent(ecs.SoundEmitterComponentID); has {
+		soundComp.(*ecs.SoundEmitterComponent).IsPlaying = false
+	}
 }
 
 // createDistortedEntity создает искаженную версию сущности
-func createDistortedEntity(world *ecs.World, originalEntity *ecs.Entity) *ecs.Entity {
+func (w *World) createDistortedEntity(world *ecs.World, originalEntity *ecs.Entity) *ecs.Entity {
 	distorted := ecs.NewEntity()
 
 	// Копируем базовые компоненты с искажениями
-	if originalEntity.HasComponent(ecs.TransformComponentID) {
-		originalTransform, _ := originalEntity.GetComponent(ecs.TransformComponentID)
-		transform := originalTransform.(*ecs.TransformComponent)
-
+	if transform, has := ecs.ComponentAs[*ecs.TransformComponent](originalEntity, ecs.TransformComponentID); has {
 		// Создаем новый трансформ с искажениями
 		newTransform := ecs.NewTransformComponent(transform.Position)
 
 		// Искажаем масштаб и поворот
-		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		r := w.rng
 		scaleDistortion := 0.5 + r.Float64()*1.5 // 0.5 - 2.0
 
 		newTransform.Scale = transform.Scale.Multiply(scaleDistortion)
@@ -1190,24 +1489,20 @@ func createDistortedEntity(world *ecs.World, originalEntity *ecs.Entity) *ecs.En
 	}
 
 	// Копируем и искажаем визуальный компонент
-	if originalEntity.HasComponent(ecs.RenderComponentID) {
-		originalRender, _ := originalEntity.GetComponent(ecs.RenderComponentID)
-		render := originalRender.(*ecs.RenderComponent)
-
+	if render, has := ecs.ComponentAs[*ecs.RenderComponent](originalEntity, ecs.RenderComponentID); has {
 		// Создаем новый компонент рендера с искажениями
 		newRender := ecs.NewRenderComponent(render.ModelID, render.TextureID)
 
-		// Искажаем цвет
-		r := rand.New(rand.NewSource(time.Now().UnixNano()))
-		newRender.Color = color.RGBA{
-			R: uint8(r.Intn(255)),
-			G: uint8(r.Intn(255)),
-			B: uint8(r.Intn(255)),
-			A: 255,
-		}
+		// Окрашиваем в цвет пустоты; собственный цвет остается в BaseColor
+		r := w.rng
+		newRender.Color = render.OwnColor()
+		newRender.TintColor(w.mutationColor(newRender.Color, 1.0, r, "void"), ecs.EffectSourceBase)
 
 		// Добавляем эффекты искажения
-		newRender.Effects = append(render.Effects, "distortion", "glow", "warp")
+		newRender.Effects = render.Effects.Clone()
+		for _, name := range []string{"distortion", "glow", "warp"} {
+			newRender.Effects.AddEffect(name, 1.0, ecs.EffectSourceBase)
+		}
 		newRender.Distortion = 0.7 + r.Float64()*0.3 // 0.7 - 1.0
 
 		distorted.AddComponent(newRender)
@@ -1222,8 +1517,8 @@ func createDistortedEntity(world *ecs.World, originalEntity *ecs.Entity) *ecs.En
 	}
 
 	// Добавляем особые теги
-	distorted.AddTag("distorted")
-	distorted.AddTag("void")
+	distorted.AddTag(TagDistorted)
+	distorted.AddTag(TagVoid)
 
 	// Добавляем сущность в мир
 	world.AddEntity(distorted)
@@ -1231,6 +1526,18 @@ func createDistortedEntity(world *ecs.World, originalEntity *ecs.Entity) *ecs.En
 	return distorted
 }
 
+// convertTerrainToVoid заменяет террейн чанка пустотой, сшитой с соседями.
+// Пустота становится новым исходным состоянием для разницы террейна: эффект
+// воссоздаст ее при повторном применении, а старые искажения к ней не относятся.
+func (w *World) convertTerrainToVoid(chunk *Chunk) {
+	chunk.Terrain = createVoidTerrain(chunk.Position[0], chunk.Position[1])
+	if w.TerrainGenerator != nil {
+		w.TerrainGenerator.StitchChunk(chunk.Terrain, chunk.Position[0], chunk.Position[1])
+	}
+	chunk.Terrain.TrackChanges()
+	chunk.Distortions = nil
+}
+
 // createVoidTerrain создает террейн типа "пустота"
 func createVoidTerrain(x, y int) *terrain.TerrainData {
 	// Создаем базовый террейн
@@ -1241,10 +1548,13 @@ func createVoidTerrain(x, y int) *terrain.TerrainData {
 
 	for i := 0; i < ChunkSize; i++ {
 		for j := 0; j < ChunkSize; j++ {
-			// Создаем странные паттерны высот
-			noise1 := math.Sin(float64(i)*0.1 + float64(j)*0.1)
-			noise2 := math.Cos(float64(i)*0.05 - float64(j)*0.07)
-			noise3 := math.Sin(math.Sqrt(float64(i*i+j*j)) * 0.1)
+			// Создаем странные паттерны высот в мировых координатах,
+			// чтобы соседние чанки пустоты совпадали на границах
+			wx := float64(x*ChunkSize + i)
+			wz := float64(y*ChunkSize + j)
+			noise1 := math.Sin(wx*0.1 + wz*0.1)
+			noise2 := math.Cos(wx*0.05 - wz*0.07)
+			noise3 := math.Sin(math.Sqrt(wx*wx+wz*wz) * 0.1)
 
 			height := noise1*5 + noise2*3 + noise3*8 + r.Float64()*2
 
@@ -1263,14 +1573,6 @@ func createVoidTerrain(x, y int) *terrain.TerrainData {
 	return terrainData
 }
 
-// generateSymbolID генерирует ID для символа
-func generateSymbolID(randomFactor float64) string {
-	symbolTypes := []string{"elemental", "arcane", "primal", "void"}
-	symbolType := symbolTypes[int(randomFactor*float64(len(symbolTypes)))]
-
-	return symbolType + "_" + strconv.FormatInt(time.Now().UnixNano(), 16)
-}
-
 // containsString проверяет, содержится ли строка в слайсе
 func containsString(slice []string, str string) bool {
 	for _, s := range slice {
@@ -1280,3 +1582,14 @@ func containsString(slice []string, str string) bool {
 	}
 	return false
 }
+
+// removeString удаляет строку из слайса
+func removeString(slice []string, str string) []string {
+	result := make([]string, 0, len(slice))
+	for _, s := range slice {
+		if s != str {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"echo-taiga/internal/savefile"
+)
+
+// worldTimeRecord - сохраненное время мира
+type worldTimeRecord struct {
+	Day       int     `json:"day"`
+	TimeOfDay float64 `json:"time_of_day"`
+}
+
+// SaveTime сохраняет число прошедших суток и время суток
+func (w *World) SaveTime(savePath string) error {
+	if err := os.MkdirAll(savePath, os.ModePerm); err != nil {
+		return err
+	}
+
+	data, err := savefile.Marshal(worldTimeRecord{Day: w.Day, TimeOfDay: w.TimeOfDay})
+	if err != nil {
+		return err
+	}
+	return savefile.Write(filepath.Join(savePath, "time.json"), data)
+}
+
+// LoadTime загружает время мира. Отсутствие файла не считается ошибкой:
+// новый мир начинается с рассвета первых суток.
+func (w *World) LoadTime(savePath string) error {
+	data, err := savefile.Read(filepath.Join(savePath, "time.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var record worldTimeRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return fmt.Errorf("invalid world time: %v", err)
+	}
+	if record.Day < 0 || record.TimeOfDay < 0 || record.TimeOfDay >= 1 {
+		return fmt.Errorf("invalid world time: day %d, time of day %.3f", record.Day, record.TimeOfDay)
+	}
+
+	w.Day = record.Day
+	w.TimeOfDay = record.TimeOfDay
+	return nil
+}
@@ -0,0 +1,34 @@
+package world
+
+import "testing"
+
+func TestWorldTimeSurvivesSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	saved := &World{Day: 3, TimeOfDay: 0.25}
+	saved.advanceTimeOfDay(8 * 60 * 60)
+	if err := saved.SaveTime(dir); err != nil {
+		t.Fatalf("SaveTime: %v", err)
+	}
+
+	loaded := &World{TimeOfDay: 0.25}
+	if err := loaded.LoadTime(dir); err != nil {
+		t.Fatalf("LoadTime: %v", err)
+	}
+	if loaded.Day != saved.Day || loaded.TimeOfDay != saved.TimeOfDay {
+		t.Errorf("loaded day %d at %.3f, saved day %d at %.3f", loaded.Day, loaded.TimeOfDay, saved.Day, saved.TimeOfDay)
+	}
+	if loaded.Day <= 3 {
+		t.Errorf("8 hours away did not advance the day count: day %d", loaded.Day)
+	}
+}
+
+func TestLoadTimeWithoutSaveKeepsDawn(t *testing.T) {
+	w := &World{TimeOfDay: 0.25}
+	if err := w.LoadTime(t.TempDir()); err != nil {
+		t.Fatalf("LoadTime without a file: %v", err)
+	}
+	if w.Day != 0 || w.TimeOfDay != 0.25 {
+		t.Errorf("new world starts on day %d at %.3f", w.Day, w.TimeOfDay)
+	}
+}